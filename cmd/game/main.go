@@ -2,56 +2,139 @@ package main
 
 import (
 	"flag"
+	"io"
 	"io/fs"
 	"log"
+	"os"
+	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/younwookim/mg/internal/application/game"
-	"github.com/younwookim/mg/internal/application/scene/playing"
-	"github.com/younwookim/mg/internal/domain/entity"
-	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/game"
+	"github.com/younwookim/mg/internal/application/crash"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
 )
 
 func main() {
 	// Parse command line flags
 	recordFlag := flag.String("record", "", "Record input to file (e.g., -record replay.json)")
+	tasFlag := flag.String("tas", "", "Open a replay in the TAS editor (e.g., -tas replay.json)")
+	debugFlag := flag.Bool("debug", false, "Show debug overlay (F3 to toggle)")
+	metricsFlag := flag.Bool("metrics", false, "Record per-system frame times (F6 exports CSV)")
+	clipsFlag := flag.Bool("clips", false, "Keep the last 5 seconds of frames (F10 exports a GIF)")
+	rumbleFlag := flag.Bool("rumble", true, "Enable gamepad vibration")
+	logFlag := flag.String("log", "", "Also write the log to a file (e.g., -log game.log)")
+	logLevelFlag := flag.String("loglevel", "info", "Minimum log level: debug, info, warn or error")
+	combatLogFlag := flag.String("combatlog", "", "Write the session's combat log (hits, kills, pickups) to a file (e.g., -combatlog combat.log)")
+	cpuProfileFlag := flag.String("cpuprofile", "", "Write a CPU profile to file (e.g., -cpuprofile cpu.prof)")
+	memProfileFlag := flag.String("memprofile", "", "Write a heap profile to file on exit")
+	traceFlag := flag.String("trace", "", "Write an execution trace to file")
+	botFlag := flag.Duration("bot", 0, "Soak test: a bot plays headlessly for this long (e.g., -bot 2h)")
+	botModeFlag := flag.String("botmode", "random", "Soak test bot: random or exit (run toward the stage exit)")
 	flag.Parse()
 
-	recordFilename := *recordFlag
+	// Logging comes first so everything below can report problems
+	if level, ok := logging.ParseLevel(*logLevelFlag); ok {
+		logging.Default().SetLevel(level)
+	} else {
+		logging.Game.Warnf("Unknown log level: %s", *logLevelFlag)
+	}
+	if *logFlag != "" {
+		file, err := os.Create(*logFlag)
+		if err != nil {
+			log.Fatalf("Failed to create log file: %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		logging.Default().SetOutput(io.MultiWriter(os.Stderr, file))
+	}
 
-	// Load configurations using embedded filesystem
+	// Configs are embedded for WebAssembly builds
 	fsys, err := fs.Sub(configFS, "configs")
 	if err != nil {
 		log.Fatalf("Failed to get config subfs: %v", err)
 	}
-	loader := config.NewFSLoader(fsys, "configs")
-	cfg, err := loader.LoadAll()
+
+	// Settings and progress live in the user config directory
+	settingsPath, err := settings.DefaultPath()
+	if err != nil {
+		logging.Game.Warnf("Settings will not be saved: %v", err)
+	}
+	savePath, err := save.DefaultPath()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		logging.Game.Warnf("Progress will not be saved: %v", err)
 	}
 
-	// Load stage
-	stageCfg, err := loader.LoadStage("demo")
+	crashDir, err := crash.DefaultDir()
 	if err != nil {
-		log.Fatalf("Failed to load stage: %v", err)
+		logging.Game.Warnf("Crash reports will not be saved: %v", err)
+	}
+	// Soak test: no window, violations are reported like crashes
+	if *botFlag > 0 {
+		mode, ok := game.ParseBotMode(*botModeFlag)
+		if !ok {
+			log.Fatalf("Unknown bot mode: %s", *botModeFlag)
+		}
+		seed := time.Now().UnixNano()
+		logging.Game.Infof("Bot playing for %s (seed: %d)", *botFlag, seed)
+		if err := game.RunBot(game.BotOptions{
+			Configs:  fsys,
+			Mode:     mode,
+			Duration: *botFlag,
+			Seed:     seed,
+			DumpDir:  crashDir,
+		}); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var combatLog io.Writer
+	if *combatLogFlag != "" {
+		file, err := os.Create(*combatLogFlag)
+		if err != nil {
+			log.Fatalf("Failed to create combat log file: %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		combatLog = file
 	}
-	stage := entity.LoadStage(stageCfg)
 
-	// Create initial scene (Playing)
-	playingScene := playing.New(cfg, stageCfg, stage, recordFilename)
+	replayDir, err := replay.DefaultDir()
+	if err != nil {
+		logging.Game.Warnf("Replays will not be saved: %v", err)
+	}
 
-	// Create game manager with scene
-	screenW := cfg.Physics.Display.ScreenWidth
-	screenH := cfg.Physics.Display.ScreenHeight
-	gameManager := game.New(playingScene, screenW, screenH)
+	g, err := game.New(game.Options{
+		Configs:       fsys,
+		SettingsPath:  settingsPath,
+		SavePath:      savePath,
+		RecordPath:    *recordFlag,
+		ReplayDir:     replayDir,
+		TASPath:       *tasFlag,
+		CrashDir:      crashDir,
+		Debug:         *debugFlag,
+		Metrics:       *metricsFlag,
+		Clips:         *clipsFlag,
+		DisableRumble: !*rumbleFlag,
+		CombatLog:     combatLog,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// Set up ebiten
-	ebiten.SetWindowSize(screenW*cfg.Physics.Display.Scale, screenH*cfg.Physics.Display.Scale)
-	ebiten.SetWindowTitle("Platform Action Game")
-	ebiten.SetTPS(cfg.Physics.Display.Framerate)
+	// Profile only the game loop, not config loading
+	stopProfiling, err := profiles{cpu: *cpuProfileFlag, mem: *memProfileFlag, trace: *traceFlag}.start()
+	if err != nil {
+		log.Fatal(err)
+	}
+	startPprofServer()
 
 	// Run game
-	if err := ebiten.RunGame(gameManager); err != nil {
-		log.Fatal(err)
+	runErr := g.Run("Platform Action Game")
+	if err := stopProfiling(); err != nil {
+		logging.Game.Errorf("Failed to write profiles: %v", err)
+	}
+	if runErr != nil {
+		log.Fatal(runErr)
 	}
 }
@@ -0,0 +1,27 @@
+//go:build dev
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof on the default mux
+
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Dev builds (go run -tags dev ./cmd/game) can serve live profiles
+func init() {
+	addr := flag.String("pprof", "", "Serve net/http/pprof on this address (e.g., -pprof localhost:6060)")
+	startPprofServer = func() {
+		if *addr == "" {
+			return
+		}
+		go func() {
+			logging.Game.Infof("pprof listening on http://%s/debug/pprof/", *addr)
+			if err := http.ListenAndServe(*addr, nil); err != nil {
+				logging.Game.Errorf("Failed to serve pprof: %v", err)
+			}
+		}()
+	}
+}
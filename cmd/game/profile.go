@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// startPprofServer serves net/http/pprof when built with -tags dev
+// (no-op otherwise, see pprof_dev.go)
+var startPprofServer = func() {}
+
+// profiles are the profile files requested on the command line ("" = off)
+type profiles struct {
+	cpu, mem, trace string
+}
+
+// start begins CPU profiling and tracing. The returned stop function ends
+// them and writes the heap profile; call it once the game loop returns.
+func (p profiles) start() (stop func() error, err error) {
+	var closers []func() error
+	stop = func() error {
+		var errs []error
+		for i := len(closers) - 1; i >= 0; i-- {
+			errs = append(errs, closers[i]())
+		}
+		if p.mem != "" {
+			errs = append(errs, writeHeapProfile(p.mem))
+		}
+		return errors.Join(errs...)
+	}
+
+	if p.cpu != "" {
+		file, err := os.Create(p.cpu)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		closers = append(closers, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if p.trace != "" {
+		file, err := os.Create(p.trace)
+		if err != nil {
+			_ = stop()
+			return nil, fmt.Errorf("failed to create trace: %w", err)
+		}
+		if err := trace.Start(file); err != nil {
+			_ = file.Close()
+			_ = stop()
+			return nil, fmt.Errorf("failed to start trace: %w", err)
+		}
+		closers = append(closers, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+	return stop, nil
+}
+
+// writeHeapProfile writes the live heap after a final GC
+func writeHeapProfile(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create memory profile: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("failed to write memory profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles_WriteRequestedFiles(t *testing.T) {
+	dir := t.TempDir()
+	p := profiles{
+		cpu:   filepath.Join(dir, "cpu.prof"),
+		mem:   filepath.Join(dir, "mem.prof"),
+		trace: filepath.Join(dir, "trace.out"),
+	}
+
+	stop, err := p.start()
+	require.NoError(t, err)
+	require.NoError(t, stop())
+
+	for _, name := range []string{p.cpu, p.mem, p.trace} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size(), name)
+	}
+}
+
+func TestProfiles_NoneRequested(t *testing.T) {
+	stop, err := profiles{}.start()
+	require.NoError(t, err)
+	assert.NoError(t, stop())
+}
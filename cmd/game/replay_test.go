@@ -10,6 +10,7 @@ import (
 	"github.com/younwookim/mg/internal/application/scene/playing"
 	"github.com/younwookim/mg/internal/domain/entity"
 	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 	"github.com/younwookim/mg/internal/infrastructure/config"
 )
 
@@ -75,7 +76,7 @@ func createTestWorld(stage *entity.Stage) *ecs.World {
 		Feet: ecs.Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
 	world := ecs.NewWorld()
-	world.CreatePlayer(stage.SpawnX, stage.SpawnY, hitbox, 100)
+	world.CreatePlayer(stage.SpawnX, stage.SpawnY, ecs.PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Set player on ground
 	mov := world.Movement[world.PlayerID]
@@ -89,25 +90,25 @@ func createTestWorld(stage *entity.Stage) *ecs.World {
 // All velocity values are converted to IU/substep at this point
 func toECSPhysicsConfig(cfg *config.PhysicsConfig) ecs.PhysicsConfig {
 	return ecs.PhysicsConfig{
-		Gravity:                 ecs.ToIUPerSubstep(cfg.Physics.Gravity),
-		MaxFallSpeed:            ecs.ToIUPerSubstep(cfg.Physics.MaxFallSpeed),
-		MaxSpeed:                ecs.ToIUPerSubstep(cfg.Movement.MaxSpeed),
-		Acceleration:            ecs.ToIUPerSubstep(cfg.Movement.Acceleration),
-		Deceleration:            ecs.ToIUPerSubstep(cfg.Movement.Deceleration),
-		AirControlPct:           ecs.PctToInt(cfg.Movement.AirControl),
-		TurnaroundPct:           ecs.PctToInt(cfg.Movement.TurnaroundBoost),
-		JumpForce:               ecs.ToIUPerSubstep(cfg.Jump.Force),
-		VarJumpPct:              ecs.PctToInt(cfg.Jump.VariableJumpMultiplier),
+		Gravity:                 fixedpoint.ToIUPerSubstep(cfg.Physics.Gravity),
+		MaxFallSpeed:            fixedpoint.ToIUPerSubstep(cfg.Physics.MaxFallSpeed),
+		MaxSpeed:                fixedpoint.ToIUPerSubstep(cfg.Movement.MaxSpeed),
+		Acceleration:            fixedpoint.ToIUPerSubstep(cfg.Movement.Acceleration),
+		Deceleration:            fixedpoint.ToIUPerSubstep(cfg.Movement.Deceleration),
+		AirControlPct:           fixedpoint.ToPct(cfg.Movement.AirControl),
+		TurnaroundPct:           fixedpoint.ToPct(cfg.Movement.TurnaroundBoost),
+		JumpForce:               fixedpoint.ToIUPerSubstep(cfg.Jump.Force),
+		VarJumpPct:              fixedpoint.ToPct(cfg.Jump.VariableJumpMultiplier),
 		CoyoteFrames:            int(cfg.Jump.CoyoteTime * 60),
 		JumpBufferFrames:        int(cfg.Jump.JumpBuffer * 60),
-		DashSpeed:               ecs.ToIUPerSubstep(cfg.Dash.Speed),
+		DashSpeed:               fixedpoint.ToIUPerSubstep(cfg.Dash.Speed),
 		DashFrames:              int(cfg.Dash.Duration * 60),
 		DashCooldownFrames:      int(cfg.Dash.Cooldown * 60),
 		DashIframes:             int(cfg.Dash.Duration * 60),
 		ApexModEnabled:          cfg.Jump.ApexModifier.Enabled,
-		ApexThreshold:           ecs.ToIUPerSubstep(cfg.Jump.ApexModifier.Threshold),
-		ApexGravityPct:          ecs.PctToInt(cfg.Jump.ApexModifier.GravityMultiplier),
-		FallMultiplierPct:       ecs.PctToInt(cfg.Jump.FallMultiplier),
+		ApexThreshold:           fixedpoint.ToIUPerSubstep(cfg.Jump.ApexModifier.Threshold),
+		ApexGravityPct:          fixedpoint.ToPct(cfg.Jump.ApexModifier.GravityMultiplier),
+		FallMultiplierPct:       fixedpoint.ToPct(cfg.Jump.FallMultiplier),
 		CornerCorrectionMargin:  4,
 		CornerCorrectionEnabled: true,
 	}
@@ -0,0 +1,605 @@
+// Package game is the public entry point for running or embedding the
+// platformer in another Go program.
+//
+// New loads the configs, stage, locales and player data and wires up the
+// scenes the same way the standalone binary does. The returned Game
+// implements ebiten.Game, so a host can either call Run or drive Update,
+// Draw and Layout from its own game loop (e.g. as a mini-game).
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/clip"
+	"github.com/younwookim/mg/internal/application/crash"
+	manager "github.com/younwookim/mg/internal/application/game"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/metrics"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/scene/leaderboard"
+	"github.com/younwookim/mg/internal/application/scene/menu"
+	"github.com/younwookim/mg/internal/application/scene/options"
+	"github.com/younwookim/mg/internal/application/scene/playing"
+	"github.com/younwookim/mg/internal/application/scene/replays"
+	"github.com/younwookim/mg/internal/application/tower"
+	"github.com/younwookim/mg/internal/domain/entity"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
+)
+
+// DefaultStage is the stage played when Options.Stage is empty
+const DefaultStage = "demo"
+
+// attractIdleSeconds is how long the main menu waits without input before
+// it plays a bundled replay (configs/replays) as an attract mode demo
+const attractIdleSeconds = 30
+
+// World is the ECS world custom systems operate on
+type World = ecs.World
+
+// System is a custom per-frame update. Systems run once per simulated frame
+// after the built-in systems, in the order they were given.
+type System = playing.System
+
+// Result describes a finished run
+type Result = playing.RunResult
+
+// Options configures a Game. Only Configs is required.
+type Options struct {
+	// Configs is the config directory (physics.json, entities.json,
+	// stages/, locales/, ...), e.g. an embed.FS sub-tree
+	Configs fs.FS
+
+	Stage    string // stage ID to play ("" = DefaultStage)
+	SkipMenu bool   // start in the stage instead of the main menu
+
+	// Persistence (empty = in memory only)
+	SettingsPath string
+	SavePath     string // profile save; the leaderboard is stored next to it
+	RecordPath   string // input recording for replays
+	ReplayDir    string // every run saved for the replay browser (empty = none)
+	TASPath      string // open this replay in the TAS editor instead of the menu
+	CrashDir     string // crash reports on panic (the error screen is shown either way)
+
+	Debug         bool // allow the debug overlay (F3)
+	Metrics       bool // record per-system frame times (F6 exports CSV)
+	Clips         bool // keep the last seconds of frames (F10 exports a GIF)
+	DisableRumble bool // no gamepad vibration
+
+	CombatLog io.Writer // every combat log entry of the session, e.g. a file (nil = none)
+
+	// Hooks (nil = ignored)
+	OnStageClear  func(Result)
+	OnPlayerDeath func(Result)
+	OnMusic       func(track string) // music track changed ("" = the stage's own); the game plays no audio itself
+	Systems       []System
+}
+
+// Game is an embeddable instance of the platformer (implements ebiten.Game)
+type Game struct {
+	manager  *manager.Game
+	settings *settings.Settings
+	display  config.DisplayConfig
+}
+
+// New loads everything the game needs and builds its scenes
+func New(opts Options) (*Game, error) {
+	if opts.Configs == nil {
+		return nil, errors.New("failed to create game: configs are required")
+	}
+	stageID := opts.Stage
+	if stageID == "" {
+		stageID = DefaultStage
+	}
+	// A replay opened in the TAS editor picks its own stage
+	var tasReplay *replay.ReplayData
+	if opts.TASPath != "" {
+		var err error
+		if tasReplay, err = replay.LoadReplay(opts.TASPath); err != nil {
+			return nil, err
+		}
+		stageID = tasReplay.Stage
+	}
+
+	// Settings come first: the difficulty picks the config override layer
+	userSettings := loadSettings(opts.SettingsPath)
+	loadConfig := func(difficulty string) (*config.GameConfig, *config.StageConfig, error) {
+		if stageID == tower.StageID {
+			cfg, _, err := loadTower(opts.Configs, difficulty)
+			return cfg, nil, err
+		}
+		return loadConfigs(opts.Configs, stageID, difficulty)
+	}
+	cfg, stageCfg, err := loadConfig(userSettings.Difficulty)
+	if err != nil {
+		return nil, err
+	}
+	// Every config conversion below depends on the simulation rate
+	fixedpoint.SetSimulationRate(cfg.Physics.Display.Framerate)
+	stage := entity.LoadStage(stageCfg)
+	if err := loadLocales(config.NewFSLoader(opts.Configs, "configs")); err != nil {
+		return nil, err
+	}
+
+	// The content hash tells replays and saves made with other configs
+	// (or another build) apart
+	configVersions, err := crash.ConfigVersions(opts.Configs)
+	if err != nil {
+		logging.Config.Warnf("Crash reports will not list config versions: %v", err)
+	}
+	versionHash := replay.VersionHash(crash.BuildVersion(), configVersions)
+
+	profile, board, leaderboardPath := loadProfile(opts.SavePath)
+	if profile.CheckContent(versionHash) {
+		logging.Game.Warnf("The save was written by a different game version or configs")
+	}
+
+	// Create the playing scene
+	playingScene := playing.New(cfg, stageCfg, stage, opts.RecordPath)
+	playingScene.SetReplayDir(opts.ReplayDir, versionHash)
+	playingScene.SetDebug(opts.Debug)
+	playingScene.SetSettings(userSettings, opts.SettingsPath)
+	playingScene.SetConfigLoader(userSettings.Difficulty, loadConfig)
+	playingScene.SetProfile(profile, opts.SavePath)
+	playingScene.SetLeaderboard(board, leaderboardPath)
+	if opts.DisableRumble {
+		playingScene.SetRumble(false)
+	}
+	if opts.Metrics {
+		playingScene.SetMetrics(metrics.NewRecorder(metrics.DefaultCapacity))
+	}
+	if opts.Clips {
+		playingScene.SetClipCapture(clip.NewBuffer(clip.DefaultCapacity, clip.DefaultInterval, clip.DefaultScale))
+	}
+	playingScene.SetOnRunEnd(func(r Result) {
+		if r.Cleared && opts.OnStageClear != nil {
+			opts.OnStageClear(r)
+		} else if !r.Cleared && opts.OnPlayerDeath != nil {
+			opts.OnPlayerDeath(r)
+		}
+	})
+	playingScene.SetOnMusic(opts.OnMusic)
+	playingScene.SetCombatLog(opts.CombatLog)
+	for _, s := range opts.Systems {
+		playingScene.AddSystem(s)
+	}
+
+	// Start in the main menu unless embedded straight into the stage
+	screenW := cfg.Physics.Display.ScreenWidth
+	screenH := cfg.Physics.Display.ScreenHeight
+	var initial scene.Scene = playingScene
+	if tasReplay != nil {
+		playingScene.EnterTAS(*tasReplay, opts.TASPath)
+	} else if !opts.SkipMenu {
+		// Exports go to the working directory, like clips and photos
+		var openReplays func(back scene.Scene) scene.Scene
+		if opts.ReplayDir != "" {
+			openReplays = func(back scene.Scene) scene.Scene {
+				return replays.New(opts.ReplayDir, ".", versionHash, func(data *replay.ReplayData, browser scene.Scene) (scene.Scene, error) {
+					return newReplayScene(opts.Configs, data, browser, "replays.playback")
+				}, screenW, screenH, back)
+			}
+		}
+		// The menu switches stages by loading them like the first one
+		stages := loadStageList(opts.Configs, stageCfg)
+		selectStage := func(id string) error {
+			prev := stageID
+			stageID = id
+			cfg, stageCfg, err := loadConfig(userSettings.Difficulty)
+			if err != nil {
+				stageID = prev
+				return err
+			}
+			playingScene.SetStage(cfg, stageCfg, entity.LoadStage(stageCfg))
+			return nil
+		}
+		// The endless tower and the daily run are on when there is a
+		// tower.json
+		var startTower func(daily bool) error
+		if _, err := fs.Stat(opts.Configs, "tower.json"); err == nil {
+			startTower = func(daily bool) error {
+				cfg, towerCfg, err := loadTower(opts.Configs, userSettings.Difficulty)
+				if err != nil {
+					return err
+				}
+				stageID = tower.StageID
+				if daily {
+					playingScene.StartDaily(towerCfg, cfg, time.Now())
+				} else {
+					playingScene.StartTower(towerCfg, cfg)
+				}
+				return nil
+			}
+		}
+		mainMenu := newMainMenu(cfg, stages, selectStage, startTower, profile, playingScene, board, openReplays, userSettings, opts.SettingsPath)
+		playingScene.SetMenu(mainMenu)
+		if demos := loadReplays(opts.Configs, versionHash); len(demos) > 0 {
+			mainMenu.SetIdle(attractIdleSeconds, newAttract(opts.Configs, demos, mainMenu))
+		}
+		initial = mainMenu
+	}
+
+	m := manager.New(initial, screenW, screenH)
+	playingScene.SetViewport(m.Viewport())
+	m.SetOnFullscreen(func(fullscreen bool) {
+		userSettings.Fullscreen = fullscreen
+		saveSettings(userSettings, opts.SettingsPath)
+	})
+	m.SetCrashReports(opts.CrashDir, configVersions)
+
+	return &Game{manager: m, settings: userSettings, display: cfg.Physics.Display}, nil
+}
+
+// Update advances the current scene (implements ebiten.Game)
+func (g *Game) Update() error {
+	return g.manager.Update()
+}
+
+// Draw renders the current scene (implements ebiten.Game)
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.manager.Draw(screen)
+}
+
+// Layout scales the game to the outside size (implements ebiten.Game)
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.manager.Layout(outsideWidth, outsideHeight)
+}
+
+// Run opens a window with the user's video settings and runs the game
+// until it quits
+func (g *Game) Run(title string) error {
+	ebiten.SetWindowTitle(title)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	options.ApplyVideo(g.settings, g.display)
+	return ebiten.RunGame(g)
+}
+
+// BotMode is how the soak test bot plays (see RunBot)
+type BotMode = playing.BotMode
+
+// Soak test bot modes
+const (
+	BotRandom   = playing.BotRandom
+	BotSeekExit = playing.BotSeekExit
+)
+
+// ParseBotMode returns the bot mode named "random" or "exit"
+func ParseBotMode(name string) (BotMode, bool) {
+	return playing.ParseBotMode(name)
+}
+
+// BotOptions configures a soak test. Configs is required.
+type BotOptions struct {
+	Configs  fs.FS
+	Stage    string // stage ID to play ("" = DefaultStage)
+	Mode     BotMode
+	Duration time.Duration // wall-clock time to play for
+	Seed     int64
+	DumpDir  string // where a violation's report is written
+}
+
+// RunBot soak tests the game without a window: a scripted bot plays the
+// stage over and over for the duration while the world invariants are
+// checked every frame. It returns the first violation (its report, with
+// the world dump and the run's replay, is written to DumpDir).
+func RunBot(opts BotOptions) error {
+	if opts.Configs == nil {
+		return errors.New("failed to run bot: configs are required")
+	}
+	stageID := opts.Stage
+	if stageID == "" {
+		stageID = DefaultStage
+	}
+	cfg, stageCfg, err := loadConfigs(opts.Configs, stageID, "")
+	if err != nil {
+		return err
+	}
+	fixedpoint.SetSimulationRate(cfg.Physics.Display.Framerate)
+	if err := loadLocales(config.NewFSLoader(opts.Configs, "configs")); err != nil {
+		return err
+	}
+	configVersions, err := crash.ConfigVersions(opts.Configs)
+	if err != nil {
+		logging.Config.Warnf("Bot reports will not list config versions: %v", err)
+	}
+
+	s := playing.New(cfg, stageCfg, entity.LoadStage(stageCfg), "")
+	s.SetRumble(false)
+	return s.RunBot(playing.BotOptions{
+		Mode:     opts.Mode,
+		Duration: opts.Duration,
+		Seed:     opts.Seed,
+		DumpDir:  opts.DumpDir,
+		Configs:  configVersions,
+	})
+}
+
+// loadConfigs loads the configs and stage with the stage's and
+// difficulty's override files layered on top
+func loadConfigs(fsys fs.FS, stageID, difficulty string) (*config.GameConfig, *config.StageConfig, error) {
+	loader := config.NewFSLoader(fsys, "configs")
+	loader.SetOverrides(stageID, difficulty)
+	cfg, err := loader.LoadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	stageCfg, err := loader.LoadStage(stageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load stage: %w", err)
+	}
+	return cfg, stageCfg, nil
+}
+
+// loadTower loads the configs and the tower with the tower's stage
+// override file (overrides/stages/tower.json) and the difficulty's layered
+// on top
+func loadTower(fsys fs.FS, difficulty string) (*config.GameConfig, *config.TowerConfig, error) {
+	loader := config.NewFSLoader(fsys, "configs")
+	loader.SetOverrides(tower.StageID, difficulty)
+	cfg, err := loader.LoadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	towerCfg, err := loader.LoadTower()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, towerCfg, nil
+}
+
+// loadLocales registers the UI string tables (English is required as the
+// fallback)
+func loadLocales(loader *config.Loader) error {
+	for _, lang := range i18n.Languages {
+		table, err := loader.LoadLocale(lang)
+		if err != nil {
+			if lang == i18n.DefaultLanguage {
+				return fmt.Errorf("failed to load locale: %w", err)
+			}
+			logging.Config.Errorf("Failed to load locale: %v", err)
+			continue
+		}
+		i18n.Register(lang, table)
+	}
+	return nil
+}
+
+// loadSettings reads user settings (missing file or no path = defaults)
+func loadSettings(path string) *settings.Settings {
+	if path == "" {
+		return settings.Default()
+	}
+	s, err := settings.Load(path)
+	if err != nil {
+		logging.Config.Errorf("Failed to load settings: %v", err)
+	}
+	return s
+}
+
+// saveSettings writes user settings (no path = not persisted)
+func saveSettings(s *settings.Settings, path string) {
+	if path == "" {
+		return
+	}
+	if err := s.Save(path); err != nil {
+		logging.Config.Errorf("Failed to save settings: %v", err)
+	}
+}
+
+// loadProfile reads the profile save and the local leaderboard next to it
+// (missing files or no path = new profile, empty board)
+func loadProfile(savePath string) (*save.Data, *save.Leaderboard, string) {
+	profile := save.New()
+	board := save.NewLeaderboard()
+	if savePath == "" {
+		return profile, board, ""
+	}
+
+	var err error
+	if profile, err = save.Load(savePath); err != nil {
+		logging.Game.Errorf("Failed to load save: %v", err)
+	}
+	leaderboardPath := save.LeaderboardPath(savePath)
+	if board, err = save.LoadLeaderboard(leaderboardPath); err != nil {
+		logging.Game.Errorf("Failed to load leaderboard: %v", err)
+	}
+	return profile, board, leaderboardPath
+}
+
+// loadReplays reads the replays bundled in the configs' replays directory
+// (missing directory = none). Unreadable replays and ones recorded with
+// other content than versionHash (they would desync) are skipped.
+func loadReplays(fsys fs.FS, versionHash string) []*replay.ReplayData {
+	entries, err := fs.ReadDir(fsys, "replays")
+	if err != nil {
+		return nil
+	}
+	var demos []*replay.ReplayData
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".json" {
+			continue
+		}
+		file, err := fsys.Open(path.Join("replays", e.Name()))
+		if err != nil {
+			logging.Replay.Warnf("Failed to open bundled replay %s: %v", e.Name(), err)
+			continue
+		}
+		data, err := replay.Decode(file)
+		_ = file.Close()
+		if err != nil {
+			logging.Replay.Warnf("Skipping bundled replay %s: %v", e.Name(), err)
+			continue
+		}
+		if c := data.Compatibility(versionHash); c == replay.Mismatched || c == replay.Incompatible {
+			logging.Replay.Warnf("Skipping bundled replay %s: recorded with other game content", e.Name())
+			continue
+		}
+		demos = append(demos, data)
+	}
+	return demos
+}
+
+// newAttract returns the menu's idle scene: a fresh playing scene on each
+// demo's stage, driven by the demos in turn and returning to back
+func newAttract(fsys fs.FS, demos []*replay.ReplayData, back scene.Scene) func() scene.Scene {
+	next := 0
+	return func() scene.Scene {
+		data := demos[next%len(demos)]
+		next++
+		demo, err := newReplayScene(fsys, data, back, "attract.demo")
+		if err != nil {
+			logging.Replay.Warnf("Skipping attract demo: %v", err)
+			return nil
+		}
+		return demo
+	}
+}
+
+// newReplayScene builds a playing scene on the replay's stage that plays
+// it back under a banner with label (a locale key) and returns to back
+func newReplayScene(fsys fs.FS, data *replay.ReplayData, back scene.Scene, label string) (scene.Scene, error) {
+	if data.Stage == tower.StageID {
+		return newTowerReplayScene(fsys, data, back, label)
+	}
+	cfg, stageCfg, err := loadConfigs(fsys, data.Stage, data.Difficulty)
+	if err != nil {
+		return nil, err
+	}
+	s := playing.New(cfg, stageCfg, entity.LoadStage(stageCfg), "")
+	s.SetRumble(false)
+	s.PlayReplay(*data)
+	s.SetAttract(back, label)
+	return s, nil
+}
+
+// newTowerReplayScene is newReplayScene for a tower run (a daily run): the
+// floors are generated again from the replay's seed
+func newTowerReplayScene(fsys fs.FS, data *replay.ReplayData, back scene.Scene, label string) (scene.Scene, error) {
+	cfg, towerCfg, err := loadTower(fsys, data.Difficulty)
+	if err != nil {
+		return nil, err
+	}
+	first := tower.Generate(towerCfg, data.Seed, 1)
+	s := playing.New(cfg, first.Stage, entity.LoadStage(first.Stage), "")
+	s.SetRumble(false)
+	s.PlayTowerReplay(towerCfg, cfg, *data)
+	s.SetAttract(back, label)
+	return s, nil
+}
+
+// newMainMenu builds the main menu: play, stage (when there are several),
+// tower and daily run (when startTower is set), character, leaderboard,
+// replays (when openReplays is set), options, quit
+func newMainMenu(cfg *config.GameConfig, stages *stageList, selectStage func(id string) error, startTower func(daily bool) error, profile *save.Data, playingScene *playing.Playing, board *save.Leaderboard, openReplays func(back scene.Scene) scene.Scene, userSettings *settings.Settings, settingsPath string) *menu.Menu {
+	screenW := cfg.Physics.Display.ScreenWidth
+	screenH := cfg.Physics.Display.ScreenHeight
+
+	// Character selection cycles the configured characters; the playing
+	// scene picks up the change (and restarts) when it is entered
+	cycleCharacter := func(dir int) {
+		chars := cfg.Entities.Characters
+		if len(chars) == 0 {
+			return
+		}
+		current := cfg.Entities.Character(userSettings.Character).ID
+		i := 0
+		for j, ch := range chars {
+			if ch.ID == current {
+				i = j
+			}
+		}
+		userSettings.Character = chars[(i+dir+len(chars))%len(chars)].ID
+		saveSettings(userSettings, settingsPath)
+	}
+
+	// Stage selection cycles the unlocked stages and starts the new one
+	cycleStage := func(dir int) {
+		unlocked := stages.unlocked(profile)
+		next := (stages.current + dir + len(unlocked)) % len(unlocked)
+		if next == stages.current {
+			return
+		}
+		if err := selectStage(unlocked[next].ID); err != nil {
+			logging.Config.Errorf("Failed to load stage: %v", err)
+			return
+		}
+		stages.current = next
+	}
+
+	var mainMenu *menu.Menu
+	items := []menu.Item{
+		{Label: "menu.play", Select: func() (scene.Scene, error) {
+			// Back from the tower to the selected stage
+			if playingScene.InTower() {
+				if err := selectStage(stages.stages[stages.current].ID); err != nil {
+					logging.Config.Errorf("Failed to load stage: %v", err)
+					return nil, nil
+				}
+			}
+			return playingScene, nil
+		}},
+		{Label: "menu.character", Value: func() string {
+			return i18n.T(cfg.Entities.Character(userSettings.Character).Name)
+		}, Change: cycleCharacter, Select: func() (scene.Scene, error) {
+			cycleCharacter(1)
+			return nil, nil
+		}},
+		{Label: "menu.leaderboard", Select: func() (scene.Scene, error) {
+			boards := stages.unlocked(profile)
+			if startTower != nil {
+				now := time.Now()
+				boards = append(slices.Clone(boards),
+					&config.StageConfig{ID: tower.StageID, Name: i18n.T("menu.tower")},
+					&config.StageConfig{ID: tower.DailyID(now), Name: i18n.Tf("menu.dailyBoard", now.UTC().Format(time.DateOnly))})
+			}
+			return leaderboard.New(board, boards, screenW, screenH, mainMenu), nil
+		}},
+		{Label: "menu.options", Select: func() (scene.Scene, error) {
+			return options.New(userSettings, settingsPath, cfg.Physics.Display, mainMenu), nil
+		}},
+		{Label: "menu.quit", Select: func() (scene.Scene, error) {
+			return nil, ebiten.Termination
+		}},
+	}
+	if openReplays != nil {
+		items = slices.Insert(items, 3, menu.Item{Label: "menu.replays", Select: func() (scene.Scene, error) {
+			return openReplays(mainMenu), nil
+		}})
+	}
+	if startTower != nil {
+		climb := func(daily bool) func() (scene.Scene, error) {
+			return func() (scene.Scene, error) {
+				if err := startTower(daily); err != nil {
+					logging.Config.Errorf("Failed to load tower: %v", err)
+					return nil, nil
+				}
+				return playingScene, nil
+			}
+		}
+		items = slices.Insert(items, 1,
+			menu.Item{Label: "menu.tower", Select: climb(false)},
+			menu.Item{Label: "menu.daily", Select: climb(true)})
+	}
+	if len(stages.stages) > 1 {
+		items = slices.Insert(items, 1, menu.Item{Label: "menu.stage", Value: func() string {
+			return stages.stages[stages.current].Name
+		}, Change: cycleStage, Select: func() (scene.Scene, error) {
+			cycleStage(1)
+			return nil, nil
+		}})
+	}
+	mainMenu = menu.New(screenW, screenH, items)
+	return mainMenu
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+func TestNew_RequiresConfigs(t *testing.T) {
+	_, err := New(Options{})
+	assert.Error(t, err)
+}
+
+func TestNew_UnknownStage(t *testing.T) {
+	_, err := New(Options{Configs: os.DirFS("../cmd/game/configs"), Stage: "missing"})
+	assert.Error(t, err)
+}
+
+func TestNew_EmbeddedStage(t *testing.T) {
+	g, err := New(Options{
+		Configs:  os.DirFS("../cmd/game/configs"),
+		SkipMenu: true,
+		Systems:  []System{func(*World) {}},
+	})
+	require.NoError(t, err)
+
+	w, h := g.Layout(640, 480)
+	assert.Equal(t, [2]int{640, 480}, [2]int{w, h})
+}
+
+func TestStageList_UnlocksInOrder(t *testing.T) {
+	fsys := os.DirFS("../cmd/game/configs")
+	first, err := config.NewFSLoader(fsys, "configs").LoadStage(DefaultStage)
+	require.NoError(t, err)
+	stages := loadStageList(fsys, first)
+	require.Greater(t, len(stages.stages), 1, "the default stage leads to another")
+
+	profile := save.New()
+	assert.Len(t, stages.unlocked(profile), 1)
+	profile.ClearStage(DefaultStage, 1)
+	assert.Len(t, stages.unlocked(profile), 2)
+	assert.Equal(t, first.Next, stages.unlocked(profile)[1].ID)
+}
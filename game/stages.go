@@ -0,0 +1,45 @@
+package game
+
+import (
+	"io/fs"
+
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+// stageList is the stages the main menu offers: the first stage and the
+// ones its "next" links lead to, in order. A stage is unlocked once the
+// one before it has been cleared.
+type stageList struct {
+	stages  []*config.StageConfig
+	current int // stage the playing scene is on
+}
+
+// loadStageList follows the next links from first. A stage that fails to
+// load ends the list.
+func loadStageList(fsys fs.FS, first *config.StageConfig) *stageList {
+	loader := config.NewFSLoader(fsys, "configs")
+	l := &stageList{stages: []*config.StageConfig{first}}
+	seen := map[string]bool{first.ID: true}
+	for next := first.Next; next != "" && !seen[next]; {
+		stageCfg, err := loader.LoadStage(next)
+		if err != nil {
+			logging.Config.Errorf("Failed to load next stage: %v", err)
+			break
+		}
+		l.stages = append(l.stages, stageCfg)
+		seen[next] = true
+		next = stageCfg.Next
+	}
+	return l
+}
+
+// unlocked returns the stages the profile has unlocked
+func (l *stageList) unlocked(profile *save.Data) []*config.StageConfig {
+	n := 1
+	for n < len(l.stages) && profile.StageCleared(l.stages[n-1].ID) {
+		n++
+	}
+	return l.stages[:n]
+}
@@ -0,0 +1,143 @@
+// Package achievement unlocks achievements from gameplay events.
+//
+// The Tracker consumes ecs.Events drained by the scene each frame, keeps
+// lifetime counters in the profile save data and reports newly unlocked
+// achievements so the HUD can show a toast.
+package achievement
+
+import (
+	"time"
+
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+// Kind is an achievement condition
+type Kind string
+
+const (
+	KindKills         Kind = "kills"         // lifetime enemies killed >= Target
+	KindGold          Kind = "gold"          // lifetime gold collected >= Target
+	KindStageNoDamage Kind = "stageNoDamage" // stage cleared without taking damage
+	KindBossTime      Kind = "bossTime"      // boss killed within Target seconds
+)
+
+// Definition is one achievement
+type Definition struct {
+	ID          string
+	Name        string
+	Description string
+	Kind        Kind
+	Target      int
+}
+
+// FromConfig converts the achievements config
+func FromConfig(cfg *config.AchievementsConfig) []Definition {
+	if cfg == nil {
+		return nil
+	}
+	defs := make([]Definition, 0, len(cfg.Achievements))
+	for _, a := range cfg.Achievements {
+		defs = append(defs, Definition{
+			ID:          a.ID,
+			Name:        a.Name,
+			Description: a.Description,
+			Kind:        Kind(a.Kind),
+			Target:      a.Target,
+		})
+	}
+	return defs
+}
+
+// Tracker evaluates achievements against events
+type Tracker struct {
+	defs     []Definition
+	progress *save.AchievementsData
+
+	stageDamaged bool // damage taken since the stage started
+
+	// now returns the unlock timestamp (overridable in tests)
+	now func() time.Time
+}
+
+// NewTracker creates a tracker writing into progress (usually the profile save)
+func NewTracker(defs []Definition, progress *save.AchievementsData) *Tracker {
+	if progress.Unlocked == nil {
+		progress.Unlocked = map[string]int64{}
+	}
+	return &Tracker{
+		defs:     defs,
+		progress: progress,
+		now:      time.Now,
+	}
+}
+
+// Handle updates counters from one event and returns achievements it unlocked
+func (t *Tracker) Handle(ev ecs.Event) []Definition {
+	switch ev.Type {
+	case ecs.EventStageStarted:
+		t.stageDamaged = false
+		return nil
+	case ecs.EventPlayerDamaged:
+		t.stageDamaged = true
+		return nil
+	case ecs.EventEnemyKilled:
+		t.progress.Kills++
+		return t.check(KindKills, t.progress.Kills)
+	case ecs.EventGoldCollected:
+		t.progress.Gold += ev.Amount
+		return t.check(KindGold, t.progress.Gold)
+	case ecs.EventStageCleared:
+		if t.stageDamaged {
+			return nil
+		}
+		return t.check(KindStageNoDamage, 0)
+	case ecs.EventBossKilled:
+		return t.checkBossTime(ev.Amount)
+	}
+	return nil
+}
+
+// Unlocked reports whether an achievement has been unlocked
+func (t *Tracker) Unlocked(id string) bool {
+	_, ok := t.progress.Unlocked[id]
+	return ok
+}
+
+// Definitions returns all achievement definitions
+func (t *Tracker) Definitions() []Definition {
+	return t.defs
+}
+
+// check unlocks every locked achievement of kind whose target value is reached
+func (t *Tracker) check(kind Kind, value int) []Definition {
+	var unlocked []Definition
+	for _, def := range t.defs {
+		if def.Kind == kind && value >= def.Target && t.unlock(def) {
+			unlocked = append(unlocked, def)
+		}
+	}
+	return unlocked
+}
+
+// checkBossTime unlocks boss time achievements whose limit was beaten
+func (t *Tracker) checkBossTime(frames int) []Definition {
+	var unlocked []Definition
+	for _, def := range t.defs {
+		if def.Kind == KindBossTime && frames < fixedpoint.SecondsToFrames(float64(def.Target)) && t.unlock(def) {
+			unlocked = append(unlocked, def)
+		}
+	}
+	return unlocked
+}
+
+// unlock records def as unlocked. Returns false if it already was.
+func (t *Tracker) unlock(def Definition) bool {
+	if t.Unlocked(def.ID) {
+		return false
+	}
+	t.progress.Unlocked[def.ID] = t.now().Unix()
+	return true
+}
@@ -0,0 +1,93 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+func testDefinitions() []Definition {
+	return []Definition{
+		{ID: "first_blood", Kind: KindKills, Target: 1},
+		{ID: "slayer", Kind: KindKills, Target: 3},
+		{ID: "hoarder", Kind: KindGold, Target: 100},
+		{ID: "untouchable", Kind: KindStageNoDamage},
+		{ID: "speed_slayer", Kind: KindBossTime, Target: 60},
+	}
+}
+
+func newTestTracker(progress *save.AchievementsData) *Tracker {
+	tr := NewTracker(testDefinitions(), progress)
+	tr.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return tr
+}
+
+func ids(defs []Definition) []string {
+	var out []string
+	for _, d := range defs {
+		out = append(out, d.ID)
+	}
+	return out
+}
+
+func TestTracker_Kills(t *testing.T) {
+	progress := &save.AchievementsData{}
+	tr := newTestTracker(progress)
+
+	kill := ecs.Event{Type: ecs.EventEnemyKilled}
+	assert.Equal(t, []string{"first_blood"}, ids(tr.Handle(kill)))
+	assert.Empty(t, tr.Handle(kill))
+	assert.Equal(t, []string{"slayer"}, ids(tr.Handle(kill)))
+	assert.Empty(t, tr.Handle(kill), "already unlocked")
+
+	assert.Equal(t, 4, progress.Kills)
+	assert.Equal(t, int64(1700000000), progress.Unlocked["slayer"])
+}
+
+func TestTracker_GoldAccumulatesAcrossSessions(t *testing.T) {
+	progress := &save.AchievementsData{Gold: 90}
+	tr := newTestTracker(progress)
+
+	assert.Empty(t, tr.Handle(ecs.Event{Type: ecs.EventGoldCollected, Amount: 5}))
+	assert.Equal(t, []string{"hoarder"}, ids(tr.Handle(ecs.Event{Type: ecs.EventGoldCollected, Amount: 5})))
+	assert.Equal(t, 100, progress.Gold)
+}
+
+func TestTracker_StageNoDamage(t *testing.T) {
+	tr := newTestTracker(&save.AchievementsData{})
+
+	tr.Handle(ecs.Event{Type: ecs.EventStageStarted})
+	tr.Handle(ecs.Event{Type: ecs.EventPlayerDamaged, Amount: 10})
+	assert.Empty(t, tr.Handle(ecs.Event{Type: ecs.EventStageCleared}), "damaged this stage")
+
+	tr.Handle(ecs.Event{Type: ecs.EventStageStarted})
+	assert.Equal(t, []string{"untouchable"}, ids(tr.Handle(ecs.Event{Type: ecs.EventStageCleared})))
+}
+
+func TestTracker_BossTime(t *testing.T) {
+	tr := newTestTracker(&save.AchievementsData{})
+
+	assert.Empty(t, tr.Handle(ecs.Event{Type: ecs.EventBossKilled, Amount: 60 * 60}), "exactly 60s is not under 60s")
+	assert.Equal(t, []string{"speed_slayer"}, ids(tr.Handle(ecs.Event{Type: ecs.EventBossKilled, Amount: 59 * 60})))
+}
+
+func TestTracker_PreviouslyUnlockedStaysSilent(t *testing.T) {
+	progress := &save.AchievementsData{Unlocked: map[string]int64{"first_blood": 1}}
+	tr := newTestTracker(progress)
+
+	assert.True(t, tr.Unlocked("first_blood"))
+	assert.Empty(t, tr.Handle(ecs.Event{Type: ecs.EventEnemyKilled}))
+	assert.Equal(t, int64(1), progress.Unlocked["first_blood"], "unlock time unchanged")
+}
+
+func TestFromConfig(t *testing.T) {
+	defs := FromConfig(&config.AchievementsConfig{Achievements: []config.AchievementConfig{
+		{ID: "a", Name: "A", Kind: "kills", Target: 5},
+	}})
+	assert.Equal(t, []Definition{{ID: "a", Name: "A", Kind: KindKills, Target: 5}}, defs)
+	assert.Nil(t, FromConfig(nil))
+}
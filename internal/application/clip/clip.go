@@ -0,0 +1,168 @@
+// Package clip keeps the last few seconds of gameplay for export as an
+// animated GIF.
+//
+// A Buffer stores downscaled frames in a ring buffer; the oldest frame is
+// overwritten once it is full. Frames are kept as RGBA and only quantized
+// to a palette on export, so capturing stays cheap.
+// All Buffer methods are safe to call on a nil *Buffer, so callers can
+// leave capture calls in place and simply pass nil when clips are disabled.
+package clip
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"time"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Defaults: 5 seconds at 30fps (every 2nd frame of 60), half resolution
+const (
+	DefaultCapacity = 150
+	DefaultInterval = 2
+	DefaultScale    = 2
+)
+
+// Buffer keeps the most recent captured frames
+type Buffer struct {
+	frames   []*image.RGBA
+	next     int // index of the slot the next frame writes to
+	count    int // number of frames stored
+	interval int // game frames per captured frame
+	scale    int // downscale factor
+	tick     int // game frames since the last capture
+}
+
+// NewBuffer creates a buffer of capacity frames, capturing every interval
+// game frames at 1/scale resolution (zero values use the defaults)
+func NewBuffer(capacity, interval, scale int) *Buffer {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	return &Buffer{
+		frames:   make([]*image.RGBA, capacity),
+		interval: interval,
+		scale:    scale,
+	}
+}
+
+// Due advances the frame counter and reports whether this frame should be
+// captured. Callers check it before reading back the screen.
+func (b *Buffer) Due() bool {
+	if b == nil {
+		return false
+	}
+	b.tick++
+	if b.tick < b.interval {
+		return false
+	}
+	b.tick = 0
+	return true
+}
+
+// Add stores a downscaled copy of src, replacing the oldest frame when full
+func (b *Buffer) Add(src *image.RGBA) {
+	if b == nil {
+		return
+	}
+	bounds := src.Bounds()
+	w, h := bounds.Dx()/b.scale, bounds.Dy()/b.scale
+	dst := b.frames[b.next]
+	if dst == nil || dst.Bounds().Dx() != w || dst.Bounds().Dy() != h {
+		dst = image.NewRGBA(image.Rect(0, 0, w, h))
+		b.frames[b.next] = dst
+	}
+	// Nearest neighbour keeps pixel art crisp
+	for y := range h {
+		for x := range w {
+			si := src.PixOffset(bounds.Min.X+x*b.scale, bounds.Min.Y+y*b.scale)
+			di := dst.PixOffset(x, y)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	b.next = (b.next + 1) % len(b.frames)
+	if b.count < len(b.frames) {
+		b.count++
+	}
+}
+
+// Frames returns the stored frames, oldest first
+func (b *Buffer) Frames() []*image.RGBA {
+	if b == nil {
+		return nil
+	}
+	out := make([]*image.RGBA, 0, b.count)
+	start := (b.next - b.count + len(b.frames)) % len(b.frames)
+	for i := 0; i < b.count; i++ {
+		out = append(out, b.frames[(start+i)%len(b.frames)])
+	}
+	return out
+}
+
+// Duration returns the length of the stored clip
+func (b *Buffer) Duration() time.Duration {
+	if b == nil {
+		return 0
+	}
+	return time.Duration(fixedpoint.FramesToSeconds(b.count*b.interval) * float64(time.Second))
+}
+
+// Reset drops all stored frames (e.g. on restart)
+func (b *Buffer) Reset() {
+	if b == nil {
+		return
+	}
+	b.next, b.count, b.tick = 0, 0, 0
+}
+
+// WriteGIF encodes the stored frames as a looping animated GIF
+func (b *Buffer) WriteGIF(w io.Writer) error {
+	frames := b.Frames()
+	if len(frames) == 0 {
+		return errors.New("failed to write clip: no frames captured")
+	}
+
+	// GIF delays are in 1/100s; round so the clip plays at about real speed
+	delay := fixedpoint.RoundHalfUp(fixedpoint.FramesToSeconds(b.interval) * 100)
+	anim := &gif.GIF{}
+	for _, f := range frames {
+		img := image.NewPaletted(f.Bounds(), palette.Plan9)
+		draw.Draw(img, img.Bounds(), f, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("failed to encode clip: %w", err)
+	}
+	return nil
+}
+
+// SaveGIF writes the stored frames to a GIF file
+func (b *Buffer) SaveGIF(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return b.WriteGIF(file)
+}
+
+// GenerateFilename creates a GIF filename based on current time
+func GenerateFilename() string {
+	return fmt.Sprintf("clip_%s.gif", time.Now().Format("20060102_150405"))
+}
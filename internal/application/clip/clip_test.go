@@ -0,0 +1,92 @@
+package clip
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solidFrame(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return img
+}
+
+func TestBuffer_NilIsNoop(t *testing.T) {
+	var b *Buffer
+
+	assert.NotPanics(t, func() {
+		b.Add(solidFrame(4, 4, color.RGBA{}))
+		b.Reset()
+	})
+	assert.False(t, b.Due())
+	assert.Nil(t, b.Frames())
+	assert.Equal(t, time.Duration(0), b.Duration())
+}
+
+func TestBuffer_DueEveryInterval(t *testing.T) {
+	b := NewBuffer(4, 3, 1)
+
+	var due []bool
+	for range 6 {
+		due = append(due, b.Due())
+	}
+	assert.Equal(t, []bool{false, false, true, false, false, true}, due)
+}
+
+func TestBuffer_AddDownscales(t *testing.T) {
+	b := NewBuffer(4, 1, 2)
+	src := solidFrame(8, 6, color.RGBA{255, 0, 0, 255})
+	src.SetRGBA(2, 2, color.RGBA{0, 0, 255, 255})
+
+	b.Add(src)
+
+	frames := b.Frames()
+	require.Len(t, frames, 1)
+	assert.Equal(t, image.Rect(0, 0, 4, 3), frames[0].Bounds())
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, frames[0].RGBAAt(1, 1))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, frames[0].RGBAAt(0, 0))
+}
+
+func TestBuffer_RingBufferWraps(t *testing.T) {
+	b := NewBuffer(3, 2, 1)
+	for i := range 5 {
+		b.Add(solidFrame(2, 2, color.RGBA{uint8(i), 0, 0, 255}))
+	}
+
+	frames := b.Frames()
+	require.Len(t, frames, 3)
+	for i, f := range frames {
+		assert.Equal(t, uint8(i+2), f.RGBAAt(0, 0).R, "oldest first")
+	}
+	assert.Equal(t, 100*time.Millisecond, b.Duration())
+
+	b.Reset()
+	assert.Empty(t, b.Frames())
+}
+
+func TestBuffer_WriteGIF(t *testing.T) {
+	b := NewBuffer(4, 2, 1)
+	assert.Error(t, b.WriteGIF(&bytes.Buffer{}), "nothing captured yet")
+
+	b.Add(solidFrame(4, 4, color.RGBA{255, 0, 0, 255}))
+	b.Add(solidFrame(4, 4, color.RGBA{0, 0, 255, 255}))
+
+	var buf bytes.Buffer
+	require.NoError(t, b.WriteGIF(&buf))
+
+	anim, err := gif.DecodeAll(&buf)
+	require.NoError(t, err)
+	assert.Len(t, anim.Image, 2)
+	assert.Equal(t, []int{3, 3}, anim.Delay)
+	r, _, _, _ := anim.Image[0].At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+}
@@ -0,0 +1,164 @@
+// Package crash writes panic reports so players can send in crashes that
+// would otherwise be undiagnosable.
+//
+// A report is a folder holding the panic message and stack trace, the
+// build and config versions, and JSON attachments the crashing scene adds
+// (world dump, replay buffer).
+package crash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Source is a scene that adds its state to crash reports
+type Source interface {
+	CrashReport(r *Report)
+}
+
+// Report is everything known about a panic
+type Report struct {
+	Time    time.Time
+	Panic   string
+	Stack   []byte
+	Build   string            // module version and VCS revision
+	Configs map[string]string // config file → content hash
+
+	attachments map[string]any
+}
+
+// NewReport creates a report for a recovered panic value and its stack
+func NewReport(recovered any, stack []byte, configs map[string]string) *Report {
+	return &Report{
+		Time:    time.Now(),
+		Panic:   fmt.Sprint(recovered),
+		Stack:   stack,
+		Build:   BuildVersion(),
+		Configs: configs,
+	}
+}
+
+// Attach adds a value written as name.json
+func (r *Report) Attach(name string, v any) {
+	if r.attachments == nil {
+		r.attachments = make(map[string]any)
+	}
+	r.attachments[name] = v
+}
+
+// Write saves the report into a new timestamped folder under dir and
+// returns the folder. Attachments that fail to encode are noted in the
+// crash log instead of failing the report.
+func (r *Report) Write(dir string) (string, error) {
+	folder := filepath.Join(dir, "crash_"+r.Time.Format("20060102_150405"))
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create crash folder: %w", err)
+	}
+
+	var notes []string
+	names := make([]string, 0, len(r.attachments))
+	for name := range r.attachments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeJSON(filepath.Join(folder, name+".json"), r.attachments[name]); err != nil {
+			notes = append(notes, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if err := writeJSON(filepath.Join(folder, "configs.json"), r.Configs); err != nil {
+		notes = append(notes, fmt.Sprintf("configs: %v", err))
+	}
+
+	if err := os.WriteFile(filepath.Join(folder, "crash.txt"), r.log(notes), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write crash log: %w", err)
+	}
+	return folder, nil
+}
+
+// log formats the panic, versions and stack trace
+func (r *Report) log(notes []string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: %s\n", r.Panic)
+	fmt.Fprintf(&b, "time: %s\n", r.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "build: %s\n", r.Build)
+	for _, note := range notes {
+		fmt.Fprintf(&b, "failed attachment %s\n", note)
+	}
+	fmt.Fprintf(&b, "\n%s", r.Stack)
+	return []byte(b.String())
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(filename string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// ConfigVersions hashes every JSON config in fsys, so a report shows which
+// configs the crashing build ran with
+func ConfigVersions(fsys fs.FS) (map[string]string, error) {
+	versions := make(map[string]string)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(name) != ".json" {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		versions[name] = hex.EncodeToString(sum[:6])
+		return nil
+	})
+	if err != nil {
+		return versions, fmt.Errorf("failed to hash configs: %w", err)
+	}
+	return versions, nil
+}
+
+// DefaultDir returns the crash folder in the user config directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config dir: %w", err)
+	}
+	return filepath.Join(dir, "mg", "crashes"), nil
+}
+
+// BuildVersion returns the module version and VCS revision of the binary
+func BuildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			version += " " + s.Value
+		}
+		if s.Key == "vcs.modified" && s.Value == "true" {
+			version += " (modified)"
+		}
+	}
+	return version
+}
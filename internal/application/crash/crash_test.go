@@ -0,0 +1,54 @@
+package crash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport_Write(t *testing.T) {
+	r := NewReport(errors.New("index out of range"), []byte("goroutine 1 [running]:"), map[string]string{"physics.json": "abc"})
+	r.Attach("world", map[string]int{"player": 1})
+	r.Attach("broken", func() {})
+
+	folder, err := r.Write(t.TempDir())
+	require.NoError(t, err)
+
+	log, err := os.ReadFile(filepath.Join(folder, "crash.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "panic: index out of range")
+	assert.Contains(t, string(log), "goroutine 1 [running]:")
+	assert.Contains(t, string(log), "failed attachment broken", "encode errors don't lose the report")
+
+	world, err := os.ReadFile(filepath.Join(folder, "world.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"player": 1}`, string(world))
+
+	configs, err := os.ReadFile(filepath.Join(folder, "configs.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"physics.json": "abc"}`, string(configs))
+}
+
+func TestConfigVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"physics.json":     {Data: []byte(`{"gravity": 1}`)},
+		"stages/demo.json": {Data: []byte(`{}`)},
+		"README.md":        {Data: []byte("not a config")},
+	}
+
+	versions, err := ConfigVersions(fsys)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Len(t, versions["stages/demo.json"], 12)
+
+	fsys["physics.json"] = &fstest.MapFile{Data: []byte(`{"gravity": 2}`)}
+	changed, err := ConfigVersions(fsys)
+	require.NoError(t, err)
+	assert.NotEqual(t, versions["physics.json"], changed["physics.json"])
+	assert.Equal(t, versions["stages/demo.json"], changed["stages/demo.json"])
+}
@@ -0,0 +1,168 @@
+// Package dialogue runs config-defined conversations.
+//
+// A Conversation is a list of pages (speaker, portrait, text) optionally
+// ending in choices that branch to other conversations. The Runner reveals
+// page text with a typewriter effect and advances on player input; the scene
+// only feeds it input once per frame and draws its current state.
+package dialogue
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// Page is one text box of a conversation
+type Page struct {
+	Speaker  string
+	Portrait string // portrait ID (empty = none)
+	Text     string
+}
+
+// Choice is a selectable answer shown after the last page
+type Choice struct {
+	Text string
+	Next string // conversation to start (empty = end)
+}
+
+// Conversation is a sequence of pages with optional trailing choices
+type Conversation struct {
+	ID      string
+	Pages   []Page
+	Choices []Choice
+}
+
+// Input is the per-frame dialogue input
+type Input struct {
+	Advance bool // just pressed: skip typing / next page / confirm choice
+	Up      bool // just pressed: previous choice
+	Down    bool // just pressed: next choice
+}
+
+// DefaultCharsPerSecond is the typewriter speed when none is configured
+const DefaultCharsPerSecond = 40
+
+// Runner plays conversations one at a time
+type Runner struct {
+	conversations map[string]Conversation
+	charsPerFrame float64
+
+	current  *Conversation
+	page     int
+	revealed float64 // runes revealed on the current page
+	choice   int
+}
+
+// NewRunner creates a runner. charsPerSecond <= 0 uses the default speed.
+func NewRunner(conversations map[string]Conversation, charsPerSecond float64) *Runner {
+	if charsPerSecond <= 0 {
+		charsPerSecond = DefaultCharsPerSecond
+	}
+	return &Runner{
+		conversations: conversations,
+		charsPerFrame: fixedpoint.Sim.PerFrame(charsPerSecond),
+	}
+}
+
+// Start begins the conversation with the given ID.
+// Returns false if it does not exist or has no pages.
+func (r *Runner) Start(id string) bool {
+	conv, ok := r.conversations[id]
+	if !ok || len(conv.Pages) == 0 {
+		return false
+	}
+	r.current = &conv
+	r.page = 0
+	r.revealed = 0
+	r.choice = 0
+	return true
+}
+
+// Active reports whether a conversation is running
+func (r *Runner) Active() bool {
+	return r.current != nil
+}
+
+// Stop ends the current conversation
+func (r *Runner) Stop() {
+	r.current = nil
+}
+
+// Update advances the typewriter and handles input for one frame
+func (r *Runner) Update(in Input) {
+	if r.current == nil {
+		return
+	}
+
+	pageLen := float64(len([]rune(r.Page().Text)))
+	if r.revealed < pageLen {
+		r.revealed += r.charsPerFrame
+		if r.revealed > pageLen {
+			r.revealed = pageLen
+		}
+		if in.Advance {
+			r.revealed = pageLen
+		}
+		return
+	}
+
+	if choices := r.Choices(); len(choices) > 0 {
+		switch {
+		case in.Up:
+			r.choice = (r.choice + len(choices) - 1) % len(choices)
+		case in.Down:
+			r.choice = (r.choice + 1) % len(choices)
+		}
+	}
+
+	if !in.Advance {
+		return
+	}
+
+	if r.page < len(r.current.Pages)-1 {
+		r.page++
+		r.revealed = 0
+		return
+	}
+
+	if choices := r.current.Choices; len(choices) > 0 {
+		next := choices[r.choice].Next
+		if next != "" && r.Start(next) {
+			return
+		}
+	}
+	r.current = nil
+}
+
+// Page returns the current page (zero value when inactive)
+func (r *Runner) Page() Page {
+	if r.current == nil {
+		return Page{}
+	}
+	return r.current.Pages[r.page]
+}
+
+// VisibleText returns the revealed part of the current page text
+func (r *Runner) VisibleText() string {
+	runes := []rune(r.Page().Text)
+	n := int(r.revealed)
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return string(runes[:n])
+}
+
+// PageComplete reports whether the current page is fully revealed
+func (r *Runner) PageComplete() bool {
+	return r.current != nil && int(r.revealed) >= len([]rune(r.Page().Text))
+}
+
+// Choices returns the choices to show now: only on the last page once
+// its text is fully revealed
+func (r *Runner) Choices() []Choice {
+	if r.current == nil || r.page < len(r.current.Pages)-1 || !r.PageComplete() {
+		return nil
+	}
+	return r.current.Choices
+}
+
+// Selected returns the highlighted choice index
+func (r *Runner) Selected() int {
+	return r.choice
+}
@@ -0,0 +1,120 @@
+package dialogue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConversations() map[string]Conversation {
+	return map[string]Conversation{
+		"intro": {
+			ID: "intro",
+			Pages: []Page{
+				{Speaker: "Guide", Text: "Hello"},
+				{Speaker: "Guide", Text: "Need help?"},
+			},
+			Choices: []Choice{
+				{Text: "Yes", Next: "help"},
+				{Text: "No"},
+			},
+		},
+		"help": {
+			ID:    "help",
+			Pages: []Page{{Speaker: "Guide", Text: "Press Space to dash."}},
+		},
+		"empty": {ID: "empty"},
+	}
+}
+
+// advance skips typing and moves to the next page
+func advance(r *Runner) {
+	r.Update(Input{Advance: true}) // reveal
+	r.Update(Input{Advance: true}) // next
+}
+
+func TestRunner_Start(t *testing.T) {
+	r := NewRunner(testConversations(), 60)
+
+	assert.False(t, r.Start("missing"))
+	assert.False(t, r.Start("empty"), "conversation without pages")
+	assert.False(t, r.Active())
+
+	assert.True(t, r.Start("intro"))
+	assert.True(t, r.Active())
+	assert.Equal(t, "Guide", r.Page().Speaker)
+	assert.Equal(t, "", r.VisibleText())
+}
+
+func TestRunner_Typewriter(t *testing.T) {
+	r := NewRunner(testConversations(), 60) // 1 rune per frame
+	r.Start("intro")
+
+	r.Update(Input{})
+	r.Update(Input{})
+	assert.Equal(t, "He", r.VisibleText())
+	assert.False(t, r.PageComplete())
+
+	r.Update(Input{Advance: true})
+	assert.Equal(t, "Hello", r.VisibleText(), "advance reveals the whole page")
+	assert.True(t, r.PageComplete())
+	assert.Equal(t, "Guide", r.Page().Speaker, "still on the first page")
+}
+
+func TestRunner_TypewriterCountsRunes(t *testing.T) {
+	r := NewRunner(map[string]Conversation{
+		"ko": {Pages: []Page{{Text: "안녕하세요"}}},
+	}, 120) // 2 runes per frame
+	r.Start("ko")
+
+	r.Update(Input{})
+	assert.Equal(t, "안녕", r.VisibleText())
+}
+
+func TestRunner_ChoicesOnLastPage(t *testing.T) {
+	r := NewRunner(testConversations(), 60)
+	r.Start("intro")
+
+	r.Update(Input{Advance: true})
+	assert.Nil(t, r.Choices(), "no choices on the first page")
+
+	r.Update(Input{Advance: true})
+	assert.Equal(t, "Need help?", r.Page().Text)
+	assert.Nil(t, r.Choices(), "no choices while typing")
+
+	r.Update(Input{Advance: true})
+	assert.Len(t, r.Choices(), 2)
+
+	r.Update(Input{Down: true})
+	assert.Equal(t, 1, r.Selected())
+	r.Update(Input{Down: true})
+	assert.Equal(t, 0, r.Selected(), "selection wraps")
+	r.Update(Input{Up: true})
+	assert.Equal(t, 1, r.Selected())
+}
+
+func TestRunner_ChoiceBranches(t *testing.T) {
+	r := NewRunner(testConversations(), 60)
+	r.Start("intro")
+	advance(r)
+	r.Update(Input{Advance: true}) // reveal last page
+
+	r.Update(Input{Advance: true}) // pick "Yes"
+	assert.True(t, r.Active())
+	assert.Equal(t, "Press Space to dash.", r.Page().Text)
+
+	advance(r)
+	assert.False(t, r.Active(), "conversation without choices ends after its last page")
+}
+
+func TestRunner_ChoiceWithoutNextEnds(t *testing.T) {
+	r := NewRunner(testConversations(), 60)
+	r.Start("intro")
+	advance(r)
+	r.Update(Input{Advance: true})
+
+	r.Update(Input{Down: true})
+	r.Update(Input{Advance: true}) // pick "No"
+	assert.False(t, r.Active())
+	assert.Equal(t, Page{}, r.Page())
+}
@@ -2,26 +2,50 @@
 package game
 
 import (
+	"image/color"
+	"runtime/debug"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/crash"
 	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/scene/crashed"
+	"github.com/younwookim/mg/internal/application/viewport"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
 )
 
+// colorLetterbox fills the window area outside the scaled screen
+var colorLetterbox = color.Black
+
 // Game implements ebiten.Game and manages Scene transitions.
+//
+// Scenes render at the fixed logical resolution into an offscreen image,
+// which is scaled to the window with integer scaling and letterboxing.
 type Game struct {
-	current scene.Scene
-	screenW int
-	screenH int
-	dt      float64
+	current   scene.Scene
+	screenW   int
+	screenH   int
+	dt        float64 // fixed tick length (0 = from the TPS)
+	viewport  *viewport.Viewport
+	offscreen *ebiten.Image
+
+	// onFullscreen is called after F11 toggles fullscreen (nil = ignored)
+	onFullscreen func(fullscreen bool)
+
+	// Crash reports (empty dir = the panic is only logged)
+	crashDir     string
+	crashConfigs map[string]string
+	crashed      bool
 }
 
 // New creates a new Game with the given initial scene.
 // The initial scene's OnEnter is called immediately.
 func New(initialScene scene.Scene, screenW, screenH int) *Game {
 	g := &Game{
-		current: initialScene,
-		screenW: screenW,
-		screenH: screenH,
-		dt:      1.0 / 60.0, // Default to 60 FPS
+		current:  initialScene,
+		screenW:  screenW,
+		screenH:  screenH,
+		viewport: viewport.New(screenW, screenH),
 	}
 	g.current.OnEnter()
 	return g
@@ -30,7 +54,13 @@ func New(initialScene scene.Scene, screenW, screenH int) *Game {
 // Update updates the current scene and handles scene transitions.
 // Implements ebiten.Game interface.
 func (g *Game) Update() error {
-	next, err := g.current.Update(g.dt)
+	defer g.recoverCrash()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		g.toggleFullscreen()
+	}
+
+	next, err := g.current.Update(g.tickDT())
 	if err != nil {
 		return err
 	}
@@ -45,20 +75,124 @@ func (g *Game) Update() error {
 	return nil
 }
 
-// Draw renders the current scene.
+// Draw renders the current scene at the logical resolution and
+// scales it onto the window.
 // Implements ebiten.Game interface.
 func (g *Game) Draw(screen *ebiten.Image) {
-	g.current.Draw(screen)
+	defer g.recoverCrash()
+
+	if g.offscreen == nil {
+		g.offscreen = ebiten.NewImage(g.screenW, g.screenH)
+	}
+	g.offscreen.Clear()
+	g.current.Draw(g.offscreen)
+
+	screen.Fill(colorLetterbox)
+	scale := g.viewport.Scale()
+	x, y := g.viewport.Offset()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	screen.DrawImage(g.offscreen, op)
 }
 
-// Layout returns the game's logical screen dimensions.
+// Layout uses the full window size so the scale factor is computed
+// from the actual window instead of the startup display scale.
 // Implements ebiten.Game interface.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return g.screenW, g.screenH
+	g.viewport.Resize(outsideWidth, outsideHeight)
+	return outsideWidth, outsideHeight
+}
+
+// Viewport returns the logical → window mapping (for cursor conversion)
+func (g *Game) Viewport() *viewport.Viewport {
+	return g.viewport
+}
+
+// SetOnFullscreen registers a callback for F11 fullscreen toggles
+// (e.g. to persist the setting).
+func (g *Game) SetOnFullscreen(fn func(fullscreen bool)) {
+	g.onFullscreen = fn
+}
+
+// SetCrashReports sets the folder crash reports are written to and the
+// config versions they record
+func (g *Game) SetCrashReports(dir string, configs map[string]string) {
+	g.crashDir = dir
+	g.crashConfigs = configs
+}
+
+// recoverCrash turns a panic in the current scene into a crash report and
+// switches to the error screen. A panic on the error screen is not recovered.
+func (g *Game) recoverCrash() {
+	if g.crashed {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+	g.crashed = true
+	stack := debug.Stack()
+	logging.Game.Errorf("Panic: %v\n%s", r, stack)
+
+	folder := ""
+	if g.crashDir != "" {
+		report := crash.NewReport(r, stack, g.crashConfigs)
+		report.Attach("log", logging.Default().Entries())
+		if src, ok := g.current.(crash.Source); ok {
+			collectCrashState(src, report)
+		}
+		var err error
+		if folder, err = report.Write(g.crashDir); err != nil {
+			logging.Game.Errorf("Failed to write crash report: %v", err)
+		} else {
+			logging.Game.Infof("Crash report saved: %s", folder)
+		}
+	}
+
+	g.current = crashed.New(g.screenW, g.screenH, folder)
+	g.current.OnEnter()
+}
+
+// collectCrashState lets the crashed scene add its state to the report.
+// The state may be what broke, so a second panic only loses the attachments.
+func collectCrashState(src crash.Source, r *crash.Report) {
+	defer func() {
+		if err := recover(); err != nil {
+			logging.Game.Errorf("Failed to collect crash state: %v", err)
+		}
+	}()
+	src.CrashReport(r)
 }
 
-// SetDT sets the delta time used for updates.
-// Useful for testing or custom frame rates.
+// toggleFullscreen switches between windowed and fullscreen
+func (g *Game) toggleFullscreen() {
+	fullscreen := !ebiten.IsFullscreen()
+	ebiten.SetFullscreen(fullscreen)
+	if g.onFullscreen != nil {
+		g.onFullscreen(fullscreen)
+	}
+}
+
+// SetDT sets a fixed delta time for updates instead of one derived from
+// the TPS (0 = derive again). Useful for testing.
 func (g *Game) SetDT(dt float64) {
 	g.dt = dt
 }
+
+// tickDT returns the length of this tick in seconds: the SetDT override, or
+// 1/TPS (the measured TPS while synced with the display)
+func (g *Game) tickDT() float64 {
+	if g.dt > 0 {
+		return g.dt
+	}
+	tps := float64(ebiten.TPS())
+	if tps <= 0 {
+		tps = ebiten.ActualTPS()
+	}
+	if tps <= 0 {
+		return 1.0 / 60.0
+	}
+	return 1 / tps
+}
@@ -1,11 +1,16 @@
 package game
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/younwookim/mg/internal/application/crash"
 	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/scene/crashed"
 )
 
 // mockScene is a test double for Scene interface
@@ -67,9 +72,14 @@ func TestGame_Layout(t *testing.T) {
 	mockInitial := &mockScene{}
 	g := New(mockInitial, 320, 240)
 
-	w, h := g.Layout(640, 480)
-	assert.Equal(t, 320, w)
-	assert.Equal(t, 240, h)
+	w, h := g.Layout(1920, 1080)
+	assert.Equal(t, 1920, w, "Layout uses the full window size")
+	assert.Equal(t, 1080, h)
+
+	assert.Equal(t, 4.0, g.Viewport().Scale(), "largest integer scale that fits")
+	x, y := g.Viewport().Offset()
+	assert.Equal(t, 320.0, x, "letterboxed horizontally")
+	assert.Equal(t, 60.0, y)
 }
 
 func TestGame_SceneTransition(t *testing.T) {
@@ -119,3 +129,34 @@ func TestGame_UpdateError(t *testing.T) {
 	err := g.Update()
 	assert.Error(t, err, "Error should propagate from scene")
 }
+
+// panicScene panics on Update and adds a marker to crash reports
+type panicScene struct {
+	mockScene
+}
+
+func (p *panicScene) Update(dt float64) (scene.Scene, error) {
+	panic("physics desync")
+}
+
+func (p *panicScene) CrashReport(r *crash.Report) {
+	r.Attach("world", map[string]int{"entities": 3})
+}
+
+func TestGame_PanicWritesCrashReport(t *testing.T) {
+	dir := t.TempDir()
+	g := New(&panicScene{}, 320, 240)
+	g.SetCrashReports(dir, map[string]string{"physics.json": "abc"})
+
+	err := g.Update()
+	assert.NoError(t, err, "the panic is recovered")
+	assert.IsType(t, &crashed.Screen{}, g.current, "the error screen replaces the scene")
+
+	reports, err := filepath.Glob(filepath.Join(dir, "crash_*", "world.json"))
+	require.NoError(t, err)
+	assert.Len(t, reports, 1)
+
+	log, err := os.ReadFile(filepath.Join(filepath.Dir(reports[0]), "crash.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "panic: physics desync")
+}
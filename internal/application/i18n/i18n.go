@@ -0,0 +1,98 @@
+// Package i18n provides localized UI strings.
+//
+// String tables are loaded from config (configs/locales/<lang>.json) and
+// registered at startup. Draw code looks strings up with T/Tf; a key missing
+// from the current language falls back to English, then to the key itself,
+// so an incomplete translation never shows an empty label.
+package i18n
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Supported languages, in menu order
+const (
+	LangEnglish  = "en"
+	LangKorean   = "ko"
+	LangJapanese = "ja"
+
+	DefaultLanguage = LangEnglish
+)
+
+// Languages lists the supported languages in menu order
+var Languages = []string{LangEnglish, LangKorean, LangJapanese}
+
+// Table maps string keys to localized text
+type Table map[string]string
+
+var (
+	mu      sync.RWMutex
+	tables  = map[string]Table{}
+	current = DefaultLanguage
+)
+
+// Register adds (or replaces) the string table for a language
+func Register(lang string, table Table) {
+	mu.Lock()
+	defer mu.Unlock()
+	tables[lang] = table
+}
+
+// SetLanguage switches the current language.
+// Returns false (and keeps the current language) if no table is registered.
+func SetLanguage(lang string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := tables[lang]; !ok {
+		return false
+	}
+	current = lang
+	return true
+}
+
+// Language returns the current language
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Available returns the registered languages in menu order
+func Available() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	var langs []string
+	for _, lang := range Languages {
+		if _, ok := tables[lang]; ok {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// T returns the localized string for key
+func T(key string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if s, ok := tables[current][key]; ok {
+		return s
+	}
+	if s, ok := tables[DefaultLanguage][key]; ok {
+		return s
+	}
+	return key
+}
+
+// Tf returns the localized format string for key, formatted with args
+func Tf(key string, args ...any) string {
+	return fmt.Sprintf(T(key), args...)
+}
+
+// Reset clears all tables and restores the default language (for tests)
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	tables = map[string]Table{}
+	current = DefaultLanguage
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T) {
+	t.Helper()
+	Reset()
+	t.Cleanup(Reset)
+	Register(LangEnglish, Table{"hello": "Hello", "gold": "Gold: %d", "onlyEn": "English only"})
+	Register(LangKorean, Table{"hello": "안녕하세요", "gold": "골드: %d"})
+}
+
+func TestT_CurrentLanguage(t *testing.T) {
+	setup(t)
+
+	assert.Equal(t, "Hello", T("hello"))
+
+	assert.True(t, SetLanguage(LangKorean))
+	assert.Equal(t, LangKorean, Language())
+	assert.Equal(t, "안녕하세요", T("hello"))
+	assert.Equal(t, "골드: 5", Tf("gold", 5))
+}
+
+func TestT_Fallback(t *testing.T) {
+	setup(t)
+	SetLanguage(LangKorean)
+
+	assert.Equal(t, "English only", T("onlyEn"), "missing key falls back to English")
+	assert.Equal(t, "missing.key", T("missing.key"), "unknown key returns the key")
+}
+
+func TestSetLanguage_Unregistered(t *testing.T) {
+	setup(t)
+
+	assert.False(t, SetLanguage(LangJapanese))
+	assert.Equal(t, LangEnglish, Language(), "language unchanged")
+}
+
+func TestAvailable_MenuOrder(t *testing.T) {
+	setup(t)
+	Register(LangJapanese, Table{})
+
+	assert.Equal(t, []string{LangEnglish, LangKorean, LangJapanese}, Available())
+}
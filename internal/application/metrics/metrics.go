@@ -0,0 +1,201 @@
+// Package metrics records per-system update times for performance profiling.
+//
+// A Recorder keeps the last N frames in a ring buffer so frame spikes can be
+// inspected on screen (graph) or exported to CSV for offline analysis.
+// All Recorder methods are safe to call on a nil *Recorder, so callers can
+// leave timing calls in place and simply pass nil when metrics are disabled.
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// System identifies a measured game system
+type System int
+
+const (
+	PlayerPhysics System = iota
+	EnemyAI
+	Projectiles
+	Damage
+	Render
+	NumSystems
+)
+
+// String returns the system name (used as CSV column prefix)
+func (s System) String() string {
+	switch s {
+	case PlayerPhysics:
+		return "player_physics"
+	case EnemyAI:
+		return "enemy_ai"
+	case Projectiles:
+		return "projectiles"
+	case Damage:
+		return "damage"
+	case Render:
+		return "render"
+	default:
+		return "unknown"
+	}
+}
+
+// Frame holds the accumulated time spent in each system during one frame
+type Frame struct {
+	Number    int
+	Durations [NumSystems]time.Duration
+}
+
+// Total returns the sum of all system durations
+func (f Frame) Total() time.Duration {
+	var total time.Duration
+	for _, d := range f.Durations {
+		total += d
+	}
+	return total
+}
+
+// DefaultCapacity is the default ring buffer size (4 seconds at 60fps)
+const DefaultCapacity = 240
+
+// Recorder accumulates system timings into a fixed-size ring buffer
+type Recorder struct {
+	frames  []Frame
+	next    int // index of the slot the current frame writes to
+	count   int // number of completed frames stored
+	current Frame
+}
+
+// NewRecorder creates a recorder that keeps the last capacity frames
+func NewRecorder(capacity int) *Recorder {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Recorder{
+		frames: make([]Frame, capacity),
+	}
+}
+
+// Start returns the start time for a measurement (zero if r is nil)
+func (r *Recorder) Start() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// Stop adds the time elapsed since start to the system's total for the current frame.
+// Calling Stop several times per frame (e.g. once per substep) accumulates.
+func (r *Recorder) Stop(sys System, start time.Time) {
+	if r == nil {
+		return
+	}
+	r.current.Durations[sys] += time.Since(start)
+}
+
+// Add adds a duration to the system's total for the current frame
+func (r *Recorder) Add(sys System, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.current.Durations[sys] += d
+}
+
+// EndFrame commits the current frame to the ring buffer and starts a new one
+func (r *Recorder) EndFrame() {
+	if r == nil {
+		return
+	}
+	r.frames[r.next] = r.current
+	r.next = (r.next + 1) % len(r.frames)
+	if r.count < len(r.frames) {
+		r.count++
+	}
+	r.current = Frame{Number: r.current.Number + 1}
+}
+
+// Frames returns the stored frames, oldest first
+func (r *Recorder) Frames() []Frame {
+	if r == nil {
+		return nil
+	}
+	out := make([]Frame, 0, r.count)
+	start := (r.next - r.count + len(r.frames)) % len(r.frames)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.frames[(start+i)%len(r.frames)])
+	}
+	return out
+}
+
+// Average returns the mean duration of a system over the stored frames
+func (r *Recorder) Average(sys System) time.Duration {
+	frames := r.Frames()
+	if len(frames) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, f := range frames {
+		total += f.Durations[sys]
+	}
+	return total / time.Duration(len(frames))
+}
+
+// Peak returns the largest frame total over the stored frames
+func (r *Recorder) Peak() time.Duration {
+	var peak time.Duration
+	for _, f := range r.Frames() {
+		if t := f.Total(); t > peak {
+			peak = t
+		}
+	}
+	return peak
+}
+
+// WriteCSV writes the stored frames as CSV (durations in microseconds)
+func (r *Recorder) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"frame"}
+	for sys := System(0); sys < NumSystems; sys++ {
+		header = append(header, sys.String()+"_us")
+	}
+	header = append(header, "total_us")
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, f := range r.Frames() {
+		row := []string{strconv.Itoa(f.Number)}
+		for _, d := range f.Durations {
+			row = append(row, strconv.FormatInt(d.Microseconds(), 10))
+		}
+		row = append(row, strconv.FormatInt(f.Total().Microseconds(), 10))
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write frame %d: %w", f.Number, err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// SaveCSV writes the stored frames to a CSV file
+func (r *Recorder) SaveCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return r.WriteCSV(file)
+}
+
+// GenerateFilename creates a CSV filename based on current time
+func GenerateFilename() string {
+	return fmt.Sprintf("metrics_%s.csv", time.Now().Format("20060102_150405"))
+}
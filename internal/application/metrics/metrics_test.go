@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_NilIsNoop(t *testing.T) {
+	var r *Recorder
+
+	assert.NotPanics(t, func() {
+		start := r.Start()
+		r.Stop(PlayerPhysics, start)
+		r.Add(Render, time.Millisecond)
+		r.EndFrame()
+	})
+	assert.Nil(t, r.Frames())
+	assert.Equal(t, time.Duration(0), r.Average(Render))
+}
+
+func TestRecorder_AccumulatesPerFrame(t *testing.T) {
+	r := NewRecorder(4)
+
+	r.Add(EnemyAI, 2*time.Millisecond)
+	r.Add(EnemyAI, 3*time.Millisecond)
+	r.Add(Render, time.Millisecond)
+	r.EndFrame()
+
+	frames := r.Frames()
+	require.Len(t, frames, 1)
+	assert.Equal(t, 5*time.Millisecond, frames[0].Durations[EnemyAI])
+	assert.Equal(t, 6*time.Millisecond, frames[0].Total())
+}
+
+func TestRecorder_RingBufferWraps(t *testing.T) {
+	r := NewRecorder(3)
+
+	for i := 1; i <= 5; i++ {
+		r.Add(Damage, time.Duration(i)*time.Millisecond)
+		r.EndFrame()
+	}
+
+	frames := r.Frames()
+	require.Len(t, frames, 3, "only the last 3 frames are kept")
+	assert.Equal(t, 2, frames[0].Number, "oldest first")
+	assert.Equal(t, 4, frames[2].Number)
+	assert.Equal(t, 5*time.Millisecond, frames[2].Durations[Damage])
+	assert.Equal(t, 4*time.Millisecond, r.Average(Damage))
+	assert.Equal(t, 5*time.Millisecond, r.Peak())
+}
+
+func TestRecorder_WriteCSV(t *testing.T) {
+	r := NewRecorder(8)
+	r.Add(PlayerPhysics, 150*time.Microsecond)
+	r.EndFrame()
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "frame,player_physics_us,enemy_ai_us,projectiles_us,damage_us,render_us,total_us", lines[0])
+	assert.Equal(t, "0,150,0,0,0,0,150", lines[1])
+}
@@ -1,5 +1,19 @@
 package replay
 
+// FormatVersion is the current replay file format. Replays in another
+// format cannot be played back.
+const FormatVersion = "1.0"
+
+// Compatibility is whether a replay plays back correctly in this build
+type Compatibility int
+
+const (
+	Compatible   Compatibility = iota // same content hash
+	Unversioned                       // recorded without a content hash or run settings (may desync)
+	Mismatched                        // recorded with different content (will likely desync)
+	Incompatible                      // unknown file format
+)
+
 // FrameInput records input state for a single frame
 type FrameInput struct {
 	F   int  `json:"f"`             // Frame number
@@ -11,11 +25,23 @@ type FrameInput struct {
 	JP  bool `json:"jp,omitempty"`  // JumpPressed
 	JR  bool `json:"jr,omitempty"`  // JumpReleased
 	Dsh bool `json:"dsh,omitempty"` // Dash
+	Blk bool `json:"blk,omitempty"` // Block (held)
+	Sum bool `json:"sum,omitempty"` // Summon
+	Int bool `json:"int,omitempty"` // Interact (talk, shop)
 	MX  int  `json:"mx"`            // MouseX
 	MY  int  `json:"my"`            // MouseY
 	MC  bool `json:"mc,omitempty"`  // MouseClick
 	RCP bool `json:"rcp,omitempty"` // RightClickPressed
 	RCR bool `json:"rcr,omitempty"` // RightClickReleased
+
+	// Arrow select wheel by number key and gamepad stick
+	Num int     `json:"num,omitempty"` // arrow slot number key (1-based, 0 = none)
+	SX  float64 `json:"sx,omitempty"`  // right stick X
+	SY  float64 `json:"sy,omitempty"`  // right stick Y
+
+	Gr int  `json:"gr,omitempty"` // lag compensation frames added to the jump windows
+	GS int  `json:"gs,omitempty"` // game speed setting in percent (0 = normal)
+	AA bool `json:"aa,omitempty"` // aim assist on
 }
 
 // ReplayData contains all data needed to replay a game session
@@ -25,4 +51,39 @@ type ReplayData struct {
 	Stage     string       `json:"stage"`
 	StartTime string       `json:"startTime"`
 	Frames    []FrameInput `json:"frames"`
+
+	// Metadata for the replay browser (older replays leave it empty)
+	Player      string `json:"player,omitempty"`      // leaderboard initials
+	Duration    int    `json:"duration,omitempty"`    // frames
+	Gold        int    `json:"gold,omitempty"`        // final gold
+	VersionHash string `json:"versionHash,omitempty"` // see VersionHash
+
+	// Settings the run was played with that change how it plays
+	Difficulty string `json:"difficulty,omitempty"` // difficulty profile ("" = config default)
+	Character  string `json:"character,omitempty"`  // character ID ("" = first configured)
+}
+
+// Compatibility compares the replay with the current build's content hash
+// (see VersionHash). The hash covers the content only, so a replay must
+// also name the character it was played with (always recorded along with
+// the difficulty) to play back for sure.
+func (d *ReplayData) Compatibility(versionHash string) Compatibility {
+	switch {
+	case d.Version != FormatVersion:
+		return Incompatible
+	case d.VersionHash == "" || d.Character == "":
+		return Unversioned
+	case d.VersionHash != versionHash:
+		return Mismatched
+	}
+	return Compatible
+}
+
+// FrameCount returns the replay's length in frames (Duration, or the
+// recorded frames for replays without metadata)
+func (d *ReplayData) FrameCount() int {
+	if d.Duration > 0 {
+		return d.Duration
+	}
+	return len(d.Frames)
 }
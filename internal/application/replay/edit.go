@@ -0,0 +1,190 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Field is an input that can be edited over a range of frames (TAS tools)
+type Field int
+
+const (
+	FieldLeft Field = iota
+	FieldRight
+	FieldUp
+	FieldDown
+	FieldJump // held; the press and release edges follow it
+	FieldDash
+	FieldBlock
+	FieldAttack
+	FieldCount
+)
+
+// fieldNames label the fields in the editor's timeline
+var fieldNames = [FieldCount]string{"L", "R", "U", "D", "J", "Dsh", "Blk", "Atk"}
+
+// String returns the field's short label
+func (f Field) String() string {
+	if f < 0 || f >= FieldCount {
+		return "?"
+	}
+	return fieldNames[f]
+}
+
+// flag returns the frame's bool for a field (jump excluded: see JumpHeld)
+func (fi *FrameInput) flag(f Field) *bool {
+	switch f {
+	case FieldLeft:
+		return &fi.L
+	case FieldRight:
+		return &fi.R
+	case FieldUp:
+		return &fi.U
+	case FieldDown:
+		return &fi.D
+	case FieldDash:
+		return &fi.Dsh
+	case FieldBlock:
+		return &fi.Blk
+	case FieldAttack:
+		return &fi.MC
+	}
+	return nil
+}
+
+// Input converts a recorded frame to playback input
+func (fi FrameInput) Input() ReplayInput {
+	return ReplayInput{
+		Left:               fi.L,
+		Right:              fi.R,
+		Up:                 fi.U,
+		Down:               fi.D,
+		Jump:               fi.J,
+		JumpPressed:        fi.JP,
+		JumpReleased:       fi.JR,
+		Dash:               fi.Dsh,
+		Block:              fi.Blk,
+		Summon:             fi.Sum,
+		Interact:           fi.Int,
+		MouseX:             fi.MX,
+		MouseY:             fi.MY,
+		MouseClick:         fi.MC,
+		RightClickPressed:  fi.RCP,
+		RightClickReleased: fi.RCR,
+		ArrowNumber:        fi.Num,
+		StickX:             fi.SX,
+		StickY:             fi.SY,
+		GraceFrames:        fi.Gr,
+		GameSpeedPct:       fi.GS,
+		AimAssist:          fi.AA,
+	}
+}
+
+// Has reports whether field f is set on frame i (see JumpHeld to check
+// the jump on many frames)
+func (d *ReplayData) Has(i int, f Field) bool {
+	if f == FieldJump {
+		return d.JumpHeld()[i]
+	}
+	return *d.Frames[i].flag(f)
+}
+
+// JumpHeld returns whether the jump key is down on each frame, following
+// the recorded press and release edges
+func (d *ReplayData) JumpHeld() []bool {
+	held := make([]bool, len(d.Frames))
+	prev := false
+	for i, fi := range d.Frames {
+		held[i] = fi.JP || (prev && !fi.JR)
+		prev = held[i]
+	}
+	return held
+}
+
+// Toggle sets field f on frames lo..hi (inclusive), or clears it if every
+// one of them has it already. Editing the held jump rewrites the press and
+// release edges around the range.
+func (d *ReplayData) Toggle(lo, hi int, f Field) {
+	lo, hi = max(lo, 0), min(hi, len(d.Frames)-1)
+	if lo > hi {
+		return
+	}
+	if f != FieldJump {
+		on := false
+		for i := lo; i <= hi; i++ {
+			on = on || !*d.Frames[i].flag(f)
+		}
+		for i := lo; i <= hi; i++ {
+			*d.Frames[i].flag(f) = on
+		}
+		return
+	}
+
+	held := d.JumpHeld()
+	on := false
+	for i := lo; i <= hi; i++ {
+		on = on || !held[i]
+	}
+	for i := lo; i <= hi; i++ {
+		held[i] = on
+	}
+	// Frame hi+1 keeps its own state, so its edge may change too
+	for i := lo; i <= min(hi+1, len(d.Frames)-1); i++ {
+		prev := i > 0 && held[i-1]
+		fi := &d.Frames[i]
+		fi.J = held[i]
+		fi.JP = held[i] && !prev
+		fi.JR = !held[i] && prev
+	}
+}
+
+// Insert inserts a copy of frame i before it (one-shot presses are not
+// copied) and renumbers the frames
+func (d *ReplayData) Insert(i int) {
+	if i < 0 || i >= len(d.Frames) {
+		return
+	}
+	fi := d.Frames[i]
+	fi.JP, fi.JR, fi.Dsh, fi.Sum, fi.Int = false, false, false, false, false
+	fi.MC, fi.RCP, fi.RCR, fi.Num = false, false, false, 0
+	d.Frames = append(d.Frames[:i], append([]FrameInput{fi}, d.Frames[i:]...)...)
+	d.renumber(i)
+}
+
+// Delete removes frame i and renumbers the frames
+func (d *ReplayData) Delete(i int) {
+	if i < 0 || i >= len(d.Frames) {
+		return
+	}
+	d.Frames = append(d.Frames[:i], d.Frames[i+1:]...)
+	d.renumber(i)
+}
+
+// renumber fixes the frame numbers from i on
+func (d *ReplayData) renumber(i int) {
+	for ; i < len(d.Frames); i++ {
+		d.Frames[i].F = i
+	}
+}
+
+// Save writes the replay to a file (Duration is updated first)
+func (d *ReplayData) Save(filename string) error {
+	if len(d.Frames) == 0 {
+		return fmt.Errorf("no frames to save")
+	}
+	d.Duration = len(d.Frames)
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(d); err != nil {
+		return fmt.Errorf("failed to encode replay: %w", err)
+	}
+	return nil
+}
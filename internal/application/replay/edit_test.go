@@ -0,0 +1,79 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayData_ToggleRange(t *testing.T) {
+	data := CreateTestReplayData(5, 0, 0)
+	data.Frames[2].R = true
+
+	data.Toggle(1, 3, FieldRight)
+	for i := 1; i <= 3; i++ {
+		assert.True(t, data.Has(i, FieldRight), "set where any frame lacked it")
+	}
+	data.Toggle(1, 3, FieldRight)
+	assert.False(t, data.Has(2, FieldRight), "cleared when all had it")
+	assert.False(t, data.Has(0, FieldRight))
+
+	data.Toggle(3, 10, FieldDash)
+	assert.True(t, data.Frames[4].Dsh, "clamped to the replay")
+}
+
+func TestReplayData_ToggleJumpRewritesEdges(t *testing.T) {
+	data := CreateTestReplayData(6, 0, 0)
+	data.Toggle(1, 3, FieldJump)
+	assert.Equal(t, []bool{false, true, true, true, false, false}, data.JumpHeld())
+	assert.True(t, data.Frames[1].JP)
+	assert.True(t, data.Frames[4].JR, "released after the range")
+
+	data.Toggle(2, 2, FieldJump)
+	assert.Equal(t, []bool{false, true, false, true, false, false}, data.JumpHeld(), "split into two presses")
+	assert.True(t, data.Frames[2].JR)
+	assert.True(t, data.Frames[3].JP)
+}
+
+func TestReplayData_InsertAndDelete(t *testing.T) {
+	data := CreateTestReplayData(3, 0, 0)
+	data.Frames[1].R = true
+	data.Frames[1].Dsh = true
+
+	data.Insert(1)
+	require.Len(t, data.Frames, 4)
+	assert.True(t, data.Frames[1].R, "held inputs are copied")
+	assert.False(t, data.Frames[1].Dsh, "presses are not")
+	assert.Equal(t, 3, data.Frames[3].F, "renumbered")
+
+	data.Delete(0)
+	require.Len(t, data.Frames, 3)
+	assert.Equal(t, 0, data.Frames[0].F)
+	assert.True(t, data.Frames[0].R)
+}
+
+func TestReplayer_Seek(t *testing.T) {
+	data := CreateTestReplayData(3, 0, 0)
+	data.Frames[2].L = true
+	r := NewReplayer(data)
+	r.Seek(2)
+	in, ok := r.GetInput()
+	require.True(t, ok)
+	assert.True(t, in.Left)
+	r.Seek(10)
+	assert.Equal(t, 3, r.CurrentFrame(), "clamped to the end")
+}
+
+func TestReplayData_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.json")
+	data := CreateTestReplayData(4, 0, 0)
+	require.NoError(t, data.Save(path))
+	loaded, err := LoadReplay(path)
+	require.NoError(t, err)
+	assert.Equal(t, 4, loaded.Duration)
+	assert.Len(t, loaded.Frames, 4)
+
+	assert.Error(t, (&ReplayData{}).Save(path), "nothing to save")
+}
@@ -0,0 +1,181 @@
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry is a saved replay in a replay directory. Frames are not kept;
+// LoadReplay(Path) reads the whole replay for playback.
+type Entry struct {
+	Path    string
+	ModTime time.Time
+	Data    ReplayData
+}
+
+// SortKey orders a replay listing
+type SortKey int
+
+const (
+	SortNewest   SortKey = iota // most recently saved first
+	SortStage                   // by stage, then newest
+	SortDuration                // longest first
+	SortGold                    // most gold first
+	sortKeyCount
+)
+
+// Next returns the following sort key, wrapping around
+func (k SortKey) Next() SortKey {
+	return (k + 1) % sortKeyCount
+}
+
+// String returns the key's name (e.g. for a locale key)
+func (k SortKey) String() string {
+	switch k {
+	case SortStage:
+		return "stage"
+	case SortDuration:
+		return "duration"
+	case SortGold:
+		return "gold"
+	default:
+		return "newest"
+	}
+}
+
+// DefaultDir returns the replay folder in the user config directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config dir: %w", err)
+	}
+	return filepath.Join(dir, "mg", "replays"), nil
+}
+
+// List reads the metadata of every replay in dir, newest first. A missing
+// directory is empty; unreadable replays are skipped.
+func List(dir string) ([]Entry, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replays: %w", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		data, err := LoadReplay(path)
+		if err != nil {
+			continue
+		}
+		data.Duration = data.FrameCount()
+		data.Frames = nil
+		entries = append(entries, Entry{Path: path, ModTime: info.ModTime(), Data: *data})
+	}
+	Sort(entries, SortNewest)
+	return entries, nil
+}
+
+// Sort orders entries by key (ties go to the newest)
+func Sort(entries []Entry, key SortKey) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch key {
+		case SortStage:
+			if a.Data.Stage != b.Data.Stage {
+				return a.Data.Stage < b.Data.Stage
+			}
+		case SortDuration:
+			if a.Data.Duration != b.Data.Duration {
+				return a.Data.Duration > b.Data.Duration
+			}
+		case SortGold:
+			if a.Data.Gold != b.Data.Gold {
+				return a.Data.Gold > b.Data.Gold
+			}
+		}
+		return a.ModTime.After(b.ModTime)
+	})
+}
+
+// Delete removes a saved replay
+func Delete(e Entry) error {
+	if err := os.Remove(e.Path); err != nil {
+		return fmt.Errorf("failed to delete replay: %w", err)
+	}
+	return nil
+}
+
+// Export copies a saved replay into dir (e.g. to share it) and returns
+// the copy's path
+func Export(e Entry, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create export dir: %w", err)
+	}
+	src, err := os.Open(e.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open replay: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	path := filepath.Join(dir, filepath.Base(e.Path))
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return "", fmt.Errorf("failed to export replay: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to export replay: %w", err)
+	}
+	return path, nil
+}
+
+// unhashedDirs hold configs that don't affect the simulation
+var unhashedDirs = []string{"locales/", "replays/"}
+
+// VersionHash combines the game build and per-file content hashes (e.g.
+// crash.BuildVersion and crash.ConfigVersions: physics values, stage
+// layouts, ...) into one short hash. Replays and saves record it, since a
+// config tweak silently desyncs old replays. Translations and the bundled
+// replays themselves are left out.
+func VersionHash(build string, versions map[string]string) string {
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		if !slices.ContainsFunc(unhashedDirs, func(dir string) bool { return strings.HasPrefix(name, dir) }) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(build)
+	b.WriteByte('\n')
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(versions[name])
+		b.WriteByte('\n')
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:6])
+}
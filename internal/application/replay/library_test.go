@@ -0,0 +1,92 @@
+package replay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReplay(t *testing.T, dir, name string, data ReplayData, age time.Duration) {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, raw, 0o644))
+	mod := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, mod, mod))
+}
+
+func TestList_SortsAndSkipsUnreadable(t *testing.T) {
+	dir := t.TempDir()
+	old := CreateTestReplayData(30, 0, 0)
+	old.Gold = 50
+	writeReplay(t, dir, "old.json", old, time.Hour)
+	recent := CreateTestReplayData(10, 0, 0)
+	recent.Stage = "cave"
+	recent.Player = "ABC"
+	writeReplay(t, dir, "recent.json", recent, time.Minute)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644))
+
+	entries, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ABC", entries[0].Data.Player, "newest first")
+	assert.Equal(t, 30, entries[1].Data.Duration, "older replays count their frames")
+	assert.Nil(t, entries[1].Data.Frames, "frames are not kept for the listing")
+
+	Sort(entries, SortGold)
+	assert.Equal(t, 50, entries[0].Data.Gold)
+	Sort(entries, SortStage)
+	assert.Equal(t, "cave", entries[0].Data.Stage)
+	assert.Equal(t, SortNewest, SortGold.Next(), "wraps around")
+
+	missing, err := List(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestExportAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	writeReplay(t, dir, "run.json", CreateTestReplayData(5, 0, 0), 0)
+	entries, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	path, err := Export(entries[0], filepath.Join(dir, "exports"))
+	require.NoError(t, err)
+	exported, err := LoadReplay(path)
+	require.NoError(t, err)
+	assert.Len(t, exported.Frames, 5)
+
+	require.NoError(t, Delete(entries[0]))
+	entries, err = List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the exports folder is not listed")
+	assert.Error(t, Delete(Entry{Path: filepath.Join(dir, "run.json")}))
+}
+
+func TestVersionHash(t *testing.T) {
+	a := VersionHash("v1", map[string]string{"physics.json": "aa", "stages/demo.json": "bb"})
+	assert.Len(t, a, 12)
+	assert.Equal(t, a, VersionHash("v1", map[string]string{"stages/demo.json": "bb", "physics.json": "aa", "locales/en.json": "cc"}),
+		"order and translations don't matter")
+	assert.NotEqual(t, a, VersionHash("v1", map[string]string{"physics.json": "ab", "stages/demo.json": "bb"}))
+	assert.NotEqual(t, a, VersionHash("v2", map[string]string{"physics.json": "aa", "stages/demo.json": "bb"}))
+}
+
+func TestReplayData_Compatibility(t *testing.T) {
+	data := CreateTestReplayData(1, 0, 0)
+	assert.Equal(t, Unversioned, data.Compatibility("abc"))
+	data.VersionHash = "abc"
+	assert.Equal(t, Unversioned, data.Compatibility("abc"), "without the run settings")
+	data.Character = "player"
+	assert.Equal(t, Compatible, data.Compatibility("abc"))
+	assert.Equal(t, Mismatched, data.Compatibility("def"))
+	data.Version = "2.0"
+	assert.Equal(t, Incompatible, data.Compatibility("abc"))
+}
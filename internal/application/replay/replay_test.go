@@ -2,6 +2,7 @@ package replay
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -180,11 +181,18 @@ func TestReplayer_ReturnsCorrectInputState(t *testing.T) {
 				JP:  true,
 				JR:  true,
 				Dsh: true,
+				Int: true,
 				MX:  123,
 				MY:  456,
 				MC:  true,
 				RCP: true,
 				RCR: true,
+				Num: 3,
+				SX:  0.5,
+				SY:  -0.25,
+				Gr:  2,
+				GS:  70,
+				AA:  true,
 			},
 		},
 	}
@@ -201,9 +209,28 @@ func TestReplayer_ReturnsCorrectInputState(t *testing.T) {
 	assert.True(t, input.JumpPressed)
 	assert.True(t, input.JumpReleased)
 	assert.True(t, input.Dash)
+	assert.True(t, input.Interact)
 	assert.Equal(t, 123, input.MouseX)
 	assert.Equal(t, 456, input.MouseY)
 	assert.True(t, input.MouseClick)
 	assert.True(t, input.RightClickPressed)
 	assert.True(t, input.RightClickReleased)
+	assert.Equal(t, 3, input.ArrowNumber)
+	assert.Equal(t, 0.5, input.StickX)
+	assert.Equal(t, -0.25, input.StickY)
+	assert.Equal(t, 2, input.GraceFrames)
+	assert.Equal(t, 70, input.GameSpeedPct)
+	assert.True(t, input.AimAssist)
+}
+
+func TestDecode(t *testing.T) {
+	data, err := Decode(strings.NewReader(`{"version":"1.0","seed":7,"stage":"demo","frames":[{"f":0,"r":true,"mx":1,"my":2}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), data.Seed)
+	assert.Equal(t, "demo", data.Stage)
+	require.Len(t, data.Frames, 1)
+	assert.True(t, data.Frames[0].R)
+
+	_, err = Decode(strings.NewReader("not json"))
+	assert.Error(t, err)
 }
@@ -3,6 +3,7 @@ package replay
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"time"
 )
@@ -17,11 +18,19 @@ type ReplayInput struct {
 	JumpPressed        bool
 	JumpReleased       bool
 	Dash               bool
+	Block              bool
+	Summon             bool
+	Interact           bool
 	MouseX             int
 	MouseY             int
 	MouseClick         bool
 	RightClickPressed  bool
 	RightClickReleased bool
+	ArrowNumber        int     // arrow slot number key (0 = none)
+	StickX, StickY     float64 // gamepad right stick
+	GraceFrames        int     // lag compensation of the jump windows
+	GameSpeedPct       int     // game speed setting (0 = normal)
+	AimAssist          bool    // aim assist setting
 }
 
 // Replayer handles input playback from recorded data
@@ -46,8 +55,13 @@ func LoadReplay(filename string) (*ReplayData, error) {
 	}
 	defer func() { _ = file.Close() }()
 
+	return Decode(file)
+}
+
+// Decode reads replay data (e.g. a replay bundled with the game)
+func Decode(r io.Reader) (*ReplayData, error) {
 	var data ReplayData
-	decoder := json.NewDecoder(file)
+	decoder := json.NewDecoder(r)
 	if err := decoder.Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode replay: %w", err)
 	}
@@ -64,21 +78,12 @@ func (r *Replayer) GetInput() (ReplayInput, bool) {
 	fi := r.data.Frames[r.frame]
 	r.frame++
 
-	return ReplayInput{
-		Left:               fi.L,
-		Right:              fi.R,
-		Up:                 fi.U,
-		Down:               fi.D,
-		Jump:               fi.J,
-		JumpPressed:        fi.JP,
-		JumpReleased:       fi.JR,
-		Dash:               fi.Dsh,
-		MouseX:             fi.MX,
-		MouseY:             fi.MY,
-		MouseClick:         fi.MC,
-		RightClickPressed:  fi.RCP,
-		RightClickReleased: fi.RCR,
-	}, true
+	return fi.Input(), true
+}
+
+// Seek moves playback to frame (e.g. after restoring a snapshot of it)
+func (r *Replayer) Seek(frame int) {
+	r.frame = max(0, min(frame, len(r.data.Frames)))
 }
 
 // CurrentFrame returns the current frame number
@@ -104,7 +109,7 @@ func (r *Replayer) Reset() {
 // CreateTestReplayData creates replay data for testing (idle player)
 func CreateTestReplayData(frames int, mouseX, mouseY int) ReplayData {
 	data := ReplayData{
-		Version:   "1.0",
+		Version:   FormatVersion,
 		Seed:      12345,
 		Stage:     "test",
 		StartTime: time.Now().Format(time.RFC3339),
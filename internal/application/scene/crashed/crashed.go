@@ -0,0 +1,62 @@
+// Package crashed provides the error screen shown after the game recovers
+// from a panic.
+package crashed
+
+import (
+	"image/color"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+)
+
+var colorBG = color.RGBA{32, 16, 16, 255}
+
+// Screen apologizes for the crash and says where the report went
+type Screen struct {
+	screenW, screenH int
+	folder           string // crash report folder ("" = the report failed)
+}
+
+// New creates the error screen for a report saved to folder
+// ("" when it could not be saved). Enter or ESC quits.
+func New(screenW, screenH int, folder string) *Screen {
+	return &Screen{
+		screenW: screenW,
+		screenH: screenH,
+		folder:  folder,
+	}
+}
+
+// Update quits on Enter or ESC (implements scene.Scene)
+func (s *Screen) Update(_ float64) (scene.Scene, error) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return nil, ebiten.Termination
+	}
+	return nil, nil
+}
+
+// Draw renders the apology and the report location (implements scene.Scene)
+func (s *Screen) Draw(screen *ebiten.Image) {
+	screen.Fill(colorBG)
+	cx := float64(s.screenW / 2)
+
+	ui.Draw(screen, i18n.T("crash.title"), cx, 48, ui.StyleTitle)
+	if s.folder != "" {
+		ui.Draw(screen, i18n.T("crash.saved"), cx, 96, ui.StyleCenter)
+		ui.Draw(screen, filepath.Base(s.folder), cx, 112, ui.StyleCenter)
+	} else {
+		ui.Draw(screen, i18n.T("crash.notSaved"), cx, 96, ui.StyleCenter)
+	}
+
+	ui.Draw(screen, i18n.T("crash.help"), cx, float64(s.screenH-18), ui.StyleCenter)
+}
+
+// OnEnter is called when entering this scene
+func (s *Screen) OnEnter() {}
+
+// OnExit is called when leaving this scene
+func (s *Screen) OnExit() {}
@@ -0,0 +1,35 @@
+package scene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Scratch buffers for AnyInput
+var (
+	anyKeys     []ebiten.Key
+	anyGamepads []ebiten.GamepadID
+	anyButtons  []ebiten.GamepadButton
+)
+
+// AnyInput reports whether any key, mouse button or gamepad button was
+// just pressed (e.g. to leave a demo or skip a screen)
+func AnyInput() bool {
+	anyKeys = inpututil.AppendJustPressedKeys(anyKeys[:0])
+	if len(anyKeys) > 0 {
+		return true
+	}
+	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
+		if inpututil.IsMouseButtonJustPressed(b) {
+			return true
+		}
+	}
+	anyGamepads = ebiten.AppendGamepadIDs(anyGamepads[:0])
+	for _, id := range anyGamepads {
+		anyButtons = inpututil.AppendJustPressedGamepadButtons(id, anyButtons[:0])
+		if len(anyButtons) > 0 {
+			return true
+		}
+	}
+	return false
+}
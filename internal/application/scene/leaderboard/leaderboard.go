@@ -0,0 +1,78 @@
+// Package leaderboard provides the scene that shows the local top scores.
+package leaderboard
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+var colorBG = color.RGBA{16, 16, 32, 255}
+
+// Board shows the top scores of one stage at a time
+type Board struct {
+	board            *save.Leaderboard
+	stages           []*config.StageConfig
+	screenW, screenH int
+	back             scene.Scene
+	current          int // index into stages
+}
+
+// New creates the leaderboard scene. Left/Right switch between stages
+// and ESC returns to back.
+func New(lb *save.Leaderboard, stages []*config.StageConfig, screenW, screenH int, back scene.Scene) *Board {
+	return &Board{
+		board:   lb,
+		stages:  stages,
+		screenW: screenW,
+		screenH: screenH,
+		back:    back,
+	}
+}
+
+// Update handles stage switching and leaving (implements scene.Scene)
+func (b *Board) Update(_ float64) (scene.Scene, error) {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape), inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		return b.back, nil
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft) && len(b.stages) > 0:
+		b.current = (b.current + len(b.stages) - 1) % len(b.stages)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight) && len(b.stages) > 0:
+		b.current = (b.current + 1) % len(b.stages)
+	}
+	return nil, nil
+}
+
+// Draw renders the selected stage's table (implements scene.Scene)
+func (b *Board) Draw(screen *ebiten.Image) {
+	screen.Fill(colorBG)
+	cx := float64(b.screenW / 2)
+
+	ui.Draw(screen, i18n.T("leaderboard.title"), cx, 8, ui.StyleTitle)
+
+	var top []save.ScoreEntry
+	if len(b.stages) > 0 {
+		stage := b.stages[b.current]
+		ui.Draw(screen, stage.Name, cx, 36, ui.StyleCenter)
+		top = b.board.Top(stage.ID)
+	}
+	if len(top) > 0 {
+		ui.DrawScores(screen, top, cx, 56, -1)
+	} else {
+		ui.Draw(screen, i18n.T("leaderboard.empty"), cx, 56, ui.StyleCenter)
+	}
+
+	ui.Draw(screen, i18n.T("leaderboard.help"), cx, float64(b.screenH-18), ui.StyleCenter)
+}
+
+// OnEnter is called when entering this scene
+func (b *Board) OnEnter() {}
+
+// OnExit is called when leaving this scene
+func (b *Board) OnExit() {}
@@ -0,0 +1,142 @@
+// Package menu provides the main menu scene.
+//
+// The menu is a vertical list of items; each item opens a scene (play,
+// leaderboard, options) or ends the game. Items with a value (e.g. the
+// character) cycle it with Left/Right. Scenes opened from the menu receive
+// it as their back scene. Left idle, the menu can open an attract mode demo.
+package menu
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+)
+
+// Colors for rendering
+var (
+	colorBG       = color.RGBA{16, 16, 32, 255}
+	colorSelected = color.RGBA{60, 60, 100, 255}
+)
+
+const (
+	lineHeight = 18
+	listTop    = 96 // y of the first row
+	rowWidth   = 180
+)
+
+// Item is one menu entry
+type Item struct {
+	Label  string                      // i18n key
+	Select func() (scene.Scene, error) // scene to open (nil = stay); ebiten.Termination quits
+	Value  func() string               // current value shown after the label (nil = none)
+	Change func(dir int)               // cycles the value by -1/+1 (nil = not adjustable)
+}
+
+// Menu is the main menu scene
+type Menu struct {
+	screenW, screenH int
+	items            []Item
+	cursor           int
+
+	// Attract mode: after idleAfter seconds without input, onIdle opens a
+	// demo (nil = never)
+	idleAfter float64
+	idle      float64
+	onIdle    func() scene.Scene
+}
+
+// New creates the main menu
+func New(screenW, screenH int, items []Item) *Menu {
+	return &Menu{
+		screenW: screenW,
+		screenH: screenH,
+		items:   items,
+	}
+}
+
+// SetIdle opens the scene returned by open (e.g. an attract mode demo)
+// after seconds without input. open may return nil to stay.
+func (m *Menu) SetIdle(seconds float64, open func() scene.Scene) {
+	m.idleAfter = seconds
+	m.onIdle = open
+}
+
+// Update moves the cursor and opens the selected item (implements scene.Scene)
+func (m *Menu) Update(dt float64) (scene.Scene, error) {
+	if next := m.updateIdle(dt); next != nil {
+		return next, nil
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		m.cursor = (m.cursor + len(m.items) - 1) % len(m.items)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		m.cursor = (m.cursor + 1) % len(m.items)
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		m.change(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		m.change(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		return m.items[m.cursor].Select()
+	}
+	return nil, nil
+}
+
+// updateIdle counts the time without input and opens the idle scene once
+// it reaches idleAfter
+func (m *Menu) updateIdle(dt float64) scene.Scene {
+	if m.onIdle == nil {
+		return nil
+	}
+	if scene.AnyInput() {
+		m.idle = 0
+		return nil
+	}
+	m.idle += dt
+	if m.idle < m.idleAfter {
+		return nil
+	}
+	m.idle = 0
+	return m.onIdle()
+}
+
+// change cycles the selected item's value, if it has one
+func (m *Menu) change(dir int) {
+	if it := m.items[m.cursor]; it.Change != nil {
+		it.Change(dir)
+	}
+}
+
+// Draw renders the title and the item list (implements scene.Scene)
+func (m *Menu) Draw(screen *ebiten.Image) {
+	screen.Fill(colorBG)
+	cx := float64(m.screenW / 2)
+
+	ui.Draw(screen, i18n.T("menu.title"), cx, 40, ui.StyleTitle)
+
+	for i, it := range m.items {
+		y := float64(listTop + i*lineHeight)
+		if i == m.cursor {
+			ui.FillRect(screen, cx-rowWidth/2, y-3, rowWidth, lineHeight, colorSelected)
+		}
+		label := i18n.T(it.Label)
+		if it.Value != nil {
+			label = "< " + label + ": " + it.Value() + " >"
+		}
+		ui.Draw(screen, label, cx, y, ui.StyleCenter)
+	}
+
+	ui.Draw(screen, i18n.T("menu.help"), cx, float64(m.screenH-18), ui.StyleCenter)
+}
+
+// OnEnter is called when entering this scene
+func (m *Menu) OnEnter() {
+	m.idle = 0
+}
+
+// OnExit is called when leaving this scene
+func (m *Menu) OnExit() {}
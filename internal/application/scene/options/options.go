@@ -0,0 +1,431 @@
+// Package options provides the settings menu scene.
+//
+// The menu edits a *settings.Settings in place, applies video changes
+// immediately and saves the file when the player leaves the menu.
+package options
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
+)
+
+// Colors for rendering
+var (
+	colorBG       = color.RGBA{16, 16, 32, 255}
+	colorSelected = color.RGBA{60, 60, 100, 255}
+)
+
+const (
+	maxWindowScale = 6
+	volumeStep     = 10
+	lineHeight     = 14
+	listTop        = 36 // y of the first row
+	listBottom     = 24 // space reserved for the help line
+)
+
+// tpsChoices are the selectable update rates (0 = config default). The
+// simulation keeps the config rate; only input and drawing follow the TPS.
+var tpsChoices = []int{0, 30, 60, 120, 144}
+
+// difficultyChoices are the difficulty profiles in difficulty.json ("" = config default)
+var difficultyChoices = []string{"", "easy", "normal", "hard"}
+
+// Accessibility choices: game speeds (0 = 100%) and input timing windows
+// in milliseconds (0 = off)
+var (
+	gameSpeedChoices = []int{0, 90, 75}
+	inputMsChoices   = []int{0, 100, 200, 300}
+)
+
+// paletteChoices are the color palettes ("" = default)
+var paletteChoices = []string{"", "redGreen", "blueYellow"}
+
+// item is one menu row
+type item struct {
+	label  func() string
+	value  func() string
+	change func(delta int) // Left/Right (-1/+1), Enter (+1)
+	action string          // non-empty for key binding rows
+}
+
+// Options is the settings menu scene
+type Options struct {
+	settings *settings.Settings
+	path     string
+	display  config.DisplayConfig
+	back     scene.Scene
+
+	items   []item
+	cursor  int
+	scroll  int  // first visible row
+	rebind  bool // waiting for a key press for the selected binding
+	keysBuf []ebiten.Key
+	dirty   bool
+}
+
+// New creates the options menu. Changes are saved to path (if not empty)
+// and the menu returns to back when closed.
+func New(s *settings.Settings, path string, display config.DisplayConfig, back scene.Scene) *Options {
+	o := &Options{
+		settings: s,
+		path:     path,
+		display:  display,
+		back:     back,
+	}
+	o.items = o.buildItems()
+	return o
+}
+
+func (o *Options) buildItems() []item {
+	s := o.settings
+	items := []item{
+		{
+			label: text("options.windowScale"),
+			value: func() string { return scaleLabel(s.WindowScale, o.display.Scale) },
+			change: func(d int) {
+				s.WindowScale = wrap(effective(s.WindowScale, o.display.Scale)+d, 1, maxWindowScale)
+				ApplyVideo(s, o.display)
+			},
+		},
+		{
+			label:  text("options.fullscreen"),
+			value:  func() string { return onOff(s.Fullscreen) },
+			change: func(int) { s.Fullscreen = !s.Fullscreen; ApplyVideo(s, o.display) },
+		},
+		{
+			label:  text("options.vsync"),
+			value:  func() string { return onOff(s.VSync) },
+			change: func(int) { s.VSync = !s.VSync; ApplyVideo(s, o.display) },
+		},
+		{
+			label: text("options.tps"),
+			value: func() string { return tpsLabel(s.TPS, o.display.Framerate) },
+			change: func(d int) {
+				s.TPS = tpsChoices[wrap(indexOf(tpsChoices, s.TPS)+d, 0, len(tpsChoices)-1)]
+				ApplyVideo(s, o.display)
+			},
+		},
+		{
+			label:  text("options.masterVolume"),
+			value:  func() string { return fmt.Sprintf("%d%%", s.MasterVolume) },
+			change: func(d int) { s.MasterVolume = settings.ClampVolume(s.MasterVolume + d*volumeStep) },
+		},
+		{
+			label:  text("options.sfxVolume"),
+			value:  func() string { return fmt.Sprintf("%d%%", s.SFXVolume) },
+			change: func(d int) { s.SFXVolume = settings.ClampVolume(s.SFXVolume + d*volumeStep) },
+		},
+		{
+			label:  text("options.musicVolume"),
+			value:  func() string { return fmt.Sprintf("%d%%", s.MusicVolume) },
+			change: func(d int) { s.MusicVolume = settings.ClampVolume(s.MusicVolume + d*volumeStep) },
+		},
+		{
+			label:  text("options.screenShake"),
+			value:  func() string { return fmt.Sprintf("%d%%", s.ScreenShakePct) },
+			change: func(d int) { s.ScreenShakePct = settings.ClampVolume(s.ScreenShakePct + d*volumeStep) },
+		},
+		{
+			label:  text("options.rumble"),
+			value:  func() string { return onOff(s.Rumble) },
+			change: func(int) { s.Rumble = !s.Rumble },
+		},
+		{
+			label:  text("options.aimAssist"),
+			value:  func() string { return onOff(s.AimAssist) },
+			change: func(int) { s.AimAssist = !s.AimAssist },
+		},
+		{
+			label:  text("options.combatLog"),
+			value:  func() string { return onOff(s.CombatLog) },
+			change: func(int) { s.CombatLog = !s.CombatLog },
+		},
+		{
+			label: text("options.palette"),
+			value: func() string { return paletteLabel(s.Palette) },
+			change: func(d int) {
+				s.Palette = paletteChoices[wrap(indexOf(paletteChoices, s.Palette)+d, 0, len(paletteChoices)-1)]
+			},
+		},
+		{
+			label:  text("options.arrowMarkers"),
+			value:  func() string { return onOff(s.ArrowMarkers) },
+			change: func(int) { s.ArrowMarkers = !s.ArrowMarkers },
+		},
+		{
+			label:  text("options.highContrast"),
+			value:  func() string { return onOff(s.HighContrast) },
+			change: func(int) { s.HighContrast = !s.HighContrast },
+		},
+		{
+			label:  text("options.reduceShake"),
+			value:  func() string { return onOff(s.ReduceShake) },
+			change: func(int) { s.ReduceShake = !s.ReduceShake },
+		},
+		{
+			label:  text("options.dashToggle"),
+			value:  func() string { return onOff(s.DashToggle) },
+			change: func(int) { s.DashToggle = !s.DashToggle },
+		},
+		{
+			label: text("options.jumpRepeatGuard"),
+			value: func() string { return msLabel(s.JumpRepeatGuardMs) },
+			change: func(d int) {
+				s.JumpRepeatGuardMs = inputMsChoices[wrap(indexOf(inputMsChoices, s.JumpRepeatGuardMs)+d, 0, len(inputMsChoices)-1)]
+			},
+		},
+		{
+			label: text("options.holdTolerance"),
+			value: func() string { return msLabel(s.HoldToleranceMs) },
+			change: func(d int) {
+				s.HoldToleranceMs = inputMsChoices[wrap(indexOf(inputMsChoices, s.HoldToleranceMs)+d, 0, len(inputMsChoices)-1)]
+			},
+		},
+		{
+			label: text("options.gameSpeed"),
+			value: func() string { return fmt.Sprintf("%d%%", effective(s.GameSpeedPct, 100)) },
+			change: func(d int) {
+				s.GameSpeedPct = gameSpeedChoices[wrap(indexOf(gameSpeedChoices, s.GameSpeedPct)+d, 0, len(gameSpeedChoices)-1)]
+			},
+		},
+		{
+			label: text("options.difficulty"),
+			value: func() string { return difficultyLabel(s.Difficulty) },
+			change: func(d int) {
+				s.Difficulty = difficultyChoices[wrap(indexOf(difficultyChoices, s.Difficulty)+d, 0, len(difficultyChoices)-1)]
+			},
+		},
+		{
+			label: text("options.language"),
+			value: func() string { return i18n.T("language." + i18n.Language()) },
+			change: func(d int) {
+				langs := i18n.Available()
+				if len(langs) == 0 {
+					return
+				}
+				s.Language = langs[wrap(indexOf(langs, i18n.Language())+d, 0, len(langs)-1)]
+				i18n.SetLanguage(s.Language)
+			},
+		},
+	}
+
+	for _, action := range settings.Actions {
+		action := action
+		items = append(items, item{
+			label:  func() string { return i18n.Tf("options.key", i18n.T("action."+action)) },
+			value:  func() string { return s.KeyBindings[action] },
+			action: action,
+		})
+	}
+
+	return items
+}
+
+// Update handles menu navigation (implements scene.Scene)
+func (o *Options) Update(_ float64) (scene.Scene, error) {
+	if o.rebind {
+		o.updateRebind()
+		return nil, nil
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		return o.back, nil
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		o.moveCursor(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		o.moveCursor(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		o.change(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		o.change(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		if o.items[o.cursor].action != "" {
+			o.rebind = true
+		} else {
+			o.change(1)
+		}
+	}
+
+	return nil, nil
+}
+
+// moveCursor selects the previous/next row and scrolls it into view
+func (o *Options) moveCursor(delta int) {
+	o.cursor = wrap(o.cursor+delta, 0, len(o.items)-1)
+
+	rows := o.visibleRows()
+	if o.cursor < o.scroll {
+		o.scroll = o.cursor
+	}
+	if o.cursor >= o.scroll+rows {
+		o.scroll = o.cursor - rows + 1
+	}
+}
+
+// visibleRows returns how many rows fit between the title and help line
+func (o *Options) visibleRows() int {
+	return max(1, (o.display.ScreenHeight-listTop-listBottom)/lineHeight)
+}
+
+func (o *Options) change(delta int) {
+	it := o.items[o.cursor]
+	if it.change == nil {
+		return
+	}
+	it.change(delta)
+	o.dirty = true
+}
+
+// updateRebind waits for the next key press and binds it (ESC cancels)
+func (o *Options) updateRebind() {
+	o.keysBuf = inpututil.AppendJustPressedKeys(o.keysBuf[:0])
+	if len(o.keysBuf) == 0 {
+		return
+	}
+
+	o.rebind = false
+	key := o.keysBuf[0]
+	if key == ebiten.KeyEscape {
+		return
+	}
+	o.settings.KeyBindings[o.items[o.cursor].action] = key.String()
+	o.dirty = true
+}
+
+// Draw renders the menu (implements scene.Scene)
+func (o *Options) Draw(screen *ebiten.Image) {
+	screen.Fill(colorBG)
+	w := o.display.ScreenWidth
+
+	ui.Draw(screen, i18n.T("options.title"), float64(w/2), 8, ui.StyleTitle)
+
+	rows := o.visibleRows()
+	for i := o.scroll; i < len(o.items) && i < o.scroll+rows; i++ {
+		it := o.items[i]
+		y := listTop + (i-o.scroll)*lineHeight
+		if i == o.cursor {
+			ui.FillRect(screen, float64(w/2-140), float64(y-1), 280, lineHeight, colorSelected)
+		}
+		value := it.value()
+		if i == o.cursor && o.rebind {
+			value = i18n.T("options.pressKey")
+		}
+		ui.Draw(screen, it.label(), float64(w/2-130), float64(y), ui.StyleDefault)
+		ui.Draw(screen, value, float64(w/2+30), float64(y), ui.StyleDefault)
+	}
+
+	ui.Draw(screen, i18n.T("options.help"), float64(w/2), float64(o.display.ScreenHeight-18), ui.StyleCenter)
+}
+
+// OnEnter is called when entering this scene
+func (o *Options) OnEnter() {
+	o.rebind = false
+}
+
+// OnExit saves changed settings
+func (o *Options) OnExit() {
+	if !o.dirty || o.path == "" {
+		return
+	}
+	if err := o.settings.Save(o.path); err != nil {
+		logging.Scene.Errorf("Failed to save settings: %v", err)
+		return
+	}
+	o.dirty = false
+	logging.Scene.Infof("Settings saved: %s", o.path)
+}
+
+// ApplyVideo applies window, vsync and TPS settings.
+// Zero values fall back to the config display settings.
+func ApplyVideo(s *settings.Settings, display config.DisplayConfig) {
+	scale := effective(s.WindowScale, display.Scale)
+	ebiten.SetWindowSize(display.ScreenWidth*scale, display.ScreenHeight*scale)
+	ebiten.SetFullscreen(s.Fullscreen)
+	ebiten.SetVsyncEnabled(s.VSync)
+	ebiten.SetTPS(effective(s.TPS, display.Framerate))
+}
+
+// effective returns v, or def if v is unset
+func effective(v, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// wrap keeps v in [lo, hi], wrapping around at either end
+func wrap(v, lo, hi int) int {
+	if v < lo {
+		return hi
+	}
+	if v > hi {
+		return lo
+	}
+	return v
+}
+
+func indexOf[T comparable](values []T, v T) int {
+	for i, x := range values {
+		if x == v {
+			return i
+		}
+	}
+	return 0
+}
+
+// text returns a label func that looks up key in the current language
+func text(key string) func() string {
+	return func() string { return i18n.T(key) }
+}
+
+func onOff(b bool) string {
+	if b {
+		return i18n.T("options.on")
+	}
+	return i18n.T("options.off")
+}
+
+func scaleLabel(v, def int) string {
+	if v <= 0 {
+		return i18n.Tf("options.default", fmt.Sprintf("%dx", def))
+	}
+	return fmt.Sprintf("%dx", v)
+}
+
+func tpsLabel(v, def int) string {
+	if v <= 0 {
+		return i18n.Tf("options.default", fmt.Sprint(def))
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+func msLabel(ms int) string {
+	if ms <= 0 {
+		return i18n.T("options.off")
+	}
+	return fmt.Sprintf("%d ms", ms)
+}
+
+func paletteLabel(name string) string {
+	if name == "" {
+		return i18n.T("palette.default")
+	}
+	return i18n.T("palette." + name)
+}
+
+func difficultyLabel(name string) string {
+	if name == "" {
+		return i18n.T("difficulty.default")
+	}
+	return i18n.T("difficulty." + name)
+}
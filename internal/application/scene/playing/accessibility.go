@@ -0,0 +1,106 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+// reducedShakePct caps screen shake when Reduce Shake is on
+const reducedShakePct = 25
+
+var (
+	colorOutline = color.RGBA{255, 255, 255, 255}
+	colorMarker  = color.RGBA{255, 255, 255, 230}
+)
+
+// palette holds the colors that tell arrow types and enemy states apart
+type palette struct {
+	arrows    map[ecs.ArrowType]color.RGBA
+	telegraph color.RGBA // enemy winding up an attack
+}
+
+// palettes by settings name ("" = default). The alternatives keep the
+// arrow types apart for red-green and blue-yellow color blindness.
+var palettes = map[string]palette{
+	"": {
+		arrows:    ecs.ArrowColors,
+		telegraph: colorTelegraph,
+	},
+	"redGreen": {
+		arrows: map[ecs.ArrowType]color.RGBA{
+			ecs.ArrowGray:   {150, 150, 150, 255},
+			ecs.ArrowRed:    {230, 159, 0, 255},
+			ecs.ArrowBlue:   {0, 114, 178, 255},
+			ecs.ArrowPurple: {204, 121, 167, 255},
+		},
+		telegraph: color.RGBA{240, 228, 66, 255},
+	},
+	"blueYellow": {
+		arrows: map[ecs.ArrowType]color.RGBA{
+			ecs.ArrowGray:   {150, 150, 150, 255},
+			ecs.ArrowRed:    {220, 40, 60, 255},
+			ecs.ArrowBlue:   {0, 170, 170, 255},
+			ecs.ArrowPurple: {250, 120, 200, 255},
+		},
+		telegraph: color.RGBA{255, 80, 80, 255},
+	},
+}
+
+// arrowMarkers are 5x5 shapes (one byte per row, high bit left) drawn on
+// arrows so their type reads without color
+var arrowMarkers = map[ecs.ArrowType][5]uint8{
+	ecs.ArrowGray:   {0b00000, 0b00000, 0b11111, 0b00000, 0b00000}, // bar
+	ecs.ArrowRed:    {0b00100, 0b01110, 0b11111, 0b00000, 0b00000}, // triangle
+	ecs.ArrowBlue:   {0b11111, 0b10001, 0b10001, 0b10001, 0b11111}, // square
+	ecs.ArrowPurple: {0b10001, 0b01010, 0b00100, 0b01010, 0b10001}, // cross
+}
+
+// palette returns the colors of the selected palette
+func (p *Playing) palette() palette {
+	if p.settings != nil {
+		if pal, ok := palettes[p.settings.Palette]; ok {
+			return pal
+		}
+	}
+	return palettes[""]
+}
+
+// arrowColor returns the color of an arrow type in the selected palette
+func (p *Playing) arrowColor(t ecs.ArrowType) color.RGBA {
+	return p.palette().arrows[t]
+}
+
+// arrowMarkersOn reports whether arrows carry shape markers
+func (p *Playing) arrowMarkersOn() bool {
+	return p.settings != nil && p.settings.ArrowMarkers
+}
+
+// highContrast reports whether hazards and hitboxes are outlined
+func (p *Playing) highContrast() bool {
+	return p.settings != nil && p.settings.HighContrast
+}
+
+// drawArrowMarker draws the shape marker of an arrow type centered on (x, y)
+func drawArrowMarker(screen *ebiten.Image, x, y float64, t ecs.ArrowType) {
+	rows, ok := arrowMarkers[t]
+	if !ok {
+		return
+	}
+	for row, bits := range rows {
+		for col := range 5 {
+			if bits&(0b10000>>col) != 0 {
+				ui.FillRect(screen, x-2+float64(col), y-2+float64(row), 1, 1, colorMarker)
+			}
+		}
+	}
+}
+
+// drawOutline outlines a rectangle in high-contrast mode
+func (p *Playing) drawOutline(screen *ebiten.Image, x, y, w, h float64) {
+	if p.highContrast() {
+		ui.StrokeRect(screen, x, y, w, h, colorOutline)
+	}
+}
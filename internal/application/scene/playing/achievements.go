@@ -0,0 +1,71 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/achievement"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+// SetProfile attaches the player's save data. path is where progress is
+// written when achievements unlock and on exit (empty = don't persist);
+// cleared stages are appended to the history file next to it.
+func (p *Playing) SetProfile(d *save.Data, path string) {
+	p.profile = d
+	p.profilePath = path
+	p.historyPath = ""
+	if path != "" {
+		p.historyPath = save.HistoryPath(path)
+	}
+	p.achievements = achievement.NewTracker(achievement.FromConfig(p.config.Achievements), &d.Achievements)
+}
+
+// processEvents drains the world's gameplay events and forwards them to listeners
+func (p *Playing) processEvents() {
+	unlocked := false
+	for _, ev := range p.world.Events.Drain() {
+		p.stats.Handle(ev)
+		p.inputTimeline.handleEvent(ev)
+		p.notifyEvent(ev)
+		p.logCombat(ev)
+		switch ev.Type {
+		case ecs.EventStageCleared:
+			p.setMusic(victoryTrack)
+			if p.tower != nil {
+				p.clearFloor()
+				break
+			}
+			p.markStageCleared()
+			p.appendHistory()
+			p.finishRun(true)
+		case ecs.EventPlayerDied:
+			p.finishRun(false)
+		case ecs.EventBossAppeared, ecs.EventBossKilled:
+			p.handleBossEvent(ev)
+		}
+		for _, def := range p.achievements.Handle(ev) {
+			p.toasts.Notify(ui.IconAchievement, i18n.T("toast.achievement")+"\n"+def.Name, 0)
+			unlocked = true
+		}
+	}
+	if unlocked {
+		p.saveProfile()
+	}
+}
+
+// startStage notifies listeners that a (new) run of the stage began
+func (p *Playing) startStage() {
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventStageStarted})
+}
+
+// saveProfile writes the save file
+func (p *Playing) saveProfile() {
+	if p.profilePath == "" {
+		return
+	}
+	if err := p.profile.Save(p.profilePath); err != nil {
+		logging.Scene.Errorf("Failed to save profile: %v", err)
+	}
+}
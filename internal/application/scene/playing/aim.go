@@ -0,0 +1,133 @@
+package playing
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+// Crosshair colors
+var (
+	colorCrosshair       = color.RGBA{255, 255, 255, 230}
+	colorCrosshairLocked = color.RGBA{255, 90, 90, 255}
+)
+
+const (
+	crosshairGap    = 2 // pixels between the center and each arm
+	crosshairArm    = 4
+	lockBracket     = 4 // length of the target bracket corners
+	aimAssistPasses = 3 // aim corrections toward the locked enemy
+
+	trajectoryMaxFrames = 120 // preview length cap for shots that never leave their range
+)
+
+// closestApproach returns how near a path comes to (x, y) and the path point
+// where it does
+func closestApproach(path []ecs.PathPoint, x, y float64) (ecs.PathPoint, float64) {
+	var best ecs.PathPoint
+	bestDist := math.Inf(1)
+	for _, pt := range path {
+		if d := math.Hypot(float64(pt.X)-x, float64(pt.Y)-y); d < bestDist {
+			best, bestDist = pt, d
+		}
+	}
+	return best, bestDist
+}
+
+// aimAssistRadius returns how close the predicted path must pass to an
+// enemy for the aim to snap to it (0 = assist off)
+func (p *Playing) aimAssistRadius(assist bool) float64 {
+	if !assist {
+		return 0
+	}
+	return p.config.Physics.Projectile.AimAssist
+}
+
+// updateAim sets the aim point from the mouse. With aim assist, if the
+// predicted path passes within the assist radius of an enemy, the aim is
+// corrected so the arrow's arc goes through that enemy's center instead.
+// assist is the frame's aim assist setting (recorded in replays).
+func (p *Playing) updateAim(assist bool) {
+	p.aimX, p.aimY = p.mouseWorldX, p.mouseWorldY
+	p.aimTarget = 0
+
+	radius := p.aimAssistRadius(assist)
+	if radius <= 0 {
+		return
+	}
+
+	path := p.predictTrajectory(int(p.aimX), int(p.aimY))
+	bestDist := radius
+	for _, id := range p.world.Query(ecs.TagEnemy) {
+		if p.world.AI[id].SpawnTimer > 0 {
+			continue
+		}
+		ex, ey := ecs.HitboxCenter(p.world, id)
+		_, d := closestApproach(path, float64(ex), float64(ey))
+		if d < bestDist {
+			p.aimTarget = id
+			bestDist = d
+		}
+	}
+	if p.aimTarget == 0 {
+		return
+	}
+
+	// Aim at the center, then lift the aim point by however much gravity
+	// makes the arc miss
+	ex, ey := ecs.HitboxCenter(p.world, p.aimTarget)
+	tx, ty := float64(ex), float64(ey)
+	p.aimX, p.aimY = tx, ty
+	for range aimAssistPasses {
+		pt, _ := closestApproach(p.predictTrajectory(int(p.aimX), int(p.aimY)), tx, ty)
+		p.aimX += tx - float64(pt.X)
+		p.aimY += ty - float64(pt.Y)
+	}
+}
+
+// drawCrosshair draws the cursor at the mouse position and, when aim assist
+// has locked on, brackets around the target
+func (p *Playing) drawCrosshair(screen *ebiten.Image) {
+	camX, camY := p.getCameraOffset()
+	x := p.mouseWorldX - float64(camX)
+	y := p.mouseWorldY - float64(camY)
+
+	c := colorCrosshair
+	if p.aimTarget != 0 && p.world.Exists(p.aimTarget) {
+		c = colorCrosshairLocked
+		p.drawLockBrackets(screen, camX, camY)
+	}
+
+	ui.FillRect(screen, x-crosshairGap-crosshairArm, y, crosshairArm, 1, c)
+	ui.FillRect(screen, x+crosshairGap+1, y, crosshairArm, 1, c)
+	ui.FillRect(screen, x, y-crosshairGap-crosshairArm, 1, crosshairArm, c)
+	ui.FillRect(screen, x, y+crosshairGap+1, 1, crosshairArm, c)
+	ui.FillRect(screen, x, y, 1, 1, c)
+}
+
+// drawLockBrackets draws corner brackets around the aim assist target's hitbox
+func (p *Playing) drawLockBrackets(screen *ebiten.Image, camX, camY int) {
+	id := p.aimTarget
+	pos := p.drawPosition(id)
+	hb := p.world.Hitbox[id]
+	left := float64(pos.PixelX()+hb.OffsetX-camX) - 2
+	top := float64(pos.PixelY()+hb.OffsetY-camY) - 2
+	right := left + float64(hb.Width) + 3
+	bottom := top + float64(hb.Height) + 3
+
+	for _, corner := range [][2]float64{{left, top}, {right, top}, {left, bottom}, {right, bottom}} {
+		cx, cy := corner[0], corner[1]
+		dx, dy := 1.0, 1.0
+		if cx == right {
+			dx = -1
+		}
+		if cy == bottom {
+			dy = -1
+		}
+		ui.FillRect(screen, math.Min(cx, cx+dx*lockBracket), cy, lockBracket, 1, colorCrosshairLocked)
+		ui.FillRect(screen, cx, math.Min(cy, cy+dy*lockBracket), 1, lockBracket, colorCrosshairLocked)
+	}
+}
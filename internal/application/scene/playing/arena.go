@@ -0,0 +1,164 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+const (
+	triggerTypeBoss = "boss"
+
+	bossBannerFrames = 150 // 2.5 seconds on screen
+	bossBannerFade   = 20  // fade in/out duration
+)
+
+var (
+	colorBossBanner  = color.RGBA{0, 0, 0, 170}
+	colorBossTitle   = color.RGBA{255, 80, 80, 255}
+	colorBossTitleBG = color.RGBA{255, 80, 80, 120}
+)
+
+// bossBanner is the boss intro shown across the screen
+type bossBanner struct {
+	title  string
+	frames int // frames left (0 = hidden)
+}
+
+// updateBossArenas runs the boss fight script once per frame. Walking into
+// a boss trigger closes its arena's doors and spawns the boss (the music
+// and banner follow EventBossAppeared, see handleBossEvent); beating the
+// boss opens the doors and drops the reward.
+func (p *Playing) updateBossArenas() {
+	w := p.world
+	if w.Boss.Active() {
+		arena := ecs.UpdateBossFight(w)
+		if arena < 0 {
+			return
+		}
+		cfg := p.stageCfg.Triggers[arena].Arena
+		p.sealDoors(cfg.Doors, false)
+		ecs.DropReward(w, cfg.Chest.X, cfg.Chest.Y, buildLootTable(cfg.Reward))
+		return
+	}
+
+	for i, trigger := range p.stageCfg.Triggers {
+		if trigger.Type != triggerTypeBoss || trigger.Arena == nil || w.Boss.Beaten(i) || !p.playerInRect(trigger.Rect) {
+			continue
+		}
+		boss := p.spawnEnemy(trigger.Arena.Boss)
+		if boss == 0 {
+			logging.Config.Warnf("Unknown boss enemy: %s", trigger.Arena.Boss.Type)
+			continue
+		}
+		if !ecs.StartBossFight(w, i, boss) {
+			w.DestroyEntity(boss)
+			continue
+		}
+		ecs.SpawnIn(w, boss)
+		p.sealDoors(trigger.Arena.Doors, true)
+		return
+	}
+}
+
+// handleBossEvent switches the music and shows the banner for boss events
+func (p *Playing) handleBossEvent(ev ecs.Event) {
+	switch ev.Type {
+	case ecs.EventBossAppeared:
+		if ev.Amount < 0 || ev.Amount >= len(p.stageCfg.Triggers) || p.stageCfg.Triggers[ev.Amount].Arena == nil {
+			return
+		}
+		cfg := p.stageCfg.Triggers[ev.Amount].Arena
+		if cfg.Music != "" {
+			p.setMusic(cfg.Music)
+		}
+		p.banner = bossBanner{title: translateOr(cfg.Title), frames: bossBannerFrames}
+	case ecs.EventBossKilled:
+		p.setMusic("")
+	}
+}
+
+// sealDoors closes or opens the door tiles inside r (pixels)
+func (p *Playing) sealDoors(r config.RectConfig, sealed bool) {
+	size := p.stage.TileSize
+	for ty := r.Y / size; ty*size < r.Y+r.H; ty++ {
+		for tx := r.X / size; tx*size < r.X+r.W; tx++ {
+			p.stage.SetTileSealed(tx, ty, sealed)
+		}
+	}
+}
+
+// syncBossArena closes the doors and plays the music of the fight in
+// progress after the world and the stage's tiles were restored (rewind, TAS)
+func (p *Playing) syncBossArena() {
+	arena := p.world.Boss.Arena - 1
+	if arena < 0 || arena >= len(p.stageCfg.Triggers) || p.stageCfg.Triggers[arena].Arena == nil {
+		p.setMusic("")
+		return
+	}
+	cfg := p.stageCfg.Triggers[arena].Arena
+	p.sealDoors(cfg.Doors, true)
+	if cfg.Music != "" {
+		p.setMusic(cfg.Music)
+	}
+}
+
+// playerInRect reports whether the player's body overlaps r (pixels)
+func (p *Playing) playerInRect(r config.RectConfig) bool {
+	bx, by, bw, bh := p.playerBody()
+	return bx < r.X+r.W && bx+bw > r.X && by < r.Y+r.H && by+bh > r.Y
+}
+
+// playerBody returns the player's body hitbox in world pixels
+func (p *Playing) playerBody() (x, y, w, h int) {
+	pos := p.world.Position[p.world.PlayerID]
+	facing := p.world.Facing[p.world.PlayerID]
+	hitbox := p.world.HitboxTrapezoid[p.world.PlayerID]
+	return hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+}
+
+// setMusic changes the music track ("" = the stage's own music) and tells
+// the host, which plays it
+func (p *Playing) setMusic(track string) {
+	if track == p.music {
+		return
+	}
+	p.music = track
+	logging.Scene.Debugf("Music: %q", track)
+	if p.onMusic != nil {
+		p.onMusic(track)
+	}
+}
+
+// translateOr returns the translation of key, or key itself when it isn't
+// an i18n key
+func translateOr(key string) string {
+	if text := i18n.T(key); text != key {
+		return text
+	}
+	return key
+}
+
+// drawBossBanner draws the boss intro across the middle of the screen,
+// fading in and out
+func (p *Playing) drawBossBanner(screen *ebiten.Image) {
+	b := p.banner
+	if b.frames <= 0 {
+		return
+	}
+	alpha := min(1, float64(min(b.frames, bossBannerFrames-b.frames))/bossBannerFade)
+	fade := func(c color.RGBA) color.RGBA {
+		return color.RGBA{uint8(float64(c.R) * alpha), uint8(float64(c.G) * alpha), uint8(float64(c.B) * alpha), uint8(float64(c.A) * alpha)}
+	}
+
+	y := float64(p.screenH)/3 - 16
+	ui.FillRect(screen, 0, y, float64(p.screenW), 32, fade(colorBossBanner))
+	ui.FillRect(screen, 0, y, float64(p.screenW), 1, fade(colorBossTitleBG))
+	ui.FillRect(screen, 0, y+31, float64(p.screenW), 1, fade(colorBossTitleBG))
+	ui.Draw(screen, b.title, float64(p.screenW/2), y+8, ui.Style{Color: fade(colorBossTitle), Align: ui.AlignCenter})
+}
@@ -0,0 +1,132 @@
+package playing
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// arrowSlotKeys pick an arrow select slot directly (1-9)
+var arrowSlotKeys = []ebiten.Key{
+	ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3,
+	ebiten.KeyDigit4, ebiten.KeyDigit5, ebiten.KeyDigit6,
+	ebiten.KeyDigit7, ebiten.KeyDigit8, ebiten.KeyDigit9,
+}
+
+// defaultArrowSelectSlowMotion is the simulation speed while the wheel is
+// open when config has none (one substep of ten)
+const defaultArrowSelectSlowMotion = 10
+
+// buildArrowSelect creates the arrow select wheel with one slot per
+// equipped arrow
+func buildArrowSelect(cfg config.ArrowSelectConfig, slots int) *ui.ArrowSelect {
+	return ui.NewArrowSelect(ui.ArrowSelectConfig{
+		Radius:        cfg.Radius,
+		MinDistance:   cfg.MinDistance,
+		MaxFrame:      cfg.MaxFrame,
+		StickDeadzone: cfg.StickDeadzone,
+	}, slots)
+}
+
+// resetArrowSelect recreates the wheel for the current player's loadout
+func (p *Playing) resetArrowSelect() {
+	slots := len(p.world.PlayerData[p.world.PlayerID].EquippedArrows)
+	p.arrowSelectUI = buildArrowSelect(p.config.Physics.ArrowSelect, slots)
+}
+
+// arrowSelectSlowMotion returns the simulation speed (0-100) while the
+// wheel is open
+func (p *Playing) arrowSelectSlowMotion() int {
+	if p.config.Physics.ArrowSelect.SlowMotion <= 0 {
+		return defaultArrowSelectSlowMotion
+	}
+	return fixedpoint.ToPct(p.config.Physics.ArrowSelect.SlowMotion)
+}
+
+// getArrowSelectInput reads the wheel button (right mouse or the left
+// shoulder of the first gamepad), the right stick and the number keys
+func (p *Playing) getArrowSelectInput(input inputState) ui.ArrowSelectInput {
+	in := ui.ArrowSelectInput{
+		Open:    inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight),
+		Close:   inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight),
+		CursorX: input.MouseX,
+		CursorY: input.MouseY,
+	}
+	for i, key := range arrowSlotKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			in.Number = i + 1
+			break
+		}
+	}
+
+	p.gamepadIDs = ebiten.AppendGamepadIDs(p.gamepadIDs[:0])
+	for _, id := range p.gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		in.Open = in.Open || inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonFrontTopLeft)
+		in.Close = in.Close || inpututil.IsStandardGamepadButtonJustReleased(id, ebiten.StandardGamepadButtonFrontTopLeft)
+		in.StickX = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickHorizontal)
+		in.StickY = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickVertical)
+		break
+	}
+	return in
+}
+
+// updateArrowSelect advances the wheel, equips the selected arrow and holds
+// slow motion while the wheel is open. It returns the selected slot
+// (ui.NoSlot = none).
+func (p *Playing) updateArrowSelect(in ui.ArrowSelectInput) int {
+	slot := p.arrowSelectUI.Update(in, p.screenW, p.screenH)
+	p.selectArrowSlot(slot)
+
+	if p.arrowSelectUI.IsActive() {
+		p.world.TimeScale.Hold(ecs.TimeScaleArrowSelect, p.arrowSelectSlowMotion())
+	} else {
+		p.world.TimeScale.Release(ecs.TimeScaleArrowSelect)
+	}
+	return slot
+}
+
+// selectArrowSlot equips the arrow in an arrow select slot
+func (p *Playing) selectArrowSlot(slot int) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	if slot < 0 || slot >= len(playerData.EquippedArrows) {
+		return
+	}
+	playerData.CurrentArrow = playerData.EquippedArrows[slot]
+	p.world.PlayerData[p.world.PlayerID] = playerData
+}
+
+func (p *Playing) drawArrowSelectOverlay(screen *ebiten.Image) {
+	progress := p.arrowSelectUI.Progress()
+	easedProgress := math.Sin(progress * math.Pi / 2)
+
+	alpha := uint8(128 * easedProgress)
+	overlay := color.RGBA{0, 0, 0, alpha}
+	ui.FillRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
+}
+
+func (p *Playing) drawArrowSelectUI(screen *ebiten.Image) {
+	progress := p.arrowSelectUI.Progress()
+	easedProgress := math.Sin(progress * math.Pi / 2)
+	playerData := p.world.PlayerData[p.world.PlayerID]
+
+	for slot, arrowType := range playerData.EquippedArrows {
+		x, y := p.arrowSelectUI.IconPosition(slot, easedProgress)
+		highlighted := slot == p.arrowSelectUI.Highlighted
+
+		brightness := 0.7
+		if arrowType == playerData.CurrentArrow || highlighted {
+			brightness = 1.0
+		}
+
+		p.drawArrowIcon(screen, x, y, arrowType, brightness*easedProgress, highlighted)
+	}
+}
@@ -0,0 +1,37 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// enemyBeam reports whether the projectile an enemy is configured to fire
+// is a hitscan beam, and whether it pierces
+func (p *Playing) enemyBeam(enemyCfg *config.EnemyConfig) (hitscan, piercing bool) {
+	projCfg, ok := p.config.Entities.Projectiles[enemyCfg.AI.Projectile]
+	if enemyCfg.AI.Projectile == "" || !ok || !projCfg.Physics.Hitscan {
+		return false, false
+	}
+	return true, projCfg.Physics.Piercing
+}
+
+// drawBeams draws the recent hitscan beams, fading out over their life
+func (p *Playing) drawBeams(screen *ebiten.Image, camX, camY int) {
+	for _, b := range p.world.Beams {
+		c := colorEnemyArrow
+		if b.Faction == ecs.FactionPlayer {
+			c = p.arrowColor(b.Arrow)
+		}
+		a := float64(b.Life) / ecs.BeamFrames
+		c = color.RGBA{uint8(float64(c.R) * a), uint8(float64(c.G) * a), uint8(float64(c.B) * a), uint8(float64(c.A) * a)}
+
+		x0, y0 := float64(b.X0-camX), float64(b.Y0-camY)
+		x1, y1 := float64(b.X1-camX), float64(b.Y1-camY)
+		ebitenutil.DrawLine(screen, x0, y0, x1, y1, c)
+		ebitenutil.DrawLine(screen, x0, y0+1, x1, y1+1, c)
+	}
+}
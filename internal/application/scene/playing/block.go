@@ -0,0 +1,71 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+var (
+	colorShield       = color.RGBA{140, 200, 255, 255}
+	colorShieldParry  = color.RGBA{255, 255, 255, 255}
+	colorStamina      = color.RGBA{120, 180, 255, 255}
+	colorStaminaEmpty = color.RGBA{255, 120, 80, 255}
+)
+
+// buildBlockConfig converts shield config to ECS units
+func buildBlockConfig(cfg *config.GameConfig, character config.PlayerConfig) ecs.BlockConfig {
+	if !character.Abilities.Block {
+		return ecs.BlockConfig{}
+	}
+	b := cfg.Physics.Combat.Block
+	return ecs.BlockConfig{
+		MaxStamina:   int(b.MaxStamina * ecs.StaminaScale),
+		Drain:        int(fixedpoint.Sim.PerFrame(b.DrainPerSecond * ecs.StaminaScale)),
+		HitCost:      int(b.HitCost * ecs.StaminaScale),
+		Regen:        int(fixedpoint.Sim.PerFrame(b.RegenPerSecond * ecs.StaminaScale)),
+		RegenDelay:   fixedpoint.SecondsToFrames(b.RegenDelay),
+		ReductionPct: fixedpoint.ToPct(b.DamageReduction),
+		ParryFrames:  fixedpoint.SecondsToFrames(b.ParryWindow),
+	}
+}
+
+// drawShield draws the raised shield in front of the player.
+// It flashes white while the parry window is open.
+func (p *Playing) drawShield(screen *ebiten.Image, x, y, w, h float64) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	if !playerData.Blocking {
+		return
+	}
+
+	c := colorShield
+	if playerData.BlockFrames <= p.world.Block.ParryFrames {
+		c = colorShieldParry
+	}
+	shieldX := x - 3
+	if p.world.Facing[p.world.PlayerID].Right {
+		shieldX = x + w + 1
+	}
+	ui.FillRect(screen, shieldX, y+2, 2, h-4, c)
+}
+
+// drawStaminaBar draws the shield stamina under the health bar
+func (p *Playing) drawStaminaBar(screen *ebiten.Image, x, y, w float64) {
+	maxStamina := p.world.Block.MaxStamina
+	if maxStamina <= 0 {
+		return
+	}
+	playerData := p.world.PlayerData[p.world.PlayerID]
+
+	c := colorStamina
+	if playerData.GuardBroken {
+		c = colorStaminaEmpty
+	}
+	ratio := float64(playerData.Stamina(maxStamina)) / float64(maxStamina)
+	ui.FillRect(screen, x, y, w, 3, p.hud.theme.barBG)
+	ui.FillRect(screen, x, y, w*ratio, 3, c)
+}
@@ -0,0 +1,41 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// bombFuseFlash is how many frames before exploding a bomb starts to flash
+const bombFuseFlash = 30
+
+var colorBombFlash = color.RGBA{255, 60, 60, 255}
+
+// bombExplosion converts a player bomb's blast config to ECS units.
+// Without a fuse or blast the projectile doesn't explode.
+func (p *Playing) bombExplosion(projCfg config.ProjectileConfig) ecs.Explosion {
+	blast := projCfg.Physics.Explosion
+	if blast == nil || projCfg.Physics.Fuse <= 0 {
+		return ecs.Explosion{}
+	}
+	return ecs.Explosion{
+		Radius:       int(blast.Radius),
+		Damage:       projCfg.Damage,
+		FalloffPct:   fixedpoint.ToPct(blast.Falloff),
+		Knockback:    fixedpoint.ToIUPerSubstep(blast.Knockback),
+		IframeFrames: p.playerIframes(),
+		SparePlayer:  true,
+	}
+}
+
+// drawBomb draws a bomb at screen (x, y), flashing as its fuse runs out
+func drawBomb(screen *ebiten.Image, proj ecs.Projectile, x, y float64, c color.RGBA) {
+	if proj.Fuse < bombFuseFlash && proj.Fuse%8 < 4 {
+		c = colorBombFlash
+	}
+	ui.FillRect(screen, x-3, y-3, 6, 6, c)
+}
@@ -0,0 +1,223 @@
+package playing
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"time"
+
+	"github.com/younwookim/mg/internal/application/crash"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// BotMode is how the soak test bot picks its inputs
+type BotMode int
+
+const (
+	BotRandom   BotMode = iota // random but valid inputs
+	BotSeekExit                // run toward the stage exit, jumping over what blocks it
+)
+
+// Bot tuning
+const (
+	botRunSeconds    = 300 // a run that lasts this long starts over
+	botStuckFrames   = 15  // frames without moving before the bot jumps
+	botDetourFrames  = 60  // frames the exit seeker backs off when jumping doesn't help
+	botLogInterval   = time.Minute
+	botMinHoldFrames = 10
+	botMaxHoldFrames = 90
+	botMaxJumpFrames = 24
+)
+
+// BotOptions configures a soak test (see RunBot)
+type BotOptions struct {
+	Mode      BotMode
+	Duration  time.Duration     // wall-clock time to play for
+	MaxFrames int               // stop after this many frames (0 = Duration only)
+	Seed      int64             // seeds the bot and its first run
+	DumpDir   string            // where a violation's report is written ("" = not written)
+	Configs   map[string]string // config versions for the report
+}
+
+// ParseBotMode returns the mode named "random" or "exit"
+func ParseBotMode(name string) (BotMode, bool) {
+	switch name {
+	case "random":
+		return BotRandom, true
+	case "exit":
+		return BotSeekExit, true
+	}
+	return BotRandom, false
+}
+
+// bot is a scripted player. It holds a direction for a while and jumps
+// with a press, a hold and a release, like a person on a keyboard.
+type bot struct {
+	mode      BotMode
+	rng       *rand.Rand
+	dir       int // -1 left, 0 still, 1 right
+	hold      int // frames left on dir
+	jumpHold  int // frames left holding jump
+	blockHold int // frames left holding block
+	lastX     int
+	stuck     int
+}
+
+func newBot(mode BotMode, seed int64) *bot {
+	return &bot{mode: mode, rng: rand.New(rand.NewSource(seed))}
+}
+
+// next returns the input for the coming frame
+func (b *bot) next(p *Playing) inputState {
+	var jump bool
+	if b.mode == BotSeekExit && b.hold == 0 {
+		jump = b.seekExit(p)
+	} else {
+		jump = b.wander()
+	}
+
+	in := inputState{Left: b.dir < 0, Right: b.dir > 0}
+	if b.jumpHold > 0 {
+		b.jumpHold--
+		in.Up = b.jumpHold > 0
+		in.JumpReleased = !in.Up
+	} else if jump {
+		b.jumpHold = 1 + b.rng.Intn(botMaxJumpFrames)
+		in.Up, in.JumpPressed = true, true
+	}
+
+	// Everything else the player can do, now and then
+	in.Down = b.rng.Intn(60) == 0
+	in.Dash = b.rng.Intn(120) == 0
+	if b.blockHold > 0 {
+		b.blockHold--
+		in.Block = true
+	} else if b.rng.Intn(240) == 0 {
+		b.blockHold = botMinHoldFrames + b.rng.Intn(botMaxHoldFrames)
+	}
+	in.Attack = b.rng.Intn(40) == 0
+	in.Summon = b.rng.Intn(600) == 0
+	in.MouseX = b.rng.Intn(max(1, p.screenW))
+	in.MouseY = b.rng.Intn(max(1, p.screenH))
+	return in
+}
+
+// wander keeps a random direction for a random time and jumps at random
+func (b *bot) wander() (jump bool) {
+	if b.hold == 0 {
+		b.dir = b.rng.Intn(3) - 1
+		b.hold = botMinHoldFrames + b.rng.Intn(botMaxHoldFrames)
+	}
+	b.hold--
+	return b.rng.Intn(30) == 0
+}
+
+// seekExit heads for the stage's exit trigger (else its right edge) and
+// jumps when it stops moving. Stuck for long, it backs off for a while.
+func (b *bot) seekExit(p *Playing) (jump bool) {
+	targetX := p.stage.Width * p.tileSize
+	for _, t := range p.stageCfg.Triggers {
+		if t.Type == triggerTypeExit {
+			targetX = t.Rect.X + t.Rect.W/2
+			break
+		}
+	}
+	x := p.world.Position[p.world.PlayerID].PixelX()
+	switch {
+	case x < targetX-p.tileSize/2:
+		b.dir = 1
+	case x > targetX+p.tileSize/2:
+		b.dir = -1
+	default:
+		b.dir = 0
+	}
+
+	if x == b.lastX && b.dir != 0 {
+		b.stuck++
+	} else {
+		b.stuck = 0
+	}
+	b.lastX = x
+	if b.stuck > botStuckFrames*4 {
+		b.dir = -b.dir
+		b.hold = botDetourFrames
+		b.stuck = 0
+	}
+	return b.stuck >= botStuckFrames || b.rng.Intn(90) == 0
+}
+
+// RunBot plays the stage headlessly with a scripted bot until the
+// duration or frame limit is up, starting over after each run, and checks
+// the world invariants (see ecs.CheckInvariants) every frame. The first
+// violation is written as a report with the world dump and the run's
+// replay, and returned.
+func (p *Playing) RunBot(opts BotOptions) error {
+	p.bot = newBot(opts.Mode, opts.Seed)
+	defer func() { p.bot = nil }()
+	p.startBotRun(opts.Seed)
+
+	start := time.Now()
+	nextLog := start.Add(botLogInterval)
+	runs, frames := 1, 0
+	runFrames := fixedpoint.SecondsToFrames(botRunSeconds)
+	for time.Since(start) < opts.Duration && (opts.MaxFrames == 0 || frames < opts.MaxFrames) {
+		p.stepPlaying(p.bot.next(p), ui.ArrowSelectInput{})
+		p.processEvents()
+		frames++
+
+		stageW, stageH := p.stage.Width*p.tileSize, p.stage.Height*p.tileSize
+		if err := ecs.CheckInvariants(p.world, stageW, stageH); err != nil {
+			err = fmt.Errorf("run %d (seed %d), frame %d: %w", runs, p.seed, p.stats.Frames, err)
+			p.writeBotReport(err, opts)
+			return err
+		}
+
+		switch p.state {
+		case state.StateDialogue:
+			p.dialogue.Stop()
+			p.state = state.StatePlaying
+		case state.StateShop:
+			p.state = state.StatePlaying
+		case state.StateGameOver, state.StateStageClear:
+			p.startBotRun(p.bot.rng.Int63())
+			runs++
+		default:
+			if p.stats.Frames >= runFrames {
+				p.startBotRun(p.bot.rng.Int63())
+				runs++
+			}
+		}
+
+		if now := time.Now(); now.After(nextLog) {
+			logging.Game.Infof("Bot: %d frames, %d runs, no violations", frames, runs)
+			nextLog = now.Add(botLogInterval)
+		}
+	}
+	logging.Game.Infof("Bot finished: %d frames, %d runs, no violations", frames, runs)
+	return nil
+}
+
+// startBotRun restarts the stage with seed and records the run
+func (p *Playing) startBotRun(seed int64) {
+	p.restartWithSeed(seed)
+	p.recorder = NewRecorder(seed, p.stageCfg.ID)
+}
+
+// writeBotReport dumps the world and the run's replay for a violation
+func (p *Playing) writeBotReport(violation error, opts BotOptions) {
+	if opts.DumpDir == "" {
+		return
+	}
+	r := crash.NewReport(violation, debug.Stack(), opts.Configs)
+	p.CrashReport(r)
+	folder, err := r.Write(opts.DumpDir)
+	if err != nil {
+		logging.Game.Errorf("Failed to write bot report: %v", err)
+		return
+	}
+	logging.Game.Errorf("Bot report written to %s", folder)
+}
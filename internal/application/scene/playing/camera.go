@@ -0,0 +1,94 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// bossFrameMargin is how close (pixels) the player may get to the screen
+// edge while the camera frames a large enemy
+const bossFrameMargin = 32
+
+// cameraFocus returns the world point the camera centers on: the player,
+// pulled toward the nearest large enemy on screen so both stay in frame
+func (p *Playing) cameraFocus() (int, int) {
+	px, py := ecs.HitboxCenter(p.world, p.world.PlayerID)
+
+	boss := p.nearestLargeEnemy(px, py)
+	if boss == 0 {
+		return px, py
+	}
+
+	// Midpoint, but never so far that the player leaves the margin
+	bx, by := ecs.HitboxCenter(p.world, boss)
+	reachX := max(0, p.screenW/2-bossFrameMargin)
+	reachY := max(0, p.screenH/2-bossFrameMargin)
+	focusX := px + max(-reachX, min(reachX, (bx-px)/2))
+	focusY := py + max(-reachY, min(reachY, (by-py)/2))
+	return focusX, focusY
+}
+
+// nearestLargeEnemy returns the closest enemy wider and taller than a tile
+// within a screen of (x, y) (0 = none)
+func (p *Playing) nearestLargeEnemy(x, y int) ecs.EntityID {
+	var best ecs.EntityID
+	bestDist := 0
+	for _, id := range p.world.Query(ecs.TagEnemy) {
+		hb := p.world.Hitbox[id]
+		if hb.Width <= p.tileSize || hb.Height <= p.tileSize {
+			continue
+		}
+		ex, ey := ecs.HitboxCenter(p.world, id)
+		dx, dy := max(ex-x, x-ex), max(ey-y, y-ey)
+		if dx > p.screenW || dy > p.screenH {
+			continue
+		}
+		if dist := dx + dy; best == 0 || dist < bestDist {
+			best, bestDist = id, dist
+		}
+	}
+	return best
+}
+
+// cameraPeek shifts the camera vertically while the player looks up or down
+type cameraPeek struct {
+	held   int     // frames Up or Down has been held while standing still
+	offset float64 // current vertical shift (pixels, negative = up)
+}
+
+// updateCameraPeek shifts the camera toward the held direction once Up or
+// Down has been held long enough while standing still on the ground, and
+// back as soon as the player moves or lets go
+func (p *Playing) updateCameraPeek(input inputState) {
+	cfg := p.config.Physics.Camera.Peek
+	id := p.world.PlayerID
+
+	dir := 0
+	if input.Up {
+		dir--
+	}
+	if input.Down {
+		dir++
+	}
+	still := p.world.Movement[id].OnGround && p.world.Velocity[id].X == 0 && !p.world.Dash[id].Active
+	if dir == 0 || !still {
+		p.peek.held = 0
+	} else {
+		p.peek.held++
+	}
+
+	target := 0.0
+	if dir != 0 && p.peek.held > fixedpoint.SecondsToFrames(cfg.Delay) {
+		target = float64(dir) * cfg.Distance
+	}
+
+	step := fixedpoint.Sim.PerFrame(cfg.Speed)
+	switch {
+	case step <= 0:
+		p.peek.offset = target
+	case p.peek.offset < target:
+		p.peek.offset = min(target, p.peek.offset+step)
+	case p.peek.offset > target:
+		p.peek.offset = max(target, p.peek.offset-step)
+	}
+}
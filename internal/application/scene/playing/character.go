@@ -0,0 +1,69 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// arrowTypes maps config arrow names to arrow types
+var arrowTypes = map[string]ecs.ArrowType{
+	"gray":   ecs.ArrowGray,
+	"red":    ecs.ArrowRed,
+	"blue":   ecs.ArrowBlue,
+	"purple": ecs.ArrowPurple,
+}
+
+// buildPlayerProfile converts a character config to the ECS player profile.
+// Unknown arrow names are skipped.
+func buildPlayerProfile(ch config.PlayerConfig) ecs.PlayerProfile {
+	toHitbox := func(r config.Rect) ecs.Hitbox {
+		return ecs.Hitbox{OffsetX: r.OffsetX, OffsetY: r.OffsetY, Width: r.Width, Height: r.Height}
+	}
+
+	var arrows []ecs.ArrowType
+	for _, name := range ch.Abilities.Arrows {
+		t, ok := arrowTypes[name]
+		if !ok {
+			logging.Config.Warnf("Unknown arrow type for character %s: %s", ch.ID, name)
+			continue
+		}
+		arrows = append(arrows, t)
+	}
+
+	var crouch ecs.HitboxTrapezoid
+	if c := ch.Hitbox.Crouch; c != nil {
+		crouch = ecs.HitboxTrapezoid{
+			Head:        toHitbox(c.Head),
+			Body:        toHitbox(c.Body),
+			Feet:        toHitbox(c.Feet),
+			SpriteWidth: ch.Sprite.FrameWidth,
+		}
+	}
+
+	return ecs.PlayerProfile{
+		Hitbox: ecs.HitboxTrapezoid{
+			Head:        toHitbox(ch.Hitbox.Head),
+			Body:        toHitbox(ch.Hitbox.Body),
+			Feet:        toHitbox(ch.Hitbox.Feet),
+			SpriteWidth: ch.Sprite.FrameWidth,
+		},
+		Crouch:       crouch,
+		MaxHealth:    ch.Stats.MaxHealth,
+		Arrows:       arrows,
+		Ammo:         ch.Stats.StartingAmmo,
+		DashLevel:    ch.Abilities.DashLevel,
+		MagnetLevel:  ch.Abilities.MagnetLevel,
+		Summon:       ch.Abilities.Summon,
+		Mass:         fixedpoint.ToPct(ch.Stats.Mass),
+		GravityScale: fixedpoint.ToPct(ch.Stats.GravityScale),
+	}
+}
+
+// applyCharacter selects the character from settings or the replay being
+// played back (empty = first configured). It takes effect when the player
+// is created on restart.
+func (p *Playing) applyCharacter() {
+	p.character = p.config.Entities.Character(p.runSettings().character)
+}
@@ -0,0 +1,48 @@
+package playing
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/clip"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// SetClipCapture enables recent-frame capture with the given buffer (nil disables).
+// When enabled, F10 exports the last few seconds as an animated GIF.
+func (p *Playing) SetClipCapture(b *clip.Buffer) {
+	p.clips = b
+}
+
+// captureClip adds the drawn screen to the clip buffer when a capture is due
+func (p *Playing) captureClip(screen *ebiten.Image) {
+	if !p.clips.Due() {
+		return
+	}
+	if p.clipBuffer == nil {
+		p.clipBuffer = image.NewRGBA(screen.Bounds())
+	}
+	screen.ReadPixels(p.clipBuffer.Pix)
+	p.clips.Add(p.clipBuffer)
+}
+
+// updateClipExport saves the clip buffer as a GIF on F10. When recording,
+// the replay is saved as well: it replays the whole run at a fraction of
+// the size.
+func (p *Playing) updateClipExport() {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+		return
+	}
+	if p.clips != nil {
+		filename := clip.GenerateFilename()
+		if err := p.clips.SaveGIF(filename); err != nil {
+			logging.Scene.Errorf("Failed to save clip: %v", err)
+		} else {
+			logging.Scene.Infof("Clip saved: %s (%.1fs)", filename, p.clips.Duration().Seconds())
+		}
+	}
+	if p.recorder != nil {
+		p.saveRecording()
+	}
+}
@@ -0,0 +1,152 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+const (
+	combatLogLines  = 6   // lines kept on screen
+	combatLogFrames = 300 // frames a line stays on screen
+	combatLogFade   = 60  // fade-out at the end of a line's time
+)
+
+// Combat log colors
+var (
+	colorLogDealt  = color.RGBA{255, 255, 255, 255}
+	colorLogKill   = color.RGBA{255, 215, 0, 255}
+	colorLogTaken  = color.RGBA{255, 100, 100, 255}
+	colorLogPickup = color.RGBA{120, 230, 140, 255}
+)
+
+// combatLog is the kill feed: the last few combat events on screen (when
+// the setting is on) and every one of them in the session's log file (when
+// the host gave one)
+type combatLog struct {
+	lines []combatLogLine // oldest first
+	out   io.Writer
+}
+
+// combatLogLine is one on-screen entry
+type combatLogLine struct {
+	text  string
+	color color.RGBA
+	age   int // frames since logged
+}
+
+// SetCombatLog writes every combat log entry of the session to w, e.g. a
+// file (nil = none)
+func (p *Playing) SetCombatLog(w io.Writer) {
+	p.combatLog.out = w
+}
+
+// combatLogEntry returns the combat log line of an event (ok is false for
+// events that aren't logged)
+func combatLogEntry(ev ecs.Event) (text string, c color.RGBA, ok bool) {
+	switch ev.Type {
+	case ecs.EventEnemyHit, ecs.EventDashHit, ecs.EventStomp:
+		return i18n.Tf("log.hit", ev.Entity, ev.Amount), colorLogDealt, true
+	case ecs.EventEnemyKilled:
+		return i18n.Tf("log.kill", ev.Entity), colorLogKill, true
+	case ecs.EventBossKilled:
+		return i18n.T("log.bossKill"), colorLogKill, true
+	case ecs.EventPlayerDamaged:
+		return i18n.Tf("log.taken", ev.Amount), colorLogTaken, true
+	case ecs.EventAttackBlocked:
+		return i18n.Tf("log.blocked", ev.Amount), colorLogDealt, true
+	case ecs.EventGoldCollected:
+		return i18n.Tf("log.gold", ev.Amount), colorLogPickup, true
+	case ecs.EventHealthCollected:
+		return i18n.Tf("toast.health", ev.Amount), colorLogPickup, true
+	case ecs.EventAmmoCollected:
+		return i18n.Tf("toast.ammo", ev.Amount), colorLogPickup, true
+	case ecs.EventTreasureCollected:
+		return i18n.Tf("toast.treasure", ev.Amount), colorLogPickup, true
+	}
+	return "", c, false
+}
+
+// logCombat adds an event to the combat log. The file gets a line for each
+// stage start too, so runs can be told apart.
+func (p *Playing) logCombat(ev ecs.Event) {
+	if ev.Type == ecs.EventStageStarted {
+		p.combatLog.lines = p.combatLog.lines[:0]
+		p.writeCombatLog(fmt.Sprintf("--- %s (seed %d) ---", p.stageCfg.ID, p.seed))
+		return
+	}
+	text, c, ok := combatLogEntry(ev)
+	if !ok {
+		return
+	}
+	p.writeCombatLog(fmt.Sprintf("%s %s", formatLogTime(p.stats.Frames), text))
+
+	log := &p.combatLog
+	if len(log.lines) == combatLogLines {
+		log.lines = append(log.lines[:0], log.lines[1:]...)
+	}
+	log.lines = append(log.lines, combatLogLine{text: text, color: c})
+}
+
+// writeCombatLog appends a line to the session's log file
+func (p *Playing) writeCombatLog(line string) {
+	if p.combatLog.out == nil {
+		return
+	}
+	if _, err := fmt.Fprintln(p.combatLog.out, line); err != nil {
+		logging.Scene.Errorf("Failed to write combat log: %v", err)
+		p.combatLog.out = nil
+	}
+}
+
+// formatLogTime formats a run time in frames as m:ss.cc
+func formatLogTime(frames int) string {
+	cs := fixedpoint.RoundHalfUp(fixedpoint.FramesToSeconds(frames) * 100)
+	return fmt.Sprintf("%d:%02d.%02d", cs/6000, cs/100%60, cs%100)
+}
+
+// updateCombatLog ages the on-screen lines and drops the expired ones
+func (p *Playing) updateCombatLog() {
+	log := &p.combatLog
+	kept := log.lines[:0]
+	for _, line := range log.lines {
+		line.age++
+		if line.age < combatLogFrames {
+			kept = append(kept, line)
+		}
+	}
+	log.lines = kept
+}
+
+// showCombatLog reports whether the kill feed is on screen
+func (p *Playing) showCombatLog() bool {
+	return p.settings != nil && p.settings.CombatLog && !p.hud.combatLog.Hidden
+}
+
+// drawCombatLog draws the on-screen lines, newest at the bottom
+func (p *Playing) drawCombatLog(dst *ebiten.Image) {
+	lines := p.combatLog.lines
+	if !p.showCombatLog() || len(lines) == 0 {
+		return
+	}
+	lineH := ui.LineHeight(ui.StyleHUD)
+	x, y := place(p.hud.combatLog, 0, lineH*combatLogLines, dst.Bounds())
+	y += lineH * float64(combatLogLines-len(lines))
+	for _, line := range lines {
+		style := textStyle(line.color)
+		if left := combatLogFrames - line.age; left < combatLogFade {
+			alpha := float64(left) / combatLogFade
+			style.Color = color.RGBA{uint8(float64(line.color.R) * alpha), uint8(float64(line.color.G) * alpha), uint8(float64(line.color.B) * alpha), uint8(float64(line.color.A) * alpha)}
+			style.Outline = color.RGBA{0, 0, 0, uint8(255 * alpha)}
+		}
+		ui.Draw(dst, line.text, x, y, style)
+		y += lineH
+	}
+}
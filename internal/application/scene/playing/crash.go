@@ -0,0 +1,22 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/crash"
+)
+
+// crashScene identifies the run in crash reports
+type crashScene struct {
+	Stage string
+	Seed  int64
+	State string
+}
+
+// CrashReport adds the run, world and replay buffer to a crash report
+// (implements crash.Source)
+func (p *Playing) CrashReport(r *crash.Report) {
+	r.Attach("scene", crashScene{Stage: p.stageCfg.ID, Seed: p.seed, State: p.state.String()})
+	r.Attach("world", p.world.Dump())
+	if p.recorder != nil {
+		r.Attach("replay", p.recorder.GetData())
+	}
+}
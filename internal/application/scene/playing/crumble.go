@@ -0,0 +1,23 @@
+package playing
+
+import "github.com/younwookim/mg/internal/ecs"
+
+// crumbleFallFrames is how long a fallen tile is drawn dropping away
+const crumbleFallFrames = 20
+
+// crumbleOffset returns where a crumbling tile is drawn relative to its
+// slot: jittering while it shakes, dropping away once it falls.
+// visible is false once the tile has dropped out of sight.
+func (p *Playing) crumbleOffset(tx, ty int) (dx, dy float64, visible bool) {
+	c, ok := p.world.Crumbles[ecs.TileCoord{X: tx, Y: ty}]
+	switch {
+	case !ok:
+		return 0, 0, true
+	case c.State == ecs.CrumbleShaking:
+		return float64(c.Age%3 - 1), 0, true
+	case c.Age < crumbleFallFrames:
+		return 0, float64(c.Age * c.Age / 4), true
+	default:
+		return 0, 0, false
+	}
+}
@@ -0,0 +1,195 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Debug overlay colors
+var (
+	colorDebugPanel   = color.RGBA{0, 0, 0, 160}
+	colorDebugHitbox  = color.RGBA{255, 0, 255, 160}
+	colorDebugContact = color.RGBA{0, 255, 255, 255}
+)
+
+// Log panel layout (ui's bundled font is 6px wide with 14px lines)
+const (
+	debugLogLines   = 4 // entries shown at once
+	debugCharWidth  = 6
+	debugLineHeight = 14
+)
+
+// SetDebug enables debug mode (the -debug flag).
+// In debug mode the overlay starts visible and F3 toggles it, and the
+// world history is kept for rewinding (F7).
+func (p *Playing) SetDebug(enabled bool) {
+	p.debugEnabled = enabled
+	p.debugVisible = enabled
+	p.rewind = nil
+	if enabled {
+		p.rewind = ecs.NewRewind(rewindFrames)
+	}
+}
+
+// updateDebugToggle handles the F3 overlay toggle (debug mode only) and
+// PageUp/PageDown scrolling of the log panel
+func (p *Playing) updateDebugToggle() {
+	if p.debugEnabled && inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		p.debugVisible = !p.debugVisible
+	}
+	if !p.debugVisible {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageUp) {
+		p.debugLogScroll++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageDown) {
+		p.debugLogScroll--
+	}
+	p.debugLogScroll = max(0, min(p.debugLogScroll, len(logging.Default().Entries())-debugLogLines))
+}
+
+// drawDebugOverlay renders physics and ECS introspection on top of the world
+func (p *Playing) drawDebugOverlay(screen *ebiten.Image, camX, camY int) {
+	p.drawDebugHitboxes(screen, camX, camY)
+	p.drawDebugContacts(screen, camX, camY)
+	p.drawDebugEnemyStates(screen, camX, camY)
+	if p.inspected != 0 {
+		p.drawInspector(screen, camX, camY)
+	} else {
+		p.drawDebugPanel(screen)
+	}
+	p.drawInputTimeline(screen)
+	p.drawDebugLog(screen)
+}
+
+// drawDebugPanel draws the text panel with frame rate, counts and player state
+func (p *Playing) drawDebugPanel(screen *ebiten.Image) {
+	id := p.world.PlayerID
+	vel := p.world.Velocity[id]
+	mov := p.world.Movement[id]
+	dash := p.world.Dash[id]
+	playerData := p.world.PlayerData[id]
+	cc := p.world.CrowdControl[id]
+	pos := p.world.Position[id]
+
+	lines := fmt.Sprintf(
+		"FPS %.1f  TPS %.1f\n"+
+			"ENT P:%d E:%d A:%d G:%d\n"+
+			"POS %d,%d px\n"+
+			"VEL %d,%d IU/ss\n"+
+			"    %.0f,%.0f px/s\n"+
+			"GND:%t COY:%d BUF:%d\n"+
+			"DASH:%t T:%d CD:%d\n"+
+			"IFR:%d STUN:%d KB:%d\n"+
+			"TIME %d%%>%d%% STOP:%d",
+		ebiten.ActualFPS(), ebiten.ActualTPS(),
+		len(p.world.IsPlayer), len(p.world.IsEnemy), len(p.world.IsProjectile), len(p.world.IsPickup),
+		pos.PixelX(), pos.PixelY(),
+		vel.X, vel.Y,
+		fixedpoint.FromIUPerSubstep(vel.X), fixedpoint.FromIUPerSubstep(vel.Y),
+		mov.OnGround, playerData.CoyoteTimer, playerData.JumpBufferTimer,
+		dash.Active, dash.Timer, dash.Cooldown,
+		cc.IframeTimer, cc.StunTimer, cc.KnockbackTimer,
+		p.world.TimeScale.Pct(), p.world.TimeScale.Target(), p.world.TimeScale.HitstopFrames(),
+	)
+
+	panelX := float64(p.screenW - 150)
+	ui.FillRect(screen, panelX, 16, 150, 9*debugLineHeight+4, colorDebugPanel)
+	ui.Draw(screen, lines, panelX+4, 18, ui.StyleDefault)
+}
+
+// drawDebugLog draws the most recent log entries (scrolled back by PageUp)
+func (p *Playing) drawDebugLog(screen *ebiten.Image) {
+	entries := logging.Default().Entries()
+	end := len(entries) - p.debugLogScroll
+	start := max(0, end-debugLogLines)
+	maxChars := (p.screenW - 8) / debugCharWidth
+
+	var lines strings.Builder
+	for _, e := range entries[start:max(start, end)] {
+		line := fmt.Sprintf("%c %s: %s", strings.ToUpper(e.Level.String())[0], e.Tag, e.Message)
+		if len(line) > maxChars {
+			line = line[:maxChars]
+		}
+		lines.WriteString(line + "\n")
+	}
+
+	panelH := float64(debugLogLines*debugLineHeight + 4)
+	panelY := float64(p.screenH) - panelH
+	ui.FillRect(screen, 0, panelY, float64(p.screenW), panelH, colorDebugPanel)
+	ui.Draw(screen, lines.String(), 4, panelY+2, ui.StyleDefault)
+}
+
+// drawDebugHitboxes outlines the body hitbox of every entity
+func (p *Playing) drawDebugHitboxes(screen *ebiten.Image, camX, camY int) {
+	for id, hb := range p.world.Hitbox {
+		pos := p.world.Position[id]
+		x := float64(pos.PixelX() + hb.OffsetX - camX)
+		y := float64(pos.PixelY() + hb.OffsetY - camY)
+		drawRectOutline(screen, x, y, float64(hb.Width), float64(hb.Height), colorDebugHitbox)
+	}
+
+	id := p.world.PlayerID
+	pos := p.world.Position[id]
+	facing := p.world.Facing[id]
+	hitbox := p.world.HitboxTrapezoid[id]
+	for _, hb := range []ecs.Hitbox{hitbox.Head, hitbox.Body, hitbox.Feet} {
+		x, y, w, h := hb.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+		drawRectOutline(screen, float64(x-camX), float64(y-camY), float64(w), float64(h), colorDebugHitbox)
+	}
+}
+
+// drawDebugContacts highlights the player hitbox edges that are touching solid tiles
+func (p *Playing) drawDebugContacts(screen *ebiten.Image, camX, camY int) {
+	id := p.world.PlayerID
+	pos := p.world.Position[id]
+	mov := p.world.Movement[id]
+	facing := p.world.Facing[id]
+	hitbox := p.world.HitboxTrapezoid[id]
+
+	if mov.OnGround {
+		x, y, w, h := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+		ui.FillRect(screen, float64(x-camX), float64(y+h-1-camY), float64(w), 2, colorDebugContact)
+	}
+	if mov.OnCeiling {
+		x, y, w, _ := hitbox.Head.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+		ui.FillRect(screen, float64(x-camX), float64(y-1-camY), float64(w), 2, colorDebugContact)
+	}
+	x, y, w, h := hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+	if mov.OnWallLeft {
+		ui.FillRect(screen, float64(x-1-camX), float64(y-camY), 2, float64(h), colorDebugContact)
+	}
+	if mov.OnWallRight {
+		ui.FillRect(screen, float64(x+w-1-camX), float64(y-camY), 2, float64(h), colorDebugContact)
+	}
+}
+
+// drawDebugEnemyStates prints AI type and timers above each enemy
+func (p *Playing) drawDebugEnemyStates(screen *ebiten.Image, camX, camY int) {
+	for id := range p.world.IsEnemy {
+		pos := p.world.Position[id]
+		ai := p.world.AI[id]
+		mov := p.world.Movement[id]
+		health := p.world.Health[id]
+
+		label := fmt.Sprintf("%s %d\nH%d A%d G:%t", ai.Type, health.Current, p.world.CrowdControl[id].StunTimer, ai.AttackTimer, mov.OnGround)
+		ui.Draw(screen, label, float64(pos.PixelX()-camX-8), float64(pos.PixelY()-camY-28), ui.StyleHUD)
+	}
+}
+
+func drawRectOutline(screen *ebiten.Image, x, y, w, h float64, c color.Color) {
+	ebitenutil.DrawLine(screen, x, y, x+w, y, c)
+	ebitenutil.DrawLine(screen, x, y+h, x+w, y+h, c)
+	ebitenutil.DrawLine(screen, x, y, x, y+h, c)
+	ebitenutil.DrawLine(screen, x+w, y, x+w, y+h, c)
+}
@@ -0,0 +1,227 @@
+package playing
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/dialogue"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// Dialogue box colors
+var (
+	colorDialogueBox     = color.RGBA{10, 10, 30, 220}
+	colorDialogueBorder  = color.RGBA{200, 200, 220, 255}
+	colorDialogueSpeaker = color.RGBA{255, 215, 0, 255}
+)
+
+const (
+	triggerTypeDialogue = "dialogue"
+
+	portraitSize  = 40
+	dialogueBoxH  = 72
+	dialoguePad   = 6
+	dialogueWrapW = 38 // halfwidth characters per text line
+)
+
+// buildDialogue creates the dialogue runner and portrait colors from config
+func buildDialogue(cfg *config.GameConfig) (*dialogue.Runner, map[string]color.RGBA) {
+	conversations := map[string]dialogue.Conversation{}
+	portraits := map[string]color.RGBA{}
+	if cfg.Dialogues == nil {
+		return dialogue.NewRunner(conversations, 0), portraits
+	}
+
+	for id, d := range cfg.Dialogues.Dialogues {
+		conv := dialogue.Conversation{ID: id}
+		for _, page := range d.Pages {
+			conv.Pages = append(conv.Pages, dialogue.Page{Speaker: page.Speaker, Portrait: page.Portrait, Text: page.Text})
+		}
+		for _, choice := range d.Choices {
+			conv.Choices = append(conv.Choices, dialogue.Choice{Text: choice.Text, Next: choice.Next})
+		}
+		conversations[id] = conv
+	}
+	for id, portrait := range cfg.Dialogues.Portraits {
+		portraits[id] = parseHexColor(portrait.Color)
+	}
+
+	return dialogue.NewRunner(conversations, cfg.Dialogues.TypewriterSpeed), portraits
+}
+
+// startDialogue switches to the dialogue state if the conversation exists
+func (p *Playing) startDialogue(id string) bool {
+	if !p.dialogue.Start(id) {
+		return false
+	}
+	p.state = state.StateDialogue
+	return true
+}
+
+// checkDialogueTriggers starts a conversation from a stage trigger area or
+// interacts with a nearby NPC. Returns true if the simulation should pause.
+func (p *Playing) checkDialogueTriggers(input inputState) bool {
+	pos := p.world.Position[p.world.PlayerID]
+	facing := p.world.Facing[p.world.PlayerID]
+	hitbox := p.world.HitboxTrapezoid[p.world.PlayerID]
+	bx, by, bw, bh := hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+
+	touches := p.world.Collision.Collides(ecs.LayerPlayer, ecs.LayerTrigger)
+	for i, trigger := range p.stageCfg.Triggers {
+		if !touches || trigger.Type != triggerTypeDialogue || (trigger.Once && p.firedTriggers[i]) {
+			continue
+		}
+		r := trigger.Rect
+		if bx < r.X+r.W && bx+bw > r.X && by < r.Y+r.H && by+bh > r.Y {
+			p.firedTriggers[i] = true
+			if p.startDialogue(trigger.Target) {
+				return true
+			}
+		}
+	}
+
+	if !input.Interact {
+		return false
+	}
+	return p.interactNPC()
+}
+
+// updateDialogue feeds input to the running conversation (simulation is paused)
+func (p *Playing) updateDialogue() {
+	p.dialogue.Update(dialogue.Input{
+		Advance: inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(p.keys.Interact) ||
+			inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+		Up:   inpututil.IsKeyJustPressed(p.keys.Up) || inpututil.IsKeyJustPressed(ebiten.KeyUp),
+		Down: inpututil.IsKeyJustPressed(p.keys.Down) || inpututil.IsKeyJustPressed(ebiten.KeyDown),
+	})
+	if !p.dialogue.Active() {
+		p.state = state.StatePlaying
+	}
+}
+
+// drawDialogueBox draws the text box with portrait, speaker, typed text and choices
+func (p *Playing) drawDialogueBox(screen *ebiten.Image) {
+	page := p.dialogue.Page()
+
+	boxX := float64(dialoguePad)
+	boxY := float64(p.screenH - dialogueBoxH - dialoguePad)
+	boxW := float64(p.screenW - 2*dialoguePad)
+	ui.FillRect(screen, boxX, boxY, boxW, dialogueBoxH, colorDialogueBox)
+	drawRectOutline(screen, boxX, boxY, boxW, dialogueBoxH, colorDialogueBorder)
+
+	textX := boxX + dialoguePad
+	if c, ok := p.portraits[page.Portrait]; ok {
+		ui.FillRect(screen, textX, boxY+dialoguePad, portraitSize, portraitSize, c)
+		drawRectOutline(screen, textX, boxY+dialoguePad, portraitSize, portraitSize, colorDialogueBorder)
+		textX += portraitSize + dialoguePad
+	}
+
+	textY := boxY + dialoguePad
+	if page.Speaker != "" {
+		ui.Draw(screen, page.Speaker, textX, textY, ui.Style{Color: colorDialogueSpeaker})
+		textY += ui.LineHeight(ui.StyleDefault)
+	}
+	ui.Draw(screen, wrapText(p.dialogue.VisibleText(), dialogueWrapW), textX, textY, ui.StyleDefault)
+
+	if choices := p.dialogue.Choices(); len(choices) > 0 {
+		p.drawDialogueChoices(screen, choices, boxX+boxW, boxY)
+		return
+	}
+	if p.dialogue.PageComplete() {
+		hint := i18n.Tf("dialogue.continue", p.keys.Interact)
+		ui.Draw(screen, hint, boxX+boxW-dialoguePad, boxY+dialogueBoxH-16, ui.Style{Color: colorDialogueBorder, Align: ui.AlignRight})
+	}
+}
+
+// drawDialogueChoices lists the choices above the right edge of the text box
+func (p *Playing) drawDialogueChoices(screen *ebiten.Image, choices []dialogue.Choice, right, bottom float64) {
+	lineH := ui.LineHeight(ui.StyleDefault)
+	w := 0.0
+	for _, c := range choices {
+		cw, _ := ui.Measure("> "+c.Text, ui.StyleDefault)
+		w = max(w, cw)
+	}
+	w += 2 * dialoguePad
+	h := float64(len(choices))*lineH + 2*dialoguePad
+
+	x := right - w
+	y := bottom - h - 2
+	ui.FillRect(screen, x, y, w, h, colorDialogueBox)
+	drawRectOutline(screen, x, y, w, h, colorDialogueBorder)
+
+	for i, c := range choices {
+		label := "  " + c.Text
+		style := ui.StyleDefault
+		if i == p.dialogue.Selected() {
+			label = "> " + c.Text
+			style.Color = colorDialogueSpeaker
+		}
+		ui.Draw(screen, label, x+dialoguePad, y+dialoguePad+float64(i)*lineH, style)
+	}
+}
+
+// wrapText breaks s into lines of at most width halfwidth cells at spaces.
+// Fullwidth runes count as two cells; words longer than a line are split.
+func wrapText(s string, width int) string {
+	var b strings.Builder
+	col := 0
+	for i, word := range strings.Split(s, " ") {
+		w := textCells(word)
+		if i > 0 {
+			if col+1+w > width {
+				b.WriteByte('\n')
+				col = 0
+			} else {
+				b.WriteByte(' ')
+				col++
+			}
+		}
+		for _, r := range word {
+			rw := runeCells(r)
+			if col+rw > width {
+				b.WriteByte('\n')
+				col = 0
+			}
+			b.WriteRune(r)
+			col += rw
+		}
+	}
+	return b.String()
+}
+
+func textCells(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeCells(r)
+	}
+	return n
+}
+
+// runeCells returns the display width of r (CJK and Hangul are fullwidth)
+func runeCells(r rune) int {
+	if r >= 0x1100 && (r <= 0x115F || (r >= 0x2E80 && r <= 0xA4CF) || (r >= 0xAC00 && r <= 0xD7A3) || (r >= 0xF900 && r <= 0xFAFF) || (r >= 0xFF00 && r <= 0xFF60)) {
+		return 2
+	}
+	return 1
+}
+
+// parseHexColor parses "#rrggbb" or "#rrggbbaa" (invalid input returns
+// opaque gray)
+func parseHexColor(s string) color.RGBA {
+	hex := strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	switch {
+	case err == nil && len(hex) == 6:
+		return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 255}
+	case err == nil && len(hex) == 8:
+		return color.RGBAModel.Convert(color.NRGBA{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}).(color.RGBA)
+	}
+	return color.RGBA{128, 128, 128, 255}
+}
@@ -0,0 +1,168 @@
+package playing
+
+import (
+	"math"
+
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/domain/entity"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+const (
+	spawnIntervalFrames = 30 // base frames between periodic enemy spawns
+	maxSpawnedEnemies   = 10 // periodic spawns stop at this many live enemies
+)
+
+// ConfigLoader loads the config and stage with a difficulty's override
+// files applied
+type ConfigLoader func(difficulty string) (*config.GameConfig, *config.StageConfig, error)
+
+// SetConfigLoader sets how the config is reloaded when the difficulty
+// setting changes, so difficulty override files take effect. loaded is the
+// difficulty the current config was loaded with.
+func (p *Playing) SetConfigLoader(loaded string, load ConfigLoader) {
+	p.configDifficulty = loaded
+	p.loadConfig = load
+}
+
+// reloadConfig reloads the config and stage if the difficulty setting no
+// longer matches the one they were loaded with. Reports whether they changed.
+func (p *Playing) reloadConfig() bool {
+	difficulty := p.runSettings().difficulty
+	if p.loadConfig == nil || (p.settings == nil && p.replaySettings == nil) || difficulty == p.configDifficulty {
+		return false
+	}
+	cfg, stageCfg, err := p.loadConfig(difficulty)
+	if err != nil {
+		logging.Config.Errorf("Failed to reload config: %v", err)
+		return false
+	}
+	p.configDifficulty = difficulty
+	if p.tower != nil {
+		// The tower stays on its floor with the new base config
+		p.tower.base = cfg
+		cfg, stageCfg = p.tower.floor.Apply(cfg, p.tower.cfg), p.tower.floor.Stage
+	}
+	p.useConfig(cfg, stageCfg)
+	return true
+}
+
+// SetStage switches to another stage, with cfg loaded for it (its override
+// files applied), and starts it over
+func (p *Playing) SetStage(cfg *config.GameConfig, stageCfg *config.StageConfig, stage *entity.Stage) {
+	p.tower = nil
+	p.useConfig(cfg, stageCfg)
+	p.stage = stage
+	p.tileSize = stage.TileSize
+	p.firedTriggers = map[int]bool{}
+	p.restart()
+}
+
+// useConfig replaces the config and stage config and what is built from them
+func (p *Playing) useConfig(cfg *config.GameConfig, stageCfg *config.StageConfig) {
+	p.config = cfg
+	p.stageCfg = stageCfg
+	p.arrowCfg = buildArrowConfig(cfg)
+	p.eliteTints = buildEliteTints(cfg)
+	p.lighting = buildLighting(cfg, stageCfg)
+	p.hud = buildHUDLayout(cfg)
+}
+
+// applyDifficulty selects the difficulty profile from settings or the
+// replay being played back (empty = config default), raised to the tower
+// floor on a tower run. It is read when enemies spawn and when the player
+// is hit.
+func (p *Playing) applyDifficulty() {
+	p.difficulty = p.config.Difficulty.Profile(p.runSettings().difficulty)
+	if p.tower != nil {
+		p.difficulty = p.tower.floor.Escalate(p.difficulty, p.tower.cfg)
+	}
+}
+
+// scaleInt multiplies a tuning value by a difficulty multiplier, rounding
+// to the nearest integer (0 multiplier = unchanged)
+func scaleInt(v int, mult float64) int {
+	if mult <= 0 {
+		return v
+	}
+	return int(math.Round(float64(v) * mult))
+}
+
+// scaleEnemyConfig applies the difficulty to an enemy before it spawns
+func (p *Playing) scaleEnemyConfig(cfg *config.EnemyConfig) (maxHealth, contactDamage, attackCooldown int) {
+	d := p.difficulty
+	maxHealth = max(1, scaleInt(cfg.Stats.MaxHealth, d.EnemyHealth))
+	contactDamage = scaleInt(cfg.Stats.ContactDamage, d.ContactDamage)
+	baseCooldown := ecs.DefaultAttackCooldown()
+	if arrow := p.config.Entities.Projectiles["enemyArrow"]; arrow.Physics.Cooldown > 0 {
+		baseCooldown = fixedpoint.SecondsToFrames(arrow.Physics.Cooldown)
+	}
+	if cfg.AI.AttackCooldown > 0 {
+		baseCooldown = fixedpoint.SecondsToFrames(cfg.AI.AttackCooldown)
+	}
+	attackCooldown = max(1, scaleInt(baseCooldown, d.AttackCooldown))
+	return maxHealth, contactDamage, attackCooldown
+}
+
+// spawnInterval returns the frames between periodic enemy spawns
+func (p *Playing) spawnInterval() int {
+	if p.difficulty.SpawnRate <= 0 {
+		return spawnIntervalFrames
+	}
+	return max(1, int(math.Round(spawnIntervalFrames/p.difficulty.SpawnRate)))
+}
+
+// playerIframes returns the invincibility frames after the player is hit
+func (p *Playing) playerIframes() int {
+	return scaleInt(fixedpoint.SecondsToFrames(p.config.Physics.Combat.Iframes), p.difficulty.PlayerIframes)
+}
+
+// runSettings are the settings that change how a run plays, recorded with
+// its replay
+type runSettings struct {
+	difficulty string // difficulty profile ("" = config default)
+	character  string // character ID ("" = first configured)
+}
+
+// runSettings returns the settings the run is played with: the replay's
+// while one is played back, else the user's
+func (p *Playing) runSettings() runSettings {
+	switch {
+	case p.replaySettings != nil:
+		return *p.replaySettings
+	case p.settings != nil:
+		return runSettings{difficulty: p.settings.Difficulty, character: p.settings.Character}
+	}
+	return runSettings{}
+}
+
+// useReplaySettings plays the run with the difficulty and character the
+// replay was recorded with until the next fresh run (see endReplaySettings)
+func (p *Playing) useReplaySettings(data replay.ReplayData) {
+	p.replaySettings = &runSettings{difficulty: data.Difficulty, character: data.Character}
+	p.reloadConfig()
+	p.applyDifficulty()
+	p.applyCharacter()
+}
+
+// endReplaySettings goes back to the user's difficulty and character once
+// a fresh run starts after playing back a replay
+func (p *Playing) endReplaySettings() {
+	if p.replaySettings == nil {
+		return
+	}
+	p.replaySettings = nil
+	p.reloadConfig()
+	p.applyDifficulty()
+	p.applyCharacter()
+}
+
+// recordRunSettings stores the run's difficulty and character in the
+// recording
+func (p *Playing) recordRunSettings() {
+	settings := p.runSettings()
+	p.recorder.SetRunSettings(settings.difficulty, p.character.ID)
+}
@@ -0,0 +1,83 @@
+package playing
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// buildEliteTints maps elite modifiers to their render colors
+func buildEliteTints(cfg *config.GameConfig) map[ecs.EliteModifier]color.RGBA {
+	tints := map[ecs.EliteModifier]color.RGBA{}
+	for name, elite := range cfg.Entities.Elites {
+		if m, ok := ecs.ParseEliteModifier(name); ok {
+			tints[m] = parseHexColor(elite.Tint)
+		}
+	}
+	return tints
+}
+
+// applyElite turns an enemy config into an elite variant.
+// Empty or unknown names leave the enemy regular.
+func (p *Playing) applyElite(cfg *ecs.EnemyConfig, name string) {
+	if name == "" {
+		return
+	}
+	modifier, ok := ecs.ParseEliteModifier(name)
+	elite, found := p.config.Entities.Elites[name]
+	if !ok || !found {
+		logging.Config.Warnf("Unknown elite modifier: %s", name)
+		return
+	}
+
+	cfg.MaxHealth = max(1, scaleInt(cfg.MaxHealth, elite.Health))
+	cfg.MoveSpeed = scaleInt(cfg.MoveSpeed, elite.MoveSpeed)
+	cfg.ChargeSpeed = scaleInt(cfg.ChargeSpeed, elite.MoveSpeed)
+	cfg.AttackCooldown = max(1, scaleInt(cfg.AttackCooldown, elite.AttackCooldown))
+	cfg.Loot = scaleLootGold(cfg.Loot, elite.GoldMultiplier)
+
+	cfg.Elite = ecs.Elite{
+		Modifier:      modifier,
+		ArmorPct:      elite.ArmorPct,
+		ExplodeRadius: elite.ExplodeRadius,
+		ExplodeDamage: elite.ExplodeDamage,
+	}
+	if elite.RegenPerSecond > 0 {
+		cfg.Elite.RegenFrames = max(1, int(60/elite.RegenPerSecond))
+	}
+}
+
+// rollElite picks an elite modifier for a spawner enemy using the stage's
+// spawner weights and the scene RNG ("" = regular enemy)
+func (p *Playing) rollElite() string {
+	spawner := p.stageCfg.Spawner
+	if spawner.EliteChance <= 0 || p.rng.Float64() >= spawner.EliteChance {
+		return ""
+	}
+
+	// Sorted names keep the roll deterministic for replays
+	names := make([]string, 0, len(spawner.EliteWeights))
+	total := 0
+	for name, weight := range spawner.EliteWeights {
+		if weight > 0 {
+			names = append(names, name)
+			total += weight
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	roll := p.rng.Intn(total)
+	for _, name := range names {
+		roll -= spawner.EliteWeights[name]
+		if roll < 0 {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+var (
+	colorEnergy      = color.RGBA{240, 200, 80, 255}
+	colorEnergyRegen = color.RGBA{160, 130, 60, 255}
+)
+
+// buildEnergyConfig converts energy meter config to ECS units.
+// Unknown arrow names in shotCost are skipped.
+func buildEnergyConfig(cfg *config.GameConfig) ecs.EnergyConfig {
+	e := cfg.Physics.Energy
+	pool := make([]int, len(e.Max))
+	for i, m := range e.Max {
+		pool[i] = int(m * ecs.StaminaScale)
+	}
+	shotCost := make(map[ecs.ArrowType]int, len(e.ShotCost))
+	for name, cost := range e.ShotCost {
+		t, ok := arrowTypes[name]
+		if !ok {
+			logging.Config.Warnf("Unknown arrow type in energy shot cost: %s", name)
+			continue
+		}
+		shotCost[t] = int(cost * ecs.StaminaScale)
+	}
+	return ecs.EnergyConfig{
+		Max:        pool,
+		Regen:      int(fixedpoint.Sim.PerFrame(e.RegenPerSecond * ecs.StaminaScale)),
+		RegenDelay: fixedpoint.SecondsToFrames(e.RegenDelay),
+		PerGold:    int(e.PerGold * ecs.StaminaScale),
+		DashCost:   int(e.DashCost * ecs.StaminaScale),
+		BlockCost:  int(e.BlockCost * ecs.StaminaScale),
+		ShotCost:   shotCost,
+	}
+}
+
+// drawEnergyBar draws the energy meter under the health bar (below the
+// shield stamina when the character can block). Its fill dims while
+// regen is delayed.
+func (p *Playing) drawEnergyBar(screen *ebiten.Image, x, y, w float64) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	maxEnergy := p.world.Energy.MaxAt(playerData.EnergyLevel)
+	if maxEnergy <= 0 {
+		return
+	}
+	if p.world.Block.MaxStamina > 0 {
+		y += 4 // below the stamina bar
+	}
+
+	c := colorEnergy
+	if playerData.EnergyRegenTimer > 0 {
+		c = colorEnergyRegen
+	}
+	ratio := float64(playerData.Energy(maxEnergy)) / float64(maxEnergy)
+	ui.FillRect(screen, x, y, w, 3, p.hud.theme.barBG)
+	ui.FillRect(screen, x, y, w*ratio, 3, c)
+}
@@ -0,0 +1,82 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// maxStepsPerTick bounds the frames caught up in one tick, so a long stall
+// drops time instead of spiralling into ever longer updates
+const maxStepsPerTick = 4
+
+// stepEpsilon absorbs float error in the accumulated tick lengths, so 60
+// ticks of 1/60 s make exactly 60 frames at 60 Hz
+const stepEpsilon = 1e-6
+
+// stepClock is the fixed-timestep accumulator: it turns ticks of any length
+// into whole simulation frames at the simulation rate
+type stepClock struct {
+	acc float64 // seconds not yet simulated
+}
+
+// advance adds a tick of dt seconds and returns the frames to simulate
+func (c *stepClock) advance(dt float64, rate fixedpoint.Rate) int {
+	c.acc += dt
+	steps := int(c.acc*float64(rate) + stepEpsilon)
+	if steps > maxStepsPerTick {
+		c.acc = 0
+		return maxStepsPerTick
+	}
+	c.acc = max(c.acc-rate.FramesToSeconds(steps), 0)
+	return steps
+}
+
+// alpha returns how far the display is between the last simulated frame and
+// the next one (0-1)
+func (c *stepClock) alpha(rate fixedpoint.Rate) float64 {
+	return min(c.acc*float64(rate), 1)
+}
+
+// pendingPresses holds the presses of ticks that simulated no frame, so a
+// tap between frames still reaches the next one
+type pendingPresses struct {
+	input inputState
+	arrow ui.ArrowSelectInput
+}
+
+// hold keeps the presses of input and arrow for the next frame
+func (pp *pendingPresses) hold(input inputState, arrow ui.ArrowSelectInput) {
+	pp.input, pp.arrow = input, arrow
+}
+
+// merge adds the held presses to this tick's input and clears them
+func (pp *pendingPresses) merge(input inputState, arrow ui.ArrowSelectInput) (inputState, ui.ArrowSelectInput) {
+	held := pp.input
+	input.JumpPressed = input.JumpPressed || held.JumpPressed
+	input.JumpReleased = input.JumpReleased || held.JumpReleased
+	input.Dash = input.Dash || held.Dash
+	input.Interact = input.Interact || held.Interact
+	input.Attack = input.Attack || held.Attack
+
+	arrow.Open = arrow.Open || pp.arrow.Open
+	arrow.Close = arrow.Close || pp.arrow.Close
+	if arrow.Number == 0 {
+		arrow.Number = pp.arrow.Number
+	}
+	*pp = pendingPresses{}
+	return input, arrow
+}
+
+// withoutPresses keeps only the held state of input and arrow, for the
+// extra frames of a catch-up tick
+func withoutPresses(input inputState, arrow ui.ArrowSelectInput) (inputState, ui.ArrowSelectInput) {
+	input.JumpPressed = false
+	input.JumpReleased = false
+	input.Dash = false
+	input.Interact = false
+	input.Attack = false
+	arrow.Open = false
+	arrow.Close = false
+	arrow.Number = 0
+	return input, arrow
+}
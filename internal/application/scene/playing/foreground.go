@@ -0,0 +1,48 @@
+package playing
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/domain/entity"
+)
+
+// foregroundFadeFrames is how long the foreground takes to fade out or in
+const foregroundFadeFrames = 12
+
+// updateForegroundFade fades the foreground down to the stage's
+// foregroundFade opacity while the player is behind it, and back up after
+func (p *Playing) updateForegroundFade() {
+	step := 1.0 / foregroundFadeFrames
+	if p.stageCfg.Layers.ForegroundFade > 0 && p.playerBehindForeground() {
+		p.foregroundFade = min(p.foregroundFade+step, 1)
+	} else {
+		p.foregroundFade = max(p.foregroundFade-step, 0)
+	}
+}
+
+// playerBehindForeground reports whether the player's body overlaps a
+// foreground tile
+func (p *Playing) playerBehindForeground() bool {
+	if p.stage.Foreground == nil {
+		return false
+	}
+	bx, by, bw, bh := p.playerBody()
+	size := p.tileSize
+	for ty := by / size; ty <= (by+bh-1)/size; ty++ {
+		for tx := bx / size; tx <= (bx+bw-1)/size; tx++ {
+			if p.stage.GetForegroundTile(tx, ty).Type != entity.TileEmpty {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// drawForeground draws the foreground layer over the entities
+func (p *Playing) drawForeground(screen *ebiten.Image, camX, camY int) {
+	if p.stage.Foreground == nil {
+		return
+	}
+	p.foreground.sync(p.stage, p.highContrast())
+	alpha := 1 - p.foregroundFade*(1-p.stageCfg.Layers.ForegroundFade)
+	p.drawChunks(screen, &p.foreground, camX, camY, alpha)
+}
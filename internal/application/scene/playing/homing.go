@@ -0,0 +1,63 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+const (
+	playerArrowKey = "playerArrow"
+	homingArrowKey = "homingArrow"
+)
+
+// buildHoming converts projectile homing config to ECS units.
+// A nil config disables homing.
+func buildHoming(cfg *config.HomingConfig) ecs.Homing {
+	if cfg == nil || cfg.TurnRate <= 0 {
+		return ecs.Homing{}
+	}
+	return ecs.Homing{
+		// Low turn rates round down to zero IU; keep them steering
+		TurnRate: max(1, fixedpoint.ToIUAccelPerSubstep(cfg.TurnRate)),
+		Range:    int(cfg.AcquireRange),
+		Lifetime: fixedpoint.SecondsToFrames(cfg.Lifetime),
+	}
+}
+
+// enemyHoming returns the homing of the projectile an enemy is configured to fire
+func (p *Playing) enemyHoming(enemyCfg *config.EnemyConfig) ecs.Homing {
+	if enemyCfg.AI.Projectile == "" {
+		return ecs.Homing{}
+	}
+	projCfg, ok := p.config.Entities.Projectiles[enemyCfg.AI.Projectile]
+	if !ok {
+		return ecs.Homing{}
+	}
+	return buildHoming(projCfg.Physics.Homing)
+}
+
+// playerArrowProjectile returns the projectile config for the equipped arrow.
+// The purple arrow fires the homing variant when one is configured and the
+// player has ammo for it; usesAmmo reports whether the shot spends one.
+// Other arrows fire the projectile mapped to them in the projectile
+// behavior's fires (playerArrow if none, or if it takes ammo and the
+// player has none).
+func (p *Playing) playerArrowProjectile() (cfg config.ProjectileConfig, usesAmmo bool) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	if playerData.CurrentArrow == ecs.ArrowPurple && playerData.Ammo > 0 {
+		if projCfg, ok := p.config.Entities.Projectiles[homingArrowKey]; ok {
+			return projCfg, true
+		}
+	}
+	for name, key := range p.config.Physics.Projectile.Fires {
+		if arrowTypes[name] != playerData.CurrentArrow {
+			continue
+		}
+		projCfg, ok := p.config.Entities.Projectiles[key]
+		if ok && (!projCfg.Physics.Ammo || playerData.Ammo > 0) {
+			return projCfg, projCfg.Physics.Ammo
+		}
+	}
+	return p.config.Entities.Projectiles[playerArrowKey], false
+}
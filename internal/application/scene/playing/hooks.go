@@ -0,0 +1,51 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/stats"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+// RunResult describes a finished run (stage cleared or player died)
+type RunResult struct {
+	StageID string
+	Cleared bool
+	Points  int // leaderboard score
+	Stats   stats.Run
+}
+
+// System is a custom update registered with the world's scheduler
+type System = ecs.SystemFunc
+
+// customSystem is a system added by the host; it is registered again with
+// each new world on restart
+type customSystem struct {
+	fn       System
+	phase    ecs.Phase
+	priority int
+}
+
+// SetOnRunEnd registers a callback for when a run ends (nil = none)
+func (p *Playing) SetOnRunEnd(fn func(RunResult)) {
+	p.onRunEnd = fn
+}
+
+// SetOnMusic registers a callback for when the music track changes, e.g.
+// to the boss theme of an arena or the "clear" jingle of a stage clear
+// ("" = back to the stage's own; nil = none)
+func (p *Playing) SetOnMusic(fn func(track string)) {
+	p.onMusic = fn
+}
+
+// AddSystem appends a custom system that runs once per simulated frame,
+// after the built-in systems and before the game-over check, in the order
+// systems were added.
+func (p *Playing) AddSystem(s System) {
+	p.AddSystemAt(s, ecs.PhaseFrameEnd, 0)
+}
+
+// AddSystemAt adds a custom system to a scheduler phase. Built-in systems
+// use priority 0: negative priorities run before them, others after.
+func (p *Playing) AddSystemAt(s System, phase ecs.Phase, priority int) {
+	p.systems = append(p.systems, customSystem{fn: s, phase: phase, priority: priority})
+	p.world.AddSystemPriority(s, phase, priority)
+}
@@ -0,0 +1,248 @@
+package playing
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Ability widget colors
+var (
+	colorAbilityDash   = color.RGBA{120, 220, 255, 255}
+	colorAbilityReady  = color.RGBA{255, 255, 255, 255}
+	colorAbilityDimmed = color.RGBA{90, 90, 110, 255}
+)
+
+const (
+	abilitySize     = 12 // widget box width and height
+	abilityGap      = 4
+	arrowSlotWidth  = 16
+	arrowSlotDimmed = 0.4 // brightness of arrows that are equipped but not selected
+)
+
+// abilityWidget is one HUD slot showing an ability's readiness
+type abilityWidget struct {
+	color    color.RGBA
+	progress float64 // fill: 0 = just used, 1 = recovered
+	ready    bool    // can be used right now (drawn with a bright border)
+	level    int     // upgrade tier, drawn as pips under the box
+}
+
+// abilityWidgets returns the player's dash widget followed by a widget for
+// each unlocked ability (shield, magnet upgrades, turret)
+func (p *Playing) abilityWidgets() []abilityWidget {
+	id := p.world.PlayerID
+	dash := p.world.Dash[id]
+	playerData := p.world.PlayerData[id]
+
+	// Dash fills back up over its cooldown; the charge is spent in the air
+	// until the player lands
+	dashWidget := abilityWidget{color: colorAbilityDash, progress: 1, level: dash.Level}
+	if total := p.physicsCfg.DashCooldownFrames; total > 0 && dash.Cooldown > 0 {
+		dashWidget.progress = 1 - float64(dash.Cooldown)/float64(total)
+	}
+	if dash.Active {
+		dashWidget.progress = 0
+	}
+	dashWidget.ready = dash.CanDash && dash.Cooldown <= 0 && !dash.Active
+	widgets := []abilityWidget{dashWidget}
+
+	if maxStamina := p.world.Block.MaxStamina; maxStamina > 0 {
+		widgets = append(widgets, abilityWidget{
+			color:    colorStamina,
+			progress: float64(playerData.Stamina(maxStamina)) / float64(maxStamina),
+			ready:    !playerData.GuardBroken,
+		})
+	}
+	if playerData.MagnetLevel > 0 {
+		widgets = append(widgets, abilityWidget{color: colorGold, progress: 1, ready: true, level: playerData.MagnetLevel})
+	}
+	if summon, ok := p.summonWidget(); ok {
+		widgets = append(widgets, summon)
+	}
+	return widgets
+}
+
+// abilityHUDWidth returns the width of the arrows and ability widgets row
+func (p *Playing) abilityHUDWidth(widgets []abilityWidget) float64 {
+	arrows := len(p.world.PlayerData[p.world.PlayerID].EquippedArrows)
+	return float64(arrows)*arrowSlotWidth + float64(len(widgets))*(abilitySize+abilityGap)
+}
+
+// drawAbilityHUD draws the equipped arrows and ability widgets. x, y is the
+// top-left corner of the row.
+func (p *Playing) drawAbilityHUD(screen *ebiten.Image, x, y float64, widgets []abilityWidget) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+
+	// Equipped arrows; the selected one is large and bright
+	for i, arrowType := range playerData.EquippedArrows {
+		current := arrowType == playerData.CurrentArrow
+		brightness := arrowSlotDimmed
+		if current {
+			brightness = 1.0
+		}
+		p.drawArrowIcon(screen, x+float64(i)*arrowSlotWidth+arrowSlotWidth/2, y+abilitySize/2, arrowType, brightness, current)
+	}
+	if playerData.CurrentArrow == ecs.ArrowPurple {
+		ui.Draw(screen, i18n.Tf("hud.ammo", playerData.Ammo), x, y-14, textStyle(p.hud.theme.text))
+	}
+
+	x += float64(len(playerData.EquippedArrows))*arrowSlotWidth + abilityGap
+	for _, w := range widgets {
+		drawAbilityWidget(screen, x, y, w, p.hud.theme.barBG)
+		x += abilitySize + abilityGap
+	}
+}
+
+// drawAbilityWidget draws a box that fills bottom-up as the ability recovers
+func drawAbilityWidget(screen *ebiten.Image, x, y float64, w abilityWidget, bg color.RGBA) {
+	ui.FillRect(screen, x, y, abilitySize, abilitySize, bg)
+
+	fill := abilitySize * max(0, min(1, w.progress))
+	c := colorAbilityDimmed
+	border := colorAbilityDimmed
+	if w.ready {
+		c = w.color
+		border = colorAbilityReady
+	}
+	ui.FillRect(screen, x, y+abilitySize-fill, abilitySize, fill, c)
+	drawRectOutline(screen, x, y, abilitySize, abilitySize, border)
+
+	for i := range w.level {
+		ui.FillRect(screen, x+float64(i)*3, y+abilitySize+2, 2, 2, w.color)
+	}
+}
+
+// hudLayout is the HUD config (ui.json) resolved for drawing
+type hudLayout struct {
+	scale                                              float64
+	health, gold, arrows, minimap, controls, combatLog config.HUDElementConfig
+	theme                                              hudTheme
+}
+
+// hudTheme is the HUD colors of a theme
+type hudTheme struct {
+	healthFG, barBG, text, gold                         color.RGBA
+	minimapBG, minimapWall, minimapPlayer, minimapEnemy color.RGBA
+}
+
+// defaultHUD is the layout used without a UI config (320x240 screen)
+var defaultHUD = config.HUDConfig{
+	Scale:     1,
+	Health:    config.HUDElementConfig{Anchor: "bottomLeft", X: 10, Y: -10, Width: 100, Height: 10},
+	Gold:      config.HUDElementConfig{Anchor: "bottomLeft", X: 10, Y: -22},
+	Arrows:    config.HUDElementConfig{Anchor: "bottomLeft", X: 118, Y: -9},
+	Minimap:   config.HUDElementConfig{Anchor: "bottomRight", X: -4, Y: -4, Width: 64, Height: 24},
+	Controls:  config.HUDElementConfig{Anchor: "topLeft", X: 2, Y: 2},
+	CombatLog: config.HUDElementConfig{Anchor: "topLeft", X: 2, Y: 18},
+}
+
+// defaultTheme is the built-in HUD colors, used for any a theme leaves out
+var defaultTheme = hudTheme{
+	healthFG:      colorHealthFG,
+	barBG:         colorHealthBG,
+	text:          color.RGBA{255, 255, 255, 255},
+	gold:          color.RGBA{255, 255, 255, 255},
+	minimapBG:     color.RGBA{0, 0, 0, 150},
+	minimapWall:   color.RGBA{140, 140, 170, 255},
+	minimapPlayer: colorPlayer,
+	minimapEnemy:  colorEnemy,
+}
+
+// hudAnchors maps an anchor name to where along the free width and height
+// of the screen an element sits (0 = left/top, 1 = right/bottom)
+var hudAnchors = map[string][2]float64{
+	"topLeft":     {0, 0},
+	"top":         {0.5, 0},
+	"topRight":    {1, 0},
+	"left":        {0, 0.5},
+	"center":      {0.5, 0.5},
+	"right":       {1, 0.5},
+	"bottomLeft":  {0, 1},
+	"bottom":      {0.5, 1},
+	"bottomRight": {1, 1},
+}
+
+// buildHUDLayout resolves the UI config's HUD layout and theme (no UI
+// config = the built-in layout)
+func buildHUDLayout(cfg *config.GameConfig) hudLayout {
+	if cfg.UI == nil {
+		return newHUDLayout(defaultHUD, defaultTheme)
+	}
+	theme := defaultTheme
+	t, ok := cfg.UI.Themes[cfg.UI.Theme]
+	if !ok && cfg.UI.Theme != "" {
+		logging.Config.Warnf("Unknown UI theme: %s", cfg.UI.Theme)
+	}
+	for _, c := range []struct {
+		dst *color.RGBA
+		hex string
+	}{
+		{&theme.healthFG, t.HealthFG},
+		{&theme.barBG, t.BarBG},
+		{&theme.text, t.Text},
+		{&theme.gold, t.Gold},
+		{&theme.minimapBG, t.MinimapBG},
+		{&theme.minimapWall, t.MinimapWall},
+		{&theme.minimapPlayer, t.MinimapPlayer},
+		{&theme.minimapEnemy, t.MinimapEnemy},
+	} {
+		if c.hex != "" {
+			*c.dst = parseHexColor(c.hex)
+		}
+	}
+
+	hud := cfg.UI.HUD
+	for _, e := range []config.HUDElementConfig{hud.Health, hud.Gold, hud.Arrows, hud.Minimap, hud.Controls, hud.CombatLog} {
+		if _, ok := hudAnchors[e.Anchor]; !ok {
+			logging.Config.Warnf("Unknown HUD anchor: %q", e.Anchor)
+		}
+	}
+	return newHUDLayout(hud, theme)
+}
+
+func newHUDLayout(hud config.HUDConfig, theme hudTheme) hudLayout {
+	scale := hud.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	return hudLayout{
+		scale:     scale,
+		health:    hud.Health,
+		gold:      hud.Gold,
+		arrows:    hud.Arrows,
+		minimap:   hud.Minimap,
+		controls:  hud.Controls,
+		combatLog: hud.CombatLog,
+		theme:     theme,
+	}
+}
+
+// place returns the top-left corner of an element w by h pixels big on a
+// canvas (the screen at HUD scale). An element's configured size wins
+// over its content's.
+func place(e config.HUDElementConfig, w, h float64, canvas image.Rectangle) (x, y float64) {
+	if e.Width > 0 {
+		w = float64(e.Width)
+	}
+	if e.Height > 0 {
+		h = float64(e.Height)
+	}
+	a := hudAnchors[e.Anchor] // unknown = top-left
+	x = a[0]*(float64(canvas.Dx())-w) + float64(e.X)
+	y = a[1]*(float64(canvas.Dy())-h) + float64(e.Y)
+	return x, y
+}
+
+// textStyle returns the HUD text style in a theme color
+func textStyle(c color.RGBA) ui.Style {
+	style := ui.StyleHUD
+	style.Color = c
+	return style
+}
@@ -0,0 +1,83 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
+)
+
+// inputAssist applies the accessibility input settings where keys become
+// input, so the systems never see the difference between a held key and
+// a tolerated release, or a tap and a toggled run
+type inputAssist struct {
+	running   bool    // toggled by the dash key (DashToggle)
+	jumpGuard float64 // seconds left in which a jump press is a repeat
+	slowOff   bool    // the slow key switched back to normal speed
+
+	// Seconds each held input stays held after its key is released
+	left, right, up, down, block float64
+}
+
+// apply filters one tick (dt seconds) of raw input
+func (a *inputAssist) apply(in inputState, s *settings.Settings, dt float64) inputState {
+	if s == nil {
+		return in
+	}
+
+	tolerance := float64(s.HoldToleranceMs) / 1000
+	in.Left = holdThrough(in.Left, &a.left, tolerance, dt)
+	in.Right = holdThrough(in.Right, &a.right, tolerance, dt)
+	in.Up = holdThrough(in.Up, &a.up, tolerance, dt)
+	in.Down = holdThrough(in.Down, &a.down, tolerance, dt)
+	in.Block = holdThrough(in.Block, &a.block, tolerance, dt)
+
+	a.jumpGuard -= dt
+	if in.JumpPressed {
+		if a.jumpGuard > timeSlack {
+			in.JumpPressed = false
+		} else {
+			a.jumpGuard = float64(s.JumpRepeatGuardMs) / 1000
+		}
+	}
+
+	if s.DashToggle {
+		if in.Dash {
+			a.running = !a.running
+		}
+		// Keep asking: the dash starts whenever it is ready again
+		in.Dash = a.running && in.Left != in.Right
+	}
+	return in
+}
+
+// timeSlack absorbs the rounding of summed tick lengths, so a 50 ms timer
+// runs out on the third 1/60 s tick
+const timeSlack = 1e-9
+
+// holdThrough keeps a held input held for tolerance seconds after release
+func holdThrough(held bool, timer *float64, tolerance, dt float64) bool {
+	if held {
+		*timer = tolerance
+		return true
+	}
+	*timer -= dt
+	return *timer > timeSlack
+}
+
+// gameSpeedPct returns the simulation speed set for accessibility, unless
+// the slow key switched it off (100 = normal)
+func (a *inputAssist) gameSpeedPct(s *settings.Settings) int {
+	if s == nil || a.slowOff || s.GameSpeedPct <= 0 {
+		return 100
+	}
+	return s.GameSpeedPct
+}
+
+// applyGameSpeed holds the reduced game speed pct on the world's time scale
+// (0 or 100 = normal, as in replays recorded without it)
+func (p *Playing) applyGameSpeed(pct int) {
+	if pct > 0 && pct < 100 {
+		p.world.TimeScale.Hold(ecs.TimeScaleGameSpeed, pct)
+	} else {
+		p.world.TimeScale.Release(ecs.TimeScaleGameSpeed)
+	}
+}
@@ -0,0 +1,120 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// inputTimelineFrames is how many recent frames the jump timeline shows
+// (2 pixels each, clear of the debug panel on a 320 pixel screen)
+const inputTimelineFrames = 80
+
+// Jump timeline colors
+var (
+	colorTimelineGround = color.RGBA{60, 140, 60, 255}
+	colorTimelinePress  = color.RGBA{255, 255, 255, 255}
+	colorTimelineMissed = color.RGBA{255, 60, 60, 255}
+	colorTimelineJump   = map[ecs.JumpKind]color.RGBA{
+		ecs.JumpGround:   {120, 255, 120, 255},
+		ecs.JumpCoyote:   {255, 220, 60, 255},
+		ecs.JumpBuffered: {80, 200, 255, 255},
+	}
+)
+
+// jumpSample is one frame of the jump timeline
+type jumpSample struct {
+	onGround bool
+	pressed  bool
+	jumped   bool
+	kind     ecs.JumpKind // how the jump was taken (jumped only)
+	missed   bool         // a buffered press expired this frame
+}
+
+// inputTimeline keeps the recent jump presses relative to ground contact,
+// to diagnose presses that were buffered, saved by coyote time or missed
+type inputTimeline struct {
+	samples [inputTimelineFrames]jumpSample
+	next    int // ring index of the next sample
+}
+
+// record appends the sample of the frame just simulated
+func (t *inputTimeline) record(s jumpSample) {
+	t.samples[t.next] = s
+	t.next = (t.next + 1) % inputTimelineFrames
+}
+
+// last returns the most recent sample
+func (t *inputTimeline) last() *jumpSample {
+	return &t.samples[(t.next+inputTimelineFrames-1)%inputTimelineFrames]
+}
+
+// handleEvent marks jump outcomes on the frame that produced them
+func (t *inputTimeline) handleEvent(ev ecs.Event) {
+	switch ev.Type {
+	case ecs.EventJump:
+		t.last().jumped = true
+		t.last().kind = ecs.JumpKind(ev.Amount)
+	case ecs.EventJumpMissed:
+		t.last().missed = true
+	}
+}
+
+// recordInputTimeline samples the frame just simulated
+func (p *Playing) recordInputTimeline(input inputState) {
+	p.inputTimeline.record(jumpSample{
+		onGround: p.world.Movement[p.world.PlayerID].OnGround,
+		pressed:  input.JumpPressed,
+	})
+}
+
+// lagGraceFrames returns the frames added to coyote time and the jump buffer
+// at the current TPS: none at the target TPS, growing with the shortfall up
+// to the configured maximum at half the target
+func (p *Playing) lagGraceFrames(actualTPS float64) int {
+	maxFrames := fixedpoint.SecondsToFrames(p.config.Physics.Jump.LagCompensation)
+	target := float64(p.config.Physics.Display.Framerate)
+	if maxFrames <= 0 || target <= 0 || actualTPS <= 0 || actualTPS >= target {
+		return 0
+	}
+	shortfall := 2 * (target - actualTPS) / target
+	return min(maxFrames, int(float64(maxFrames)*shortfall+0.5))
+}
+
+// drawInputTimeline draws the jump timeline above the debug log: ground
+// contact, presses and how each press ended, oldest frame on the left
+func (p *Playing) drawInputTimeline(screen *ebiten.Image) {
+	const stripH = 12
+	y := float64(p.screenH - debugLogLines*debugLineHeight - 4 - debugLineHeight - stripH)
+	ui.FillRect(screen, 0, y, inputTimelineFrames*2+8, debugLineHeight+stripH, colorDebugPanel)
+
+	var jumps [ecs.JumpBuffered + 1]int
+	missed := 0
+	for i := range inputTimelineFrames {
+		s := p.inputTimeline.samples[(p.inputTimeline.next+i)%inputTimelineFrames]
+		x := float64(4 + i*2)
+		sy := y + debugLineHeight
+		if s.onGround {
+			ui.FillRect(screen, x, sy+stripH-3, 2, 3, colorTimelineGround)
+		}
+		if s.pressed {
+			ui.FillRect(screen, x, sy, 2, 4, colorTimelinePress)
+		}
+		switch {
+		case s.jumped:
+			ui.FillRect(screen, x, sy+4, 2, stripH-7, colorTimelineJump[s.kind])
+			jumps[s.kind]++
+		case s.missed:
+			ui.FillRect(screen, x, sy+4, 2, stripH-7, colorTimelineMissed)
+			missed++
+		}
+	}
+
+	label := fmt.Sprintf("JUMP G:%d C:%d B:%d M:%d +%d",
+		jumps[ecs.JumpGround], jumps[ecs.JumpCoyote], jumps[ecs.JumpBuffered], missed, p.physicsCfg.InputGraceFrames)
+	ui.Draw(screen, label, 4, y, ui.StyleDefault)
+}
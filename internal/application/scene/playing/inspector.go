@@ -0,0 +1,218 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Inspector colors
+var (
+	colorInspectSelected = color.RGBA{255, 220, 0, 255}
+	colorInspectRow      = color.RGBA{80, 80, 140, 200}
+)
+
+// Inspector layout (screen pixels): the panel replaces the debug text
+// panel and ends above the log panel
+const (
+	inspectorW       = 150
+	inspectorY       = 16
+	inspectorRowH    = 12
+	inspectorChars   = (inspectorW - 8) / debugCharWidth
+	inspectorBigStep = 100 // +/- step while Shift is held
+)
+
+// inspectorRow is a component header or one of its fields
+type inspectorRow struct {
+	component string
+	field     ecs.InspectField
+	header    bool
+}
+
+// label formats the row for the panel
+func (r inspectorRow) label() string {
+	if r.header {
+		return r.component
+	}
+	f := r.field
+	var value string
+	switch f.Kind {
+	case reflect.Bool:
+		value = fmt.Sprint(f.Value != 0)
+	case reflect.Float32, reflect.Float64:
+		value = fmt.Sprintf("%.2f", f.Value)
+	default:
+		value = fmt.Sprintf("%d", int64(f.Value))
+	}
+	switch {
+	case f.Name != "":
+		value += " " + f.Name
+	case r.component == "Position":
+		value += fmt.Sprintf(" (%dpx)", int(f.Value)/ecs.PositionScale)
+	case r.component == "Velocity":
+		value += fmt.Sprintf(" (%.0fpx/s)", fixedpoint.FromIUPerSubstep(int(f.Value)))
+	}
+	line := fmt.Sprintf(" %s %s", f.Path, value)
+	if len(line) > inspectorChars {
+		line = line[:inspectorChars]
+	}
+	return line
+}
+
+// inspectorRows lists the inspected entity's components and fields
+func (p *Playing) inspectorRows() []inspectorRow {
+	var rows []inspectorRow
+	for _, c := range p.world.Inspect(p.inspected) {
+		rows = append(rows, inspectorRow{component: c.Name, header: true})
+		for _, f := range c.Fields {
+			rows = append(rows, inspectorRow{component: c.Name, field: f})
+		}
+	}
+	return rows
+}
+
+// inspectorVisibleRows returns how many rows fit above the log panel
+func (p *Playing) inspectorVisibleRows() int {
+	return (p.screenH - debugLogLines*debugLineHeight - 4 - inspectorY - 14) / inspectorRowH
+}
+
+// inspectorCapturesMouse reports whether a click belongs to the inspector
+// (Ctrl+click picks an entity, clicks on the panel pick a row), so it
+// doesn't fire arrows
+func (p *Playing) inspectorCapturesMouse(mx, my int) bool {
+	if !p.debugVisible {
+		return false
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		return true
+	}
+	return p.inspected != 0 && mx >= p.screenW-inspectorW && my >= inspectorY &&
+		my < inspectorY+14+p.inspectorVisibleRows()*inspectorRowH
+}
+
+// updateInspector handles the entity inspector (debug overlay only):
+// Ctrl+click selects the entity under the cursor, clicking a field selects
+// it for editing, +/- change it (x100 with Shift) and the wheel scrolls
+func (p *Playing) updateInspector() {
+	if !p.debugVisible {
+		return
+	}
+	if p.inspected != 0 && !p.world.Exists(p.inspected) {
+		p.inspected = 0
+	}
+
+	mx, my := p.viewport.ToLogical(ebiten.CursorPosition())
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		switch {
+		case ebiten.IsKeyPressed(ebiten.KeyControl):
+			camX, camY := p.getCameraOffset()
+			p.inspected = p.entityAt(mx+camX, my+camY)
+			p.inspectRow, p.inspectScroll = -1, 0
+		case p.inspectorCapturesMouse(mx, my):
+			p.inspectRow = -1
+			if row := p.inspectScroll + (my-inspectorY-14)/inspectorRowH; my >= inspectorY+14 {
+				if rows := p.inspectorRows(); row < len(rows) && !rows[row].header {
+					p.inspectRow = row
+				}
+			}
+		}
+	}
+	if p.inspected == 0 {
+		return
+	}
+
+	rows := p.inspectorRows()
+	if _, wheel := ebiten.Wheel(); wheel != 0 && p.inspectorCapturesMouse(mx, my) {
+		p.inspectScroll -= int(wheel)
+	}
+	p.inspectScroll = max(0, min(p.inspectScroll, len(rows)-p.inspectorVisibleRows()))
+
+	if p.inspectRow < 0 || p.inspectRow >= len(rows) {
+		return
+	}
+	step := 0.0
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadAdd) {
+		step = 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadSubtract) {
+		step = -1
+	}
+	if step == 0 {
+		return
+	}
+	row := rows[p.inspectRow]
+	value := row.field.Value + step
+	switch {
+	case row.field.Kind == reflect.Bool:
+		value = 1 - row.field.Value
+	case ebiten.IsKeyPressed(ebiten.KeyShift):
+		value = row.field.Value + step*inspectorBigStep
+	}
+	p.world.SetInspectField(p.inspected, row.component, row.field.Path, value)
+}
+
+// entityAt returns the entity whose hitbox contains a world position
+// (0 = none). The player wins over overlapping enemies.
+func (p *Playing) entityAt(x, y int) ecs.EntityID {
+	w := p.world
+	if id := w.PlayerID; id != 0 {
+		pos := w.Position[id]
+		facing := w.Facing[id]
+		hitbox := w.HitboxTrapezoid[id]
+		for _, hb := range []ecs.Hitbox{hitbox.Head, hitbox.Body, hitbox.Feet} {
+			hx, hy, hw, hh := hb.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+			if x >= hx && x < hx+hw && y >= hy && y < hy+hh {
+				return id
+			}
+		}
+	}
+
+	ids := make([]ecs.EntityID, 0, len(w.Hitbox))
+	for id := range w.Hitbox {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		pos, hb := w.Position[id], w.Hitbox[id]
+		hx, hy := pos.PixelX()+hb.OffsetX, pos.PixelY()+hb.OffsetY
+		if x >= hx && x < hx+hb.Width && y >= hy && y < hy+hb.Height {
+			return id
+		}
+	}
+	return 0
+}
+
+// drawInspector lists the inspected entity's components in place of the
+// debug text panel and outlines the entity
+func (p *Playing) drawInspector(screen *ebiten.Image, camX, camY int) {
+	w := p.world
+	pos := w.Position[p.inspected]
+	if hb, ok := w.Hitbox[p.inspected]; ok {
+		x := float64(pos.PixelX() + hb.OffsetX - camX)
+		y := float64(pos.PixelY() + hb.OffsetY - camY)
+		drawRectOutline(screen, x-1, y-1, float64(hb.Width+2), float64(hb.Height+2), colorInspectSelected)
+	} else {
+		ui.FillRect(screen, float64(pos.PixelX()-camX-2), float64(pos.PixelY()-camY-2), 4, 4, colorInspectSelected)
+	}
+
+	rows := p.inspectorRows()
+	visible := p.inspectorVisibleRows()
+	panelX := float64(p.screenW - inspectorW)
+	ui.FillRect(screen, panelX, inspectorY, inspectorW, float64(14+visible*inspectorRowH), colorDebugPanel)
+	ui.Draw(screen, fmt.Sprintf("ENTITY %d", p.inspected.Index()), panelX+4, inspectorY, ui.StyleDefault)
+
+	for i := p.inspectScroll; i < len(rows) && i < p.inspectScroll+visible; i++ {
+		y := inspectorY + 14 + (i-p.inspectScroll)*inspectorRowH
+		if i == p.inspectRow {
+			ui.FillRect(screen, panelX, float64(y), inspectorW, inspectorRowH, colorInspectRow)
+		}
+		ui.Draw(screen, rows[i].label(), panelX+4, float64(y), ui.StyleDefault)
+	}
+}
@@ -0,0 +1,30 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// drawAlpha returns how far drawing is between the last two simulation
+// frames. Outside play nothing advances, so the current frame is drawn.
+func (p *Playing) drawAlpha() float64 {
+	if p.state != state.StatePlaying {
+		return 1
+	}
+	return p.clock.alpha(fixedpoint.Sim)
+}
+
+// drawPosition returns where to draw id: its position interpolated between
+// the last two simulation frames
+func (p *Playing) drawPosition(id ecs.EntityID) ecs.Position {
+	return ecs.InterpolatedPosition(p.world, id, p.drawAlpha())
+}
+
+// drawOffset returns how far (pixels) id is drawn from its simulated
+// position, for things drawn from the simulated position that should
+// follow it (camera, lights)
+func (p *Playing) drawOffset(id ecs.EntityID) (dx, dy int) {
+	drawn, pos := p.drawPosition(id), p.world.Position[id]
+	return drawn.PixelX() - pos.PixelX(), drawn.PixelY() - pos.PixelY()
+}
@@ -0,0 +1,100 @@
+package playing
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+// SetLeaderboard enables the local leaderboard. Qualifying runs ask for
+// initials on the game over / stage clear screen and are saved to path
+// (empty = don't persist).
+func (p *Playing) SetLeaderboard(lb *save.Leaderboard, path string) {
+	p.leaderboard = lb
+	p.leaderboardPath = path
+}
+
+// SetMenu sets the scene the pause screen returns to (Q)
+func (p *Playing) SetMenu(menu scene.Scene) {
+	p.menu = menu
+}
+
+// finishRun scores the ended run and starts initials entry if it makes
+// the leaderboard
+func (p *Playing) finishRun(cleared bool) {
+	if p.tower != nil {
+		p.runPoints = p.finishTowerRun()
+	} else {
+		p.runPoints = p.stats.Points(p.stageCfg.ParTime, cleared)
+	}
+	p.runCleared = cleared
+	p.boardRank = -1
+	if p.onRunEnd != nil {
+		p.onRunEnd(RunResult{StageID: p.stageCfg.ID, Cleared: cleared, Points: p.runPoints, Stats: p.stats})
+	}
+	// Game over saves the replay itself; a clear is saved here
+	if cleared && p.recorder != nil {
+		p.saveRecording()
+	}
+	if p.leaderboard == nil || !p.leaderboard.Qualifies(p.boardID(), p.runPoints, p.stats.Seconds()) {
+		return
+	}
+	p.initials = ui.NewInitials(save.InitialsLength, p.lastInitials)
+}
+
+// updateInitials feeds keyboard input to the initials entry and submits
+// the score when it is confirmed
+func (p *Playing) updateInitials() {
+	p.charsBuf = ebiten.AppendInputChars(p.charsBuf[:0])
+	p.initials.Update(ui.InitialsInput{
+		Up:      inpututil.IsKeyJustPressed(ebiten.KeyUp),
+		Down:    inpututil.IsKeyJustPressed(ebiten.KeyDown),
+		Left:    inpututil.IsKeyJustPressed(ebiten.KeyLeft),
+		Right:   inpututil.IsKeyJustPressed(ebiten.KeyRight),
+		Confirm: inpututil.IsKeyJustPressed(ebiten.KeyEnter),
+		Back:    inpututil.IsKeyJustPressed(ebiten.KeyBackspace),
+		Chars:   p.charsBuf,
+	})
+	if p.initials.Done() {
+		p.submitScore(p.initials.String())
+	}
+}
+
+// submitScore inserts the finished run into the leaderboard and saves it
+func (p *Playing) submitScore(initials string) {
+	p.boardRank = p.leaderboard.Insert(p.boardID(), save.ScoreEntry{
+		Initials: initials,
+		Score:    p.runPoints,
+		Seconds:  p.stats.Seconds(),
+		Cleared:  p.runCleared,
+		Time:     time.Now().Unix(),
+	})
+	p.lastInitials = initials
+	p.initials = nil
+	// The run's replay was saved at the end; save it again with the name
+	if p.recorder != nil {
+		p.saveRecording()
+	}
+
+	if p.leaderboardPath == "" {
+		return
+	}
+	if err := p.leaderboard.Save(p.leaderboardPath); err != nil {
+		logging.Scene.Errorf("Failed to save leaderboard: %v", err)
+	}
+}
+
+// drawInitialsEntry draws the new high score prompt and the initials widget
+func (p *Playing) drawInitialsEntry(screen *ebiten.Image, y float64) {
+	cx := float64(p.screenW / 2)
+	text := i18n.T("leaderboard.newHighScore") + "\n" + i18n.T("leaderboard.enterInitials")
+	ui.Draw(screen, text, cx, y, ui.StyleCenter)
+	p.initials.Draw(screen, cx, y+32)
+	ui.Draw(screen, i18n.T("leaderboard.initialsHelp"), cx, float64(p.screenH-18), ui.StyleCenter)
+}
@@ -0,0 +1,160 @@
+package playing
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// lightGradientSize is the pixel size of the radial falloff sprite that is
+// scaled to each light's radius
+const lightGradientSize = 64
+
+var (
+	colorTorch = color.RGBA{200, 120, 40, 255}
+
+	// blendMultiply multiplies the scene by the light map
+	blendMultiply = ebiten.Blend{
+		BlendFactorSourceRGB:        ebiten.BlendFactorDestinationColor,
+		BlendFactorSourceAlpha:      ebiten.BlendFactorZero,
+		BlendFactorDestinationRGB:   ebiten.BlendFactorZero,
+		BlendFactorDestinationAlpha: ebiten.BlendFactorOne,
+		BlendOperationRGB:           ebiten.BlendOperationAdd,
+		BlendOperationAlpha:         ebiten.BlendOperationAdd,
+	}
+
+	lightGradient *ebiten.Image // created on first use
+)
+
+// lightSource is a point light's radius (pixels) and color
+type lightSource struct {
+	radius int
+	color  color.RGBA
+}
+
+// lighting is a stage's darkness and the lights that cut through it
+type lighting struct {
+	ambient    color.RGBA
+	player     lightSource
+	projectile lightSource
+	torch      lightSource
+	torches    []config.PositionConfig
+
+	lightMap *ebiten.Image // screen-sized, created on first draw
+}
+
+// buildLighting converts the stage's lighting config (nil = fully lit stage)
+func buildLighting(cfg *config.GameConfig, stageCfg *config.StageConfig) *lighting {
+	stageLight := stageCfg.Lighting
+	if stageLight == nil {
+		return nil
+	}
+
+	ambient := color.RGBA{255, 255, 255, 255}
+	if stageLight.Color != "" {
+		ambient = parseHexColor(stageLight.Color)
+	}
+	level := max(0, min(1, stageLight.Ambient))
+	ambient.R = uint8(float64(ambient.R) * level)
+	ambient.G = uint8(float64(ambient.G) * level)
+	ambient.B = uint8(float64(ambient.B) * level)
+
+	lights := cfg.Physics.Lighting
+	return &lighting{
+		ambient:    ambient,
+		player:     buildLightSource(lights.Player),
+		projectile: buildLightSource(lights.Projectile),
+		torch:      buildLightSource(lights.Torch),
+		torches:    stageLight.Torches,
+	}
+}
+
+// buildLightSource converts a light's config
+func buildLightSource(cfg config.LightConfig) lightSource {
+	c := color.RGBA{255, 255, 255, 255}
+	if cfg.Color != "" {
+		c = parseHexColor(cfg.Color)
+	}
+	return lightSource{radius: cfg.Radius, color: c}
+}
+
+// drawTorches draws the stage's torches
+func (p *Playing) drawTorches(screen *ebiten.Image, camX, camY int) {
+	if p.lighting == nil {
+		return
+	}
+	for _, torch := range p.lighting.torches {
+		x := float64(torch.X - camX)
+		y := float64(torch.Y - camY)
+		ui.FillRect(screen, x-1, y, 3, 8, colorTorch)
+		ui.FillRect(screen, x-2, y-3, 5, 3, colorLava)
+	}
+}
+
+// drawLighting darkens the world to the stage's ambient level and adds the
+// player, projectile and torch lights back on top. The light map is built
+// offscreen and multiplied onto the scene.
+func (p *Playing) drawLighting(screen *ebiten.Image, camX, camY int) {
+	l := p.lighting
+	if l == nil {
+		return
+	}
+	if l.lightMap == nil {
+		l.lightMap = ebiten.NewImage(p.screenW, p.screenH)
+	}
+	l.lightMap.Fill(l.ambient)
+
+	px, py := ecs.HitboxCenter(p.world, p.world.PlayerID)
+	followX, followY := p.drawOffset(p.world.PlayerID)
+	l.drawLight(px+followX-camX, py+followY-camY, l.player)
+	for id := range p.world.IsProjectile {
+		pos := p.drawPosition(id)
+		l.drawLight(pos.PixelX()-camX, pos.PixelY()-camY, l.projectile)
+	}
+	for _, torch := range l.torches {
+		l.drawLight(torch.X-camX, torch.Y-camY, l.torch)
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.Blend = blendMultiply
+	screen.DrawImage(l.lightMap, op)
+}
+
+// drawLight adds a light centered on screen position (x, y) to the light map
+func (l *lighting) drawLight(x, y int, light lightSource) {
+	if light.radius <= 0 {
+		return
+	}
+	scale := float64(2*light.radius) / lightGradientSize
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(x-light.radius), float64(y-light.radius))
+	op.ColorScale.ScaleWithColor(light.color)
+	op.Blend = ebiten.BlendLighter
+	l.lightMap.DrawImage(radialGradient(), op)
+}
+
+// radialGradient returns a white disc fading out toward its edge
+func radialGradient() *ebiten.Image {
+	if lightGradient != nil {
+		return lightGradient
+	}
+	const half = lightGradientSize / 2
+	pix := make([]byte, lightGradientSize*lightGradientSize*4)
+	for y := range lightGradientSize {
+		for x := range lightGradientSize {
+			dx, dy := float64(x-half)+0.5, float64(y-half)+0.5
+			falloff := max(0, 1-math.Sqrt(dx*dx+dy*dy)/half)
+			v := byte(255 * falloff * falloff)
+			i := (y*lightGradientSize + x) * 4
+			pix[i], pix[i+1], pix[i+2], pix[i+3] = v, v, v, v
+		}
+	}
+	lightGradient = ebiten.NewImage(lightGradientSize, lightGradientSize)
+	lightGradient.WritePixels(pix)
+	return lightGradient
+}
@@ -0,0 +1,58 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+var (
+	colorHealthPickup = color.RGBA{255, 90, 120, 255}
+	colorAmmoPickup   = color.RGBA{180, 100, 255, 255}
+	colorTreasure     = color.RGBA{120, 255, 230, 255}
+)
+
+// buildLootTable converts an enemy's loot config to ECS form.
+// Entries with unknown pickups are skipped.
+func buildLootTable(cfg config.LootTableConfig) ecs.LootTable {
+	table := ecs.LootTable{Rolls: cfg.Rolls}
+	for _, e := range cfg.Entries {
+		kind, ok := ecs.ParsePickupKind(e.Pickup)
+		if !ok {
+			logging.Config.Warnf("Unknown loot pickup: %s", e.Pickup)
+			continue
+		}
+		table.Entries = append(table.Entries, ecs.LootEntry{Kind: kind, Weight: e.Weight, Min: e.Min, Max: e.Max})
+	}
+	return table
+}
+
+// scaleLootGold returns a copy of table with gold amounts scaled by mult
+func scaleLootGold(table ecs.LootTable, mult float64) ecs.LootTable {
+	entries := make([]ecs.LootEntry, len(table.Entries))
+	for i, e := range table.Entries {
+		if e.Kind == ecs.PickupGold {
+			e.Min = scaleInt(e.Min, mult)
+			e.Max = scaleInt(e.Max, mult)
+		}
+		entries[i] = e
+	}
+	table.Entries = entries
+	return table
+}
+
+// pickupColor returns the placeholder color of a dropped pickup
+func pickupColor(kind ecs.PickupKind) color.RGBA {
+	switch kind {
+	case ecs.PickupHealth:
+		return colorHealthPickup
+	case ecs.PickupAmmo:
+		return colorAmmoPickup
+	case ecs.PickupTreasure:
+		return colorTreasure
+	default:
+		return colorGold
+	}
+}
@@ -0,0 +1,79 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/metrics"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Colors for each measured system in the metrics graph
+var metricsColors = [metrics.NumSystems]color.RGBA{
+	metrics.PlayerPhysics: {100, 200, 100, 220},
+	metrics.EnemyAI:       {200, 100, 100, 220},
+	metrics.Projectiles:   {100, 150, 255, 220},
+	metrics.Damage:        {255, 215, 0, 220},
+	metrics.Render:        {180, 80, 255, 220},
+}
+
+// metricsBudget is the frame budget drawn as the full graph height (60fps)
+const metricsBudget = time.Second / 60
+
+// SetMetrics enables per-system timing with the given recorder (nil disables).
+// When enabled, a frame time graph is drawn and F6 exports the buffer to CSV.
+func (p *Playing) SetMetrics(r *metrics.Recorder) {
+	p.metrics = r
+}
+
+// updateMetricsExport saves the metrics buffer to CSV on F6
+func (p *Playing) updateMetricsExport() {
+	if p.metrics == nil || !inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		return
+	}
+	filename := metrics.GenerateFilename()
+	if err := p.metrics.SaveCSV(filename); err != nil {
+		logging.Scene.Errorf("Failed to save metrics: %v", err)
+	} else {
+		logging.Scene.Infof("Metrics saved: %s (%d frames)", filename, len(p.metrics.Frames()))
+	}
+}
+
+// drawMetricsGraph draws a stacked bar per recorded frame, scaled to the 60fps budget
+func (p *Playing) drawMetricsGraph(screen *ebiten.Image) {
+	frames := p.metrics.Frames()
+
+	graphH := 40.0
+	graphX := 4.0
+	graphY := float64(p.screenH) - 48 - graphH
+	barW := float64(p.screenW-8) / float64(metrics.DefaultCapacity)
+
+	ui.FillRect(screen, graphX, graphY, float64(p.screenW-8), graphH, colorDebugPanel)
+
+	for i, f := range frames {
+		x := graphX + float64(i)*barW
+		y := graphY + graphH
+		for sys, d := range f.Durations {
+			h := graphH * float64(d) / float64(metricsBudget)
+			if h <= 0 {
+				continue
+			}
+			y -= h
+			ui.FillRect(screen, x, y, barW, h, metricsColors[sys])
+		}
+	}
+
+	label := fmt.Sprintf("avg phys %dus ai %dus proj %dus dmg %dus draw %dus  peak %.1fms",
+		p.metrics.Average(metrics.PlayerPhysics).Microseconds(),
+		p.metrics.Average(metrics.EnemyAI).Microseconds(),
+		p.metrics.Average(metrics.Projectiles).Microseconds(),
+		p.metrics.Average(metrics.Damage).Microseconds(),
+		p.metrics.Average(metrics.Render).Microseconds(),
+		float64(p.metrics.Peak().Microseconds())/1000,
+	)
+	ui.Draw(screen, label, graphX, graphY-14, ui.StyleDefault)
+}
@@ -0,0 +1,73 @@
+package playing
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/domain/entity"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+// minimapCache holds the stage drawn one pixel per tile, rebuilt after the
+// stage, its tiles or the theme's colors change
+type minimapCache struct {
+	stage    *entity.Stage
+	revision int
+	wall, bg color.RGBA
+	img      *ebiten.Image
+}
+
+// image returns the stage's minimap image
+func (c *minimapCache) image(stage *entity.Stage, wall, bg color.RGBA) *ebiten.Image {
+	if c.img != nil && c.stage == stage && c.revision == stage.Revision() && c.wall == wall && c.bg == bg {
+		return c.img
+	}
+	if c.img != nil {
+		c.img.Deallocate()
+	}
+	pix := image.NewRGBA(image.Rect(0, 0, stage.Width, stage.Height))
+	for ty, row := range stage.Tiles {
+		for tx, tile := range row {
+			if tile.Solid {
+				pix.SetRGBA(tx, ty, wall)
+			} else {
+				pix.SetRGBA(tx, ty, bg)
+			}
+		}
+	}
+	c.stage, c.revision, c.wall, c.bg = stage, stage.Revision(), wall, bg
+	c.img = ebiten.NewImageFromImage(pix)
+	return c.img
+}
+
+// drawMinimap draws the whole stage shrunk into the minimap element, with
+// dots for the player and the enemies
+func (p *Playing) drawMinimap(dst *ebiten.Image, canvas image.Rectangle) {
+	l := &p.hud
+	if p.stage.Width == 0 || p.stage.Height == 0 || l.minimap.Width <= 0 || l.minimap.Height <= 0 {
+		return
+	}
+	x, y := place(l.minimap, 0, 0, canvas)
+	w, h := float64(l.minimap.Width), float64(l.minimap.Height)
+	scale := min(w/float64(p.stage.Width), h/float64(p.stage.Height))
+	// Center the stage in the element
+	x += (w - scale*float64(p.stage.Width)) / 2
+	y += (h - scale*float64(p.stage.Height)) / 2
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	dst.DrawImage(p.minimap.image(p.stage, l.theme.minimapWall, l.theme.minimapBG), op)
+
+	pixel := scale / float64(p.tileSize)
+	dot := func(id ecs.EntityID, c color.RGBA) {
+		cx, cy := ecs.HitboxCenter(p.world, id)
+		ui.FillRect(dst, x+float64(cx)*pixel-1, y+float64(cy)*pixel-1, 2, 2, c)
+	}
+	for id := range p.world.IsEnemy {
+		dot(id, l.theme.minimapEnemy)
+	}
+	dot(p.world.PlayerID, l.theme.minimapPlayer)
+}
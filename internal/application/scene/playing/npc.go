@@ -0,0 +1,135 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// NPC colors by role
+var (
+	colorNPC           = color.RGBA{120, 140, 220, 255}
+	colorShopkeeper    = color.RGBA{220, 170, 60, 255}
+	colorQuestGiver    = color.RGBA{120, 200, 120, 255}
+	colorQuestMarker   = color.RGBA{255, 230, 0, 255}
+	colorInteractFocus = color.RGBA{255, 255, 255, 255}
+)
+
+const (
+	npcWidth       = 12
+	npcHeight      = 16
+	npcTalkRange   = 24 // pixels between player and NPC centers
+	npcWalkSpeed   = 30 // pixels/sec
+	npcPauseFrames = 60 // idle time at each patrol turn
+)
+
+// npcRole decides what interacting with an NPC does
+type npcRole int
+
+const (
+	npcTalker     npcRole = iota // starts its dialogue
+	npcShopkeeper                // opens its shop
+	npcQuestGiver                // starts its dialogue, marked with "!"
+)
+
+// npc is the scene-side data of a stage character the player can talk to
+type npc struct {
+	name     string
+	dialogue string
+	role     npcRole
+	shop     []shopItem
+}
+
+// parseNPCRole returns the role for a config name ("" = talker)
+func parseNPCRole(name string) (npcRole, bool) {
+	switch name {
+	case "", "talker":
+		return npcTalker, true
+	case "shopkeeper":
+		return npcShopkeeper, true
+	case "questGiver":
+		return npcQuestGiver, true
+	default:
+		return npcTalker, false
+	}
+}
+
+// spawnNPCs creates the stage's NPC entities
+func (p *Playing) spawnNPCs() {
+	p.npcs = make(map[ecs.EntityID]npc, len(p.stageCfg.NPCs))
+	for _, n := range p.stageCfg.NPCs {
+		role, ok := parseNPCRole(n.Role)
+		if !ok {
+			logging.Config.Warnf("Unknown NPC role: %s", n.Role)
+		}
+		npcCfg := ecs.NPCConfig{
+			HitboxWidth:   npcWidth,
+			HitboxHeight:  npcHeight,
+			InteractRange: npcTalkRange,
+		}
+		if n.Behavior == ecs.NPCPatrol.String() {
+			npcCfg.Behavior = ecs.NPCPatrol
+			npcCfg.PatrolDistance = n.PatrolDistance
+			npcCfg.Speed = fixedpoint.ToIUPerSubstep(npcWalkSpeed)
+			npcCfg.PauseFrames = npcPauseFrames
+		}
+		id := p.world.CreateNPC(n.X, n.Y, npcCfg, false)
+		p.npcs[id] = npc{name: n.Name, dialogue: n.Dialogue, role: role, shop: buildShop(n.Shop)}
+	}
+}
+
+// interactNPC opens the shop or starts the dialogue of the NPC in range.
+// Returns true if the simulation should pause.
+func (p *Playing) interactNPC() bool {
+	id := ecs.NearbyNPC(p.world)
+	if id == 0 {
+		return false
+	}
+	n := p.npcs[id]
+	if n.role == npcShopkeeper && len(n.shop) > 0 {
+		p.openShop(id)
+		return true
+	}
+	return p.startDialogue(n.dialogue)
+}
+
+// drawNPCs draws NPCs with their name and an interaction prompt when in range
+func (p *Playing) drawNPCs(screen *ebiten.Image, camX, camY int) {
+	nearby := ecs.NearbyNPC(p.world)
+	for id := range p.world.IsNPC {
+		n := p.npcs[id]
+		pos := p.drawPosition(id)
+		x := float64(pos.PixelX() - camX)
+		y := float64(pos.PixelY() - camY)
+
+		c := colorNPC
+		switch n.role {
+		case npcShopkeeper:
+			c = colorShopkeeper
+		case npcQuestGiver:
+			c = colorQuestGiver
+		}
+		ui.FillRect(screen, x, y, npcWidth, npcHeight, c)
+
+		// Eye on the facing side
+		eyeX := x + 2
+		if p.world.Facing[id].Right {
+			eyeX = x + npcWidth - 4
+		}
+		ui.FillRect(screen, eyeX, y+4, 2, 2, colorInteractFocus)
+
+		label := n.name
+		if id == nearby && p.state == state.StatePlaying {
+			label = "[" + p.keys.Interact.String() + "] " + n.name
+		}
+		ui.Draw(screen, label, x+npcWidth/2, y-14, ui.StyleCenter)
+		if n.role == npcQuestGiver {
+			ui.Draw(screen, "!", x+npcWidth/2, y-26, ui.Style{Color: colorQuestMarker, Outline: color.Black, Align: ui.AlignCenter})
+		}
+	}
+}
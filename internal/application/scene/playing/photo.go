@@ -0,0 +1,136 @@
+package playing
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Photo mode camera tuning
+const (
+	photoPanSpeed = 4 // pixels per frame at 1x zoom
+	photoMaxZoom  = 4
+)
+
+var colorPhotoHint = color.RGBA{0, 0, 0, 140}
+
+// photoCamera is the free camera of photo mode
+type photoCamera struct {
+	camX, camY int  // top-left of the unzoomed view (pixels)
+	zoom       int  // 1..photoMaxZoom, around the view center
+	capture    bool // save the next drawn frame as a screenshot
+
+	canvas *ebiten.Image // unzoomed world view, created on first zoomed draw
+}
+
+// enterPhotoMode pauses the simulation and hands the camera to the player
+func (p *Playing) enterPhotoMode() {
+	camX, camY := p.getCameraOffset()
+	p.photo = photoCamera{camX: camX, camY: camY, zoom: 1, canvas: p.photo.canvas}
+	p.state = state.StatePhoto
+}
+
+// updatePhoto pans and zooms the photo camera and takes screenshots.
+// F9 or Escape returns to the game.
+func (p *Playing) updatePhoto() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		p.state = state.StatePlaying
+		return
+	}
+
+	speed := max(1, photoPanSpeed/p.photo.zoom)
+	if ebiten.IsKeyPressed(p.keys.Left) || ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		p.photo.camX -= speed
+	}
+	if ebiten.IsKeyPressed(p.keys.Right) || ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		p.photo.camX += speed
+	}
+	if ebiten.IsKeyPressed(p.keys.Up) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		p.photo.camY -= speed
+	}
+	if ebiten.IsKeyPressed(p.keys.Down) || ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		p.photo.camY += speed
+	}
+	p.photo.camX = max(0, min(p.photo.camX, p.stage.Width*p.tileSize-p.screenW))
+	p.photo.camY = max(0, min(p.photo.camY, p.stage.Height*p.tileSize-p.screenH))
+
+	_, wheel := ebiten.Wheel()
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || wheel > 0 {
+		p.photo.zoom = min(photoMaxZoom, p.photo.zoom+1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || wheel < 0 {
+		p.photo.zoom = max(1, p.photo.zoom-1)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		p.photo.capture = true
+	}
+}
+
+// drawPhoto draws the world from the photo camera without the HUD, saving
+// it if a screenshot was requested
+func (p *Playing) drawPhoto(screen *ebiten.Image) {
+	cam := &p.photo
+	if cam.zoom <= 1 {
+		p.drawWorld(screen, cam.camX, cam.camY)
+	} else {
+		if cam.canvas == nil {
+			cam.canvas = ebiten.NewImage(p.screenW, p.screenH)
+		}
+		cam.canvas.Fill(colorBG)
+		p.drawWorld(cam.canvas, cam.camX, cam.camY)
+
+		// Scale around the view center
+		zoom := float64(cam.zoom)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(-float64(p.screenW)/2, -float64(p.screenH)/2)
+		op.GeoM.Scale(zoom, zoom)
+		op.GeoM.Translate(float64(p.screenW)/2, float64(p.screenH)/2)
+		screen.DrawImage(cam.canvas, op)
+	}
+
+	if cam.capture {
+		cam.capture = false
+		filename := screenshotFilename()
+		if err := saveScreenshot(screen, filename); err != nil {
+			logging.Scene.Errorf("Failed to save screenshot: %v", err)
+		} else {
+			logging.Scene.Infof("Screenshot saved: %s", filename)
+		}
+	}
+
+	ui.FillRect(screen, 0, 0, float64(p.screenW), 12, colorPhotoHint)
+	ui.Draw(screen, fmt.Sprintf("PHOTO %dx  move: pan  +/-: zoom  P: save  F9: back", cam.zoom), 4, 0, ui.StyleDefault)
+}
+
+// screenshotFilename returns a timestamped screenshot filename
+func screenshotFilename() string {
+	return fmt.Sprintf("screenshot_%s.png", time.Now().Format("20060102_150405"))
+}
+
+// saveScreenshot writes an image to a PNG file
+func saveScreenshot(img *ebiten.Image, filename string) error {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	img.ReadPixels(rgba.Pix)
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := png.Encode(file, rgba); err != nil {
+		return fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+var colorPotionCork = color.RGBA{150, 100, 60, 255}
+
+// buildPickupConfig converts a pickup's config to ECS form
+func buildPickupConfig(cfg config.PickupConfig) ecs.PickupConfig {
+	phys := cfg.Physics
+	return ecs.PickupConfig{
+		Gravity:       fixedpoint.ToIUAccelPerFrame(phys.Gravity),
+		BouncePercent: int(phys.BounceDecay * 100),
+		CollectDelay:  fixedpoint.SecondsToFrames(phys.CollectDelay),
+		HitboxWidth:   cfg.Hitbox.Width,
+		HitboxHeight:  cfg.Hitbox.Height,
+		CollectRadius: int(phys.CollectRadius),
+		MagnetRadius:  int(phys.MagnetRadius),
+		MagnetSpeed:   fixedpoint.ToIUPerSubstep(phys.MagnetSpeed),
+	}
+}
+
+// spawnStagePickups places the stage's health and ammo pickups and tracks
+// the ones that respawn
+func (p *Playing) spawnStagePickups() {
+	for i, spawn := range p.stageCfg.Pickups {
+		p.world.TrackRespawn(p.spawnStagePickup(spawn), ecs.Respawn{
+			Spawn:  i,
+			Pickup: true,
+			Rule:   respawnRule("pickup", spawn.Type, spawn.Respawn),
+			Frames: fixedpoint.SecondsToFrames(spawn.RespawnDelay),
+			X:      spawn.X,
+			Y:      spawn.Y,
+		})
+	}
+}
+
+// spawnStagePickup places one stage pickup.
+// Unknown or unconfigured pickup types are skipped (0 is returned).
+func (p *Playing) spawnStagePickup(spawn config.PickupSpawnConfig) ecs.EntityID {
+	kind, ok := ecs.ParsePickupKind(spawn.Type)
+	pickupCfg, configured := p.config.Entities.Pickups[spawn.Type]
+	if !ok || !configured {
+		logging.Config.Warnf("Unknown stage pickup: %s", spawn.Type)
+		return 0
+	}
+	amount := 0
+	switch kind {
+	case ecs.PickupHealth:
+		amount = pickupCfg.HealAmount
+	case ecs.PickupAmmo:
+		amount = pickupCfg.AmmoAmount
+	}
+	if amount <= 0 {
+		return 0
+	}
+	return p.world.PlacePickup(spawn.X, spawn.Y, kind, amount, buildPickupConfig(pickupCfg))
+}
+
+// drawPickup draws a pickup's placeholder shape at screen position x, y:
+// a heart for health, a potion for ammo and a coin for gold and treasure.
+func drawPickup(screen *ebiten.Image, x, y float64, pickup ecs.Pickup) {
+	c := pickupColor(pickup.Kind)
+	w := float64(max(pickup.HitboxWidth, 8))
+	h := float64(max(pickup.HitboxHeight, 8))
+
+	switch pickup.Kind {
+	case ecs.PickupHealth:
+		// Two lobes over a tapering point
+		ui.FillRect(screen, x, y+1, w/2, h/3, c)
+		ui.FillRect(screen, x+w/2, y+1, w/2, h/3, c)
+		ui.FillRect(screen, x+1, y+h/3, w-2, h/4, c)
+		ui.FillRect(screen, x+w/4, y+h/3+h/4, w/2, h/4, c)
+		ui.FillRect(screen, x+w/2-1, y+h-h/6, 2, h/6, c)
+	case ecs.PickupAmmo:
+		// Cork, neck and round flask
+		ui.FillRect(screen, x+w/2-1, y, 2, 2, colorPotionCork)
+		ui.FillRect(screen, x+w/3, y+2, w/3, h/4, c)
+		ui.FillRect(screen, x, y+2+h/4, w, h-2-h/4, c)
+	default:
+		ui.FillRect(screen, x, y, 8, 8, c)
+	}
+}
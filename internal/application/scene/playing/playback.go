@@ -0,0 +1,97 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+)
+
+// attractBlinkFrames is the on/off period of the attract mode prompt
+const attractBlinkFrames = 40
+
+var colorAttractBar = color.RGBA{0, 0, 0, 160}
+
+// PlayReplay restarts the stage with the replay's seed and drives the
+// player with its recorded input until it runs out (then the keys take
+// over again)
+func (p *Playing) PlayReplay(data replay.ReplayData) {
+	p.useReplaySettings(data)
+	p.restartWithSeed(data.Seed)
+	p.replayer = replay.NewReplayer(data)
+	p.recorder = nil // watching a replay doesn't make a new one
+}
+
+// SetAttract turns the scene into an attract mode demo (or a replay being
+// watched): the HUD is hidden behind a banner with label (a locale key)
+// and any input, the end of the replay or the end of the run returns to
+// back
+func (p *Playing) SetAttract(back scene.Scene, label string) {
+	p.attractBack = back
+	p.attractLabel = label
+}
+
+// updateAttract returns the scene to leave the demo for (nil = keep
+// playing, or not a demo)
+func (p *Playing) updateAttract() scene.Scene {
+	if p.attractBack == nil {
+		return nil
+	}
+	// A tower replay goes on through the floors it clears
+	climbing := p.tower != nil && p.state == state.StateStageClear
+	if scene.AnyInput() || p.replayer == nil || (p.state != state.StatePlaying && !climbing) {
+		return p.attractBack
+	}
+	return nil
+}
+
+// replayInput returns the next recorded frame as this frame's input.
+// ok is false once the replay ran out.
+func (p *Playing) replayInput() (in inputState, arrowIn ui.ArrowSelectInput, ok bool) {
+	r, ok := p.replayer.GetInput()
+	if !ok {
+		return in, arrowIn, false
+	}
+	in = inputState{
+		Left:         r.Left,
+		Right:        r.Right,
+		Up:           r.Up,
+		Down:         r.Down,
+		JumpPressed:  r.JumpPressed,
+		JumpReleased: r.JumpReleased,
+		Dash:         r.Dash,
+		Attack:       r.MouseClick,
+		Block:        r.Block,
+		Summon:       r.Summon,
+		Interact:     r.Interact,
+		MouseX:       r.MouseX,
+		MouseY:       r.MouseY,
+		GraceFrames:  r.GraceFrames,
+		GameSpeedPct: r.GameSpeedPct,
+		AimAssist:    r.AimAssist,
+	}
+	arrowIn = ui.ArrowSelectInput{
+		Open:    r.RightClickPressed,
+		Close:   r.RightClickReleased,
+		CursorX: r.MouseX,
+		CursorY: r.MouseY,
+		StickX:  r.StickX,
+		StickY:  r.StickY,
+		Number:  r.ArrowNumber,
+	}
+	return in, arrowIn, true
+}
+
+// drawAttractOverlay draws the demo banner and a blinking prompt
+func (p *Playing) drawAttractOverlay(screen *ebiten.Image) {
+	cx := float64(p.screenW / 2)
+	ui.FillRect(screen, 0, float64(p.screenH-28), float64(p.screenW), 20, colorAttractBar)
+	ui.Draw(screen, i18n.T(p.attractLabel), 6, float64(p.screenH-24), ui.StyleHUD)
+	if p.replayer != nil && (p.replayer.CurrentFrame()/attractBlinkFrames)%2 == 0 {
+		ui.Draw(screen, i18n.T("attract.pressAnyKey"), cx, float64(p.screenH-24), ui.StyleCenter)
+	}
+}
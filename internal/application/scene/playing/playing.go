@@ -2,9 +2,8 @@
 package playing
 
 import (
-	"fmt"
+	"image"
 	"image/color"
-	"log"
 	"math"
 	"math/rand"
 	"time"
@@ -12,17 +11,37 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/achievement"
+	"github.com/younwookim/mg/internal/application/clip"
+	"github.com/younwookim/mg/internal/application/dialogue"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/metrics"
+	"github.com/younwookim/mg/internal/application/replay"
 	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/scene/options"
 	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/stats"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/application/viewport"
 	"github.com/younwookim/mg/internal/domain/entity"
 	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
 )
 
 // Colors for rendering
 var (
 	colorWall       = color.RGBA{80, 80, 100, 255}
 	colorSpike      = color.RGBA{200, 50, 50, 255}
+	colorLava       = color.RGBA{230, 120, 30, 255}
+	colorIce        = color.RGBA{160, 210, 240, 255}
+	colorSticky     = color.RGBA{120, 90, 140, 255}
+	colorCrumble    = color.RGBA{150, 110, 70, 255}
+	colorDoor       = color.RGBA{170, 130, 60, 255}
+	colorDecoration = color.RGBA{70, 140, 80, 255}
 	colorPlayer     = color.RGBA{100, 200, 100, 255}
 	colorHead       = color.RGBA{100, 100, 200, 128}
 	colorFeet       = color.RGBA{200, 200, 100, 128}
@@ -47,32 +66,183 @@ type Playing struct {
 
 	// Physics config for ECS systems
 	physicsCfg ecs.PhysicsConfig
+	character  config.PlayerConfig // selected character (settings or first configured)
 	arrowCfg   ecs.ProjectileConfig
 
-	// Feedback
-	hitstopFrames int
-	screenShakeX  float64
-	screenShakeY  float64
-	shakeDecay    float64
-
 	// Mouse aiming
 	mouseWorldX float64
 	mouseWorldY float64
+	aimX, aimY  float64      // aim point after aim assist (world pixels)
+	aimTarget   ecs.EntityID // enemy the aim assist snapped to (0 = none)
 
-	// Arrow selection UI (keep entity package for UI)
-	arrowSelectUI *entity.ArrowSelectUI
+	// Arrow select wheel (slow motion while open)
+	arrowSelectUI *ui.ArrowSelect
 
 	// Deterministic RNG
 	rng  *rand.Rand
 	seed int64
 
-	// Input recording
+	// Input recording: to recordFilename, else a new file per run in
+	// replayDir (see SetReplayDir)
 	recorder       *Recorder
 	recordFilename string
+	replayDir      string
+	replayFile     string
+	versionHash    string
 
-	// Enemy spawner
+	// Enemy spawner and the waves it has started (a wave starts when it
+	// spawns onto a cleared field)
 	spawnTimer  int
 	nextEnemyID ecs.EntityID
+	wave        int
+
+	// Debug overlay (-debug flag, F3 to toggle)
+	debugEnabled   bool
+	debugVisible   bool
+	debugLogScroll int // log panel lines scrolled back from the newest
+
+	// World history for rewinding (debug mode, F7; nil = off)
+	rewind     *ecs.Rewind
+	rewindBack int // frames rewound in StateRewind
+
+	// Entity inspector (debug overlay, Ctrl+click to select)
+	inspected     ecs.EntityID // 0 = none
+	inspectRow    int          // field row being edited (-1 = none)
+	inspectScroll int
+
+	// Physics tuning panel (debug mode, F4 to toggle)
+	tuningVisible bool
+	tuningDrag    int // slider being dragged (-1 = none)
+
+	// Per-system timing (nil when metrics are disabled)
+	metrics *metrics.Recorder
+
+	// Recent frames for GIF export (nil when clips are disabled)
+	clips      *clip.Buffer
+	clipBuffer *image.RGBA // full-size screen readback, reused
+
+	// Gamepad rumble
+	rumbleDisabled bool
+	gamepadIDs     []ebiten.GamepadID
+
+	// User settings (nil = config defaults) and resolved key bindings
+	settings     *settings.Settings
+	settingsPath string
+	keys         keyBindings
+
+	// Window → logical mapping for the mouse cursor (nil = unscaled)
+	viewport *viewport.Viewport
+
+	// Dialogue (stage triggers and NPCs)
+	dialogue      *dialogue.Runner
+	portraits     map[string]color.RGBA
+	npcs          map[ecs.EntityID]npc
+	firedTriggers map[int]bool // "once" triggers already fired (kept across restarts)
+
+	// Open shop (StateShop)
+	shopNPC      ecs.EntityID
+	shopSelected int
+	shopMessage  string // i18n key of the last purchase result
+
+	// Render colors of elite enemy modifiers
+	eliteTints map[ecs.EliteModifier]color.RGBA
+
+	// Stage darkness and point lights (nil = fully lit)
+	lighting *lighting
+
+	// Free camera of photo mode (StatePhoto)
+	photo photoCamera
+
+	// Look up/down camera shift
+	peek cameraPeek
+
+	// Pre-rendered static tiles, the foreground layer and how far it has
+	// faded with the player behind it (0 = opaque, 1 = stage's fade)
+	tiles          tileCache
+	foreground     tileCache
+	foregroundFade float64
+
+	// HUD layout and theme (ui.json), the canvas a scaled HUD is drawn on
+	// and the stage drawn small on the minimap
+	hud       hudLayout
+	hudCanvas *ebiten.Image
+	minimap   minimapCache
+
+	// Kill feed and the session's combat log file
+	combatLog combatLog
+
+	// Recent jump presses for the debug overlay
+	inputTimeline inputTimeline
+
+	// Fixed-timestep accumulator and the presses waiting for its next frame
+	clock          stepClock
+	pendingPresses pendingPresses
+
+	// Accessibility input filters
+	assist inputAssist
+
+	// Replay playback (nil = the keys drive the player), the scene an
+	// attract mode demo returns to (nil = not a demo) and its banner
+	replayer     *replay.Replayer
+	attractBack  scene.Scene
+	attractLabel string
+
+	// Difficulty and character of the replay being played back, in place
+	// of the settings' (nil = the settings')
+	replaySettings *runSettings
+
+	// TAS editor (StateTAS)
+	tas *tasSession
+
+	// Soak test bot playing headlessly (nil = none, see RunBot)
+	bot *bot
+
+	// Active difficulty multipliers (from settings, else config default)
+	difficulty config.DifficultyProfile
+
+	// Endless tower run (nil = playing a stage)
+	tower *towerRun
+
+	// Reloads the config with a difficulty's overrides (nil = fixed config)
+	loadConfig       ConfigLoader
+	configDifficulty string // difficulty the current config was loaded with
+
+	// Profile save and achievements (in-memory profile until SetProfile)
+	profile      *save.Data
+	profilePath  string
+	achievements *achievement.Tracker
+	toasts       ui.Toasts
+
+	// Per-run statistics for the stage clear summary and profile history
+	stats       stats.Run
+	historyPath string
+
+	// Local leaderboard (nil = disabled) and the pending initials entry
+	leaderboard     *save.Leaderboard
+	leaderboardPath string
+	initials        *ui.Initials
+	charsBuf        []rune
+	lastInitials    string
+	runPoints       int
+	runCleared      bool
+	boardRank       int // row of the last submitted run (-1 = not ranked)
+
+	// Main menu returned to from the pause screen (nil = no menu)
+	menu scene.Scene
+
+	// Embedding hooks: run end and music callbacks and custom systems
+	onRunEnd func(RunResult)
+	onMusic  func(track string)
+	systems  []customSystem
+
+	// Music track playing ("" = the stage's own), the boss intro and the
+	// frames left of the stage clear celebration
+	music   string
+	banner  bossBanner
+	victory int
+
+	// Input read by the scheduled systems this frame
+	frameInput inputState
 }
 
 // New creates a new Playing scene.
@@ -80,50 +250,37 @@ type Playing struct {
 func New(cfg *config.GameConfig, stageCfg *config.StageConfig, stage *entity.Stage, recordPath string) *Playing {
 	// Initialize seeded RNG for deterministic randomness
 	seed := time.Now().UnixNano()
-	rng := rand.New(rand.NewSource(seed))
+
+	// Start with the default character (settings may pick another)
+	character := cfg.Entities.Character("")
 
 	// Create ECS world
 	world := ecs.NewWorld()
-
-	// Create player hitbox from config
-	playerCfg := cfg.Entities.Player
-	hitbox := ecs.HitboxTrapezoid{
-		Head: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Head.OffsetX,
-			OffsetY: playerCfg.Hitbox.Head.OffsetY,
-			Width:   playerCfg.Hitbox.Head.Width,
-			Height:  playerCfg.Hitbox.Head.Height,
-		},
-		Body: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Body.OffsetX,
-			OffsetY: playerCfg.Hitbox.Body.OffsetY,
-			Width:   playerCfg.Hitbox.Body.Width,
-			Height:  playerCfg.Hitbox.Body.Height,
-		},
-		Feet: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Feet.OffsetX,
-			OffsetY: playerCfg.Hitbox.Feet.OffsetY,
-			Width:   playerCfg.Hitbox.Feet.Width,
-			Height:  playerCfg.Hitbox.Feet.Height,
-		},
-	}
+	world.Feedback = buildFeedback(cfg)
+	world.Block = buildBlockConfig(cfg, character)
+	world.DashAttack = buildDashAttackConfig(cfg)
+	world.CC = buildCCConfig(cfg)
+	world.Magnet = buildMagnetConfig(cfg)
+	world.Stomp = buildStompConfig(cfg)
+	world.Contact = buildContactConfig(cfg)
+	world.Factions = buildFactionRelations(cfg)
+	world.Collision = buildCollisionMask(cfg)
+	world.Energy = buildEnergyConfig(cfg)
+	world.Aggro = buildAggroConfig(cfg)
+	world.Summon = buildSummonConfig(cfg)
+	world.Weather = buildWeather(stageCfg.Weather)
+	world.TimeScale = buildTimeScale(cfg)
+	rng := world.SeedRand(seed)
 
 	// Create player entity
-	world.CreatePlayer(stage.SpawnX, stage.SpawnY, hitbox, playerCfg.Stats.MaxHealth)
+	world.CreatePlayer(stage.SpawnX, stage.SpawnY, buildPlayerProfile(character))
 
 	// Build physics config for ECS
-	physicsCfg := buildPhysicsConfig(cfg)
+	physicsCfg := buildPhysicsConfig(cfg, character)
 
 	// Build arrow config
 	arrowCfg := buildArrowConfig(cfg)
 
-	// Create arrow select UI with config
-	arrowSelectCfg := entity.ArrowSelectConfig{
-		Radius:      cfg.Physics.ArrowSelect.Radius,
-		MinDistance: cfg.Physics.ArrowSelect.MinDistance,
-		MaxFrame:    cfg.Physics.ArrowSelect.MaxFrame,
-	}
-
 	p := &Playing{
 		config:         cfg,
 		stageCfg:       stageCfg,
@@ -134,61 +291,84 @@ func New(cfg *config.GameConfig, stageCfg *config.StageConfig, stage *entity.Sta
 		screenH:        cfg.Physics.Display.ScreenHeight,
 		tileSize:       stage.TileSize,
 		physicsCfg:     physicsCfg,
+		character:      character,
 		arrowCfg:       arrowCfg,
-		shakeDecay:     cfg.Physics.Feedback.ScreenShake.Decay,
-		arrowSelectUI:  entity.NewArrowSelectUIWithConfig(arrowSelectCfg),
 		rng:            rng,
 		seed:           seed,
 		recordFilename: recordPath,
+		keys:           resolveKeyBindings(settings.DefaultKeyBindings()),
+		firedTriggers:  map[int]bool{},
+		boardRank:      -1,
+		tuningDrag:     -1,
+		foreground:     tileCache{foreground: true},
 	}
+	p.resetArrowSelect()
+	p.registerSystems()
+	p.dialogue, p.portraits = buildDialogue(cfg)
+	p.eliteTints = buildEliteTints(cfg)
+	p.lighting = buildLighting(cfg, stageCfg)
+	p.hud = buildHUDLayout(cfg)
+	p.SetProfile(save.New(), "")
+	p.applyDifficulty()
+	p.startStage()
 
 	// Initialize recorder if recording is enabled
 	if recordPath != "" {
-		p.recorder = NewRecorder(seed, stageCfg.Name)
-		log.Printf("Recording enabled: %s (seed: %d)", recordPath, seed)
+		p.recorder = NewRecorder(seed, stageCfg.ID)
+		logging.Replay.Infof("Recording enabled: %s (seed: %d)", recordPath, seed)
 	}
 
 	// Spawn enemies from stage config
-	for _, spawn := range stageCfg.Enemies {
-		p.spawnEnemy(spawn.X, spawn.Y, spawn.Type, spawn.FacingRight)
-	}
+	p.spawnStageEnemies()
 
 	// Initialize enemy ID counter for spawner
 	p.nextEnemyID = ecs.EntityID(len(stageCfg.Enemies) + 2) // +2 because player is ID 1
+	p.spawnStagePickups()
+	p.spawnNPCs()
 
 	return p
 }
 
-func buildPhysicsConfig(cfg *config.GameConfig) ecs.PhysicsConfig {
+// buildPhysicsConfig converts physics config to ECS units, with the
+// character's movement and jump multipliers applied
+func buildPhysicsConfig(cfg *config.GameConfig, character config.PlayerConfig) ecs.PhysicsConfig {
 	return ecs.PhysicsConfig{
 		// Physics
 		// Gravity: acceleration (pixels/sec²) → IU velocity change per frame
-		Gravity:      ecs.ToIUAccelPerFrame(cfg.Physics.Physics.Gravity),
-		MaxFallSpeed: ecs.ToIUPerSubstep(cfg.Physics.Physics.MaxFallSpeed),
+		Gravity:      fixedpoint.ToIUAccelPerFrame(cfg.Physics.Physics.Gravity),
+		MaxFallSpeed: fixedpoint.ToIUPerSubstep(cfg.Physics.Physics.MaxFallSpeed),
 
 		// Movement
-		MaxSpeed: ecs.ToIUPerSubstep(cfg.Physics.Movement.MaxSpeed),
+		MaxSpeed: scaleInt(fixedpoint.ToIUPerSubstep(cfg.Physics.Movement.MaxSpeed), character.Stats.MoveSpeed),
 		// Acceleration/Deceleration: pixels/sec² → IU velocity change per frame
-		Acceleration:  ecs.ToIUAccelPerFrame(cfg.Physics.Movement.Acceleration),
-		Deceleration:  ecs.ToIUAccelPerFrame(cfg.Physics.Movement.Deceleration),
-		AirControlPct: ecs.PctToInt(cfg.Physics.Movement.AirControl),
-		TurnaroundPct: ecs.PctToInt(cfg.Physics.Movement.TurnaroundBoost),
+		Acceleration:  fixedpoint.ToIUAccelPerFrame(cfg.Physics.Movement.Acceleration),
+		Deceleration:  fixedpoint.ToIUAccelPerFrame(cfg.Physics.Movement.Deceleration),
+		AirControlPct: fixedpoint.ToPct(cfg.Physics.Movement.AirControl),
+		TurnaroundPct: fixedpoint.ToPct(cfg.Physics.Movement.TurnaroundBoost),
 
 		// Jump
-		JumpForce:         ecs.ToIUPerSubstep(cfg.Physics.Jump.Force),
-		VarJumpPct:        ecs.PctToInt(cfg.Physics.Jump.VariableJumpMultiplier),
-		CoyoteFrames:      int(cfg.Physics.Jump.CoyoteTime * 60),
-		JumpBufferFrames:  int(cfg.Physics.Jump.JumpBuffer * 60),
+		JumpForce:         scaleInt(fixedpoint.ToIUPerSubstep(cfg.Physics.Jump.Force), character.Stats.JumpForce),
+		VarJumpPct:        fixedpoint.ToPct(cfg.Physics.Jump.VariableJumpMultiplier),
+		CoyoteFrames:      fixedpoint.SecondsToFrames(cfg.Physics.Jump.CoyoteTime),
+		JumpBufferFrames:  fixedpoint.SecondsToFrames(cfg.Physics.Jump.JumpBuffer),
 		ApexModEnabled:    cfg.Physics.Jump.ApexModifier.Enabled,
-		ApexThreshold:     ecs.ToIUPerSubstep(cfg.Physics.Jump.ApexModifier.Threshold),
-		ApexGravityPct:    ecs.PctToInt(cfg.Physics.Jump.ApexModifier.GravityMultiplier),
-		FallMultiplierPct: ecs.PctToInt(cfg.Physics.Jump.FallMultiplier),
+		ApexThreshold:     fixedpoint.ToIUPerSubstep(cfg.Physics.Jump.ApexModifier.Threshold),
+		ApexGravityPct:    fixedpoint.ToPct(cfg.Physics.Jump.ApexModifier.GravityMultiplier),
+		FallMultiplierPct: fixedpoint.ToPct(cfg.Physics.Jump.FallMultiplier),
 
 		// Dash
-		DashSpeed:          ecs.ToIUPerSubstep(cfg.Physics.Dash.Speed),
-		DashFrames:         int(cfg.Physics.Dash.Duration * 60),
-		DashCooldownFrames: int(cfg.Physics.Dash.Cooldown * 60),
-		DashIframes:        int(cfg.Physics.Dash.IframesDuration * 60),
+		DashSpeed:          fixedpoint.ToIUPerSubstep(cfg.Physics.Dash.Speed),
+		DashFrames:         fixedpoint.SecondsToFrames(cfg.Physics.Dash.Duration),
+		DashCooldownFrames: fixedpoint.SecondsToFrames(cfg.Physics.Dash.Cooldown),
+		DashIframes:        fixedpoint.SecondsToFrames(cfg.Physics.Dash.IframesDuration),
+		DashDirections:     buildDashDirections(cfg.Physics.Dash.Directions),
+		DashToAim:          cfg.Physics.Dash.Aim,
+
+		// Crouch
+		CrouchSpeedPct: fixedpoint.ToPct(cfg.Physics.Crouch.Speed),
+		SlideMinSpeed:  fixedpoint.ToIUPerSubstep(cfg.Physics.Crouch.SlideMinSpeed),
+		SlideFrames:    fixedpoint.SecondsToFrames(cfg.Physics.Crouch.SlideDuration),
+		SlideDecel:     fixedpoint.ToIUAccelPerFrame(cfg.Physics.Crouch.SlideFriction),
 
 		// Collision
 		CornerCorrectionMargin:  cfg.Physics.Collision.CornerCorrection.Margin,
@@ -196,25 +376,334 @@ func buildPhysicsConfig(cfg *config.GameConfig) ecs.PhysicsConfig {
 	}
 }
 
+// buildDashDirections parses the allowed dash directions. Unknown names
+// keep the horizontal-only dash.
+func buildDashDirections(name string) ecs.DashDirections {
+	switch name {
+	case "", "horizontal":
+		return ecs.DashHorizontal
+	case "four":
+		return ecs.DashFourWay
+	case "eight":
+		return ecs.DashEightWay
+	}
+	logging.Config.Warnf("Unknown dash directions: %s", name)
+	return ecs.DashHorizontal
+}
+
+// arrowStuckSeconds is how long arrows stay stuck in walls and enemies
+const arrowStuckSeconds = 5
+
+// buildArrowConfig converts the enemyArrow projectile config to ECS units
 func buildArrowConfig(cfg *config.GameConfig) ecs.ProjectileConfig {
-	arrowCfg := cfg.Entities.Projectiles["playerArrow"]
+	arrowCfg := cfg.Entities.Projectiles["enemyArrow"]
 	return ecs.ProjectileConfig{
-		GravityAccel:  ecs.ToIUAccelPerFrame(arrowCfg.Physics.GravityAccel),
-		MaxFallSpeed:  ecs.ToIUPerSubstep(arrowCfg.Physics.MaxFallSpeed),
+		Speed:         fixedpoint.ToIUPerSubstep(arrowCfg.Physics.Speed),
+		GravityAccel:  fixedpoint.ToIUAccelPerFrame(arrowCfg.Physics.GravityAccel),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(arrowCfg.Physics.MaxFallSpeed),
 		MaxRange:      int(arrowCfg.Physics.MaxRange),
 		Damage:        arrowCfg.Damage,
-		HitboxOffsetX: 2,
-		HitboxOffsetY: 2,
-		HitboxWidth:   12,
-		HitboxHeight:  4,
-		StuckDuration: 300, // 5 seconds at 60fps
+		HitboxOffsetX: arrowCfg.Hitbox.OffsetX,
+		HitboxOffsetY: arrowCfg.Hitbox.OffsetY,
+		HitboxWidth:   arrowCfg.Hitbox.Width,
+		HitboxHeight:  arrowCfg.Hitbox.Height,
+		StuckDuration: fixedpoint.SecondsToFrames(arrowStuckSeconds),
+	}
+}
+
+// buildDashAttackConfig converts dash attack config to ECS units
+func buildDashAttackConfig(cfg *config.GameConfig) ecs.DashAttackConfig {
+	attack := cfg.Physics.Dash.Attack
+	return ecs.DashAttackConfig{
+		Damage:         attack.Damage,
+		Knockback:      fixedpoint.ToIUPerSubstep(attack.Knockback),
+		SlowdownFrames: fixedpoint.SecondsToFrames(attack.Slowdown),
+		SlowdownPct:    fixedpoint.ToPct(attack.SlowdownScale),
+	}
+}
+
+// buildTimeScale creates the world's time scale with the configured slow
+// motion ramps
+func buildTimeScale(cfg *config.GameConfig) *ecs.TimeScale {
+	ts := ecs.NewTimeScale()
+	ts.RampInPct = rampPct(cfg.Physics.TimeScale.RampIn)
+	ts.RampOutPct = rampPct(cfg.Physics.TimeScale.RampOut)
+	return ts
+}
+
+// rampPct converts the seconds a full 100% speed change takes to
+// percentage points per frame (0 = instant)
+func rampPct(seconds float64) int {
+	frames := fixedpoint.SecondsToFrames(seconds)
+	if frames <= 0 {
+		return 0
+	}
+	return (100 + frames - 1) / frames
+}
+
+func buildStompConfig(cfg *config.GameConfig) ecs.StompConfig {
+	stomp := cfg.Physics.Combat.Stomp
+	return ecs.StompConfig{
+		Damage:     stomp.Damage,
+		Bounce:     fixedpoint.ToIUPerSubstep(stomp.Bounce),
+		JumpFrames: fixedpoint.SecondsToFrames(stomp.JumpWindow),
+	}
+}
+
+// buildContactConfig converts enemy touch damage config to ECS units
+func buildContactConfig(cfg *config.GameConfig) ecs.ContactConfig {
+	contact := cfg.Physics.Combat.Contact
+	return ecs.ContactConfig{
+		CooldownFrames: fixedpoint.SecondsToFrames(contact.Cooldown),
+		Separation:     fixedpoint.ToIUPerSubstep(contact.Separation),
+	}
+}
+
+// buildFactionRelations applies the configured faction relations over the
+// built-in ones. Unknown faction names are skipped.
+func buildFactionRelations(cfg *config.GameConfig) ecs.FactionRelations {
+	relations := ecs.DefaultFactionRelations()
+	for attackerName, targets := range cfg.Physics.Combat.Factions {
+		attacker, ok := ecs.ParseFaction(attackerName)
+		if !ok {
+			logging.Config.Warnf("Unknown faction: %s", attackerName)
+			continue
+		}
+		relations[attacker] = [ecs.FactionCount]bool{}
+		for _, targetName := range targets {
+			target, ok := ecs.ParseFaction(targetName)
+			if !ok {
+				logging.Config.Warnf("Unknown faction: %s", targetName)
+				continue
+			}
+			relations[attacker][target] = true
+		}
+	}
+	return relations
+}
+
+// buildCollisionMask applies the configured collision layers over the
+// built-in mask. A listed layer interacts with exactly the layers listed
+// for it, and with the listed layers that list it. Unknown layer names are
+// skipped.
+func buildCollisionMask(cfg *config.GameConfig) ecs.CollisionMask {
+	mask := ecs.DefaultCollisionMask()
+	layers := cfg.Physics.Collision.Layers
+	for name := range layers {
+		layer, ok := ecs.ParseLayer(name)
+		if !ok {
+			logging.Config.Warnf("Unknown collision layer: %s", name)
+			continue
+		}
+		for other := ecs.LayerPlayer; other < ecs.LayerCount; other++ {
+			mask.Set(layer, other, false)
+		}
+	}
+	for name, others := range layers {
+		layer, ok := ecs.ParseLayer(name)
+		if !ok {
+			continue
+		}
+		for _, otherName := range others {
+			other, ok := ecs.ParseLayer(otherName)
+			if !ok {
+				logging.Config.Warnf("Unknown collision layer: %s", otherName)
+				continue
+			}
+			mask.Set(layer, other, true)
+		}
 	}
+	return mask
 }
 
-func (p *Playing) spawnEnemy(x, y int, enemyType string, facingRight bool) {
-	enemyCfg, ok := p.config.Entities.Enemies[enemyType]
+// enemyFaction returns the side an enemy fights on.
+// Unknown names fall back to regular enemies.
+func enemyFaction(enemyCfg config.EnemyConfig) ecs.Faction {
+	if enemyCfg.Faction == "" {
+		return ecs.FactionEnemy
+	}
+	faction, ok := ecs.ParseFaction(enemyCfg.Faction)
 	if !ok {
-		return
+		logging.Config.Warnf("Unknown faction for enemy %s: %s", enemyCfg.ID, enemyCfg.Faction)
+		return ecs.FactionEnemy
+	}
+	return faction
+}
+
+// enemyAimMode returns how an enemy aims its shots.
+// Unknown names fall back to straight shots.
+func enemyAimMode(enemyCfg config.EnemyConfig) ecs.AimMode {
+	if enemyCfg.AI.Aim == "" {
+		return ecs.AimStraight
+	}
+	mode, ok := ecs.ParseAimMode(enemyCfg.AI.Aim)
+	if !ok {
+		logging.Config.Warnf("Unknown aim mode for enemy %s: %s", enemyCfg.ID, enemyCfg.AI.Aim)
+	}
+	return mode
+}
+
+// buildFlock converts swarm steering config (nil = no flocking)
+func buildFlock(cfg *config.FlockConfig) ecs.Flock {
+	if cfg == nil {
+		return ecs.Flock{}
+	}
+	return ecs.Flock{
+		Radius:     int(cfg.Radius),
+		Separation: fixedpoint.ToPct(cfg.Separation),
+		Alignment:  fixedpoint.ToPct(cfg.Alignment),
+		Cohesion:   fixedpoint.ToPct(cfg.Cohesion),
+		Seek:       fixedpoint.ToPct(cfg.Seek),
+	}
+}
+
+// buildPatrolRoute converts a spawn's waypoints (unknown modes loop)
+func buildPatrolRoute(spawn config.EnemySpawnConfig) ecs.PatrolRoute {
+	if len(spawn.Waypoints) == 0 {
+		return ecs.PatrolRoute{}
+	}
+	route := ecs.PatrolRoute{Points: make([]ecs.Waypoint, len(spawn.Waypoints))}
+	for i, wp := range spawn.Waypoints {
+		route.Points[i] = ecs.Waypoint{X: wp.X, Y: wp.Y}
+	}
+	if spawn.PatrolMode != "" {
+		mode, ok := ecs.ParsePatrolMode(spawn.PatrolMode)
+		if !ok {
+			logging.Config.Warnf("Unknown patrol mode for enemy %s: %s", spawn.Type, spawn.PatrolMode)
+		}
+		route.Mode = mode
+	}
+	return route
+}
+
+func buildAggroConfig(cfg *config.GameConfig) ecs.AggroConfig {
+	aggro := cfg.Physics.Combat.Aggro
+	return ecs.AggroConfig{
+		Radius:      aggro.Radius,
+		DelayFrames: fixedpoint.SecondsToFrames(aggro.Delay),
+		AlertFrames: fixedpoint.SecondsToFrames(aggro.Duration),
+	}
+}
+
+// hazardConfig converts damage tile config to ECS units (player iframes
+// follow the difficulty)
+func (p *Playing) hazardConfig() ecs.HazardConfig {
+	combat := p.config.Physics.Combat
+	return ecs.HazardConfig{
+		Bounce:         fixedpoint.ToIUPerSubstep(combat.Hazard.Bounce),
+		IframeFrames:   p.playerIframes(),
+		EnemyIframes:   fixedpoint.SecondsToFrames(combat.Hazard.EnemyIframes),
+		KnockbackForce: fixedpoint.ToIUPerSubstep(combat.Knockback.Force),
+		KnockbackUp:    fixedpoint.ToIUPerSubstep(combat.Knockback.UpForce),
+		BurnFrames:     fixedpoint.SecondsToFrames(combat.Hazard.BurnDuration),
+		BurnTickFrames: fixedpoint.SecondsToFrames(combat.Hazard.BurnInterval),
+	}
+}
+
+// enemyStompKind returns how an enemy reacts to being landed on.
+// Unknown names fall back to ordinary contact.
+func enemyStompKind(enemyCfg config.EnemyConfig) ecs.StompKind {
+	if enemyCfg.Stomp == "" {
+		return ecs.StompNone
+	}
+	kind, ok := ecs.ParseStompKind(enemyCfg.Stomp)
+	if !ok {
+		logging.Config.Warnf("Unknown stomp kind for enemy %s: %s", enemyCfg.ID, enemyCfg.Stomp)
+	}
+	return kind
+}
+
+func buildMagnetConfig(cfg *config.GameConfig) ecs.MagnetConfig {
+	magnet := cfg.Physics.Magnet
+	radius := make([]int, len(magnet.Radius))
+	for i, r := range magnet.Radius {
+		radius[i] = int(r)
+	}
+	return ecs.MagnetConfig{
+		Radius: radius,
+		Accel:  fixedpoint.ToIUAccelPerFrame(magnet.Acceleration),
+	}
+}
+
+// buildCCConfig converts crowd-control config to ECS units.
+// Sides missing from config keep the built-in defaults.
+func buildCCConfig(cfg *config.GameConfig) ecs.CCConfig {
+	cc := ecs.DefaultCCConfig()
+	cc.Player = buildCCProfile(cfg.Physics.Combat.CrowdControl.Player, cc.Player)
+	cc.Enemy = buildCCProfile(cfg.Physics.Combat.CrowdControl.Enemy, cc.Enemy)
+	return cc
+}
+
+func buildCCProfile(c config.CCProfileConfig, def ecs.CCProfile) ecs.CCProfile {
+	if c == (config.CCProfileConfig{}) {
+		return def
+	}
+	curve := ecs.CurveLinear
+	switch c.KnockbackCurve {
+	case "quadratic":
+		curve = ecs.CurveQuadratic
+	case "constant":
+		curve = ecs.CurveConstant
+	}
+	return ecs.CCProfile{
+		Curve:           curve,
+		KnockbackFrames: fixedpoint.SecondsToFrames(c.KnockbackDuration),
+		StunFrames:      fixedpoint.SecondsToFrames(c.StunDuration),
+		DecayVertical:   c.DecayVertical,
+	}
+}
+
+// buildFeedback creates the world's feedback state from config profiles.
+// Events missing from config keep their built-in defaults.
+func buildFeedback(cfg *config.GameConfig) *ecs.Feedback {
+	fb := ecs.NewFeedback()
+	fb.HitstopEnabled = cfg.Physics.Feedback.Hitstop.Enabled
+	fb.ShakeEnabled = cfg.Physics.Feedback.ScreenShake.Enabled
+	fb.RumbleEnabled = cfg.Physics.Feedback.Rumble.Enabled
+
+	for name, pc := range cfg.Physics.Feedback.Profiles {
+		profile := ecs.FeedbackProfile{
+			HitstopFrames:  pc.Hitstop,
+			ShakeIntensity: pc.Shake,
+			ShakeFrames:    fixedpoint.SecondsToFrames(pc.Duration),
+			DecayPct:       fixedpoint.ToPct(pc.Decay),
+			Directional:    pc.Directional,
+		}
+		if pc.Rumble != nil {
+			profile.Rumble = ecs.Rumble{
+				Strong: pc.Rumble.Strong,
+				Weak:   pc.Rumble.Weak,
+				Frames: fixedpoint.SecondsToFrames(pc.Rumble.Duration),
+			}
+		}
+		switch pc.Falloff {
+		case "quadratic":
+			profile.Falloff = ecs.FalloffQuadratic
+		case "exponential":
+			profile.Falloff = ecs.FalloffExponential
+		default:
+			profile.Falloff = ecs.FalloffLinear
+		}
+		switch pc.Stacking {
+		case "max":
+			profile.Stacking = ecs.StackMax
+		case "add":
+			profile.Stacking = ecs.StackAdd
+		default:
+			profile.Stacking = ecs.StackReplace
+		}
+		fb.Profiles[name] = profile
+	}
+
+	return fb
+}
+
+// spawnEnemy creates an enemy from its entity config.
+// It returns 0 for unknown enemy types.
+func (p *Playing) spawnEnemy(spawn config.EnemySpawnConfig) ecs.EntityID {
+	enemyCfg, ok := p.config.Entities.Enemies[spawn.Type]
+	if !ok {
+		return 0
 	}
 
 	aiType := ecs.AIPatrol
@@ -227,54 +716,130 @@ func (p *Playing) spawnEnemy(x, y int, enemyType string, facingRight bool) {
 		aiType = ecs.AIChase
 	case "aggressive":
 		aiType = ecs.AIAggressive
+	case "swarm":
+		aiType = ecs.AISwarm
+	case "charger":
+		aiType = ecs.AICharger
 	}
 
+	maxHealth, contactDamage, attackCooldown := p.scaleEnemyConfig(&enemyCfg)
+	hitscan, piercing := p.enemyBeam(&enemyCfg)
 	ecsCfg := ecs.EnemyConfig{
-		MaxHealth:     enemyCfg.Stats.MaxHealth,
-		ContactDamage: enemyCfg.Stats.ContactDamage,
-		MoveSpeed:     ecs.ToIUPerSubstep(enemyCfg.Stats.MoveSpeed),
-		HitboxOffsetX: enemyCfg.Hitbox.Body.OffsetX,
-		HitboxOffsetY: enemyCfg.Hitbox.Body.OffsetY,
-		HitboxWidth:   enemyCfg.Hitbox.Body.Width,
-		HitboxHeight:  enemyCfg.Hitbox.Body.Height,
-		AIType:        aiType,
-		DetectRange:   int(enemyCfg.AI.DetectRange),
-		PatrolDist:    int(enemyCfg.AI.PatrolDistance),
-		AttackRange:   int(enemyCfg.AI.AttackRange),
-		JumpForce:     ecs.ToIUPerSubstep(enemyCfg.AI.JumpForce),
-		Flying:        enemyCfg.AI.Flying,
-		GoldDropMin:   enemyCfg.Stats.GoldDrop.Min,
-		GoldDropMax:   enemyCfg.Stats.GoldDrop.Max,
-	}
-
-	p.world.CreateEnemy(x, y, ecsCfg, facingRight)
+		MaxHealth:      maxHealth,
+		ContactDamage:  contactDamage,
+		MoveSpeed:      fixedpoint.ToIUPerSubstep(enemyCfg.Stats.MoveSpeed),
+		HitboxOffsetX:  enemyCfg.Hitbox.Body.OffsetX,
+		HitboxOffsetY:  enemyCfg.Hitbox.Body.OffsetY,
+		HitboxWidth:    enemyCfg.Hitbox.Body.Width,
+		HitboxHeight:   enemyCfg.Hitbox.Body.Height,
+		AIType:         aiType,
+		DetectRange:    p.weatherDetectRange(int(enemyCfg.AI.DetectRange)),
+		PatrolDist:     int(enemyCfg.AI.PatrolDistance),
+		AttackRange:    int(enemyCfg.AI.AttackRange),
+		AttackCooldown: attackCooldown,
+		JumpForce:      fixedpoint.ToIUPerSubstep(enemyCfg.AI.JumpForce),
+		Flying:         enemyCfg.AI.Flying,
+		Loot:           buildLootTable(enemyCfg.Loot),
+		Homing:         p.enemyHoming(&enemyCfg),
+		Hitscan:        hitscan,
+		Piercing:       piercing,
+		SpawnFrames:    fixedpoint.SecondsToFrames(enemyCfg.AI.SpawnDuration),
+		WindupFrames:   fixedpoint.SecondsToFrames(enemyCfg.AI.WindupDuration),
+		Mass:           fixedpoint.ToPct(enemyCfg.Stats.Mass),
+		GravityScale:   fixedpoint.ToPct(enemyCfg.Stats.GravityScale),
+		Solid:          enemyCfg.Solid,
+		Stomp:          enemyStompKind(enemyCfg),
+		Group:          spawn.Group,
+		Route:          buildPatrolRoute(spawn),
+		TurnAtLedges:   enemyCfg.AI.TurnAtLedges,
+		Aim:            enemyAimMode(enemyCfg),
+		ArcShots:       enemyCfg.AI.Arc,
+		Flock:          buildFlock(enemyCfg.AI.Flock),
+		Faction:        enemyFaction(enemyCfg),
+
+		ChargeSpeed:      fixedpoint.ToIUPerSubstep(enemyCfg.AI.ChargeSpeed),
+		ChargeStunFrames: fixedpoint.SecondsToFrames(enemyCfg.AI.ChargeStun),
+
+		KnockbackResistPct: fixedpoint.ToPct(enemyCfg.Stats.KnockbackResist),
+		SuperArmor:         enemyCfg.Stats.SuperArmor,
+		ArmoredCharge:      enemyCfg.AI.ArmoredCharge,
+	}
+	p.applyElite(&ecsCfg, spawn.Modifier)
+
+	return p.world.CreateEnemy(spawn.X, spawn.Y, ecsCfg, spawn.FacingRight)
 }
 
-// Update proceeds the game state (implements scene.Scene)
-func (p *Playing) Update(_ float64) (scene.Scene, error) {
-	// Handle hitstop
-	if p.hitstopFrames > 0 {
-		p.hitstopFrames--
-		return nil, nil
+// Update proceeds the game state (implements scene.Scene). dt is the
+// length of this tick; the simulation advances in fixed frames at the
+// configured simulation rate however often Update is called.
+func (p *Playing) Update(dt float64) (scene.Scene, error) {
+	p.updateDebugToggle()
+	p.updateInspector()
+	p.updateTuning()
+	p.updateMetricsExport()
+	p.updateClipExport()
+
+	if next := p.updateAttract(); next != nil {
+		return next, nil
 	}
 
+	steps := p.clock.advance(dt, fixedpoint.Sim)
+
 	switch p.state {
 	case state.StatePlaying:
-		p.updatePlaying()
+		p.updatePlaying(steps, dt)
 	case state.StatePaused:
 		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 			p.state = state.StatePlaying
 		}
-	case state.StateGameOver:
-		if inpututil.IsKeyJustPressed(ebiten.KeyZ) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if inpututil.IsKeyJustPressed(ebiten.KeyO) && p.settings != nil {
+			return options.New(p.settings, p.settingsPath, p.config.Physics.Display, p), nil
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyQ) && p.menu != nil {
 			p.restart()
+			return p.menu, nil
 		}
+	case state.StateDialogue:
+		p.updateDialogue()
+	case state.StateShop:
+		p.updateShop()
+	case state.StatePhoto:
+		p.updatePhoto()
+	case state.StateRewind:
+		p.updateRewind()
+	case state.StateTAS:
+		p.updateTAS()
+	case state.StateGameOver, state.StateStageClear:
+		if p.victory > 0 {
+			p.victory = max(p.victory-steps, 0)
+		} else if p.state == state.StateStageClear && p.tower != nil {
+			p.enterFloor(p.tower.floor.Number + 1)
+		} else if p.initials != nil {
+			p.updateInitials()
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyZ) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+			p.restart()
+		} else {
+			p.updateDailyExport()
+		}
+	}
+
+	p.processEvents()
+	for range steps {
+		p.toasts.Update()
+		p.updateCombatLog()
+		if p.banner.frames > 0 {
+			p.banner.frames--
+		}
+		p.updateForegroundFade()
 	}
 
 	return nil, nil // nil = stay on this scene
 }
 
-func (p *Playing) updatePlaying() {
+// updatePlaying reads input once per tick (dt seconds) and runs steps
+// simulation frames with it. Presses on a tick without a frame wait for the
+// next frame.
+func (p *Playing) updatePlaying(steps int, dt float64) {
 	// Check for pause
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		p.state = state.StatePaused
@@ -286,10 +851,79 @@ func (p *Playing) updatePlaying() {
 		p.saveRecording()
 	}
 
-	// Get input
-	input := p.getInput()
+	// F9: Photo mode
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		p.enterPhotoMode()
+		return
+	}
+
+	// F7: Rewind (debug mode)
+	if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+		p.enterRewind()
+		return
+	}
+
+	// F2: TAS editor on the recorded run (debug mode)
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		p.enterTASFromRun()
+		if p.state == state.StateTAS {
+			return
+		}
+	}
+
+	// Slow key: switch the reduced game speed off and on
+	if inpututil.IsKeyJustPressed(p.keys.Slow) {
+		p.assist.slowOff = !p.assist.slowOff
+	}
+
+	// Get input (through the accessibility filters)
+	input := p.assist.apply(p.getInput(), p.settings, dt)
+	arrowIn := p.getArrowSelectInput(input)
+	input, arrowIn = p.pendingPresses.merge(input, arrowIn)
+	if steps == 0 {
+		p.pendingPresses.hold(input, arrowIn)
+		return
+	}
+
+	for i := range steps {
+		if i > 0 {
+			input, arrowIn = withoutPresses(input, arrowIn)
+		}
+		if !p.stepPlaying(input, arrowIn) {
+			return
+		}
+		p.processEvents()
+	}
+}
+
+// stepPlaying runs one simulation frame. It returns false once the frame
+// left the playing state (dialogue, exit, game over).
+func (p *Playing) stepPlaying(input inputState, arrowIn ui.ArrowSelectInput) bool {
+	// Handle hitstop (held still, so nothing is drawn between frames)
+	if p.world.TimeScale.TickHitstop() {
+		ecs.StorePreviousPositions(p.world)
+		return true
+	}
+
+	// Lag compensation depends on the wall clock and the game speed and aim
+	// assist on the settings (and slow key), so they are recorded with the
+	// frame's input and taken from the replay on playback
+	input.GraceFrames = p.lagGraceFrames(ebiten.ActualTPS())
+	input.GameSpeedPct = p.assist.gameSpeedPct(p.settings)
+	input.AimAssist = p.settings != nil && p.settings.AimAssist
+
+	// A replay drives the player frame by frame while it lasts
+	if p.replayer != nil {
+		if in, arrow, ok := p.replayInput(); ok {
+			input, arrowIn = in, arrow
+		} else {
+			p.replayer = nil
+		}
+	}
+	input = p.towerInput(input)
 
-	// Record input if recording is enabled
+	// Record input if recording is enabled, before dialogue and exits can
+	// end the frame: playback consumes this frame's input all the same
 	if p.recorder != nil {
 		p.recorder.RecordFrame(RecordableInput{
 			Left:               input.Left,
@@ -300,142 +934,79 @@ func (p *Playing) updatePlaying() {
 			JumpPressed:        input.JumpPressed,
 			JumpReleased:       input.JumpReleased,
 			Dash:               input.Dash,
+			Block:              input.Block,
+			Summon:             input.Summon,
+			Interact:           input.Interact,
 			MouseX:             input.MouseX,
 			MouseY:             input.MouseY,
-			MouseClick:         inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
-			RightClickPressed:  inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight),
-			RightClickReleased: inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight),
+			MouseClick:         input.Attack,
+			RightClickPressed:  arrowIn.Open,
+			RightClickReleased: arrowIn.Close,
+			ArrowNumber:        arrowIn.Number,
+			StickX:             arrowIn.StickX,
+			StickY:             arrowIn.StickY,
+			GraceFrames:        input.GraceFrames,
+			GameSpeedPct:       input.GameSpeedPct,
+			AimAssist:          input.AimAssist,
 		})
 	}
 
-	// Update arrow selection UI (always, for animation)
-	p.arrowSelectUI.Update(
-		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight),
-		inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight),
-		input.MouseX, input.MouseY, p.screenW, p.screenH,
-	)
+	// Stage triggers and NPC interaction pause the simulation for dialogue
+	if p.checkDialogueTriggers(input) {
+		return false
+	}
+	if p.checkExitTrigger() {
+		return false
+	}
+	p.stats.Tick()
 
-	// Get player data for arrow selection
+	// Update the arrow select wheel (always, for animation)
+	p.updateArrowSelect(arrowIn)
+	p.updateCameraPeek(input)
 	playerData := p.world.PlayerData[p.world.PlayerID]
 
-	// Update highlight based on mouse position
-	if p.arrowSelectUI.IsActive() {
-		selectedDir := p.arrowSelectUI.UpdateHighlight(input.MouseX, input.MouseY)
-
-		// On right click release, confirm selection
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight) && selectedDir != entity.DirNone {
-			playerData.CurrentArrow = ecs.ArrowType(selectedDir)
-			p.world.PlayerData[p.world.PlayerID] = playerData
-		}
-	}
-
 	// Calculate camera offset for mouse world position
 	camX, camY := p.getCameraOffset()
 
 	// Convert mouse screen position to world position
 	p.mouseWorldX = float64(input.MouseX + camX)
 	p.mouseWorldY = float64(input.MouseY + camY)
+	p.updateAim(input.AimAssist)
 
 	// Handle attack (mouse click) - only when arrow selection UI is not active
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !p.arrowSelectUI.IsActive() {
-		pos := p.world.Position[p.world.PlayerID]
-		vel := p.world.Velocity[p.world.PlayerID]
-		mov := p.world.Movement[p.world.PlayerID]
-
-		arrowX := pos.PixelX() + 8
-		arrowY := pos.PixelY() + 10
-
-		// Player velocity is already in IU/substep
-		playerVX := vel.X
-		playerVY := vel.Y
-		if mov.OnGround {
-			playerVY = 0
-		}
-
-		p.spawnPlayerArrow(arrowX, arrowY, int(p.mouseWorldX), int(p.mouseWorldY), playerVX, playerVY)
+	// and the shield is down
+	if input.Attack && !p.arrowSelectUI.IsActive() && !playerData.Blocking &&
+		!p.tuningCapturesMouse(input.MouseX, input.MouseY) && !p.inspectorCapturesMouse(input.MouseX, input.MouseY) {
+		arrowX, arrowY, playerVX, playerVY := p.playerArrowOrigin()
+		p.spawnPlayerArrow(arrowX, arrowY, int(p.aimX), int(p.aimY), playerVX, playerVY)
 	}
 
 	// Update ECS systems
-	subSteps := 10
-	if p.arrowSelectUI.IsActive() {
-		subSteps = 1 // Slow motion during arrow select
-	}
-
-	// Update timers (once per frame)
-	ecs.UpdateTimers(p.world)
-
-	// Update player input (once per frame)
-	ecs.UpdatePlayerInput(p.world, ecs.InputState{
-		Left:         input.Left,
-		Right:        input.Right,
-		Up:           input.Up,
-		Down:         input.Down,
-		JumpPressed:  input.JumpPressed,
-		JumpReleased: input.JumpReleased,
-		Dash:         input.Dash,
-	}, p.physicsCfg)
-
-	// Apply gravity once per frame (before substep loop)
-	ecs.ApplyPlayerGravity(p.world, p.physicsCfg)
-	ecs.ApplyEnemyGravity(p.world, p.stage, p.physicsCfg.Gravity, p.physicsCfg.MaxFallSpeed)
-	ecs.ApplyProjectileGravity(p.world)
-	ecs.ApplyGoldGravity(p.world)
-
-	// Substep loop: movement and collision per substep
-	// subSteps=10 is normal speed, subSteps=1 is 10x slow motion
-	for i := 0; i < subSteps; i++ {
-		ecs.UpdatePlayerPhysics(p.world, p.stage, p.physicsCfg)
-		ecs.UpdateEnemyAI(p.world, p.stage, p.arrowCfg, p.physicsCfg)
-		ecs.UpdateProjectiles(p.world, p.stage)
-		ecs.UpdateGoldPhysics(p.world, p.stage)
-	}
-
-	// Collect gold
-	ecs.CollectGold(p.world)
-
-	// Update damage
-	knockbackForce := ecs.ToIUPerSubstep(p.config.Physics.Combat.Knockback.Force)
-	knockbackUp := ecs.ToIUPerSubstep(p.config.Physics.Combat.Knockback.UpForce)
-	iframeFrames := int(p.config.Physics.Combat.Iframes * 60)
-	result := ecs.UpdateDamage(p.world, knockbackForce, knockbackUp, iframeFrames)
-
-	// Handle damage feedback
-	if result.HitstopFrames > 0 {
-		p.hitstopFrames = result.HitstopFrames
-	}
-	if result.ScreenShake > 0 {
-		p.screenShakeX = result.ScreenShake
-		p.screenShakeY = result.ScreenShake
-	}
-
-	// Resolve enemy collisions
-	ecs.ResolveEnemyCollisions(p.world)
-
-	// Check spike damage
-	p.checkSpikeDamage()
-
-	// Decay screen shake
-	p.screenShakeX *= p.shakeDecay
-	p.screenShakeY *= p.shakeDecay
-
-	// Spawn enemies periodically (max 10 active enemies)
-	p.spawnTimer++
-	if p.spawnTimer >= 30 {
-		p.spawnTimer = 0
-		if p.world.CountEnemies() < 10 {
-			p.spawnEnemyOnRight()
-		}
-	}
+	// The arrow select wheel, dash hits and the game speed setting slow the
+	// simulation
+	p.applyGameSpeed(input.GameSpeedPct)
+	subSteps := p.world.TimeScale.Tick()
+
+	// Timers, input, gravity, substep movement, damage and spawning,
+	// followed by custom systems
+	p.frameInput = input
+	p.physicsCfg.InputGraceFrames = input.GraceFrames
+	p.world.RunFrame(subSteps)
+	p.recordInputTimeline(input)
+	p.recordRewind()
 
 	// Check game over
 	health := p.world.Health[p.world.PlayerID]
 	if health.Current <= 0 {
 		p.state = state.StateGameOver
+		p.world.Events.Emit(ecs.Event{Type: ecs.EventPlayerDied})
 		// Auto-save recording on game over
 		if p.recorder != nil {
 			p.saveRecording()
 		}
+		return false
 	}
+	return true
 }
 
 type inputState struct {
@@ -443,26 +1014,53 @@ type inputState struct {
 	JumpPressed           bool
 	JumpReleased          bool
 	Dash                  bool
+	Interact              bool
+	Attack                bool // mouse click
+	Block                 bool // held
+	Summon                bool // summon key pressed
 	MouseX, MouseY        int
+	GraceFrames           int // lag compensation of the jump windows (see lagGraceFrames)
+	GameSpeedPct          int // game speed setting (see applyGameSpeed)
+	AimAssist             bool // aim assist setting (see updateAim)
 }
 
 func (p *Playing) getInput() inputState {
-	mx, my := ebiten.CursorPosition()
+	mx, my := p.viewport.ToLogical(ebiten.CursorPosition())
 	return inputState{
-		Left:         ebiten.IsKeyPressed(ebiten.KeyA),
-		Right:        ebiten.IsKeyPressed(ebiten.KeyD),
-		Up:           ebiten.IsKeyPressed(ebiten.KeyW),
-		Down:         ebiten.IsKeyPressed(ebiten.KeyS),
-		JumpPressed:  inpututil.IsKeyJustPressed(ebiten.KeyW),
-		JumpReleased: inpututil.IsKeyJustReleased(ebiten.KeyW),
-		Dash:         inpututil.IsKeyJustPressed(ebiten.KeySpace),
+		Left:         ebiten.IsKeyPressed(p.keys.Left),
+		Right:        ebiten.IsKeyPressed(p.keys.Right),
+		Up:           ebiten.IsKeyPressed(p.keys.Up),
+		Down:         ebiten.IsKeyPressed(p.keys.Down),
+		JumpPressed:  inpututil.IsKeyJustPressed(p.keys.Jump),
+		JumpReleased: inpututil.IsKeyJustReleased(p.keys.Jump),
+		Dash:         inpututil.IsKeyJustPressed(p.keys.Dash),
+		Interact:     inpututil.IsKeyJustPressed(p.keys.Interact),
+		Attack:       inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+		Block:        ebiten.IsKeyPressed(p.keys.Block),
+		Summon:       inpututil.IsKeyJustPressed(p.keys.Summon),
 		MouseX:       mx,
 		MouseY:       my,
 	}
 }
 
-func (p *Playing) spawnPlayerArrow(x, y, targetX, targetY int, playerVX, playerVY int) {
-	arrowCfg := p.config.Entities.Projectiles["playerArrow"]
+// playerArrowOrigin returns where the player's arrows spawn (pixels) and the
+// player velocity (IU/substep) they inherit
+func (p *Playing) playerArrowOrigin() (x, y, playerVX, playerVY int) {
+	pos := p.world.Position[p.world.PlayerID]
+	vel := p.world.Velocity[p.world.PlayerID]
+	mov := p.world.Movement[p.world.PlayerID]
+
+	// Player velocity is already in IU/substep
+	playerVY = vel.Y
+	if mov.OnGround {
+		playerVY = 0
+	}
+	return pos.PixelX() + 8, pos.PixelY() + 10, vel.X, playerVY
+}
+
+// playerArrowLaunch returns the velocity (IU/substep) and ECS config of an
+// arrow fired from (x, y) toward (targetX, targetY)
+func (p *Playing) playerArrowLaunch(arrowCfg config.ProjectileConfig, x, y, targetX, targetY, playerVX, playerVY int) (vx, vy int, cfg ecs.ProjectileConfig) {
 	velocityInfluence := p.config.Physics.Projectile.VelocityInfluence
 
 	// Calculate direction (use float for normalization, convert to int at end)
@@ -474,7 +1072,7 @@ func (p *Playing) spawnPlayerArrow(x, y, targetX, targetY int, playerVX, playerV
 	}
 
 	// Convert speed to IU/substep
-	speedIU := ecs.ToIUPerSubstep(arrowCfg.Physics.Speed)
+	speedIU := fixedpoint.ToIUPerSubstep(arrowCfg.Physics.Speed)
 
 	// Calculate velocity components
 	vxf := (dx / dist) * float64(speedIU)
@@ -484,29 +1082,81 @@ func (p *Playing) spawnPlayerArrow(x, y, targetX, targetY int, playerVX, playerV
 	vxf += float64(playerVX) * velocityInfluence
 	vyf += float64(playerVY) * velocityInfluence
 
-	// Convert to int
-	vx := int(vxf)
-	vy := int(vyf)
-
-	cfg := ecs.ProjectileConfig{
-		GravityAccel:  ecs.ToIUAccelPerFrame(arrowCfg.Physics.GravityAccel),
-		MaxFallSpeed:  ecs.ToIUPerSubstep(arrowCfg.Physics.MaxFallSpeed),
+	cfg = ecs.ProjectileConfig{
+		GravityAccel:  fixedpoint.ToIUAccelPerFrame(arrowCfg.Physics.GravityAccel),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(arrowCfg.Physics.MaxFallSpeed),
 		MaxRange:      int(arrowCfg.Physics.MaxRange),
 		Damage:        arrowCfg.Damage,
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 2,
 		HitboxWidth:   12,
 		HitboxHeight:  4,
-		StuckDuration: 300, // 5 seconds
+		StuckDuration: fixedpoint.SecondsToFrames(arrowStuckSeconds),
+		Homing:        buildHoming(arrowCfg.Physics.Homing),
+		Intercepts:    arrowCfg.Physics.Intercepts,
+		Hitscan:       arrowCfg.Physics.Hitscan,
+		Piercing:      arrowCfg.Physics.Piercing,
+		BouncePercent: fixedpoint.ToPct(arrowCfg.Physics.Bounce),
+		FuseFrames:    fixedpoint.SecondsToFrames(arrowCfg.Physics.Fuse),
+		Explosion:     p.bombExplosion(arrowCfg),
+		Boomerang:     arrowCfg.Physics.Boomerang,
+	}
+	return int(vxf), int(vyf), cfg
+}
+
+func (p *Playing) spawnPlayerArrow(x, y, targetX, targetY int, playerVX, playerVY int) {
+	// Charged arrows need energy
+	currentArrow := p.world.PlayerData[p.world.PlayerID].CurrentArrow
+	if !ecs.SpendEnergy(p.world, p.world.Energy.ShotCost[currentArrow]) {
+		return
+	}
+
+	arrowCfg, usesAmmo := p.playerArrowProjectile()
+	if usesAmmo {
+		playerData := p.world.PlayerData[p.world.PlayerID]
+		playerData.Ammo--
+		p.world.PlayerData[p.world.PlayerID] = playerData
 	}
 
-	p.world.CreateProjectile(x, y, vx, vy, cfg, true)
+	vx, vy, cfg := p.playerArrowLaunch(arrowCfg, x, y, targetX, targetY, playerVX, playerVY)
+	cfg.Arrow = currentArrow
+	if cfg.Homing.Enabled() {
+		cfg.Arrow = ecs.ArrowPurple
+	}
+	if cfg.Hitscan {
+		ecs.FireBeam(p.world, p.stage, p.world.PlayerID, x, y, targetX-x, targetY-y, cfg, ecs.FactionPlayer)
+		p.world.Events.Emit(ecs.Event{Type: ecs.EventArrowFired})
+		return
+	}
+	cfg.StickInEnemies = p.config.Physics.Projectile.StickInEnemies
+	cfg.Bleed = p.arrowBleed(currentArrow)
+	cfg.Ammo = usesAmmo
+	id := p.world.CreateProjectile(x, y, vx, vy, cfg, ecs.FactionPlayer)
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventArrowFired, Entity: id})
+}
+
+// arrowBleed returns the damage over time an arrow type deals while stuck
+// in an enemy (none when it isn't configured)
+func (p *Playing) arrowBleed(t ecs.ArrowType) ecs.Bleed {
+	for name, b := range p.config.Physics.Projectile.Bleed {
+		if at, ok := arrowTypes[name]; !ok || at != t || b.Damage <= 0 {
+			continue
+		}
+		tick := max(1, fixedpoint.SecondsToFrames(b.Interval))
+		return ecs.Bleed{
+			Damage:     b.Damage,
+			TickFrames: tick,
+			Ticks:      fixedpoint.SecondsToFrames(b.Duration) / tick,
+		}
+	}
+	return ecs.Bleed{}
 }
 
 func (p *Playing) getCameraOffset() (int, int) {
-	pos := p.world.Position[p.world.PlayerID]
-	camX := pos.PixelX() - p.screenW/2 + 8
-	camY := pos.PixelY() - p.screenH/2 + 12
+	focusX, focusY := p.cameraFocus()
+	focusY += int(p.peek.offset)
+	camX := focusX - p.screenW/2
+	camY := focusY - p.screenH/2
 	if camX < 0 {
 		camX = 0
 	}
@@ -526,61 +1176,32 @@ func (p *Playing) getCameraOffset() (int, int) {
 
 // saveRecording saves the current recording to file
 func (p *Playing) saveRecording() {
-	if p.recorder == nil {
+	// A bot's runs stay in memory for its violation reports
+	if p.recorder == nil || p.bot != nil {
+		return
+	}
+	// Without a replay directory a daily run is only exported on request
+	if p.inDaily() && p.recordFilename == "" && p.replayDir == "" {
 		return
 	}
 
+	p.recorder.SetMetadata(p.lastInitials, p.stats.Gold, p.versionHash)
+	p.recordRunSettings()
 	filename := p.recordFilename
 	if filename == "" {
-		filename = GenerateFilename()
+		filename = p.runReplayFile()
 	}
 
 	if err := p.recorder.Save(filename); err != nil {
-		log.Printf("Failed to save recording: %v", err)
+		logging.Replay.Errorf("Failed to save recording: %v", err)
 	} else {
-		log.Printf("Recording saved: %s (%d frames)", filename, p.recorder.FrameCount())
-	}
-}
-
-func (p *Playing) checkSpikeDamage() {
-	playerID := p.world.PlayerID
-	playerData := p.world.PlayerData[playerID]
-	dash := p.world.Dash[playerID]
-
-	if playerData.IsInvincible(dash.Active) {
-		return
-	}
-
-	pos := p.world.Position[playerID]
-	hitbox := p.world.HitboxTrapezoid[playerID]
-	facing := p.world.Facing[playerID]
-
-	fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, 16)
-
-	for py := fy; py < fy+fh; py++ {
-		for px := fx; px < fx+fw; px++ {
-			tile := p.stage.GetTileAtPixel(px, py)
-			if tile.Type == entity.TileSpike {
-				health := p.world.Health[playerID]
-				health.Current -= tile.Damage
-				p.world.Health[playerID] = health
-
-				playerData.IframeTimer = int(p.config.Physics.Combat.Iframes * 60)
-				p.world.PlayerData[playerID] = playerData
-
-				vel := p.world.Velocity[playerID]
-				vel.Y = -150 * ecs.PositionScale
-				p.world.Velocity[playerID] = vel
-
-				p.screenShakeX = p.config.Physics.Feedback.ScreenShake.Intensity
-				p.screenShakeY = p.config.Physics.Feedback.ScreenShake.Intensity
-				return
-			}
-		}
+		logging.Replay.Infof("Recording saved: %s (%d frames)", filename, p.recorder.FrameCount())
 	}
 }
 
-func (p *Playing) spawnEnemyOnRight() {
+// spawnEnemyOnRight spawns a berserker at the right edge of the stage (0 =
+// no room found)
+func (p *Playing) spawnEnemyOnRight() ecs.EntityID {
 	spawnX := (p.stage.Width - 3) * p.tileSize
 
 	maxAttempts := 20
@@ -597,80 +1218,128 @@ func (p *Playing) spawnEnemyOnRight() {
 				}
 			}
 			if hasGround {
-				p.spawnEnemy(spawnX, spawnY, "berserker", false)
+				id := p.spawnEnemy(config.EnemySpawnConfig{Type: "berserker", X: spawnX, Y: spawnY, Modifier: p.rollElite()})
+				if id != 0 {
+					ecs.SpawnIn(p.world, id)
+				}
 				p.nextEnemyID++
-				return
+				return id
 			}
 		}
 	}
+	return 0
 }
 
 func (p *Playing) restart() {
-	// Reset RNG with new seed
-	p.seed = time.Now().UnixNano()
-	p.rng = rand.New(rand.NewSource(p.seed))
+	p.endReplaySettings()
+	if p.inDaily() {
+		p.startTowerRun(p.tower.seed)
+		return
+	}
+	if p.tower != nil {
+		p.startTowerRun(time.Now().UnixNano())
+		return
+	}
+	p.restartWithSeed(time.Now().UnixNano())
+}
+
+// restartWithSeed starts the stage over with the RNG seeded with seed (a
+// replay's seed plays back the same run)
+func (p *Playing) restartWithSeed(seed int64) {
+	p.seed = seed
+	p.replayer = nil
 
-	// Create new world
+	// Create new world and respawn crumbled tiles
 	p.world = ecs.NewWorld()
+	p.stage.RestoreFallen()
+	if p.rewind != nil {
+		p.rewind.Clear()
+	}
+	p.setMusic("")
+	p.banner = bossBanner{}
+	p.victory = 0
+	p.world.Feedback = buildFeedback(p.config)
+	p.world.Block = buildBlockConfig(p.config, p.character)
+	p.world.DashAttack = buildDashAttackConfig(p.config)
+	p.world.CC = buildCCConfig(p.config)
+	p.world.Magnet = buildMagnetConfig(p.config)
+	p.world.Stomp = buildStompConfig(p.config)
+	p.world.Contact = buildContactConfig(p.config)
+	p.world.Factions = buildFactionRelations(p.config)
+	p.world.Collision = buildCollisionMask(p.config)
+	p.world.Energy = buildEnergyConfig(p.config)
+	p.world.Aggro = buildAggroConfig(p.config)
+	p.world.Summon = buildSummonConfig(p.config)
+	p.world.Weather = buildWeather(p.stageCfg.Weather)
+	p.world.TimeScale = buildTimeScale(p.config)
+	// Reset RNG with the new seed
+	p.rng = p.world.SeedRand(p.seed)
+	p.applyFeedbackSettings()
+	p.registerSystems()
 
 	// Create player
-	playerCfg := p.config.Entities.Player
-	hitbox := ecs.HitboxTrapezoid{
-		Head: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Head.OffsetX,
-			OffsetY: playerCfg.Hitbox.Head.OffsetY,
-			Width:   playerCfg.Hitbox.Head.Width,
-			Height:  playerCfg.Hitbox.Head.Height,
-		},
-		Body: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Body.OffsetX,
-			OffsetY: playerCfg.Hitbox.Body.OffsetY,
-			Width:   playerCfg.Hitbox.Body.Width,
-			Height:  playerCfg.Hitbox.Body.Height,
-		},
-		Feet: ecs.Hitbox{
-			OffsetX: playerCfg.Hitbox.Feet.OffsetX,
-			OffsetY: playerCfg.Hitbox.Feet.OffsetY,
-			Width:   playerCfg.Hitbox.Feet.Width,
-			Height:  playerCfg.Hitbox.Feet.Height,
-		},
-	}
-	p.world.CreatePlayer(p.stage.SpawnX, p.stage.SpawnY, hitbox, playerCfg.Stats.MaxHealth)
+	p.world.CreatePlayer(p.stage.SpawnX, p.stage.SpawnY, buildPlayerProfile(p.character))
+	p.physicsCfg = buildPhysicsConfig(p.config, p.character)
 
 	p.state = state.StatePlaying
+	p.dialogue.Stop()
+	p.initials = nil
+	p.boardRank = -1
+	p.startStage()
 
 	// Reset UI
-	p.arrowSelectUI = entity.NewArrowSelectUIWithConfig(entity.ArrowSelectConfig{
-		Radius:      p.config.Physics.ArrowSelect.Radius,
-		MinDistance: p.config.Physics.ArrowSelect.MinDistance,
-		MaxFrame:    p.config.Physics.ArrowSelect.MaxFrame,
-	})
+	p.resetArrowSelect()
+	p.peek = cameraPeek{}
+	p.pendingPresses = pendingPresses{}
+	p.assist.running = false
 
 	// Respawn enemies
-	for _, spawn := range p.stageCfg.Enemies {
-		p.spawnEnemy(spawn.X, spawn.Y, spawn.Type, spawn.FacingRight)
-	}
+	p.spawnStageEnemies()
 
 	// Reset spawner
 	p.spawnTimer = 0
 	p.nextEnemyID = ecs.EntityID(len(p.stageCfg.Enemies) + 2)
-
-	// Reset recorder if recording
-	if p.recordFilename != "" {
-		p.recorder = NewRecorder(p.seed, p.stageCfg.Name)
-		log.Printf("Recording restarted (seed: %d)", p.seed)
+	p.wave = 0
+	p.spawnStagePickups()
+	p.spawnNPCs()
+
+	// Reset recorder if recording (a new run is a new replay file)
+	if (p.recordFilename != "" || p.replayDir != "") && p.tower == nil {
+		p.recorder = NewRecorder(p.seed, p.stageCfg.ID)
+		p.replayFile = ""
+		logging.Replay.Infof("Recording restarted (seed: %d)", p.seed)
 	}
 }
 
 // Draw renders the game screen
 func (p *Playing) Draw(screen *ebiten.Image) {
+	// A frame's sample holds the updates since the last draw and the draw
+	// that shows them, so render spikes land on the frame they happened
+	t := p.metrics.Start()
+	defer func() {
+		p.metrics.Stop(metrics.Render, t)
+		p.metrics.EndFrame()
+	}()
+
 	screen.Fill(colorBG)
 
+	// Photo mode shows only the world, through its free camera
+	if p.state == state.StatePhoto {
+		p.drawPhoto(screen)
+		return
+	}
+
 	camX, camY := p.getCameraOffset()
 
+	// Follow the player between simulation frames
+	followX, followY := p.drawOffset(p.world.PlayerID)
+	camX += followX
+	camY += followY
+
 	// Apply screen shake
-	camX += int(p.screenShakeX * (2*randFloat() - 1))
-	camY += int(p.screenShakeY * (2*randFloat() - 1))
+	shakeX, shakeY := p.world.Feedback.ShakeOffset(2*randFloat()-1, 2*randFloat()-1)
+	camX += int(shakeX)
+	camY += int(shakeY)
 
 	// Clamp camera
 	maxCamX := p.stage.Width*p.tileSize - p.screenW
@@ -688,13 +1357,9 @@ func (p *Playing) Draw(screen *ebiten.Image) {
 		camY = maxCamY
 	}
 
-	// Draw world
-	p.drawTiles(screen, camX, camY)
-	p.drawGolds(screen, camX, camY)
-	p.drawEnemies(screen, camX, camY)
-	p.drawProjectiles(screen, camX, camY)
-	p.drawPlayer(screen, camX, camY)
+	p.drawWorld(screen, camX, camY)
 	p.drawTrajectory(screen, camX, camY)
+	p.drawCrosshair(screen)
 
 	// Draw dark overlay when arrow selection UI is active
 	if p.arrowSelectUI.IsActive() {
@@ -706,105 +1371,142 @@ func (p *Playing) Draw(screen *ebiten.Image) {
 		p.drawArrowSelectUI(screen)
 	}
 
-	// Draw UI (HP bar, current arrow, etc.) - always on top
+	// Draw UI (HP bar, current arrow, etc.) - always on top; a demo shows
+	// only its banner
+	if p.attractBack != nil {
+		p.drawAttractOverlay(screen)
+		return
+	}
 	p.drawUI(screen)
 
+	if p.debugVisible {
+		p.drawDebugOverlay(screen, camX, camY)
+	}
+	if p.metrics != nil {
+		p.drawMetricsGraph(screen)
+	}
+	if p.tuningVisible {
+		p.drawTuningPanel(screen)
+	}
+
+	p.drawBossBanner(screen)
+	p.drawToasts(screen)
+
 	// Draw state overlays
 	switch p.state {
 	case state.StatePaused:
 		p.drawPauseOverlay(screen)
 	case state.StateGameOver:
 		p.drawGameOverOverlay(screen)
+	case state.StateDialogue:
+		p.drawDialogueBox(screen)
+	case state.StateShop:
+		p.drawShop(screen)
+	case state.StateRewind:
+		p.drawRewindOverlay(screen)
+	case state.StateTAS:
+		p.drawTASOverlay(screen)
+	case state.StateStageClear:
+		p.drawStageClearOverlay(screen)
 	}
-}
-
-func (p *Playing) drawTiles(screen *ebiten.Image, camX, camY int) {
-	startTileX := camX / p.tileSize
-	startTileY := camY / p.tileSize
-	endTileX := (camX + p.screenW) / p.tileSize + 1
-	endTileY := (camY + p.screenH) / p.tileSize + 1
-
-	for ty := startTileY; ty <= endTileY && ty < p.stage.Height; ty++ {
-		for tx := startTileX; tx <= endTileX && tx < p.stage.Width; tx++ {
-			if tx < 0 || ty < 0 {
-				continue
-			}
-			tile := p.stage.GetTile(tx, ty)
-			if tile.Type == entity.TileEmpty {
-				continue
-			}
 
-			x := float64(tx*p.tileSize - camX)
-			y := float64(ty*p.tileSize - camY)
-
-			var c color.Color
-			switch tile.Type {
-			case entity.TileWall:
-				c = colorWall
-			case entity.TileSpike:
-				c = colorSpike
-			}
+	p.captureClip(screen)
+}
 
-			ebitenutil.DrawRect(screen, x, y, float64(p.tileSize), float64(p.tileSize), c)
-		}
-	}
+// drawWorld draws the stage and its entities seen from camera (camX, camY)
+func (p *Playing) drawWorld(screen *ebiten.Image, camX, camY int) {
+	p.drawTiles(screen, camX, camY)
+	p.drawGoals(screen, camX, camY)
+	p.drawTorches(screen, camX, camY)
+	p.drawNPCs(screen, camX, camY)
+	p.drawPickups(screen, camX, camY)
+	p.drawEnemies(screen, camX, camY)
+	p.drawAllies(screen, camX, camY)
+	p.drawProjectiles(screen, camX, camY)
+	p.drawBeams(screen, camX, camY)
+	p.drawParticles(screen, camX, camY)
+	p.drawPlayer(screen, camX, camY)
+	p.drawForeground(screen, camX, camY)
+	p.drawFog(screen)
+	p.drawLighting(screen, camX, camY)
 }
 
 func (p *Playing) drawPlayer(screen *ebiten.Image, camX, camY int) {
-	pos := p.world.Position[p.world.PlayerID]
-	playerData := p.world.PlayerData[p.world.PlayerID]
+	pos := p.drawPosition(p.world.PlayerID)
 	facing := p.world.Facing[p.world.PlayerID]
-	dash := p.world.Dash[p.world.PlayerID]
+	cc := p.world.CrowdControl[p.world.PlayerID]
 
 	playerScreenX := float64(pos.PixelX() - camX)
 	playerScreenY := float64(pos.PixelY() - camY)
 
-	playerW := float64(p.config.Entities.Player.Sprite.FrameWidth)
-	playerH := float64(p.config.Entities.Player.Sprite.FrameHeight)
+	playerW := float64(p.character.Sprite.FrameWidth)
+	playerH := float64(p.character.Sprite.FrameHeight)
+
+	// Crouching lowers the top of the player to the crouched head
+	if crouch := p.world.Crouch[p.world.PlayerID]; crouch.Crouching {
+		top := float64(crouch.Low.Head.OffsetY)
+		playerScreenY += top
+		playerH -= top
+	}
 
 	// Flash when invincible
 	playerColor := colorPlayer
-	if playerData.IsInvincible(dash.Active) && playerData.IframeTimer%6 < 3 {
+	if p.world.IsInvincible(p.world.PlayerID) && cc.IframeTimer%6 < 3 {
 		playerColor = color.RGBA{255, 255, 255, 200}
 	}
 
-	ebitenutil.DrawRect(screen, playerScreenX, playerScreenY, playerW, playerH, playerColor)
+	ui.FillRect(screen, playerScreenX, playerScreenY, playerW, playerH, playerColor)
+	p.drawOutline(screen, playerScreenX, playerScreenY, playerW, playerH)
+	p.drawShield(screen, playerScreenX, playerScreenY, playerW, playerH)
 
 	// Draw hitbox debug
 	if ebiten.IsKeyPressed(ebiten.KeyTab) {
 		hitbox := p.world.HitboxTrapezoid[p.world.PlayerID]
-		hx, hy, hw, hh := hitbox.Head.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, 16)
-		ebitenutil.DrawRect(screen, float64(hx-camX), float64(hy-camY), float64(hw), float64(hh), colorHead)
+		hx, hy, hw, hh := hitbox.Head.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+		ui.FillRect(screen, float64(hx-camX), float64(hy-camY), float64(hw), float64(hh), colorHead)
 
-		fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, 16)
-		ebitenutil.DrawRect(screen, float64(fx-camX), float64(fy-camY), float64(fw), float64(fh), colorFeet)
+		fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+		ui.FillRect(screen, float64(fx-camX), float64(fy-camY), float64(fw), float64(fh), colorFeet)
 	}
 }
 
 func (p *Playing) drawEnemies(screen *ebiten.Image, camX, camY int) {
 	for id := range p.world.IsEnemy {
-		pos := p.world.Position[id]
+		pos := p.drawPosition(id)
+		cc := p.world.CrowdControl[id]
 		ai := p.world.AI[id]
 		hitbox := p.world.Hitbox[id]
 
+		// Blink while spawning in
+		if ai.SpawnTimer > 0 && ai.SpawnTimer%8 < 4 {
+			continue
+		}
+
 		x := float64(pos.PixelX() - camX)
 		y := float64(pos.PixelY() - camY)
 
-		// Flash on hit
+		// Elite tint, flash on hit or wind-up
 		c := colorEnemy
-		if ai.HitTimer > 0 {
+		if elite, ok := p.world.Elite[id]; ok {
+			if tint, ok := p.eliteTints[elite.Modifier]; ok {
+				c = tint
+			}
+		}
+		c = telegraphColor(ai, c, p.palette().telegraph)
+		if cc.IsStunned() {
 			c = color.RGBA{255, 255, 255, 255}
 		}
 
-		ebitenutil.DrawRect(screen, x, y, float64(hitbox.Width+4), float64(hitbox.Height+4), c)
+		ui.FillRect(screen, x, y, float64(hitbox.Width+4), float64(hitbox.Height+4), c)
+		p.drawOutline(screen, x, y, float64(hitbox.Width+4), float64(hitbox.Height+4))
+		drawSuperArmor(screen, ai, x, y, float64(hitbox.Width+4), float64(hitbox.Height+4))
+		p.drawTelegraphLine(screen, id, x+float64(hitbox.Width+4)/2, y+float64(hitbox.Height+4)/2, camX, camY)
 	}
 }
 
 func (p *Playing) drawProjectiles(screen *ebiten.Image, camX, camY int) {
-	playerData := p.world.PlayerData[p.world.PlayerID]
-
 	for id := range p.world.IsProjectile {
-		pos := p.world.Position[id]
+		pos := p.drawPosition(id)
 		vel := p.world.Velocity[id]
 		proj := p.world.ProjectileData[id]
 
@@ -813,8 +1515,10 @@ func (p *Playing) drawProjectiles(screen *ebiten.Image, camX, camY int) {
 
 		// Determine color
 		var c color.RGBA
-		if proj.IsPlayerOwned {
-			c = ecs.ArrowColors[playerData.CurrentArrow]
+		arrowType := proj.Arrow
+		playerOwned := p.world.Faction[id] == ecs.FactionPlayer
+		if playerOwned {
+			c = p.arrowColor(arrowType)
 		} else {
 			c = colorEnemyArrow
 		}
@@ -828,108 +1532,192 @@ func (p *Playing) drawProjectiles(screen *ebiten.Image, camX, camY int) {
 			uint8(float64(c.A) * alpha),
 		}
 
+		if proj.IsBomb() {
+			drawBomb(screen, proj, x, y, c)
+			continue
+		}
+
 		// Draw rotated arrow
 		rot := proj.Rotation(vel.X, vel.Y)
 		length := 12.0
 		prevX := x - math.Cos(rot)*length
 		prevY := y - math.Sin(rot)*length
 
-		ebitenutil.DrawRect(screen, x-2, y-2, 4, 4, c)
+		ui.FillRect(screen, x-2, y-2, 4, 4, c)
 		ebitenutil.DrawLine(screen, x, y, prevX, prevY, c)
+		if playerOwned && p.arrowMarkersOn() {
+			drawArrowMarker(screen, x, y-6, arrowType)
+		} else if !playerOwned {
+			p.drawOutline(screen, x-3, y-3, 6, 6)
+		}
 	}
 }
 
-func (p *Playing) drawGolds(screen *ebiten.Image, camX, camY int) {
-	for id := range p.world.IsGold {
-		pos := p.world.Position[id]
+func (p *Playing) drawParticles(screen *ebiten.Image, camX, camY int) {
+	for id, particle := range p.world.Particle {
+		pos := p.drawPosition(id)
 
 		x := float64(pos.PixelX() - camX)
 		y := float64(pos.PixelY() - camY)
 
-		ebitenutil.DrawRect(screen, x, y, 8, 8, colorGold)
+		alpha := particle.Alpha()
+		c := color.RGBA{
+			uint8(float64(particle.Color.R) * alpha),
+			uint8(float64(particle.Color.G) * alpha),
+			uint8(float64(particle.Color.B) * alpha),
+			uint8(float64(particle.Color.A) * alpha),
+		}
+		if particle.Streak {
+			ebitenutil.DrawLine(screen, x, y, x-float64(particle.VX)/ecs.PositionScale, y-float64(particle.VY)/ecs.PositionScale, c)
+			continue
+		}
+		ui.FillRect(screen, x-1, y-1, 2, 2, c)
 	}
 }
 
-func (p *Playing) drawUI(screen *ebiten.Image) {
-	health := p.world.Health[p.world.PlayerID]
-	playerData := p.world.PlayerData[p.world.PlayerID]
+func (p *Playing) drawPickups(screen *ebiten.Image, camX, camY int) {
+	for id := range p.world.IsPickup {
+		pos := p.drawPosition(id)
 
-	// Health bar
-	barX := 10.0
-	barY := float64(p.screenH - 20)
-	barW := 100.0
-	barH := 10.0
+		x := float64(pos.PixelX() - camX)
+		y := float64(pos.PixelY() - camY)
+
+		drawPickup(screen, x, y, p.world.PickupData[id])
+	}
+}
 
-	ebitenutil.DrawRect(screen, barX, barY, barW, barH, colorHealthBG)
+// drawUI draws the HUD, scaled up from an offscreen canvas when the UI
+// config sets a scale
+func (p *Playing) drawUI(screen *ebiten.Image) {
+	scale := p.hud.scale
+	if scale == 1 {
+		p.drawHUD(screen)
+		return
+	}
+	w, h := int(float64(p.screenW)/scale), int(float64(p.screenH)/scale)
+	if p.hudCanvas == nil || p.hudCanvas.Bounds().Dx() != w || p.hudCanvas.Bounds().Dy() != h {
+		if p.hudCanvas != nil {
+			p.hudCanvas.Deallocate()
+		}
+		p.hudCanvas = ebiten.NewImage(w, h)
+	}
+	p.hudCanvas.Clear()
+	p.drawHUD(p.hudCanvas)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	screen.DrawImage(p.hudCanvas, op)
+}
 
-	healthRatio := float64(health.Current) / float64(health.Max)
-	if healthRatio < 0 {
-		healthRatio = 0
+// drawHUD draws the HUD elements where the layout places them on dst
+func (p *Playing) drawHUD(dst *ebiten.Image) {
+	health := p.world.Health[p.world.PlayerID]
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	canvas := dst.Bounds()
+	l := &p.hud
+
+	// Health bar, with the stamina and energy bars under it
+	if !l.health.Hidden {
+		barX, barY := place(l.health, 0, 0, canvas)
+		barW, barH := float64(l.health.Width), float64(l.health.Height)
+		ui.FillRect(dst, barX, barY, barW, barH, l.theme.barBG)
+		healthRatio := max(float64(health.Current)/float64(health.Max), 0)
+		ui.FillRect(dst, barX, barY, barW*healthRatio, barH, l.theme.healthFG)
+		p.drawStaminaBar(dst, barX, barY+barH+2, barW)
+		p.drawEnergyBar(dst, barX, barY+barH+2, barW)
 	}
-	ebitenutil.DrawRect(screen, barX, barY, barW*healthRatio, barH, colorHealthFG)
 
-	// Current arrow indicator
-	p.drawArrowIcon(screen, barX+barW+10, barY+barH/2, playerData.CurrentArrow, 1.0, true)
+	// Equipped arrows and ability cooldowns
+	if !l.arrows.Hidden {
+		widgets := p.abilityWidgets()
+		x, y := place(l.arrows, p.abilityHUDWidth(widgets), abilitySize, canvas)
+		p.drawAbilityHUD(dst, x, y, widgets)
+	}
 
 	// Gold
-	goldText := fmt.Sprintf("Gold: %d", playerData.Gold)
-	ebitenutil.DebugPrintAt(screen, goldText, 10, p.screenH-35)
+	if !l.gold.Hidden {
+		style := textStyle(l.theme.gold)
+		goldText := i18n.Tf("hud.gold", playerData.Gold)
+		w, h := ui.Measure(goldText, style)
+		x, y := place(l.gold, w, h, canvas)
+		ui.Draw(dst, goldText, x, y, style)
+	}
+
+	if !l.minimap.Hidden {
+		p.drawMinimap(dst, canvas)
+	}
+	p.drawCombatLog(dst)
 
 	// Controls
-	debugText := "A/D: Move | W: Jump | Space: Dash | LClick: Attack | RClick: Arrow Select | ESC: Pause"
-	ebitenutil.DebugPrint(screen, debugText)
+	if !l.controls.Hidden {
+		style := textStyle(l.theme.text)
+		controlsText := i18n.Tf("hud.controls", p.keys.Left, p.keys.Right, p.keys.Jump, p.keys.Dash)
+		w, h := ui.Measure(controlsText, style)
+		x, y := place(l.controls, w, h, canvas)
+		ui.Draw(dst, controlsText, x, y, style)
+	}
 }
 
 func (p *Playing) drawPauseOverlay(screen *ebiten.Image) {
 	overlay := color.RGBA{0, 0, 0, 128}
-	ebitenutil.DrawRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
+	ui.FillRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
 
-	text := "PAUSED\n\nPress ESC to resume"
-	ebitenutil.DebugPrintAt(screen, text, p.screenW/2-50, p.screenH/2-20)
+	cx, cy := float64(p.screenW/2), float64(p.screenH/2)
+	ui.Draw(screen, i18n.T("pause.title"), cx, cy-40, ui.StyleTitle)
+
+	text := i18n.T("pause.resume")
+	if p.settings != nil {
+		text += "\n" + i18n.T("pause.options")
+	}
+	if p.menu != nil {
+		text += "\n" + i18n.T("pause.menu")
+	}
+	ui.Draw(screen, text, cx, cy, ui.StyleCenter)
 }
 
 func (p *Playing) drawGameOverOverlay(screen *ebiten.Image) {
 	playerData := p.world.PlayerData[p.world.PlayerID]
 
 	overlay := color.RGBA{100, 0, 0, 180}
-	ebitenutil.DrawRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
-
-	text := fmt.Sprintf("GAME OVER\n\nGold collected: %d\n\nPress Z to restart", playerData.Gold)
-	ebitenutil.DebugPrintAt(screen, text, p.screenW/2-60, p.screenH/2-30)
-}
+	ui.FillRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
 
-func (p *Playing) drawArrowSelectOverlay(screen *ebiten.Image) {
-	progress := p.arrowSelectUI.GetProgress()
-	easedProgress := math.Sin(progress * math.Pi / 2)
-
-	alpha := uint8(128 * easedProgress)
-	overlay := color.RGBA{0, 0, 0, alpha}
-	ebitenutil.DrawRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), overlay)
-}
-
-func (p *Playing) drawArrowSelectUI(screen *ebiten.Image) {
-	progress := p.arrowSelectUI.GetProgress()
-	easedProgress := math.Sin(progress * math.Pi / 2)
-	playerData := p.world.PlayerData[p.world.PlayerID]
+	cx, cy := float64(p.screenW/2), float64(p.screenH/2)
+	result := i18n.Tf("gameover.gold", playerData.Gold)
+	if p.tower != nil {
+		result = p.towerSummary()
+	}
+	restart := i18n.T("gameover.restart")
+	if p.inDaily() && p.recorder != nil {
+		restart = i18n.T("daily.export") + "\n" + restart
+	}
+	if p.leaderboard == nil {
+		ui.Draw(screen, i18n.T("gameover.title"), cx, cy-48, ui.StyleTitle)
 
-	for dir := entity.DirRight; dir <= entity.DirDown; dir++ {
-		arrowType := playerData.EquippedArrows[int(dir)]
-		x, y := p.arrowSelectUI.GetIconPosition(dir, easedProgress)
+		text := result + "\n\n" + restart
+		ui.Draw(screen, text, cx, cy-8, ui.StyleCenter)
+		return
+	}
 
-		brightness := 0.7
-		if arrowType == playerData.CurrentArrow {
-			brightness = 1.0
-		}
-		if dir == p.arrowSelectUI.Highlighted {
-			brightness = 1.0
-		}
+	// With a leaderboard: title, run score, then initials entry or the board
+	ui.Draw(screen, i18n.T("gameover.title"), cx, 12, ui.StyleTitle)
+	text := result + "\n" + i18n.Tf("gameover.score", p.runPoints)
+	ui.Draw(screen, text, cx, 40, ui.StyleCenter)
 
-		p.drawArrowIcon(screen, x, y, arrowType, brightness*easedProgress, dir == p.arrowSelectUI.Highlighted)
+	if p.initials != nil {
+		p.drawInitialsEntry(screen, 84)
+		return
+	}
+	if top := p.leaderboard.Top(p.boardID()); len(top) > 0 {
+		ui.DrawScores(screen, top, cx, 72, p.boardRank)
+	} else {
+		ui.Draw(screen, i18n.T("leaderboard.empty"), cx, 72, ui.StyleCenter)
 	}
+	// The prompt ends at the bottom of the screen
+	_, h := ui.Measure(restart, ui.StyleCenter)
+	ui.Draw(screen, restart, cx, float64(p.screenH-18)-h+ui.LineHeight(ui.StyleCenter), ui.StyleCenter)
 }
 
 func (p *Playing) drawArrowIcon(screen *ebiten.Image, x, y float64, arrowType ecs.ArrowType, brightness float64, large bool) {
-	baseColor := ecs.ArrowColors[arrowType]
+	baseColor := p.arrowColor(arrowType)
 
 	c := color.RGBA{
 		uint8(float64(baseColor.R) * brightness),
@@ -957,43 +1745,29 @@ func (p *Playing) drawArrowIcon(screen *ebiten.Image, x, y float64, arrowType ec
 	ebitenutil.DrawLine(screen, tipX, tipY, tipX-tipSize, tipY-tipSize/2, c)
 	ebitenutil.DrawLine(screen, tipX, tipY, tipX-tipSize, tipY+tipSize/2, c)
 
-	ebitenutil.DrawRect(screen, tipX-1, tipY-1, 2, 2, c)
-}
-
-func (p *Playing) drawTrajectory(screen *ebiten.Image, camX, camY int) {
-	arrowCfg := p.config.Entities.Projectiles["playerArrow"]
-	speed := arrowCfg.Physics.Speed
-	gravity := arrowCfg.Physics.GravityAccel
-	maxFall := arrowCfg.Physics.MaxFallSpeed
-	maxRange := arrowCfg.Physics.MaxRange
-	velocityInfluence := p.config.Physics.Projectile.VelocityInfluence
-
-	pos := p.world.Position[p.world.PlayerID]
-	vel := p.world.Velocity[p.world.PlayerID]
-	mov := p.world.Movement[p.world.PlayerID]
-	playerData := p.world.PlayerData[p.world.PlayerID]
+	ui.FillRect(screen, tipX-1, tipY-1, 2, 2, c)
 
-	startX := float64(pos.PixelX() + 8)
-	startY := float64(pos.PixelY() + 10)
-
-	dx := p.mouseWorldX - startX
-	dy := p.mouseWorldY - startY
-	dist := math.Sqrt(dx*dx + dy*dy)
-	if dist < 1 {
-		dist = 1
+	if p.arrowMarkersOn() {
+		drawArrowMarker(screen, x, y-length/2-2, arrowType)
 	}
-	vx := (dx / dist) * speed
-	vy := (dy / dist) * speed
+}
 
-	playerVX := float64(vel.X) / float64(ecs.PositionScale)
-	playerVY := float64(vel.Y) / float64(ecs.PositionScale)
-	if mov.OnGround {
-		playerVY = 0
+// predictTrajectory returns the exact path (pixels) of the equipped arrow if
+// fired now toward (targetX, targetY), using the ECS integer simulation
+func (p *Playing) predictTrajectory(targetX, targetY int) []ecs.PathPoint {
+	x, y, playerVX, playerVY := p.playerArrowOrigin()
+	arrowCfg, _ := p.playerArrowProjectile()
+	vx, vy, cfg := p.playerArrowLaunch(arrowCfg, x, y, targetX, targetY, playerVX, playerVY)
+	if cfg.Hitscan {
+		return ecs.PredictBeamPath(p.world, p.stage, x, y, targetX-x, targetY-y, cfg)
 	}
-	vx += playerVX * velocityInfluence
-	vy += playerVY * velocityInfluence
+	return ecs.PredictProjectilePath(p.world, p.stage, x, y, vx, vy, cfg, trajectoryMaxFrames*ecs.SubstepsPerFrame)
+}
 
-	arrowColor := ecs.ArrowColors[playerData.CurrentArrow]
+func (p *Playing) drawTrajectory(screen *ebiten.Image, camX, camY int) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+
+	arrowColor := p.arrowColor(playerData.CurrentArrow)
 	trajectoryColor := color.RGBA{
 		uint8((int(arrowColor.R) + 255) / 2),
 		uint8((int(arrowColor.G) + 255) / 2),
@@ -1001,50 +1775,43 @@ func (p *Playing) drawTrajectory(screen *ebiten.Image, camX, camY int) {
 		200,
 	}
 
-	x, y := startX, startY
-	dt := 1.0 / 60.0
 	dotSpacing := 8.0
 	accumulated := 0.0
 	dotSize := 3.0
 
-	for traveled := 0.0; traveled < maxRange; {
-		vy += gravity * dt
-		if vy > maxFall {
-			vy = maxFall
-		}
-
-		prevX, prevY := x, y
-
-		x += vx * dt
-		y += vy * dt
-
-		stepDx := x - prevX
-		stepDy := y - prevY
-		stepDist := math.Sqrt(stepDx*stepDx + stepDy*stepDy)
-		traveled += stepDist
-		accumulated += stepDist
-
-		if p.stage.IsSolidAt(int(x), int(y)) {
-			break
-		}
+	path := p.predictTrajectory(int(p.aimX), int(p.aimY))
+	for i := 1; i < len(path); i++ {
+		stepDx := float64(path[i].X - path[i-1].X)
+		stepDy := float64(path[i].Y - path[i-1].Y)
+		accumulated += math.Sqrt(stepDx*stepDx + stepDy*stepDy)
 
 		if accumulated >= dotSpacing {
 			accumulated -= dotSpacing
-			screenX := x - float64(camX) - dotSize/2
-			screenY := y - float64(camY) - dotSize/2
-			ebitenutil.DrawRect(screen, screenX, screenY, dotSize, dotSize, trajectoryColor)
+			screenX := float64(path[i].X-camX) - dotSize/2
+			screenY := float64(path[i].Y-camY) - dotSize/2
+			ui.FillRect(screen, screenX, screenY, dotSize, dotSize, trajectoryColor)
 		}
 	}
 }
 
 // OnEnter is called when entering this scene
 func (p *Playing) OnEnter() {
-	// Scene is already initialized in New
+	// Scene is already initialized in New; re-apply settings changed in the options menu
+	p.applySettings()
+	// The crosshair replaces the system cursor while playing
+	ebiten.SetCursorMode(ebiten.CursorModeHidden)
 }
 
 // OnExit is called when leaving this scene
 func (p *Playing) OnExit() {
+	ebiten.SetCursorMode(ebiten.CursorModeVisible)
 	p.saveRecording()
+	p.saveProfile()
+}
+
+// SetViewport sets the window → logical mapping used for mouse aiming
+func (p *Playing) SetViewport(v *viewport.Viewport) {
+	p.viewport = v
 }
 
 // Layout returns the game's screen dimensions
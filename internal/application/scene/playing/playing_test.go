@@ -1,14 +1,29 @@
 package playing
 
 import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/replay"
 	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/tower"
+	"github.com/younwookim/mg/internal/application/ui"
 	"github.com/younwookim/mg/internal/domain/entity"
 	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
 )
 
 // createTestConfig creates a minimal config for testing
@@ -65,9 +80,12 @@ func createTestConfig() *config.GameConfig {
 			Combat: config.CombatConfig{
 				Iframes: 1.0,
 				Knockback: config.KnockbackConfig{
-					Force:        100,
-					UpForce:      50,
-					StunDuration: 0.2,
+					Force:   100,
+					UpForce: 50,
+				},
+				CrowdControl: config.CrowdControlConfig{
+					Player: config.CCProfileConfig{KnockbackCurve: "linear", KnockbackDuration: 0.2, StunDuration: 0.2},
+					Enemy:  config.CCProfileConfig{KnockbackCurve: "quadratic", KnockbackDuration: 0.25, StunDuration: 0.2, DecayVertical: true},
 				},
 			},
 			ArrowSelect: config.ArrowSelectConfig{
@@ -80,7 +98,8 @@ func createTestConfig() *config.GameConfig {
 			},
 		},
 		Entities: &config.EntitiesConfig{
-			Player: config.PlayerConfig{
+			Characters: []config.PlayerConfig{{
+				ID: "player",
 				Stats: config.PlayerStats{
 					MaxHealth: 100,
 				},
@@ -93,7 +112,8 @@ func createTestConfig() *config.GameConfig {
 					FrameWidth:  16,
 					FrameHeight: 24,
 				},
-			},
+				Abilities: config.AbilitiesConfig{Block: true},
+			}},
 			Projectiles: map[string]config.ProjectileConfig{
 				"playerArrow": {
 					Physics: config.ProjectilePhysicsConfig{
@@ -106,6 +126,7 @@ func createTestConfig() *config.GameConfig {
 					Damage: 25,
 				},
 				"enemyArrow": {
+					Hitbox: config.Rect{OffsetX: 2, OffsetY: 2, Width: 12, Height: 4},
 					Physics: config.ProjectilePhysicsConfig{
 						Speed:          220,
 						LaunchAngleDeg: 0,
@@ -318,3 +339,1421 @@ func TestPlaying_OnExitWithRecorder(t *testing.T) {
 		p.OnExit()
 	})
 }
+
+func TestPlaying_DialogueTrigger(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Dialogues = &config.DialoguesConfig{
+		Dialogues: map[string]config.DialogueConfig{
+			"hello": {Pages: []config.DialoguePageConfig{{Speaker: "Guide", Text: "Hi"}}},
+		},
+	}
+	stageCfg := createTestStageConfig()
+	stageCfg.Triggers = []config.TriggerConfig{
+		{Type: "dialogue", Rect: config.RectConfig{X: 0, Y: 0, W: 160, H: 80}, Target: "hello", Once: true},
+	}
+	stage := createTestStage()
+
+	p := New(cfg, stageCfg, stage, "")
+
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+	assert.Equal(t, state.StateDialogue, p.state, "player spawned inside the trigger")
+	assert.Equal(t, "Guide", p.dialogue.Page().Speaker)
+
+	p.dialogue.Stop()
+	p.state = state.StatePlaying
+	_, err = p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+	assert.Equal(t, state.StatePlaying, p.state, "once trigger does not fire again")
+}
+
+func TestPlaying_ShopkeeperOpensShop(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.NPCs = []config.NPCSpawnConfig{
+		{Name: "Merchant", X: 84, Y: 48, Role: "shopkeeper", Shop: []config.ShopItemConfig{
+			{Item: "ammo", Price: 20, Amount: 5},
+			{Item: "lottery", Price: 1},
+		}},
+		{Name: "Guide", X: 10, Y: 48, Role: "questGiver", Behavior: "patrol", PatrolDistance: 16},
+	}
+
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+
+	require.Len(t, p.world.IsNPC, 2)
+	merchant := ecs.NearbyNPC(p.world)
+	require.NotZero(t, merchant, "player spawned next to the merchant")
+	assert.Equal(t, npcShopkeeper, p.npcs[merchant].role)
+	assert.Equal(t, []shopItem{{kind: shopAmmo, price: 20, amount: 5}}, p.npcs[merchant].shop, "unknown items are skipped")
+
+	assert.True(t, p.checkDialogueTriggers(inputState{Interact: true}))
+	assert.Equal(t, state.StateShop, p.state)
+	assert.Equal(t, merchant, p.shopNPC)
+}
+
+func TestPlaying_BuyShopItem(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.world.DashAttack.Damage = []int{10, 20}
+	playerID := p.world.PlayerID
+	playerData := p.world.PlayerData[playerID]
+	playerData.Gold = 50
+	playerData.Ammo = 0
+	p.world.PlayerData[playerID] = playerData
+
+	assert.Equal(t, "shop.soldOut", p.buyShopItem(shopItem{kind: shopHealth, price: 10, amount: 20}), "already at full health")
+	assert.Equal(t, "shop.noGold", p.buyShopItem(shopItem{kind: shopAmmo, price: 60, amount: 5}))
+	assert.Equal(t, "shop.bought", p.buyShopItem(shopItem{kind: shopAmmo, price: 20, amount: 5}))
+	assert.Equal(t, "shop.bought", p.buyShopItem(shopItem{kind: shopDash, price: 20}))
+	assert.Equal(t, "shop.soldOut", p.buyShopItem(shopItem{kind: shopDash, price: 5}), "dash at its last tier")
+
+	assert.Equal(t, 10, p.world.PlayerData[playerID].Gold)
+	assert.Equal(t, 5, p.world.PlayerData[playerID].Ammo)
+	assert.Equal(t, 1, p.world.Dash[playerID].Level)
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, "hello\nworld", wrapText("hello world", 8))
+	assert.Equal(t, "a b c", wrapText("a b c", 8))
+	assert.Equal(t, "abcd\nefgh", wrapText("abcdefgh", 4), "long words are split")
+	assert.Equal(t, "안녕\n하세", wrapText("안녕하세", 4), "fullwidth runes take two cells")
+}
+
+func TestParseHexColor(t *testing.T) {
+	assert.Equal(t, color.RGBA{0x6a, 0x8c, 0xaf, 255}, parseHexColor("#6a8caf"))
+	assert.Equal(t, color.RGBA{128, 128, 128, 255}, parseHexColor("nope"))
+	assert.Equal(t, color.RGBA{0x66, 0, 0, 0x66}, parseHexColor("#ff000066"), "alpha is premultiplied")
+}
+
+func TestPlaying_AchievementUnlockShowsToast(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Achievements = &config.AchievementsConfig{Achievements: []config.AchievementConfig{
+		{ID: "first_blood", Name: "First Blood", Kind: "kills", Target: 1},
+	}}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	profile := save.New()
+	p.SetProfile(profile, "")
+
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventEnemyKilled})
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+
+	require.Len(t, p.toasts.Items(), 1)
+	assert.Equal(t, ui.IconAchievement, p.toasts.Items()[0].Icon)
+	assert.Contains(t, p.toasts.Items()[0].Text, "First Blood")
+	assert.Contains(t, profile.Achievements.Unlocked, "first_blood")
+	assert.Equal(t, 1, profile.Achievements.Kills)
+}
+
+func TestPlaying_EventToasts(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventHealthCollected, Amount: 20})
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventWaveStarted, Amount: 2})
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventGoldCollected, Amount: 5})
+	p.processEvents()
+
+	items := p.toasts.Items()
+	require.Len(t, items, 2, "gold is too frequent for a toast")
+	assert.Equal(t, ui.Toast{Icon: ui.IconPickup, Text: i18n.Tf("toast.health", 20), Duration: ui.DefaultToastFrames}, items[0])
+	assert.Equal(t, ui.IconWave, items[1].Icon)
+}
+
+func TestPlaying_LowHealthWarnsOnce(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.toasts.Clear()
+	health := p.world.Health[p.world.PlayerID]
+	hit := func(damage int) {
+		health.Current -= damage
+		p.world.Health[p.world.PlayerID] = health
+		p.world.Events.Emit(ecs.Event{Type: ecs.EventPlayerDamaged, Amount: damage})
+		p.processEvents()
+	}
+
+	hit(health.Max / 2)
+	assert.Empty(t, p.toasts.Items())
+	hit(health.Max/2 - 1)
+	require.Len(t, p.toasts.Items(), 1)
+	assert.Equal(t, ui.IconWarning, p.toasts.Items()[0].Icon)
+	hit(0)
+	assert.Len(t, p.toasts.Items(), 1, "only when crossing the threshold")
+}
+
+func TestPlaying_CombatLog(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.ID = "test"
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+	var file bytes.Buffer
+	p.SetCombatLog(&file)
+
+	// The queued stage start of New comes first
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventEnemyHit, Entity: 5, Amount: 12})
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventArrowFired})
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventEnemyKilled, Entity: 5})
+	p.processEvents()
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	require.Len(t, lines, 3, "arrows fired aren't logged")
+	assert.Contains(t, lines[0], "test")
+	assert.Equal(t, "0:00.00 "+i18n.Tf("log.hit", ecs.EntityID(5), 12), lines[1])
+	require.Len(t, p.combatLog.lines, 2)
+	assert.Equal(t, colorLogKill, p.combatLog.lines[1].color)
+
+	// Only the newest lines stay on screen, and only for a while
+	for range combatLogLines {
+		p.world.Events.Emit(ecs.Event{Type: ecs.EventGoldCollected, Amount: 1})
+	}
+	p.processEvents()
+	assert.Len(t, p.combatLog.lines, combatLogLines)
+	for range combatLogFrames {
+		p.updateCombatLog()
+	}
+	assert.Empty(t, p.combatLog.lines)
+}
+
+func TestFormatLogTime(t *testing.T) {
+	assert.Equal(t, "0:00.00", formatLogTime(0))
+	assert.Equal(t, "0:01.50", formatLogTime(90))
+	assert.Equal(t, "2:05.00", formatLogTime(125*60))
+	t.Cleanup(func() { fixedpoint.Sim = fixedpoint.FramesPerSecond })
+	fixedpoint.SetSimulationRate(30)
+	assert.Equal(t, "0:01.50", formatLogTime(45), "at the simulation rate")
+}
+
+func TestPlaying_ExitTriggerClearsStage(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.ParTime = 60
+	stageCfg.Triggers = []config.TriggerConfig{
+		{Type: "exit", Rect: config.RectConfig{X: 0, Y: 0, W: 160, H: 80}},
+	}
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+	savePath := filepath.Join(t.TempDir(), "save.json")
+	p.SetProfile(save.New(), savePath)
+
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventArrowFired})
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+
+	assert.Equal(t, state.StateStageClear, p.state, "player spawned inside the exit")
+	assert.Equal(t, 1, p.stats.ArrowsFired)
+
+	history, err := save.LoadHistory(save.HistoryPath(savePath))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 1, history[0].ArrowsFired)
+}
+
+func TestPlaying_StageClearUnlocksNextStage(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.ID = "first"
+	stageCfg.Next = "second"
+	stageCfg.Triggers = []config.TriggerConfig{
+		{Type: "exit", Rect: config.RectConfig{X: 0, Y: 0, W: 160, H: 80}},
+	}
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+	profile := save.New()
+	p.SetProfile(profile, "")
+	var tracks []string
+	p.SetOnMusic(func(track string) { tracks = append(tracks, track) })
+
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+
+	assert.True(t, profile.StageCleared("first"))
+	require.Len(t, p.toasts.Items(), 1)
+	assert.Contains(t, p.toasts.Items()[0].Text, i18n.T("toast.stageUnlocked"))
+	assert.Equal(t, []string{victoryTrack}, tracks)
+	assert.Equal(t, victoryFrames, p.victory, "celebration before the results")
+
+	for range victoryFrames {
+		p.Update(1.0 / 60.0)
+	}
+	assert.Zero(t, p.victory)
+}
+
+func TestPlaying_GameOverLeaderboardEntry(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	board := save.NewLeaderboard()
+	p.SetLeaderboard(board, "")
+
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventEnemyKilled})
+	health := p.world.Health[p.world.PlayerID]
+	health.Current = 0
+	p.world.Health[p.world.PlayerID] = health
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+
+	assert.Equal(t, state.StateGameOver, p.state)
+	require.NotNil(t, p.initials, "an empty board always qualifies")
+	assert.Equal(t, 100, p.runPoints)
+
+	p.submitScore("ABC")
+	assert.Nil(t, p.initials)
+	assert.Equal(t, 0, p.boardRank)
+	require.Len(t, board.Top(p.stageCfg.ID), 1)
+	assert.Equal(t, "ABC", board.Top(p.stageCfg.ID)[0].Initials)
+
+	p.restart()
+	assert.Equal(t, -1, p.boardRank)
+}
+
+// createTestTowerConfig creates a tower without enemies or modifiers
+func createTestTowerConfig() *config.TowerConfig {
+	return &config.TowerConfig{
+		Size:        config.StageSizeConfig{Width: 640, Height: 240, TileSize: 16},
+		Platforms:   4,
+		TileMapping: map[string]config.TileMappingConfig{"#": {Type: "wall", Solid: true}},
+	}
+}
+
+func TestPlaying_TowerClimbsFloors(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	board := save.NewLeaderboard()
+	p.SetLeaderboard(board, "")
+	p.StartTower(createTestTowerConfig(), p.config)
+	require.True(t, p.InTower())
+	assert.Equal(t, tower.StageID, p.stageCfg.ID)
+	assert.Equal(t, 1, p.tower.floor.Number)
+	assert.Nil(t, p.recorder, "tower runs aren't recorded")
+
+	// Reach the exit with some gold and damage taken
+	id := p.world.PlayerID
+	data := p.world.PlayerData[id]
+	data.Gold = 7
+	p.world.PlayerData[id] = data
+	health := p.world.Health[id]
+	health.Current -= 10
+	p.world.Health[id] = health
+	exit := p.stageCfg.Triggers[0].Rect
+	p.world.Position[id] = ecs.Position{X: exit.X * ecs.PositionScale, Y: exit.Y * ecs.PositionScale}
+	p.world.Events.Emit(ecs.Event{Type: ecs.EventEnemyKilled})
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+	require.Equal(t, state.StateStageClear, p.state)
+	assert.Equal(t, 1, p.tower.total.Kills)
+	assert.Nil(t, p.initials, "a cleared floor doesn't end the run")
+
+	for range victoryFrames + 1 {
+		p.Update(1.0 / 60.0)
+	}
+	assert.Equal(t, state.StatePlaying, p.state)
+	assert.Equal(t, 2, p.tower.floor.Number)
+	id = p.world.PlayerID
+	assert.Equal(t, 7, p.world.PlayerData[id].Gold, "gold carried up")
+	assert.Equal(t, health.Current, p.world.Health[id].Current, "health carried up")
+
+	// Dying ends the run, scored on the floors cleared
+	health = p.world.Health[id]
+	health.Current = 0
+	p.world.Health[id] = health
+	_, err = p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+	assert.Equal(t, state.StateGameOver, p.state)
+	assert.Equal(t, 1, p.stats.Kills, "stats of the whole run")
+	assert.Equal(t, tower.Points(1, p.stats), p.runPoints)
+	require.NotNil(t, p.initials)
+	p.submitScore("TWR")
+	require.Len(t, board.Top(tower.StageID), 1)
+
+	p.restart()
+	assert.Equal(t, 1, p.tower.floor.Number, "a new run starts at the bottom")
+
+	p.SetStage(createTestConfig(), createTestStageConfig(), createTestStage())
+	assert.False(t, p.InTower())
+}
+
+func TestPlaying_DailyRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+	day := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	board := save.NewLeaderboard()
+	p.SetLeaderboard(board, "")
+	p.StartDaily(createTestTowerConfig(), p.config, day)
+
+	assert.Equal(t, tower.DailySeed(day), p.tower.seed)
+	assert.Equal(t, "daily-2026-10-15", p.boardID())
+	require.NotNil(t, p.recorder, "daily runs are always recorded")
+	assert.Equal(t, tower.StageID, p.recorder.GetData().Stage)
+	layout := p.stageCfg.Layers.Collision
+
+	// Dying scores the run on the day's board; without a replay directory
+	// the replay is only written when exported
+	health := p.world.Health[p.world.PlayerID]
+	health.Current = 0
+	p.world.Health[p.world.PlayerID] = health
+	_, err := p.Update(1.0 / 60.0)
+	require.NoError(t, err)
+	require.Equal(t, state.StateGameOver, p.state)
+	require.NotNil(t, p.initials)
+	p.submitScore("DAY")
+	require.Len(t, board.Top("daily-2026-10-15"), 1)
+	assert.Empty(t, board.Top(tower.StageID))
+	files, err := filepath.Glob("*.json")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	// The day's run is played over on the same floors
+	p.restart()
+	assert.Equal(t, tower.DailySeed(day), p.tower.seed)
+	assert.Equal(t, layout, p.stageCfg.Layers.Collision)
+	assert.Zero(t, p.recorder.FrameCount(), "a new recording")
+}
+
+func TestDailyReplayFile(t *testing.T) {
+	assert.Equal(t, "daily-2026-10-15.json", dailyReplayFile("daily-2026-10-15", ""))
+	assert.Equal(t, "daily-2026-10-15_ABC.json", dailyReplayFile("daily-2026-10-15", "ABC"))
+}
+
+func TestPlaying_TowerReplayGoesOnToNextFloor(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.PlayTowerReplay(createTestTowerConfig(), p.config, replay.ReplayData{Seed: 5, Stage: tower.StageID})
+	require.NotNil(t, p.replayer)
+	assert.Nil(t, p.recorder)
+
+	p.enterFloor(2)
+	assert.NotNil(t, p.replayer, "the replay drives the next floor too")
+	p.restart()
+	assert.Nil(t, p.replayer)
+}
+
+func TestPlaying_TowerNoDash(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	assert.True(t, p.towerInput(inputState{Dash: true}).Dash)
+
+	p.tower = &towerRun{floor: tower.Floor{Modifiers: []tower.Modifier{tower.NoDash}}}
+	assert.False(t, p.towerInput(inputState{Dash: true}).Dash)
+}
+
+func TestPlaying_DifficultyScalesTuning(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Difficulty = &config.DifficultyConfig{
+		Default: "normal",
+		Profiles: map[string]config.DifficultyProfile{
+			"normal": {},
+			"hard":   {EnemyHealth: 1.5, ContactDamage: 2, AttackCooldown: 0.5, SpawnRate: 2, PlayerIframes: 0.5},
+		},
+	}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	enemy := config.EnemyConfig{
+		Stats: config.EnemyStats{MaxHealth: 30, ContactDamage: 10},
+		AI:    config.AIConfig{AttackCooldown: 1.0},
+	}
+
+	health, damage, cooldown := p.scaleEnemyConfig(&enemy)
+	assert.Equal(t, []int{30, 10, 60}, []int{health, damage, cooldown}, "default profile is neutral")
+	assert.Equal(t, spawnIntervalFrames, p.spawnInterval())
+	assert.Equal(t, 60, p.playerIframes())
+
+	s := settings.Default()
+	s.Difficulty = "hard"
+	p.SetSettings(s, "")
+
+	health, damage, cooldown = p.scaleEnemyConfig(&enemy)
+	assert.Equal(t, []int{45, 20, 30}, []int{health, damage, cooldown})
+	assert.Equal(t, spawnIntervalFrames/2, p.spawnInterval())
+	assert.Equal(t, 30, p.playerIframes())
+}
+
+func TestPlaying_DifficultyReloadsConfig(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	s := settings.Default()
+	p.SetSettings(s, "")
+
+	var loaded []string
+	p.SetConfigLoader(s.Difficulty, func(difficulty string) (*config.GameConfig, *config.StageConfig, error) {
+		loaded = append(loaded, difficulty)
+		cfg := createTestConfig()
+		cfg.Physics.Physics.Gravity = 1600
+		return cfg, createTestStageConfig(), nil
+	})
+	p.SetSettings(s, "")
+	assert.Empty(t, loaded, "same difficulty keeps the config")
+
+	s.Difficulty = "hard"
+	p.SetSettings(s, "")
+	assert.Equal(t, []string{"hard"}, loaded)
+	assert.Equal(t, 1600.0, p.config.Physics.Physics.Gravity)
+	assert.Equal(t, fixedpoint.ToIUAccelPerFrame(1600), p.physicsCfg.Gravity, "restarted with the new config")
+}
+
+func TestPlaying_Tuning(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	p.setTuning(0, 1234)
+	assert.Equal(t, 1230.0, p.config.Physics.Physics.Gravity, "snapped to the step")
+	assert.Equal(t, fixedpoint.ToIUAccelPerFrame(1230), p.physicsCfg.Gravity, "live physics rebuilt")
+
+	p.setTuning(1, 10000)
+	assert.Equal(t, 400.0, p.config.Physics.Movement.MaxSpeed, "clamped to the range")
+
+	p.setTuning(4, 0.1)
+	assert.Equal(t, 6, p.physicsCfg.CoyoteFrames)
+
+	// Dragging to the left end of a track sets the minimum
+	p.setTuningFromSlider(3, 0)
+	assert.Equal(t, 50.0, p.config.Physics.Dash.Speed)
+
+	assert.Equal(t, -1, tuningRowAt(tuningX+10, tuningY), "header")
+	assert.Equal(t, 0, tuningRowAt(tuningX+10, tuningY+14))
+	assert.Equal(t, 2, tuningRowAt(tuningX+10, tuningY+14+2*tuningRowH+5))
+	assert.Equal(t, -1, tuningRowAt(tuningX+tuningW+1, tuningY+14))
+
+	assert.False(t, p.tuningCapturesMouse(tuningX+10, tuningY+14), "panel hidden")
+	p.tuningVisible = true
+	assert.True(t, p.tuningCapturesMouse(tuningX+10, tuningY+14))
+	assert.False(t, p.tuningCapturesMouse(tuningX+tuningW+10, tuningY+14))
+
+	override := p.tuningOverride()
+	physics := override["physics"].(map[string]any)
+	assert.Equal(t, 1230.0, physics["physics"].(map[string]any)["gravity"])
+	assert.Equal(t, 50.0, physics["dash"].(map[string]any)["speed"])
+}
+
+func TestPlaying_Character(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Combat.Block.MaxStamina = 100
+	swordsman := cfg.Entities.Characters[0]
+	swordsman.ID = "swordsman"
+	swordsman.Stats = config.PlayerStats{MaxHealth: 140, MoveSpeed: 0.5, JumpForce: 2}
+	swordsman.Abilities = config.AbilitiesConfig{Arrows: []string{"gray", "red", "bogus"}, DashLevel: 1}
+	cfg.Entities.Characters = append(cfg.Entities.Characters, swordsman)
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	base := p.physicsCfg
+
+	assert.Equal(t, "player", p.character.ID, "first character is the default")
+	assert.Positive(t, p.world.Block.MaxStamina)
+
+	s := settings.Default()
+	s.Character = "swordsman"
+	p.SetSettings(s, "")
+
+	id := p.world.PlayerID
+	assert.Equal(t, "swordsman", p.character.ID)
+	assert.Equal(t, 140, p.world.Health[id].Max)
+	assert.Equal(t, 1, p.world.Dash[id].Level)
+	assert.Equal(t, []ecs.ArrowType{ecs.ArrowGray, ecs.ArrowRed}, p.world.PlayerData[id].EquippedArrows)
+	assert.Equal(t, ecs.BlockConfig{}, p.world.Block, "character without block cannot raise the shield")
+	assert.Equal(t, scaleInt(base.MaxSpeed, 0.5), p.physicsCfg.MaxSpeed)
+	assert.Equal(t, scaleInt(base.JumpForce, 2), p.physicsCfg.JumpForce)
+}
+
+func TestPlaying_AbilityWidgets(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Combat.Block.MaxStamina = 100
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	id := p.world.PlayerID
+
+	widgets := p.abilityWidgets()
+	require.Len(t, widgets, 2, "dash and shield")
+	assert.True(t, widgets[0].ready)
+	assert.Equal(t, 1.0, widgets[0].progress)
+
+	// Half-way through the dash cooldown
+	dash := p.world.Dash[id]
+	dash.Cooldown = p.physicsCfg.DashCooldownFrames / 2
+	p.world.Dash[id] = dash
+	widgets = p.abilityWidgets()
+	assert.False(t, widgets[0].ready)
+	assert.InDelta(t, 0.5, widgets[0].progress, 0.01)
+
+	// Air dash spent: recovered but not ready until landing
+	dash.Cooldown = 0
+	dash.CanDash = false
+	p.world.Dash[id] = dash
+	widgets = p.abilityWidgets()
+	assert.False(t, widgets[0].ready)
+	assert.Equal(t, 1.0, widgets[0].progress)
+
+	// Guard broken shield; magnet upgrade unlocks its widget
+	playerData := p.world.PlayerData[id]
+	playerData.GuardBroken = true
+	playerData.StaminaUsed = p.world.Block.MaxStamina
+	playerData.MagnetLevel = 2
+	p.world.PlayerData[id] = playerData
+	widgets = p.abilityWidgets()
+	require.Len(t, widgets, 3)
+	assert.False(t, widgets[1].ready)
+	assert.Equal(t, 0.0, widgets[1].progress)
+	assert.Equal(t, 2, widgets[2].level)
+}
+
+func TestPlaying_AimAssist(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.config.Physics.Projectile.AimAssist = 12
+	enemy := p.world.CreateEnemy(130, 40, ecs.EnemyConfig{MaxHealth: 10, HitboxWidth: 16, HitboxHeight: 16}, false)
+	ex, ey := ecs.HitboxCenter(p.world, enemy)
+
+	// Near miss without assist: the aim follows the mouse
+	p.mouseWorldX, p.mouseWorldY = 140, 42
+	p.updateAim(false)
+	assert.Equal(t, [2]float64{140, 42}, [2]float64{p.aimX, p.aimY})
+	assert.Zero(t, p.aimTarget)
+
+	s := settings.Default()
+	s.AimAssist = true
+	p.SetSettings(s, "")
+
+	p.updateAim(true)
+	assert.Equal(t, enemy, p.aimTarget)
+	_, miss := closestApproach(p.predictTrajectory(int(p.aimX), int(p.aimY)), float64(ex), float64(ey))
+	assert.LessOrEqual(t, miss, 2.0, "assisted arc passes through the enemy center")
+
+	// Aiming well away from the enemy is left alone
+	p.mouseWorldX, p.mouseWorldY = 100, 0
+	p.updateAim(true)
+	assert.Zero(t, p.aimTarget)
+	assert.Equal(t, [2]float64{100, 0}, [2]float64{p.aimX, p.aimY})
+}
+
+func TestPlaying_CameraFramesLargeEnemy(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	px, py := ecs.HitboxCenter(p.world, p.world.PlayerID)
+	fx, fy := p.cameraFocus()
+	assert.Equal(t, [2]int{px, py}, [2]int{fx, fy}, "small enemies don't move the camera")
+
+	boss := p.world.CreateEnemy(0, 0, ecs.EnemyConfig{MaxHealth: 100, HitboxWidth: 48, HitboxHeight: 48}, false)
+	p.world.Position[boss] = ecs.Position{X: (px + 60) * ecs.PositionScale, Y: (py - 24) * ecs.PositionScale}
+	bx, by := ecs.HitboxCenter(p.world, boss)
+	fx, fy = p.cameraFocus()
+	assert.Equal(t, [2]int{px + (bx-px)/2, py + (by-py)/2}, [2]int{fx, fy}, "camera centers between player and boss")
+
+	// Off screen: the player is followed as usual
+	p.world.Position[boss] = ecs.Position{X: (px + 2*p.screenW) * ecs.PositionScale, Y: py * ecs.PositionScale}
+	fx, fy = p.cameraFocus()
+	assert.Equal(t, [2]int{px, py}, [2]int{fx, fy})
+}
+
+func TestPlaying_CameraPeek(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Camera.Peek = config.CameraPeekConfig{Distance: 40, Delay: 0.05, Speed: 600}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	id := p.world.PlayerID
+	p.world.Movement[id] = ecs.Movement{OnGround: true}
+
+	for i := 0; i < 3; i++ {
+		p.updateCameraPeek(inputState{Down: true})
+	}
+	assert.Zero(t, p.peek.offset, "waits for the delay")
+
+	for i := 0; i < 10; i++ {
+		p.updateCameraPeek(inputState{Down: true})
+	}
+	assert.Equal(t, 40.0, p.peek.offset)
+
+	p.world.Velocity[id] = ecs.Velocity{X: 10}
+	p.updateCameraPeek(inputState{Down: true})
+	assert.Equal(t, 30.0, p.peek.offset, "moving returns the camera")
+	assert.Zero(t, p.peek.held)
+}
+
+func TestPlaying_LagGraceFrames(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Jump.LagCompensation = 0.1 // 6 frames
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	assert.Zero(t, p.lagGraceFrames(60))
+	assert.Zero(t, p.lagGraceFrames(0), "no measurement yet")
+	assert.Equal(t, 3, p.lagGraceFrames(45))
+	assert.Equal(t, 6, p.lagGraceFrames(20), "capped")
+}
+
+func TestPlaying_InputTimelineMarksJumps(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	p.recordInputTimeline(inputState{JumpPressed: true})
+	p.inputTimeline.handleEvent(ecs.Event{Type: ecs.EventJump, Amount: int(ecs.JumpCoyote)})
+	p.recordInputTimeline(inputState{})
+	p.inputTimeline.handleEvent(ecs.Event{Type: ecs.EventJumpMissed})
+
+	first := p.inputTimeline.samples[0]
+	assert.True(t, first.pressed && first.jumped)
+	assert.Equal(t, ecs.JumpCoyote, first.kind)
+	assert.True(t, p.inputTimeline.last().missed)
+}
+
+func TestPlaying_TrajectoryPreviewMatchesArrow(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	x, y, playerVX, playerVY := p.playerArrowOrigin()
+	predicted := p.predictTrajectory(x+60, y-20)
+
+	p.spawnPlayerArrow(x, y, x+60, y-20, playerVX, playerVY)
+	var arrow ecs.EntityID
+	for id := range p.world.IsProjectile {
+		arrow = id
+	}
+	actual := []ecs.PathPoint{{X: x, Y: y}}
+	for len(actual) < len(predicted) && p.world.Exists(arrow) && !p.world.ProjectileData[arrow].Stuck {
+		ecs.UpdateTimers(p.world)
+		ecs.ApplyProjectileGravity(p.world)
+		for i := 0; i < ecs.SubstepsPerFrame && p.world.Exists(arrow); i++ {
+			ecs.UpdateProjectiles(p.world, p.stage)
+			if p.world.Exists(arrow) {
+				pos := p.world.Position[arrow]
+				actual = append(actual, ecs.PathPoint{X: pos.PixelX(), Y: pos.PixelY()})
+			}
+		}
+	}
+	assert.Equal(t, predicted, actual[:min(len(actual), len(predicted))])
+}
+
+func TestPlaying_Hooks(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	var results []RunResult
+	p.SetOnRunEnd(func(r RunResult) { results = append(results, r) })
+	var order []int
+	p.AddSystem(func(w *ecs.World) { order = append(order, 1) })
+	p.AddSystem(func(w *ecs.World) {
+		assert.Same(t, p.world, w)
+		order = append(order, 2)
+	})
+
+	p.AddSystemAt(func(w *ecs.World) { order = append(order, 0) }, ecs.PhaseFrameEnd, -1)
+
+	p.world.Systems.Run(p.world, ecs.PhaseFrameEnd)
+	assert.Equal(t, []int{0, 1, 2}, order)
+
+	// Custom systems are registered again with the new world on restart
+	builtins := p.world.Systems.Len() - 3
+	assert.Positive(t, builtins)
+	p.restart()
+	assert.Equal(t, builtins+3, p.world.Systems.Len())
+	order = nil
+	p.world.Systems.Run(p.world, ecs.PhaseFrameEnd)
+	assert.Equal(t, []int{0, 1, 2}, order)
+
+	p.finishRun(true)
+	p.finishRun(false)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Cleared)
+	assert.False(t, results[1].Cleared)
+	assert.Equal(t, p.stageCfg.ID, results[0].StageID)
+}
+
+func TestPlaying_ApplyElite(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Entities.Elites = map[string]config.EliteConfig{
+		"armored":      {Tint: "#a0a0b8", Health: 2, ArmorPct: 50, GoldMultiplier: 2},
+		"regenerating": {RegenPerSecond: 4},
+	}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	loot := ecs.LootTable{Entries: []ecs.LootEntry{
+		{Kind: ecs.PickupGold, Weight: 1, Min: 3, Max: 5},
+		{Kind: ecs.PickupHealth, Weight: 1, Min: 10, Max: 10},
+	}}
+	enemy := ecs.EnemyConfig{MaxHealth: 20, MoveSpeed: 100, AttackCooldown: 90, Loot: loot}
+	p.applyElite(&enemy, "armored")
+	assert.Equal(t, 40, enemy.MaxHealth)
+	assert.Equal(t, 100, enemy.MoveSpeed, "missing multipliers leave stats unchanged")
+	assert.Equal(t, []ecs.LootEntry{
+		{Kind: ecs.PickupGold, Weight: 1, Min: 6, Max: 10},
+		{Kind: ecs.PickupHealth, Weight: 1, Min: 10, Max: 10},
+	}, enemy.Loot.Entries, "only gold is scaled")
+	assert.Equal(t, 3, loot.Entries[0].Min, "shared table is not modified")
+	assert.Equal(t, ecs.Elite{Modifier: ecs.EliteArmored, ArmorPct: 50}, enemy.Elite)
+	assert.Equal(t, color.RGBA{0xa0, 0xa0, 0xb8, 255}, p.eliteTints[ecs.EliteArmored])
+
+	regen := ecs.EnemyConfig{MaxHealth: 20}
+	p.applyElite(&regen, "regenerating")
+	assert.Equal(t, 15, regen.Elite.RegenFrames)
+
+	regular := ecs.EnemyConfig{MaxHealth: 20}
+	p.applyElite(&regular, "golden")
+	assert.Equal(t, ecs.EliteNone, regular.Elite.Modifier, "unknown modifiers are ignored")
+}
+
+func TestPlaying_RollElite(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+	assert.Empty(t, p.rollElite(), "no elite chance configured")
+
+	stageCfg.Spawner = config.SpawnerConfig{EliteChance: 1, EliteWeights: map[string]int{"fast": 1, "armored": 0}}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "fast", p.rollElite(), "zero weights are never picked")
+	}
+}
+
+func TestPlaying_PurpleArrowHomes(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Entities.Projectiles["homingArrow"] = config.ProjectileConfig{
+		Physics: config.ProjectilePhysicsConfig{
+			Speed:    260,
+			MaxRange: 400,
+			Homing:   &config.HomingConfig{TurnRate: 3000, AcquireRange: 140, Lifetime: 1.5},
+		},
+		Damage: 20,
+	}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	p.spawnPlayerArrow(50, 50, 100, 50, 0, 0)
+
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	playerData.CurrentArrow = ecs.ArrowPurple
+	playerData.Ammo = 1
+	p.world.PlayerData[p.world.PlayerID] = playerData
+	p.spawnPlayerArrow(50, 50, 100, 50, 0, 0)
+	p.spawnPlayerArrow(50, 50, 100, 50, 0, 0) // out of ammo: regular arrow
+	assert.Equal(t, 0, p.world.PlayerData[p.world.PlayerID].Ammo)
+
+	var homing []ecs.Homing
+	for id := range p.world.IsProjectile {
+		if h := p.world.ProjectileData[id].Homing; h.Enabled() {
+			homing = append(homing, h)
+		}
+	}
+	require.Len(t, homing, 1, "only the purple arrow homes")
+	assert.Equal(t, ecs.Homing{TurnRate: 2, Range: 140, Lifetime: 90}, homing[0])
+}
+
+func TestPlaying_BlockConfig(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Combat.Block = config.BlockConfig{
+		MaxStamina:      100,
+		DrainPerSecond:  25,
+		HitCost:         20,
+		RegenPerSecond:  40,
+		RegenDelay:      0.5,
+		DamageReduction: 1.0,
+		ParryWindow:     0.15,
+	}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, ecs.BlockConfig{
+		MaxStamina:   6000,
+		Drain:        25,
+		HitCost:      1200,
+		Regen:        40,
+		RegenDelay:   30,
+		ReductionPct: 100,
+		ParryFrames:  9,
+	}, p.world.Block)
+
+	p.restart()
+	assert.Equal(t, 6000, p.world.Block.MaxStamina, "restart keeps the shield tuning")
+}
+
+func TestPlaying_DashAttackConfig(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Dash.Attack = config.DashAttackConfig{
+		Damage:        []int{15, 25},
+		Knockback:     300,
+		Slowdown:      0.1,
+		SlowdownScale: 0.5,
+	}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, ecs.DashAttackConfig{
+		Damage:         []int{15, 25},
+		Knockback:      fixedpoint.ToIUPerSubstep(300),
+		SlowdownFrames: 6,
+		SlowdownPct:    50,
+	}, p.world.DashAttack)
+}
+
+func TestPlaying_CCConfig(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, ecs.CCProfile{Curve: ecs.CurveLinear, KnockbackFrames: 12, StunFrames: 12}, p.world.CC.Player)
+	assert.Equal(t, ecs.CCProfile{Curve: ecs.CurveQuadratic, KnockbackFrames: 15, StunFrames: 12, DecayVertical: true}, p.world.CC.Enemy)
+
+	cfg := createTestConfig()
+	cfg.Physics.Combat.CrowdControl = config.CrowdControlConfig{}
+	p = New(cfg, createTestStageConfig(), createTestStage(), "")
+	assert.Equal(t, ecs.DefaultCCConfig(), p.world.CC, "missing config keeps the defaults")
+}
+
+func TestPlaying_MagnetConfig(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Magnet = config.MagnetConfig{Radius: []float64{48, 72.5}, Acceleration: 1500}
+
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, ecs.MagnetConfig{Radius: []int{48, 72}, Accel: fixedpoint.ToIUAccelPerFrame(1500)}, p.world.Magnet)
+}
+
+func TestPlaying_BuildLootTable(t *testing.T) {
+	table := buildLootTable(config.LootTableConfig{
+		Rolls: 2,
+		Entries: []config.LootEntryConfig{
+			{Pickup: "gold", Weight: 70, Min: 5, Max: 10},
+			{Pickup: "treasure", Weight: 1, Min: 1, Max: 1},
+			{Pickup: "mystery", Weight: 5},
+		},
+	})
+
+	assert.Equal(t, ecs.LootTable{Rolls: 2, Entries: []ecs.LootEntry{
+		{Kind: ecs.PickupGold, Weight: 70, Min: 5, Max: 10},
+		{Kind: ecs.PickupTreasure, Weight: 1, Min: 1, Max: 1},
+	}}, table, "unknown pickups are skipped")
+}
+
+func TestPlaying_EnemyArrowFromConfig(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, fixedpoint.ToIUPerSubstep(220), p.arrowCfg.Speed)
+	assert.Equal(t, 300, p.arrowCfg.MaxRange)
+	assert.Equal(t, 10, p.arrowCfg.Damage)
+	assert.Equal(t, 12, p.arrowCfg.HitboxWidth)
+}
+
+func TestPlaying_BuildFlock(t *testing.T) {
+	assert.Equal(t, ecs.Flock{}, buildFlock(nil))
+	assert.Equal(t, ecs.Flock{Radius: 40, Separation: 150, Alignment: 50, Cohesion: 60, Seek: 100},
+		buildFlock(&config.FlockConfig{Radius: 40, Separation: 1.5, Alignment: 0.5, Cohesion: 0.6, Seek: 1}))
+}
+
+func TestPlaying_BuildPatrolRoute(t *testing.T) {
+	route := buildPatrolRoute(config.EnemySpawnConfig{
+		Type:       "slime",
+		Waypoints:  []config.PositionConfig{{X: 10, Y: 20}, {X: 50, Y: 20}},
+		PatrolMode: "pingPong",
+	})
+	assert.Equal(t, ecs.PatrolRoute{Points: []ecs.Waypoint{{X: 10, Y: 20}, {X: 50, Y: 20}}, Mode: ecs.PatrolPingPong}, route)
+
+	assert.Empty(t, buildPatrolRoute(config.EnemySpawnConfig{Type: "slime"}).Points)
+}
+
+func TestPlaying_SpawnStagePickups(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Entities.Pickups = map[string]config.PickupConfig{
+		"health": {
+			Hitbox:     config.Rect{Width: 12, Height: 12},
+			Physics:    config.PickupPhysicsConfig{CollectRadius: 16, MagnetRadius: 32, MagnetSpeed: 150},
+			HealAmount: 25,
+		},
+	}
+	stageCfg := createTestStageConfig()
+	stageCfg.Pickups = []config.PickupSpawnConfig{
+		{Type: "health", X: 40, Y: 40},
+		{Type: "ammo", X: 60, Y: 40}, // not configured
+	}
+
+	p := New(cfg, stageCfg, createTestStage(), "")
+
+	require.Len(t, p.world.IsPickup, 1)
+	for id := range p.world.IsPickup {
+		pickup := p.world.PickupData[id]
+		assert.Equal(t, ecs.PickupHealth, pickup.Kind)
+		assert.Equal(t, 25, pickup.Amount)
+		assert.Equal(t, 32, pickup.MagnetRadius)
+		assert.Equal(t, fixedpoint.ToIUPerSubstep(150), pickup.MagnetSpeed)
+		assert.Equal(t, ecs.Velocity{}, p.world.Velocity[id])
+	}
+
+	p.restart()
+	assert.Len(t, p.world.IsPickup, 1, "placed again on restart")
+}
+
+func TestPlaying_StagePickupRespawns(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Entities.Pickups = map[string]config.PickupConfig{
+		"health": {Hitbox: config.Rect{Width: 12, Height: 12}, HealAmount: 25},
+	}
+	stageCfg := createTestStageConfig()
+	stageCfg.Pickups = []config.PickupSpawnConfig{
+		{Type: "health", X: 40, Y: 40, Respawn: "timed", RespawnDelay: 0.05},
+		{Type: "health", X: 80, Y: 40},
+	}
+
+	p := New(cfg, stageCfg, createTestStage(), "")
+	require.Len(t, p.world.Respawns, 1, "pickups that never respawn aren't tracked")
+	p.world.DestroyEntity(p.world.Respawns[0].Entity)
+
+	for range 3 {
+		p.respawnStageEntities()
+	}
+	assert.Len(t, p.world.IsPickup, 2)
+	assert.True(t, p.world.Exists(p.world.Respawns[0].Entity))
+}
+
+func TestPlaying_BuildLighting(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.Lighting = config.LightingConfig{
+		Player: config.LightConfig{Radius: 96, Color: "#ff8000"},
+		Torch:  config.LightConfig{Radius: 80},
+	}
+	stageCfg := createTestStageConfig()
+	assert.Nil(t, buildLighting(cfg, stageCfg), "no lighting = fully lit")
+
+	stageCfg.Lighting = &config.StageLightingConfig{Ambient: 0.5, Color: "#8080ff", Torches: []config.PositionConfig{{X: 10, Y: 20}}}
+	l := buildLighting(cfg, stageCfg)
+	require.NotNil(t, l)
+	assert.Equal(t, color.RGBA{64, 64, 127, 255}, l.ambient)
+	assert.Equal(t, lightSource{radius: 96, color: color.RGBA{255, 128, 0, 255}}, l.player)
+	assert.Equal(t, lightSource{radius: 80, color: color.RGBA{255, 255, 255, 255}}, l.torch)
+	assert.Zero(t, l.projectile.radius)
+	assert.Len(t, l.torches, 1)
+}
+
+func TestPlaying_BuildWeather(t *testing.T) {
+	assert.Equal(t, ecs.Weather{}, buildWeather(nil))
+	assert.Equal(t, ecs.Weather{}, buildWeather(&config.WeatherConfig{Type: "hail"}))
+	assert.Equal(t, ecs.Weather{Kind: ecs.WeatherRain, Density: 4, FrictionPct: 50},
+		buildWeather(&config.WeatherConfig{Type: "rain", Density: 4, Friction: 0.5}))
+}
+
+func TestPlaying_FogShortensDetectRange(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.Weather = &config.WeatherConfig{Type: "fog", Visibility: 0.5}
+	p := New(createTestConfig(), stageCfg, createTestStage(), "")
+
+	assert.Equal(t, 60, p.weatherDetectRange(120))
+}
+
+func TestPlaying_PhotoModeCamera(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	camX, camY := p.getCameraOffset()
+
+	p.enterPhotoMode()
+
+	assert.Equal(t, state.StatePhoto, p.state)
+	assert.Equal(t, photoCamera{camX: camX, camY: camY, zoom: 1}, p.photo)
+	assert.Regexp(t, `^screenshot_\d{8}_\d{6}\.png$`, screenshotFilename())
+}
+
+func TestPlaying_InspectorPicksEntityAndListsFields(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	px, py := ecs.HitboxCenter(p.world, p.world.PlayerID)
+
+	assert.Equal(t, p.world.PlayerID, p.entityAt(px, py))
+	assert.Equal(t, ecs.EntityID(0), p.entityAt(-100, -100))
+
+	p.inspected = p.world.PlayerID
+	rows := p.inspectorRows()
+	require.NotEmpty(t, rows)
+	assert.Equal(t, inspectorRow{component: "Position", header: true}, rows[0])
+	assert.Regexp(t, `^ X \d+ \(\d+px\)$`, rows[1].label())
+}
+
+func TestPlaying_RewindRestoresEarlierFrame(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.SetDebug(true)
+	player := p.world.PlayerID
+	for x := range 3 {
+		p.world.Position[player] = ecs.Position{X: x * ecs.PositionScale}
+		p.recordRewind()
+	}
+
+	p.enterRewind()
+	require.Equal(t, state.StateRewind, p.state)
+	p.restoreSnapshot(p.rewind.At(2))
+	assert.Equal(t, 0, p.world.Position[player].X)
+
+	p.SetDebug(false)
+	assert.Nil(t, p.rewind, "history is only kept in debug mode")
+}
+
+func TestPlaying_ArrowSelectEquipsSlotAndSlowsTime(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	player := p.world.PlayerID
+	require.Equal(t, len(ecs.DefaultArrows), p.arrowSelectUI.Slots, "one slot per equipped arrow")
+
+	assert.Equal(t, 2, p.updateArrowSelect(ui.ArrowSelectInput{Number: 3}))
+	assert.Equal(t, ecs.DefaultArrows[2], p.world.PlayerData[player].CurrentArrow)
+
+	p.updateArrowSelect(ui.ArrowSelectInput{Open: true, CursorX: 100, CursorY: 100})
+	assert.Equal(t, 1, p.world.TimeScale.Tick(), "slow motion while the wheel is open")
+
+	p.arrowSelectUI.State = ui.ArrowSelectIdle
+	p.updateArrowSelect(ui.ArrowSelectInput{})
+	assert.Equal(t, ecs.SubstepsPerFrame, p.world.TimeScale.Tick())
+}
+
+func TestPlaying_TimeScaleRamps(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Physics.TimeScale = config.TimeScaleConfig{RampIn: 0.1, RampOut: 0}
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+
+	assert.Equal(t, 17, p.world.TimeScale.RampInPct, "100% over 6 frames, rounded up")
+	assert.Equal(t, 0, p.world.TimeScale.RampOutPct)
+
+	p.restart()
+	assert.Equal(t, 17, p.world.TimeScale.RampInPct, "restart keeps the ramps")
+}
+
+func TestStepClock_FixedFramesAtAnyTickRate(t *testing.T) {
+	var c stepClock
+	frames := 0
+	for range 144 {
+		frames += c.advance(1.0/144, 60)
+	}
+	assert.Equal(t, 60, frames, "one second at 144 Hz simulates one second")
+
+	c = stepClock{}
+	assert.Equal(t, 2, c.advance(1.0/30, 60), "30 Hz runs two frames a tick")
+	assert.Zero(t, c.advance(1.0/120, 60))
+	assert.InDelta(t, 0.5, c.alpha(60), 1e-9, "halfway to the next frame")
+
+	assert.Equal(t, maxStepsPerTick, c.advance(1, 60), "a stall drops time")
+	assert.Zero(t, c.alpha(60))
+}
+
+func TestPendingPresses_ReachTheNextFrame(t *testing.T) {
+	var pp pendingPresses
+	pp.hold(inputState{JumpPressed: true, Attack: true}, ui.ArrowSelectInput{Number: 2})
+
+	input, arrow := pp.merge(inputState{Left: true}, ui.ArrowSelectInput{})
+	assert.True(t, input.JumpPressed)
+	assert.True(t, input.Attack)
+	assert.True(t, input.Left)
+	assert.Equal(t, 2, arrow.Number)
+
+	input, arrow = pp.merge(inputState{}, ui.ArrowSelectInput{})
+	assert.False(t, input.JumpPressed, "presses are used once")
+	assert.Zero(t, arrow.Number)
+
+	input, _ = withoutPresses(inputState{Left: true, Dash: true}, ui.ArrowSelectInput{})
+	assert.True(t, input.Left)
+	assert.False(t, input.Dash)
+}
+
+func TestPlaying_DrawPositionInterpolates(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	id := p.world.PlayerID
+	ecs.StorePreviousPositions(p.world)
+	pos := p.world.Position[id]
+	p.world.Position[id] = ecs.Position{X: pos.X + 8*ecs.PositionScale, Y: pos.Y}
+
+	p.clock.acc = 0.5 / 60
+	assert.Equal(t, pos.PixelX()+4, p.drawPosition(id).PixelX(), "halfway to the next frame")
+	dx, _ := p.drawOffset(id)
+	assert.Equal(t, -4, dx)
+
+	p.state = state.StatePaused
+	assert.Equal(t, pos.PixelX()+8, p.drawPosition(id).PixelX(), "paused: the current frame")
+}
+
+func TestTileCache_SyncTracksStageChanges(t *testing.T) {
+	stage := createTestStage()
+	stage.Tiles[3][2] = entity.Tile{Type: entity.TileCrumble, Solid: true}
+
+	var c tileCache
+	c.sync(stage, false)
+	assert.Equal(t, []ecs.TileCoord{{X: 2, Y: 3}}, c.crumbles, "crumbling tiles are drawn live")
+	assert.Empty(t, c.chunks)
+
+	stage.SetTileFallen(2, 3, true)
+	c.sync(stage, false)
+	assert.Equal(t, stage.Revision(), c.revision, "rebuilt after the tiles changed")
+}
+
+func TestPlaying_ForegroundFadesWithPlayerBehind(t *testing.T) {
+	stageCfg := createTestStageConfig()
+	stageCfg.Layers.ForegroundFade = 0.4
+	stage := createTestStage()
+	p := New(createTestConfig(), stageCfg, stage, "")
+
+	p.updateForegroundFade()
+	assert.Zero(t, p.foregroundFade, "no foreground layer")
+
+	// A foreground row covering the whole stage hides the player
+	stage.Foreground = [][]entity.Tile{}
+	for range stage.Height {
+		row := make([]entity.Tile, stage.Width)
+		for x := range row {
+			row[x] = entity.Tile{Type: entity.TileDecoration}
+		}
+		stage.Foreground = append(stage.Foreground, row)
+	}
+	require.True(t, p.playerBehindForeground())
+	for range foregroundFadeFrames {
+		p.updateForegroundFade()
+	}
+	assert.InDelta(t, 1, p.foregroundFade, 1e-9)
+
+	stageCfg.Layers.ForegroundFade = 0
+	p.updateForegroundFade()
+	assert.Less(t, p.foregroundFade, 1.0, "a stage without fade stays opaque")
+}
+
+func TestPlaceHUDElement_Anchors(t *testing.T) {
+	canvas := image.Rect(0, 0, 320, 240)
+	tests := []struct {
+		e    config.HUDElementConfig
+		x, y float64
+	}{
+		{config.HUDElementConfig{Anchor: "topLeft", X: 2, Y: 2}, 2, 2},
+		{config.HUDElementConfig{Anchor: "bottomLeft", X: 10, Y: -10}, 10, 220},
+		{config.HUDElementConfig{Anchor: "bottom", Y: -4}, 150, 226},
+		{config.HUDElementConfig{Anchor: "topRight", X: -4, Y: 4, Width: 60}, 256, 4},
+		{config.HUDElementConfig{Anchor: "center"}, 150, 115},
+		{config.HUDElementConfig{Anchor: "unknown", X: 3}, 3, 0},
+	}
+	for _, tt := range tests {
+		x, y := place(tt.e, 20, 10, canvas)
+		assert.Equal(t, tt.x, x, tt.e.Anchor)
+		assert.Equal(t, tt.y, y, tt.e.Anchor)
+	}
+
+	// The layout follows the resolution
+	x, y := place(config.HUDElementConfig{Anchor: "bottomLeft", X: 10, Y: -10, Height: 10}, 100, 0, image.Rect(0, 0, 640, 360))
+	assert.Equal(t, 10.0, x)
+	assert.Equal(t, 340.0, y)
+}
+
+func TestBuildHUDLayout(t *testing.T) {
+	cfg := createTestConfig()
+	l := buildHUDLayout(cfg)
+	assert.Equal(t, 1.0, l.scale)
+	assert.Equal(t, defaultTheme, l.theme, "no UI config: built-in layout")
+	assert.Equal(t, defaultHUD.Health, l.health)
+
+	cfg.UI = &config.UIConfig{
+		HUD:    config.HUDConfig{Gold: config.HUDElementConfig{Anchor: "top", Hidden: true}},
+		Theme:  "amber",
+		Themes: map[string]config.ThemeConfig{"amber": {HealthFG: "#ffb000"}},
+	}
+	l = buildHUDLayout(cfg)
+	assert.Equal(t, 1.0, l.scale, "0 scale = 1")
+	assert.True(t, l.gold.Hidden)
+	assert.Equal(t, color.RGBA{0xff, 0xb0, 0, 255}, l.theme.healthFG)
+	assert.Equal(t, defaultTheme.barBG, l.theme.barBG, "colors left out keep the built-in ones")
+
+	cfg.UI.Theme = "missing"
+	assert.Equal(t, defaultTheme, buildHUDLayout(cfg).theme)
+}
+
+func TestPlaying_AccessibilitySettings(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	assert.Equal(t, ecs.ArrowColors[ecs.ArrowRed], p.arrowColor(ecs.ArrowRed), "no settings: default palette")
+
+	s := settings.Default()
+	s.Palette = "redGreen"
+	s.ReduceShake = true
+	p.SetSettings(s, "")
+	assert.Equal(t, palettes["redGreen"].arrows[ecs.ArrowRed], p.arrowColor(ecs.ArrowRed))
+	assert.Equal(t, reducedShakePct, p.world.Feedback.ShakeScalePct, "capped below the 100% setting")
+
+	s.Palette = "unknown"
+	assert.Equal(t, ecs.ArrowColors[ecs.ArrowBlue], p.arrowColor(ecs.ArrowBlue), "unknown palettes fall back")
+	for arrow := range ecs.ArrowColors {
+		for name, pal := range palettes {
+			assert.Contains(t, pal.arrows, arrow, name)
+		}
+		assert.Contains(t, arrowMarkers, arrow)
+	}
+}
+
+func TestInputAssist_Filters(t *testing.T) {
+	const dt = 1.0 / 60
+	s := settings.Default()
+	s.HoldToleranceMs = 50
+	s.JumpRepeatGuardMs = 200
+	s.DashToggle = true
+	var a inputAssist
+
+	a.apply(inputState{Right: true}, s, dt)
+	assert.True(t, a.apply(inputState{}, s, dt).Right, "a short release keeps the key held")
+	a.apply(inputState{}, s, dt)
+	assert.False(t, a.apply(inputState{}, s, dt).Right, "released after the tolerance")
+
+	assert.True(t, a.apply(inputState{JumpPressed: true}, s, dt).JumpPressed)
+	assert.False(t, a.apply(inputState{JumpPressed: true}, s, dt).JumpPressed, "a repeat inside the guard is ignored")
+
+	s.HoldToleranceMs = 0 // a tolerated release would keep running
+	assert.True(t, a.apply(inputState{Dash: true, Left: true}, s, dt).Dash, "the dash key starts running")
+	assert.True(t, a.apply(inputState{Left: true}, s, dt).Dash, "running keeps dashing")
+	assert.False(t, a.apply(inputState{}, s, dt).Dash, "not while standing")
+	a.apply(inputState{Dash: true}, s, dt)
+	assert.False(t, a.apply(inputState{Left: true}, s, dt).Dash, "pressed again: stopped")
+
+	s.GameSpeedPct = 75
+	assert.Equal(t, 75, a.gameSpeedPct(s))
+	a.slowOff = true
+	assert.Equal(t, 100, a.gameSpeedPct(s), "the slow key switches it off")
+}
+
+func TestPlaying_AttractReplay(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	back := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	data := replay.CreateTestReplayData(2, 10, 20)
+	p.PlayReplay(data)
+	p.SetAttract(back, "attract.demo")
+	assert.Equal(t, data.Seed, p.seed, "the replay's seed plays back the same run")
+	assert.Nil(t, p.updateAttract())
+
+	p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+	assert.Equal(t, 1, p.replayer.CurrentFrame(), "one recorded frame per simulation frame")
+	p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+	p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+	assert.Nil(t, p.replayer, "ran out")
+	assert.Equal(t, scene.Scene(back), p.updateAttract(), "the demo ends with the replay")
+
+	p.restart()
+	assert.Nil(t, p.replayer)
+}
+
+func TestPlaying_ReplayDirSavesRunsWithMetadata(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	dir := t.TempDir()
+	p.SetReplayDir(dir, "abc123")
+	require.NotNil(t, p.recorder, "every run is recorded")
+
+	p.stepPlaying(inputState{Right: true}, ui.ArrowSelectInput{})
+	p.stats.Gold = 7
+	p.lastInitials = "ZED"
+	p.saveRecording()
+
+	entries, err := replay.List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	meta := entries[0].Data
+	assert.Equal(t, "ZED", meta.Player)
+	assert.Equal(t, 7, meta.Gold)
+	assert.Equal(t, 1, meta.Duration)
+	assert.Equal(t, "abc123", meta.VersionHash)
+	assert.Equal(t, p.stageCfg.ID, meta.Stage)
+
+	p.PlayReplay(replay.CreateTestReplayData(1, 0, 0))
+	assert.Nil(t, p.recorder, "watching a replay is not recorded")
+}
+
+func TestPlaying_ReplayKeepsRunSettings(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Difficulty = &config.DifficultyConfig{
+		Default: "normal",
+		Profiles: map[string]config.DifficultyProfile{
+			"normal": {},
+			"hard":   {EnemyHealth: 1.5},
+		},
+	}
+	swordsman := cfg.Entities.Characters[0]
+	swordsman.ID = "swordsman"
+	swordsman.Stats.MaxHealth = 140
+	cfg.Entities.Characters = append(cfg.Entities.Characters, swordsman)
+
+	p := New(cfg, createTestStageConfig(), createTestStage(), "")
+	p.SetReplayDir(t.TempDir(), "abc123")
+	s := settings.Default()
+	s.Difficulty = "hard"
+	s.Character = "swordsman"
+	p.SetSettings(s, "")
+	p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+	p.saveRecording()
+	data := p.recorder.GetData()
+	assert.Equal(t, "hard", data.Difficulty)
+	assert.Equal(t, "swordsman", data.Character)
+
+	// Watched without the user's settings
+	viewer := New(cfg, createTestStageConfig(), createTestStage(), "")
+	viewer.PlayReplay(data)
+	assert.Equal(t, "swordsman", viewer.character.ID)
+	assert.Equal(t, 140, viewer.world.Health[viewer.world.PlayerID].Max)
+	assert.Equal(t, 1.5, viewer.difficulty.EnemyHealth)
+
+	viewer.restart()
+	assert.Equal(t, "player", viewer.character.ID, "a fresh run goes back to the settings")
+	assert.Equal(t, config.DifficultyProfile{}, viewer.difficulty)
+}
+
+func TestPlaying_ReplayKeepsAimAssist(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.SetReplayDir(t.TempDir(), "")
+	s := settings.Default()
+	s.AimAssist = true
+	p.SetSettings(s, "")
+	p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+	data := p.recorder.GetData()
+	require.NotEmpty(t, data.Frames)
+	assert.True(t, data.Frames[0].AA)
+
+	// Watched with assist off, the recorded setting aims the arrows
+	viewer := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	viewer.SetSettings(settings.Default(), "")
+	viewer.PlayReplay(data)
+	in, _, ok := viewer.replayInput()
+	require.True(t, ok)
+	assert.True(t, in.AimAssist)
+}
+
+func TestPlaying_TASScrubAndEdit(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	data := replay.CreateTestReplayData(150, 0, 0)
+	p.EnterTAS(data, "")
+	assert.Equal(t, state.StateTAS, p.state)
+	id := p.world.PlayerID
+
+	p.tasSeek(120)
+	assert.Equal(t, 120, p.replayer.CurrentFrame())
+	assert.Contains(t, p.tas.keyframes, 60, "snapshots on the way")
+	at120 := p.world.Position[id]
+	p.tasSeek(70)
+	p.tasSeek(120)
+	assert.Equal(t, at120, p.world.Position[id], "scrubbing back and forth re-simulates the same frames")
+
+	p.tas.mark = 10
+	stale := p.tas.keyframes[60]
+	p.tasEdit(func(lo, hi int) { p.tas.data.Toggle(lo, hi, replay.FieldRight) })
+	assert.True(t, p.tas.data.Frames[100].R, "the selection is edited")
+	assert.NotSame(t, stale, p.tas.keyframes[60], "later keyframes are taken again")
+	assert.Equal(t, 120, p.replayer.CurrentFrame())
+	assert.Greater(t, p.world.Position[id].X, at120.X, "re-simulated with the new input")
+
+	p.SetReplayDir(t.TempDir(), "")
+	p.exitTAS()
+	assert.Equal(t, state.StatePlaying, p.state)
+	assert.Nil(t, p.replayer)
+	assert.Equal(t, 120, p.recorder.FrameCount(), "the edited frames start the recording")
+}
+
+func TestPlaying_TASResimulationMatchesStraightRun(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	roll := 0
+	p.AddSystem(func(w *ecs.World) { roll = w.Rand.Intn(1000) })
+	data := replay.CreateTestReplayData(200, 0, 0)
+	for i := range data.Frames {
+		data.Frames[i].R = i%40 < 20
+		data.Frames[i].L = i%40 >= 30
+		data.Frames[i].J = i%50 == 0
+	}
+	p.EnterTAS(data, "")
+	frameState := func() string {
+		return fmt.Sprint(p.world.Position, p.world.Velocity, p.world.Health, p.spawnTimer, roll)
+	}
+
+	var straight []string
+	for f := 1; f <= 200; f++ {
+		p.tasSeek(f)
+		straight = append(straight, frameState())
+	}
+
+	// Back through the keyframe at 60 and forward again
+	p.tasSeek(61)
+	for f := 61; f <= 200; f++ {
+		p.tasSeek(f)
+		require.Equal(t, straight[f-1], frameState(), "frame %d", f)
+	}
+}
+
+func TestPlaying_RunBot(t *testing.T) {
+	for _, mode := range []BotMode{BotRandom, BotSeekExit} {
+		p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+		err := p.RunBot(BotOptions{Mode: mode, Duration: time.Minute, MaxFrames: 600, Seed: 1, DumpDir: t.TempDir()})
+		require.NoError(t, err)
+		assert.Nil(t, p.bot)
+		assert.NotNil(t, p.recorder, "the run is recorded for reports")
+	}
+}
+
+func TestPlaying_RunBotReportsViolations(t *testing.T) {
+	p := New(createTestConfig(), createTestStageConfig(), createTestStage(), "")
+	p.AddSystem(func(w *ecs.World) {
+		h := w.Health[w.PlayerID]
+		h.Current = h.Max + 1
+		w.Health[w.PlayerID] = h
+	})
+	dir := t.TempDir()
+	err := p.RunBot(BotOptions{Duration: time.Minute, MaxFrames: 600, Seed: 1, DumpDir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "above max")
+
+	reports, _ := filepath.Glob(filepath.Join(dir, "crash_*", "world.json"))
+	assert.Len(t, reports, 1, "the world is dumped")
+}
+
+func TestParseBotMode(t *testing.T) {
+	mode, ok := ParseBotMode("exit")
+	assert.True(t, ok)
+	assert.Equal(t, BotSeekExit, mode)
+	_, ok = ParseBotMode("fly")
+	assert.False(t, ok)
+}
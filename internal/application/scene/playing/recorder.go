@@ -1,12 +1,14 @@
 package playing
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
 )
 
 // RecordableInput is the input interface for recording
@@ -16,10 +18,18 @@ type RecordableInput struct {
 	JumpPressed           bool
 	JumpReleased          bool
 	Dash                  bool
+	Block                 bool
+	Summon                bool
+	Interact              bool
 	MouseX, MouseY        int
 	MouseClick            bool
 	RightClickPressed     bool
 	RightClickReleased    bool
+	ArrowNumber           int
+	StickX, StickY        float64
+	GraceFrames           int
+	GameSpeedPct          int
+	AimAssist             bool
 }
 
 // Recorder handles input recording for replay
@@ -33,7 +43,7 @@ type Recorder struct {
 func NewRecorder(seed int64, stage string) *Recorder {
 	return &Recorder{
 		data: replay.ReplayData{
-			Version:   "1.0",
+			Version:   replay.FormatVersion,
 			Seed:      seed,
 			Stage:     stage,
 			StartTime: time.Now().Format(time.RFC3339),
@@ -44,6 +54,13 @@ func NewRecorder(seed int64, stage string) *Recorder {
 	}
 }
 
+// resumeRecorder continues recording after the first frames of data (e.g.
+// a run edited in the TAS tools)
+func resumeRecorder(data replay.ReplayData, frames int) *Recorder {
+	data.Frames = slices.Clone(data.Frames[:frames])
+	return &Recorder{data: data, recording: true, frame: frames}
+}
+
 // RecordFrame records a single frame's input
 func (r *Recorder) RecordFrame(input RecordableInput) {
 	if !r.recording {
@@ -60,36 +77,46 @@ func (r *Recorder) RecordFrame(input RecordableInput) {
 		JP:  input.JumpPressed,
 		JR:  input.JumpReleased,
 		Dsh: input.Dash,
+		Blk: input.Block,
+		Sum: input.Summon,
+		Int: input.Interact,
 		MX:  input.MouseX,
 		MY:  input.MouseY,
 		MC:  input.MouseClick,
 		RCP: input.RightClickPressed,
 		RCR: input.RightClickReleased,
+		Num: input.ArrowNumber,
+		SX:  input.StickX,
+		SY:  input.StickY,
+		Gr:  input.GraceFrames,
+		AA:  input.AimAssist,
+	}
+	if input.GameSpeedPct < 100 {
+		frameInput.GS = input.GameSpeedPct
 	}
 
 	r.data.Frames = append(r.data.Frames, frameInput)
 	r.frame++
 }
 
-// Save writes the replay data to a file
-func (r *Recorder) Save(filename string) error {
-	if len(r.data.Frames) == 0 {
-		return fmt.Errorf("no frames to save")
-	}
-
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer func() { _ = file.Close() }()
+// SetMetadata fills in what the replay browser lists besides the stage
+// and length: the player's initials, final gold and content version
+func (r *Recorder) SetMetadata(player string, gold int, versionHash string) {
+	r.data.Player = player
+	r.data.Gold = gold
+	r.data.VersionHash = versionHash
+}
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(r.data); err != nil {
-		return fmt.Errorf("failed to encode replay: %w", err)
-	}
+// SetRunSettings records the difficulty and character the run is played
+// with, so it plays back with them
+func (r *Recorder) SetRunSettings(difficulty, character string) {
+	r.data.Difficulty = difficulty
+	r.data.Character = character
+}
 
-	return nil
+// Save writes the replay data to a file
+func (r *Recorder) Save(filename string) error {
+	return r.data.Save(filename)
 }
 
 // Stop stops recording
@@ -112,6 +139,31 @@ func (r *Recorder) GetData() replay.ReplayData {
 	return r.data
 }
 
+// SetReplayDir records every run and saves each into its own file in dir
+// (for the replay browser) unless a record path was given. versionHash
+// identifies the content the runs are played with.
+func (p *Playing) SetReplayDir(dir, versionHash string) {
+	p.replayDir = dir
+	p.versionHash = versionHash
+	if dir != "" && p.recorder == nil {
+		p.recorder = NewRecorder(p.seed, p.stageCfg.ID)
+	}
+}
+
+// runReplayFile returns the current run's file in the replay directory,
+// creating the directory (no directory = the working directory)
+func (p *Playing) runReplayFile() string {
+	if p.replayFile == "" {
+		p.replayFile = filepath.Join(p.replayDir, GenerateFilename())
+	}
+	if p.replayDir != "" {
+		if err := os.MkdirAll(p.replayDir, 0o755); err != nil {
+			logging.Replay.Errorf("Failed to create replay dir: %v", err)
+		}
+	}
+	return p.replayFile
+}
+
 // GenerateFilename creates a filename based on current time
 func GenerateFilename() string {
 	return fmt.Sprintf("replay_%s.json", time.Now().Format("20060102_150405"))
@@ -0,0 +1,53 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// respawnRule returns a stage entity's respawn rule.
+// Unknown names never respawn.
+func respawnRule(kind, typ, name string) ecs.RespawnRule {
+	if name == "" {
+		return ecs.RespawnNever
+	}
+	rule, ok := ecs.ParseRespawnRule(name)
+	if !ok {
+		logging.Config.Warnf("Unknown respawn rule for %s %s: %s", kind, typ, name)
+	}
+	return rule
+}
+
+// spawnStageEnemies places the stage's enemies and tracks the ones that
+// respawn
+func (p *Playing) spawnStageEnemies() {
+	for i, spawn := range p.stageCfg.Enemies {
+		p.world.TrackRespawn(p.spawnEnemy(spawn), ecs.Respawn{
+			Spawn:  i,
+			Rule:   respawnRule("enemy", spawn.Type, spawn.Respawn),
+			Frames: fixedpoint.SecondsToFrames(spawn.RespawnDelay),
+			X:      spawn.X,
+			Y:      spawn.Y,
+		})
+	}
+}
+
+// respawnStageEntities brings back killed stage enemies and collected
+// stage pickups once their respawn rule allows it
+func (p *Playing) respawnStageEntities() {
+	if len(p.world.Respawns) == 0 {
+		return
+	}
+	camX, camY := p.getCameraOffset()
+	for _, i := range ecs.UpdateRespawns(p.world, camX, camY, p.screenW, p.screenH) {
+		r := p.world.Respawns[i]
+		var id ecs.EntityID
+		if r.Pickup {
+			id = p.spawnStagePickup(p.stageCfg.Pickups[r.Spawn])
+		} else if id = p.spawnEnemy(p.stageCfg.Enemies[r.Spawn]); id != 0 {
+			ecs.SpawnIn(p.world, id)
+		}
+		p.world.Respawned(i, id)
+	}
+}
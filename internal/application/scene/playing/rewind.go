@@ -0,0 +1,91 @@
+package playing
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Rewind tuning
+const (
+	rewindFrames   = 600 // history kept in debug mode (10 seconds)
+	rewindFastStep = 10  // frames Shift+Left/Right step at once
+)
+
+// recordRewind snapshots the world after a simulated frame (debug mode)
+func (p *Playing) recordRewind() {
+	if p.rewind != nil {
+		p.rewind.Record(p.world)
+	}
+}
+
+// enterRewind pauses the simulation to scrub back through the history
+func (p *Playing) enterRewind() {
+	if p.rewind == nil || p.rewind.Len() == 0 {
+		return
+	}
+	p.rewindBack = 0
+	p.state = state.StateRewind
+}
+
+// updateRewind handles rewind mode: Left/Right step a frame back/forward
+// (10 with Shift), Enter or F7 resumes play from the shown frame and ESC
+// returns to the latest frame
+func (p *Playing) updateRewind() {
+	step := 1
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		step = rewindFastStep
+	}
+	back := p.rewindBack
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyComma) {
+		back += step
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyPeriod) {
+		back -= step
+	}
+	back = max(0, min(back, p.rewind.Len()-1))
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		p.restoreSnapshot(p.rewind.At(0))
+		p.state = state.StatePlaying
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyF7):
+		// Frames after the shown one are replaced by the new timeline
+		p.rewind.Truncate(p.rewindBack)
+		if p.recorder != nil && p.rewindBack > 0 {
+			logging.Replay.Warnf("Recording no longer matches the run after rewinding %d frames", p.rewindBack)
+		}
+		p.state = state.StatePlaying
+	case back != p.rewindBack:
+		p.rewindBack = back
+		p.restoreSnapshot(p.rewind.At(back))
+	}
+}
+
+// restoreSnapshot puts the world back to a snapshot and syncs the stage's
+// fallen crumbling tiles and the boss arena with it. The frame's events were handled when it
+// was first played, so they are dropped.
+func (p *Playing) restoreSnapshot(s *ecs.Snapshot) {
+	p.world.Restore(s)
+	p.world.Events.Drain()
+	p.stage.RestoreFallen()
+	for coord, c := range p.world.Crumbles {
+		if c.State == ecs.CrumbleFallen {
+			p.stage.SetTileFallen(coord.X, coord.Y, true)
+		}
+	}
+	p.syncBossArena()
+}
+
+// drawRewindOverlay shows the rewound time and the controls
+func (p *Playing) drawRewindOverlay(screen *ebiten.Image) {
+	ui.FillRect(screen, 0, 0, float64(p.screenW), 30, colorPhotoHint)
+	ui.Draw(screen, fmt.Sprintf("REWIND -%df (%.2fs) of %d", p.rewindBack, fixedpoint.FramesToSeconds(p.rewindBack), p.rewind.Len()-1), 4, 2, ui.StyleDefault)
+	ui.Draw(screen, "</>: step  Enter: resume  ESC: back", 4, 16, ui.StyleDefault)
+}
@@ -0,0 +1,32 @@
+package playing
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// SetRumble enables or disables gamepad vibration (settings toggle).
+// The config value feedback.rumble.enabled is the default.
+func (p *Playing) SetRumble(enabled bool) {
+	p.rumbleDisabled = !enabled
+	p.world.Feedback.RumbleEnabled = enabled
+}
+
+// playRumble sends the frame's strongest rumble request to all connected gamepads
+func (p *Playing) playRumble() {
+	rumble, ok := p.world.Feedback.TakeRumble()
+	if !ok {
+		return
+	}
+
+	p.gamepadIDs = ebiten.AppendGamepadIDs(p.gamepadIDs[:0])
+	for _, id := range p.gamepadIDs {
+		ebiten.VibrateGamepad(id, &ebiten.VibrateGamepadOptions{
+			Duration:        time.Duration(fixedpoint.FramesToSeconds(rumble.Frames) * float64(time.Second)),
+			StrongMagnitude: rumble.Strong,
+			WeakMagnitude:   rumble.Weak,
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package playing
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/settings"
+)
+
+// keyBindings holds the resolved keyboard keys for each input action
+type keyBindings struct {
+	Left, Right, Up, Down ebiten.Key
+	Jump, Dash            ebiten.Key
+	Interact, Block       ebiten.Key
+	Slow, Summon          ebiten.Key
+}
+
+// resolveKeyBindings converts settings key names to ebiten keys.
+// Unknown names fall back to the default binding for that action.
+func resolveKeyBindings(names map[string]string) keyBindings {
+	defaults := settings.DefaultKeyBindings()
+	resolve := func(action string) ebiten.Key {
+		var k ebiten.Key
+		if err := k.UnmarshalText([]byte(names[action])); err == nil {
+			return k
+		}
+		if err := k.UnmarshalText([]byte(defaults[action])); err != nil {
+			logging.Config.Warnf("Invalid default key binding for %s: %v", action, err)
+		}
+		return k
+	}
+
+	return keyBindings{
+		Left:  resolve(settings.ActionLeft),
+		Right: resolve(settings.ActionRight),
+		Up:    resolve(settings.ActionUp),
+		Down:  resolve(settings.ActionDown),
+		Jump:  resolve(settings.ActionJump),
+		Dash:  resolve(settings.ActionDash),
+
+		Interact: resolve(settings.ActionInteract),
+		Block:    resolve(settings.ActionBlock),
+		Slow:     resolve(settings.ActionSlow),
+		Summon:   resolve(settings.ActionSummon),
+	}
+}
+
+// SetSettings attaches user settings. path is where the options menu saves
+// changes (empty = don't persist).
+func (p *Playing) SetSettings(s *settings.Settings, path string) {
+	p.settings = s
+	p.settingsPath = path
+	p.applySettings()
+}
+
+// applySettings applies gameplay and input settings to the scene
+func (p *Playing) applySettings() {
+	if p.settings == nil {
+		return
+	}
+	p.keys = resolveKeyBindings(p.settings.KeyBindings)
+	i18n.SetLanguage(p.settings.Language)
+	p.applyFeedbackSettings()
+
+	// A different difficulty starts the stage over with rescaled enemies
+	// (and the difficulty's config overrides)
+	reloaded := p.reloadConfig()
+	prev := p.difficulty
+	p.applyDifficulty()
+	// A different character starts the stage over with the new player
+	prevCharacter := p.character.ID
+	p.applyCharacter()
+	if reloaded || p.difficulty != prev || p.character.ID != prevCharacter {
+		p.restart()
+	}
+}
+
+// applyFeedbackSettings applies user feedback settings to the current world.
+// Called again after restart since the world's Feedback is rebuilt from config.
+func (p *Playing) applyFeedbackSettings() {
+	f := p.world.Feedback
+	if p.rumbleDisabled {
+		f.RumbleEnabled = false
+	}
+	if p.settings == nil {
+		return
+	}
+	f.ShakeScalePct = p.settings.ScreenShakePct
+	if p.settings.ReduceShake {
+		f.ShakeScalePct = min(f.ShakeScalePct, reducedShakePct)
+	}
+	if !p.settings.Rumble {
+		f.RumbleEnabled = false
+	}
+}
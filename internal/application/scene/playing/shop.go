@@ -0,0 +1,198 @@
+package playing
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+const shopWidth = 200
+
+// shopItemKind is what a shop item gives the player
+type shopItemKind int
+
+const (
+	shopHealth shopItemKind = iota // heals Amount, capped at max health
+	shopAmmo                       // adds Amount homing arrows
+	shopMagnet                     // raises the magnet upgrade tier
+	shopDash                       // raises the dash attack upgrade tier
+	shopEnergy                     // raises the energy pool upgrade tier
+	shopTurret                     // unlocks the summonable turret
+)
+
+// shopItem is an item a shopkeeper sells
+type shopItem struct {
+	kind   shopItemKind
+	price  int
+	amount int
+}
+
+// shopItemKinds maps config item names to kinds
+var shopItemKinds = map[string]shopItemKind{
+	"health": shopHealth,
+	"ammo":   shopAmmo,
+	"magnet": shopMagnet,
+	"dash":   shopDash,
+	"energy": shopEnergy,
+	"turret": shopTurret,
+}
+
+// buildShop converts a shopkeeper's item config. Unknown items are skipped.
+func buildShop(cfg []config.ShopItemConfig) []shopItem {
+	var items []shopItem
+	for _, c := range cfg {
+		kind, ok := shopItemKinds[c.Item]
+		if !ok {
+			logging.Config.Warnf("Unknown shop item: %s", c.Item)
+			continue
+		}
+		items = append(items, shopItem{kind: kind, price: c.Price, amount: c.Amount})
+	}
+	return items
+}
+
+// label returns the item's display name
+func (item shopItem) label() string {
+	switch item.kind {
+	case shopHealth:
+		return i18n.Tf("shop.health", item.amount)
+	case shopAmmo:
+		return i18n.Tf("shop.ammo", item.amount)
+	case shopMagnet:
+		return i18n.T("shop.magnet")
+	case shopEnergy:
+		return i18n.T("shop.energy")
+	case shopTurret:
+		return i18n.T("shop.turret")
+	default:
+		return i18n.T("shop.dash")
+	}
+}
+
+// openShop pauses the simulation and shows an NPC's shop
+func (p *Playing) openShop(id ecs.EntityID) {
+	p.shopNPC = id
+	p.shopSelected = 0
+	p.shopMessage = ""
+	p.state = state.StateShop
+}
+
+// soldOut reports whether an item can no longer help the player:
+// full health, or an upgrade already at its last tier.
+func (p *Playing) soldOut(item shopItem) bool {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	switch item.kind {
+	case shopHealth:
+		health := p.world.Health[p.world.PlayerID]
+		return health.Current >= health.Max
+	case shopMagnet:
+		return playerData.MagnetLevel >= len(p.world.Magnet.Radius)-1
+	case shopDash:
+		return p.world.Dash[p.world.PlayerID].Level >= len(p.world.DashAttack.Damage)-1
+	case shopEnergy:
+		return playerData.EnergyLevel >= len(p.world.Energy.Max)-1
+	case shopTurret:
+		return playerData.SummonUnlocked
+	default:
+		return false
+	}
+}
+
+// buyShopItem spends gold on an item and applies it.
+// It returns the i18n key of the result message.
+func (p *Playing) buyShopItem(item shopItem) string {
+	playerID := p.world.PlayerID
+	playerData := p.world.PlayerData[playerID]
+	if p.soldOut(item) {
+		return "shop.soldOut"
+	}
+	if playerData.Gold < item.price {
+		return "shop.noGold"
+	}
+	playerData.Gold -= item.price
+
+	switch item.kind {
+	case shopHealth:
+		health := p.world.Health[playerID]
+		health.Current = min(health.Max, health.Current+item.amount)
+		p.world.Health[playerID] = health
+	case shopAmmo:
+		playerData.Ammo += item.amount
+	case shopMagnet:
+		playerData.MagnetLevel++
+	case shopEnergy:
+		playerData.EnergyLevel++
+	case shopTurret:
+		playerData.SummonUnlocked = true
+	case shopDash:
+		dash := p.world.Dash[playerID]
+		dash.Level++
+		p.world.Dash[playerID] = dash
+	}
+	p.world.PlayerData[playerID] = playerData
+	return "shop.bought"
+}
+
+// updateShop handles shop input (simulation is paused)
+func (p *Playing) updateShop() {
+	items := p.npcs[p.shopNPC].shop
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || len(items) == 0 {
+		p.state = state.StatePlaying
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(p.keys.Up) || inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		p.shopSelected = (p.shopSelected + len(items) - 1) % len(items)
+	case inpututil.IsKeyJustPressed(p.keys.Down) || inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		p.shopSelected = (p.shopSelected + 1) % len(items)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(p.keys.Interact):
+		p.shopMessage = p.buyShopItem(items[p.shopSelected])
+	}
+}
+
+// drawShop draws the shopkeeper's item list with prices and the player's gold
+func (p *Playing) drawShop(screen *ebiten.Image) {
+	n := p.npcs[p.shopNPC]
+	lineH := ui.LineHeight(ui.StyleDefault)
+	h := float64(len(n.shop)+4)*lineH + 2*dialoguePad
+
+	x := float64(p.screenW-shopWidth) / 2
+	y := float64(p.screenH)/2 - h/2
+	ui.FillRect(screen, x, y, shopWidth, h, colorDialogueBox)
+	drawRectOutline(screen, x, y, shopWidth, h, colorDialogueBorder)
+
+	textY := y + dialoguePad
+	ui.Draw(screen, n.name, x+dialoguePad, textY, ui.Style{Color: colorDialogueSpeaker})
+	gold := i18n.Tf("hud.gold", p.world.PlayerData[p.world.PlayerID].Gold)
+	ui.Draw(screen, gold, x+shopWidth-dialoguePad, textY, ui.Style{Color: colorGold, Align: ui.AlignRight})
+	textY += lineH * 1.5
+
+	for i, item := range n.shop {
+		label := "  " + item.label()
+		style := ui.StyleDefault
+		if i == p.shopSelected {
+			label = "> " + item.label()
+			style.Color = colorDialogueSpeaker
+		}
+		price := i18n.Tf("shop.price", item.price)
+		if p.soldOut(item) {
+			price = i18n.T("shop.soldOut")
+		}
+		ui.Draw(screen, label, x+dialoguePad, textY, style)
+		ui.Draw(screen, price, x+shopWidth-dialoguePad, textY, ui.Style{Color: style.Color, Align: ui.AlignRight})
+		textY += lineH
+	}
+
+	textY += lineH / 2
+	if p.shopMessage != "" {
+		ui.Draw(screen, i18n.T(p.shopMessage), x+shopWidth/2, textY, ui.Style{Color: colorDialogueBorder, Align: ui.AlignCenter})
+	}
+	hint := i18n.Tf("shop.help", p.keys.Interact)
+	ui.Draw(screen, hint, x+shopWidth/2, y+h-dialoguePad-lineH, ui.Style{Color: colorDialogueBorder, Align: ui.AlignCenter})
+}
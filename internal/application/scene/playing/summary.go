@@ -0,0 +1,167 @@
+package playing
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+const (
+	triggerTypeExit = "exit"
+
+	victoryFrames = 90      // stage clear celebration before the results
+	victoryTrack  = "clear" // music track of the stage clear jingle
+	confettiCount = 48
+)
+
+var (
+	colorSummaryOverlay = color.RGBA{0, 30, 60, 200}
+	colorRank           = color.RGBA{255, 215, 0, 255}
+	colorGoalPole       = color.RGBA{200, 200, 210, 255}
+
+	confettiColors = []color.RGBA{
+		{255, 215, 0, 255},
+		{255, 100, 120, 255},
+		{100, 200, 255, 255},
+		{120, 230, 140, 255},
+	}
+)
+
+// checkExitTrigger clears the stage when the player reaches an exit area:
+// the world freezes for the victory celebration, then the results show
+func (p *Playing) checkExitTrigger() bool {
+	if !p.world.Collision.Collides(ecs.LayerPlayer, ecs.LayerTrigger) {
+		return false
+	}
+	for _, trigger := range p.stageCfg.Triggers {
+		if trigger.Type != triggerTypeExit {
+			continue
+		}
+		if p.playerInRect(trigger.Rect) {
+			p.world.Events.Emit(ecs.Event{Type: ecs.EventStageCleared, Amount: p.stats.Frames})
+			p.state = state.StateStageClear
+			p.victory = victoryFrames
+			return true
+		}
+	}
+	return false
+}
+
+// markStageCleared records the clear in the profile; the first clear of a
+// stage with a next one announces that it was unlocked
+func (p *Playing) markStageCleared() {
+	if !p.profile.ClearStage(p.stageCfg.ID, time.Now().Unix()) {
+		return
+	}
+	if p.stageCfg.Next != "" {
+		p.toasts.Notify(ui.IconInfo, i18n.T("toast.stageUnlocked")+"\n"+i18n.T("toast.stageUnlockedHint"), 0)
+	}
+	p.saveProfile()
+}
+
+// appendHistory records the cleared run in the profile history file
+func (p *Playing) appendHistory() {
+	if p.historyPath == "" {
+		return
+	}
+	par := p.stageCfg.ParTime
+	entry := save.HistoryEntry{
+		Time:        time.Now().Unix(),
+		Stage:       p.stageCfg.ID,
+		Seconds:     p.stats.Seconds(),
+		DamageTaken: p.stats.DamageTaken,
+		ArrowsFired: p.stats.ArrowsFired,
+		ArrowsHit:   p.stats.ArrowsHit,
+		Kills:       p.stats.Kills,
+		Gold:        p.stats.Gold,
+		Score:       p.stats.Score(par),
+		Rank:        string(p.stats.Rank(par)),
+	}
+	if err := save.AppendHistory(p.historyPath, entry); err != nil {
+		logging.Scene.Errorf("Failed to append history: %v", err)
+	}
+}
+
+// drawGoals draws a flag at each exit of the stage
+func (p *Playing) drawGoals(screen *ebiten.Image, camX, camY int) {
+	wave := math.Sin(float64(p.stats.Frames) / 8)
+	for _, trigger := range p.stageCfg.Triggers {
+		if trigger.Type != triggerTypeExit {
+			continue
+		}
+		r := trigger.Rect
+		x := float64(r.X + r.W/2 - camX)
+		top := float64(r.Y - camY)
+		ui.FillRect(screen, x, top, 2, float64(r.H), colorGoalPole)
+		for i := range 12 {
+			// The cloth ripples more toward its free end
+			dy := math.Round(wave * float64(i) / 6)
+			ui.FillRect(screen, x+2+float64(i), top+2+dy, 1, 10-float64(i)*0.6, colorRank)
+		}
+	}
+}
+
+// drawVictory draws the stage clear celebration: confetti falls and the
+// title drops in over the frozen world
+func (p *Playing) drawVictory(screen *ebiten.Image) {
+	t := float64(victoryFrames - p.victory)
+	for i := range confettiCount {
+		// Each piece has its own column, speed and start height
+		x := float64((i*97 + 13) % p.screenW)
+		speed := 2 + float64(i*37%10)/5
+		y := t*speed - float64(i*53%120) - 8
+		x += 6 * math.Sin(t/10+float64(i))
+		ui.FillRect(screen, x, y, 3, 2, confettiColors[i%len(confettiColors)])
+	}
+
+	// Ease out from above the screen to the upper third
+	ease := 1 - math.Pow(1-min(t/30, 1), 3)
+	cy := float64(p.screenH/3) * ease
+	style := ui.StyleTitle
+	style.Color = colorRank
+	ui.Draw(screen, i18n.T("summary.title"), float64(p.screenW/2), cy-16, style)
+}
+
+func (p *Playing) drawStageClearOverlay(screen *ebiten.Image) {
+	if p.victory > 0 {
+		p.drawVictory(screen)
+		return
+	}
+	ui.FillRect(screen, 0, 0, float64(p.screenW), float64(p.screenH), colorSummaryOverlay)
+
+	cx, cy := float64(p.screenW/2), float64(p.screenH/2)
+	ui.Draw(screen, i18n.T("summary.title"), cx, cy-80, ui.StyleTitle)
+
+	run := p.stats
+	accuracy := int(math.Round(run.Accuracy() * 100))
+	text := i18n.Tf("summary.time", run.Seconds()) + "\n" +
+		i18n.Tf("summary.damage", run.DamageTaken) + "\n" +
+		i18n.Tf("summary.arrows", run.ArrowsHit, run.ArrowsFired, accuracy) + "\n" +
+		i18n.Tf("summary.kills", run.Kills) + "\n" +
+		i18n.Tf("summary.gold", run.Gold)
+	ui.Draw(screen, text, cx, cy-44, ui.StyleCenter)
+
+	par := p.stageCfg.ParTime
+	rankStyle := ui.StyleTitle
+	rankStyle.Color = colorRank
+	ui.Draw(screen, i18n.Tf("summary.rank", run.Rank(par), run.Score(par)), cx, cy+34, rankStyle)
+
+	switch {
+	case p.initials != nil:
+		ui.Draw(screen, i18n.T("leaderboard.newHighScore"), cx, cy+58, ui.StyleCenter)
+		p.initials.Draw(screen, cx, cy+74)
+	case p.boardRank >= 0:
+		text := i18n.Tf("leaderboard.rank", p.boardRank+1) + "\n" + i18n.T("summary.restart")
+		ui.Draw(screen, text, cx, cy+58, ui.StyleCenter)
+	default:
+		ui.Draw(screen, i18n.T("summary.restart"), cx, cy+64, ui.StyleCenter)
+	}
+}
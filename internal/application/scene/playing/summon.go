@@ -0,0 +1,84 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+var (
+	colorAlly    = color.RGBA{120, 230, 180, 255}
+	colorAllyEye = color.RGBA{255, 255, 255, 255}
+)
+
+// allyBlinkFrames is how long before vanishing a turret starts blinking
+const allyBlinkFrames = 120
+
+// buildSummonConfig converts the summonable turret config to ECS units.
+// Turrets fire enemy arrows with the summon's damage.
+func buildSummonConfig(cfg *config.GameConfig) ecs.SummonConfig {
+	s := cfg.Physics.Combat.Summon
+	aim := ecs.AimDirect
+	if s.Aim != "" {
+		mode, ok := ecs.ParseAimMode(s.Aim)
+		if !ok {
+			logging.Config.Warnf("Unknown summon aim mode: %s", s.Aim)
+		}
+		aim = mode
+	}
+	projectile := buildArrowConfig(cfg)
+	projectile.Damage = s.Damage
+	return ecs.SummonConfig{
+		Lifetime:       fixedpoint.SecondsToFrames(s.Duration),
+		Cooldown:       fixedpoint.SecondsToFrames(s.Cooldown),
+		Range:          s.Range,
+		AttackCooldown: fixedpoint.SecondsToFrames(s.FireRate),
+		Aim:            aim,
+		Width:          s.Width,
+		Height:         s.Height,
+		Projectile:     projectile,
+	}
+}
+
+// drawAllies draws the summoned turrets, blinking before they vanish
+func (p *Playing) drawAllies(screen *ebiten.Image, camX, camY int) {
+	for id, ally := range p.world.Ally {
+		if ally.Lifetime < allyBlinkFrames && ally.Lifetime%8 < 4 {
+			continue
+		}
+		pos := p.drawPosition(id)
+		hitbox := p.world.Hitbox[id]
+		x := float64(pos.PixelX() - camX)
+		y := float64(pos.PixelY() - camY)
+		w, h := float64(hitbox.Width), float64(hitbox.Height)
+
+		ui.FillRect(screen, x, y, w, h, telegraphColor(p.world.AI[id], colorAlly, p.palette().telegraph))
+		p.drawOutline(screen, x, y, w, h)
+
+		// Eye on the facing side
+		eyeX := x + 1
+		if p.world.Facing[id].Right {
+			eyeX = x + w - 3
+		}
+		ui.FillRect(screen, eyeX, y+h/2-1, 2, 2, colorAllyEye)
+	}
+}
+
+// summonWidget returns the HUD slot of the summonable turret: it refills
+// over the cooldown (ok is false until summoning is unlocked)
+func (p *Playing) summonWidget() (w abilityWidget, ok bool) {
+	playerData := p.world.PlayerData[p.world.PlayerID]
+	if !playerData.SummonUnlocked || p.world.Summon.Lifetime <= 0 {
+		return w, false
+	}
+	w = abilityWidget{color: colorAlly, progress: 1, ready: playerData.SummonCooldown <= 0}
+	if total := p.world.Summon.Cooldown; total > 0 && playerData.SummonCooldown > 0 {
+		w.progress = 1 - float64(playerData.SummonCooldown)/float64(total)
+	}
+	return w, true
+}
@@ -0,0 +1,125 @@
+package playing
+
+import (
+	"github.com/younwookim/mg/internal/application/metrics"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// registerSystems registers the built-in gameplay systems with the world's
+// scheduler, followed by the custom systems added with AddSystem.
+// Called whenever a new world is created.
+func (p *Playing) registerSystems() {
+	w := p.world
+
+	// Previous positions (for drawing between frames), timers, enemy
+	// alerts, shield, crouch, turrets and player input (once per frame)
+	w.AddSystem(ecs.StorePreviousPositions, ecs.PhaseFrameStart)
+	w.AddSystem(ecs.UpdateTimers, ecs.PhaseFrameStart)
+	w.AddSystem(ecs.UpdateAggro, ecs.PhaseFrameStart)
+	w.AddSystem(ecs.UpdateEnergy, ecs.PhaseFrameStart)
+	w.AddSystem(func(w *ecs.World) {
+		// Raise or lower the shield before movement reads it
+		ecs.UpdatePlayerBlock(w, p.frameInput.Block)
+	}, ecs.PhaseFrameStart)
+	w.AddSystem(func(w *ecs.World) { ecs.UpdatePlayerCrouch(w, p.stage, p.frameInput.Down, p.physicsCfg) }, ecs.PhaseFrameStart)
+	w.AddSystem(func(w *ecs.World) {
+		// Summon a turret on the key press, then let turrets shoot
+		if p.frameInput.Summon {
+			ecs.SummonAlly(w)
+		}
+		ecs.UpdateAllies(w, p.stage)
+	}, ecs.PhaseFrameStart)
+	w.AddSystem(func(w *ecs.World) {
+		input := p.frameInput
+		originX, originY, _, _ := p.playerArrowOrigin()
+		ecs.UpdatePlayerInput(w, ecs.InputState{
+			Left:         input.Left,
+			Right:        input.Right,
+			Up:           input.Up,
+			Down:         input.Down,
+			JumpPressed:  input.JumpPressed,
+			JumpReleased: input.JumpReleased,
+			Dash:         input.Dash,
+			AimX:         int(p.aimX) - originX,
+			AimY:         int(p.aimY) - originY,
+		}, p.physicsCfg)
+	}, ecs.PhaseFrameStart)
+
+	// Gravity, swarm steering and pickup attraction (once per frame, before the substeps)
+	w.AddSystem(func(w *ecs.World) { ecs.ApplyPlayerGravity(w, p.physicsCfg) }, ecs.PhasePreStep)
+	w.AddSystem(func(w *ecs.World) {
+		ecs.ApplyEnemyGravity(w, p.stage, p.physicsCfg.Gravity, p.physicsCfg.MaxFallSpeed)
+	}, ecs.PhasePreStep)
+	w.AddSystem(ecs.ApplyProjectileGravity, ecs.PhasePreStep)
+	w.AddSystem(ecs.UpdateSwarm, ecs.PhasePreStep)
+	w.AddSystem(ecs.UpdatePickupMagnet, ecs.PhasePreStep)
+	w.AddSystem(ecs.ApplyPickupGravity, ecs.PhasePreStep)
+
+	// Movement and collision per substep
+	w.AddSystem(func(w *ecs.World) {
+		t := p.metrics.Start()
+		ecs.UpdatePlayerPhysics(w, p.stage, p.physicsCfg)
+		p.metrics.Stop(metrics.PlayerPhysics, t)
+	}, ecs.PhaseSubstep)
+	w.AddSystem(func(w *ecs.World) {
+		t := p.metrics.Start()
+		ecs.UpdateEnemyAI(w, p.stage, p.arrowCfg, p.physicsCfg)
+		ecs.UpdateNPCs(w, p.stage)
+		p.metrics.Stop(metrics.EnemyAI, t)
+	}, ecs.PhaseSubstep)
+	w.AddSystem(func(w *ecs.World) { ecs.ResolveSolidEnemies(w, p.stage) }, ecs.PhaseSubstep)
+	w.AddSystem(func(w *ecs.World) {
+		t := p.metrics.Start()
+		ecs.UpdateProjectiles(w, p.stage)
+		ecs.UpdatePickupPhysics(w, p.stage)
+		p.metrics.Stop(metrics.Projectiles, t)
+	}, ecs.PhaseSubstep)
+
+	// Pickups, damage, bomb fuses and collisions (once per frame, after the substeps)
+	w.AddSystem(ecs.CollectPickups, ecs.PhaseResolve)
+	w.AddSystem(func(w *ecs.World) {
+		knockbackForce := fixedpoint.ToIUPerSubstep(p.config.Physics.Combat.Knockback.Force)
+		knockbackUp := fixedpoint.ToIUPerSubstep(p.config.Physics.Combat.Knockback.UpForce)
+		t := p.metrics.Start()
+		ecs.UpdateDamage(w, knockbackForce, knockbackUp, p.playerIframes())
+		p.metrics.Stop(metrics.Damage, t)
+	}, ecs.PhaseResolve)
+	w.AddSystem(func(w *ecs.World) { ecs.ResolveEnemyCollisions(w, p.stage) }, ecs.PhaseResolve)
+	w.AddSystem(ecs.UpdateAttachedProjectiles, ecs.PhaseResolve)
+	w.AddSystem(func(w *ecs.World) { ecs.UpdateBombs(w, p.stage) }, ecs.PhaseResolve)
+	w.AddSystem(func(w *ecs.World) { ecs.UpdateTileHazards(w, p.stage, p.hazardConfig()) }, ecs.PhaseResolve)
+	w.AddSystem(func(w *ecs.World) { ecs.UpdateCrumbles(w, p.stage) }, ecs.PhaseResolve)
+
+	// Screen shake, rumble, particles, beams and periodic spawns
+	w.AddSystem(func(w *ecs.World) {
+		ecs.UpdateFeedback(w)
+		p.playRumble()
+	}, ecs.PhaseFrameEnd)
+	w.AddSystem(func(w *ecs.World) {
+		camX, camY := p.getCameraOffset()
+		ecs.SpawnWeather(w, camX, camY, p.screenW, p.screenH)
+	}, ecs.PhaseFrameEnd)
+	w.AddSystem(ecs.UpdateParticles, ecs.PhaseFrameEnd)
+	w.AddSystem(ecs.UpdateBeams, ecs.PhaseFrameEnd)
+	w.AddSystem(func(w *ecs.World) { p.respawnStageEntities() }, ecs.PhaseFrameEnd)
+	w.AddSystem(func(w *ecs.World) { p.updateBossArenas() }, ecs.PhaseFrameEnd)
+	w.AddSystem(func(w *ecs.World) {
+		// Rate scaled by difficulty
+		p.spawnTimer++
+		if p.spawnTimer >= p.spawnInterval() {
+			p.spawnTimer = 0
+			count := w.CountEnemies()
+			if count < maxSpawnedEnemies {
+				if id := p.spawnEnemyOnRight(); id != 0 && count == 0 {
+					p.wave++
+					w.Events.Emit(ecs.Event{Type: ecs.EventWaveStarted, Entity: id, Amount: p.wave})
+				}
+			}
+		}
+	}, ecs.PhaseFrameEnd)
+
+	for _, s := range p.systems {
+		w.AddSystemPriority(s.fn, s.phase, s.priority)
+	}
+}
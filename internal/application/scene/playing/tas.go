@@ -0,0 +1,329 @@
+package playing
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/application/state"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// TAS editor tuning
+const (
+	tasKeyframeFrames = 60 // frames between the snapshots edits re-simulate from
+	tasTimelineFrames = 61 // frames shown around the current one
+	tasCellW          = 4
+	tasCellH          = 5
+)
+
+// TAS timeline colors
+var (
+	colorTASBG     = color.RGBA{0, 0, 0, 180}
+	colorTASOn     = color.RGBA{120, 220, 255, 255}
+	colorTASSelect = color.RGBA{90, 90, 40, 255}
+	colorTASCursor = color.RGBA{255, 255, 255, 255}
+)
+
+// tasKeys are the keys that toggle an input on the selected frames
+var tasKeys = []struct {
+	key   ebiten.Key
+	field replay.Field
+}{
+	{ebiten.KeyA, replay.FieldLeft},
+	{ebiten.KeyD, replay.FieldRight},
+	{ebiten.KeyW, replay.FieldUp},
+	{ebiten.KeyS, replay.FieldDown},
+	{ebiten.KeyJ, replay.FieldJump},
+	{ebiten.KeyK, replay.FieldDash},
+	{ebiten.KeyB, replay.FieldBlock},
+	{ebiten.KeyC, replay.FieldAttack},
+}
+
+// tasSession is a replay opened in the TAS editor. The world shows the
+// state after the replayer's current frame count of inputs.
+type tasSession struct {
+	data      replay.ReplayData
+	path      string               // where F5 saves ("" = a new file)
+	keyframes map[int]*tasKeyframe // state before each keyframe's input
+	mark      int                  // selection start (-1 = the current frame only)
+	status    string               // result of the last save
+}
+
+// tasKeyframe is the state an edit re-simulates from: the world (with its
+// RNG) and the scene state the simulation reads outside of it
+type tasKeyframe struct {
+	world         *ecs.Snapshot
+	spawnTimer    int
+	nextEnemyID   ecs.EntityID
+	wave          int
+	firedTriggers map[int]bool
+	peek          cameraPeek
+	arrowSelect   ui.ArrowSelect
+}
+
+// tasKeyframe captures the current frame's state
+func (p *Playing) tasKeyframe() *tasKeyframe {
+	return &tasKeyframe{
+		world:         p.world.Snapshot(),
+		spawnTimer:    p.spawnTimer,
+		nextEnemyID:   p.nextEnemyID,
+		wave:          p.wave,
+		firedTriggers: maps.Clone(p.firedTriggers),
+		peek:          p.peek,
+		arrowSelect:   *p.arrowSelectUI,
+	}
+}
+
+// restoreTASKeyframe puts the world and the scene back to a keyframe
+func (p *Playing) restoreTASKeyframe(k *tasKeyframe) {
+	p.restoreSnapshot(k.world)
+	p.spawnTimer = k.spawnTimer
+	p.nextEnemyID = k.nextEnemyID
+	p.wave = k.wave
+	p.firedTriggers = maps.Clone(k.firedTriggers)
+	p.peek = k.peek
+	*p.arrowSelectUI = k.arrowSelect
+}
+
+// selection returns the edited frames: from the mark to the current frame
+// (clamped to the replay)
+func (t *tasSession) selection(frame int) (lo, hi int) {
+	frame = min(frame, len(t.data.Frames)-1)
+	if t.mark < 0 {
+		return frame, frame
+	}
+	return min(t.mark, frame), max(t.mark, frame)
+}
+
+// EnterTAS opens a replay in the TAS editor: the stage restarts with its
+// seed and the frames can be scrubbed and edited. Keyframes carry the RNG,
+// so a re-simulated edit plays out as a playback from the start would.
+// path is where the edited replay is saved.
+func (p *Playing) EnterTAS(data replay.ReplayData, path string) {
+	data.Frames = slices.Clone(data.Frames)
+	p.useReplaySettings(data)
+	p.restartWithSeed(data.Seed)
+	p.recorder = nil
+	p.tas = &tasSession{
+		data:      data,
+		path:      path,
+		keyframes: map[int]*tasKeyframe{0: p.tasKeyframe()},
+		mark:      -1,
+	}
+	p.replayer = replay.NewReplayer(p.tas.data)
+	p.state = state.StateTAS
+}
+
+// enterTASFromRun opens the run recorded so far in the TAS editor at its
+// last frame (debug mode)
+func (p *Playing) enterTASFromRun() {
+	if !p.debugEnabled || p.recorder == nil || p.recorder.FrameCount() == 0 {
+		return
+	}
+	p.recordRunSettings()
+	data := p.recorder.GetData()
+	p.EnterTAS(data, p.recordFilename)
+	p.tasSimulate(len(data.Frames))
+}
+
+// updateTAS handles the editor: Left/Right step a frame (10 with Shift),
+// Home/End jump to the ends, Space marks a selection, the input keys
+// toggle inputs on it, I/Delete insert/delete frames, F5 saves and Enter
+// (or ESC, F2) resumes play from the shown frame
+func (p *Playing) updateTAS() {
+	t := p.tas
+	frame := p.replayer.CurrentFrame()
+	step := 1
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		step = rewindFastStep
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyEscape),
+		inpututil.IsKeyJustPressed(ebiten.KeyF2):
+		p.exitTAS()
+		return
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft), inpututil.IsKeyJustPressed(ebiten.KeyComma):
+		p.tasSeek(frame - step)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight), inpututil.IsKeyJustPressed(ebiten.KeyPeriod):
+		p.tasSeek(frame + step)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		p.tasSeek(0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		p.tasSeek(len(t.data.Frames))
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		if t.mark >= 0 {
+			t.mark = -1
+		} else if len(t.data.Frames) > 0 {
+			t.mark = min(frame, len(t.data.Frames)-1)
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyI):
+		p.tasEdit(func(lo, _ int) { t.data.Insert(lo) })
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete), inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		p.tasEdit(func(lo, hi int) {
+			for i := hi; i >= lo; i-- {
+				t.data.Delete(i)
+			}
+			t.mark = -1
+		})
+	case inpututil.IsKeyJustPressed(ebiten.KeyF5):
+		p.saveTAS()
+	}
+
+	for _, k := range tasKeys {
+		if inpututil.IsKeyJustPressed(k.key) {
+			p.tasEdit(func(lo, hi int) { t.data.Toggle(lo, hi, k.field) })
+		}
+	}
+}
+
+// tasSeek shows frame (clamped to the replay), going back through the
+// nearest keyframe
+func (p *Playing) tasSeek(frame int) {
+	frame = max(0, min(frame, len(p.tas.data.Frames)))
+	if frame < p.replayer.CurrentFrame() {
+		p.tasRestore(frame)
+	}
+	p.tasSimulate(frame)
+}
+
+// tasRestore puts the world back to the last keyframe at or before frame
+func (p *Playing) tasRestore(frame int) {
+	t := p.tas
+	k := frame / tasKeyframeFrames * tasKeyframeFrames
+	for k > 0 && t.keyframes[k] == nil {
+		k -= tasKeyframeFrames
+	}
+	p.restoreTASKeyframe(t.keyframes[k])
+	p.replayer = replay.NewReplayer(t.data)
+	p.replayer.Seek(k)
+}
+
+// tasSimulate plays the replay forward to frame, snapshotting keyframes on
+// the way. The frames' events are dropped: the editor shows the world, not
+// its effects.
+func (p *Playing) tasSimulate(frame int) {
+	t := p.tas
+	frame = min(frame, len(t.data.Frames))
+	for p.replayer.CurrentFrame() < frame {
+		if f := p.replayer.CurrentFrame(); f%tasKeyframeFrames == 0 && t.keyframes[f] == nil {
+			t.keyframes[f] = p.tasKeyframe()
+		}
+		p.stepPlaying(inputState{}, ui.ArrowSelectInput{})
+		p.world.Events.Drain()
+		p.state = state.StateTAS
+	}
+}
+
+// tasEdit applies an edit to the selected frames and re-simulates from the
+// nearest keyframe before them back to the shown frame
+func (p *Playing) tasEdit(edit func(lo, hi int)) {
+	t := p.tas
+	if len(t.data.Frames) == 0 {
+		return
+	}
+	frame := p.replayer.CurrentFrame()
+	lo, hi := t.selection(frame)
+	edit(lo, hi)
+	for k := range t.keyframes {
+		if k > lo {
+			delete(t.keyframes, k)
+		}
+	}
+	p.tasRestore(lo)
+	p.tasSimulate(frame)
+}
+
+// exitTAS resumes play from the shown frame. When recording, the edited
+// frames up to it become the start of the recording.
+func (p *Playing) exitTAS() {
+	frame := p.replayer.CurrentFrame()
+	if p.recordFilename != "" || p.replayDir != "" {
+		p.recorder = resumeRecorder(p.tas.data, frame)
+	}
+	p.tas = nil
+	p.replayer = nil
+	p.state = state.StatePlaying
+}
+
+// saveTAS writes the edited replay to the session's file, else a new file
+// in the replay directory
+func (p *Playing) saveTAS() {
+	t := p.tas
+	if t.path == "" {
+		if p.replayDir != "" {
+			if err := os.MkdirAll(p.replayDir, 0o755); err != nil {
+				logging.Replay.Errorf("Failed to create replay dir: %v", err)
+			}
+		}
+		t.path = filepath.Join(p.replayDir, "tas_"+GenerateFilename())
+	}
+	if p.versionHash != "" {
+		t.data.VersionHash = p.versionHash
+	}
+	if err := t.data.Save(t.path); err != nil {
+		logging.Replay.Errorf("Failed to save TAS replay: %v", err)
+		t.status = "save failed"
+		return
+	}
+	logging.Replay.Infof("TAS replay saved: %s (%d frames)", t.path, len(t.data.Frames))
+	t.status = "saved " + filepath.Base(t.path)
+}
+
+// drawTASOverlay shows the frame, the controls and the input timeline
+// around the shown frame
+func (p *Playing) drawTASOverlay(screen *ebiten.Image) {
+	t := p.tas
+	frame := p.replayer.CurrentFrame()
+	n := len(t.data.Frames)
+
+	ui.FillRect(screen, 0, 0, float64(p.screenW), 30, colorPhotoHint)
+	info := fmt.Sprintf("TAS %d/%d (%.2fs)", frame, n, fixedpoint.FramesToSeconds(frame))
+	lo, hi := t.selection(frame)
+	if t.mark >= 0 {
+		info += fmt.Sprintf(" sel %d-%d", lo, hi)
+	}
+	if t.status != "" {
+		info += "  " + t.status
+	}
+	ui.Draw(screen, info, 4, 2, ui.StyleDefault)
+	ui.Draw(screen, "</>: step Spc: select ADWSJKBC: toggle I/Del F5: save", 4, 16, ui.StyleDefault)
+
+	rows := int(replay.FieldCount)
+	half := tasTimelineFrames / 2
+	left := float64(p.screenW/2 - half*tasCellW)
+	top := float64(p.screenH - rows*tasCellH - 4)
+	ui.FillRect(screen, left-2, top-2, tasTimelineFrames*tasCellW+4, float64(rows*tasCellH+4), colorTASBG)
+
+	held := t.data.JumpHeld()
+	for col := range tasTimelineFrames {
+		f := frame - half + col
+		if f < 0 || f >= n {
+			continue
+		}
+		x := left + float64(col*tasCellW)
+		if t.mark >= 0 && f >= lo && f <= hi {
+			ui.FillRect(screen, x, top, tasCellW, float64(rows*tasCellH), colorTASSelect)
+		}
+		for field := range replay.FieldCount {
+			on := held[f]
+			if field != replay.FieldJump {
+				on = t.data.Has(f, field)
+			}
+			if on {
+				ui.FillRect(screen, x, top+float64(int(field)*tasCellH), tasCellW-1, tasCellH-1, colorTASOn)
+			}
+		}
+	}
+	ui.StrokeRect(screen, left+float64(half*tasCellW), top, tasCellW, float64(rows*tasCellH), colorTASCursor)
+}
@@ -0,0 +1,53 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+var (
+	colorTelegraph     = color.RGBA{255, 170, 40, 255}
+	colorTelegraphLine = color.RGBA{255, 60, 60, 160}
+	colorSuperArmor    = color.RGBA{200, 220, 255, 110}
+)
+
+// telegraphColor flashes an enemy winding up an attack in the warn color
+func telegraphColor(ai ecs.AI, c, warn color.RGBA) color.RGBA {
+	if ai.Telegraph != ecs.TelegraphNone && ai.WindupTimer%8 < 4 {
+		return warn
+	}
+	return c
+}
+
+// drawTelegraphLine draws a warning line for a wound-up attack: along the
+// shot's path, or toward the player for a charge.
+// x, y is the enemy's screen-space center.
+func (p *Playing) drawTelegraphLine(screen *ebiten.Image, id ecs.EntityID, x, y float64, camX, camY int) {
+	ai := p.world.AI[id]
+	switch ai.Telegraph {
+	case ecs.TelegraphShot:
+		length := float64(ai.AttackRange)
+		if !p.world.Facing[id].Right {
+			length = -length
+		}
+		ebitenutil.DrawLine(screen, x, y, x+length, y, colorTelegraphLine)
+	case ecs.TelegraphCharge:
+		playerPos := p.drawPosition(p.world.PlayerID)
+		px := float64(playerPos.PixelX() - camX + 8)
+		py := float64(playerPos.PixelY() - camY + 8)
+		ebitenutil.DrawLine(screen, x, y, px, py, colorTelegraphLine)
+	}
+}
+
+// drawSuperArmor frames an enemy that hits won't interrupt with a faint
+// inner outline. x, y, w, h is its drawn body.
+func drawSuperArmor(screen *ebiten.Image, ai ecs.AI, x, y, w, h float64) {
+	if !ai.Armored() {
+		return
+	}
+	ui.StrokeRect(screen, x+1, y+1, w-2, h-2, colorSuperArmor)
+}
@@ -0,0 +1,143 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/domain/entity"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+// tileChunkTiles is the width and height of a cached tile chunk, in tiles
+const tileChunkTiles = 16
+
+// tileCache holds the stage's static tiles pre-rendered into chunk images,
+// so drawing the stage is one DrawImage per visible chunk. Crumbling tiles
+// shake and fall, so they are drawn every frame instead. The chunks are
+// rebuilt (lazily, when visible) after the stage's tiles or the
+// high-contrast setting change. A second cache holds the foreground layer.
+type tileCache struct {
+	stage      *entity.Stage
+	foreground bool // caches the foreground layer instead of the main one
+	revision   int
+	outline    bool // hazards are outlined (high contrast)
+	chunks     map[[2]int]*ebiten.Image
+	crumbles   []ecs.TileCoord
+}
+
+// tileColor returns the color a tile is drawn in (nil = not drawn)
+func tileColor(t entity.TileType) color.Color {
+	switch t {
+	case entity.TileWall:
+		return colorWall
+	case entity.TileSpike:
+		return colorSpike
+	case entity.TileLava:
+		return colorLava
+	case entity.TileIce:
+		return colorIce
+	case entity.TileSticky:
+		return colorSticky
+	case entity.TileCrumble:
+		return colorCrumble
+	case entity.TileDoor:
+		return colorDoor
+	case entity.TileDecoration:
+		return colorDecoration
+	}
+	return nil
+}
+
+// sync drops the chunks when the stage, its tiles or the outline changed
+func (c *tileCache) sync(stage *entity.Stage, outline bool) {
+	if c.stage == stage && c.revision == stage.Revision() && c.outline == outline && c.chunks != nil {
+		return
+	}
+	for _, img := range c.chunks {
+		img.Deallocate()
+	}
+	if c.stage != stage && !c.foreground {
+		c.crumbles = c.crumbles[:0]
+		for ty, row := range stage.Tiles {
+			for tx, tile := range row {
+				if tile.Type == entity.TileCrumble {
+					c.crumbles = append(c.crumbles, ecs.TileCoord{X: tx, Y: ty})
+				}
+			}
+		}
+	}
+	c.stage, c.revision, c.outline = stage, stage.Revision(), outline
+	c.chunks = make(map[[2]int]*ebiten.Image)
+}
+
+// tile returns the cached layer's tile at tile coordinates
+func (c *tileCache) tile(tx, ty int) entity.Tile {
+	if c.foreground {
+		return c.stage.GetForegroundTile(tx, ty)
+	}
+	return c.stage.GetTile(tx, ty)
+}
+
+// chunk returns the image of chunk (cx, cy), rendering it on first use
+func (c *tileCache) chunk(cx, cy, tileSize int) *ebiten.Image {
+	key := [2]int{cx, cy}
+	if img, ok := c.chunks[key]; ok {
+		return img
+	}
+	size := tileChunkTiles * tileSize
+	img := ebiten.NewImage(size, size)
+	for ty := cy * tileChunkTiles; ty < min((cy+1)*tileChunkTiles, c.stage.Height); ty++ {
+		for tx := cx * tileChunkTiles; tx < min((cx+1)*tileChunkTiles, c.stage.Width); tx++ {
+			tile := c.tile(tx, ty)
+			clr := tileColor(tile.Type)
+			if clr == nil || (tile.Type == entity.TileCrumble && !c.foreground) || (tile.Type == entity.TileDoor && !tile.Sealed) {
+				continue
+			}
+			x := float64((tx - cx*tileChunkTiles) * tileSize)
+			y := float64((ty - cy*tileChunkTiles) * tileSize)
+			ui.FillRect(img, x, y, float64(tileSize), float64(tileSize), clr)
+			if c.outline && tile.Damage > 0 {
+				ui.StrokeRect(img, x, y, float64(tileSize), float64(tileSize), colorOutline)
+			}
+		}
+	}
+	c.chunks[key] = img
+	return img
+}
+
+// drawTiles draws the visible chunks of static tiles, then the crumbling
+// tiles in view
+func (p *Playing) drawTiles(screen *ebiten.Image, camX, camY int) {
+	p.tiles.sync(p.stage, p.highContrast())
+	p.drawChunks(screen, &p.tiles, camX, camY, 1)
+
+	size := float64(p.tileSize)
+	for _, coord := range p.tiles.crumbles {
+		x := float64(coord.X*p.tileSize - camX)
+		y := float64(coord.Y*p.tileSize - camY)
+		if x <= -size || y <= -size || x >= float64(p.screenW) || y >= float64(p.screenH) {
+			continue
+		}
+		dx, dy, visible := p.crumbleOffset(coord.X, coord.Y)
+		if !visible {
+			continue
+		}
+		ui.FillRect(screen, x+dx, y+dy, size, size, colorCrumble)
+	}
+}
+
+// drawChunks draws the visible chunks of a tile cache at an opacity
+func (p *Playing) drawChunks(screen *ebiten.Image, c *tileCache, camX, camY int, alpha float64) {
+	chunkSize := tileChunkTiles * p.tileSize
+	lastX := min(camX+p.screenW, p.stage.Width*p.tileSize-1)
+	lastY := min(camY+p.screenH, p.stage.Height*p.tileSize-1)
+	for cy := max(camY, 0) / chunkSize; cy <= lastY/chunkSize; cy++ {
+		for cx := max(camX, 0) / chunkSize; cx <= lastX/chunkSize; cx++ {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64(cx*chunkSize-camX), float64(cy*chunkSize-camY))
+			op.ColorScale.ScaleAlpha(float32(alpha))
+			screen.DrawImage(c.chunk(cx, cy, p.tileSize), op)
+		}
+	}
+}
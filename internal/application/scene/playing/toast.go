@@ -0,0 +1,40 @@
+package playing
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+)
+
+const (
+	toastMargin    = 4    // gap between the toasts and the screen's top edge
+	lowHealthRatio = 0.25 // a hit leaving less health than this warns the player
+)
+
+// notifyEvent raises the HUD toast of a gameplay event, if it has one. The
+// systems only emit events; what is worth a toast is decided here.
+func (p *Playing) notifyEvent(ev ecs.Event) {
+	switch ev.Type {
+	case ecs.EventHealthCollected:
+		p.toasts.Notify(ui.IconPickup, i18n.Tf("toast.health", ev.Amount), 0)
+	case ecs.EventAmmoCollected:
+		p.toasts.Notify(ui.IconPickup, i18n.Tf("toast.ammo", ev.Amount), 0)
+	case ecs.EventTreasureCollected:
+		p.toasts.Notify(ui.IconPickup, i18n.Tf("toast.treasure", ev.Amount), 0)
+	case ecs.EventWaveStarted:
+		p.toasts.Notify(ui.IconWave, i18n.Tf("toast.wave", ev.Amount), 0)
+	case ecs.EventPlayerDamaged:
+		// Warn once when a hit crosses the threshold, not on every hit below it
+		health := p.world.Health[p.world.PlayerID]
+		limit := int(float64(health.Max) * lowHealthRatio)
+		if health.Current > 0 && health.Current <= limit && health.Current+ev.Amount > limit {
+			p.toasts.Notify(ui.IconWarning, i18n.T("toast.lowHealth"), 0)
+		}
+	}
+}
+
+// drawToasts draws the toasts sliding in from the top-right corner
+func (p *Playing) drawToasts(screen *ebiten.Image) {
+	p.toasts.Draw(screen, float64(p.screenW), toastMargin)
+}
@@ -0,0 +1,184 @@
+package playing
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/application/stats"
+	"github.com/younwookim/mg/internal/application/tower"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/domain/entity"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// towerRun is an endless tower run: generated floors one after another,
+// each cleared at its exit, until the player dies
+type towerRun struct {
+	cfg   *config.TowerConfig
+	base  *config.GameConfig // config the floor modifiers are applied to
+	seed  int64
+	floor tower.Floor
+	total stats.Run // the floors cleared so far
+	daily string    // leaderboard ID of a daily run ("" = a run on a random seed)
+}
+
+// StartTower leaves the stage for a new endless tower run, with cfg (the
+// difficulty's overrides applied) as the config of every floor. Tower runs
+// are not recorded.
+func (p *Playing) StartTower(tcfg *config.TowerConfig, cfg *config.GameConfig) {
+	p.tower = &towerRun{cfg: tcfg, base: cfg}
+	p.firedTriggers = map[int]bool{}
+	p.startTowerRun(time.Now().UnixNano())
+}
+
+// StartDaily leaves the stage for the daily run of day: a tower run on the
+// day's seed, played over on the same seed on restart. Daily runs are
+// always recorded; the replay is saved with the others when there is a
+// replay directory and can be exported from the game over screen.
+func (p *Playing) StartDaily(tcfg *config.TowerConfig, cfg *config.GameConfig, day time.Time) {
+	p.tower = &towerRun{cfg: tcfg, base: cfg, daily: tower.DailyID(day)}
+	p.firedTriggers = map[int]bool{}
+	p.startTowerRun(tower.DailySeed(day))
+}
+
+// PlayTowerReplay starts a tower run on the replay's seed and drives the
+// player with its recorded input, floor after floor
+func (p *Playing) PlayTowerReplay(tcfg *config.TowerConfig, cfg *config.GameConfig, data replay.ReplayData) {
+	p.tower = &towerRun{cfg: tcfg, base: cfg}
+	p.useReplaySettings(data)
+	p.startTowerRun(data.Seed)
+	p.replayer = replay.NewReplayer(data)
+}
+
+// InTower reports whether the scene is on a tower run
+func (p *Playing) InTower() bool {
+	return p.tower != nil
+}
+
+// startTowerRun starts the tower over from the first floor. The seed picks
+// every floor of the run.
+func (p *Playing) startTowerRun(seed int64) {
+	p.tower.seed = seed
+	p.tower.total = stats.Run{}
+	p.replayer = nil
+	p.recorder = nil
+	if p.tower.daily != "" {
+		p.recorder = NewRecorder(seed, tower.StageID)
+		p.replayFile = ""
+	}
+	p.enterFloor(1)
+}
+
+// enterFloor generates floor n of the run and starts it. Past the first
+// floor the player keeps their health, gold, ammo and upgrades. A replay
+// being played back and the recording go on into the next floor.
+func (p *Playing) enterFloor(n int) {
+	t := p.tower
+	id := p.world.PlayerID
+	player, health, dash := p.world.PlayerData[id], p.world.Health[id], p.world.Dash[id]
+	replayer := p.replayer
+
+	t.floor = tower.Generate(t.cfg, t.seed, n)
+	p.useConfig(t.floor.Apply(t.base, t.cfg), t.floor.Stage)
+	p.stage = entity.LoadStage(t.floor.Stage)
+	p.tileSize = p.stage.TileSize
+	p.applyDifficulty()
+	p.restartWithSeed(t.seed + int64(n))
+	p.replayer = replayer
+
+	if n > 1 {
+		id = p.world.PlayerID
+		data := p.world.PlayerData[id]
+		data.Gold = player.Gold
+		data.Ammo = player.Ammo
+		data.MagnetLevel = player.MagnetLevel
+		data.EnergyLevel = player.EnergyLevel
+		data.SummonUnlocked = player.SummonUnlocked
+		p.world.PlayerData[id] = data
+		h := p.world.Health[id]
+		h.Current = min(health.Current, h.Max)
+		p.world.Health[id] = h
+		d := p.world.Dash[id]
+		d.Level = dash.Level
+		p.world.Dash[id] = d
+	}
+
+	text := i18n.Tf("tower.floor", n)
+	for _, m := range t.floor.Modifiers {
+		text += "\n" + i18n.T("tower.mod."+string(m))
+	}
+	p.toasts.Notify(ui.IconWave, text, 0)
+}
+
+// clearFloor adds the cleared floor to the run; the next one starts after
+// the celebration
+func (p *Playing) clearFloor() {
+	p.tower.total.Add(p.stats)
+}
+
+// towerInput applies the floor's modifiers to the player's input
+func (p *Playing) towerInput(input inputState) inputState {
+	if p.tower != nil && p.tower.floor.Has(tower.NoDash) {
+		input.Dash = false
+	}
+	return input
+}
+
+// finishTowerRun folds the floor the player died on into the run's stats
+// and scores the run
+func (p *Playing) finishTowerRun() int {
+	run := p.tower.total
+	run.Add(p.stats)
+	p.stats = run
+	return tower.Points(p.tower.floor.Number-1, run)
+}
+
+// towerSummary is the game over line of a tower run
+func (p *Playing) towerSummary() string {
+	return i18n.Tf("tower.reached", p.tower.floor.Number, p.stats.Kills, p.stats.Seconds())
+}
+
+// inDaily reports whether the scene is on a daily run
+func (p *Playing) inDaily() bool {
+	return p.tower != nil && p.tower.daily != ""
+}
+
+// boardID returns the leaderboard the run's score goes to: the day's board
+// on a daily run, else the stage's
+func (p *Playing) boardID() string {
+	if p.inDaily() {
+		return p.tower.daily
+	}
+	return p.stageCfg.ID
+}
+
+// updateDailyExport exports the daily run's replay on the game over screen
+// (E) to the working directory, for sharing
+func (p *Playing) updateDailyExport() {
+	if !p.inDaily() || p.recorder == nil || !inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		return
+	}
+	filename := dailyReplayFile(p.tower.daily, p.lastInitials)
+	p.recorder.SetMetadata(p.lastInitials, p.stats.Gold, p.versionHash)
+	p.recordRunSettings()
+	if err := p.recorder.Save(filename); err != nil {
+		logging.Replay.Errorf("Failed to export daily replay: %v", err)
+		p.toasts.Notify(ui.IconWarning, i18n.T("daily.exportFailed"), 0)
+		return
+	}
+	logging.Replay.Infof("Daily replay exported: %s", filename)
+	p.toasts.Notify(ui.IconInfo, i18n.Tf("daily.exported", filename), 0)
+}
+
+// dailyReplayFile names an exported daily replay after the day and the
+// player's initials
+func dailyReplayFile(daily, initials string) string {
+	if initials == "" {
+		return daily + ".json"
+	}
+	return daily + "_" + initials + ".json"
+}
@@ -0,0 +1,181 @@
+package playing
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Tuning panel colors
+var (
+	colorTuningTrack = color.RGBA{90, 90, 110, 255}
+	colorTuningFill  = color.RGBA{120, 220, 255, 255}
+	colorTuningKnob  = color.RGBA{255, 255, 255, 255}
+)
+
+// Tuning panel layout (screen pixels)
+const (
+	tuningX      = 4
+	tuningY      = 40
+	tuningW      = 150
+	tuningRowH   = 20
+	tuningTrackY = 12 // track offset within a row, below the label
+	tuningTrackH = 4
+	tuningPad    = 6 // horizontal padding of the tracks
+)
+
+// tuningParam is a physics.json value exposed as a slider
+type tuningParam struct {
+	label    string
+	min, max float64
+	step     float64                                // values snap to multiples of step
+	field    func(c *config.PhysicsConfig) *float64 // the value in config units
+	format   func(v float64) string
+}
+
+// tuningParams are the sliders of the tuning panel, in display order
+var tuningParams = []tuningParam{
+	{"gravity", 100, 3000, 10, func(c *config.PhysicsConfig) *float64 { return &c.Physics.Gravity }, formatPixelsPerSec2},
+	{"max speed", 20, 400, 5, func(c *config.PhysicsConfig) *float64 { return &c.Movement.MaxSpeed }, formatPixelsPerSec},
+	{"jump force", 50, 800, 5, func(c *config.PhysicsConfig) *float64 { return &c.Jump.Force }, formatPixelsPerSec},
+	{"dash speed", 50, 1000, 10, func(c *config.PhysicsConfig) *float64 { return &c.Dash.Speed }, formatPixelsPerSec},
+	{"coyote", 0, 0.5, 1.0 / 60, func(c *config.PhysicsConfig) *float64 { return &c.Jump.CoyoteTime }, formatFrames},
+}
+
+func formatPixelsPerSec(v float64) string  { return fmt.Sprintf("%.0f px/s", v) }
+func formatPixelsPerSec2(v float64) string { return fmt.Sprintf("%.0f px/s2", v) }
+func formatFrames(v float64) string        { return fmt.Sprintf("%d f", fixedpoint.SecondsToFrames(v)) }
+
+// updateTuning handles the physics tuning panel (debug mode only): F4
+// toggles it, dragging a slider changes the live physics, and F8 exports
+// the tuned values
+func (p *Playing) updateTuning() {
+	if !p.debugEnabled {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		p.tuningVisible = !p.tuningVisible
+		p.tuningDrag = -1
+	}
+	if !p.tuningVisible {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		p.exportTuning()
+	}
+
+	mx, my := p.viewport.ToLogical(ebiten.CursorPosition())
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		p.tuningDrag = tuningRowAt(mx, my)
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		p.tuningDrag = -1
+	}
+	if p.tuningDrag >= 0 {
+		p.setTuningFromSlider(p.tuningDrag, mx)
+	}
+}
+
+// tuningPanelH returns the panel height
+func tuningPanelH() int {
+	return 14 + len(tuningParams)*tuningRowH + 12
+}
+
+// tuningCapturesMouse reports whether the mouse is over the tuning panel (or
+// dragging one of its sliders), so clicks don't fire arrows
+func (p *Playing) tuningCapturesMouse(mx, my int) bool {
+	if !p.tuningVisible {
+		return false
+	}
+	return p.tuningDrag >= 0 ||
+		(mx >= tuningX && mx < tuningX+tuningW && my >= tuningY && my < tuningY+tuningPanelH())
+}
+
+// tuningRowAt returns the slider row under a screen position (-1 = none)
+func tuningRowAt(mx, my int) int {
+	if mx < tuningX || mx >= tuningX+tuningW {
+		return -1
+	}
+	row := (my - tuningY - 14) / tuningRowH
+	if my < tuningY+14 || row >= len(tuningParams) {
+		return -1
+	}
+	return row
+}
+
+// setTuningFromSlider sets a parameter from a mouse x over its track
+func (p *Playing) setTuningFromSlider(i, mx int) {
+	trackX := float64(tuningX + tuningPad)
+	trackW := float64(tuningW - 2*tuningPad)
+	t := max(0, min(1, (float64(mx)-trackX)/trackW))
+	param := tuningParams[i]
+	p.setTuning(i, param.min+t*(param.max-param.min))
+}
+
+// setTuning sets a parameter (snapped to its step and clamped to its range)
+// and rebuilds the live ECS physics from the config
+func (p *Playing) setTuning(i int, v float64) {
+	param := tuningParams[i]
+	v = math.Round(v/param.step) * param.step
+	*param.field(p.config.Physics) = max(param.min, min(param.max, v))
+	p.physicsCfg = buildPhysicsConfig(p.config, p.character)
+}
+
+// tuningOverride returns the tuned values as a config override, the format
+// of the overrides/ files (see config.Override)
+func (p *Playing) tuningOverride() config.Override {
+	c := p.config.Physics
+	return config.Override{"physics": map[string]any{
+		"physics":  map[string]any{"gravity": c.Physics.Gravity},
+		"movement": map[string]any{"maxSpeed": c.Movement.MaxSpeed},
+		"jump":     map[string]any{"force": c.Jump.Force, "coyoteTime": c.Jump.CoyoteTime},
+		"dash":     map[string]any{"speed": c.Dash.Speed},
+	}}
+}
+
+// exportTuning saves the tuned values as an override file to copy into
+// configs/overrides/ (or merge into physics.json)
+func (p *Playing) exportTuning() {
+	data, err := json.MarshalIndent(p.tuningOverride(), "", "  ")
+	if err != nil {
+		logging.Scene.Errorf("Failed to encode tuning: %v", err)
+		return
+	}
+	filename := fmt.Sprintf("tuning_%s.json", time.Now().Format("20060102_150405"))
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		logging.Scene.Errorf("Failed to save tuning: %v", err)
+		return
+	}
+	logging.Scene.Infof("Tuning saved: %s", filename)
+}
+
+// drawTuningPanel draws the sliders with their current values
+func (p *Playing) drawTuningPanel(screen *ebiten.Image) {
+	ui.FillRect(screen, tuningX, tuningY, tuningW, float64(tuningPanelH()), colorDebugPanel)
+	ui.Draw(screen, "TUNING (F4)", tuningX+4, tuningY, ui.StyleDefault)
+
+	trackW := float64(tuningW - 2*tuningPad)
+	for i, param := range tuningParams {
+		y := tuningY + 14 + i*tuningRowH
+		v := *param.field(p.config.Physics)
+		ui.Draw(screen, fmt.Sprintf("%-10s %s", param.label, param.format(v)), tuningX+4, float64(y), ui.StyleDefault)
+
+		trackX := float64(tuningX + tuningPad)
+		trackY := float64(y + tuningTrackY)
+		t := (v - param.min) / (param.max - param.min)
+		ui.FillRect(screen, trackX, trackY, trackW, tuningTrackH, colorTuningTrack)
+		ui.FillRect(screen, trackX, trackY, trackW*t, tuningTrackH, colorTuningFill)
+		ui.FillRect(screen, trackX+trackW*t-1, trackY-2, 3, tuningTrackH+4, colorTuningKnob)
+	}
+	ui.Draw(screen, "F8 export", tuningX+4, float64(tuningY+14+len(tuningParams)*tuningRowH), ui.StyleDefault)
+}
@@ -0,0 +1,69 @@
+package playing
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+// Fog gradient rendering
+const (
+	fogBand     = 8   // pixels per gradient band
+	fogMaxAlpha = 160 // opacity at the bottom of the screen
+)
+
+var colorFog = color.RGBA{170, 175, 185, 255}
+
+// buildWeather converts the stage's weather config.
+// Unknown weather types are clear.
+func buildWeather(cfg *config.WeatherConfig) ecs.Weather {
+	if cfg == nil {
+		return ecs.Weather{}
+	}
+	kind, ok := ecs.ParseWeatherKind(cfg.Type)
+	if !ok {
+		logging.Config.Warnf("Unknown stage weather: %s", cfg.Type)
+		return ecs.Weather{}
+	}
+	return ecs.Weather{
+		Kind:        kind,
+		Density:     cfg.Density,
+		Wind:        fixedpoint.ToIUPerSubstep(cfg.Wind) * ecs.SubstepsPerFrame,
+		FrictionPct: fixedpoint.ToPct(cfg.Friction),
+	}
+}
+
+// weatherDetectRange shortens an enemy's detect range in fog
+func (p *Playing) weatherDetectRange(detectRange int) int {
+	weather := p.stageCfg.Weather
+	if p.world.Weather.Kind != ecs.WeatherFog || weather.Visibility <= 0 {
+		return detectRange
+	}
+	return fixedpoint.MulPct(detectRange, fixedpoint.ToPct(weather.Visibility))
+}
+
+// drawFog draws the fog gradient, thickening toward the bottom of the screen
+func (p *Playing) drawFog(screen *ebiten.Image) {
+	if p.world.Weather.Kind != ecs.WeatherFog {
+		return
+	}
+	c := colorFog
+	if p.stageCfg.Weather.Color != "" {
+		c = parseHexColor(p.stageCfg.Weather.Color)
+	}
+	for y := 0; y < p.screenH; y += fogBand {
+		alpha := float64(fogMaxAlpha*y/p.screenH) / 255
+		band := color.RGBA{
+			uint8(float64(c.R) * alpha),
+			uint8(float64(c.G) * alpha),
+			uint8(float64(c.B) * alpha),
+			uint8(255 * alpha),
+		}
+		ui.FillRect(screen, 0, float64(y), float64(p.screenW), fogBand, band)
+	}
+}
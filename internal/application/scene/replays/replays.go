@@ -0,0 +1,243 @@
+// Package replays provides the replay browser: the runs saved in the
+// replay directory, sortable, with playback, delete and export.
+//
+// Replays recorded with other game content (see replay.VersionHash) are
+// marked; playing one asks for confirmation since it will likely desync,
+// and replays in an unknown format are refused.
+package replays
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/younwookim/mg/internal/application/i18n"
+	"github.com/younwookim/mg/internal/application/replay"
+	"github.com/younwookim/mg/internal/application/scene"
+	"github.com/younwookim/mg/internal/application/ui"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/logging"
+)
+
+const (
+	listTop    = 56
+	lineHeight = 14
+	rowWidth   = 300
+)
+
+var (
+	colorBG       = color.RGBA{16, 16, 32, 255}
+	colorSelected = color.RGBA{60, 60, 110, 255}
+	colorOutdated = color.RGBA{230, 160, 80, 255}
+)
+
+// confirmAction is the action waiting for a second key press
+type confirmAction int
+
+const (
+	confirmNone confirmAction = iota
+	confirmDelete
+	confirmPlay
+)
+
+// PlayFunc builds the scene that plays a replay and returns to back
+type PlayFunc func(data *replay.ReplayData, back scene.Scene) (scene.Scene, error)
+
+// Browser lists the saved replays
+type Browser struct {
+	dir, exportDir   string
+	versionHash      string
+	play             PlayFunc
+	screenW, screenH int
+	back             scene.Scene
+
+	entries []replay.Entry
+	sortKey replay.SortKey
+	cursor  int
+	top     int           // first visible row
+	confirm confirmAction // pressed once on the selected replay
+	status  string        // result of the last action
+}
+
+// New creates the replay browser for dir. Replays are checked against
+// versionHash, exports are copied to exportDir and ESC returns to back.
+func New(dir, exportDir, versionHash string, play PlayFunc, screenW, screenH int, back scene.Scene) *Browser {
+	return &Browser{
+		dir:         dir,
+		exportDir:   exportDir,
+		versionHash: versionHash,
+		play:        play,
+		screenW:     screenW,
+		screenH:     screenH,
+		back:        back,
+	}
+}
+
+// Update handles selection and the replay actions (implements scene.Scene)
+func (b *Browser) Update(_ float64) (scene.Scene, error) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return b.back, nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		b.sortKey = b.sortKey.Next()
+		replay.Sort(b.entries, b.sortKey)
+		b.confirm = confirmNone
+	}
+	if len(b.entries) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		b.move(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		b.move(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		return b.playSelected()
+	case inpututil.IsKeyJustPressed(ebiten.KeyE):
+		b.exportSelected()
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete), inpututil.IsKeyJustPressed(ebiten.KeyX):
+		b.deleteSelected()
+	}
+	return nil, nil
+}
+
+// move changes the selection and scrolls it into view
+func (b *Browser) move(d int) {
+	b.cursor = (b.cursor + d + len(b.entries)) % len(b.entries)
+	b.confirm = confirmNone
+	rows := b.rows()
+	if b.cursor < b.top {
+		b.top = b.cursor
+	} else if b.cursor >= b.top+rows {
+		b.top = b.cursor - rows + 1
+	}
+}
+
+// rows returns how many replays fit on screen
+func (b *Browser) rows() int {
+	return max(1, (b.screenH-listTop-40)/lineHeight)
+}
+
+// playSelected loads the selected replay and opens its playback. Replays
+// made with other content play only once confirmed.
+func (b *Browser) playSelected() (scene.Scene, error) {
+	switch b.entries[b.cursor].Data.Compatibility(b.versionHash) {
+	case replay.Incompatible:
+		b.status = i18n.T("replays.incompatible")
+		return nil, nil
+	case replay.Mismatched, replay.Unversioned:
+		if b.confirm != confirmPlay {
+			b.confirm = confirmPlay
+			b.status = i18n.T("replays.confirmOutdated")
+			return nil, nil
+		}
+	}
+	b.confirm = confirmNone
+
+	data, err := replay.LoadReplay(b.entries[b.cursor].Path)
+	if err != nil {
+		b.fail(err)
+		return nil, nil
+	}
+	next, err := b.play(data, b)
+	if err != nil {
+		b.fail(err)
+		return nil, nil
+	}
+	return next, nil
+}
+
+// exportSelected copies the selected replay to the export directory
+func (b *Browser) exportSelected() {
+	path, err := replay.Export(b.entries[b.cursor], b.exportDir)
+	if err != nil {
+		b.fail(err)
+		return
+	}
+	b.status = i18n.Tf("replays.exported", path)
+}
+
+// deleteSelected asks for confirmation, then deletes the selected replay
+func (b *Browser) deleteSelected() {
+	if b.confirm != confirmDelete {
+		b.confirm = confirmDelete
+		b.status = i18n.T("replays.confirmDelete")
+		return
+	}
+	b.confirm = confirmNone
+	if err := replay.Delete(b.entries[b.cursor]); err != nil {
+		b.fail(err)
+		return
+	}
+	b.entries = append(b.entries[:b.cursor], b.entries[b.cursor+1:]...)
+	b.cursor = min(b.cursor, max(0, len(b.entries)-1))
+	b.top = min(b.top, b.cursor)
+	b.status = i18n.T("replays.deleted")
+}
+
+// fail logs err and shows it in the status line
+func (b *Browser) fail(err error) {
+	logging.Replay.Errorf("Replay browser: %v", err)
+	b.status = err.Error()
+}
+
+// row formats one replay with fixed-width columns
+func row(e replay.Entry) string {
+	player := e.Data.Player
+	if player == "" {
+		player = "---"
+	}
+	seconds := fixedpoint.FramesToSeconds(e.Data.Duration)
+	return fmt.Sprintf("%s  %-8.8s %-3s %6.1fs %5dG", e.ModTime.Format("01-02 15:04"), e.Data.Stage, player, seconds, e.Data.Gold)
+}
+
+// Draw renders the list (implements scene.Scene)
+func (b *Browser) Draw(screen *ebiten.Image) {
+	screen.Fill(colorBG)
+	cx := float64(b.screenW / 2)
+
+	ui.Draw(screen, i18n.T("replays.title"), cx, 8, ui.StyleTitle)
+	ui.Draw(screen, i18n.Tf("replays.sort", i18n.T("replays.sort."+b.sortKey.String())), cx, 36, ui.StyleCenter)
+
+	if len(b.entries) == 0 {
+		ui.Draw(screen, i18n.T("replays.empty"), cx, listTop, ui.StyleCenter)
+	}
+	end := min(len(b.entries), b.top+b.rows())
+	for i := b.top; i < end; i++ {
+		y := float64(listTop + (i-b.top)*lineHeight)
+		if i == b.cursor {
+			ui.FillRect(screen, cx-rowWidth/2, y-3, rowWidth, lineHeight, colorSelected)
+		}
+		e := b.entries[i]
+		style := ui.StyleCenter
+		if e.Data.Compatibility(b.versionHash) != replay.Compatible {
+			style.Color = colorOutdated
+		}
+		ui.Draw(screen, row(e), cx, y, style)
+	}
+
+	if b.status != "" {
+		ui.Draw(screen, b.status, cx, float64(b.screenH-34), ui.StyleCenter)
+	}
+	ui.Draw(screen, i18n.T("replays.help"), cx, float64(b.screenH-18), ui.StyleCenter)
+}
+
+// OnEnter reloads the replay directory (implements scene.Scene)
+func (b *Browser) OnEnter() {
+	entries, err := replay.List(b.dir)
+	if err != nil {
+		b.fail(err)
+	}
+	replay.Sort(entries, b.sortKey)
+	b.entries = entries
+	b.cursor = min(b.cursor, max(0, len(entries)-1))
+	b.top = min(b.top, b.cursor)
+	b.confirm = confirmNone
+}
+
+// OnExit is called when leaving this scene
+func (b *Browser) OnExit() {
+	b.status = ""
+}
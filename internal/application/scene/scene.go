@@ -12,7 +12,7 @@ import "github.com/hajimehoshi/ebiten/v2"
 // Scene transitions are handled by returning a new Scene from Update.
 type Scene interface {
 	// Update updates the scene state.
-	// dt is the delta time in seconds (typically 1/60).
+	// dt is the length of this tick in seconds (1/TPS, e.g. 1/60).
 	// Returns the next scene if a transition is needed, nil to stay on current scene.
 	// Returns an error to terminate the game.
 	Update(dt float64) (next Scene, err error)
@@ -10,6 +10,11 @@ const (
 	StatePaused
 	StateGameOver
 	StateStageClear
+	StateDialogue
+	StateShop
+	StatePhoto
+	StateRewind
+	StateTAS
 )
 
 // String returns the string representation of the game state
@@ -27,6 +32,16 @@ func (s GameState) String() string {
 		return "GameOver"
 	case StateStageClear:
 		return "StageClear"
+	case StateDialogue:
+		return "Dialogue"
+	case StateShop:
+		return "Shop"
+	case StatePhoto:
+		return "Photo"
+	case StateRewind:
+		return "Rewind"
+	case StateTAS:
+		return "TAS"
 	default:
 		return "Unknown"
 	}
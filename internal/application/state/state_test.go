@@ -17,6 +17,11 @@ func TestGameState_String(t *testing.T) {
 		{StatePaused, "Paused"},
 		{StateGameOver, "GameOver"},
 		{StateStageClear, "StageClear"},
+		{StateDialogue, "Dialogue"},
+		{StateShop, "Shop"},
+		{StatePhoto, "Photo"},
+		{StateRewind, "Rewind"},
+		{StateTAS, "TAS"},
 		{GameState(99), "Unknown"},
 	}
 
@@ -35,4 +40,5 @@ func TestGameStateConstants(t *testing.T) {
 	assert.Equal(t, GameState(3), StatePaused)
 	assert.Equal(t, GameState(4), StateGameOver)
 	assert.Equal(t, GameState(5), StateStageClear)
+	assert.Equal(t, GameState(6), StateDialogue)
 }
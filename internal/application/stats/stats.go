@@ -0,0 +1,155 @@
+// Package stats tracks per-run statistics and ranks a cleared stage.
+//
+// Run is fed the world's gameplay events (like the achievement tracker) and
+// ticked once per simulated frame. The summary screen reads it when the
+// stage is cleared, and the result is appended to the profile history.
+package stats
+
+import (
+	"math"
+
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Run holds the statistics of the current stage attempt
+type Run struct {
+	Frames      int // simulated frames (pauses and dialogue excluded)
+	DamageTaken int
+	ArrowsFired int
+	ArrowsHit   int
+	Kills       int
+	Gold        int
+	Intercepts  int // enemy projectiles shot down
+	Treasures   int // rare treasure pickups collected
+}
+
+// Handle updates the counters from one event.
+// EventStageStarted resets the run.
+func (r *Run) Handle(ev ecs.Event) {
+	switch ev.Type {
+	case ecs.EventStageStarted:
+		*r = Run{}
+	case ecs.EventPlayerDamaged:
+		r.DamageTaken += ev.Amount
+	case ecs.EventArrowFired:
+		r.ArrowsFired++
+	case ecs.EventEnemyHit:
+		r.ArrowsHit++
+	case ecs.EventEnemyKilled:
+		r.Kills++
+	case ecs.EventGoldCollected:
+		r.Gold += ev.Amount
+	case ecs.EventProjectileIntercepted:
+		r.Intercepts++
+	case ecs.EventTreasureCollected:
+		r.Treasures += ev.Amount
+	}
+}
+
+// Tick counts one simulated frame
+func (r *Run) Tick() {
+	r.Frames++
+}
+
+// Add adds the counters of another run (e.g. the floors of a tower run)
+func (r *Run) Add(o Run) {
+	r.Frames += o.Frames
+	r.DamageTaken += o.DamageTaken
+	r.ArrowsFired += o.ArrowsFired
+	r.ArrowsHit += o.ArrowsHit
+	r.Kills += o.Kills
+	r.Gold += o.Gold
+	r.Intercepts += o.Intercepts
+	r.Treasures += o.Treasures
+}
+
+// Seconds returns the run time in seconds at the simulation rate
+func (r Run) Seconds() float64 {
+	return fixedpoint.FramesToSeconds(r.Frames)
+}
+
+// Accuracy returns the fraction of fired arrows that hit (0 if none fired)
+func (r Run) Accuracy() float64 {
+	if r.ArrowsFired == 0 {
+		return 0
+	}
+	return math.Min(1, float64(r.ArrowsHit)/float64(r.ArrowsFired))
+}
+
+// Rank is the letter grade of a cleared stage
+type Rank string
+
+const (
+	RankS Rank = "S"
+	RankA Rank = "A"
+	RankB Rank = "B"
+	RankC Rank = "C"
+	RankD Rank = "D"
+)
+
+// Score weights (sum to 100)
+const (
+	scoreTime     = 40
+	scoreDamage   = 35
+	scoreAccuracy = 25
+
+	// damageForZero is the damage taken that zeroes the damage score
+	damageForZero = 100
+)
+
+// Score returns 0-100. Time earns full points at or under parSeconds and
+// none at twice par; damage and accuracy scale linearly.
+// parSeconds <= 0 awards full time points.
+func (r Run) Score(parSeconds int) int {
+	timeFactor := 1.0
+	if parSeconds > 0 {
+		par := float64(parSeconds)
+		timeFactor = clamp01((2*par - r.Seconds()) / par)
+	}
+	damageFactor := clamp01(1 - float64(r.DamageTaken)/damageForZero)
+
+	score := scoreTime*timeFactor + scoreDamage*damageFactor + scoreAccuracy*r.Accuracy()
+	return int(math.Round(score))
+}
+
+// Rank grades the run by score
+func (r Run) Rank(parSeconds int) Rank {
+	switch score := r.Score(parSeconds); {
+	case score >= 90:
+		return RankS
+	case score >= 75:
+		return RankA
+	case score >= 60:
+		return RankB
+	case score >= 40:
+		return RankC
+	default:
+		return RankD
+	}
+}
+
+// Arcade points for the leaderboard
+const (
+	pointsPerKill      = 100
+	pointsPerGold      = 10
+	pointsPerIntercept = 25
+	pointsPerTreasure  = 500
+	pointsPerScore     = 50 // clear bonus per rank score point
+)
+
+// Points returns the leaderboard score of the run: kills, gold, treasure and
+// intercepted projectiles, plus a clear bonus scaled by Score when the stage
+// was cleared.
+func (r Run) Points(parSeconds int, cleared bool) int {
+	points := r.Kills*pointsPerKill + r.Gold*pointsPerGold + r.Intercepts*pointsPerIntercept +
+		r.Treasures*pointsPerTreasure
+	if cleared {
+		points += r.Score(parSeconds) * pointsPerScore
+	}
+	return points
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
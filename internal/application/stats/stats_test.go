@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+func TestRun_Handle(t *testing.T) {
+	var r Run
+	for _, ev := range []ecs.Event{
+		{Type: ecs.EventArrowFired},
+		{Type: ecs.EventArrowFired},
+		{Type: ecs.EventEnemyHit, Amount: 10},
+		{Type: ecs.EventEnemyKilled, Amount: 5},
+		{Type: ecs.EventGoldCollected, Amount: 5},
+		{Type: ecs.EventPlayerDamaged, Amount: 25},
+		{Type: ecs.EventProjectileIntercepted},
+		{Type: ecs.EventTreasureCollected, Amount: 1},
+	} {
+		r.Handle(ev)
+	}
+	r.Tick()
+
+	assert.Equal(t, Run{Frames: 1, DamageTaken: 25, ArrowsFired: 2, ArrowsHit: 1, Kills: 1, Gold: 5, Intercepts: 1, Treasures: 1}, r)
+	assert.Equal(t, 0.5, r.Accuracy())
+
+	r.Handle(ecs.Event{Type: ecs.EventStageStarted})
+	assert.Equal(t, Run{}, r, "stage start resets the run")
+}
+
+func TestRun_SecondsFollowSimulationRate(t *testing.T) {
+	t.Cleanup(func() { fixedpoint.Sim = fixedpoint.FramesPerSecond })
+	r := Run{Frames: 90}
+	assert.Equal(t, 1.5, r.Seconds())
+
+	fixedpoint.SetSimulationRate(30)
+	assert.Equal(t, 3.0, r.Seconds())
+}
+
+func TestRun_Accuracy(t *testing.T) {
+	assert.Equal(t, 0.0, Run{}.Accuracy(), "no arrows fired")
+	assert.Equal(t, 1.0, Run{ArrowsFired: 1, ArrowsHit: 3}.Accuracy(), "piercing hits are capped")
+}
+
+func TestRun_Score(t *testing.T) {
+	perfect := Run{Frames: 30 * 60, ArrowsFired: 10, ArrowsHit: 10}
+	assert.Equal(t, 100, perfect.Score(60))
+	assert.Equal(t, RankS, perfect.Rank(60))
+
+	// 90s on a 60s par: half time points; 50 damage: half damage points; 50% accuracy
+	mid := Run{Frames: 90 * 60, DamageTaken: 50, ArrowsFired: 10, ArrowsHit: 5}
+	assert.Equal(t, 50, mid.Score(60)) // 20 + 17.5 + 12.5
+	assert.Equal(t, RankC, mid.Rank(60))
+
+	slow := Run{Frames: 200 * 60, DamageTaken: 150}
+	assert.Equal(t, 0, slow.Score(60))
+	assert.Equal(t, RankD, slow.Rank(60))
+
+	assert.Equal(t, 75, Run{Frames: 999 * 60}.Score(0), "no par: full time points")
+}
+
+func TestRun_Points(t *testing.T) {
+	run := Run{Frames: 30 * 60, Kills: 3, Gold: 25, ArrowsFired: 10, ArrowsHit: 10}
+	assert.Equal(t, 550, run.Points(60, false), "kills and gold only")
+	assert.Equal(t, 550+100*50, run.Points(60, true), "clear bonus from a perfect score")
+
+	run.Intercepts = 2
+	assert.Equal(t, 600, run.Points(60, false), "intercept bonus")
+
+	run.Treasures = 1
+	assert.Equal(t, 1100, run.Points(60, false), "treasure bonus")
+}
+
+func TestRun_Add(t *testing.T) {
+	total := Run{Frames: 60, Kills: 2, Gold: 10}
+	total.Add(Run{Frames: 30, Kills: 1, DamageTaken: 5})
+	assert.Equal(t, Run{Frames: 90, Kills: 3, Gold: 10, DamageTaken: 5}, total)
+}
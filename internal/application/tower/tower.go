@@ -0,0 +1,267 @@
+// Package tower generates the floors of the endless tower mode.
+//
+// A run is a seed: floor n of a run is always the same floor, laid out and
+// given its modifiers by an RNG seeded from the run seed and n. Every floor
+// has unbroken ground from the spawn on the left to the exit on the right,
+// so it can always be cleared; platforms, spikes and enemies are placed
+// around it, more of them the higher the floor. The daily run is a run on
+// the day's seed, the same for every player.
+package tower
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/younwookim/mg/internal/application/stats"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+// StageID is the stage ID of every floor (leaderboard, events)
+const StageID = "tower"
+
+// DailySeed returns the seed of the daily run on day: the same for every
+// player on the same (UTC) date, e.g. 20261015
+func DailySeed(day time.Time) int64 {
+	y, m, d := day.UTC().Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
+
+// DailyID returns the leaderboard ID of the daily run on day
+func DailyID(day time.Time) string {
+	return "daily-" + day.UTC().Format(time.DateOnly)
+}
+
+// Modifier changes the rules of one floor
+type Modifier string
+
+const (
+	LowGravity    Modifier = "lowGravity"
+	DoubleEnemies Modifier = "doubleEnemies"
+	NoDash        Modifier = "noDash"
+)
+
+// Modifiers lists every modifier in roll order
+var Modifiers = []Modifier{LowGravity, DoubleEnemies, NoDash}
+
+// Tiles of the generated tilemap (see TowerConfig.TileMapping)
+const (
+	tileWall  = '#'
+	tileSpike = 'S'
+	tileEmpty = '.'
+)
+
+// Layout limits (tiles)
+const (
+	spawnClear  = 6 // no spikes or enemies this close to the spawn
+	exitClear   = 5 // no spikes this close to the exit
+	spikeLength = 2 // short enough to jump over
+	spikeGap    = 3 // between spike strips
+)
+
+// pointsPerFloor is the leaderboard bonus of each floor cleared
+const pointsPerFloor = 2500
+
+// Floor is one generated floor of a run
+type Floor struct {
+	Number    int // from 1
+	Modifiers []Modifier
+	Stage     *config.StageConfig
+}
+
+// Has reports whether the floor has a modifier
+func (f Floor) Has(m Modifier) bool {
+	for _, have := range f.Modifiers {
+		if have == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Scale returns the multiplier enemy stats are raised by on the floor
+func (f Floor) Scale(cfg *config.TowerConfig) float64 {
+	return 1 + cfg.Growth*float64(f.Number-1)
+}
+
+// surface is a run of tiles that can be stood on: row is the solid row,
+// entities stand in the two rows above it
+type surface struct {
+	x0, x1 int // first and last tile
+	row    int
+}
+
+// Generate builds floor number (from 1) of the run with seed
+func Generate(cfg *config.TowerConfig, seed int64, number int) Floor {
+	rng := rand.New(rand.NewSource(seed*1_000_003 + int64(number)))
+	floor := Floor{Number: number, Modifiers: rollModifiers(cfg.Modifiers, rng, number)}
+
+	size := cfg.Size.TileSize
+	w, h := cfg.Size.Width/size, cfg.Size.Height/size
+	grid := make([][]byte, h)
+	for y := range grid {
+		grid[y] = make([]byte, w)
+		for x := range grid[y] {
+			grid[y][x] = tileEmpty
+			if y == 0 || y == h-1 || x == 0 || x == w-1 {
+				grid[y][x] = tileWall
+			}
+		}
+	}
+
+	// Platforms from left to right, each a few tiles past the last
+	surfaces := []surface{{x0: 1, x1: w - 2, row: h - 1}}
+	x := spawnClear
+	for range cfg.Platforms {
+		length := 3 + rng.Intn(4)
+		if x+length >= w-exitClear {
+			break
+		}
+		row := h - 5 - rng.Intn(max(1, h-9))
+		for i := range length {
+			grid[row][x+i] = tileWall
+		}
+		surfaces = append(surfaces, surface{x0: x, x1: x + length - 1, row: row})
+		x += length + 2 + rng.Intn(3)
+	}
+
+	// Spike strips on the ground, apart from each other
+	spikes := 0
+	if s := cfg.Spikes; s.FromFloor > 0 && number >= s.FromFloor {
+		spikes = min(s.Max, s.PerFloor*(number-s.FromFloor+1))
+	}
+	for attempt := 0; spikes > 0 && attempt < 20; attempt++ {
+		sx := spawnClear + rng.Intn(max(1, w-spawnClear-exitClear-spikeLength))
+		if !clearOfSpikes(grid[h-2], sx-spikeGap, sx+spikeLength+spikeGap) {
+			continue
+		}
+		for i := range spikeLength {
+			grid[h-2][sx+i] = tileSpike
+		}
+		spikes--
+	}
+
+	stage := &config.StageConfig{
+		ID:          StageID,
+		Name:        StageID,
+		Size:        cfg.Size,
+		Background:  cfg.Background,
+		PlayerSpawn: config.PositionConfig{X: 2 * size, Y: (h - 3) * size},
+		TileMapping: cfg.TileMapping,
+		Triggers: []config.TriggerConfig{{
+			Type: "exit",
+			Rect: config.RectConfig{X: (w - 3) * size, Y: (h - 4) * size, W: 2 * size, H: 3 * size},
+		}},
+	}
+	for _, row := range grid {
+		stage.Layers.Collision = append(stage.Layers.Collision, string(row))
+	}
+
+	// Enemies stand on a random surface, away from the spawn
+	count := 0
+	if e := cfg.Enemies; len(e.Types) > 0 {
+		count = min(e.Max, e.Base+e.PerFloor*(number-1))
+	}
+	if floor.Has(DoubleEnemies) {
+		count *= 2
+	}
+	for range count {
+		ex, ey, ok := standOn(surfaces, grid, rng)
+		if !ok {
+			continue
+		}
+		stage.Enemies = append(stage.Enemies, config.EnemySpawnConfig{
+			Type: cfg.Enemies.Types[rng.Intn(len(cfg.Enemies.Types))],
+			X:    ex * size,
+			Y:    ey * size,
+		})
+	}
+
+	// A health pickup to make up for the damage taken on the way
+	if px, py, ok := standOn(surfaces, grid, rng); ok {
+		stage.Pickups = []config.PickupSpawnConfig{{Type: "health", X: px * size, Y: py * size}}
+	}
+
+	floor.Stage = stage
+	return floor
+}
+
+// rollModifiers picks the floor's modifiers: each in turn, in a random
+// order, applies with the configured chance until there are Max of them
+func rollModifiers(cfg config.TowerModifiersConfig, rng *rand.Rand, number int) []Modifier {
+	// Roll the order first so every floor draws the same amount from the RNG
+	order := rng.Perm(len(Modifiers))
+	if cfg.FromFloor <= 0 || number < cfg.FromFloor {
+		return nil
+	}
+	var mods []Modifier
+	for _, i := range order {
+		if len(mods) < cfg.Max && rng.Float64() < cfg.Chance {
+			mods = append(mods, Modifiers[i])
+		}
+	}
+	return mods
+}
+
+// clearOfSpikes reports whether a row has no spikes between two tiles
+func clearOfSpikes(row []byte, from, to int) bool {
+	for x := max(from, 0); x < min(to, len(row)); x++ {
+		if row[x] == tileSpike {
+			return false
+		}
+	}
+	return true
+}
+
+// standOn returns the top tile of a free spot two tiles high: above a
+// random surface, not on spikes and away from the spawn (ok is false if
+// none was found)
+func standOn(surfaces []surface, grid [][]byte, rng *rand.Rand) (x, y int, ok bool) {
+	for range 10 {
+		s := surfaces[rng.Intn(len(surfaces))]
+		x = s.x0 + rng.Intn(s.x1-s.x0+1)
+		y = s.row - 2
+		if x >= spawnClear && y > 0 && grid[y][x] == tileEmpty && grid[y+1][x] == tileEmpty {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// Apply returns cfg with the floor's rule changes: lower gravity on a low
+// gravity floor. cfg itself is not modified.
+func (f Floor) Apply(cfg *config.GameConfig, tcfg *config.TowerConfig) *config.GameConfig {
+	if !f.Has(LowGravity) || tcfg.Modifiers.LowGravity <= 0 {
+		return cfg
+	}
+	physics := *cfg.Physics
+	physics.Physics.Gravity *= tcfg.Modifiers.LowGravity
+	applied := *cfg
+	applied.Physics = &physics
+	return &applied
+}
+
+// Escalate raises a difficulty profile to the floor: enemies get tougher,
+// hit harder, attack and spawn faster (0 multipliers count as 1)
+func (f Floor) Escalate(d config.DifficultyProfile, cfg *config.TowerConfig) config.DifficultyProfile {
+	scale := f.Scale(cfg)
+	mult := func(v float64) float64 {
+		if v <= 0 {
+			v = 1
+		}
+		return v * scale
+	}
+	d.EnemyHealth = mult(d.EnemyHealth)
+	d.ContactDamage = mult(d.ContactDamage)
+	d.SpawnRate = mult(d.SpawnRate)
+	if d.AttackCooldown <= 0 {
+		d.AttackCooldown = 1
+	}
+	d.AttackCooldown /= scale
+	return d
+}
+
+// Points scores a tower run: a bonus per floor cleared on top of the
+// stage points of the run's kills, gold, treasure and intercepts
+func Points(floors int, run stats.Run) int {
+	return floors*pointsPerFloor + run.Points(0, false)
+}
@@ -0,0 +1,137 @@
+package tower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/younwookim/mg/internal/application/stats"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
+
+func testConfig() *config.TowerConfig {
+	return &config.TowerConfig{
+		Size:      config.StageSizeConfig{Width: 640, Height: 240, TileSize: 16},
+		Platforms: 6,
+		Enemies:   config.TowerEnemiesConfig{Types: []string{"slime", "bat"}, Base: 3, PerFloor: 1, Max: 8},
+		Spikes:    config.TowerSpikesConfig{FromFloor: 3, PerFloor: 1, Max: 4},
+		Growth:    0.1,
+		Modifiers: config.TowerModifiersConfig{FromFloor: 2, Chance: 0.5, Max: 2, LowGravity: 0.5},
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	cfg := testConfig()
+	for n := 1; n <= 10; n++ {
+		assert.Equal(t, Generate(cfg, 42, n), Generate(cfg, 42, n), "floor %d", n)
+	}
+	assert.NotEqual(t, Generate(cfg, 42, 5).Stage.Layers.Collision, Generate(cfg, 43, 5).Stage.Layers.Collision)
+}
+
+func TestGenerate_Layout(t *testing.T) {
+	cfg := testConfig()
+	for n := 1; n <= 20; n++ {
+		floor := Generate(cfg, 7, n)
+		rows := floor.Stage.Layers.Collision
+		require.Len(t, rows, 15)
+		for _, row := range rows {
+			require.Len(t, row, 40)
+		}
+
+		// Unbroken ground and nothing in the way of the exit
+		assert.NotContains(t, rows[len(rows)-1], ".", "floor %d ground", n)
+		exit := floor.Stage.Triggers[0].Rect
+		assert.Equal(t, "exit", floor.Stage.Triggers[0].Type)
+		for y := exit.Y / 16; y < (exit.Y+exit.H)/16; y++ {
+			assert.Equal(t, "..", rows[y][exit.X/16:(exit.X+exit.W)/16], "floor %d exit", n)
+		}
+
+		want := min(cfg.Enemies.Max, cfg.Enemies.Base+n-1)
+		if floor.Has(DoubleEnemies) {
+			want *= 2
+		}
+		assert.LessOrEqual(t, len(floor.Stage.Enemies), want, "floor %d", n)
+		assert.Positive(t, len(floor.Stage.Enemies), "floor %d", n)
+		for _, e := range floor.Stage.Enemies {
+			assert.GreaterOrEqual(t, e.X/16, spawnClear, "floor %d enemy near the spawn", n)
+		}
+	}
+}
+
+func TestGenerate_Spikes(t *testing.T) {
+	cfg := testConfig()
+	spikes := func(n int) int {
+		count := 0
+		for _, row := range Generate(cfg, 1, n).Stage.Layers.Collision {
+			for _, c := range row {
+				if c == tileSpike {
+					count++
+				}
+			}
+		}
+		return count / spikeLength
+	}
+	assert.Zero(t, spikes(1))
+	assert.Zero(t, spikes(2))
+	assert.Equal(t, 1, spikes(3))
+	assert.Equal(t, 4, spikes(20), "capped")
+}
+
+func TestRollModifiers(t *testing.T) {
+	cfg := testConfig()
+	assert.Empty(t, Generate(cfg, 3, 1).Modifiers, "none before FromFloor")
+
+	seen := map[Modifier]bool{}
+	for n := 2; n <= 50; n++ {
+		mods := Generate(cfg, 3, n).Modifiers
+		assert.LessOrEqual(t, len(mods), cfg.Modifiers.Max)
+		for _, m := range mods {
+			seen[m] = true
+		}
+	}
+	assert.Len(t, seen, len(Modifiers), "every modifier comes up")
+
+	cfg.Modifiers.FromFloor = 0
+	assert.Empty(t, Generate(cfg, 3, 10).Modifiers, "modifiers off")
+}
+
+func TestFloor_Apply(t *testing.T) {
+	cfg := &config.GameConfig{Physics: &config.PhysicsConfig{Physics: config.PhysicsSettings{Gravity: 800}}}
+	tcfg := testConfig()
+
+	assert.Same(t, cfg, Floor{}.Apply(cfg, tcfg))
+
+	low := Floor{Modifiers: []Modifier{LowGravity}}.Apply(cfg, tcfg)
+	assert.Equal(t, 400.0, low.Physics.Physics.Gravity)
+	assert.Equal(t, 800.0, cfg.Physics.Physics.Gravity, "base config unchanged")
+}
+
+func TestFloor_Escalate(t *testing.T) {
+	tcfg := testConfig()
+	d := config.DifficultyProfile{EnemyHealth: 2}
+
+	assert.Equal(t, config.DifficultyProfile{EnemyHealth: 2, ContactDamage: 1, SpawnRate: 1, AttackCooldown: 1}, Floor{Number: 1}.Escalate(d, tcfg))
+
+	up := Floor{Number: 11}.Escalate(d, tcfg)
+	assert.InDelta(t, 4.0, up.EnemyHealth, 1e-9)
+	assert.InDelta(t, 2.0, up.ContactDamage, 1e-9)
+	assert.InDelta(t, 2.0, up.SpawnRate, 1e-9)
+	assert.InDelta(t, 0.5, up.AttackCooldown, 1e-9)
+}
+
+func TestPoints(t *testing.T) {
+	run := stats.Run{Kills: 3, Gold: 20}
+	assert.Equal(t, run.Points(0, false), Points(0, run))
+	assert.Equal(t, 2*pointsPerFloor+run.Points(0, false), Points(2, run))
+}
+
+func TestDaily(t *testing.T) {
+	morning := time.Date(2026, 10, 15, 1, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(20261015), DailySeed(morning))
+	assert.Equal(t, DailySeed(morning), DailySeed(evening), "one run a day")
+	assert.Equal(t, DailySeed(morning), DailySeed(morning.In(time.FixedZone("KST", 9*3600))), "same date everywhere")
+	assert.NotEqual(t, DailySeed(morning), DailySeed(morning.AddDate(0, 0, 1)))
+	assert.Equal(t, "daily-2026-10-15", DailyID(evening))
+}
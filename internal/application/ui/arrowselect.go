@@ -0,0 +1,204 @@
+package ui
+
+import "math"
+
+// ArrowSelectState is the animation state of the arrow select wheel
+type ArrowSelectState int
+
+const (
+	ArrowSelectIdle ArrowSelectState = iota
+	ArrowSelectAppearing
+	ArrowSelectShown
+	ArrowSelectDisappearing
+)
+
+// NoSlot is returned when no slot is highlighted or selected
+const NoSlot = -1
+
+// ArrowSelectConfig holds configuration for the arrow select wheel
+type ArrowSelectConfig struct {
+	Radius        int     // Icon distance from center (pixels)
+	MinDistance   int     // Minimum cursor distance for selection (pixels)
+	MaxFrame      int     // Animation duration (frames)
+	StickDeadzone float64 // Minimum right stick deflection for selection (0-1)
+}
+
+// DefaultArrowSelectConfig returns the default configuration
+func DefaultArrowSelectConfig() ArrowSelectConfig {
+	return ArrowSelectConfig{
+		Radius:        32,
+		MinDistance:   16,
+		MaxFrame:      10,
+		StickDeadzone: 0.5,
+	}
+}
+
+// ArrowSelectInput is one frame of input for the arrow select wheel
+type ArrowSelectInput struct {
+	Open, Close      bool    // wheel button pressed / released (right click, gamepad shoulder)
+	CursorX, CursorY int     // mouse position (logical pixels)
+	StickX, StickY   float64 // gamepad right stick (-1..1, +Y down)
+	Number           int     // number key pressed this frame (1-based slot, 0 = none)
+}
+
+// ArrowSelect is a radial wheel of equipped arrows. It opens around the
+// cursor while the wheel button is held; the slot under the cursor or right
+// stick is chosen on release. Number keys pick a slot directly.
+type ArrowSelect struct {
+	Config      ArrowSelectConfig
+	State       ArrowSelectState
+	Frame       int // Current animation frame (0~MaxFrame)
+	CenterX     int // Wheel center (clamped to keep icons on screen)
+	CenterY     int
+	Slots       int // Number of slots around the wheel
+	Highlighted int // Currently highlighted slot (NoSlot = none)
+}
+
+// NewArrowSelect creates a wheel with slots entries. Zero config values
+// take the defaults.
+func NewArrowSelect(cfg ArrowSelectConfig, slots int) *ArrowSelect {
+	def := DefaultArrowSelectConfig()
+	if cfg.Radius == 0 {
+		cfg.Radius = def.Radius
+	}
+	if cfg.MinDistance == 0 {
+		cfg.MinDistance = def.MinDistance
+	}
+	if cfg.MaxFrame == 0 {
+		cfg.MaxFrame = def.MaxFrame
+	}
+	if cfg.StickDeadzone == 0 {
+		cfg.StickDeadzone = def.StickDeadzone
+	}
+
+	return &ArrowSelect{
+		Config:      cfg,
+		State:       ArrowSelectIdle,
+		Slots:       max(1, slots),
+		Highlighted: NoSlot,
+	}
+}
+
+// IsActive returns true if the wheel is visible
+func (s *ArrowSelect) IsActive() bool {
+	return s.State != ArrowSelectIdle
+}
+
+// Progress returns the animation progress (0.0 ~ 1.0)
+func (s *ArrowSelect) Progress() float64 {
+	return float64(s.Frame) / float64(s.Config.MaxFrame)
+}
+
+// Update applies one frame of input and returns the slot selected this
+// frame (NoSlot = none)
+func (s *ArrowSelect) Update(in ArrowSelectInput, screenW, screenH int) int {
+	selected := NoSlot
+	if in.Number >= 1 && in.Number <= s.Slots {
+		selected = in.Number - 1
+	}
+
+	s.animate(in, screenW, screenH)
+	if !s.IsActive() {
+		s.Highlighted = NoSlot
+		return selected
+	}
+
+	s.updateHighlight(in)
+	if in.Close && s.Highlighted != NoSlot {
+		selected = s.Highlighted
+	}
+	return selected
+}
+
+// animate advances the open/close animation
+func (s *ArrowSelect) animate(in ArrowSelectInput, screenW, screenH int) {
+	maxFrame := s.Config.MaxFrame
+
+	switch s.State {
+	case ArrowSelectIdle:
+		if in.Open {
+			s.State = ArrowSelectAppearing
+			s.Frame = 0
+			s.center(in, screenW, screenH)
+		}
+
+	case ArrowSelectAppearing:
+		if in.Close {
+			// Transition to disappearing, keep frame
+			s.State = ArrowSelectDisappearing
+		} else {
+			s.Frame++
+			if s.Frame >= maxFrame {
+				s.State = ArrowSelectShown
+				s.Frame = maxFrame
+			}
+		}
+
+	case ArrowSelectShown:
+		if in.Close {
+			s.State = ArrowSelectDisappearing
+			s.Frame = maxFrame
+		}
+
+	case ArrowSelectDisappearing:
+		if in.Open {
+			// Transition to appearing, keep frame
+			s.State = ArrowSelectAppearing
+			s.center(in, screenW, screenH)
+		} else {
+			s.Frame--
+			if s.Frame <= 0 {
+				s.State = ArrowSelectIdle
+				s.Frame = 0
+			}
+		}
+	}
+}
+
+// center places the wheel on the cursor, clamped to keep icons on screen
+func (s *ArrowSelect) center(in ArrowSelectInput, screenW, screenH int) {
+	r := s.Config.Radius
+	s.CenterX = clampInt(in.CursorX, r, screenW-r)
+	s.CenterY = clampInt(in.CursorY, r, screenH-r)
+}
+
+// updateHighlight highlights the slot the right stick points at, or else
+// the slot under the cursor. A centered stick and cursor highlight nothing.
+func (s *ArrowSelect) updateHighlight(in ArrowSelectInput) {
+	if math.Hypot(in.StickX, in.StickY) >= s.Config.StickDeadzone {
+		s.Highlighted = s.SlotAt(in.StickX, in.StickY)
+		return
+	}
+
+	dx := float64(in.CursorX - s.CenterX)
+	dy := float64(in.CursorY - s.CenterY)
+	if math.Hypot(dx, dy) < float64(s.Config.MinDistance) {
+		s.Highlighted = NoSlot
+		return
+	}
+	s.Highlighted = s.SlotAt(dx, dy)
+}
+
+// SlotAt returns the slot whose wedge contains direction (dx, dy) (+Y down).
+// Slot 0 is to the right and the rest follow counter-clockwise, so four
+// slots sit right, up, left and down.
+func (s *ArrowSelect) SlotAt(dx, dy float64) int {
+	step := 2 * math.Pi / float64(s.Slots)
+	angle := math.Atan2(-dy, dx)
+	slot := int(math.Round(angle / step))
+	return (slot%s.Slots + s.Slots) % s.Slots
+}
+
+// IconPosition returns the icon position of a slot at the given eased
+// animation progress
+func (s *ArrowSelect) IconPosition(slot int, easedProgress float64) (x, y float64) {
+	angle := 2 * math.Pi * float64(slot) / float64(s.Slots)
+	radius := float64(s.Config.Radius) * easedProgress
+	x = float64(s.CenterX) + math.Cos(angle)*radius
+	y = float64(s.CenterY) - math.Sin(angle)*radius
+	return x, y
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestArrowSelect() *ArrowSelect {
+	return NewArrowSelect(ArrowSelectConfig{}, 4)
+}
+
+func TestNewArrowSelect(t *testing.T) {
+	s := newTestArrowSelect()
+
+	assert.Equal(t, ArrowSelectIdle, s.State)
+	assert.Equal(t, DefaultArrowSelectConfig(), s.Config, "zero config takes the defaults")
+	assert.Equal(t, 0, s.Frame)
+	assert.Equal(t, NoSlot, s.Highlighted)
+
+	s = NewArrowSelect(ArrowSelectConfig{Radius: 50, MinDistance: 20, MaxFrame: 15}, 6)
+	assert.Equal(t, 50, s.Config.Radius)
+	assert.Equal(t, 20, s.Config.MinDistance)
+	assert.Equal(t, 15, s.Config.MaxFrame)
+	assert.Equal(t, 6, s.Slots)
+}
+
+func TestArrowSelect_IsActive(t *testing.T) {
+	s := newTestArrowSelect()
+	assert.False(t, s.IsActive())
+
+	s.State = ArrowSelectAppearing
+	assert.True(t, s.IsActive())
+
+	s.State = ArrowSelectShown
+	assert.True(t, s.IsActive())
+
+	s.State = ArrowSelectDisappearing
+	assert.True(t, s.IsActive())
+}
+
+func TestArrowSelect_Update_Appearing(t *testing.T) {
+	s := newTestArrowSelect()
+	maxFrame := s.Config.MaxFrame
+	held := ArrowSelectInput{CursorX: 100, CursorY: 100}
+
+	// Button pressed - starts appearing
+	s.Update(ArrowSelectInput{Open: true, CursorX: 100, CursorY: 100}, 320, 240)
+	assert.Equal(t, ArrowSelectAppearing, s.State)
+	assert.Equal(t, 0, s.Frame)
+
+	// Frame advances
+	s.Update(held, 320, 240)
+	assert.Equal(t, 1, s.Frame)
+
+	// Advance to max frame
+	for i := 0; i < maxFrame-1; i++ {
+		s.Update(held, 320, 240)
+	}
+	assert.Equal(t, ArrowSelectShown, s.State)
+	assert.Equal(t, maxFrame, s.Frame)
+}
+
+func TestArrowSelect_Update_Disappearing(t *testing.T) {
+	s := newTestArrowSelect()
+	maxFrame := s.Config.MaxFrame
+	s.State = ArrowSelectShown
+	s.Frame = maxFrame
+
+	// Button released - starts disappearing
+	s.Update(ArrowSelectInput{Close: true}, 320, 240)
+	assert.Equal(t, ArrowSelectDisappearing, s.State)
+	assert.Equal(t, maxFrame, s.Frame)
+
+	// Frame decrements
+	s.Update(ArrowSelectInput{}, 320, 240)
+	assert.Equal(t, maxFrame-1, s.Frame)
+
+	// Advance to 0
+	for i := 0; i < maxFrame-1; i++ {
+		s.Update(ArrowSelectInput{}, 320, 240)
+	}
+	assert.Equal(t, ArrowSelectIdle, s.State)
+	assert.Equal(t, 0, s.Frame)
+}
+
+func TestArrowSelect_Update_MidTransition(t *testing.T) {
+	s := newTestArrowSelect()
+
+	// Start appearing
+	s.Update(ArrowSelectInput{Open: true}, 320, 240)
+	for i := 0; i < 5; i++ {
+		s.Update(ArrowSelectInput{}, 320, 240)
+	}
+	assert.Equal(t, ArrowSelectAppearing, s.State)
+	assert.Equal(t, 5, s.Frame)
+
+	// Release mid-animation - frame preserved
+	s.Update(ArrowSelectInput{Close: true}, 320, 240)
+	assert.Equal(t, ArrowSelectDisappearing, s.State)
+	assert.Equal(t, 5, s.Frame)
+
+	// Press again - frame preserved
+	s.Update(ArrowSelectInput{Open: true}, 320, 240)
+	assert.Equal(t, ArrowSelectAppearing, s.State)
+	assert.Equal(t, 5, s.Frame)
+}
+
+func TestArrowSelect_CursorHighlight(t *testing.T) {
+	s := newTestArrowSelect()
+	s.State = ArrowSelectShown
+	s.CenterX = 100
+	s.CenterY = 100
+	minDist := s.Config.MinDistance
+
+	tests := []struct {
+		name     string
+		mouseX   int
+		mouseY   int
+		expected int
+	}{
+		{"right", 100 + minDist + 10, 100, 0},
+		{"up", 100, 100 - minDist - 10, 1},
+		{"left", 100 - minDist - 10, 100, 2},
+		{"down", 100, 100 + minDist + 10, 3},
+		{"too close", 100 + 5, 100 + 5, NoSlot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.Update(ArrowSelectInput{CursorX: tt.mouseX, CursorY: tt.mouseY}, 320, 240)
+			assert.Equal(t, tt.expected, s.Highlighted)
+		})
+	}
+}
+
+func TestArrowSelect_StickOverridesCursor(t *testing.T) {
+	s := newTestArrowSelect()
+	s.State = ArrowSelectShown
+	s.CenterX, s.CenterY = 100, 100
+
+	// Cursor points right, stick points down
+	in := ArrowSelectInput{CursorX: 140, CursorY: 100, StickY: 0.9}
+	s.Update(in, 320, 240)
+	assert.Equal(t, 3, s.Highlighted)
+
+	// Stick inside the deadzone falls back to the cursor
+	in.StickY = 0.2
+	s.Update(in, 320, 240)
+	assert.Equal(t, 0, s.Highlighted)
+}
+
+func TestArrowSelect_SelectOnRelease(t *testing.T) {
+	s := newTestArrowSelect()
+	s.State = ArrowSelectShown
+	s.Frame = s.Config.MaxFrame
+	s.CenterX, s.CenterY = 100, 100
+
+	assert.Equal(t, NoSlot, s.Update(ArrowSelectInput{CursorX: 60, CursorY: 100}, 320, 240), "nothing selected while held")
+	assert.Equal(t, 2, s.Update(ArrowSelectInput{Close: true, CursorX: 60, CursorY: 100}, 320, 240))
+
+	s.State = ArrowSelectShown
+	assert.Equal(t, NoSlot, s.Update(ArrowSelectInput{Close: true, CursorX: 100, CursorY: 100}, 320, 240), "releasing in the center cancels")
+}
+
+func TestArrowSelect_NumberKeys(t *testing.T) {
+	s := NewArrowSelect(ArrowSelectConfig{}, 6)
+
+	assert.Equal(t, 4, s.Update(ArrowSelectInput{Number: 5}, 320, 240), "number keys select without opening the wheel")
+	assert.False(t, s.IsActive())
+	assert.Equal(t, NoSlot, s.Update(ArrowSelectInput{Number: 7}, 320, 240), "keys past the last slot are ignored")
+}
+
+func TestArrowSelect_SlotAt(t *testing.T) {
+	s := NewArrowSelect(ArrowSelectConfig{}, 6)
+
+	assert.Equal(t, 0, s.SlotAt(1, 0))
+	assert.Equal(t, 1, s.SlotAt(1, -1.5), "60° up-right")
+	assert.Equal(t, 3, s.SlotAt(-1, 0))
+	assert.Equal(t, 5, s.SlotAt(1, 1.5), "60° down-right")
+	assert.Equal(t, 0, s.SlotAt(1, 0.2), "near the boundary rounds to the closest slot")
+}
+
+func TestArrowSelect_IconPosition(t *testing.T) {
+	s := newTestArrowSelect()
+	s.CenterX = 100
+	s.CenterY = 100
+	r := float64(s.Config.Radius)
+
+	tests := []struct {
+		slot     int
+		progress float64
+		expectX  float64
+		expectY  float64
+	}{
+		{0, 1.0, 100 + r, 100},
+		{1, 1.0, 100, 100 - r},
+		{2, 1.0, 100 - r, 100},
+		{3, 1.0, 100, 100 + r},
+		{0, 0.5, 100 + r*0.5, 100},
+		{0, 0.0, 100, 100},
+	}
+
+	for _, tt := range tests {
+		x, y := s.IconPosition(tt.slot, tt.progress)
+		assert.InDelta(t, tt.expectX, x, 1e-9)
+		assert.InDelta(t, tt.expectY, y, 1e-9)
+	}
+}
+
+func TestArrowSelect_CenterClamp(t *testing.T) {
+	r := DefaultArrowSelectConfig().Radius
+	screenW, screenH := 320, 240
+	open := func(x, y int) *ArrowSelect {
+		s := newTestArrowSelect()
+		s.Update(ArrowSelectInput{Open: true, CursorX: x, CursorY: y}, screenW, screenH)
+		return s
+	}
+
+	assert.Equal(t, r, open(10, 100).CenterX, "left edge")
+	assert.Equal(t, screenW-r, open(screenW-10, 100).CenterX, "right edge")
+	assert.Equal(t, r, open(100, 10).CenterY, "top edge")
+	assert.Equal(t, screenH-r, open(100, screenH-10).CenterY, "bottom edge")
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// initialsAlphabet is the set Up/Down cycles through
+const initialsAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Initials entry layout (pixels)
+const (
+	initialsSpacing = 20 // distance between letter centers
+	initialsScale   = 2
+)
+
+var colorInitialsCursor = color.RGBA{255, 215, 0, 255}
+
+// InitialsInput is one frame of input for the initials widget
+type InitialsInput struct {
+	Up, Down    bool // cycle the selected letter
+	Left, Right bool // move the cursor
+	Confirm     bool // next letter, or finish on the last one
+	Back        bool // previous letter
+	Chars       []rune
+}
+
+// Initials is an arcade-style name entry: a few letters edited with the
+// arrow keys or typed directly
+type Initials struct {
+	letters []rune
+	cursor  int
+	done    bool
+}
+
+// NewInitials creates the widget with length letters, prefilled from
+// initial (e.g. the last name used). Missing letters start as 'A'.
+func NewInitials(length int, initial string) *Initials {
+	e := &Initials{letters: make([]rune, length)}
+	prefill := []rune(strings.ToUpper(initial))
+	for i := range e.letters {
+		e.letters[i] = 'A'
+		if i < len(prefill) && strings.ContainsRune(initialsAlphabet, prefill[i]) {
+			e.letters[i] = prefill[i]
+		}
+	}
+	return e
+}
+
+// Update applies one frame of input. Does nothing once finished.
+func (e *Initials) Update(in InitialsInput) {
+	if e.done {
+		return
+	}
+
+	for _, r := range in.Chars {
+		r = unicode.ToUpper(r)
+		if !strings.ContainsRune(initialsAlphabet, r) {
+			continue
+		}
+		e.letters[e.cursor] = r
+		e.cursor = min(e.cursor+1, len(e.letters)-1)
+	}
+
+	switch {
+	case in.Up:
+		e.cycle(1)
+	case in.Down:
+		e.cycle(-1)
+	case in.Left, in.Back:
+		e.cursor = max(e.cursor-1, 0)
+	case in.Right:
+		e.cursor = min(e.cursor+1, len(e.letters)-1)
+	case in.Confirm:
+		if e.cursor == len(e.letters)-1 {
+			e.done = true
+		} else {
+			e.cursor++
+		}
+	}
+}
+
+func (e *Initials) cycle(delta int) {
+	n := len(initialsAlphabet)
+	i := strings.IndexRune(initialsAlphabet, e.letters[e.cursor])
+	e.letters[e.cursor] = rune(initialsAlphabet[((i+delta)%n+n)%n])
+}
+
+// Done reports whether the player confirmed the last letter
+func (e *Initials) Done() bool {
+	return e.done
+}
+
+// String returns the entered letters
+func (e *Initials) String() string {
+	return string(e.letters)
+}
+
+// Draw renders the letters centered on x with the cursor letter underlined
+func (e *Initials) Draw(dst *ebiten.Image, x, y float64) {
+	left := x - float64(len(e.letters)-1)*initialsSpacing/2
+	style := Style{Scale: initialsScale, Color: color.White, Outline: color.Black, Align: AlignCenter}
+	for i, r := range e.letters {
+		cx := left + float64(i*initialsSpacing)
+		s := style
+		if i == e.cursor && !e.done {
+			s.Color = colorInitialsCursor
+			FillRect(dst, cx-6, y+LineHeight(s), 12, 2, colorInitialsCursor)
+		}
+		Draw(dst, string(r), cx, y, s)
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitials_ArrowEntry(t *testing.T) {
+	e := NewInitials(3, "")
+	assert.Equal(t, "AAA", e.String())
+
+	e.Update(InitialsInput{Down: true})
+	assert.Equal(t, "9AA", e.String(), "cycling wraps around")
+	e.Update(InitialsInput{Up: true})
+	e.Update(InitialsInput{Up: true})
+	e.Update(InitialsInput{Confirm: true})
+	e.Update(InitialsInput{Up: true})
+	e.Update(InitialsInput{Confirm: true})
+	assert.False(t, e.Done())
+	e.Update(InitialsInput{Confirm: true})
+
+	assert.True(t, e.Done())
+	assert.Equal(t, "BBA", e.String())
+
+	e.Update(InitialsInput{Up: true})
+	assert.Equal(t, "BBA", e.String(), "finished entry ignores input")
+}
+
+func TestInitials_TypedAndPrefilled(t *testing.T) {
+	e := NewInitials(3, "zq")
+	assert.Equal(t, "ZQA", e.String(), "prefill is upper-cased and padded")
+
+	e.Update(InitialsInput{Chars: []rune("k-9x")})
+	assert.Equal(t, "K9X", e.String(), "invalid characters are skipped")
+
+	e.Update(InitialsInput{Back: true})
+	e.Update(InitialsInput{Chars: []rune("m")})
+	assert.Equal(t, "KMX", e.String())
+}
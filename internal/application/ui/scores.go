@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+var colorScoreHighlight = color.RGBA{255, 215, 0, 255}
+
+// ScoreRow formats one leaderboard row with fixed-width columns
+func ScoreRow(rank int, e save.ScoreEntry) string {
+	return fmt.Sprintf("%2d. %-3s %7d %6.1fs", rank+1, e.Initials, e.Score, e.Seconds)
+}
+
+// DrawScores draws a leaderboard table centered on x starting at y.
+// The row at index highlight (e.g. the player's new entry) is drawn in
+// gold; pass -1 for none.
+func DrawScores(dst *ebiten.Image, entries []save.ScoreEntry, x, y float64, highlight int) {
+	for i, e := range entries {
+		style := StyleCenter
+		if i == highlight {
+			style.Color = colorScoreHighlight
+		}
+		Draw(dst, ScoreRow(i, e), x, y+float64(i)*lineSpacing, style)
+	}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/younwookim/mg/internal/infrastructure/save"
+)
+
+func TestScoreRow(t *testing.T) {
+	row := ScoreRow(0, save.ScoreEntry{Initials: "ABC", Score: 1234, Seconds: 56.78})
+	assert.Equal(t, " 1. ABC    1234   56.8s", row)
+	assert.Len(t, ScoreRow(9, save.ScoreEntry{Initials: "X", Score: 5}), len(row), "columns are fixed width")
+}
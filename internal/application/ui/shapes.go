@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// FillRect fills a rectangle without anti-aliasing. Consecutive calls batch
+// into one draw call (it draws a scaled white image).
+func FillRect(dst *ebiten.Image, x, y, width, height float64, clr color.Color) {
+	vector.FillRect(dst, float32(x), float32(y), float32(width), float32(height), clr, false)
+}
+
+// StrokeRect outlines a rectangle with a 1px line inside its bounds
+func StrokeRect(dst *ebiten.Image, x, y, width, height float64, clr color.Color) {
+	vector.StrokeRect(dst, float32(x)+0.5, float32(y)+0.5, float32(width)-1, float32(height)-1, 1, clr, false)
+}
@@ -0,0 +1,118 @@
+// Package ui provides text rendering for the HUD, overlays and menus.
+//
+// Text is drawn with ebiten/text/v2 using a bundled 12px bitmap font that
+// covers Latin, Hangul and Japanese, so every locale renders without
+// system fonts. Bitmap glyphs stay crisp at integer Scale values.
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Align is the horizontal alignment relative to the x position
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Style controls how text is drawn
+type Style struct {
+	Scale   float64     // integer values keep pixels crisp (0 = 1)
+	Color   color.Color // nil = white
+	Outline color.Color // nil = no outline
+	Align   Align
+	VCenter bool // center vertically on y instead of using y as the top
+}
+
+// Common styles
+var (
+	StyleDefault = Style{Color: color.White}
+	StyleHUD     = Style{Color: color.White, Outline: color.Black}
+	StyleTitle   = Style{Scale: 2, Color: color.White, Outline: color.Black, Align: AlignCenter}
+	StyleCenter  = Style{Color: color.White, Outline: color.Black, Align: AlignCenter}
+)
+
+// lineSpacing is the distance between baselines at scale 1 (pixels)
+const lineSpacing = 14
+
+// face is the bundled font (created on first use)
+var face *text.GoXFace
+
+func fontFace() *text.GoXFace {
+	if face == nil {
+		face = text.NewGoXFace(bitmapfont.Face)
+	}
+	return face
+}
+
+// outlineOffsets are the 8 neighbours drawn in the outline color
+var outlineOffsets = [8][2]float64{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+
+// Draw renders s at (x, y). Newlines start new lines.
+func Draw(dst *ebiten.Image, s string, x, y float64, style Style) {
+	scale := style.scale()
+	if style.VCenter {
+		_, h := Measure(s, style)
+		y -= h / 2
+	}
+
+	op := &text.DrawOptions{}
+	op.LineSpacing = lineSpacing
+	switch style.Align {
+	case AlignCenter:
+		op.PrimaryAlign = text.AlignCenter
+	case AlignRight:
+		op.PrimaryAlign = text.AlignEnd
+	}
+
+	if style.Outline != nil {
+		for _, o := range outlineOffsets {
+			op.GeoM.Reset()
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(x+o[0]*scale, y+o[1]*scale)
+			op.ColorScale.Reset()
+			op.ColorScale.ScaleWithColor(style.Outline)
+			text.Draw(dst, s, fontFace(), op)
+		}
+	}
+
+	op.GeoM.Reset()
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	op.ColorScale.Reset()
+	op.ColorScale.ScaleWithColor(style.color())
+	text.Draw(dst, s, fontFace(), op)
+}
+
+// Measure returns the size of s in pixels
+func Measure(s string, style Style) (w, h float64) {
+	w, h = text.Measure(s, fontFace(), lineSpacing)
+	scale := style.scale()
+	return w * scale, h * scale
+}
+
+// LineHeight returns the line advance in pixels for the style
+func LineHeight(style Style) float64 {
+	return lineSpacing * style.scale()
+}
+
+func (s Style) scale() float64 {
+	if s.Scale <= 0 {
+		return 1
+	}
+	return s.Scale
+}
+
+func (s Style) color() color.Color {
+	if s.Color == nil {
+		return color.White
+	}
+	return s.Color
+}
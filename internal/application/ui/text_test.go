@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	w, h := Measure("abcd", StyleDefault)
+	assert.Equal(t, 24.0, w, "halfwidth glyphs are 6px wide")
+	assert.Greater(t, h, 0.0)
+
+	w2, h2 := Measure("abcd\nab", Style{Scale: 2})
+	assert.Equal(t, 2*w, w2, "width of the longest line, scaled")
+	assert.Equal(t, 2*(h+lineSpacing), h2, "second line adds one line spacing, scaled")
+}
+
+func TestMeasure_Hangul(t *testing.T) {
+	w, _ := Measure("설정", StyleDefault)
+	assert.Equal(t, 24.0, w, "fullwidth glyphs are 12px wide")
+}
+
+func TestLineHeight(t *testing.T) {
+	assert.Equal(t, float64(lineSpacing), LineHeight(StyleDefault))
+	assert.Equal(t, 3.0*lineSpacing, LineHeight(Style{Scale: 3}))
+}
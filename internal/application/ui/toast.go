@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ToastIcon is the badge drawn at the left of a toast
+type ToastIcon int
+
+const (
+	IconInfo ToastIcon = iota
+	IconAchievement
+	IconPickup
+	IconWave
+	IconWarning
+)
+
+// Toast layout and timing
+const (
+	DefaultToastFrames = 180 // 3 seconds on screen
+	toastSlideFrames   = 12  // slide in/out duration
+	toastMaxVisible    = 4   // the rest wait their turn
+	toastMinWidth      = 100
+	toastPad           = 4
+	toastGap           = 2
+	toastIconSize      = 10
+)
+
+var (
+	colorToastBG     = color.RGBA{20, 20, 40, 230}
+	colorToastBorder = color.RGBA{255, 215, 0, 255}
+	colorToastGlyph  = color.RGBA{20, 20, 40, 255}
+)
+
+// toastIcons is the badge color and glyph of each icon
+var toastIcons = map[ToastIcon]struct {
+	color color.RGBA
+	glyph string
+}{
+	IconInfo:        {color.RGBA{120, 180, 255, 255}, "i"},
+	IconAchievement: {color.RGBA{255, 215, 0, 255}, "*"},
+	IconPickup:      {color.RGBA{100, 220, 120, 255}, "+"},
+	IconWave:        {color.RGBA{255, 140, 60, 255}, ">"},
+	IconWarning:     {color.RGBA{255, 70, 70, 255}, "!"},
+}
+
+// Toast is one queued notification
+type Toast struct {
+	Icon     ToastIcon
+	Text     string // may span several lines
+	Duration int    // frames fully shown
+	Frames   int    // frames elapsed since it started sliding in
+}
+
+// slide returns how far the toast is on screen: 0 = hidden off the right
+// edge, 1 = fully shown
+func (t Toast) slide() float64 {
+	if t.Frames < toastSlideFrames {
+		return float64(t.Frames) / toastSlideFrames
+	}
+	if remaining := t.Duration + 2*toastSlideFrames - t.Frames; remaining < toastSlideFrames {
+		return max(float64(remaining), 0) / toastSlideFrames
+	}
+	return 1
+}
+
+// Toasts is a queue of HUD notifications. A few are on screen at once,
+// stacked top-down; each slides in from the right, stays for its duration
+// and slides back out while the ones below move up into its place.
+type Toasts struct {
+	items []Toast
+}
+
+// Notify queues a toast shown for duration frames (0 = DefaultToastFrames)
+func (t *Toasts) Notify(icon ToastIcon, text string, duration int) {
+	if duration <= 0 {
+		duration = DefaultToastFrames
+	}
+	t.items = append(t.items, Toast{Icon: icon, Text: text, Duration: duration})
+}
+
+// Update advances the visible toasts by a frame and drops the finished ones
+func (t *Toasts) Update() {
+	kept := t.items[:0]
+	for i, item := range t.items {
+		if i < toastMaxVisible {
+			item.Frames++
+		}
+		if item.Frames < item.Duration+2*toastSlideFrames {
+			kept = append(kept, item)
+		}
+	}
+	t.items = kept
+}
+
+// Items returns the queued toasts, oldest first
+func (t *Toasts) Items() []Toast {
+	return t.items
+}
+
+// Clear drops every toast
+func (t *Toasts) Clear() {
+	t.items = t.items[:0]
+}
+
+// Draw draws the visible toasts stacked down from top, sliding in from
+// right (the right edge of the screen)
+func (t *Toasts) Draw(dst *ebiten.Image, right, top float64) {
+	y := top
+	for i, item := range t.items {
+		if i >= toastMaxVisible {
+			break
+		}
+		slide := item.slide()
+		tw, th := Measure(item.Text, StyleDefault)
+		w := max(toastMinWidth, toastIconSize+tw+3*toastPad)
+		h := max(toastIconSize, th) + 2*toastPad
+		x := right - slide*(w+toastPad)
+
+		FillRect(dst, x, y, w, h, colorToastBG)
+		StrokeRect(dst, x, y, w, h, colorToastBorder)
+		icon := toastIcons[item.Icon]
+		FillRect(dst, x+toastPad, y+toastPad, toastIconSize, toastIconSize, icon.color)
+		Draw(dst, icon.glyph, x+toastPad+toastIconSize/2, y+toastPad+toastIconSize/2, Style{Color: colorToastGlyph, Align: AlignCenter, VCenter: true})
+		Draw(dst, item.Text, x+toastIconSize+2*toastPad, y+toastPad, StyleDefault)
+
+		// Leaving toasts give their place up as they slide out
+		if item.Frames >= toastSlideFrames {
+			y += (h + toastGap) * slide
+		} else {
+			y += h + toastGap
+		}
+	}
+}
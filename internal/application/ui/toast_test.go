@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToasts_NotifyAndExpire(t *testing.T) {
+	var toasts Toasts
+	toasts.Notify(IconPickup, "+10 HP", 0)
+	toasts.Notify(IconWarning, "Low health!", 30)
+
+	items := toasts.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, DefaultToastFrames, items[0].Duration, "0 = default duration")
+	assert.Equal(t, 30, items[1].Duration)
+
+	// Both are on screen at once; the shorter one leaves first
+	for range 30 + 2*toastSlideFrames {
+		toasts.Update()
+	}
+	require.Len(t, toasts.Items(), 1)
+	assert.Equal(t, "+10 HP", toasts.Items()[0].Text)
+}
+
+func TestToasts_QueuePastVisibleLimit(t *testing.T) {
+	var toasts Toasts
+	for range toastMaxVisible + 1 {
+		toasts.Notify(IconInfo, "hi", 10)
+	}
+
+	for range 10 + 2*toastSlideFrames {
+		toasts.Update()
+	}
+	require.Len(t, toasts.Items(), 1, "the waiting toast starts once one leaves")
+	assert.Zero(t, toasts.Items()[0].Frames)
+}
+
+func TestToast_Slide(t *testing.T) {
+	toast := Toast{Duration: 60}
+	assert.Zero(t, toast.slide(), "hidden before sliding in")
+	toast.Frames = toastSlideFrames / 2
+	assert.InDelta(t, 0.5, toast.slide(), 1e-9)
+	toast.Frames = toastSlideFrames + 30
+	assert.Equal(t, 1.0, toast.slide())
+	toast.Frames = 60 + 2*toastSlideFrames - toastSlideFrames/2
+	assert.InDelta(t, 0.5, toast.slide(), 1e-9, "sliding out")
+}
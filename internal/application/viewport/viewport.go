@@ -0,0 +1,66 @@
+// Package viewport maps the fixed internal resolution onto the window.
+//
+// The game renders at a fixed logical size (e.g. 320x240). The viewport
+// scales it by the largest integer factor that fits the window and centers
+// it, leaving black letterbox bars. Windows smaller than the logical size
+// fall back to a fractional fit so the whole screen stays visible.
+package viewport
+
+// Viewport holds the logical and window sizes
+type Viewport struct {
+	ScreenW, ScreenH   int // logical resolution
+	OutsideW, OutsideH int // window size from Layout
+}
+
+// New creates a viewport for the logical resolution
+func New(screenW, screenH int) *Viewport {
+	return &Viewport{
+		ScreenW:  screenW,
+		ScreenH:  screenH,
+		OutsideW: screenW,
+		OutsideH: screenH,
+	}
+}
+
+// Resize updates the window size (call from Layout)
+func (v *Viewport) Resize(outsideW, outsideH int) {
+	v.OutsideW = outsideW
+	v.OutsideH = outsideH
+}
+
+// Scale returns the logical → window scale factor.
+// Integer when the window is at least the logical size.
+func (v *Viewport) Scale() float64 {
+	if v.ScreenW <= 0 || v.ScreenH <= 0 || v.OutsideW <= 0 || v.OutsideH <= 0 {
+		return 1
+	}
+
+	sx := v.OutsideW / v.ScreenW
+	sy := v.OutsideH / v.ScreenH
+	if s := min(sx, sy); s >= 1 {
+		return float64(s)
+	}
+
+	fx := float64(v.OutsideW) / float64(v.ScreenW)
+	fy := float64(v.OutsideH) / float64(v.ScreenH)
+	return min(fx, fy)
+}
+
+// Offset returns the top-left corner of the scaled screen in window pixels
+func (v *Viewport) Offset() (float64, float64) {
+	s := v.Scale()
+	x := (float64(v.OutsideW) - float64(v.ScreenW)*s) / 2
+	y := (float64(v.OutsideH) - float64(v.ScreenH)*s) / 2
+	return x, y
+}
+
+// ToLogical converts window coordinates (e.g. the cursor) to logical
+// screen coordinates. A nil viewport returns the input unchanged.
+func (v *Viewport) ToLogical(x, y int) (int, int) {
+	if v == nil {
+		return x, y
+	}
+	s := v.Scale()
+	ox, oy := v.Offset()
+	return int((float64(x) - ox) / s), int((float64(y) - oy) / s)
+}
@@ -0,0 +1,64 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewport_IntegerScale(t *testing.T) {
+	tests := []struct {
+		name               string
+		outsideW, outsideH int
+		wantScale          float64
+		wantX, wantY       float64
+	}{
+		{"exact fit", 320, 240, 1, 0, 0},
+		{"exact 3x", 960, 720, 3, 0, 0},
+		{"1080p letterbox", 1920, 1080, 4, 320, 60},
+		{"non-integer rounds down", 700, 500, 2, 30, 10},
+		{"tall window", 640, 1000, 2, 0, 260},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New(320, 240)
+			v.Resize(tt.outsideW, tt.outsideH)
+
+			assert.Equal(t, tt.wantScale, v.Scale())
+			x, y := v.Offset()
+			assert.Equal(t, tt.wantX, x)
+			assert.Equal(t, tt.wantY, y)
+		})
+	}
+}
+
+func TestViewport_SmallWindowFitsFractionally(t *testing.T) {
+	v := New(320, 240)
+	v.Resize(160, 240)
+
+	assert.Equal(t, 0.5, v.Scale())
+	x, y := v.Offset()
+	assert.Equal(t, 0.0, x)
+	assert.Equal(t, 60.0, y)
+}
+
+func TestViewport_ToLogical(t *testing.T) {
+	v := New(320, 240)
+	v.Resize(1920, 1080) // scale 4, offset (320, 60)
+
+	x, y := v.ToLogical(320, 60)
+	assert.Equal(t, 0, x)
+	assert.Equal(t, 0, y)
+
+	x, y = v.ToLogical(320+4*100, 60+4*50)
+	assert.Equal(t, 100, x)
+	assert.Equal(t, 50, y)
+}
+
+func TestViewport_NilToLogical(t *testing.T) {
+	var v *Viewport
+	x, y := v.ToLogical(12, 34)
+	assert.Equal(t, 12, x)
+	assert.Equal(t, 34, y)
+}
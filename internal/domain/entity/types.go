@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/younwookim/mg/internal/infrastructure/config"
+import (
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+	"github.com/younwookim/mg/internal/infrastructure/config"
+)
 
 // EntityID is a unique identifier for an entity
 type EntityID uint32
@@ -12,13 +16,22 @@ const (
 	TileEmpty TileType = iota
 	TileWall
 	TileSpike
+	TileLava
+	TileIce
+	TileSticky
+	TileCrumble
+	TileDoor       // passable until a boss fight seals it
+	TileDecoration // drawn only, never solid (grass, vines)
 )
 
 // Tile represents a single tile in the stage
 type Tile struct {
-	Type   TileType
-	Solid  bool
-	Damage int
+	Type    TileType
+	Solid   bool
+	Damage  int
+	Surface ecs.Surface
+	Fallen  bool // a crumbling tile that has fallen (not solid until it respawns)
+	Sealed  bool // a door closed for a boss fight (solid until opened)
 }
 
 // Stage represents the current stage's tile data
@@ -29,6 +42,12 @@ type Stage struct {
 	Tiles    [][]Tile
 	SpawnX   int
 	SpawnY   int
+
+	// Foreground tiles are drawn over the entities and never collide
+	// (nil = no foreground layer)
+	Foreground [][]Tile
+
+	revision int // bumped whenever a tile changes
 }
 
 // GetTile returns the tile at the given tile coordinates
@@ -39,16 +58,73 @@ func (s *Stage) GetTile(tx, ty int) Tile {
 	return s.Tiles[ty][tx]
 }
 
-// GetTileAtPixel returns the tile at the given pixel coordinates
+// GetForegroundTile returns the foreground tile at the given tile
+// coordinates (empty outside the layer)
+func (s *Stage) GetForegroundTile(tx, ty int) Tile {
+	if ty < 0 || ty >= len(s.Foreground) || tx < 0 || tx >= len(s.Foreground[ty]) {
+		return Tile{}
+	}
+	return s.Foreground[ty][tx]
+}
+
+// GetTileAtPixel returns the tile at the given pixel coordinates. Pixels
+// left of or above the stage are outside it, not in column or row 0.
 func (s *Stage) GetTileAtPixel(px, py int) Tile {
 	tx := px / s.TileSize
 	ty := py / s.TileSize
+	if px%s.TileSize < 0 {
+		tx--
+	}
+	if py%s.TileSize < 0 {
+		ty--
+	}
 	return s.GetTile(tx, ty)
 }
 
 // IsSolidAt checks if the tile at pixel coordinates is solid
 func (s *Stage) IsSolidAt(px, py int) bool {
-	return s.GetTileAtPixel(px, py).Solid
+	tile := s.GetTileAtPixel(px, py)
+	return (tile.Solid && !tile.Fallen) || tile.Sealed
+}
+
+// SetTileFallen marks a crumbling tile as fallen or respawned
+func (s *Stage) SetTileFallen(tx, ty int, fallen bool) {
+	if tx < 0 || tx >= s.Width || ty < 0 || ty >= s.Height {
+		return
+	}
+	if s.Tiles[ty][tx].Fallen != fallen {
+		s.Tiles[ty][tx].Fallen = fallen
+		s.revision++
+	}
+}
+
+// SetTileSealed closes or opens a door tile. Other tiles are left alone.
+func (s *Stage) SetTileSealed(tx, ty int, sealed bool) {
+	if tx < 0 || tx >= s.Width || ty < 0 || ty >= s.Height || s.Tiles[ty][tx].Type != TileDoor {
+		return
+	}
+	if s.Tiles[ty][tx].Sealed != sealed {
+		s.Tiles[ty][tx].Sealed = sealed
+		s.revision++
+	}
+}
+
+// RestoreFallen respawns every fallen tile and opens every sealed door (on
+// stage restart)
+func (s *Stage) RestoreFallen() {
+	for _, row := range s.Tiles {
+		for x := range row {
+			row[x].Fallen = false
+			row[x].Sealed = false
+		}
+	}
+	s.revision++
+}
+
+// Revision returns a counter that changes whenever a tile changes, so
+// anything cached from the tiles knows to rebuild
+func (s *Stage) Revision() int {
+	return s.revision
 }
 
 // GetTileType returns the tile type at pixel coordinates
@@ -61,6 +137,11 @@ func (s *Stage) GetTileDamage(px, py int) int {
 	return s.GetTileAtPixel(px, py).Damage
 }
 
+// GetSurface returns the surface properties of the tile at pixel coordinates
+func (s *Stage) GetSurface(px, py int) ecs.Surface {
+	return s.GetTileAtPixel(px, py).Surface
+}
+
 // GetWidth returns the stage width in tiles
 func (s *Stage) GetWidth() int {
 	return s.Width
@@ -76,6 +157,12 @@ func (s *Stage) GetTileSize() int {
 	return s.TileSize
 }
 
+// Raycast returns the first solid tile on the segment from (x0, y0) to
+// (x1, y1) in pixels and where the segment enters it
+func (s *Stage) Raycast(x0, y0, x1, y1 int) (ecs.RayHit, bool) {
+	return ecs.RaycastTiles(s, x0, y0, x1, y1)
+}
+
 // GetSpawnX returns the player spawn X position
 func (s *Stage) GetSpawnX() int {
 	return s.SpawnX
@@ -89,46 +176,88 @@ func (s *Stage) GetSpawnY() int {
 // LoadStage converts a StageConfig into a Stage entity
 func LoadStage(cfg *config.StageConfig) *Stage {
 	tileWidth := cfg.Size.Width / cfg.Size.TileSize
-	tileHeight := len(cfg.Layers.Collision)
 
-	tiles := make([][]Tile, tileHeight)
-	for y, row := range cfg.Layers.Collision {
+	var foreground [][]Tile
+	if len(cfg.Layers.Foreground) > 0 {
+		foreground = loadLayer(cfg.Layers.Foreground, tileWidth, cfg.TileMapping)
+		// Nothing in the foreground collides
+		for _, row := range foreground {
+			for x := range row {
+				row[x].Solid = false
+			}
+		}
+	}
+
+	tiles := loadLayer(cfg.Layers.Collision, tileWidth, cfg.TileMapping)
+	return &Stage{
+		Width:      tileWidth,
+		Height:     len(tiles),
+		TileSize:   cfg.Size.TileSize,
+		Tiles:      tiles,
+		SpawnX:     cfg.PlayerSpawn.X,
+		SpawnY:     cfg.PlayerSpawn.Y,
+		Foreground: foreground,
+	}
+}
+
+// loadLayer converts a layer's rows of tile mapping characters into tiles.
+// Unmapped characters are empty.
+func loadLayer(rows []string, tileWidth int, mappings map[string]config.TileMappingConfig) [][]Tile {
+	tiles := make([][]Tile, len(rows))
+	for y, row := range rows {
 		tiles[y] = make([]Tile, tileWidth)
 		for x, char := range row {
 			if x >= tileWidth {
 				break
 			}
-			charStr := string(char)
-			mapping, ok := cfg.TileMapping[charStr]
-			if !ok {
-				tiles[y][x] = Tile{Type: TileEmpty, Solid: false}
-				continue
-			}
-
-			var tileType TileType
-			switch mapping.Type {
-			case "wall":
-				tileType = TileWall
-			case "spike":
-				tileType = TileSpike
-			default:
-				tileType = TileEmpty
-			}
-
-			tiles[y][x] = Tile{
-				Type:   tileType,
-				Solid:  mapping.Solid,
-				Damage: mapping.Damage,
+			if mapping, ok := mappings[string(char)]; ok {
+				tiles[y][x] = tileFromMapping(mapping)
 			}
 		}
 	}
+	return tiles
+}
 
-	return &Stage{
-		Width:    tileWidth,
-		Height:   tileHeight,
-		TileSize: cfg.Size.TileSize,
-		Tiles:    tiles,
-		SpawnX:   cfg.PlayerSpawn.X,
-		SpawnY:   cfg.PlayerSpawn.Y,
+// tileFromMapping converts a tile mapping into a tile
+func tileFromMapping(mapping config.TileMappingConfig) Tile {
+	var tileType TileType
+	switch mapping.Type {
+	case "wall":
+		tileType = TileWall
+	case "spike":
+		tileType = TileSpike
+	case "lava":
+		tileType = TileLava
+	case "ice":
+		tileType = TileIce
+	case "sticky":
+		tileType = TileSticky
+	case "crumble":
+		tileType = TileCrumble
+	case "door":
+		tileType = TileDoor
+	case "decoration":
+		tileType = TileDecoration
+	default:
+		tileType = TileEmpty
+	}
+
+	return Tile{
+		Type:   tileType,
+		Solid:  mapping.Solid && tileType != TileDecoration,
+		Damage: mapping.Damage,
+		Surface: ecs.Surface{
+			FrictionPct:  fixedpoint.ToPct(mapping.Friction),
+			SpeedPct:     fixedpoint.ToPct(mapping.Speed),
+			NoDash:       mapping.NoDash,
+			KnockbackPct: fixedpoint.ToPct(mapping.Knockback),
+			Burn:         mapping.Burn,
+
+			SparesPlayer:  mapping.Affects == "enemies",
+			SparesEnemies: mapping.Affects == "player",
+
+			CrumbleFrames: fixedpoint.SecondsToFrames(mapping.CrumbleDelay),
+			RespawnFrames: fixedpoint.SecondsToFrames(mapping.Respawn),
+		},
 	}
 }
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/younwookim/mg/internal/ecs"
+	"github.com/younwookim/mg/internal/infrastructure/config"
 )
 
 func createTestStage() *Stage {
@@ -107,6 +110,7 @@ func TestStage_IsSolidAt(t *testing.T) {
 		{"empty space", 24, 24, false},
 		{"spike (not solid)", 24, 40, false},
 		{"out of bounds", -5, -5, true},
+		{"just above an open tile", 20, -3, true},
 	}
 
 	for _, tt := range tests {
@@ -121,4 +125,109 @@ func TestTileTypes(t *testing.T) {
 	assert.Equal(t, TileType(0), TileEmpty)
 	assert.Equal(t, TileType(1), TileWall)
 	assert.Equal(t, TileType(2), TileSpike)
+	assert.Equal(t, TileType(3), TileLava)
+	assert.Equal(t, TileType(4), TileIce)
+	assert.Equal(t, TileType(5), TileSticky)
+	assert.Equal(t, TileType(6), TileCrumble)
+	assert.Equal(t, TileType(7), TileDoor)
+	assert.Equal(t, TileType(8), TileDecoration)
+}
+
+func TestStage_SetTileFallen(t *testing.T) {
+	stage := createTestStage()
+
+	stage.SetTileFallen(0, 0, true)
+	assert.False(t, stage.IsSolidAt(0, 0))
+	stage.SetTileFallen(10, 10, true) // out of bounds is ignored
+
+	stage.RestoreFallen()
+	assert.True(t, stage.IsSolidAt(0, 0))
+}
+
+func TestStage_Raycast(t *testing.T) {
+	stage := createTestStage()
+
+	hit, ok := stage.Raycast(8, 24, 8, 0)
+	assert.True(t, ok)
+	assert.Equal(t, ecs.RayHit{X: 8, Y: 15, TileX: 0, TileY: 0}, hit)
+
+	_, ok = stage.Raycast(24, 40, 24, 0)
+	assert.False(t, ok, "straight up the open column")
+
+	stage.SetTileFallen(0, 0, true)
+	_, ok = stage.Raycast(8, 24, 8, 0)
+	assert.False(t, ok, "fallen tiles let rays through")
+}
+
+func TestStage_SetTileSealed(t *testing.T) {
+	stage := LoadStage(&config.StageConfig{
+		Size:   config.StageSizeConfig{Width: 32, TileSize: 16},
+		Layers: config.LayersConfig{Collision: []string{"D."}},
+		TileMapping: map[string]config.TileMappingConfig{
+			"D": {Type: "door"},
+		},
+	})
+	assert.False(t, stage.IsSolidAt(0, 0), "doors start open")
+
+	rev := stage.Revision()
+	stage.SetTileSealed(0, 0, true)
+	stage.SetTileSealed(1, 0, true) // not a door
+	assert.True(t, stage.IsSolidAt(0, 0))
+	assert.False(t, stage.IsSolidAt(16, 0))
+	assert.Equal(t, rev+1, stage.Revision())
+
+	stage.RestoreFallen()
+	assert.False(t, stage.IsSolidAt(0, 0), "restarts open the doors")
+}
+
+func TestLoadStage_ForegroundAndDecorations(t *testing.T) {
+	stage := LoadStage(&config.StageConfig{
+		Size: config.StageSizeConfig{Width: 48, TileSize: 16},
+		Layers: config.LayersConfig{
+			Collision:  []string{"v#.", "###"},
+			Foreground: []string{"#v"},
+		},
+		TileMapping: map[string]config.TileMappingConfig{
+			"#": {Type: "wall", Solid: true},
+			"v": {Type: "decoration", Solid: true},
+		},
+	})
+
+	assert.Equal(t, TileDecoration, stage.GetTile(0, 0).Type)
+	assert.False(t, stage.IsSolidAt(0, 0), "decorations never collide")
+	assert.True(t, stage.IsSolidAt(16, 0))
+
+	assert.Equal(t, TileWall, stage.GetForegroundTile(0, 0).Type)
+	assert.False(t, stage.GetForegroundTile(0, 0).Solid, "the foreground never collides")
+	assert.Equal(t, TileDecoration, stage.GetForegroundTile(1, 0).Type)
+	assert.Equal(t, Tile{}, stage.GetForegroundTile(0, 1), "past the layer's rows")
+	assert.Nil(t, createTestStage().Foreground)
+}
+
+func TestStage_RevisionCountsTileChanges(t *testing.T) {
+	stage := createTestStage()
+	rev := stage.Revision()
+
+	stage.SetTileFallen(0, 0, true)
+	assert.Equal(t, rev+1, stage.Revision())
+	stage.SetTileFallen(0, 0, true)
+	stage.SetTileFallen(10, 10, true)
+	assert.Equal(t, rev+1, stage.Revision(), "no change, no new revision")
+}
+
+func TestLoadStage_Surfaces(t *testing.T) {
+	stage := LoadStage(&config.StageConfig{
+		Size:   config.StageSizeConfig{Width: 48, TileSize: 16},
+		Layers: config.LayersConfig{Collision: []string{"IG#"}},
+		TileMapping: map[string]config.TileMappingConfig{
+			"I": {Type: "ice", Solid: true, Friction: 0.1},
+			"G": {Type: "sticky", Solid: true, Speed: 0.5, NoDash: true},
+			"#": {Type: "wall", Solid: true},
+		},
+	})
+
+	assert.Equal(t, TileIce, stage.GetTile(0, 0).Type)
+	assert.Equal(t, ecs.Surface{FrictionPct: 10}, stage.GetSurface(4, 4))
+	assert.Equal(t, ecs.Surface{SpeedPct: 50, NoDash: true}, stage.GetSurface(20, 4))
+	assert.Equal(t, ecs.Surface{}, stage.GetSurface(36, 4))
 }
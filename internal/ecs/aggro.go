@@ -0,0 +1,96 @@
+package ecs
+
+// AggroConfig holds alert sharing tuning.
+// Values are pre-converted to frames (like PhysicsConfig).
+type AggroConfig struct {
+	Radius      int // pixels: ungrouped enemies alert others this close (0 = no sharing)
+	DelayFrames int // before an alerted enemy reacts
+	AlertFrames int // an alert outlasts losing sight of the player by this long
+}
+
+// Alerted returns true while the enemy knows where the player is
+func (ai AI) Alerted() bool {
+	return ai.AlertTimer > 0
+}
+
+// UpdateAggro spots the player and shares the alert (call once per frame).
+// An enemy spots the player within its DetectRange and alerts the rest of
+// its group after DelayFrames: every member of a named (stage-defined)
+// group, or other ungrouped enemies within Radius. Alerted patrol and chase
+// enemies go after the player until AlertFrames pass without a sighting.
+func UpdateAggro(w *World) {
+	playerID := w.PlayerID
+	if playerID == 0 {
+		return
+	}
+	playerPos := w.Position[playerID]
+	playerPX, playerPY := playerPos.PixelX(), playerPos.PixelY()
+	alertFrames := max(1, w.Aggro.AlertFrames)
+
+	ids := w.Query(TagEnemy)
+
+	// Count down pending and running alerts
+	var spotters []EntityID
+	for _, id := range ids {
+		ai := w.AI[id]
+		if ai.AlertDelay > 0 {
+			ai.AlertDelay--
+			if ai.AlertDelay == 0 {
+				ai.AlertTimer = alertFrames
+				w.Events.Emit(Event{Type: EventEnemyAlerted, Entity: id})
+			}
+		} else if ai.AlertTimer > 0 {
+			ai.AlertTimer--
+		}
+
+		pos := w.Position[id]
+		if ai.SpawnTimer <= 0 && ai.DetectRange > 0 &&
+			abs(playerPX-pos.PixelX())+abs(playerPY-pos.PixelY()) <= ai.DetectRange {
+			ai.AlertTimer = alertFrames
+			ai.AlertDelay = 0
+			spotters = append(spotters, id)
+		}
+		w.AI[id] = ai
+	}
+
+	for _, spotter := range spotters {
+		alertGroup(w, ids, spotter, alertFrames)
+	}
+}
+
+// alertGroup alerts the members of the spotter's group, keeping the alert
+// running for members that already know
+func alertGroup(w *World, ids []EntityID, spotter EntityID, alertFrames int) {
+	group := w.AI[spotter].Group
+	if group == "" && w.Aggro.Radius <= 0 {
+		return
+	}
+	spotterPos := w.Position[spotter]
+
+	for _, id := range ids {
+		ai := w.AI[id]
+		if id == spotter || ai.Group != group || ai.SpawnTimer > 0 {
+			continue
+		}
+		if group == "" {
+			pos := w.Position[id]
+			dist := abs(pos.PixelX()-spotterPos.PixelX()) + abs(pos.PixelY()-spotterPos.PixelY())
+			if dist > w.Aggro.Radius {
+				continue
+			}
+		}
+
+		switch {
+		case ai.Alerted():
+			ai.AlertTimer = alertFrames
+		case ai.AlertDelay > 0:
+			continue
+		case w.Aggro.DelayFrames > 0:
+			ai.AlertDelay = w.Aggro.DelayFrames
+		default:
+			ai.AlertTimer = alertFrames
+			w.Events.Emit(Event{Type: EventEnemyAlerted, Entity: id})
+		}
+		w.AI[id] = ai
+	}
+}
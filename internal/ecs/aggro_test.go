@@ -0,0 +1,90 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAggroWorld() *World {
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	w.Aggro = AggroConfig{Radius: 64, DelayFrames: 3, AlertFrames: 10}
+	return w
+}
+
+func createAggroEnemy(w *World, x int, detectRange int, group string) EntityID {
+	return w.CreateEnemy(x, 100, EnemyConfig{
+		MaxHealth:   10,
+		AIType:      AIPatrol,
+		DetectRange: detectRange,
+		PatrolDist:  32,
+		MoveSpeed:   10,
+		Flying:      true,
+		Group:       group,
+	}, false)
+}
+
+func TestUpdateAggro_AlertsNamedGroupAfterDelay(t *testing.T) {
+	w := newAggroWorld()
+	spotter := createAggroEnemy(w, 150, 80, "camp")
+	ally := createAggroEnemy(w, 900, 0, "camp")
+	stranger := createAggroEnemy(w, 160, 0, "tower")
+
+	UpdateAggro(w)
+	assert.True(t, w.AI[spotter].Alerted())
+	assert.False(t, w.AI[ally].Alerted(), "still reacting")
+
+	for range 3 {
+		UpdateAggro(w)
+	}
+	assert.True(t, w.AI[ally].Alerted(), "named groups share regardless of distance")
+	assert.False(t, w.AI[stranger].Alerted())
+	assert.Equal(t, []Event{{Type: EventEnemyAlerted, Entity: ally}}, w.Events.Drain())
+}
+
+func TestUpdateAggro_UngroupedAlertsWithinRadius(t *testing.T) {
+	w := newAggroWorld()
+	w.Aggro.DelayFrames = 0
+	createAggroEnemy(w, 150, 80, "")
+	near := createAggroEnemy(w, 200, 0, "")
+	far := createAggroEnemy(w, 300, 0, "")
+
+	UpdateAggro(w)
+
+	assert.True(t, w.AI[near].Alerted())
+	assert.False(t, w.AI[far].Alerted())
+}
+
+func TestUpdateAggro_AlertExpires(t *testing.T) {
+	w := newAggroWorld()
+	spotter := createAggroEnemy(w, 150, 80, "")
+	UpdateAggro(w)
+
+	pos := w.Position[w.PlayerID]
+	pos.X = 1000 * PositionScale
+	w.Position[w.PlayerID] = pos
+	for range 9 {
+		UpdateAggro(w)
+	}
+	assert.True(t, w.AI[spotter].Alerted())
+	UpdateAggro(w)
+	assert.False(t, w.AI[spotter].Alerted())
+}
+
+func TestUpdateEnemyAI_AlertedPatrolChasesPlayer(t *testing.T) {
+	w := newAggroWorld()
+	w.Aggro.DelayFrames = 0
+	createAggroEnemy(w, 150, 80, "")
+	ally := createAggroEnemy(w, 200, 0, "") // patrols away from the player unless alerted
+	ai := w.AI[ally]
+	ai.PatrolDir = 1
+	w.AI[ally] = ai
+	stage := newMockStage(100, 100, 16)
+
+	UpdateAggro(w)
+	stepFrame(w, stage)
+
+	assert.Less(t, w.Position[ally].X, 200*PositionScale)
+	assert.False(t, w.Facing[ally].Right)
+}
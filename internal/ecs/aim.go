@@ -0,0 +1,119 @@
+package ecs
+
+// AimMode is how an enemy aims its shots
+type AimMode int
+
+const (
+	AimStraight AimMode = iota // flat along its facing
+	AimDirect                  // at the player's current position
+	AimLead                    // at where the player will be when the shot arrives
+)
+
+// aimNames maps aim modes to their config names
+var aimNames = map[AimMode]string{
+	AimStraight: "straight",
+	AimDirect:   "direct",
+	AimLead:     "lead",
+}
+
+// String returns the config name of the mode
+func (m AimMode) String() string {
+	return aimNames[m]
+}
+
+// ParseAimMode returns the aim mode for a config name
+func ParseAimMode(name string) (AimMode, bool) {
+	for m, n := range aimNames {
+		if n == name {
+			return m, true
+		}
+	}
+	return AimStraight, false
+}
+
+// DefaultArrowSpeed is the enemy shot speed when none is configured
+const DefaultArrowSpeed = 94 // 220 pixels/sec in IU/substep
+
+// leadPasses is how many times a lead shot refines its flight time estimate
+const leadPasses = 2
+
+// aimShot returns the launch velocity (IU/substep) of a shot fired from
+// (x, y), in pixels, at the AI's target (else the player). Aimed shots
+// solve for the projectile's gravity, taking the flat trajectory or, with
+// arc, the high lob.
+func aimShot(w *World, x, y int, ai *AI, facingRight bool, cfg ProjectileConfig) (vx, vy int) {
+	speed := cfg.Speed
+	if speed <= 0 {
+		speed = DefaultArrowSpeed
+	}
+	target := w.PlayerID
+	if ai.Target != 0 {
+		target = ai.Target
+	}
+	if ai.Aim == AimStraight || target == 0 {
+		if !facingRight {
+			speed = -speed
+		}
+		return speed, 0
+	}
+
+	tx, ty := HitboxCenter(w, target)
+	dx, dy := (tx-x)*PositionScale, (ty-y)*PositionScale
+	vx, vy = launchVelocity(dx, dy, speed, cfg.GravityAccel, ai.ArcShots)
+	if ai.Aim != AimLead {
+		return vx, vy
+	}
+
+	// Aim where the target will be after the flight time of the last guess
+	targetVel := w.Velocity[target]
+	if w.Movement[target].OnGround {
+		targetVel.Y = 0
+	}
+	for range leadPasses {
+		if vx == 0 {
+			break
+		}
+		flight := abs(dx) / abs(vx) // substeps
+		lx := dx + targetVel.X*flight
+		ly := dy + targetVel.Y*flight
+		vx, vy = launchVelocity(lx, ly, speed, cfg.GravityAccel, ai.ArcShots)
+	}
+	return vx, vy
+}
+
+// launchVelocity returns the velocity (IU/substep) of a shot at the given
+// speed that lands on (dx, dy) (IU) under gravity (IU/substep per frame).
+// Targets out of reach get the 45° shot that flies farthest.
+func launchVelocity(dx, dy, speed, gravity int, arc bool) (vx, vy int) {
+	if dx == 0 && dy == 0 {
+		return 0, 0
+	}
+	dir := 1
+	if dx < 0 {
+		dir = -1
+	}
+	x := abs(dx)
+
+	// Without gravity (or straight up/down) fire along the line
+	if gravity <= 0 || x == 0 {
+		d := isqrt(dx*dx + dy*dy)
+		return divRound(dx*speed, d), divRound(dy*speed, d)
+	}
+
+	// tanθ = (S² ± √(S⁴ - g(gx² + 2yS²))) / (gx), with per-frame speed 10S
+	// and gravity 10g folded in. y is up, so it is -dy.
+	s2 := speed * speed
+	disc := 100*s2*s2 - gravity*(gravity*x*x-20*dy*s2)
+	num, den := PositionScale, PositionScale // 45° (scaled so the rounding below stays exact)
+	if disc >= 0 {
+		root := isqrt(disc)
+		num, den = 10*s2-root, gravity*x
+		if arc {
+			num = 10*s2 + root
+		}
+	}
+
+	// (den, num) points along the launch angle
+	l := isqrt(den*den + num*num)
+	return dir * divRound(den*speed, l), -divRound(num*speed, l)
+}
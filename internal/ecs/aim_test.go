@@ -0,0 +1,99 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAimWorld(playerX, playerY int) *World {
+	w := NewWorld()
+	body := Hitbox{Width: 8, Height: 8}
+	w.CreatePlayer(playerX, playerY, PlayerProfile{
+		Hitbox:    HitboxTrapezoid{Head: body, Body: body, Feet: body},
+		MaxHealth: 100,
+	})
+	return w
+}
+
+// flyShot simulates a shot from (x, y) and returns its closest approach
+// (pixels, taxicab) to (tx, ty)
+func flyShot(x, y, vx, vy, tx, ty int, cfg ProjectileConfig) int {
+	w := NewWorld()
+	stage := newMockStage(100, 100, 16)
+	id := w.CreateProjectile(x, y, vx, vy, cfg, FactionEnemy)
+	best := abs(tx-x) + abs(ty-y)
+	for range 120 {
+		ApplyProjectileGravity(w)
+		for range SubstepsPerFrame {
+			UpdateProjectiles(w, stage)
+			if _, ok := w.IsProjectile[id]; !ok {
+				return best
+			}
+			pos := w.Position[id]
+			best = min(best, abs(tx-pos.PixelX())+abs(ty-pos.PixelY()))
+		}
+	}
+	return best
+}
+
+func TestAimShot_StraightFliesAlongFacing(t *testing.T) {
+	w := newAimWorld(300, 100)
+	ai := AI{}
+
+	vx, vy := aimShot(w, 100, 100, &ai, false, ProjectileConfig{Speed: 80})
+	assert.Equal(t, -80, vx)
+	assert.Equal(t, 0, vy)
+
+	vx, _ = aimShot(w, 100, 100, &ai, true, ProjectileConfig{})
+	assert.Equal(t, DefaultArrowSpeed, vx)
+}
+
+func TestAimShot_DirectHitsUnderGravity(t *testing.T) {
+	w := newAimWorld(296, 96) // center (300, 100)
+	cfg := ProjectileConfig{Speed: 200, GravityAccel: 4, MaxFallSpeed: 1000, MaxRange: 1000}
+
+	flat := AI{Aim: AimDirect}
+	vx, vy := aimShot(w, 100, 140, &flat, false, cfg)
+	assert.Positive(t, vx, "turns to face the player")
+	assert.LessOrEqual(t, flyShot(100, 140, vx, vy, 300, 100, cfg), 4)
+
+	arc := AI{Aim: AimDirect, ArcShots: true}
+	avx, avy := aimShot(w, 100, 140, &arc, false, cfg)
+	assert.Less(t, avy, vy, "lob launches steeper")
+	assert.LessOrEqual(t, flyShot(100, 140, avx, avy, 300, 100, cfg), 4)
+}
+
+func TestAimShot_OutOfReachFires45Degrees(t *testing.T) {
+	w := newAimWorld(2996, 96)
+	ai := AI{Aim: AimDirect}
+
+	vx, vy := aimShot(w, 100, 100, &ai, true, ProjectileConfig{Speed: 50, GravityAccel: 4})
+
+	assert.InDelta(t, vx, -vy, 1)
+	assert.Positive(t, vx)
+}
+
+func TestAimShot_LeadsMovingPlayer(t *testing.T) {
+	w := newAimWorld(296, 96)
+	w.Velocity[w.PlayerID] = Velocity{Y: -40} // rising
+	cfg := ProjectileConfig{Speed: 100}
+
+	direct := AI{Aim: AimDirect}
+	_, vy := aimShot(w, 100, 100, &direct, true, cfg)
+	assert.Equal(t, 0, vy)
+
+	lead := AI{Aim: AimLead}
+	_, vy = aimShot(w, 100, 100, &lead, true, cfg)
+	assert.Negative(t, vy, "aims above the rising player")
+}
+
+func TestParseAimMode(t *testing.T) {
+	for _, mode := range []AimMode{AimStraight, AimDirect, AimLead} {
+		parsed, ok := ParseAimMode(mode.String())
+		assert.True(t, ok)
+		assert.Equal(t, mode, parsed)
+	}
+	_, ok := ParseAimMode("psychic")
+	assert.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package ecs
+
+// Ally is a turret summoned by the player. It hovers where it was placed
+// and shoots the nearest enemy its faction can hurt, with an AI like a
+// ranged enemy's. It isn't an enemy: nothing hurts it, and it vanishes
+// when its lifetime runs out.
+type Ally struct {
+	Lifetime int // frames left
+}
+
+// SummonConfig is the player's summonable turret (ECS units)
+type SummonConfig struct {
+	Lifetime       int // frames a turret stays (0 = summoning disabled)
+	Cooldown       int // frames from a summon until the next
+	Range          int // pixels to the farthest enemy it shoots at
+	AttackCooldown int // frames between shots
+	Aim            AimMode
+	Width, Height  int // hitbox (pixels)
+	Projectile     ProjectileConfig
+}
+
+// allyGap is the space (pixels) between the player and a summoned turret
+const allyGap = 4
+
+// SummonAlly places a turret beside the player, on the side it faces, when
+// summoning is unlocked and off cooldown. A turret still standing is
+// replaced. It returns the new turret (0 = none).
+func SummonAlly(w *World) EntityID {
+	playerID := w.PlayerID
+	player, ok := w.PlayerData[playerID]
+	cfg := w.Summon
+	if !ok || !player.SummonUnlocked || player.SummonCooldown > 0 || cfg.Lifetime <= 0 {
+		return 0
+	}
+	for _, id := range w.Query(CompAlly) {
+		w.DestroyEntity(id)
+	}
+
+	right := w.Facing[playerID].Right
+	cx, cy := HitboxCenter(w, playerID)
+	if right {
+		cx += cfg.Width + allyGap
+	} else {
+		cx -= cfg.Width + allyGap
+	}
+
+	id := w.NewEntity()
+	w.Position[id] = Position{X: (cx - cfg.Width/2) * PositionScale, Y: (cy - cfg.Height/2) * PositionScale}
+	w.Hitbox[id] = Hitbox{Width: cfg.Width, Height: cfg.Height}
+	w.Facing[id] = Facing{Right: right}
+	w.AI[id] = AI{
+		Type:           AIRanged,
+		Flying:         true,
+		AttackRange:    cfg.Range,
+		AttackCooldown: cfg.AttackCooldown,
+		Aim:            cfg.Aim,
+	}
+	w.Faction[id] = FactionPlayer
+	w.Ally[id] = Ally{Lifetime: cfg.Lifetime}
+
+	player.SummonCooldown = cfg.Cooldown
+	w.PlayerData[playerID] = player
+	w.Events.Emit(Event{Type: EventAllySummoned, Entity: id})
+	return id
+}
+
+// UpdateAllies counts down the turrets' lifetimes and shot timers and fires
+// at the nearest enemy in range (once per frame)
+func UpdateAllies(w *World, stage Stage) {
+	for _, id := range w.Query(CompAlly) {
+		ally := w.Ally[id]
+		ally.Lifetime--
+		if ally.Lifetime <= 0 {
+			w.Defer(Destroy(id))
+			continue
+		}
+		w.Ally[id] = ally
+
+		ai := w.AI[id]
+		if ai.AttackTimer > 0 {
+			ai.AttackTimer--
+		}
+		if ai.WindupTimer > 0 {
+			ai.WindupTimer--
+		}
+
+		// With nothing in range a wind-up is dropped, not fired at the
+		// player as an enemy's would be
+		target, dist := allyTarget(w, id, ai.AttackRange)
+		ai.Target = target
+		if target == 0 {
+			ai.Telegraph = TelegraphNone
+			w.AI[id] = ai
+			continue
+		}
+
+		pos, facing := w.Position[id], w.Facing[id]
+		x, _ := HitboxCenter(w, id)
+		if tx, _ := HitboxCenter(w, target); tx != x {
+			facing.Right = tx > x
+		}
+		enemyShoot(w, stage, id, &pos, w.Hitbox[id], &ai, &facing, dist, w.Summon.Projectile, w.Faction[id])
+		w.AI[id] = ai
+		w.Facing[id] = facing
+	}
+}
+
+// allyTarget returns the nearest enemy within rangePx (pixels) of an ally
+// that the ally can hurt, and its distance (0 = none). Ties go to the
+// lower ID.
+func allyTarget(w *World, id EntityID, rangePx int) (target EntityID, dist int) {
+	x, y := HitboxCenter(w, id)
+	bestSq := rangePx * rangePx
+	for _, enemyID := range w.Query(TagEnemy) {
+		if !w.Hostile(id, enemyID) || w.AI[enemyID].SpawnTimer > 0 {
+			continue
+		}
+		ex, ey := HitboxCenter(w, enemyID)
+		d2 := (ex-x)*(ex-x) + (ey-y)*(ey-y)
+		if d2 > bestSq || (target != 0 && d2 == bestSq && enemyID > target) {
+			continue
+		}
+		target, bestSq = enemyID, d2
+	}
+	if target == 0 {
+		return 0, 0
+	}
+	return target, isqrt(bestSq)
+}
@@ -0,0 +1,119 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSummon = SummonConfig{
+	Lifetime:       120,
+	Cooldown:       300,
+	Range:          150,
+	AttackCooldown: 30,
+	Aim:            AimDirect,
+	Width:          10,
+	Height:         10,
+	Projectile:     ProjectileConfig{Speed: 90, Damage: 10, HitboxWidth: 4, HitboxHeight: 4, MaxRange: 300},
+}
+
+func newSummonWorld(unlocked bool) *World {
+	w := NewWorld()
+	w.Summon = testSummon
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox, Summon: unlocked})
+	return w
+}
+
+func TestSummonAlly_NeedsUnlockAndCooldown(t *testing.T) {
+	w := newSummonWorld(false)
+	assert.Zero(t, SummonAlly(w), "locked")
+
+	w = newSummonWorld(true)
+	id := SummonAlly(w)
+	require.NotZero(t, id)
+	assert.Equal(t, FactionPlayer, w.Faction[id])
+	assert.Equal(t, testSummon.Cooldown, w.PlayerData[w.PlayerID].SummonCooldown)
+	assert.Equal(t, []Event{{Type: EventAllySummoned, Entity: id}}, w.Events.Drain())
+	assert.Zero(t, SummonAlly(w), "on cooldown")
+
+	// Off cooldown, the new turret replaces the old one
+	player := w.PlayerData[w.PlayerID]
+	player.SummonCooldown = 0
+	w.PlayerData[w.PlayerID] = player
+	next := SummonAlly(w)
+	require.NotZero(t, next)
+	assert.False(t, w.Exists(id))
+	assert.Len(t, w.Ally, 1)
+}
+
+func TestSummonAlly_PlacedOnFacingSide(t *testing.T) {
+	w := newSummonWorld(true)
+	px, _ := HitboxCenter(w, w.PlayerID)
+
+	id := SummonAlly(w)
+	x, _ := HitboxCenter(w, id)
+	assert.Greater(t, x, px)
+	assert.True(t, w.Facing[id].Right)
+}
+
+func TestUpdateAllies_ShootsNearestEnemyInRange(t *testing.T) {
+	w := newSummonWorld(true)
+	stage := newMockStage(640, 480, 16)
+	id := SummonAlly(w)
+	enemyCfg := EnemyConfig{MaxHealth: 50, HitboxWidth: 16, HitboxHeight: 16, Flying: true}
+	far := w.CreateEnemy(400, 100, enemyCfg, true)
+	near := w.CreateEnemy(30, 100, enemyCfg, true)
+
+	UpdateAllies(w, stage)
+	w.Flush()
+
+	assert.Equal(t, near, w.AI[id].Target)
+	assert.False(t, w.Facing[id].Right, "turns to the enemy")
+	var shots []EntityID
+	for p := range w.IsProjectile {
+		shots = append(shots, p)
+	}
+	require.Len(t, shots, 1)
+	assert.Equal(t, FactionPlayer, w.Faction[shots[0]])
+	assert.Less(t, w.Velocity[shots[0]].X, 0)
+	assert.Equal(t, testSummon.AttackCooldown, w.AI[id].AttackTimer)
+	assert.Equal(t, 50, w.Health[far].Current)
+}
+
+func TestUpdateAllies_IgnoresFriendlyEnemies(t *testing.T) {
+	w := newSummonWorld(true)
+	stage := newMockStage(640, 480, 16)
+	id := SummonAlly(w)
+	w.CreateEnemy(150, 100, EnemyConfig{MaxHealth: 50, HitboxWidth: 16, HitboxHeight: 16, Flying: true, Faction: FactionPlayer}, true)
+
+	UpdateAllies(w, stage)
+
+	assert.Zero(t, w.AI[id].Target)
+	assert.Empty(t, w.IsProjectile)
+}
+
+func TestUpdateAllies_ExpiresAfterLifetime(t *testing.T) {
+	w := newSummonWorld(true)
+	stage := newMockStage(640, 480, 16)
+	id := SummonAlly(w)
+
+	for range testSummon.Lifetime - 1 {
+		UpdateAllies(w, stage)
+	}
+	assert.True(t, w.Exists(id))
+	UpdateAllies(w, stage)
+	assert.True(t, w.Exists(id), "destroyed at the next sync point")
+	w.Flush()
+	assert.False(t, w.Exists(id))
+	assert.Empty(t, w.Ally)
+}
+
+func TestUpdateTimers_SummonCooldown(t *testing.T) {
+	w := newSummonWorld(true)
+	SummonAlly(w)
+
+	UpdateTimers(w)
+
+	assert.Equal(t, testSummon.Cooldown-1, w.PlayerData[w.PlayerID].SummonCooldown)
+}
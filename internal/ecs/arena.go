@@ -0,0 +1,61 @@
+package ecs
+
+// maxArenas is how many arenas a stage can have (BossFight.Won bits)
+const maxArenas = 64
+
+// BossFight is the state of a stage's scripted boss fights. The scene runs
+// the script around it (doors, music, banner); the state lives in the
+// world so snapshots and rewinds restore it along with the boss.
+type BossFight struct {
+	Arena  int // arena being fought in + 1 (0 = no fight)
+	Boss   EntityID
+	Frames int    // frames since the fight started
+	Won    uint64 // bit i: the boss of arena i has been beaten
+}
+
+// Active reports whether a boss fight is under way
+func (b BossFight) Active() bool {
+	return b.Arena > 0
+}
+
+// Beaten reports whether the boss of an arena (0-based) has been beaten
+func (b BossFight) Beaten(arena int) bool {
+	return arena >= 0 && arena < maxArenas && b.Won&(1<<arena) != 0
+}
+
+// StartBossFight starts the fight of arena (0-based) against boss and
+// emits EventBossAppeared. It reports false, starting nothing, during
+// another fight or for arenas past the 64th.
+func StartBossFight(w *World, arena int, boss EntityID) bool {
+	if w.Boss.Active() || arena < 0 || arena >= maxArenas {
+		return false
+	}
+	w.Boss.Arena = arena + 1
+	w.Boss.Boss = boss
+	w.Boss.Frames = 0
+	w.Events.Emit(Event{Type: EventBossAppeared, Entity: boss, Amount: arena})
+	return true
+}
+
+// UpdateBossFight times the fight and ends it once the boss is gone,
+// emitting EventBossKilled with the fight's length. It returns the arena
+// that was won this frame (-1 = none).
+func UpdateBossFight(w *World) int {
+	if !w.Boss.Active() {
+		return -1
+	}
+	if w.Exists(w.Boss.Boss) {
+		w.Boss.Frames++
+		return -1
+	}
+	arena := w.Boss.Arena - 1
+	w.Events.Emit(Event{Type: EventBossKilled, Entity: w.Boss.Boss, Amount: w.Boss.Frames})
+	w.Boss = BossFight{Won: w.Boss.Won | 1<<arena}
+	return arena
+}
+
+// DropReward rolls a loot table at (x, y), in pixels, like a killed
+// enemy's drop (a boss's reward chest)
+func DropReward(w *World, x, y int, table LootTable) {
+	dropTable(w, x, y, table)
+}
@@ -0,0 +1,58 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBossFight_StartAndWin(t *testing.T) {
+	w := NewWorld()
+	boss := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 400, HitboxWidth: 40, HitboxHeight: 40}, true)
+
+	require.True(t, StartBossFight(w, 2, boss))
+	assert.True(t, w.Boss.Active())
+	assert.False(t, StartBossFight(w, 3, boss), "one fight at a time")
+	assert.Equal(t, []Event{{Type: EventBossAppeared, Entity: boss, Amount: 2}}, w.Events.Drain())
+
+	for range 30 {
+		assert.Equal(t, -1, UpdateBossFight(w))
+	}
+	w.DestroyEntity(boss)
+	assert.Equal(t, 2, UpdateBossFight(w))
+
+	assert.False(t, w.Boss.Active())
+	assert.True(t, w.Boss.Beaten(2))
+	assert.False(t, w.Boss.Beaten(0))
+	assert.Equal(t, []Event{{Type: EventBossKilled, Entity: boss, Amount: 30}}, w.Events.Drain())
+	assert.Equal(t, -1, UpdateBossFight(w), "nothing to win after the fight")
+}
+
+func TestBossFight_SurvivesSnapshot(t *testing.T) {
+	w := NewWorld()
+	boss := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 400}, true)
+	snap := w.Snapshot()
+	StartBossFight(w, 0, boss)
+
+	w.Restore(snap)
+	assert.False(t, w.Boss.Active(), "rewinding before the trigger undoes the fight")
+}
+
+func TestStartBossFight_ArenaOutOfRange(t *testing.T) {
+	w := NewWorld()
+	assert.False(t, StartBossFight(w, maxArenas, 1))
+	assert.False(t, StartBossFight(w, -1, 1))
+	assert.False(t, w.Boss.Beaten(maxArenas))
+}
+
+func TestDropReward_SpawnsRolledPickups(t *testing.T) {
+	w := NewWorld()
+	DropReward(w, 50, 60, LootTable{Rolls: 3, Entries: []LootEntry{{Kind: PickupTreasure, Weight: 1, Min: 100, Max: 100}}})
+
+	require.Len(t, w.IsPickup, 3)
+	for id := range w.IsPickup {
+		assert.Equal(t, PickupTreasure, w.PickupData[id].Kind)
+		assert.Equal(t, 100, w.PickupData[id].Amount)
+	}
+}
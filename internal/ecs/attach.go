@@ -0,0 +1,76 @@
+package ecs
+
+import (
+	"math"
+	"slices"
+)
+
+// Bleed is damage over time an arrow deals while stuck in an enemy
+type Bleed struct {
+	Damage     int // per tick
+	TickFrames int // frames between ticks
+	Ticks      int // ticks before the arrow stops bleeding (0 = as long as it is stuck)
+}
+
+// attachProjectile sticks a projectile into the entity it hit. It stays at
+// the same offset from the entity (see UpdateAttachedProjectiles) until it
+// fades out or the entity is gone.
+func attachProjectile(w *World, id, parent EntityID, proj *Projectile) {
+	pos, vel, parentPos := w.Position[id], w.Velocity[id], w.Position[parent]
+	proj.StuckRotation = math.Atan2(float64(vel.Y), float64(vel.X))
+	proj.Stuck = true
+	proj.StuckTimer = 0
+	proj.Parent = parent
+	proj.ParentOffsetX = pos.X - parentPos.X
+	proj.ParentOffsetY = pos.Y - parentPos.Y
+	w.ProjectileData[id] = *proj
+	w.Velocity[id] = Velocity{}
+}
+
+// UpdateAttachedProjectiles moves arrows stuck in entities along with them
+// and removes the arrows of entities that are gone (call once per frame,
+// after everything that moves enemies)
+func UpdateAttachedProjectiles(w *World) {
+	for _, id := range w.Query(TagProjectile) {
+		proj := w.ProjectileData[id]
+		if proj.Parent == 0 {
+			continue
+		}
+		parentPos, ok := w.Position[proj.Parent]
+		if !ok || !w.Exists(proj.Parent) {
+			w.Defer(Destroy(id))
+			continue
+		}
+		w.Position[id] = Position{X: parentPos.X + proj.ParentOffsetX, Y: parentPos.Y + proj.ParentOffsetY}
+	}
+}
+
+// bleedEnemies deals the bleed damage of arrows stuck in enemies, in
+// projectile ID order so kills replay identically. Enemies it kills are
+// added to killed.
+func bleedEnemies(w *World, killed []EntityID) []EntityID {
+	for _, id := range w.Query(TagProjectile) {
+		proj := w.ProjectileData[id]
+		if proj.Parent == 0 || proj.Bleed.Damage <= 0 {
+			continue
+		}
+		b := proj.Bleed
+		tick := max(1, b.TickFrames)
+		if proj.StuckTimer == 0 || proj.StuckTimer%tick != 0 || (b.Ticks > 0 && proj.StuckTimer/tick > b.Ticks) {
+			continue
+		}
+		enemy := proj.Parent
+		health, ok := w.Health[enemy]
+		if _, isEnemy := w.IsEnemy[enemy]; !ok || !isEnemy || health.Current <= 0 || slices.Contains(killed, enemy) {
+			continue
+		}
+		damage := eliteDamage(w, enemy, b.Damage)
+		health.Current -= damage
+		w.Health[enemy] = health
+		w.Events.Emit(Event{Type: EventEnemyHit, Entity: enemy, Amount: damage})
+		if health.Current <= 0 {
+			killed = append(killed, enemy)
+		}
+	}
+	return killed
+}
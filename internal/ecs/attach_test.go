@@ -0,0 +1,103 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAttachWorld(bleed Bleed) (w *World, enemy, arrow EntityID) {
+	w = NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	enemy = w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 20, HitboxWidth: 16, HitboxHeight: 16, Flying: true}, false)
+	arrow = w.CreateProjectile(98, 104, 90, 0, ProjectileConfig{
+		Damage:         5,
+		HitboxWidth:    12,
+		HitboxHeight:   4,
+		StuckDuration:  300,
+		StickInEnemies: true,
+		Bleed:          bleed,
+	}, FactionPlayer)
+	return w, enemy, arrow
+}
+
+func TestUpdateDamage_ArrowSticksAndFollowsEnemy(t *testing.T) {
+	w, enemy, arrow := newAttachWorld(Bleed{})
+
+	UpdateDamage(w, 100, 50, 60)
+
+	require.True(t, w.Exists(arrow), "sticking arrow is kept")
+	proj := w.ProjectileData[arrow]
+	assert.True(t, proj.Stuck)
+	assert.Equal(t, enemy, proj.Parent)
+	assert.Equal(t, Velocity{}, w.Velocity[arrow])
+	assert.Equal(t, 15, w.Health[enemy].Current)
+
+	// Stuck arrows don't hit again
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 15, w.Health[enemy].Current)
+
+	// The arrow keeps its offset from the moving enemy
+	offX, offY := w.Position[arrow].X-w.Position[enemy].X, w.Position[arrow].Y-w.Position[enemy].Y
+	pos := w.Position[enemy]
+	pos.X += 5000
+	pos.Y -= 2000
+	w.Position[enemy] = pos
+	UpdateAttachedProjectiles(w)
+	assert.Equal(t, Position{X: pos.X + offX, Y: pos.Y + offY}, w.Position[arrow])
+
+	w.DestroyEntity(enemy)
+	UpdateAttachedProjectiles(w)
+	w.Flush()
+	assert.False(t, w.Exists(arrow), "arrows go with their enemy")
+}
+
+func TestUpdateDamage_KillingArrowBreaks(t *testing.T) {
+	w, enemy, arrow := newAttachWorld(Bleed{})
+	h := w.Health[enemy]
+	h.Current = 5
+	w.Health[enemy] = h
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, w.Exists(enemy))
+	assert.False(t, w.Exists(arrow))
+}
+
+func TestBleed_TicksWhileStuck(t *testing.T) {
+	w, enemy, arrow := newAttachWorld(Bleed{Damage: 2, TickFrames: 10, Ticks: 3})
+	UpdateDamage(w, 100, 50, 60)
+	require.Equal(t, 15, w.Health[enemy].Current)
+	w.Events.Drain()
+
+	for range 60 {
+		UpdateTimers(w)
+		UpdateDamage(w, 100, 50, 60)
+	}
+
+	assert.Equal(t, 9, w.Health[enemy].Current, "three ticks of 2")
+	assert.True(t, w.Exists(arrow))
+	hits := 0
+	for _, e := range w.Events.Drain() {
+		if e.Type == EventEnemyHit {
+			hits++
+		}
+	}
+	assert.Equal(t, 3, hits)
+}
+
+func TestBleed_Kills(t *testing.T) {
+	w, enemy, arrow := newAttachWorld(Bleed{Damage: 5, TickFrames: 1})
+	UpdateDamage(w, 100, 50, 60)
+
+	for range 3 {
+		UpdateTimers(w)
+		UpdateDamage(w, 100, 50, 60)
+	}
+	UpdateAttachedProjectiles(w)
+	w.Flush()
+
+	assert.False(t, w.Exists(enemy))
+	assert.False(t, w.Exists(arrow))
+}
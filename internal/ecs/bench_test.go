@@ -0,0 +1,178 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Benchmarks for the per-frame hot paths. Worlds are sized like a busy
+// stage so spatial indexing and storage changes can be compared:
+//
+//	go test ./internal/ecs -run '^$' -bench . -benchmem
+
+const (
+	benchStageWidth  = 200 // tiles
+	benchStageHeight = 30
+	benchTileSize    = 16
+	benchFloorY      = (benchStageHeight - 2) * benchTileSize // pixel top of the floor
+)
+
+// newBenchStage returns a wide stage with a floor and a few platforms
+func newBenchStage() *mockStage {
+	stage := newMockStage(benchStageWidth*benchTileSize, benchStageHeight*benchTileSize, benchTileSize)
+	for tx := 0; tx < benchStageWidth; tx++ {
+		stage.setSolid(tx, benchStageHeight-2)
+		stage.setSolid(tx, benchStageHeight-1)
+		if tx%12 < 4 {
+			stage.setSolid(tx, benchStageHeight-6)
+		}
+	}
+	return stage
+}
+
+// benchPhysicsConfig returns physics values close to configs/physics.json
+func benchPhysicsConfig() PhysicsConfig {
+	return PhysicsConfig{
+		Gravity:                 fixedpoint.ToIUAccelPerFrame(800),
+		MaxFallSpeed:            fixedpoint.ToIUPerSubstep(400),
+		MaxSpeed:                fixedpoint.ToIUPerSubstep(150),
+		Acceleration:            fixedpoint.ToIUAccelPerFrame(2000),
+		Deceleration:            fixedpoint.ToIUAccelPerFrame(2000),
+		AirControlPct:           80,
+		TurnaroundPct:           150,
+		JumpForce:               fixedpoint.ToIUPerSubstep(320),
+		VarJumpPct:              50,
+		CoyoteFrames:            6,
+		JumpBufferFrames:        6,
+		FallMultiplierPct:       160,
+		DashSpeed:               fixedpoint.ToIUPerSubstep(400),
+		DashFrames:              9,
+		DashCooldownFrames:      30,
+		CornerCorrectionMargin:  4,
+		CornerCorrectionEnabled: true,
+	}
+}
+
+// newBenchWorld creates a world with the player standing on the floor
+func newBenchWorld() *World {
+	w := NewWorld()
+	w.Rand = rand.New(rand.NewSource(1))
+	feet := Hitbox{OffsetX: 2, OffsetY: 0, Width: 12, Height: 16}
+	w.CreatePlayer(benchStageWidth*benchTileSize/2, benchFloorY-16, PlayerProfile{
+		Hitbox:    HitboxTrapezoid{Head: feet, Body: feet, Feet: feet},
+		MaxHealth: 100,
+	})
+	return w
+}
+
+// addBenchEnemies spreads n walking enemies over the floor
+func addBenchEnemies(w *World, n int) {
+	aiTypes := []AIType{AIPatrol, AIAggressive, AIChase}
+	for i := 0; i < n; i++ {
+		x := (i * 7919) % ((benchStageWidth - 2) * benchTileSize)
+		w.CreateEnemy(benchTileSize+x, benchFloorY-16, EnemyConfig{
+			MaxHealth:    20,
+			MoveSpeed:    fixedpoint.ToIUPerSubstep(60),
+			HitboxWidth:  16,
+			HitboxHeight: 16,
+			AIType:       aiTypes[i%len(aiTypes)],
+			DetectRange:  150,
+			PatrolDist:   48,
+			JumpForce:    fixedpoint.ToIUPerSubstep(250),
+		}, i%2 == 0)
+	}
+}
+
+// addBenchArrows fires n player arrows across the stage
+func addBenchArrows(w *World, n int) {
+	cfg := ProjectileConfig{
+		GravityAccel:  1,
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(400),
+		MaxRange:      benchStageWidth * benchTileSize,
+		Damage:        10,
+		HitboxWidth:   8,
+		HitboxHeight:  3,
+		StuckDuration: 60,
+	}
+	for i := 0; i < n; i++ {
+		x := benchTileSize + (i*104729)%((benchStageWidth-2)*benchTileSize)
+		y := benchFloorY - 80 + (i*31)%64
+		vx := fixedpoint.ToIUPerSubstep(300)
+		if i%2 == 0 {
+			vx = -vx
+		}
+		w.CreateProjectile(x, y, vx, -fixedpoint.ToIUPerSubstep(60), cfg, FactionPlayer)
+	}
+}
+
+func BenchmarkUpdatePlayerPhysics(b *testing.B) {
+	stage := newBenchStage()
+	cfg := benchPhysicsConfig()
+	w := newBenchWorld()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Run back and forth, jumping every half second
+		right := (i/120)%2 == 0
+		UpdatePlayerInput(w, InputState{Left: !right, Right: right, JumpPressed: i%30 == 0}, cfg)
+		ApplyPlayerGravity(w, cfg)
+		for sub := 0; sub < SubstepsPerFrame; sub++ {
+			UpdatePlayerPhysics(w, stage, cfg)
+		}
+	}
+}
+
+func BenchmarkUpdateEnemyAI_500Enemies(b *testing.B) {
+	stage := newBenchStage()
+	cfg := benchPhysicsConfig()
+	w := newBenchWorld()
+	addBenchEnemies(w, 500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UpdateTimers(w)
+		ApplyEnemyGravity(w, stage, cfg.Gravity, cfg.MaxFallSpeed)
+		for sub := 0; sub < SubstepsPerFrame; sub++ {
+			UpdateEnemyAI(w, stage, ProjectileConfig{}, cfg)
+			w.Flush()
+		}
+	}
+}
+
+func BenchmarkUpdateDamage_1000Projectiles(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Hits destroy arrows and enemies, so each run starts from a fresh world
+		b.StopTimer()
+		w := newBenchWorld()
+		addBenchEnemies(w, 100)
+		addBenchArrows(w, 1000)
+		b.StartTimer()
+
+		UpdateDamage(w, fixedpoint.ToIUPerSubstep(200), fixedpoint.ToIUPerSubstep(150), 60)
+	}
+}
+
+func BenchmarkUpdateProjectiles(b *testing.B) {
+	stage := newBenchStage()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Arrows leave their range or stick in walls, so each run starts
+		// from a fresh world
+		b.StopTimer()
+		w := newBenchWorld()
+		addBenchArrows(w, 1000)
+		b.StartTimer()
+
+		ApplyProjectileGravity(w)
+		for sub := 0; sub < SubstepsPerFrame; sub++ {
+			UpdateProjectiles(w, stage)
+			w.Flush()
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package ecs
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// StaminaScale is the number of stamina units per stamina point.
+// At 60fps a per-second rate becomes the same number of units per frame.
+const StaminaScale = 60
+
+// BlockConfig holds the player shield tuning.
+// Values are pre-converted to frames and stamina units (like PhysicsConfig).
+type BlockConfig struct {
+	MaxStamina   int // stamina units (0 = blocking disabled)
+	Drain        int // units per frame while the shield is up
+	HitCost      int // units per blocked hit
+	Regen        int // units per frame
+	RegenDelay   int // frames after lowering the shield before regen starts
+	ReductionPct int // 0-100, frontal damage prevented (100 = negated)
+	ParryFrames  int // frames after raising the shield that deflect arrows
+}
+
+// UpdatePlayerBlock raises or lowers the player's shield (call once per frame,
+// before UpdatePlayerInput). held is the block button state.
+func UpdatePlayerBlock(w *World, held bool) {
+	id := w.PlayerID
+	cfg := w.Block
+	if id == 0 || cfg.MaxStamina <= 0 {
+		return
+	}
+
+	player := w.PlayerData[id]
+	dash := w.Dash[id]
+
+	if !held {
+		player.GuardBroken = false
+	}
+
+	// Raising the shield costs energy; keeping it up drains stamina
+	if held && !player.GuardBroken && !w.CrowdControl[id].IsStunned() && !dash.Active &&
+		(player.Blocking || w.Energy.spend(&player, w.Energy.BlockCost)) {
+		player.Blocking = true
+		player.BlockFrames++
+		player.StaminaRegenTimer = cfg.RegenDelay
+		cfg.spendStamina(&player, cfg.Drain)
+	} else {
+		player.Blocking = false
+		player.BlockFrames = 0
+		if player.StaminaRegenTimer > 0 {
+			player.StaminaRegenTimer--
+		} else {
+			player.StaminaUsed = max(0, player.StaminaUsed-cfg.Regen)
+		}
+	}
+
+	w.PlayerData[id] = player
+}
+
+// spendStamina drains stamina; running out breaks the guard until the
+// block button is released.
+func (cfg BlockConfig) spendStamina(player *Player, units int) {
+	player.StaminaUsed += units
+	if player.StaminaUsed >= cfg.MaxStamina {
+		player.StaminaUsed = cfg.MaxStamina
+		player.Blocking = false
+		player.BlockFrames = 0
+		player.GuardBroken = true
+	}
+}
+
+// absorbHit spends stamina for a blocked hit and returns the damage that
+// gets through the shield
+func (cfg BlockConfig) absorbHit(player *Player, damage int) int {
+	cfg.spendStamina(player, cfg.HitCost)
+	return fixedpoint.MulPct(damage, 100-cfg.ReductionPct)
+}
+
+// blocksFrom reports whether the player's raised shield faces an attacker
+// centered at pixel X attackerX
+func blocksFrom(w *World, playerID EntityID, player *Player, attackerX int) bool {
+	if !player.Blocking {
+		return false
+	}
+	centerX := w.Position[playerID].PixelX() + 8
+	if w.Facing[playerID].Right {
+		return attackerX >= centerX
+	}
+	return attackerX <= centerX
+}
+
+// parryProjectile reflects an enemy projectile back as a player-owned one
+func parryProjectile(w *World, id EntityID) {
+	proj := w.ProjectileData[id]
+	vel := w.Velocity[id]
+	pos := w.Position[id]
+
+	vel.X, vel.Y = -vel.X, -vel.Y
+	w.Faction[id] = FactionPlayer
+	proj.StartX = pos.PixelX()
+	proj.StartY = pos.PixelY()
+	proj.Target = 0
+	proj.Age = 0
+
+	w.Velocity[id] = vel
+	w.ProjectileData[id] = proj
+	SpawnSparks(w, pos.PixelX(), pos.PixelY())
+	w.Feedback.Trigger(FeedbackParry)
+	w.Events.Emit(Event{Type: EventProjectileParried, Entity: id})
+}
@@ -0,0 +1,112 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlockWorld() (*World, EntityID) {
+	w := NewWorld()
+	w.Block = BlockConfig{
+		MaxStamina:   100 * StaminaScale,
+		Drain:        30,
+		HitCost:      20 * StaminaScale,
+		Regen:        40,
+		RegenDelay:   30,
+		ReductionPct: 100,
+		ParryFrames:  9,
+	}
+	body := Hitbox{Width: 12, Height: 20}
+	player := w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	w.Facing[player] = Facing{Right: true}
+	return w, player
+}
+
+func TestUpdatePlayerBlock_DrainsAndRegenerates(t *testing.T) {
+	w, player := newBlockWorld()
+
+	UpdatePlayerBlock(w, true)
+	data := w.PlayerData[player]
+	assert.True(t, data.Blocking)
+	assert.Equal(t, 1, data.BlockFrames)
+	assert.Equal(t, 30, data.StaminaUsed)
+
+	UpdatePlayerBlock(w, false)
+	data = w.PlayerData[player]
+	assert.False(t, data.Blocking)
+	assert.Equal(t, 29, data.StaminaRegenTimer, "regen waits for the delay")
+
+	for i := 0; i < 30; i++ {
+		UpdatePlayerBlock(w, false)
+	}
+	assert.Equal(t, 0, w.PlayerData[player].StaminaUsed)
+}
+
+func TestUpdatePlayerBlock_GuardBreakNeedsRelease(t *testing.T) {
+	w, player := newBlockWorld()
+	data := w.PlayerData[player]
+	data.StaminaUsed = w.Block.MaxStamina - 10
+	w.PlayerData[player] = data
+
+	UpdatePlayerBlock(w, true)
+	data = w.PlayerData[player]
+	assert.False(t, data.Blocking, "stamina ran out")
+	assert.True(t, data.GuardBroken)
+
+	data.StaminaUsed = 0
+	w.PlayerData[player] = data
+	UpdatePlayerBlock(w, true)
+	assert.False(t, w.PlayerData[player].Blocking, "still held after the break")
+
+	UpdatePlayerBlock(w, false)
+	UpdatePlayerBlock(w, true)
+	assert.True(t, w.PlayerData[player].Blocking)
+}
+
+func TestUpdateDamage_BlockNegatesFrontalArrow(t *testing.T) {
+	w, player := newBlockWorld()
+	for i := 0; i < 20; i++ {
+		UpdatePlayerBlock(w, true) // past the parry window
+	}
+	w.Events.Drain()
+	proj := w.CreateProjectile(106, 105, -90, 0, ProjectileConfig{Damage: 15, HitboxWidth: 12, HitboxHeight: 4}, FactionEnemy)
+
+	result := UpdateDamage(w, 100, 50, 60)
+	w.Flush()
+
+	assert.False(t, result.PlayerDamaged)
+	assert.False(t, w.Exists(proj))
+	assert.Equal(t, 100, w.Health[player].Current)
+	assert.Equal(t, []Event{{Type: EventAttackBlocked, Entity: proj, Amount: 15}}, w.Events.Drain())
+}
+
+func TestUpdateDamage_BlockIgnoresAttacksFromBehind(t *testing.T) {
+	w, player := newBlockWorld()
+	for i := 0; i < 20; i++ {
+		UpdatePlayerBlock(w, true)
+	}
+	w.CreateProjectile(98, 105, 90, 0, ProjectileConfig{Damage: 15, HitboxWidth: 4, HitboxHeight: 4}, FactionEnemy)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 85, w.Health[player].Current)
+}
+
+func TestUpdateDamage_ParryReflectsArrow(t *testing.T) {
+	w, player := newBlockWorld()
+	UpdatePlayerBlock(w, true)
+	w.Events.Drain()
+	proj := w.CreateProjectile(106, 105, -90, 0, ProjectileConfig{Damage: 15, HitboxWidth: 12, HitboxHeight: 4}, FactionEnemy)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	require.True(t, w.Exists(proj))
+	assert.Equal(t, FactionPlayer, w.Faction[proj])
+	assert.Equal(t, 90, w.Velocity[proj].X)
+	assert.Equal(t, 100, w.Health[player].Current)
+	assert.Equal(t, 30, w.PlayerData[player].StaminaUsed, "parry costs no extra stamina")
+	assert.Equal(t, []Event{{Type: EventProjectileParried, Entity: proj}}, w.Events.Drain())
+}
@@ -0,0 +1,54 @@
+package ecs
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// bounceProjectile moves a bouncing projectile by its velocity, one axis
+// and one IU at a time, reflecting the velocity off the tiles it meets and
+// keeping BouncePercent of it. Landing also slows its roll; once a landing
+// is too soft to bounce, it stops where it lies.
+func bounceProjectile(stage Stage, pos *Position, vel *Velocity, proj *Projectile) {
+	for i, dx := 0, vel.X; i < abs(dx); i++ {
+		step := sign(dx)
+		if stage.IsSolidAt((pos.X+step)/PositionScale, pos.PixelY()) {
+			vel.X = fixedpoint.MulPct(-vel.X, proj.BouncePercent)
+			break
+		}
+		pos.X += step
+	}
+
+	for i, dy := 0, vel.Y; i < abs(dy); i++ {
+		step := sign(dy)
+		if !stage.IsSolidAt(pos.PixelX(), (pos.Y+step)/PositionScale) {
+			pos.Y += step
+			continue
+		}
+		vel.Y = fixedpoint.MulPct(-vel.Y, proj.BouncePercent)
+		if step > 0 {
+			vel.X = fixedpoint.MulPct(vel.X, proj.BouncePercent)
+			if -vel.Y <= proj.GravityAccel {
+				vel.X, vel.Y = 0, 0 // resting on the floor
+			}
+		}
+		break
+	}
+}
+
+// UpdateBombs counts down the fuses of bombs and blows up those whose fuse
+// ran out with their explosion (once per frame)
+func UpdateBombs(w *World, stage Stage) {
+	for _, id := range w.Query(TagProjectile) {
+		proj := w.ProjectileData[id]
+		if !proj.IsBomb() {
+			continue
+		}
+		proj.Fuse--
+		w.ProjectileData[id] = proj
+		if proj.Fuse > 0 {
+			continue
+		}
+
+		pos := w.Position[id]
+		SpawnExplosion(w, stage, pos.PixelX(), pos.PixelY(), proj.Explosion)
+		w.Defer(Destroy(id))
+	}
+}
@@ -0,0 +1,116 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBomb = ProjectileConfig{
+	GravityAccel:  10,
+	MaxFallSpeed:  400,
+	MaxRange:      50,
+	Damage:        40,
+	HitboxWidth:   8,
+	HitboxHeight:  8,
+	BouncePercent: 50,
+	FuseFrames:    90,
+	Explosion:     Explosion{Radius: 40, Damage: 40, Knockback: 100, SparePlayer: true},
+}
+
+// runBombFrame runs one frame of bomb movement like the scheduler does
+func runBombFrame(w *World, stage Stage) {
+	ApplyProjectileGravity(w)
+	for range SubstepsPerFrame {
+		UpdateProjectiles(w, stage)
+		w.Flush()
+	}
+}
+
+func TestUpdateProjectiles_BombBouncesOffFloor(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	for tx := range 40 {
+		stage.setSolid(tx, 10)
+	}
+	w := NewWorld()
+	id := w.CreateProjectile(100, 155, 0, 200, testBomb, FactionPlayer)
+
+	runBombFrame(w, stage)
+
+	assert.False(t, w.ProjectileData[id].Stuck, "bombs never stick")
+	assert.Less(t, w.Velocity[id].Y, 0, "rebounds up")
+	assert.Greater(t, w.Velocity[id].Y, -200, "losing speed")
+	assert.Less(t, w.Position[id].PixelY(), 160)
+}
+
+func TestUpdateProjectiles_BombBouncesOffWall(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	stage.setSolid(8, 6)
+	w := NewWorld()
+	cfg := testBomb
+	cfg.GravityAccel = 0
+	id := w.CreateProjectile(120, 100, 300, 0, cfg, FactionPlayer)
+
+	runBombFrame(w, stage)
+
+	assert.Equal(t, -150, w.Velocity[id].X)
+	assert.Less(t, w.Position[id].PixelX(), 128)
+}
+
+func TestUpdateProjectiles_BombComesToRest(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	for tx := range 40 {
+		stage.setSolid(tx, 10)
+	}
+	w := NewWorld()
+	cfg := testBomb
+	cfg.FuseFrames = 1000
+	id := w.CreateProjectile(100, 120, 100, 0, cfg, FactionPlayer)
+
+	for range 300 {
+		runBombFrame(w, stage)
+	}
+
+	require.True(t, w.Exists(id), "outlives its range")
+	rest := w.Position[id]
+	runBombFrame(w, stage)
+	assert.Equal(t, rest.PixelX(), w.Position[id].PixelX())
+	assert.Equal(t, 159, w.Position[id].PixelY(), "lying on the floor")
+}
+
+func TestUpdateBombs_ExplodesWhenFuseRunsOut(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	player := w.CreatePlayer(90, 90, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(110, 90, EnemyConfig{MaxHealth: 100, HitboxWidth: 16, HitboxHeight: 16, Flying: true}, true)
+	cfg := testBomb
+	cfg.FuseFrames = 3
+	bomb := w.CreateProjectile(110, 100, 0, 0, cfg, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 100, w.Health[enemy].Current, "passes through enemies")
+
+	UpdateBombs(w, stage)
+	UpdateBombs(w, stage)
+	w.Flush()
+	require.True(t, w.Exists(bomb))
+	assert.Equal(t, 1, w.ProjectileData[bomb].Fuse)
+
+	UpdateBombs(w, stage)
+	w.Flush()
+	assert.False(t, w.Exists(bomb))
+	assert.Less(t, w.Health[enemy].Current, 100)
+	assert.Equal(t, 100, w.Health[player].Current, "player bombs spare the player")
+}
+
+func TestPredictProjectilePath_BombEndsAtExplosion(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	cfg := testBomb
+	cfg.FuseFrames = 5
+
+	path := PredictProjectilePath(w, stage, 100, 100, 50, 0, cfg, 100*SubstepsPerFrame)
+
+	assert.Len(t, path, 1+5*SubstepsPerFrame)
+}
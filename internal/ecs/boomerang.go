@@ -0,0 +1,84 @@
+package ecs
+
+// Boomerang tuning
+const (
+	boomerangTurnDiv     = 16 // the return turns up to 1/16 of the speed per substep
+	boomerangRehitFrames = 30 // an enemy can't be hit again this soon on the same pass
+)
+
+// updateBoomerang turns a boomerang back once it is its max range away
+// from where it was thrown, in any direction, and lets the player catch it
+// on the way back, giving back the ammo the throw spent. It reports whether
+// the boomerang was caught (it is then destroyed at the next sync point).
+// Without a player to return to it just drops out of the world.
+func updateBoomerang(w *World, id EntityID, pos Position, proj *Projectile) bool {
+	playerID := w.PlayerID
+	if !proj.Returning {
+		if boomerangAtRange(pos, proj) {
+			proj.Returning = true
+			clearBoomerangHits(w, id)
+		}
+		return false
+	}
+	if playerID == 0 {
+		w.Defer(Destroy(id))
+		return true
+	}
+
+	playerPos, hb, facing := w.Position[playerID], w.HitboxTrapezoid[playerID], w.Facing[playerID]
+	px, py, pw, ph := hb.Body.GetWorldRect(playerPos.PixelX(), playerPos.PixelY(), facing.Right, hb.MirrorWidth())
+	projHit := w.Hitbox[id]
+	if !rectsOverlap(pos.PixelX()+projHit.OffsetX, pos.PixelY()+projHit.OffsetY, projHit.Width, projHit.Height, px, py, pw, ph) {
+		return false
+	}
+
+	if proj.Ammo {
+		player := w.PlayerData[playerID]
+		player.Ammo++
+		w.PlayerData[playerID] = player
+	}
+	w.Defer(Destroy(id))
+	return true
+}
+
+// boomerangAtRange reports whether a boomerang is farther than its max
+// range (pixels) from where it was thrown
+func boomerangAtRange(pos Position, proj *Projectile) bool {
+	dx, dy := pos.PixelX()-proj.StartX, pos.PixelY()-proj.StartY
+	return dx*dx+dy*dy > proj.MaxRange*proj.MaxRange
+}
+
+// steerBoomerang turns a returning boomerang toward the player's body
+// center for one substep, each velocity component moving at most
+// 1/boomerangTurnDiv of its launch speed, so it swings around rather than
+// snapping back
+func steerBoomerang(w *World, pos Position, vel *Velocity, proj *Projectile) {
+	if w.PlayerID == 0 {
+		return
+	}
+	tx, ty := HitboxCenter(w, w.PlayerID)
+	dx, dy := tx-pos.PixelX(), ty-pos.PixelY()
+	dist := isqrt(dx*dx + dy*dy)
+	if dist == 0 || proj.Speed == 0 {
+		return
+	}
+
+	turn := max(1, proj.Speed/boomerangTurnDiv)
+	vel.X += clampInt(divRound(dx*proj.Speed, dist)-vel.X, -turn, turn)
+	vel.Y += clampInt(divRound(dy*proj.Speed, dist)-vel.Y, -turn, turn)
+}
+
+// boomerangHit keeps a boomerang that hit an enemy from hitting it again
+// until the boomerang turns back or boomerangRehitFrames pass
+func boomerangHit(w *World, projID, enemyID EntityID) {
+	w.ContactCooldowns[ContactPair{projID, enemyID}] = boomerangRehitFrames
+}
+
+// clearBoomerangHits lets a boomerang hit every enemy again on its way back
+func clearBoomerangHits(w *World, id EntityID) {
+	for pair := range w.ContactCooldowns {
+		if pair.Attacker == id {
+			delete(w.ContactCooldowns, pair)
+		}
+	}
+}
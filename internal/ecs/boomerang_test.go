@@ -0,0 +1,103 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBoomerang = ProjectileConfig{
+	MaxRange:     100,
+	Damage:       10,
+	HitboxWidth:  8,
+	HitboxHeight: 8,
+	Boomerang:    true,
+	Ammo:         true,
+}
+
+// runBoomerangFrame runs the substeps and damage of one frame
+func runBoomerangFrame(w *World, stage Stage) {
+	for range SubstepsPerFrame {
+		UpdateProjectiles(w, stage)
+		w.Flush()
+	}
+	UpdateDamage(w, 100, 50, 60)
+	updateContactCooldowns(w)
+}
+
+func TestUpdateProjectiles_BoomerangReturnsAndIsCaught(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	id := w.CreateProjectile(108, 112, 300, 0, testBoomerang, FactionPlayer)
+
+	farthest := 0
+	for frame := 0; frame < 120 && w.Exists(id); frame++ {
+		runBoomerangFrame(w, stage)
+		if w.Exists(id) {
+			farthest = max(farthest, w.Position[id].PixelX())
+			if w.ProjectileData[id].Returning {
+				assert.Less(t, w.Position[id].PixelX(), 108+100+20, "turned near its range")
+			}
+		}
+	}
+
+	assert.False(t, w.Exists(id), "caught")
+	assert.Greater(t, farthest, 108+100)
+	assert.Equal(t, 1, w.PlayerData[player].Ammo, "the ammo comes back")
+}
+
+func TestUpdateProjectiles_BoomerangFollowsThePlayer(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	id := w.CreateProjectile(108, 112, 300, 0, testBoomerang, FactionPlayer)
+	for frame := 0; frame < 120 && !w.ProjectileData[id].Returning; frame++ {
+		runBoomerangFrame(w, stage)
+	}
+	require.True(t, w.ProjectileData[id].Returning)
+
+	// The player moved up while it was out
+	w.Position[player] = Position{X: 150 * PositionScale, Y: 40 * PositionScale}
+	for frame := 0; frame < 120 && w.Exists(id); frame++ {
+		runBoomerangFrame(w, stage)
+	}
+	assert.False(t, w.Exists(id), "caught where the player is now")
+}
+
+func TestUpdateDamage_BoomerangHitsOnBothPasses(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(160, 100, EnemyConfig{MaxHealth: 100, HitboxWidth: 16, HitboxHeight: 24, Flying: true}, true)
+	id := w.CreateProjectile(108, 112, 300, 0, testBoomerang, FactionPlayer)
+
+	hits := 0
+	for frame := 0; frame < 120 && w.Exists(id); frame++ {
+		before := w.Health[enemy].Current
+		runBoomerangFrame(w, stage)
+		if w.Health[enemy].Current < before {
+			hits++
+		}
+	}
+
+	assert.Equal(t, 2, hits, "once out, once back")
+	assert.Equal(t, 80, w.Health[enemy].Current)
+}
+
+func TestUpdateProjectiles_BoomerangWithoutAmmoGivesNone(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	cfg := testBoomerang
+	cfg.Ammo = false
+	id := w.CreateProjectile(108, 112, 300, 0, cfg, FactionPlayer)
+
+	for frame := 0; frame < 120 && w.Exists(id); frame++ {
+		runBoomerangFrame(w, stage)
+	}
+
+	assert.False(t, w.Exists(id))
+	assert.Zero(t, w.PlayerData[player].Ammo)
+}
@@ -0,0 +1,126 @@
+package ecs
+
+// chargeDirScale is the fixed-point scale of a charge's locked direction
+const chargeDirScale = 256
+
+// updateChargerAI winds up once the player is in detect range, then dashes
+// at ChargeSpeed in the direction the player was in when the wind-up ended:
+// straight along the ground for walkers, straight at the player for flyers.
+// The dash doesn't turn; it ends on reaching the player or a wall, and a
+// wall leaves the charger stunned for ChargeStunFrames. It rests for its
+// attack cooldown after every dash. A started wind-up always ends in a dash.
+func updateChargerAI(w *World, stage Stage, id EntityID, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dy, dist int) {
+	// Apply Y movement from velocity (gravity is applied separately per frame)
+	if !ai.Flying {
+		moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
+	}
+
+	if ai.Charging {
+		dashCharger(w, stage, id, hb, pos, ai, facing)
+		return
+	}
+
+	if ai.Telegraph != TelegraphCharge && (ai.AttackTimer > 0 || (dist > ai.DetectRange && !ai.Alerted())) {
+		return
+	}
+	if ai.Telegraph != TelegraphCharge {
+		facing.Right = dx > 0
+	}
+	if !windUp(ai, TelegraphCharge) {
+		return
+	}
+
+	ai.Charging = true
+	ai.ChargeDirX, ai.ChargeDirY = chargeDirection(ai, facing, dx, dy)
+}
+
+// chargeDirection returns the unit direction (in chargeDirScale) of a
+// charge toward the player's offset (dx, dy); walkers only charge sideways
+func chargeDirection(ai *AI, facing *Facing, dx, dy int) (int, int) {
+	if !ai.Flying {
+		dy = 0
+	}
+	length := isqrt(dx*dx + dy*dy)
+	if length == 0 {
+		if facing.Right {
+			return chargeDirScale, 0
+		}
+		return -chargeDirScale, 0
+	}
+	return divRound(dx*chargeDirScale, length), divRound(dy*chargeDirScale, length)
+}
+
+// dashCharger moves a charging enemy for one substep and ends the charge
+// when it runs into a wall or the player
+func dashCharger(w *World, stage Stage, id EntityID, hb Hitbox, pos *Position, ai *AI, facing *Facing) {
+	moveX := divRound(ai.ChargeDirX*ai.ChargeSpeed, chargeDirScale)
+	moveY := divRound(ai.ChargeDirY*ai.ChargeSpeed, chargeDirScale)
+	if ai.ChargeDirX != 0 {
+		facing.Right = ai.ChargeDirX > 0
+	}
+
+	if chargeStep(stage, hb, pos, moveX, moveY) {
+		ai.Charging = false
+		ai.AttackTimer = ai.AttackCooldown
+		stunCharger(w, id, ai.ChargeStunFrames)
+		return
+	}
+	if chargerReachedPlayer(w, hb, *pos) {
+		ai.Charging = false
+		ai.AttackTimer = ai.AttackCooldown
+	}
+}
+
+// chargeStep moves a charging enemy by (moveX, moveY) IU, one axis and one
+// IU at a time, and reports whether it ran into a wall
+func chargeStep(stage Stage, hb Hitbox, pos *Position, moveX, moveY int) bool {
+	for i, step := 0, sign(moveX); i < abs(moveX); i++ {
+		if nextPixelX := (pos.X + step) / PositionScale; nextPixelX != pos.PixelX() {
+			checkX := nextPixelX + hb.OffsetX
+			if step > 0 {
+				checkX += hb.Width - 1
+			}
+			if isSolidRect(stage, checkX, pos.PixelY()+hb.OffsetY, 1, hb.Height) {
+				return true
+			}
+		}
+		pos.X += step
+	}
+
+	for i, step := 0, sign(moveY); i < abs(moveY); i++ {
+		if nextPixelY := (pos.Y + step) / PositionScale; nextPixelY != pos.PixelY() {
+			checkY := nextPixelY + hb.OffsetY
+			if step > 0 {
+				checkY += hb.Height - 1
+			}
+			if isSolidRect(stage, pos.PixelX()+hb.OffsetX, checkY, hb.Width, 1) {
+				return true
+			}
+		}
+		pos.Y += step
+	}
+	return false
+}
+
+// chargerReachedPlayer reports whether a charger's hitbox touches the
+// player's body
+func chargerReachedPlayer(w *World, hb Hitbox, pos Position) bool {
+	playerID := w.PlayerID
+	if playerID == 0 {
+		return false
+	}
+	playerPos, body, facing := w.Position[playerID], w.HitboxTrapezoid[playerID], w.Facing[playerID]
+	px, py, pw, ph := body.Body.GetWorldRect(playerPos.PixelX(), playerPos.PixelY(), facing.Right, body.MirrorWidth())
+	return rectsOverlap(pos.PixelX()+hb.OffsetX-1, pos.PixelY()+hb.OffsetY, hb.Width+2, hb.Height, px, py, pw, ph)
+}
+
+// stunCharger stuns a charger that ran into a wall. Unlike Stun it ignores
+// armor: the charger stuns itself.
+func stunCharger(w *World, id EntityID, frames int) {
+	if frames <= 0 {
+		frames = w.ccProfile(id).StunFrames
+	}
+	cc := w.CrowdControl[id]
+	cc.StunTimer = max(cc.StunTimer, frames)
+	w.CrowdControl[id] = cc
+}
@@ -0,0 +1,116 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCharger = EnemyConfig{
+	MaxHealth:        50,
+	ContactDamage:    10,
+	HitboxWidth:      16,
+	HitboxHeight:     16,
+	AIType:           AICharger,
+	DetectRange:      200,
+	AttackCooldown:   60,
+	MoveSpeed:        10,
+	WindupFrames:     5,
+	ChargeSpeed:      300,
+	ChargeStunFrames: 40,
+}
+
+// stepUntilChargeEnds runs frames until the enemy's charge is over
+func stepUntilChargeEnds(t *testing.T, w *World, stage Stage, enemy EntityID) {
+	for frame := 0; frame < 60 && w.AI[enemy].Charging; frame++ {
+		stepFrame(w, stage)
+	}
+	require.False(t, w.AI[enemy].Charging, "the charge ended")
+}
+
+func TestChargerAI_WindsUpThenDashes(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(250, 100, testCharger, false)
+
+	stepFrame(w, stage)
+	assert.Equal(t, TelegraphCharge, w.AI[enemy].Telegraph)
+	assert.Equal(t, 250, w.Position[enemy].PixelX(), "holds still while winding up")
+
+	for range 5 {
+		stepFrame(w, stage)
+	}
+	require.True(t, w.AI[enemy].Charging)
+	assert.Less(t, w.Position[enemy].PixelX(), 245, "dashes far faster than it walks")
+
+	stepUntilChargeEnds(t, w, stage, enemy)
+	assert.Less(t, w.Position[enemy].PixelX(), 130, "ran into the player")
+	assert.Zero(t, w.CrowdControl[enemy].StunTimer, "only walls stun it")
+	assert.Greater(t, w.AI[enemy].AttackTimer, 0, "rests before the next charge")
+}
+
+func TestChargerAI_StunsItselfOnWall(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	stage.setSolid(10, 6)
+	stage.setSolid(10, 7)
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	cfg := testCharger
+	cfg.WindupFrames = 0
+	cfg.ArmoredCharge = true
+	enemy := w.CreateEnemy(250, 100, cfg, false)
+
+	stepFrame(w, stage)
+	require.True(t, w.AI[enemy].Charging)
+	stepUntilChargeEnds(t, w, stage, enemy)
+
+	assert.Equal(t, 176, w.Position[enemy].PixelX(), "stopped at the wall")
+	assert.Equal(t, 40, w.CrowdControl[enemy].StunTimer, "armor doesn't spare it")
+}
+
+func TestChargerAI_FlyerDashesInALockedLine(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	cfg := testCharger
+	cfg.WindupFrames = 0
+	cfg.Flying = true
+	enemy := w.CreateEnemy(200, 0, cfg, false)
+
+	stepFrame(w, stage)
+	require.True(t, w.AI[enemy].Charging)
+	dirX, dirY := w.AI[enemy].ChargeDirX, w.AI[enemy].ChargeDirY
+	assert.Less(t, dirX, 0)
+	assert.Greater(t, dirY, 0, "flyers charge diagonally")
+
+	// Dodging doesn't turn the charge
+	w.Position[player] = Position{X: 100 * PositionScale, Y: 300 * PositionScale}
+	stepFrame(w, stage)
+	assert.Equal(t, dirX, w.AI[enemy].ChargeDirX)
+	assert.Equal(t, dirY, w.AI[enemy].ChargeDirY)
+}
+
+func TestChargerAI_IdlesOutOfRange(t *testing.T) {
+	stage := newMockStage(640, 480, 16)
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(400, 100, testCharger, false)
+
+	stepFrame(w, stage)
+
+	assert.Equal(t, TelegraphNone, w.AI[enemy].Telegraph)
+	assert.False(t, w.AI[enemy].Charging)
+	assert.Equal(t, 400, w.Position[enemy].PixelX())
+}
+
+func TestCreateEnemy_ChargeSpeedDefaultsToMoveSpeed(t *testing.T) {
+	w := NewWorld()
+	cfg := testCharger
+	cfg.ChargeSpeed = 0
+
+	enemy := w.CreateEnemy(0, 0, cfg, false)
+
+	assert.Equal(t, cfg.MoveSpeed, w.AI[enemy].ChargeSpeed)
+}
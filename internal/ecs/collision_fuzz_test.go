@@ -0,0 +1,274 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Property tests for the substep movers: entities are spawned at random
+// positions with random (bounded) velocities in a random walled stage, and
+// after every substep they must not be inside a solid tile, must not leave
+// the stage and must respect their speed clamps.
+//
+// The seeds below run as regular tests; explore more with
+//
+//	go test ./internal/ecs -run '^$' -fuzz FuzzPlayerCollision
+
+const (
+	fuzzStageWidth  = 24 // tiles
+	fuzzStageHeight = 16
+	fuzzTileSize    = 16
+	fuzzFrames      = 120
+)
+
+var fuzzSeeds = []int64{0, 1, 2, 3, 42, 1234, 99991}
+
+// newFuzzStage returns a stage walled on every side with random blocks inside
+func newFuzzStage(rng *rand.Rand) *mockStage {
+	stage := newMockStage(fuzzStageWidth*fuzzTileSize, fuzzStageHeight*fuzzTileSize, fuzzTileSize)
+	for ty := 0; ty < fuzzStageHeight; ty++ {
+		for tx := 0; tx < fuzzStageWidth; tx++ {
+			border := tx == 0 || ty == 0 || tx == fuzzStageWidth-1 || ty == fuzzStageHeight-1
+			if border || rng.Intn(100) < 15 {
+				stage.setSolid(tx, ty)
+			}
+		}
+	}
+	return stage
+}
+
+// inStage reports whether a pixel rect lies inside the stage's inner area
+// (inside the border walls)
+func inStage(x, y, w, h int) bool {
+	return x >= fuzzTileSize && y >= fuzzTileSize &&
+		x+w <= (fuzzStageWidth-1)*fuzzTileSize && y+h <= (fuzzStageHeight-1)*fuzzTileSize
+}
+
+// freeSpot returns a random pixel position where the rect at offset
+// (ox, oy) with size w x h overlaps no solid tile
+func freeSpot(rng *rand.Rand, stage *mockStage, ox, oy, w, h int) (int, int) {
+	for {
+		x := fuzzTileSize + rng.Intn((fuzzStageWidth-2)*fuzzTileSize-w-ox)
+		y := fuzzTileSize + rng.Intn((fuzzStageHeight-2)*fuzzTileSize-h-oy)
+		if !isSolidRect(stage, x+ox, y+oy, w, h) && inStage(x+ox, y+oy, w, h) {
+			return x, y
+		}
+	}
+}
+
+func FuzzPlayerCollision(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		rng := rand.New(rand.NewSource(seed))
+		stage := newFuzzStage(rng)
+		cfg := benchPhysicsConfig()
+
+		w := NewWorld()
+		profile := PlayerProfile{
+			Hitbox: HitboxTrapezoid{
+				Head: Hitbox{OffsetX: 4, OffsetY: 0, Width: 8, Height: 6},
+				Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
+				Feet: Hitbox{OffsetX: 2, OffsetY: 18, Width: 12, Height: 6},
+			},
+			MaxHealth: 100,
+		}
+		// Spawn where the whole sprite (body, head and feet) is clear
+		x, y := freeSpot(rng, stage, 0, 0, 16, 24)
+		id := w.CreatePlayer(x, y, profile)
+		w.Velocity[id] = Velocity{
+			X: rng.Intn(2*cfg.DashSpeed+1) - cfg.DashSpeed,
+			Y: rng.Intn(cfg.JumpForce+cfg.MaxFallSpeed+1) - cfg.JumpForce,
+		}
+
+		for frame := 0; frame < fuzzFrames; frame++ {
+			UpdateTimers(w)
+			UpdatePlayerInput(w, InputState{
+				Left:         rng.Intn(3) == 0,
+				Right:        rng.Intn(3) == 0,
+				Down:         rng.Intn(8) == 0,
+				JumpPressed:  rng.Intn(10) == 0,
+				JumpReleased: rng.Intn(10) == 0,
+				Dash:         rng.Intn(30) == 0,
+			}, cfg)
+			ApplyPlayerGravity(w, cfg)
+			for sub := 0; sub < SubstepsPerFrame; sub++ {
+				UpdatePlayerPhysics(w, stage, cfg)
+
+				pos, vel := w.Position[id], w.Velocity[id]
+				bx, by, bw, bh := profile.Hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), w.Facing[id].Right, profile.Hitbox.MirrorWidth())
+				if isSolidRect(stage, bx, by, bw, bh) {
+					t.Fatalf("seed %d frame %d: player body inside a solid tile at (%d, %d)", seed, frame, bx, by)
+				}
+				if !inStage(bx, by, bw, bh) {
+					t.Fatalf("seed %d frame %d: player left the stage at (%d, %d)", seed, frame, bx, by)
+				}
+				if vel.Y > cfg.MaxFallSpeed {
+					t.Fatalf("seed %d frame %d: fall speed %d above max %d", seed, frame, vel.Y, cfg.MaxFallSpeed)
+				}
+				if limit := max(cfg.MaxSpeed, cfg.DashSpeed); abs(vel.X) > limit {
+					t.Fatalf("seed %d frame %d: horizontal speed %d above max %d", seed, frame, vel.X, limit)
+				}
+			}
+		}
+	})
+}
+
+func FuzzEnemyCollision(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		rng := rand.New(rand.NewSource(seed))
+		stage := newFuzzStage(rng)
+		cfg := benchPhysicsConfig()
+
+		w := NewWorld()
+		w.Rand = rng
+		px, py := freeSpot(rng, stage, 0, 0, 16, 24)
+		w.CreatePlayer(px, py, PlayerProfile{MaxHealth: 100})
+
+		aiTypes := []AIType{AIPatrol, AIAggressive, AIChase}
+		var enemies []EntityID
+		for i := 0; i < 20; i++ {
+			// Sizes from a small bat to a large brute
+			hb := Hitbox{OffsetX: rng.Intn(5), OffsetY: rng.Intn(5), Width: 6 + rng.Intn(24), Height: 6 + rng.Intn(30)}
+			x, y := freeSpot(rng, stage, hb.OffsetX, hb.OffsetY, hb.Width, hb.Height)
+			id := w.CreateEnemy(x, y, EnemyConfig{
+				MaxHealth:     20,
+				MoveSpeed:     fixedpoint.ToIUPerSubstep(float64(30 + rng.Intn(120))),
+				HitboxOffsetX: hb.OffsetX,
+				HitboxOffsetY: hb.OffsetY,
+				HitboxWidth:   hb.Width,
+				HitboxHeight:  hb.Height,
+				AIType:        aiTypes[rng.Intn(len(aiTypes))],
+				DetectRange:   200,
+				PatrolDist:    16 + rng.Intn(64),
+				JumpForce:     fixedpoint.ToIUPerSubstep(float64(rng.Intn(300))),
+				Flying:        rng.Intn(5) == 0,
+			}, rng.Intn(2) == 0)
+			// Knockback-sized kick
+			w.Velocity[id] = Velocity{X: rng.Intn(401) - 200, Y: rng.Intn(cfg.MaxFallSpeed+201) - 200}
+			enemies = append(enemies, id)
+		}
+
+		for frame := 0; frame < fuzzFrames; frame++ {
+			UpdateTimers(w)
+			ApplyEnemyGravity(w, stage, cfg.Gravity, cfg.MaxFallSpeed)
+			for sub := 0; sub < SubstepsPerFrame; sub++ {
+				UpdateEnemyAI(w, stage, ProjectileConfig{}, cfg)
+
+				for _, id := range enemies {
+					pos, hb := w.Position[id], w.Hitbox[id]
+					x, y := pos.PixelX()+hb.OffsetX, pos.PixelY()+hb.OffsetY
+					if isSolidRect(stage, x, y, hb.Width, hb.Height) {
+						t.Fatalf("seed %d frame %d: enemy %d inside a solid tile at (%d, %d)", seed, frame, id, x, y)
+					}
+					if !inStage(x, y, hb.Width, hb.Height) {
+						t.Fatalf("seed %d frame %d: enemy %d left the stage at (%d, %d)", seed, frame, id, x, y)
+					}
+					if vy := w.Velocity[id].Y; vy > max(cfg.MaxFallSpeed, 200) {
+						t.Fatalf("seed %d frame %d: enemy %d fall speed %d above max", seed, frame, id, vy)
+					}
+				}
+			}
+		}
+	})
+}
+
+func FuzzPickupCollision(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		rng := rand.New(rand.NewSource(seed))
+		stage := newFuzzStage(rng)
+
+		w := NewWorld()
+		var pickups []EntityID
+		for i := 0; i < 30; i++ {
+			cfg := PickupConfig{
+				Gravity:       fixedpoint.ToIUAccelPerFrame(400),
+				BouncePercent: rng.Intn(101),
+				HitboxWidth:   4 + rng.Intn(9),
+				HitboxHeight:  4 + rng.Intn(9),
+			}
+			x, y := freeSpot(rng, stage, 0, 0, cfg.HitboxWidth, cfg.HitboxHeight)
+			id := w.CreatePickup(x, y, PickupGold, 1, cfg)
+			w.Velocity[id] = Velocity{X: rng.Intn(201) - 100, Y: rng.Intn(201) - 150}
+			pickups = append(pickups, id)
+		}
+
+		for frame := 0; frame < fuzzFrames; frame++ {
+			ApplyPickupGravity(w)
+			for sub := 0; sub < SubstepsPerFrame; sub++ {
+				UpdatePickupPhysics(w, stage)
+
+				for _, id := range pickups {
+					pos, pickup := w.Position[id], w.PickupData[id]
+					x, y := pos.PixelX(), pos.PixelY()
+					if isSolidRect(stage, x, y, pickup.HitboxWidth, pickup.HitboxHeight) {
+						t.Fatalf("seed %d frame %d: pickup %d inside a solid tile at (%d, %d)", seed, frame, id, x, y)
+					}
+					if !inStage(x, y, pickup.HitboxWidth, pickup.HitboxHeight) {
+						t.Fatalf("seed %d frame %d: pickup %d left the stage at (%d, %d)", seed, frame, id, x, y)
+					}
+				}
+			}
+		}
+	})
+}
+
+func FuzzProjectileCollision(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		rng := rand.New(rand.NewSource(seed))
+		stage := newFuzzStage(rng)
+
+		w := NewWorld()
+		cfg := ProjectileConfig{
+			GravityAccel:  rng.Intn(3),
+			MaxFallSpeed:  fixedpoint.ToIUPerSubstep(400),
+			MaxRange:      fuzzStageWidth * fuzzTileSize,
+			StuckDuration: fuzzFrames,
+		}
+		var arrows []EntityID
+		for i := 0; i < 30; i++ {
+			x, y := freeSpot(rng, stage, 0, 0, 1, 1)
+			// Up to 1.5 tiles per substep, faster than any configured arrow
+			vx := rng.Intn(2*384+1) - 384
+			vy := rng.Intn(2*384+1) - 384
+			arrows = append(arrows, w.CreateProjectile(x, y, vx, vy, cfg, FactionPlayer))
+		}
+
+		for frame := 0; frame < fuzzFrames; frame++ {
+			ApplyProjectileGravity(w)
+			for sub := 0; sub < SubstepsPerFrame; sub++ {
+				UpdateProjectiles(w, stage)
+
+				for _, id := range arrows {
+					if !w.Exists(id) {
+						continue
+					}
+					pos, proj := w.Position[id], w.ProjectileData[id]
+					x, y := pos.PixelX(), pos.PixelY()
+					// Arrows stop at the first solid pixel they enter
+					if !proj.Stuck && stage.IsSolidAt(x, y) {
+						t.Fatalf("seed %d frame %d: arrow %d flying inside a solid tile at (%d, %d)", seed, frame, id, x, y)
+					}
+					if !inStage(x, y, 1, 1) && !stage.IsSolidAt(x, y) {
+						t.Fatalf("seed %d frame %d: arrow %d passed through the border at (%d, %d)", seed, frame, id, x, y)
+					}
+					if vy := w.Velocity[id].Y; vy > max(cfg.MaxFallSpeed, 384) {
+						t.Fatalf("seed %d frame %d: arrow %d fall speed %d above max", seed, frame, id, vy)
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package ecs
+
+// Command is a structural change to the world (an entity destroyed or
+// spawned) put off with Defer until the next sync point
+type Command struct {
+	destroy EntityID
+	spawn   func(w *World)
+}
+
+// Destroy returns a command that destroys an entity
+func Destroy(id EntityID) Command {
+	return Command{destroy: id}
+}
+
+// Spawn returns a command that creates entities by calling fn
+func Spawn(fn func(w *World)) Command {
+	return Command{spawn: fn}
+}
+
+// Defer queues a command until the next Flush. Systems defer destroying
+// and spawning entities instead of doing it while they iterate, so the
+// sets other systems query only change between them. The scheduler
+// flushes after every phase and every substep.
+func (w *World) Defer(c Command) {
+	w.commands = append(w.commands, c)
+}
+
+// Flush applies the deferred commands in the order they were deferred,
+// including those deferred by the commands themselves
+func (w *World) Flush() {
+	for i := 0; i < len(w.commands); i++ {
+		c := w.commands[i]
+		if c.spawn != nil {
+			c.spawn(w)
+		} else {
+			w.DestroyEntity(c.destroy)
+		}
+	}
+	clear(w.commands)
+	w.commands = w.commands[:0]
+}
+
+// Pending returns the number of deferred commands not yet flushed
+func (w *World) Pending() int {
+	return len(w.commands)
+}
@@ -0,0 +1,63 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefer_AppliedOnFlush(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	w.Position[a] = Position{}
+
+	var spawned EntityID
+	w.Defer(Destroy(a))
+	w.Defer(Spawn(func(w *World) {
+		spawned = w.NewEntity()
+		w.Position[spawned] = Position{X: 5}
+	}))
+	assert.True(t, w.Exists(a), "nothing happens before the flush")
+	assert.Equal(t, 2, w.Pending())
+
+	w.Flush()
+	assert.False(t, w.Exists(a))
+	require.True(t, w.Exists(spawned))
+	assert.Equal(t, 5, w.Position[spawned].X)
+	assert.Zero(t, w.Pending())
+}
+
+func TestDefer_DestroyTwiceIsHarmless(t *testing.T) {
+	w := NewWorld()
+	a := w.CreateProjectile(0, 0, 0, 0, ProjectileConfig{}, FactionPlayer)
+	w.Defer(Destroy(a))
+	w.Defer(Destroy(a))
+	w.Flush()
+
+	projectiles, _ := w.PoolSizes()
+	assert.Equal(t, 1, projectiles, "the slot is returned once")
+}
+
+func TestDefer_CommandsDeferredDuringFlush(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	w.Position[a] = Position{}
+	w.Defer(Spawn(func(w *World) { w.Defer(Destroy(a)) }))
+
+	w.Flush()
+	assert.False(t, w.Exists(a), "applied in the same flush")
+}
+
+func TestScheduler_FlushesAfterEachPhase(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	w.Position[a] = Position{}
+	var existsInPreStep bool
+	w.AddSystem(func(w *World) { w.Defer(Destroy(a)) }, PhaseFrameStart)
+	w.AddSystem(func(w *World) { existsInPreStep = w.Exists(a) }, PhasePreStep)
+
+	w.RunFrame(1)
+	assert.False(t, existsInPreStep)
+	assert.Zero(t, w.Pending())
+}
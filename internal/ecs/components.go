@@ -3,15 +3,17 @@ package ecs
 import (
 	"image/color"
 	"math"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 )
 
 // PositionScale is the internal position scale factor.
 // 1 pixel = 256 internal units for sub-pixel precision.
 // Using 256 (2^8) allows bit-shift optimization for pixel conversion.
-const PositionScale = 256
+const PositionScale = fixedpoint.Scale
 
 // PositionShift is the bit shift amount for pixel conversion (log2(256) = 8)
-const PositionShift = 8
+const PositionShift = fixedpoint.Shift
 
 // Position represents an entity's position (256x scaled)
 type Position struct {
@@ -24,6 +26,12 @@ func (p Position) PixelX() int { return p.X >> PositionShift }
 // PixelY returns the pixel Y coordinate
 func (p Position) PixelY() int { return p.Y >> PositionShift }
 
+// PreviousPosition is the Position at the start of the current frame, so
+// drawing can interpolate between simulation frames
+type PreviousPosition struct {
+	X, Y int
+}
+
 // Velocity represents movement speed in internal units per substep.
 // All values are integers for deterministic simulation.
 type Velocity struct {
@@ -36,10 +44,30 @@ type Movement struct {
 	OnCeiling   bool
 	OnWallLeft  bool
 	OnWallRight bool
-	WasOnGround bool // for coyote time
+	WasOnGround bool    // for coyote time
+	Surface     Surface // ground being stood on (zero in the air)
 
 	Stunned bool // Cannot control
 	HitStun int  // Hit stagger frames
+
+	GravityScale int // percent of the category's gravity (0 = 100)
+	Mass         int // knockback resistance in percent (0 = 100)
+}
+
+// gravity scales a category's gravity by the entity's GravityScale
+func (m Movement) gravity(g int) int {
+	if m.GravityScale == 0 {
+		return g
+	}
+	return fixedpoint.MulPct(g, m.GravityScale)
+}
+
+// mass returns the knockback resistance in percent (100 = normal)
+func (m Movement) mass() int {
+	if m.Mass <= 0 {
+		return 100
+	}
+	return m.Mass
 }
 
 // Health represents entity health with iframe
@@ -86,11 +114,25 @@ func (h Hitbox) GetWorldRect(pixelX, pixelY int, facingRight bool, spriteWidth i
 	return pixelX + offsetX, pixelY + h.OffsetY, h.Width, h.Height
 }
 
+// DefaultSpriteWidth is the sprite width hitboxes are mirrored across when
+// none is set
+const DefaultSpriteWidth = 16
+
 // HitboxTrapezoid is for player (head/body/feet)
 type HitboxTrapezoid struct {
 	Head Hitbox
 	Body Hitbox
 	Feet Hitbox
+
+	SpriteWidth int // pixels; hitboxes mirror across it when facing left (0 = DefaultSpriteWidth)
+}
+
+// MirrorWidth returns the sprite width to pass to GetWorldRect
+func (h HitboxTrapezoid) MirrorWidth() int {
+	if h.SpriteWidth <= 0 {
+		return DefaultSpriteWidth
+	}
+	return h.SpriteWidth
 }
 
 // Facing represents which direction entity faces
@@ -106,33 +148,125 @@ const (
 	AIAggressive
 	AIRanged
 	AIChase
+	AISwarm
+	AICharger
 )
 
+// String returns the AI type name (for debug display)
+func (t AIType) String() string {
+	switch t {
+	case AIPatrol:
+		return "patrol"
+	case AIAggressive:
+		return "aggressive"
+	case AIRanged:
+		return "ranged"
+	case AIChase:
+		return "chase"
+	case AISwarm:
+		return "swarm"
+	case AICharger:
+		return "charger"
+	default:
+		return "unknown"
+	}
+}
+
 // AI represents enemy behavior
 type AI struct {
 	Type           AIType
 	DetectRange    int // pixels
 	AttackRange    int // pixels
+	AttackCooldown int // frames between shots
 	PatrolDistance int // pixels
 	JumpForce      int // IU per substep
 	MoveSpeed      int // IU per substep
 	ContactDamage  int
 	Flying         bool
+	Homing         Homing      // applied to fired projectiles
+	Hitscan        bool        // fires instant beams (see FireBeam)
+	Piercing       bool        // its beams hit every target on their line
+	SpawnFrames    int         // spawn-in duration for spawner-created enemies
+	WindupFrames   int         // telegraph before attacking (0 = attack instantly)
+	Solid          bool        // blocks the player (see ResolveSolidEnemies)
+	Stomp          StompKind   // reaction to the player landing on its head
+	Group          string      // alert group (see UpdateAggro)
+	Route          PatrolRoute // waypoints for patrol AI (empty = PatrolDistance)
+	TurnAtLedges   bool        // patrol AI turns around at platform edges
+	Aim            AimMode     // how shots are aimed (see aimShot)
+	ArcShots       bool        // aimed shots lob on the high trajectory
+	Flock          Flock       // swarm AI steering
+
+	ChargeSpeed      int // IU per substep: charger AI dash speed
+	ChargeStunFrames int // charger AI self-stun after dashing into a wall (0 = the stun profile's)
+
+	KnockbackResistPct int  // share of knockback shrugged off (100 = never pushed)
+	SuperArmor         bool // hits never push or stun it (bosses)
+	ArmoredCharge      bool // super-armor while winding up and rushing
 
 	// State
 	PatrolStartX int
 	PatrolDir    int
-	AttackTimer  int // frames (cooldown)
-	HitTimer     int // frames (hit stun)
-	HitTimerMax  int // initial HitTimer value (for decay calculation)
+	AttackTimer  int       // frames (cooldown)
+	SpawnTimer   int       // frames left spawning in (invulnerable, no AI)
+	Telegraph    Telegraph // attack being wound up
+	WindupTimer  int       // frames until the telegraphed attack
+	Charging     bool      // chase or charger AI has finished its wind-up and is rushing
+	ChargeDirX   int       // charger AI dash direction, locked at its start
+	ChargeDirY   int       // (unit vector in chargeDirScale)
+	AlertDelay   int       // frames until a group alert takes effect
+	AlertTimer   int       // frames left alerted to the player
+	Waypoint     int       // index of the route waypoint being walked to
+	RouteDir     int       // ping-pong direction along the route (+1/-1)
+	RouteDone    bool      // a "once" route has reached its end
+	Target       EntityID  // entity aimed shots go at (0 = the player)
+
+	// Drops
+	Loot LootTable
+}
+
+// Armored reports whether hits leave the enemy's action uninterrupted:
+// always with SuperArmor, else during its charge with ArmoredCharge
+func (ai AI) Armored() bool {
+	return ai.SuperArmor || (ai.ArmoredCharge && (ai.Charging || ai.Telegraph != TelegraphNone))
+}
+
+// CrowdControl holds hit reactions shared by the player and enemies.
+// Timers are in frames and advanced by UpdateCrowdControl.
+type CrowdControl struct {
+	StunTimer   int // no input or AI control
+	RootTimer   int // can't move, can still act
+	IframeTimer int // immune to damage
+
+	// Knockback: velocity decays from the initial value along a DecayCurve
+	KnockbackTimer int
+	KnockbackMax   int // initial KnockbackTimer value (for decay calculation)
+	KnockbackVelX  int // initial knockback X velocity (IU/substep)
+	KnockbackVelY  int // initial knockback Y velocity (IU/substep)
+
+	// Burn: damage dealt every HazardConfig.BurnTickFrames while BurnTimer runs
+	BurnTimer  int
+	BurnDamage int
+}
 
-	// Knockback (initial values for smooth deceleration)
-	KnockbackVelX int // initial knockback X velocity (IU/substep)
-	KnockbackVelY int // initial knockback Y velocity (IU/substep)
+// IsStunned returns true if the entity has lost control
+func (cc CrowdControl) IsStunned() bool {
+	return cc.StunTimer > 0
+}
+
+// IsRooted returns true if the entity can't move
+func (cc CrowdControl) IsRooted() bool {
+	return cc.RootTimer > 0
+}
 
-	// Gold drop
-	GoldDropMin int
-	GoldDropMax int
+// IsBurning returns true while the entity takes burn damage
+func (cc CrowdControl) IsBurning() bool {
+	return cc.BurnTimer > 0
+}
+
+// InKnockback returns true while knockback drives the entity's velocity
+func (cc CrowdControl) InKnockback() bool {
+	return cc.KnockbackTimer > 0
 }
 
 // Dash represents dash ability state
@@ -141,22 +275,92 @@ type Dash struct {
 	Timer    int  // remaining dash frames
 	Cooldown int  // cooldown frames
 	CanDash  bool // reset when grounded
+
+	// Dash attack
+	Level      int        // upgrade tier (index into DashAttackConfig.Damage)
+	SweepFromX int        // player pixel position at the previous damage pass
+	SweepFromY int
+	Hits       []EntityID // enemies already hit by the current dash
+}
+
+// Crouch is the crouch and slide state of an entity that can crouch. The
+// active hitbox (HitboxTrapezoid) is swapped between Stand and Low.
+type Crouch struct {
+	Stand      HitboxTrapezoid
+	Low        HitboxTrapezoid
+	Crouching  bool
+	SlideTimer int // frames left in the slide (0 = not sliding)
+}
+
+// NPC represents a friendly, non-combat character
+type NPC struct {
+	Behavior       NPCBehavior
+	HomeX          int // spawn pixel X (patrol center)
+	PatrolDistance int // pixels each side of HomeX
+	PatrolDir      int // -1 left, 1 right
+	Speed          int // IU/substep
+	PauseFrames    int // idle time at each patrol turn
+	PauseTimer     int
+	InteractRange  int // pixels between player and NPC centers
 }
 
 // Projectile represents projectile-specific data
 type Projectile struct {
-	StartX        int // pixel X at spawn
-	GravityAccel  int // IU per substep²
-	MaxFallSpeed  int // IU per substep
-	MaxRange      int // pixels
-	Damage        int
-	IsPlayerOwned bool
+	StartX       int // pixel X at spawn
+	StartY       int // pixel Y at spawn
+	GravityAccel int // IU per substep²
+	MaxFallSpeed int // IU per substep
+	MaxRange     int // pixels
+	Damage       int
+	Intercepts   bool // destroys hostile projectiles on overlap
+
+	// The shooter's side is the projectile's Faction component
+
+	// Homing (zero Homing = straight flight)
+	Homing Homing
+	Target EntityID // current homing target (0 = none)
+	Age    int      // frames since spawn
+
+	// Bombs bounce off tiles instead of sticking, pass through targets and
+	// explode when the fuse runs out
+	BouncePercent int       // 0-100 velocity kept per bounce (0 = sticks)
+	Fuse          int       // frames left (0 = not a bomb)
+	Explosion     Explosion
+
+	// Boomerangs fly out to their max range, then back to the player, who
+	// catches them; they hit each enemy once per pass
+	Boomerang bool
+	Returning bool // on the way back
+	Speed     int  // IU/substep launch speed, kept on the way back
+	Ammo      bool // the throw spent ammo, given back when caught
+
+	// Player arrows: the type fired and what it does to enemies it hits
+	Arrow          ArrowType
+	StickInEnemies bool  // sticks into enemies instead of breaking
+	Bleed          Bleed // damage over time while stuck in an enemy
 
 	// Stuck state
 	Stuck         bool
 	StuckTimer    int     // frames
 	StuckDuration int     // frames
 	StuckRotation float64 // radians (rendering only)
+
+	// Entity the projectile is stuck in (0 = a wall or in flight) and the
+	// offset (IU) it follows it at
+	Parent                       EntityID
+	ParentOffsetX, ParentOffsetY int
+}
+
+// Homing configures projectiles that curve toward a target
+type Homing struct {
+	TurnRate int // max velocity change per substep (IU/substep)
+	Range    int // target acquisition range (pixels)
+	Lifetime int // frames the projectile keeps homing
+}
+
+// Enabled reports whether the homing parameters steer at all
+func (h Homing) Enabled() bool {
+	return h.TurnRate > 0 && h.Lifetime > 0
 }
 
 // Rotation returns the rotation angle based on velocity (for rendering)
@@ -167,51 +371,72 @@ func (p *Projectile) Rotation(vx, vy int) float64 {
 	return math.Atan2(float64(vy), float64(vx))
 }
 
+// IsBomb reports whether the projectile explodes on a fuse
+func (p *Projectile) IsBomb() bool {
+	return p.Fuse > 0
+}
+
 // GetAlpha returns alpha for rendering (fading when stuck)
 func (p *Projectile) GetAlpha() float64 {
 	if !p.Stuck {
 		return 1.0
 	}
-	fadeStart := p.StuckDuration - 60 // fade in last second
+	fadeFrames := fixedpoint.SecondsToFrames(1) // fade in last second
+	fadeStart := p.StuckDuration - fadeFrames
 	if p.StuckTimer < fadeStart {
 		return 1.0
 	}
-	return 1.0 - float64(p.StuckTimer-fadeStart)/60.0
+	return 1.0 - float64(p.StuckTimer-fadeStart)/float64(fadeFrames)
 }
 
-// Gold represents gold pickup data
-type Gold struct {
+// Pickup represents a collectible item (gold, health, ammo or treasure)
+type Pickup struct {
+	Kind          PickupKind
 	Amount        int
 	Grounded      bool
-	CollectDelay  int // frames until collectible
-	Gravity       int // IU per substep²
-	BouncePercent int // 0-100 (70 = 70% velocity retained on bounce)
-	CollectRadius int // pixels
-	HitboxWidth   int // pixels
-	HitboxHeight  int // pixels
+	Attracted     bool // pulled toward the player, ignoring gravity and walls
+	CollectDelay  int  // frames until collectible
+	Gravity       int  // IU per substep²
+	BouncePercent int  // 0-100 (70 = 70% velocity retained on bounce)
+	CollectRadius int  // pixels
+	HitboxWidth   int  // pixels
+	HitboxHeight  int  // pixels
+	MagnetRadius  int  // pixels (0 = no magnet)
+	MagnetSpeed   int  // IU/substep
 }
 
 // Player represents player-specific data
 type Player struct {
 	Gold           int
-	EquippedArrows [4]ArrowType
+	EquippedArrows []ArrowType // one per arrow select slot (never modified after creation)
 	CurrentArrow   ArrowType
+	Ammo           int // homing arrows left (fired by the purple arrow)
+	MagnetLevel    int // upgrade tier (index into MagnetConfig.Radius)
 
 	// Timers (frames)
 	CoyoteTimer     int
 	JumpBufferTimer int
-	IframeTimer     int
-	StunTimer       int
-}
 
-// IsInvincible returns true if player has active i-frames or is dashing
-func (p *Player) IsInvincible(dashing bool) bool {
-	return p.IframeTimer > 0 || dashing
+	// Block
+	Blocking          bool
+	GuardBroken       bool // stamina ran out; block is released before it can be raised again
+	BlockFrames       int  // frames the shield has been up (parry window)
+	StaminaUsed       int  // stamina units spent (0 = full)
+	StaminaRegenTimer int  // frames until stamina regenerates
+
+	// Energy (dash, shield, charged arrows)
+	EnergyUsed       int // energy units spent (0 = full)
+	EnergyRegenTimer int // frames until energy regenerates
+	EnergyLevel      int // upgrade tier (index into EnergyConfig.Max)
+
+	// Summoned turret
+	SummonUnlocked bool // can summon (character ability or bought)
+	SummonCooldown int  // frames until the next summon
 }
 
-// IsStunned returns true if player is stunned
-func (p *Player) IsStunned() bool {
-	return p.StunTimer > 0
+// Stamina returns the remaining stamina units out of maxStamina
+func (p *Player) Stamina(maxStamina int) int {
+	return max(0, maxStamina-p.StaminaUsed)
 }
 
 // ArrowType represents the type of arrow
@@ -0,0 +1,40 @@
+package ecs
+
+// ContactConfig tunes touch damage from enemies
+type ContactConfig struct {
+	CooldownFrames int // frames before the same enemy's touch can hurt the same target again (0 = i-frames only)
+	Separation     int // IU/substep the enemy is pushed back after its touch connects (0 = none)
+}
+
+// ContactPair is an attacker whose touch connected and its target
+type ContactPair struct {
+	Attacker, Target EntityID
+}
+
+// contactReady reports whether attacker's touch can hurt target again
+func (w *World) contactReady(attacker, target EntityID) bool {
+	return w.ContactCooldowns[ContactPair{attacker, target}] <= 0
+}
+
+// contactConnected starts the pair's cooldown and pushes the attacker
+// away from the target (dir is the side of the attacker the target is
+// knocked toward), so standing in an enemy isn't hit after hit
+func (w *World) contactConnected(attacker, target EntityID, dir int) {
+	if w.Contact.CooldownFrames > 0 {
+		w.ContactCooldowns[ContactPair{attacker, target}] = w.Contact.CooldownFrames
+	}
+	if w.Contact.Separation > 0 && !w.AI[attacker].Solid {
+		Knockback(w, attacker, -dir*w.Contact.Separation, w.Velocity[attacker].Y, 0)
+	}
+}
+
+// updateContactCooldowns advances the pair cooldowns (once per frame)
+func updateContactCooldowns(w *World) {
+	for pair, frames := range w.ContactCooldowns {
+		if frames <= 1 {
+			delete(w.ContactCooldowns, pair)
+			continue
+		}
+		w.ContactCooldowns[pair] = frames - 1
+	}
+}
@@ -0,0 +1,45 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDamage_ContactCooldown(t *testing.T) {
+	w := NewWorld()
+	w.Contact = ContactConfig{CooldownFrames: 90, Separation: 40}
+	body := Hitbox{Width: 12, Height: 20}
+	player := w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	enemy := w.CreateEnemy(104, 104, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+
+	assert.True(t, UpdateDamage(w, 100, 50, 10).PlayerDamaged)
+	assert.Equal(t, 40, w.Velocity[enemy].X, "the enemy is pushed away from the player")
+
+	// Still overlapping once the i-frames are over: the pair's cooldown holds
+	for range 10 {
+		UpdateCrowdControl(w)
+	}
+	w.Position[enemy] = Position{X: 104 * PositionScale, Y: 104 * PositionScale}
+	assert.False(t, UpdateDamage(w, 100, 50, 10).PlayerDamaged)
+	assert.Equal(t, 90, w.Health[player].Current)
+
+	for range 80 {
+		UpdateCrowdControl(w)
+	}
+	assert.Empty(t, w.ContactCooldowns)
+	assert.True(t, UpdateDamage(w, 100, 50, 10).PlayerDamaged, "hurts again after the cooldown")
+}
+
+func TestUpdateDamage_ContactCooldownIsPerEnemy(t *testing.T) {
+	w := NewWorld()
+	w.Contact = ContactConfig{CooldownFrames: 90}
+	body := Hitbox{Width: 12, Height: 20}
+	w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	first := w.CreateEnemy(104, 104, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	UpdateDamage(w, 100, 50, 0)
+	assert.Contains(t, w.ContactCooldowns, ContactPair{first, w.PlayerID})
+
+	w.CreateEnemy(96, 104, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	assert.True(t, UpdateDamage(w, 100, 50, 0).PlayerDamaged, "another enemy can still hurt")
+}
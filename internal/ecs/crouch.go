@@ -0,0 +1,58 @@
+package ecs
+
+// UpdatePlayerCrouch crouches the player while down is held on the ground,
+// sliding instead when they were moving at slide speed. Standing back up
+// waits until the standing hitbox has head clearance, so a player who slid
+// under a low ceiling stays crouched until they are out. Runs once per frame
+// before UpdatePlayerInput, which slows crouched walking and skips control
+// during a slide.
+func UpdatePlayerCrouch(w *World, stage Stage, down bool, cfg PhysicsConfig) {
+	id := w.PlayerID
+	crouch, ok := w.Crouch[id]
+	if !ok {
+		return
+	}
+	vel := w.Velocity[id]
+	mov := w.Movement[id]
+
+	wants := down && mov.OnGround && !w.Dash[id].Active && !w.CrowdControl[id].IsStunned()
+	if wants && !crouch.Crouching {
+		crouch.Crouching = true
+		if cfg.SlideFrames > 0 && abs(vel.X) >= cfg.SlideMinSpeed {
+			crouch.SlideTimer = cfg.SlideFrames
+		}
+	}
+
+	// Slides decay and end early when stopped or airborne
+	if crouch.SlideTimer > 0 {
+		crouch.SlideTimer--
+		vel.X = sign(vel.X) * max(0, abs(vel.X)-cfg.SlideDecel)
+		if vel.X == 0 || !mov.OnGround {
+			crouch.SlideTimer = 0
+		}
+	}
+
+	if crouch.Crouching && !wants && crouch.SlideTimer == 0 &&
+		canStand(stage, w.Position[id], crouch.Stand, w.Facing[id].Right) {
+		crouch.Crouching = false
+	}
+
+	if crouch.Crouching {
+		w.HitboxTrapezoid[id] = crouch.Low
+	} else {
+		w.HitboxTrapezoid[id] = crouch.Stand
+	}
+	w.Crouch[id] = crouch
+	w.Velocity[id] = vel
+}
+
+// canStand returns true if the standing head and body fit at pos
+func canStand(stage Stage, pos Position, stand HitboxTrapezoid, facingRight bool) bool {
+	for _, hb := range []Hitbox{stand.Head, stand.Body} {
+		x, y, w, h := hb.GetWorldRect(pos.PixelX(), pos.PixelY(), facingRight, stand.MirrorWidth())
+		if isSolidRect(stage, x, y, w, h) {
+			return false
+		}
+	}
+	return true
+}
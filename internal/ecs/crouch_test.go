@@ -0,0 +1,86 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	standHitbox = HitboxTrapezoid{
+		Head: Hitbox{OffsetX: 4, Width: 8, Height: 6},
+		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
+		Feet: Hitbox{OffsetY: 18, Width: 16, Height: 6},
+	}
+	crouchHitbox = HitboxTrapezoid{
+		Head: Hitbox{OffsetX: 4, OffsetY: 10, Width: 8, Height: 4},
+		Body: Hitbox{OffsetX: 2, OffsetY: 12, Width: 12, Height: 6},
+		Feet: Hitbox{OffsetY: 18, Width: 16, Height: 6},
+	}
+	crouchCfg = PhysicsConfig{MaxSpeed: 100, Acceleration: 100, CrouchSpeedPct: 40, SlideMinSpeed: 80, SlideFrames: 10, SlideDecel: 10}
+)
+
+// newCrouchWorld returns a grounded player standing on ground at y=160
+func newCrouchWorld() (*World, *mockStage, EntityID) {
+	stage := newMockStage(20, 12, 16)
+	for x := 0; x < 20; x++ {
+		stage.setSolid(x, 10)
+	}
+	w := NewWorld()
+	player := w.CreatePlayer(100, 136, PlayerProfile{Hitbox: standHitbox, Crouch: crouchHitbox, MaxHealth: 100})
+	w.Movement[player] = Movement{OnGround: true}
+	return w, stage, player
+}
+
+func TestUpdatePlayerCrouch_SwapsHitboxAndSlowsWalking(t *testing.T) {
+	w, stage, player := newCrouchWorld()
+
+	UpdatePlayerCrouch(w, stage, true, crouchCfg)
+	UpdatePlayerInput(w, InputState{Down: true, Right: true, JumpPressed: true}, crouchCfg)
+
+	assert.True(t, w.Crouch[player].Crouching)
+	assert.Equal(t, crouchHitbox, w.HitboxTrapezoid[player])
+	assert.Equal(t, 40, w.Velocity[player].X, "crouch walking speed")
+	assert.Zero(t, w.Velocity[player].Y, "no jumping while crouched")
+	assert.Zero(t, w.Crouch[player].SlideTimer, "too slow to slide")
+
+	UpdatePlayerCrouch(w, stage, false, crouchCfg)
+	assert.False(t, w.Crouch[player].Crouching)
+	assert.Equal(t, standHitbox, w.HitboxTrapezoid[player])
+}
+
+func TestUpdatePlayerCrouch_SlideDecays(t *testing.T) {
+	w, stage, player := newCrouchWorld()
+	w.Velocity[player] = Velocity{X: 100}
+
+	UpdatePlayerCrouch(w, stage, true, crouchCfg)
+	UpdatePlayerInput(w, InputState{Down: true, Right: true}, crouchCfg)
+	assert.Equal(t, 9, w.Crouch[player].SlideTimer)
+	assert.Equal(t, 90, w.Velocity[player].X, "the slide owns the velocity")
+
+	UpdatePlayerCrouch(w, stage, false, crouchCfg)
+	assert.True(t, w.Crouch[player].Crouching, "releasing down doesn't cut the slide")
+	assert.Equal(t, 80, w.Velocity[player].X)
+
+	for i := 0; i < 8; i++ {
+		UpdatePlayerCrouch(w, stage, false, crouchCfg)
+	}
+	assert.Zero(t, w.Crouch[player].SlideTimer)
+	assert.False(t, w.Crouch[player].Crouching)
+}
+
+func TestUpdatePlayerCrouch_StaysDownUnderCeiling(t *testing.T) {
+	w, stage, player := newCrouchWorld()
+	UpdatePlayerCrouch(w, stage, true, crouchCfg)
+	stage.setSolid(6, 8) // low ceiling over the standing head (y 128-144)
+
+	UpdatePlayerCrouch(w, stage, false, crouchCfg)
+	assert.True(t, w.Crouch[player].Crouching, "no head clearance")
+	assert.Equal(t, crouchHitbox, w.HitboxTrapezoid[player])
+
+	pos := w.Position[player]
+	pos.X = 140 * PositionScale
+	w.Position[player] = pos
+	UpdatePlayerCrouch(w, stage, false, crouchCfg)
+	assert.False(t, w.Crouch[player].Crouching, "stands once clear")
+}
@@ -0,0 +1,186 @@
+package ecs
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// DecayCurve shapes how knockback velocity falls off over its duration
+type DecayCurve int
+
+const (
+	CurveLinear    DecayCurve = iota // velocity falls evenly to zero
+	CurveQuadratic                   // fast falloff, long slide at low speed
+	CurveConstant                    // full speed until the knockback ends
+)
+
+// String returns the config name of the curve
+func (c DecayCurve) String() string {
+	switch c {
+	case CurveLinear:
+		return "linear"
+	case CurveQuadratic:
+		return "quadratic"
+	case CurveConstant:
+		return "constant"
+	default:
+		return "unknown"
+	}
+}
+
+// apply scales the initial velocity v by the remaining share of the timer
+func (c DecayCurve) apply(v, remaining, total int) int {
+	if total <= 0 || remaining <= 0 {
+		return 0
+	}
+	switch c {
+	case CurveQuadratic:
+		return v * remaining * remaining / (total * total)
+	case CurveConstant:
+		return v
+	default:
+		return v * remaining / total
+	}
+}
+
+// CCProfile holds crowd-control tuning for one side (player or enemies).
+// Durations are pre-converted to frames (like PhysicsConfig).
+type CCProfile struct {
+	Curve           DecayCurve
+	KnockbackFrames int
+	StunFrames      int
+	DecayVertical   bool // Y knockback follows the curve too (false = gravity takes over after the launch)
+}
+
+// CCConfig holds crowd-control tuning for the player and enemies
+type CCConfig struct {
+	Player CCProfile
+	Enemy  CCProfile
+}
+
+// DefaultCCConfig returns 12-frame linear knockback and stun for both sides
+func DefaultCCConfig() CCConfig {
+	return CCConfig{
+		Player: CCProfile{Curve: CurveLinear, KnockbackFrames: 12, StunFrames: 12},
+		Enemy:  CCProfile{Curve: CurveLinear, KnockbackFrames: 12, StunFrames: 12, DecayVertical: true},
+	}
+}
+
+// ccProfile returns the tuning that applies to an entity
+func (w *World) ccProfile(id EntityID) CCProfile {
+	if id == w.PlayerID {
+		return w.CC.Player
+	}
+	return w.CC.Enemy
+}
+
+// IsInvincible returns true if the entity has active i-frames or is dashing
+func (w *World) IsInvincible(id EntityID) bool {
+	cc := w.CrowdControl[id]
+	return cc.IframeTimer > 0 || w.Dash[id].Active
+}
+
+// Knockback launches an entity with velocity (vx, vy) in IU/substep.
+// The velocity decays along the entity's curve over frames
+// (0 = the profile's knockback duration). Heavy entities are launched
+// slower and light ones faster, in proportion to their mass, and enemies
+// resist by their KnockbackResistPct. Super-armored enemies aren't moved.
+func Knockback(w *World, id EntityID, vx, vy, frames int) {
+	ai := w.AI[id]
+	if ai.Armored() {
+		return
+	}
+	if resist := ai.KnockbackResistPct; resist > 0 {
+		vx = fixedpoint.MulPct(vx, 100-min(resist, 100))
+		vy = fixedpoint.MulPct(vy, 100-min(resist, 100))
+	}
+	if frames <= 0 {
+		frames = w.ccProfile(id).KnockbackFrames
+	}
+	if mass := w.Movement[id].mass(); mass != 100 {
+		vx = fixedpoint.MulDiv(vx, 100, mass)
+		vy = fixedpoint.MulDiv(vy, 100, mass)
+	}
+	cc := w.CrowdControl[id]
+	cc.KnockbackTimer = frames
+	cc.KnockbackMax = frames
+	cc.KnockbackVelX = vx
+	cc.KnockbackVelY = vy
+	w.CrowdControl[id] = cc
+	w.Velocity[id] = Velocity{X: vx, Y: vy}
+}
+
+// Stun removes control for frames (0 = the profile's stun duration).
+// A longer running stun is kept; super-armored enemies aren't stunned.
+func Stun(w *World, id EntityID, frames int) {
+	if w.AI[id].Armored() {
+		return
+	}
+	if frames <= 0 {
+		frames = w.ccProfile(id).StunFrames
+	}
+	cc := w.CrowdControl[id]
+	cc.StunTimer = max(cc.StunTimer, frames)
+	w.CrowdControl[id] = cc
+}
+
+// Root pins an entity in place for frames. It can still attack.
+func Root(w *World, id EntityID, frames int) {
+	cc := w.CrowdControl[id]
+	cc.RootTimer = max(cc.RootTimer, frames)
+	w.CrowdControl[id] = cc
+}
+
+// GrantIframes makes an entity immune to damage for frames
+func GrantIframes(w *World, id EntityID, frames int) {
+	cc := w.CrowdControl[id]
+	cc.IframeTimer = max(cc.IframeTimer, frames)
+	w.CrowdControl[id] = cc
+}
+
+// Ignite sets an entity burning for frames, dealing damage per burn tick.
+// The stronger burn and the longer timer are kept.
+func Ignite(w *World, id EntityID, damage, frames int) {
+	cc := w.CrowdControl[id]
+	cc.BurnDamage = max(cc.BurnDamage, damage)
+	cc.BurnTimer = max(cc.BurnTimer, frames)
+	w.CrowdControl[id] = cc
+}
+
+// UpdateCrowdControl advances stun, root, i-frame and knockback timers
+// (call once per frame). Knockback velocity follows the entity's curve and
+// reaches zero exactly when the timer runs out; rooted entities stop.
+// Super-armor cuts a running knockback short. Touch damage cooldowns run
+// down too.
+func UpdateCrowdControl(w *World) {
+	updateContactCooldowns(w)
+
+	for _, id := range w.Query(CompCrowdControl) {
+		cc := w.CrowdControl[id]
+		if cc.KnockbackTimer > 0 && w.AI[id].Armored() {
+			cc.KnockbackTimer = 0
+		}
+		if cc.StunTimer > 0 {
+			cc.StunTimer--
+		}
+		if cc.RootTimer > 0 {
+			cc.RootTimer--
+		}
+		if cc.IframeTimer > 0 {
+			cc.IframeTimer--
+		}
+
+		if cc.KnockbackTimer > 0 {
+			cc.KnockbackTimer--
+			profile := w.ccProfile(id)
+			vel := w.Velocity[id]
+			vel.X = profile.Curve.apply(cc.KnockbackVelX, cc.KnockbackTimer, cc.KnockbackMax)
+			if profile.DecayVertical {
+				vel.Y = profile.Curve.apply(cc.KnockbackVelY, cc.KnockbackTimer, cc.KnockbackMax)
+			}
+			w.Velocity[id] = vel
+		} else if cc.RootTimer > 0 {
+			vel := w.Velocity[id]
+			vel.X = 0
+			w.Velocity[id] = vel
+		}
+		w.CrowdControl[id] = cc
+	}
+}
@@ -0,0 +1,169 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecayCurve_Apply(t *testing.T) {
+	assert.Equal(t, 50, CurveLinear.apply(100, 5, 10))
+	assert.Equal(t, 25, CurveQuadratic.apply(100, 5, 10))
+	assert.Equal(t, 100, CurveConstant.apply(100, 1, 10))
+	assert.Equal(t, 0, CurveConstant.apply(100, 0, 10), "zero once the timer runs out")
+	assert.Equal(t, 0, CurveLinear.apply(100, 5, 0))
+}
+
+func TestUpdateCrowdControl_KnockbackFollowsCurve(t *testing.T) {
+	w := NewWorld()
+	w.CC.Enemy.Curve = CurveQuadratic
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10}, true)
+
+	Knockback(w, enemy, 100, -40, 4)
+	assert.Equal(t, Velocity{X: 100, Y: -40}, w.Velocity[enemy])
+
+	var xs []int
+	for i := 0; i < 4; i++ {
+		UpdateCrowdControl(w)
+		xs = append(xs, w.Velocity[enemy].X)
+	}
+	assert.Equal(t, []int{56, 25, 6, 0}, xs)
+	assert.Equal(t, 0, w.Velocity[enemy].Y, "enemies decay vertically")
+	assert.False(t, w.CrowdControl[enemy].InKnockback())
+}
+
+func TestUpdateCrowdControl_PlayerKeepsGravityArc(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+
+	Knockback(w, player, 120, -80, 0)
+	UpdateCrowdControl(w)
+
+	cc := w.CrowdControl[player]
+	assert.Equal(t, 11, cc.KnockbackTimer, "default player duration")
+	assert.Equal(t, 110, w.Velocity[player].X)
+	assert.Equal(t, -80, w.Velocity[player].Y, "vertical launch is left to gravity")
+}
+
+func TestCrowdControl_TimersKeepLongest(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10}, true)
+
+	Stun(w, enemy, 20)
+	Stun(w, enemy, 0) // default 12 frames is shorter
+	GrantIframes(w, enemy, 3)
+	UpdateCrowdControl(w)
+
+	cc := w.CrowdControl[enemy]
+	assert.Equal(t, 19, cc.StunTimer)
+	assert.Equal(t, 2, cc.IframeTimer)
+	assert.True(t, w.IsInvincible(enemy))
+}
+
+func TestUpdatePlayerInput_StunAndRoot(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	cfg := PhysicsConfig{MaxSpeed: 100, Acceleration: 100, AirControlPct: 100, JumpForce: 50}
+
+	Stun(w, player, 5)
+	UpdatePlayerInput(w, InputState{Right: true, JumpPressed: true}, cfg)
+	assert.Equal(t, Velocity{}, w.Velocity[player], "no control while stunned")
+
+	w.CrowdControl[player] = CrowdControl{}
+	Root(w, player, 5)
+	UpdatePlayerInput(w, InputState{Right: true}, cfg)
+	assert.Equal(t, 0, w.Velocity[player].X, "rooted players can't walk")
+	assert.True(t, w.Facing[player].Right, "but can still turn")
+}
+
+func TestUpdateDamage_EnemyHitStunsAndKnocksBack(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 30, HitboxWidth: 12, HitboxHeight: 12}, true)
+	w.CreateProjectile(102, 104, 90, 0, ProjectileConfig{Damage: 5, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	cc := w.CrowdControl[enemy]
+	assert.True(t, cc.IsStunned())
+	assert.Equal(t, 12, cc.KnockbackMax)
+	assert.Positive(t, cc.KnockbackVelX)
+	assert.Equal(t, 25, w.Health[enemy].Current)
+}
+
+func TestKnockback_ScaledByMass(t *testing.T) {
+	w := NewWorld()
+	small := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true)
+	boss := w.CreateEnemy(200, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 48, HitboxHeight: 48}, true)
+	heavy := w.CreateEnemy(300, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, Mass: 400}, true)
+
+	assert.Equal(t, 100, w.Movement[small].Mass, "up to a tile is normal weight")
+	assert.Equal(t, 900, w.Movement[boss].Mass, "mass grows with the hitbox area")
+
+	Knockback(w, small, 180, -90, 0)
+	Knockback(w, boss, 180, -90, 0)
+	Knockback(w, heavy, 180, -90, 0)
+	assert.Equal(t, Velocity{X: 180, Y: -90}, w.Velocity[small])
+	assert.Equal(t, Velocity{X: 20, Y: -10}, w.Velocity[boss])
+	assert.Equal(t, Velocity{X: 45, Y: -22}, w.Velocity[heavy])
+}
+
+func TestKnockback_LightEntitiesFlyFarther(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Mass: 50})
+
+	Knockback(w, w.PlayerID, 100, -40, 0)
+	assert.Equal(t, Velocity{X: 200, Y: -80}, w.Velocity[w.PlayerID])
+}
+
+func TestApplyGravity_Scale(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	w := NewWorld()
+	normal := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true)
+	floaty := w.CreateEnemy(200, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, GravityScale: 50}, true)
+	heavy := w.CreateEnemy(300, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, GravityScale: 200}, true)
+
+	ApplyEnemyGravity(w, stage, 10, 100)
+	assert.Equal(t, 10, w.Velocity[normal].Y)
+	assert.Equal(t, 5, w.Velocity[floaty].Y)
+	assert.Equal(t, 20, w.Velocity[heavy].Y)
+
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, GravityScale: 50})
+	ApplyPlayerGravity(w, PhysicsConfig{Gravity: 10, FallMultiplierPct: 100})
+	assert.Equal(t, 5, w.Velocity[w.PlayerID].Y)
+}
+
+func TestKnockback_Resist(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, KnockbackResistPct: 75}, true)
+
+	Knockback(w, id, 200, -80, 0)
+	assert.Equal(t, Velocity{X: 50, Y: -20}, w.Velocity[id])
+}
+
+func TestSuperArmor_TakesDamageWithoutInterruption(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	boss := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, SuperArmor: true}, true)
+	w.CreateProjectile(102, 105, 300, 0, ProjectileConfig{Damage: 3}, FactionPlayer)
+
+	UpdateDamage(w, 200, 100, 30)
+	assert.Equal(t, 7, w.Health[boss].Current, "hits still deal damage")
+	assert.Zero(t, w.Velocity[boss], "not pushed")
+	assert.False(t, w.CrowdControl[boss].IsStunned(), "not interrupted")
+}
+
+func TestArmoredCharge(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, ArmoredCharge: true}, true)
+	assert.False(t, w.AI[id].Armored(), "only while charging")
+
+	Knockback(w, id, 200, 0, 10)
+	assert.True(t, w.CrowdControl[id].InKnockback())
+
+	ai := w.AI[id]
+	ai.Charging = true
+	w.AI[id] = ai
+	assert.True(t, ai.Armored())
+	UpdateCrowdControl(w)
+	assert.False(t, w.CrowdControl[id].InKnockback(), "armor cuts a running knockback short")
+}
@@ -0,0 +1,106 @@
+package ecs
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TileCoord is a tile position in tile units
+type TileCoord struct {
+	X, Y int
+}
+
+// CrumbleState is the phase of a crumbling tile that has been stood on
+type CrumbleState int
+
+const (
+	CrumbleShaking CrumbleState = iota // still solid, about to fall
+	CrumbleFallen                      // not solid until it respawns
+)
+
+// Crumble tracks a crumbling tile from the first ground contact until it
+// respawns
+type Crumble struct {
+	State CrumbleState
+	Timer int // frames left in the state (-1 = fallen for good)
+	Age   int // frames spent in the state
+}
+
+// touchGround notifies crumbling tiles that something stands on them.
+// (x, y) is the left end of the standing edge, width its length in pixels.
+func (w *World) touchGround(stage Stage, x, y, width int) {
+	tileSize := stage.GetTileSize()
+	ty := y / tileSize
+	for tx := x / tileSize; tx <= (x+width-1)/tileSize; tx++ {
+		coord := TileCoord{tx, ty}
+		if _, ok := w.Crumbles[coord]; ok {
+			continue
+		}
+		if frames := stage.GetSurface(tx*tileSize, ty*tileSize).CrumbleFrames; frames > 0 {
+			w.Crumbles[coord] = Crumble{State: CrumbleShaking, Timer: frames}
+		}
+	}
+}
+
+// UpdateCrumbles advances crumbling tiles (call once per frame). Shaking
+// tiles fall when their delay runs out and respawn after the surface's
+// respawn time, waiting until nothing overlaps the tile.
+func UpdateCrumbles(w *World, stage Stage) {
+	if len(w.Crumbles) == 0 {
+		return
+	}
+	coords := make([]TileCoord, 0, len(w.Crumbles))
+	for coord := range w.Crumbles {
+		coords = append(coords, coord)
+	}
+	slices.SortFunc(coords, func(a, b TileCoord) int { // deterministic for replays
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+
+	tileSize := stage.GetTileSize()
+	for _, coord := range coords {
+		c := w.Crumbles[coord]
+		c.Age++
+		if c.Timer > 0 {
+			c.Timer--
+		}
+		px, py := coord.X*tileSize, coord.Y*tileSize
+		switch {
+		case c.Timer != 0:
+			// Still shaking, or fallen and waiting (for good at -1)
+		case c.State == CrumbleShaking:
+			stage.SetTileFallen(coord.X, coord.Y, true)
+			c = Crumble{State: CrumbleFallen, Timer: stage.GetSurface(px, py).RespawnFrames}
+			if c.Timer == 0 {
+				c.Timer = -1
+			}
+		case !w.tileOccupied(px, py, tileSize):
+			stage.SetTileFallen(coord.X, coord.Y, false)
+			delete(w.Crumbles, coord)
+			continue
+		}
+		w.Crumbles[coord] = c
+	}
+}
+
+// tileOccupied reports whether the player or an enemy overlaps a tile
+func (w *World) tileOccupied(px, py, tileSize int) bool {
+	if id := w.PlayerID; id != 0 {
+		pos := w.Position[id]
+		hitbox := w.HitboxTrapezoid[id]
+		facing := w.Facing[id].Right
+		for _, hb := range []Hitbox{hitbox.Head, hitbox.Body, hitbox.Feet} {
+			x, y, hw, hh := hb.GetWorldRect(pos.PixelX(), pos.PixelY(), facing, hitbox.MirrorWidth())
+			if rectsOverlap(x, y, hw, hh, px, py, tileSize, tileSize) {
+				return true
+			}
+		}
+	}
+	for _, id := range w.Query(TagEnemy) {
+		pos, hb := w.Position[id], w.Hitbox[id]
+		if rectsOverlap(pos.PixelX()+hb.OffsetX, pos.PixelY()+hb.OffsetY, hb.Width, hb.Height, px, py, tileSize, tileSize) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCrumbleStage() *hazardStage {
+	stage := newHazardStage()
+	stage.setSolid(6, 8) // pixels 96..111, y 128..143
+	stage.surface[[2]int{6, 8}] = Surface{CrumbleFrames: 3, RespawnFrames: 5}
+	return stage
+}
+
+func TestCrumbles_FallAfterBeingStoodOn(t *testing.T) {
+	stage := newCrumbleStage()
+	w, player, enemy := newSolidTestWorld(96, 104) // feet bottom at y 128
+	w.DestroyEntity(enemy)
+	w.Velocity[player] = Velocity{Y: PositionScale}
+
+	UpdatePlayerPhysics(w, stage, PhysicsConfig{MaxFallSpeed: 1000})
+	assert.Equal(t, Crumble{State: CrumbleShaking, Timer: 3}, w.Crumbles[TileCoord{6, 8}])
+
+	for range 2 {
+		UpdateCrumbles(w, stage)
+	}
+	assert.True(t, stage.IsSolidAt(96, 128), "still shaking")
+
+	UpdateCrumbles(w, stage)
+	assert.False(t, stage.IsSolidAt(96, 128))
+	assert.Equal(t, CrumbleFallen, w.Crumbles[TileCoord{6, 8}].State)
+}
+
+func TestCrumbles_RespawnWhenClear(t *testing.T) {
+	stage := newCrumbleStage()
+	w := NewWorld()
+	w.Crumbles[TileCoord{6, 8}] = Crumble{State: CrumbleShaking, Timer: 1}
+	enemy := w.CreateEnemy(96, 120, EnemyConfig{MaxHealth: 10, HitboxWidth: 16, HitboxHeight: 16}, true)
+
+	for range 6 {
+		UpdateCrumbles(w, stage)
+	}
+	assert.False(t, stage.IsSolidAt(96, 128), "an enemy is in the way")
+
+	w.DestroyEntity(enemy)
+	UpdateCrumbles(w, stage)
+	assert.True(t, stage.IsSolidAt(96, 128))
+	assert.Empty(t, w.Crumbles)
+}
+
+func TestCrumbles_NoRespawnStaysGone(t *testing.T) {
+	stage := newCrumbleStage()
+	stage.surface[[2]int{6, 8}] = Surface{CrumbleFrames: 1}
+	w := NewWorld()
+	w.touchGround(stage, 100, 128, 4)
+
+	for range 100 {
+		UpdateCrumbles(w, stage)
+	}
+	assert.False(t, stage.IsSolidAt(96, 128))
+	assert.Equal(t, -1, w.Crumbles[TileCoord{6, 8}].Timer)
+}
@@ -0,0 +1,80 @@
+package ecs
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// DashDirections restricts which way the player can dash
+type DashDirections int
+
+const (
+	DashHorizontal DashDirections = iota // left or right only
+	DashFourWay                          // horizontal or straight up/down
+	DashEightWay                         // horizontal, vertical and diagonal
+)
+
+// diagonalPct scales each axis of a diagonal dash so it covers the same
+// distance as a straight one (1/√2)
+const diagonalPct = 71
+
+// dashDirection picks the dash direction (-1, 0 or 1 per axis) from the held
+// directions, or from the aim vector when nothing is held and aim dashes are
+// enabled. Without either the player dashes the way they face. The result is
+// restricted to the allowed directions; horizontal input wins over vertical
+// when diagonals are not allowed, and the player can't dash down into the
+// ground.
+func dashDirection(input InputState, cfg PhysicsConfig, facingRight, onGround bool) (dx, dy int) {
+	if input.Left {
+		dx--
+	}
+	if input.Right {
+		dx++
+	}
+	if input.Up {
+		dy--
+	}
+	if input.Down {
+		dy++
+	}
+	if dx == 0 && dy == 0 && cfg.DashToAim {
+		dx, dy = snapToOctant(input.AimX, input.AimY)
+	}
+
+	switch cfg.DashDirections {
+	case DashHorizontal:
+		dy = 0
+	case DashFourWay:
+		if dx != 0 {
+			dy = 0
+		}
+	}
+	if onGround && dy > 0 {
+		dy = 0
+	}
+
+	if dx == 0 && dy == 0 {
+		dx = 1
+		if !facingRight {
+			dx = -1
+		}
+	}
+	return dx, dy
+}
+
+// snapToOctant returns the nearest of the 8 directions to a vector (0, 0 for
+// a zero vector). An axis counts when it is at least ~tan(22.5°) of the other.
+func snapToOctant(x, y int) (dx, dy int) {
+	if abs(x)*5 >= abs(y)*2 {
+		dx = sign(x)
+	}
+	if abs(y)*5 >= abs(x)*2 {
+		dy = sign(y)
+	}
+	return dx, dy
+}
+
+// dashVelocity returns the dash velocity (IU/substep) along a direction
+func dashVelocity(dx, dy, speed int) (vx, vy int) {
+	if dx != 0 && dy != 0 {
+		speed = fixedpoint.MulPct(speed, diagonalPct)
+	}
+	return dx * speed, dy * speed
+}
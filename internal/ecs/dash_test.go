@@ -0,0 +1,63 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashDirection(t *testing.T) {
+	eight := PhysicsConfig{DashDirections: DashEightWay}
+	tests := []struct {
+		name     string
+		input    InputState
+		cfg      PhysicsConfig
+		onGround bool
+		dx, dy   int
+	}{
+		{"facing without input", InputState{}, eight, false, -1, 0},
+		{"up", InputState{Up: true}, eight, false, 0, -1},
+		{"diagonal", InputState{Right: true, Down: true}, eight, false, 1, 1},
+		{"no down dash on the ground", InputState{Down: true}, eight, true, -1, 0},
+		{"four-way prefers horizontal", InputState{Right: true, Up: true}, PhysicsConfig{DashDirections: DashFourWay}, false, 1, 0},
+		{"horizontal only", InputState{Up: true}, PhysicsConfig{}, false, -1, 0},
+		{"aim", InputState{AimX: 10, AimY: -12}, PhysicsConfig{DashDirections: DashEightWay, DashToAim: true}, false, 1, -1},
+		{"aim snaps to axis", InputState{AimX: -3, AimY: 40}, PhysicsConfig{DashDirections: DashEightWay, DashToAim: true}, false, 0, 1},
+		{"input beats aim", InputState{Left: true, AimX: 10}, PhysicsConfig{DashDirections: DashEightWay, DashToAim: true}, false, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dx, dy := dashDirection(tt.input, tt.cfg, false, tt.onGround)
+			assert.Equal(t, tt.dx, dx)
+			assert.Equal(t, tt.dy, dy)
+		})
+	}
+}
+
+func TestUpdatePlayerInput_DiagonalDash(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	cfg := PhysicsConfig{DashSpeed: 100, DashFrames: 10, DashDirections: DashEightWay}
+
+	UpdatePlayerInput(w, InputState{Left: true, Up: true, Dash: true}, cfg)
+
+	assert.True(t, w.Dash[player].Active)
+	assert.Equal(t, Velocity{X: -71, Y: -71}, w.Velocity[player])
+	assert.False(t, w.Facing[player].Right)
+}
+
+func TestUpdateDamage_UpDashSweepsVertically(t *testing.T) {
+	w, player := newDashWorld()
+	UpdateDamage(w, 100, 50, 60) // record sweep start at y=100
+	enemy := w.CreateEnemy(100, 60, EnemyConfig{MaxHealth: 30, HitboxWidth: 12, HitboxHeight: 4}, true)
+
+	dash := w.Dash[player]
+	dash.Active = true
+	w.Dash[player] = dash
+	pos := w.Position[player]
+	pos.Y = 40 * PositionScale
+	w.Position[player] = pos
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 20, w.Health[enemy].Current)
+}
@@ -0,0 +1,92 @@
+package ecs
+
+import "slices"
+
+// DashAttackConfig holds dash damage tuning.
+// Values are pre-converted to frames and IU (like PhysicsConfig).
+type DashAttackConfig struct {
+	Damage         []int // damage per upgrade tier (empty = dash deals no damage)
+	Knockback      int   // IU/substep
+	SlowdownFrames int   // slow motion after a dash hit
+	SlowdownPct    int   // simulation speed while slowed (0-100)
+}
+
+// damageAt returns the damage of an upgrade tier, clamped to the last tier
+func (cfg DashAttackConfig) damageAt(level int) int {
+	if len(cfg.Damage) == 0 {
+		return 0
+	}
+	return cfg.Damage[max(0, min(level, len(cfg.Damage)-1))]
+}
+
+// dashAttack damages enemies swept by the player's dash this frame.
+// The hitbox is swept from the previous damage pass to the current position,
+// so fast dashes can't skip over thin enemies. Each enemy is hit once per
+// dash. Killed enemies are appended to killed, which is returned.
+func dashAttack(w *World, killed []EntityID) []EntityID {
+	playerID := w.PlayerID
+	if playerID == 0 || !w.Collision.Collides(LayerPlayer, LayerEnemy) {
+		return killed
+	}
+	dash := w.Dash[playerID]
+	pos := w.Position[playerID]
+	facing := w.Facing[playerID]
+	hitbox := w.HitboxTrapezoid[playerID]
+
+	fromX, fromY := dash.SweepFromX, dash.SweepFromY
+	dash.SweepFromX, dash.SweepFromY = pos.PixelX(), pos.PixelY()
+	w.Dash[playerID] = dash
+
+	damage := w.DashAttack.damageAt(dash.Level)
+	if !dash.Active || damage <= 0 {
+		return killed
+	}
+
+	// Swept body rect between the previous and current position
+	x0, y0, pw, ph := hitbox.Body.GetWorldRect(fromX, fromY, facing.Right, hitbox.MirrorWidth())
+	x1, y1, _, _ := hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+	sx, sy := min(x0, x1), min(y0, y1)
+	sw := max(x0, x1) + pw - sx
+	sh := max(y0, y1) + ph - sy
+
+	dir := 1
+	if !facing.Right {
+		dir = -1
+	}
+
+	for _, enemyID := range w.Query(TagEnemy) {
+		if slices.Contains(dash.Hits, enemyID) || slices.Contains(killed, enemyID) || w.IsInvincible(enemyID) || !w.Hostile(playerID, enemyID) {
+			continue
+		}
+		enemyPos := w.Position[enemyID]
+		enemyHit := w.Hitbox[enemyID]
+		if !rectsOverlap(
+			sx, sy, sw, sh,
+			enemyPos.PixelX()+enemyHit.OffsetX, enemyPos.PixelY()+enemyHit.OffsetY, enemyHit.Width, enemyHit.Height,
+		) {
+			continue
+		}
+		dash.Hits = append(dash.Hits, enemyID)
+
+		health := w.Health[enemyID]
+		dealt := eliteDamage(w, enemyID, damage)
+		health.Current -= dealt
+		w.Events.Emit(Event{Type: EventDashHit, Entity: enemyID, Amount: dealt})
+
+		// Knock the enemy along the dash, slightly upward
+		kbVelX, kbVelY := calcKnockbackFromVelocity(dir*2, -1, w.DashAttack.Knockback)
+		Knockback(w, enemyID, kbVelX, kbVelY, 0)
+		Stun(w, enemyID, 0)
+
+		if health.Current <= 0 {
+			w.Feedback.TriggerDir(FeedbackHeavyHit, kbVelX, kbVelY)
+			killed = append(killed, enemyID)
+		} else {
+			w.Feedback.TriggerDir(FeedbackLightHit, kbVelX, kbVelY)
+			w.Health[enemyID] = health
+		}
+		w.TimeScale.SlowFor(TimeScaleDashHit, w.DashAttack.SlowdownFrames, w.DashAttack.SlowdownPct)
+	}
+	w.Dash[playerID] = dash
+	return killed
+}
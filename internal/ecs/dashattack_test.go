@@ -0,0 +1,70 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDashWorld() (*World, EntityID) {
+	w := NewWorld()
+	w.DashAttack = DashAttackConfig{Damage: []int{10, 25}, Knockback: 100, SlowdownFrames: 6, SlowdownPct: 50}
+	body := Hitbox{Width: 12, Height: 20}
+	player := w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	w.Facing[player] = Facing{Right: true}
+	return w, player
+}
+
+// dashTo records the sweep start and moves the player to pixel x mid-dash
+func dashTo(w *World, player EntityID, x int) {
+	dash := w.Dash[player]
+	dash.Active = true
+	w.Dash[player] = dash
+	pos := w.Position[player]
+	pos.X = x * PositionScale
+	w.Position[player] = pos
+}
+
+func TestUpdateDamage_DashSweepHitsEnemyOnce(t *testing.T) {
+	w, player := newDashWorld()
+	UpdateDamage(w, 100, 50, 60) // record sweep start at x=100
+	enemy := w.CreateEnemy(130, 100, EnemyConfig{MaxHealth: 30, HitboxWidth: 4, HitboxHeight: 12}, true)
+
+	dashTo(w, player, 160) // passes over the enemy within one frame
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 20, w.Health[enemy].Current)
+	assert.Positive(t, w.CrowdControl[enemy].KnockbackVelX, "knocked along the dash")
+	assert.Equal(t, []Event{{Type: EventDashHit, Entity: enemy, Amount: 10}}, w.Events.Drain())
+	assert.Equal(t, 50, w.TimeScale.Target())
+
+	dashTo(w, player, 125)
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 20, w.Health[enemy].Current, "one hit per dash")
+}
+
+func TestUpdateDamage_DashKillDropsGold(t *testing.T) {
+	w, player := newDashWorld()
+	dash := w.Dash[player]
+	dash.Level = 5 // clamps to the last tier
+	w.Dash[player] = dash
+	UpdateDamage(w, 100, 50, 60)
+	enemy := w.CreateEnemy(110, 100, EnemyConfig{MaxHealth: 20, HitboxWidth: 12, HitboxHeight: 12, Loot: GoldLoot(4, 4)}, true)
+
+	dashTo(w, player, 120)
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, w.Exists(enemy))
+	assert.Len(t, w.IsPickup, 1)
+}
+
+func TestUpdateDamage_NoDashNoDamage(t *testing.T) {
+	w, _ := newDashWorld()
+	enemy := w.CreateEnemy(104, 100, EnemyConfig{MaxHealth: 30, HitboxWidth: 12, HitboxHeight: 12}, true)
+	GrantIframes(w, w.PlayerID, 60)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 30, w.Health[enemy].Current)
+	assert.Equal(t, 100, w.TimeScale.Target())
+}
@@ -0,0 +1,100 @@
+package ecs
+
+import "reflect"
+
+// Dump is a JSON-friendly copy of a world's entities and stage state,
+// written to crash reports. Entities map component names to values.
+type Dump struct {
+	PlayerID EntityID
+	Entities map[EntityID]map[string]any
+	Crumbles []CrumbleDump
+	Contacts []ContactDump
+	Respawns []Respawn
+	Weather  Weather
+}
+
+// CrumbleDump is a crumbling tile and its state
+type CrumbleDump struct {
+	TileCoord
+	Crumble
+}
+
+// ContactDump is a touch cooldown between an attacker and its target
+type ContactDump struct {
+	ContactPair
+	Frames int
+}
+
+// namedComponent is one of the world's component maps
+type namedComponent struct {
+	name       string
+	components reflect.Value // map[EntityID]T
+}
+
+// componentMaps lists the world's component and tag maps by name
+// (for the dump and the inspector)
+func (w *World) componentMaps() []namedComponent {
+	maps := []struct {
+		name       string
+		components any
+	}{
+		{"Position", w.Position},
+		{"PreviousPosition", w.PreviousPosition},
+		{"Velocity", w.Velocity},
+		{"Movement", w.Movement},
+		{"Health", w.Health},
+		{"Hitbox", w.Hitbox},
+		{"HitboxTrapezoid", w.HitboxTrapezoid},
+		{"Facing", w.Facing},
+		{"AI", w.AI},
+		{"Dash", w.Dash},
+		{"Crouch", w.Crouch},
+		{"Projectile", w.ProjectileData},
+		{"Pickup", w.PickupData},
+		{"Player", w.PlayerData},
+		{"Elite", w.Elite},
+		{"Particle", w.Particle},
+		{"CrowdControl", w.CrowdControl},
+		{"NPC", w.NPC},
+		{"Faction", w.Faction},
+		{"Ally", w.Ally},
+		{"IsPlayer", w.IsPlayer},
+		{"IsEnemy", w.IsEnemy},
+		{"IsProjectile", w.IsProjectile},
+		{"IsPickup", w.IsPickup},
+		{"IsNPC", w.IsNPC},
+	}
+	out := make([]namedComponent, len(maps))
+	for i, m := range maps {
+		out[i] = namedComponent{m.name, reflect.ValueOf(m.components)}
+	}
+	return out
+}
+
+// Dump copies the world's entities, component by component
+func (w *World) Dump() Dump {
+	d := Dump{
+		PlayerID: w.PlayerID,
+		Entities: make(map[EntityID]map[string]any),
+		Respawns: w.Respawns,
+		Weather:  w.Weather,
+	}
+	for _, c := range w.componentMaps() {
+		iter := c.components.MapRange()
+		for iter.Next() {
+			id := iter.Key().Interface().(EntityID)
+			if d.Entities[id] == nil {
+				d.Entities[id] = make(map[string]any)
+			}
+			d.Entities[id][c.name] = iter.Value().Interface()
+		}
+	}
+
+	for coord, c := range w.Crumbles {
+		d.Crumbles = append(d.Crumbles, CrumbleDump{coord, c})
+	}
+	for pair, frames := range w.ContactCooldowns {
+		d.Contacts = append(d.Contacts, ContactDump{pair, frames})
+	}
+	return d
+}
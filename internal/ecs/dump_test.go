@@ -0,0 +1,30 @@
+package ecs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorldDump_GroupsComponentsByEntity(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(32, 32, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	w.Crumbles[TileCoord{3, 4}] = Crumble{State: CrumbleFallen, Timer: 20}
+	w.Weather = Weather{Kind: WeatherRain, Density: 2}
+
+	d := w.Dump()
+
+	assert.Equal(t, player, d.PlayerID)
+	assert.Equal(t, w.Position[player], d.Entities[player]["Position"])
+	assert.Contains(t, d.Entities[player], "IsPlayer")
+	assert.Equal(t, w.Health[enemy], d.Entities[enemy]["Health"])
+	assert.NotContains(t, d.Entities[enemy], "IsPlayer")
+	assert.Equal(t, []CrumbleDump{{TileCoord{3, 4}, Crumble{State: CrumbleFallen, Timer: 20}}}, d.Crumbles)
+
+	data, err := json.Marshal(d)
+	require.NoError(t, err, "dumps are written as JSON")
+	assert.Contains(t, string(data), `"Weather":{"Kind":1,"Density":2`)
+}
@@ -0,0 +1,127 @@
+package ecs
+
+import "github.com/younwookim/mg/internal/ecs/fixedpoint"
+
+// EliteModifier identifies an elite enemy variant
+type EliteModifier int
+
+const (
+	EliteNone         EliteModifier = iota
+	EliteFast                       // stat-only: faster movement and attacks
+	EliteArmored                    // blocks part of incoming projectile damage
+	EliteExplosive                  // damages the player nearby when killed
+	EliteRegenerating               // slowly regains health
+)
+
+// eliteNames maps modifiers to their config names
+var eliteNames = map[EliteModifier]string{
+	EliteFast:         "fast",
+	EliteArmored:      "armored",
+	EliteExplosive:    "explosive",
+	EliteRegenerating: "regenerating",
+}
+
+// String returns the config name of the modifier ("" for EliteNone)
+func (m EliteModifier) String() string {
+	return eliteNames[m]
+}
+
+// ParseEliteModifier returns the modifier for a config name
+func ParseEliteModifier(name string) (EliteModifier, bool) {
+	for m, n := range eliteNames {
+		if n == name {
+			return m, true
+		}
+	}
+	return EliteNone, false
+}
+
+// Elite holds the combat behavior of an elite enemy.
+// Stat changes (speed, health, gold) are applied to EnemyConfig before
+// the enemy is created; this component covers the rest.
+type Elite struct {
+	Modifier      EliteModifier
+	ArmorPct      int // 0-100, % of projectile damage blocked
+	RegenFrames   int // frames per 1 HP regained (0 = no regen)
+	RegenTimer    int
+	ExplodeRadius int // pixels from the enemy center
+	ExplodeDamage int
+}
+
+// eliteDamage returns the damage an enemy takes after armor (at least 1)
+func eliteDamage(w *World, id EntityID, damage int) int {
+	elite, ok := w.Elite[id]
+	if !ok || elite.ArmorPct <= 0 {
+		return damage
+	}
+	return max(1, damage-fixedpoint.MulPct(damage, elite.ArmorPct))
+}
+
+// updateEliteRegen regains health for regenerating elites.
+// Regeneration pauses during hit stun.
+func updateEliteRegen(w *World) {
+	for _, id := range w.Query(CompElite) {
+		elite := w.Elite[id]
+		if elite.RegenFrames <= 0 {
+			continue
+		}
+		health := w.Health[id]
+		if health.Current >= health.Max || w.CrowdControl[id].IsStunned() {
+			elite.RegenTimer = 0
+			w.Elite[id] = elite
+			continue
+		}
+		elite.RegenTimer++
+		if elite.RegenTimer >= elite.RegenFrames {
+			elite.RegenTimer = 0
+			health.Current++
+			w.Health[id] = health
+		}
+		w.Elite[id] = elite
+	}
+}
+
+// explodeElite damages the player if a dying explosive elite is close enough.
+// Knockback pushes the player away from the enemy.
+func explodeElite(w *World, id EntityID, result *DamageResult, knockbackForce, knockbackUp, iframeFrames int) {
+	elite, ok := w.Elite[id]
+	if !ok || elite.ExplodeRadius <= 0 {
+		return
+	}
+	w.Feedback.Trigger(FeedbackExplosion)
+
+	playerID := w.PlayerID
+	if playerID == 0 {
+		return
+	}
+	if w.IsInvincible(playerID) {
+		return
+	}
+
+	enemyPos := w.Position[id]
+	enemyHit := w.Hitbox[id]
+	ex := enemyPos.PixelX() + enemyHit.OffsetX + enemyHit.Width/2
+	ey := enemyPos.PixelY() + enemyHit.OffsetY + enemyHit.Height/2
+
+	playerPos := w.Position[playerID]
+	hitbox := w.HitboxTrapezoid[playerID]
+	bx, by, bw, bh := hitbox.Body.GetWorldRect(playerPos.PixelX(), playerPos.PixelY(), w.Facing[playerID].Right, hitbox.MirrorWidth())
+	dx, dy := bx+bw/2-ex, by+bh/2-ey
+	if dx*dx+dy*dy > elite.ExplodeRadius*elite.ExplodeRadius {
+		return
+	}
+
+	health := w.Health[playerID]
+	health.Current -= elite.ExplodeDamage
+	w.Health[playerID] = health
+	GrantIframes(w, playerID, iframeFrames)
+
+	result.PlayerDamaged = true
+	result.PlayerKnockback.VX = sign(dx) * knockbackForce
+	if dx == 0 {
+		result.PlayerKnockback.VX = knockbackForce
+	}
+	result.PlayerKnockback.VY = -knockbackUp
+	w.Feedback.Trigger(FeedbackPlayerHurt)
+	w.Events.Emit(Event{Type: EventPlayerDamaged, Entity: id, Amount: elite.ExplodeDamage})
+}
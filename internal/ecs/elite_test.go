@@ -0,0 +1,76 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEliteModifier(t *testing.T) {
+	m, ok := ParseEliteModifier("armored")
+	assert.True(t, ok)
+	assert.Equal(t, EliteArmored, m)
+	assert.Equal(t, "armored", m.String())
+
+	_, ok = ParseEliteModifier("golden")
+	assert.False(t, ok)
+}
+
+func TestUpdateDamage_ArmoredEliteBlocksDamage(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    40,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+		Elite:        Elite{Modifier: EliteArmored, ArmorPct: 50},
+	}, true)
+	w.CreateProjectile(100, 100, 50, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 35, w.Health[enemy].Current)
+	assert.Equal(t, []Event{{Type: EventEnemyHit, Entity: enemy, Amount: 5}}, w.Events.Drain())
+}
+
+func TestUpdateDamage_ExplosiveEliteHurtsNearbyPlayer(t *testing.T) {
+	w := NewWorld()
+	body := Hitbox{Width: 12, Height: 12}
+	player := w.CreatePlayer(110, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    5,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+		Elite:        Elite{Modifier: EliteExplosive, ExplodeRadius: 32, ExplodeDamage: 25},
+	}, true)
+	w.CreateProjectile(100, 100, 50, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, w.Exists(enemy))
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 75, w.Health[player].Current)
+	assert.Equal(t, 60, w.CrowdControl[player].IframeTimer)
+	assert.Positive(t, w.Velocity[player].X, "knocked away from the blast")
+}
+
+func TestUpdateTimers_RegeneratingElite(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(0, 0, EnemyConfig{
+		MaxHealth: 10,
+		Elite:     Elite{Modifier: EliteRegenerating, RegenFrames: 3},
+	}, true)
+	health := w.Health[enemy]
+	health.Current = 8
+	w.Health[enemy] = health
+
+	for i := 0; i < 3; i++ {
+		UpdateTimers(w)
+	}
+	assert.Equal(t, 9, w.Health[enemy].Current)
+
+	for i := 0; i < 30; i++ {
+		UpdateTimers(w)
+	}
+	assert.Equal(t, 10, w.Health[enemy].Current, "capped at max health")
+}
@@ -0,0 +1,81 @@
+package ecs
+
+// EnergyConfig holds the player energy meter tuning. Dashing, raising the
+// shield and firing charged arrows spend energy; it regenerates over time
+// and from collected gold. Values are pre-converted to frames and stamina
+// units (StaminaScale).
+type EnergyConfig struct {
+	Max        []int // pool per upgrade tier (empty = no meter, nothing costs energy)
+	Regen      int   // units per frame
+	RegenDelay int   // frames after spending before regen starts
+	PerGold    int   // units restored per gold collected
+
+	DashCost  int
+	BlockCost int               // to raise the shield
+	ShotCost  map[ArrowType]int // per arrow fired (missing = free)
+}
+
+// MaxAt returns the pool of an upgrade tier, clamped to the last tier
+// (0 = no meter)
+func (cfg EnergyConfig) MaxAt(level int) int {
+	if len(cfg.Max) == 0 {
+		return 0
+	}
+	return cfg.Max[max(0, min(level, len(cfg.Max)-1))]
+}
+
+// Energy returns the remaining energy units out of maxEnergy
+func (p *Player) Energy(maxEnergy int) int {
+	return max(0, maxEnergy-p.EnergyUsed)
+}
+
+// spend takes units from the player's meter. It returns false, spending
+// nothing, when the player has too little energy. Without a meter
+// everything is free.
+func (cfg EnergyConfig) spend(player *Player, units int) bool {
+	maxEnergy := cfg.MaxAt(player.EnergyLevel)
+	if maxEnergy <= 0 || units <= 0 {
+		return true
+	}
+	if player.Energy(maxEnergy) < units {
+		return false
+	}
+	player.EnergyUsed += units
+	player.EnergyRegenTimer = cfg.RegenDelay
+	return true
+}
+
+// restoreEnergy gives units back to the player's meter, up to the pool
+func restoreEnergy(player *Player, units int) {
+	player.EnergyUsed = max(0, player.EnergyUsed-units)
+}
+
+// SpendEnergy takes units from the player's meter, or returns false when
+// there is not enough (e.g. for charged arrows fired by the scene)
+func SpendEnergy(w *World, units int) bool {
+	id := w.PlayerID
+	player, ok := w.PlayerData[id]
+	if !ok {
+		return false
+	}
+	if !w.Energy.spend(&player, units) {
+		return false
+	}
+	w.PlayerData[id] = player
+	return true
+}
+
+// UpdateEnergy regenerates the player's energy (call once per frame)
+func UpdateEnergy(w *World) {
+	id := w.PlayerID
+	player, ok := w.PlayerData[id]
+	if !ok || player.EnergyUsed == 0 {
+		return
+	}
+	if player.EnergyRegenTimer > 0 {
+		player.EnergyRegenTimer--
+	} else {
+		restoreEnergy(&player, w.Energy.Regen)
+	}
+	w.PlayerData[id] = player
+}
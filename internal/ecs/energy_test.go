@@ -0,0 +1,92 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEnergyWorld() (*World, EntityID) {
+	w := NewWorld()
+	w.Energy = EnergyConfig{
+		Max:        []int{100, 150},
+		Regen:      5,
+		RegenDelay: 2,
+		PerGold:    10,
+		DashCost:   60,
+		BlockCost:  30,
+		ShotCost:   map[ArrowType]int{ArrowBlue: 20},
+	}
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	return w, player
+}
+
+func TestSpendEnergy_NeedsEnoughLeft(t *testing.T) {
+	w, player := newEnergyWorld()
+
+	assert.True(t, SpendEnergy(w, 80))
+	assert.False(t, SpendEnergy(w, 30), "only 20 left")
+	assert.Equal(t, 80, w.PlayerData[player].EnergyUsed, "a failed spend takes nothing")
+
+	data := w.PlayerData[player]
+	data.EnergyLevel = 1
+	w.PlayerData[player] = data
+	assert.True(t, SpendEnergy(w, 30), "the upgrade expands the pool")
+
+	w.Energy = EnergyConfig{}
+	assert.True(t, SpendEnergy(w, 1000), "no meter: everything is free")
+}
+
+func TestUpdateEnergy_RegeneratesAfterDelay(t *testing.T) {
+	w, player := newEnergyWorld()
+	SpendEnergy(w, 12)
+
+	UpdateEnergy(w)
+	UpdateEnergy(w)
+	assert.Equal(t, 12, w.PlayerData[player].EnergyUsed, "regen waits for the delay")
+
+	UpdateEnergy(w)
+	assert.Equal(t, 7, w.PlayerData[player].EnergyUsed)
+	UpdateEnergy(w)
+	UpdateEnergy(w)
+	assert.Equal(t, 0, w.PlayerData[player].EnergyUsed, "never above the pool")
+}
+
+func TestCollectPickups_GoldRestoresEnergy(t *testing.T) {
+	w, player := newEnergyWorld()
+	w.HitboxTrapezoid[player] = HitboxTrapezoid{Body: Hitbox{Width: 16, Height: 16}}
+	SpendEnergy(w, 50)
+
+	w.CreateGold(104, 104, 3, PickupConfig{CollectRadius: 16, HitboxWidth: 8, HitboxHeight: 8})
+	CollectPickups(w)
+
+	assert.Equal(t, 20, w.PlayerData[player].EnergyUsed)
+}
+
+func TestUpdatePlayerInput_DashCostsEnergy(t *testing.T) {
+	w, player := newEnergyWorld()
+	cfg := PhysicsConfig{DashSpeed: 100, DashFrames: 10}
+
+	UpdatePlayerInput(w, InputState{Dash: true}, cfg)
+	assert.True(t, w.Dash[player].Active)
+	assert.Equal(t, 60, w.PlayerData[player].EnergyUsed)
+
+	w.Dash[player] = Dash{CanDash: true}
+	UpdatePlayerInput(w, InputState{Dash: true}, cfg)
+	assert.False(t, w.Dash[player].Active, "40 energy left is not enough")
+}
+
+func TestUpdatePlayerBlock_RaisingCostsEnergy(t *testing.T) {
+	w, player := newEnergyWorld()
+	w.Block = BlockConfig{MaxStamina: 100 * StaminaScale}
+
+	UpdatePlayerBlock(w, true)
+	UpdatePlayerBlock(w, true)
+	assert.True(t, w.PlayerData[player].Blocking)
+	assert.Equal(t, 30, w.PlayerData[player].EnergyUsed, "paid once when raised")
+
+	SpendEnergy(w, 60)
+	UpdatePlayerBlock(w, false)
+	UpdatePlayerBlock(w, true)
+	assert.False(t, w.PlayerData[player].Blocking, "too little energy to raise it again")
+}
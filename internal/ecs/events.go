@@ -0,0 +1,136 @@
+package ecs
+
+// EventType identifies a gameplay event
+type EventType int
+
+const (
+	EventEnemyKilled           EventType = iota // Entity: enemy, Amount: gold dropped
+	EventPlayerDamaged                          // Amount: damage taken
+	EventGoldCollected                          // Amount: gold picked up
+	EventStageStarted                           // stage (re)started
+	EventStageCleared                           // Amount: frames taken
+	EventBossKilled                             // Entity: boss, Amount: fight duration in frames
+	EventArrowFired                             // Entity: player projectile (0 for a beam)
+	EventEnemyHit                               // Entity: enemy, Amount: damage dealt by a player projectile
+	EventPlayerDied                             // player health reached zero
+	EventProjectileIntercepted                  // Entity: player projectile that destroyed an enemy projectile
+	EventAttackBlocked                          // Entity: attacker, Amount: damage prevented by the shield
+	EventProjectileParried                      // Entity: enemy projectile reflected as player-owned
+	EventDashHit                                // Entity: enemy, Amount: damage dealt by the dash
+	EventHealthCollected                        // Amount: health restored by a pickup
+	EventAmmoCollected                          // Amount: homing arrows picked up
+	EventTreasureCollected                      // Amount: treasure picked up
+	EventStomp                                  // Entity: enemy, Amount: damage dealt by landing on its head
+	EventEnemyAlerted                           // Entity: enemy alerted to the player by its group
+	EventJump                                   // Amount: how the jump press was honored (JumpKind)
+	EventJumpMissed                             // a buffered jump press expired without jumping
+	EventExplosion                              // Amount: blast radius in pixels
+	EventAllySummoned                           // Entity: the summoned turret
+	EventBossAppeared                           // Entity: boss, Amount: arena (see BossFight)
+	EventWaveStarted                            // Entity: first enemy of the wave, Amount: wave number (from 1)
+)
+
+// JumpKind tells how a jump press turned into a jump (EventJump Amount)
+type JumpKind int
+
+const (
+	JumpGround   JumpKind = iota // pressed while standing
+	JumpCoyote                   // pressed just after leaving a ledge
+	JumpBuffered                 // pressed just before landing
+)
+
+// String returns the jump kind name
+func (k JumpKind) String() string {
+	switch k {
+	case JumpGround:
+		return "ground"
+	case JumpCoyote:
+		return "coyote"
+	case JumpBuffered:
+		return "buffered"
+	default:
+		return "unknown"
+	}
+}
+
+// String returns the event name
+func (t EventType) String() string {
+	switch t {
+	case EventEnemyKilled:
+		return "EnemyKilled"
+	case EventPlayerDamaged:
+		return "PlayerDamaged"
+	case EventGoldCollected:
+		return "GoldCollected"
+	case EventStageStarted:
+		return "StageStarted"
+	case EventStageCleared:
+		return "StageCleared"
+	case EventBossKilled:
+		return "BossKilled"
+	case EventArrowFired:
+		return "ArrowFired"
+	case EventEnemyHit:
+		return "EnemyHit"
+	case EventPlayerDied:
+		return "PlayerDied"
+	case EventProjectileIntercepted:
+		return "ProjectileIntercepted"
+	case EventAttackBlocked:
+		return "AttackBlocked"
+	case EventProjectileParried:
+		return "ProjectileParried"
+	case EventDashHit:
+		return "DashHit"
+	case EventHealthCollected:
+		return "HealthCollected"
+	case EventAmmoCollected:
+		return "AmmoCollected"
+	case EventTreasureCollected:
+		return "TreasureCollected"
+	case EventStomp:
+		return "Stomp"
+	case EventEnemyAlerted:
+		return "EnemyAlerted"
+	case EventJump:
+		return "Jump"
+	case EventJumpMissed:
+		return "JumpMissed"
+	case EventExplosion:
+		return "Explosion"
+	case EventAllySummoned:
+		return "AllySummoned"
+	case EventBossAppeared:
+		return "BossAppeared"
+	case EventWaveStarted:
+		return "WaveStarted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a gameplay occurrence emitted by systems for listeners outside
+// the simulation (achievements, stats, UI). Systems never read events back.
+type Event struct {
+	Type   EventType
+	Entity EntityID
+	Amount int
+}
+
+// Events is the world's per-frame event queue
+type Events struct {
+	queue []Event
+}
+
+// Emit queues an event
+func (e *Events) Emit(ev Event) {
+	e.queue = append(e.queue, ev)
+}
+
+// Drain returns the queued events and clears the queue.
+// The returned slice is reused by later Emits; consume it before the next update.
+func (e *Events) Drain() []Event {
+	events := e.queue
+	e.queue = e.queue[:0]
+	return events
+}
@@ -0,0 +1,96 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents_EmitDrain(t *testing.T) {
+	e := &Events{}
+	assert.Empty(t, e.Drain())
+
+	e.Emit(Event{Type: EventGoldCollected, Amount: 5})
+	e.Emit(Event{Type: EventEnemyKilled})
+
+	events := e.Drain()
+	assert.Equal(t, []Event{{Type: EventGoldCollected, Amount: 5}, {Type: EventEnemyKilled}}, events)
+	assert.Empty(t, e.Drain(), "queue is cleared")
+}
+
+func TestUpdateDamage_EmitsKillEvent(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    10,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+		Loot:         GoldLoot(4, 4),
+	}, true)
+	w.CreateProjectile(100, 100, 50, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, []Event{
+		{Type: EventEnemyHit, Entity: enemy, Amount: 10},
+		{Type: EventEnemyKilled, Entity: enemy, Amount: 4},
+	}, w.Events.Drain())
+}
+
+func TestCollectPickups_EmitsGoldEvent(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	gold := w.CreateGold(0, 0, 7, PickupConfig{CollectRadius: 32, HitboxWidth: 8, HitboxHeight: 8})
+
+	CollectPickups(w)
+
+	assert.Equal(t, []Event{{Type: EventGoldCollected, Entity: gold, Amount: 7}}, w.Events.Drain())
+	assert.Equal(t, 7, w.PlayerData[w.PlayerID].Gold)
+}
+
+func TestEventType_String(t *testing.T) {
+	assert.Equal(t, "EnemyKilled", EventEnemyKilled.String())
+	assert.Equal(t, "BossKilled", EventBossKilled.String())
+	assert.Equal(t, "ProjectileIntercepted", EventProjectileIntercepted.String())
+	assert.Equal(t, "Stomp", EventStomp.String())
+	assert.Equal(t, "EnemyAlerted", EventEnemyAlerted.String())
+	assert.Equal(t, "Unknown", EventType(99).String())
+}
+
+func TestUpdatePlayerInput_JumpEvents(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	cfg := PhysicsConfig{JumpForce: 50, CoyoteFrames: 3, JumpBufferFrames: 3}
+	jumped := func(kind JumpKind) []Event { return []Event{{Type: EventJump, Amount: int(kind)}} }
+
+	w.Movement[player] = Movement{OnGround: true}
+	UpdatePlayerInput(w, InputState{JumpPressed: true}, cfg)
+	assert.Equal(t, jumped(JumpGround), w.Events.Drain())
+
+	// Walked off a ledge: coyote time
+	w.Movement[player] = Movement{OnGround: true}
+	UpdatePlayerInput(w, InputState{}, cfg)
+	w.Movement[player] = Movement{}
+	w.Velocity[player] = Velocity{}
+	UpdateTimers(w)
+	UpdatePlayerInput(w, InputState{JumpPressed: true}, cfg)
+	assert.Equal(t, jumped(JumpCoyote), w.Events.Drain())
+
+	// Pressed in the air, landed before the buffer ran out
+	UpdatePlayerInput(w, InputState{JumpPressed: true}, cfg)
+	UpdateTimers(w)
+	w.Movement[player] = Movement{OnGround: true}
+	UpdatePlayerInput(w, InputState{}, cfg)
+	assert.Equal(t, jumped(JumpBuffered), w.Events.Drain())
+
+	// Pressed too early
+	w.Movement[player] = Movement{}
+	cfg.InputGraceFrames = 1
+	UpdatePlayerInput(w, InputState{JumpPressed: true}, cfg)
+	for i := 0; i < 3; i++ {
+		UpdateTimers(w)
+	}
+	assert.Empty(t, w.Events.Drain(), "the lag grace frame extends the buffer")
+	UpdateTimers(w)
+	assert.Equal(t, []Event{{Type: EventJumpMissed}}, w.Events.Drain())
+}
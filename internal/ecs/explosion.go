@@ -0,0 +1,155 @@
+package ecs
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// Explosion describes an area-of-effect blast (see SpawnExplosion)
+type Explosion struct {
+	Radius       int  // pixels
+	Damage       int  // at the center
+	FalloffPct   int  // 0-100, share of damage and knockback lost at the edge
+	Knockback    int  // radial push at the center (IU/substep)
+	IframeFrames int  // player invincibility after a hit
+	SparePlayer  bool // player-made blasts (arrows) only hurt enemies
+	SpareEnemies bool // traps meant for the player only
+}
+
+// Explosion tuning
+const (
+	explosionShakeRadii = 3 // the blast shakes the screen up to this many radii from the player
+	explosionParticles  = 12
+	explosionLife       = 18 // frames
+)
+
+// ExplosionColor is the color of explosion particles
+var ExplosionColor = color.RGBA{255, 150, 60, 255}
+
+// SpawnExplosion blasts everything within the radius of pixel (x, y) that
+// a wall doesn't shield: damage and knockback fall off toward the edge and
+// push away from the center. The screen shakes harder the closer the
+// player is. Enemies it kills drop loot like any other kill.
+func SpawnExplosion(w *World, stage Stage, x, y int, e Explosion) DamageResult {
+	result := DamageResult{}
+	if e.Radius <= 0 {
+		return result
+	}
+	spawnExplosionParticles(w, x, y, e.Radius)
+	w.Events.Emit(Event{Type: EventExplosion, Amount: e.Radius})
+
+	// Not the shared kill buffer: blasts go off from inside other systems
+	var killed []EntityID
+	for _, id := range w.Query(TagEnemy) {
+		if e.SpareEnemies || w.IsInvincible(id) || w.AI[id].SpawnTimer > 0 {
+			continue
+		}
+		damage, vx, vy, ok := explosionHit(w, stage, x, y, id, e)
+		if !ok {
+			continue
+		}
+		health := w.Health[id]
+		damage = eliteDamage(w, id, damage)
+		health.Current -= damage
+		w.Health[id] = health
+		w.Events.Emit(Event{Type: EventEnemyHit, Entity: id, Amount: damage})
+		Knockback(w, id, vx, vy, 0)
+		Stun(w, id, 0)
+		if health.Current <= 0 {
+			killed = append(killed, id)
+		}
+	}
+
+	if playerID := w.PlayerID; playerID != 0 {
+		px, py := HitboxCenter(w, playerID)
+		if pct := explosionShakePct(x-px, y-py, e.Radius); pct > 0 {
+			w.Feedback.TriggerScaled(FeedbackExplosion, pct)
+		}
+		if !e.SparePlayer && !w.IsInvincible(playerID) {
+			if damage, vx, vy, ok := explosionHit(w, stage, x, y, playerID, e); ok {
+				health := w.Health[playerID]
+				health.Current -= damage
+				w.Health[playerID] = health
+				GrantIframes(w, playerID, e.IframeFrames)
+				result.PlayerDamaged = true
+				result.PlayerKnockback.VX, result.PlayerKnockback.VY = vx, vy
+				w.Feedback.Trigger(FeedbackPlayerHurt)
+				w.Events.Emit(Event{Type: EventPlayerDamaged, Amount: damage})
+			}
+		}
+	}
+
+	killEnemies(w, killed, &result, e.Knockback, e.Knockback/2, e.IframeFrames)
+
+	if result.PlayerDamaged {
+		Knockback(w, w.PlayerID, result.PlayerKnockback.VX, result.PlayerKnockback.VY, 0)
+	}
+	return result
+}
+
+// explosionHit returns the damage and knockback an entity takes from a
+// blast at pixel (x, y). ok is false when it is out of range or behind a
+// wall.
+func explosionHit(w *World, stage Stage, x, y int, id EntityID, e Explosion) (damage, vx, vy int, ok bool) {
+	tx, ty := HitboxCenter(w, id)
+	dx, dy := tx-x, ty-y
+	dist := isqrt(dx*dx + dy*dy)
+	if dist > e.Radius || !lineOfSight(stage, x, y, tx, ty) {
+		return 0, 0, 0, false
+	}
+
+	// Linear falloff from the full value at the center
+	keepPct := 100 - fixedpoint.MulDiv(min(e.FalloffPct, 100), dist, e.Radius)
+	damage = max(1, fixedpoint.MulPct(e.Damage, keepPct))
+	force := fixedpoint.MulPct(e.Knockback, keepPct)
+	if dist == 0 {
+		return damage, 0, -force, true // straight up from the center
+	}
+	return damage, divRound(dx*force, dist), divRound(dy*force, dist), true
+}
+
+// lineOfSight reports whether no solid tile lies between two pixels,
+// sampled every half tile (the end points themselves aren't checked, so a
+// blast against a wall still reaches past its own tile)
+func lineOfSight(stage Stage, x0, y0, x1, y1 int) bool {
+	dx, dy := x1-x0, y1-y0
+	step := max(1, stage.GetTileSize()/2)
+	n := max(abs(dx), abs(dy)) / step
+	for i := 1; i < n; i++ {
+		if stage.IsSolidAt(x0+dx*i/n, y0+dy*i/n) {
+			return false
+		}
+	}
+	return true
+}
+
+// explosionShakePct returns the screen shake (0-100%) of a blast at offset
+// (dx, dy) pixels from the player
+func explosionShakePct(dx, dy, radius int) int {
+	reach := radius * explosionShakeRadii
+	dist := isqrt(dx*dx + dy*dy)
+	if dist >= reach {
+		return 0
+	}
+	return 100 - fixedpoint.MulDiv(100, dist, reach)
+}
+
+// spawnExplosionParticles emits a ring of particles that travel about the
+// blast radius over their life
+func spawnExplosionParticles(w *World, x, y, radius int) {
+	speed := radius * PositionScale / explosionLife
+	for i := range explosionParticles {
+		angle := 2 * math.Pi * float64(i) / explosionParticles
+		id := w.newPooledEntity(&w.particlePool)
+		w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
+		w.Particle[id] = Particle{
+			VX:      int(math.Cos(angle) * float64(speed)),
+			VY:      int(math.Sin(angle) * float64(speed)),
+			Life:    explosionLife,
+			MaxLife: explosionLife,
+			Color:   ExplosionColor,
+		}
+	}
+}
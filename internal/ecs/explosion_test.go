@@ -0,0 +1,77 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newExplosionWorld() (*World, *mockStage) {
+	w := NewWorld()
+	w.CreatePlayer(400, 400, PlayerProfile{MaxHealth: 100})
+	return w, newMockStage(40, 40, 16)
+}
+
+func createExplosionTarget(w *World, x, y int) EntityID {
+	// 16x16 hitbox: the center is at (x+8, y+8)
+	return w.CreateEnemy(x, y, EnemyConfig{MaxHealth: 100, HitboxWidth: 16, HitboxHeight: 16, Flying: true}, false)
+}
+
+func TestSpawnExplosion_DamageFallsOffWithDistance(t *testing.T) {
+	w, stage := newExplosionWorld()
+	center := createExplosionTarget(w, 92, 92)
+	edge := createExplosionTarget(w, 140, 92)
+	outside := createExplosionTarget(w, 200, 92)
+
+	SpawnExplosion(w, stage, 100, 100, Explosion{Radius: 48, Damage: 40, FalloffPct: 50, Knockback: 200, SparePlayer: true})
+
+	assert.Equal(t, 60, w.Health[center].Current, "full damage at the center")
+	assert.Equal(t, 80, w.Health[edge].Current, "half damage at the edge")
+	assert.Equal(t, 100, w.Health[outside].Current)
+	assert.Equal(t, -200, w.Velocity[center].Y, "pushed straight up from the center")
+	assert.Equal(t, 100, w.Velocity[edge].X, "pushed away at half force")
+	assert.Len(t, w.Particle, explosionParticles)
+}
+
+func TestSpawnExplosion_WallsShield(t *testing.T) {
+	w, stage := newExplosionWorld()
+	stage.setSolid(7, 6) // pixels 112-127 between the blast and the enemy
+	hidden := createExplosionTarget(w, 132, 92)
+
+	SpawnExplosion(w, stage, 100, 100, Explosion{Radius: 64, Damage: 40})
+
+	assert.Equal(t, 100, w.Health[hidden].Current)
+}
+
+func TestSpawnExplosion_HurtsAndShakesPlayer(t *testing.T) {
+	w, stage := newExplosionWorld()
+	px, py := HitboxCenter(w, w.PlayerID)
+
+	result := SpawnExplosion(w, stage, px-20, py, Explosion{Radius: 40, Damage: 10, Knockback: 300, IframeFrames: 30})
+
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 90, w.Health[w.PlayerID].Current)
+	assert.Positive(t, result.PlayerKnockback.VX, "pushed away from the blast")
+	assert.True(t, w.IsInvincible(w.PlayerID))
+	assert.Positive(t, w.Feedback.amplitude())
+
+	spared, _ := newExplosionWorld()
+	result = SpawnExplosion(spared, stage, px-20, py, Explosion{Radius: 40, Damage: 10, SparePlayer: true})
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 100, spared.Health[spared.PlayerID].Current)
+}
+
+func TestSpawnExplosion_Kills(t *testing.T) {
+	w, stage := newExplosionWorld()
+	enemy := createExplosionTarget(w, 92, 92)
+
+	SpawnExplosion(w, stage, 100, 100, Explosion{Radius: 32, Damage: 200, SparePlayer: true})
+
+	assert.False(t, w.Exists(enemy))
+}
+
+func TestExplosionShakePct(t *testing.T) {
+	assert.Equal(t, 100, explosionShakePct(0, 0, 20))
+	assert.Equal(t, 50, explosionShakePct(30, 0, 20))
+	assert.Equal(t, 0, explosionShakePct(60, 0, 20))
+}
@@ -0,0 +1,65 @@
+package ecs
+
+// Faction is the side an entity (or the shooter of a projectile) fights on
+type Faction int
+
+const (
+	FactionNone    Faction = iota // unaligned: enemies created without one join FactionEnemy
+	FactionPlayer                 // the player, its arrows and allies
+	FactionEnemy                  // regular enemies and their shots
+	FactionNeutral                // wildlife: attacks nobody
+	FactionHostile                // attacks everyone
+	FactionCount
+)
+
+// factionNames maps factions to their config names
+var factionNames = [FactionCount]string{"", "player", "enemy", "neutral", "hostile"}
+
+// String returns the config name of the faction
+func (f Faction) String() string {
+	if f < 0 || f >= FactionCount {
+		return "unknown"
+	}
+	return factionNames[f]
+}
+
+// ParseFaction returns the faction for a config name
+func ParseFaction(name string) (Faction, bool) {
+	for f := FactionPlayer; f < FactionCount; f++ {
+		if factionNames[f] == name {
+			return f, true
+		}
+	}
+	return FactionNone, false
+}
+
+// FactionRelations says which factions can hurt which: [attacker][target]
+type FactionRelations [FactionCount][FactionCount]bool
+
+// DefaultFactionRelations returns the built-in relations: the player and
+// enemies fight each other, the player can also hunt neutral wildlife, and
+// hostile entities fight everyone (but not each other)
+func DefaultFactionRelations() FactionRelations {
+	var r FactionRelations
+	r[FactionPlayer][FactionEnemy] = true
+	r[FactionPlayer][FactionNeutral] = true
+	r[FactionEnemy][FactionPlayer] = true
+	for f := FactionPlayer; f < FactionHostile; f++ {
+		r[FactionHostile][f] = true
+		r[f][FactionHostile] = true
+	}
+	return r
+}
+
+// Hostile reports whether the attacker's faction hurts the target's
+func (r *FactionRelations) Hostile(attacker, target Faction) bool {
+	if attacker < 0 || attacker >= FactionCount || target < 0 || target >= FactionCount {
+		return false
+	}
+	return r[attacker][target]
+}
+
+// Hostile reports whether entity attacker can hurt entity target
+func (w *World) Hostile(attacker, target EntityID) bool {
+	return w.Factions.Hostile(w.Faction[attacker], w.Faction[target])
+}
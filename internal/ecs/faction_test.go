@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPlayerHitbox = HitboxTrapezoid{
+	Head: Hitbox{OffsetX: 4, OffsetY: 0, Width: 8, Height: 6},
+	Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
+	Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
+}
+
+func TestDefaultFactionRelations(t *testing.T) {
+	r := DefaultFactionRelations()
+
+	assert.True(t, r.Hostile(FactionPlayer, FactionEnemy))
+	assert.True(t, r.Hostile(FactionEnemy, FactionPlayer))
+	assert.True(t, r.Hostile(FactionPlayer, FactionNeutral), "wildlife can be hunted")
+	assert.False(t, r.Hostile(FactionNeutral, FactionPlayer))
+	assert.False(t, r.Hostile(FactionEnemy, FactionEnemy))
+	for _, f := range []Faction{FactionPlayer, FactionEnemy, FactionNeutral} {
+		assert.True(t, r.Hostile(FactionHostile, f))
+		assert.True(t, r.Hostile(f, FactionHostile))
+	}
+	assert.False(t, r.Hostile(FactionNone, FactionPlayer))
+	assert.False(t, r.Hostile(FactionCount, FactionPlayer))
+}
+
+func TestParseFaction(t *testing.T) {
+	for f := FactionPlayer; f < FactionCount; f++ {
+		got, ok := ParseFaction(f.String())
+		assert.True(t, ok)
+		assert.Equal(t, f, got)
+	}
+	_, ok := ParseFaction("")
+	assert.False(t, ok)
+	_, ok = ParseFaction("pirates")
+	assert.False(t, ok)
+}
+
+func TestCreateEnemy_DefaultsToEnemyFaction(t *testing.T) {
+	w := NewWorld()
+	regular := w.CreateEnemy(0, 0, EnemyConfig{MaxHealth: 10}, true)
+	critter := w.CreateEnemy(0, 0, EnemyConfig{MaxHealth: 10, Faction: FactionNeutral}, true)
+	player := w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 10})
+
+	assert.Equal(t, FactionEnemy, w.Faction[regular])
+	assert.Equal(t, FactionNeutral, w.Faction[critter])
+	assert.Equal(t, FactionPlayer, w.Faction[player])
+}
+
+func TestUpdateDamage_NeutralEnemyDoesNotTouchPlayer(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 16, HitboxHeight: 24, Faction: FactionNeutral}, true)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 100, w.Health[player].Current)
+}
+
+func TestUpdateDamage_ProjectilesHitHostileFactionsOnly(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemyCfg := EnemyConfig{MaxHealth: 50, HitboxWidth: 16, HitboxHeight: 16, Flying: true}
+	ally := w.CreateEnemy(100, 100, enemyCfg, true)
+	enemyCfg.Faction = FactionHostile
+	monster := w.CreateEnemy(200, 100, enemyCfg, true)
+
+	shot := ProjectileConfig{Damage: 10, HitboxWidth: 12, HitboxHeight: 4}
+	passing := w.CreateProjectile(100, 105, 90, 0, shot, FactionEnemy)
+	hitting := w.CreateProjectile(200, 105, 90, 0, shot, FactionEnemy)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 50, w.Health[ally].Current, "enemies don't shoot each other")
+	assert.True(t, w.Exists(passing))
+	assert.Equal(t, 40, w.Health[monster].Current)
+	assert.False(t, w.Exists(hitting))
+}
+
+func TestUpdateDamage_FactionRelationsFromConfig(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	w.Factions[FactionEnemy][FactionPlayer] = false
+	shot := w.CreateProjectile(104, 110, 90, 0, ProjectileConfig{Damage: 10, HitboxWidth: 12, HitboxHeight: 4}, FactionEnemy)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 100, w.Health[player].Current)
+	assert.True(t, w.Exists(shot))
+}
+
+func TestAcquireHomingTarget_SeeksHostileFactions(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	w.CreateEnemy(110, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 16, HitboxHeight: 16}, true)
+	monster := w.CreateEnemy(160, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 16, HitboxHeight: 16, Faction: FactionHostile}, true)
+	proj := &Projectile{Homing: Homing{Range: 200}}
+
+	assert.Equal(t, w.PlayerID, acquireHomingTarget(w, 100, 100, proj, FactionEnemy))
+	assert.Equal(t, monster, acquireHomingTarget(w, 200, 100, proj, FactionEnemy), "closer than the player")
+	assert.Equal(t, EntityID(0), acquireHomingTarget(w, 100, 100, proj, FactionNone))
+}
@@ -0,0 +1,252 @@
+package ecs
+
+import "math"
+
+// Feedback event names (keys into the feedback profile table)
+const (
+	FeedbackLightHit   = "lightHit"
+	FeedbackHeavyHit   = "heavyHit"
+	FeedbackPlayerHurt = "playerHurt"
+	FeedbackBossSlam   = "bossSlam"
+	FeedbackDash       = "dash"
+	FeedbackExplosion  = "explosion"
+	FeedbackBlock      = "block"
+	FeedbackParry      = "parry"
+)
+
+// ShakeFalloff defines how shake amplitude decreases over its duration
+type ShakeFalloff int
+
+const (
+	FalloffLinear      ShakeFalloff = iota // 1 → 0 linearly
+	FalloffQuadratic                       // (1-t)², fast start, soft tail
+	FalloffExponential                     // Decay^frame, matches the old per-frame multiply
+)
+
+// ShakeStacking defines how a new shake combines with active ones
+type ShakeStacking int
+
+const (
+	StackReplace ShakeStacking = iota // clear active shakes, then start this one
+	StackMax                          // only start if stronger than the current amplitude
+	StackAdd                          // run alongside active shakes (amplitudes sum)
+)
+
+// FeedbackProfile describes the hitstop and screen shake for one event.
+// Values are pre-converted to frames (like PhysicsConfig).
+type FeedbackProfile struct {
+	HitstopFrames  int
+	ShakeIntensity float64 // pixels (rendering only)
+	ShakeFrames    int
+	Falloff        ShakeFalloff
+	DecayPct       int  // 0-100, per-frame amplitude retained (FalloffExponential only)
+	Directional    bool // shake along the event direction instead of randomly
+	Stacking       ShakeStacking
+
+	// Gamepad rumble (output only, 0 frames = no rumble)
+	Rumble Rumble
+}
+
+// Rumble is a gamepad vibration request
+type Rumble struct {
+	Strong float64 // 0-1, low-frequency motor
+	Weak   float64 // 0-1, high-frequency motor
+	Frames int
+}
+
+// DefaultFeedbackProfiles returns the built-in profiles used when config has none
+func DefaultFeedbackProfiles() map[string]FeedbackProfile {
+	return map[string]FeedbackProfile{
+		FeedbackLightHit:   {HitstopFrames: 3, ShakeIntensity: 4, ShakeFrames: 20, Falloff: FalloffExponential, DecayPct: 90, Directional: true, Stacking: StackMax},
+		FeedbackHeavyHit:   {HitstopFrames: 6, ShakeIntensity: 6, ShakeFrames: 24, Falloff: FalloffQuadratic, Directional: true, Stacking: StackAdd, Rumble: Rumble{Strong: 0.6, Weak: 0.4, Frames: 12}},
+		FeedbackPlayerHurt: {HitstopFrames: 0, ShakeIntensity: 6, ShakeFrames: 20, Falloff: FalloffExponential, DecayPct: 90, Stacking: StackReplace, Rumble: Rumble{Strong: 0.8, Weak: 0.5, Frames: 18}},
+		FeedbackBossSlam:   {HitstopFrames: 8, ShakeIntensity: 10, ShakeFrames: 40, Falloff: FalloffLinear, Stacking: StackAdd, Rumble: Rumble{Strong: 1.0, Weak: 0.6, Frames: 30}},
+		FeedbackDash:       {Rumble: Rumble{Weak: 0.3, Frames: 6}},
+		FeedbackExplosion:  {HitstopFrames: 4, ShakeIntensity: 8, ShakeFrames: 30, Falloff: FalloffQuadratic, Stacking: StackAdd, Rumble: Rumble{Strong: 0.9, Weak: 0.5, Frames: 20}},
+		FeedbackBlock:      {HitstopFrames: 2, ShakeIntensity: 2, ShakeFrames: 10, Falloff: FalloffLinear, Stacking: StackMax, Rumble: Rumble{Weak: 0.4, Frames: 8}},
+		FeedbackParry:      {HitstopFrames: 5, ShakeIntensity: 3, ShakeFrames: 12, Falloff: FalloffQuadratic, Stacking: StackReplace, Rumble: Rumble{Strong: 0.3, Weak: 0.6, Frames: 10}},
+	}
+}
+
+// maxShakeIntensity caps the summed amplitude of stacked shakes (pixels)
+const maxShakeIntensity = 12.0
+
+// directionalShakeFreq is the oscillation speed of directional shakes (radians/frame)
+const directionalShakeFreq = 1.6
+
+type shakeInstance struct {
+	profile FeedbackProfile
+	frame   int
+	dirX    float64
+	dirY    float64
+}
+
+// amplitude returns the current amplitude in pixels
+func (s shakeInstance) amplitude() float64 {
+	if s.frame >= s.profile.ShakeFrames {
+		return 0
+	}
+	t := float64(s.frame) / float64(s.profile.ShakeFrames)
+	switch s.profile.Falloff {
+	case FalloffQuadratic:
+		return s.profile.ShakeIntensity * (1 - t) * (1 - t)
+	case FalloffExponential:
+		return s.profile.ShakeIntensity * math.Pow(float64(s.profile.DecayPct)/100, float64(s.frame))
+	default:
+		return s.profile.ShakeIntensity * (1 - t)
+	}
+}
+
+// Feedback is the world's hitstop, screen shake and rumble state.
+// Systems call Trigger/TriggerDir; UpdateFeedback hands hitstop to the
+// world's TimeScale and the scene consults it for the camera offset.
+type Feedback struct {
+	Profiles map[string]FeedbackProfile
+
+	// Global toggles (config feedback.hitstop.enabled / screenShake.enabled)
+	HitstopEnabled bool
+	ShakeEnabled   bool
+	ShakeScalePct  int // 0-100+, user setting multiplier
+	RumbleEnabled  bool
+
+	hitstop int // longest hitstop requested since the last UpdateFeedback
+	shakes  []shakeInstance
+	rumble  Rumble // strongest pending rumble this frame
+}
+
+// NewFeedback creates feedback state with the default profiles
+func NewFeedback() *Feedback {
+	return &Feedback{
+		Profiles:       DefaultFeedbackProfiles(),
+		HitstopEnabled: true,
+		ShakeEnabled:   true,
+		ShakeScalePct:  100,
+		RumbleEnabled:  true,
+	}
+}
+
+// Trigger starts the named profile with no direction
+func (f *Feedback) Trigger(name string) {
+	f.TriggerDir(name, 0, 0)
+}
+
+// TriggerScaled starts the named profile with its shake and rumble scaled
+// to pct (0-100, e.g. by distance from the player)
+func (f *Feedback) TriggerScaled(name string, pct int) {
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return
+	}
+	scale := float64(pct) / 100
+	profile.ShakeIntensity *= scale
+	profile.Rumble.Strong *= scale
+	profile.Rumble.Weak *= scale
+	f.trigger(profile, 0, 0)
+}
+
+// TriggerDir starts the named profile. dirX/dirY give the shake direction
+// for directional profiles (any magnitude, typically a knockback velocity).
+func (f *Feedback) TriggerDir(name string, dirX, dirY int) {
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return
+	}
+	f.trigger(profile, dirX, dirY)
+}
+
+// trigger starts a profile's hitstop, rumble and shake
+func (f *Feedback) trigger(profile FeedbackProfile, dirX, dirY int) {
+
+	if f.HitstopEnabled && profile.HitstopFrames > f.hitstop {
+		f.hitstop = profile.HitstopFrames
+	}
+
+	if f.RumbleEnabled && profile.Rumble.Frames > 0 && profile.Rumble.Strong+profile.Rumble.Weak > f.rumble.Strong+f.rumble.Weak {
+		f.rumble = profile.Rumble
+	}
+
+	if !f.ShakeEnabled || profile.ShakeIntensity <= 0 || profile.ShakeFrames <= 0 {
+		return
+	}
+
+	inst := shakeInstance{profile: profile}
+	if profile.Directional && (dirX != 0 || dirY != 0) {
+		mag := math.Hypot(float64(dirX), float64(dirY))
+		inst.dirX = float64(dirX) / mag
+		inst.dirY = float64(dirY) / mag
+	}
+
+	switch profile.Stacking {
+	case StackReplace:
+		f.shakes = append(f.shakes[:0], inst)
+	case StackMax:
+		if profile.ShakeIntensity < f.amplitude() {
+			return
+		}
+		f.shakes = append(f.shakes[:0], inst)
+	default:
+		f.shakes = append(f.shakes, inst)
+	}
+}
+
+// Hitstop returns the hitstop frames requested this frame (not yet handed
+// to the TimeScale)
+func (f *Feedback) Hitstop() int {
+	return f.hitstop
+}
+
+// TakeRumble returns the strongest rumble requested since the last call
+// and clears it. ok is false when nothing was requested.
+func (f *Feedback) TakeRumble() (r Rumble, ok bool) {
+	r = f.rumble
+	f.rumble = Rumble{}
+	return r, r.Frames > 0
+}
+
+// amplitude returns the summed current amplitude of all active shakes
+func (f *Feedback) amplitude() float64 {
+	total := 0.0
+	for _, s := range f.shakes {
+		total += s.amplitude()
+	}
+	return math.Min(total, maxShakeIntensity)
+}
+
+// ShakeOffset returns the camera offset in pixels for this frame.
+// rx/ry are random values in [-1, 1] supplied by the renderer for
+// non-directional jitter; directional shakes oscillate along their axis.
+func (f *Feedback) ShakeOffset(rx, ry float64) (float64, float64) {
+	x, y := 0.0, 0.0
+	for _, s := range f.shakes {
+		amp := s.amplitude()
+		if s.dirX != 0 || s.dirY != 0 {
+			osc := math.Cos(float64(s.frame) * directionalShakeFreq)
+			x += s.dirX * amp * osc
+			y += s.dirY * amp * osc
+		} else {
+			x += amp * rx
+			y += amp * ry
+		}
+	}
+	scale := float64(f.ShakeScalePct) / 100
+	x = math.Max(-maxShakeIntensity, math.Min(maxShakeIntensity, x*scale))
+	y = math.Max(-maxShakeIntensity, math.Min(maxShakeIntensity, y*scale))
+	return x, y
+}
+
+// UpdateFeedback starts the frame's hitstop and advances active screen
+// shakes by one frame
+func UpdateFeedback(w *World) {
+	f := w.Feedback
+	w.TimeScale.Hitstop(f.hitstop)
+	f.hitstop = 0
+
+	active := f.shakes[:0]
+	for _, s := range f.shakes {
+		s.frame++
+		if s.frame < s.profile.ShakeFrames {
+			active = append(active, s)
+		}
+	}
+	f.shakes = active
+}
@@ -0,0 +1,141 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedback_HitstopTakesLongest(t *testing.T) {
+	w := NewWorld()
+
+	w.Feedback.Trigger(FeedbackHeavyHit) // 6 frames
+	w.Feedback.Trigger(FeedbackLightHit) // 3 frames, should not shorten
+
+	assert.Equal(t, 6, w.Feedback.Hitstop())
+	UpdateFeedback(w)
+	assert.Equal(t, 0, w.Feedback.Hitstop(), "handed to the time scale")
+	for i := 0; i < 6; i++ {
+		assert.True(t, w.TimeScale.TickHitstop())
+	}
+	assert.False(t, w.TimeScale.TickHitstop())
+}
+
+func TestFeedback_HitstopDisabled(t *testing.T) {
+	w := NewWorld()
+	w.Feedback.HitstopEnabled = false
+
+	w.Feedback.Trigger(FeedbackHeavyHit)
+	UpdateFeedback(w)
+
+	assert.False(t, w.TimeScale.TickHitstop())
+}
+
+func TestFeedback_Stacking(t *testing.T) {
+	t.Run("replace keeps only the newest shake", func(t *testing.T) {
+		f := NewFeedback()
+		f.Trigger(FeedbackBossSlam)
+		f.Trigger(FeedbackPlayerHurt)
+		assert.Len(t, f.shakes, 1)
+		assert.Equal(t, 6.0, f.amplitude())
+	})
+
+	t.Run("max ignores weaker shakes", func(t *testing.T) {
+		f := NewFeedback()
+		f.Trigger(FeedbackBossSlam)
+		f.Trigger(FeedbackLightHit)
+		assert.Len(t, f.shakes, 1)
+		assert.Equal(t, 10.0, f.amplitude())
+	})
+
+	t.Run("add sums amplitudes up to the cap", func(t *testing.T) {
+		f := NewFeedback()
+		f.Trigger(FeedbackHeavyHit)
+		f.Trigger(FeedbackBossSlam)
+		assert.Len(t, f.shakes, 2)
+		assert.Equal(t, maxShakeIntensity, f.amplitude())
+	})
+}
+
+func TestFeedback_FalloffReachesZero(t *testing.T) {
+	w := NewWorld()
+	w.Feedback.Profiles = map[string]FeedbackProfile{
+		"test": {ShakeIntensity: 8, ShakeFrames: 4, Falloff: FalloffLinear},
+	}
+	w.Feedback.Trigger("test")
+
+	expected := []float64{8, 6, 4, 2}
+	for _, amp := range expected {
+		assert.Equal(t, amp, w.Feedback.amplitude())
+		UpdateFeedback(w)
+	}
+	assert.Empty(t, w.Feedback.shakes, "expired shakes are removed")
+
+	x, y := w.Feedback.ShakeOffset(1, 1)
+	assert.Equal(t, 0.0, x)
+	assert.Equal(t, 0.0, y)
+}
+
+func TestFeedback_DirectionalShake(t *testing.T) {
+	f := NewFeedback()
+	f.Profiles = map[string]FeedbackProfile{
+		"test": {ShakeIntensity: 4, ShakeFrames: 10, Directional: true},
+	}
+
+	f.TriggerDir("test", 100, 0) // horizontal hit
+
+	// Random jitter is ignored for directional shakes
+	x, y := f.ShakeOffset(-1, 1)
+	assert.Equal(t, 4.0, x)
+	assert.Equal(t, 0.0, y)
+}
+
+func TestUpdateDamage_TriggersFeedback(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    100,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+	}, true)
+	w.CreateProjectile(100, 100, 50, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 90, w.Health[enemy].Current)
+	assert.Equal(t, DefaultFeedbackProfiles()[FeedbackLightHit].HitstopFrames, w.Feedback.Hitstop())
+}
+
+func TestFeedback_Rumble(t *testing.T) {
+	f := NewFeedback()
+
+	_, ok := f.TakeRumble()
+	assert.False(t, ok, "no rumble before any event")
+
+	f.Trigger(FeedbackDash)
+	f.Trigger(FeedbackPlayerHurt)
+	f.Trigger(FeedbackDash) // weaker, should not override
+
+	r, ok := f.TakeRumble()
+	assert.True(t, ok)
+	assert.Equal(t, DefaultFeedbackProfiles()[FeedbackPlayerHurt].Rumble, r)
+
+	_, ok = f.TakeRumble()
+	assert.False(t, ok, "rumble is consumed")
+
+	f.RumbleEnabled = false
+	f.Trigger(FeedbackPlayerHurt)
+	_, ok = f.TakeRumble()
+	assert.False(t, ok, "disabled rumble is never requested")
+}
+
+func TestFeedback_TriggerScaled(t *testing.T) {
+	f := NewFeedback()
+	f.TriggerScaled(FeedbackExplosion, 50) // 8 px profile
+
+	assert.InDelta(t, 4.0, f.amplitude(), 1e-9)
+	r, ok := f.TakeRumble()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.45, r.Strong, 1e-9)
+	assert.Equal(t, 4, f.Hitstop(), "hitstop isn't scaled")
+}
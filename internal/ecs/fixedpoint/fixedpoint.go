@@ -0,0 +1,98 @@
+// Package fixedpoint converts between config units (pixels, seconds) and the
+// integer units the ECS simulates in, and provides overflow-safe integer
+// multiplication helpers.
+//
+// Positions are in internal units (IU): 1 pixel = Scale IU. Velocities are
+// IU per substep, and the simulation runs Sim frames per second (default
+// FramesPerSecond) of SubstepsPerFrame substeps each. The package-level
+// conversions use Sim; a Rate converts for any other simulation rate.
+//
+// Rounding: float to integer conversions round half up (to the nearest
+// integer, .5 toward +Inf), so a config value maps to the closest IU value.
+// Integer helpers (MulDiv, MulPct) truncate toward zero like Go's integer
+// division, keeping the simulation's existing integer behavior.
+package fixedpoint
+
+import "math"
+
+const (
+	// Scale is the number of internal units per pixel (2^Shift)
+	Scale = 256
+	// Shift is log2(Scale), for converting IU to pixels with a shift
+	Shift = 8
+
+	// FramesPerSecond is the default simulation rate
+	FramesPerSecond   = 60
+	SubstepsPerFrame  = 10
+	SubstepsPerSecond = FramesPerSecond * SubstepsPerFrame
+)
+
+// RoundHalfUp rounds to the nearest integer, with halves rounded toward +Inf
+// (2.5 → 3, -2.5 → -2)
+func RoundHalfUp(f float64) int {
+	return int(math.Floor(f + 0.5))
+}
+
+// ToIUPerSubstep converts a speed in pixels/sec to IU/substep
+func ToIUPerSubstep(pixelsPerSec float64) int { return Sim.ToIUPerSubstep(pixelsPerSec) }
+
+// FromIUPerSubstep converts a speed in IU/substep back to pixels/sec
+func FromIUPerSubstep(iu int) float64 { return Sim.FromIUPerSubstep(iu) }
+
+// ToIUAccelPerFrame converts an acceleration in pixels/sec² to the IU/substep
+// velocity change applied once per frame
+func ToIUAccelPerFrame(pixelsPerSecSq float64) int { return Sim.ToIUAccelPerFrame(pixelsPerSecSq) }
+
+// FromIUAccelPerFrame converts a per-frame velocity change back to pixels/sec²
+func FromIUAccelPerFrame(iu int) float64 { return Sim.FromIUAccelPerFrame(iu) }
+
+// ToIUAccelPerSubstep converts an acceleration in pixels/sec² to the
+// IU/substep velocity change applied every substep
+func ToIUAccelPerSubstep(pixelsPerSecSq float64) int { return Sim.ToIUAccelPerSubstep(pixelsPerSecSq) }
+
+// FromIUAccelPerSubstep converts a per-substep velocity change back to
+// pixels/sec²
+func FromIUAccelPerSubstep(iu int) float64 { return Sim.FromIUAccelPerSubstep(iu) }
+
+// ToPct converts a fraction (1.0 = 100%) to an integer percentage
+func ToPct(f float64) int {
+	return RoundHalfUp(f * 100)
+}
+
+// FromPct converts an integer percentage back to a fraction
+func FromPct(pct int) float64 {
+	return float64(pct) / 100
+}
+
+// SecondsToFrames converts a duration in seconds to frames
+func SecondsToFrames(seconds float64) int { return Sim.SecondsToFrames(seconds) }
+
+// FramesToSeconds converts a frame count back to seconds
+func FramesToSeconds(frames int) float64 { return Sim.FramesToSeconds(frames) }
+
+// ToIU converts pixels to IU
+func ToIU(pixels int) int {
+	return pixels * Scale
+}
+
+// ToPixels converts IU to whole pixels (floor, also for negative values)
+func ToPixels(iu int) int {
+	return iu >> Shift
+}
+
+// MulDiv returns a*b/c with an int64 intermediate, so the product can't
+// overflow a 32-bit int. Truncates toward zero; c must not be 0.
+func MulDiv(a, b, c int) int {
+	return int(int64(a) * int64(b) / int64(c))
+}
+
+// MulPct scales v by a percentage (100 = unchanged), truncating toward zero
+func MulPct(v, pct int) int {
+	return MulDiv(v, pct, 100)
+}
+
+// Mul multiplies two fixed-point values (both scaled by Scale), truncating
+// toward zero
+func Mul(a, b int) int {
+	return MulDiv(a, b, Scale)
+}
@@ -0,0 +1,106 @@
+package fixedpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundHalfUp(t *testing.T) {
+	assert.Equal(t, 3, RoundHalfUp(2.5))
+	assert.Equal(t, 2, RoundHalfUp(2.49))
+	assert.Equal(t, -2, RoundHalfUp(-2.5))
+	assert.Equal(t, -3, RoundHalfUp(-2.51))
+	assert.Equal(t, 0, RoundHalfUp(0))
+}
+
+func TestToIUPerSubstep(t *testing.T) {
+	tests := []struct {
+		name         string
+		pixelsPerSec float64
+		expectedIU   int
+	}{
+		{"60 pixels/sec", 60, 26},    // 60 * 256 / 600 = 25.6 → 26
+		{"120 pixels/sec", 120, 51},  // 120 * 256 / 600 = 51.2 → 51
+		{"300 pixels/sec", 300, 128}, // 300 * 256 / 600 = 128
+		{"600 pixels/sec", 600, 256}, // 600 * 256 / 600 = 256 (1 pixel/substep)
+		{"-60 pixels/sec", -60, -26}, // rounds symmetrically away from .6
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedIU, ToIUPerSubstep(tt.pixelsPerSec))
+		})
+	}
+}
+
+func TestToIUAccelPerFrame(t *testing.T) {
+	tests := []struct {
+		name           string
+		pixelsPerSecSq float64
+		expectedIU     int
+	}{
+		{"800 pixels/sec² (gravity)", 800, 6},  // 800 * 256 / 36000 = 5.69 → 6
+		{"2000 pixels/sec² (accel)", 2000, 14}, // 2000 * 256 / 36000 = 14.2 → 14
+		{"400 pixels/sec² (gold)", 400, 3},     // 400 * 256 / 36000 = 2.84 → 3
+		{"3600 pixels/sec²", 3600, 26},         // 3600 * 256 / 36000 = 25.6 → 26
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedIU, ToIUAccelPerFrame(tt.pixelsPerSecSq))
+		})
+	}
+}
+
+func TestToIUAccelPerSubstep(t *testing.T) {
+	assert.Equal(t, 1, ToIUAccelPerSubstep(1406.25)) // exactly 1 IU/substep²
+	assert.Equal(t, 1, ToIUAccelPerSubstep(800))     // 0.57 → 1
+}
+
+func TestRoundTrip(t *testing.T) {
+	assert.Equal(t, 600.0, FromIUPerSubstep(256), "1 pixel/substep = 600 pixels/sec")
+	assert.Equal(t, 300.0, FromIUPerSubstep(ToIUPerSubstep(300)), "exact values round-trip")
+
+	// Rounded values land within half an IU of the config value
+	halfIUSpeed := FromIUPerSubstep(1) / 2
+	for _, v := range []float64{60, 120, 150, 280, 400} {
+		assert.InDelta(t, v, FromIUPerSubstep(ToIUPerSubstep(v)), halfIUSpeed)
+	}
+	halfIUAccel := FromIUAccelPerFrame(1) / 2
+	for _, v := range []float64{400, 800, 2000, 2500} {
+		assert.InDelta(t, v, FromIUAccelPerFrame(ToIUAccelPerFrame(v)), halfIUAccel)
+	}
+	assert.Equal(t, 1406.25, FromIUAccelPerSubstep(1))
+}
+
+func TestPercentAndFrames(t *testing.T) {
+	assert.Equal(t, 160, ToPct(1.6))
+	assert.Equal(t, 29, ToPct(0.29), "0.29*100 is 28.999... in float64")
+	assert.Equal(t, 0.5, FromPct(50))
+
+	assert.Equal(t, 6, SecondsToFrames(0.1))
+	assert.Equal(t, 18, SecondsToFrames(0.3))
+	assert.Equal(t, 9, SecondsToFrames(0.15))
+	assert.Equal(t, 0.5, FramesToSeconds(30))
+}
+
+func TestPixels(t *testing.T) {
+	assert.Equal(t, 512, ToIU(2))
+	assert.Equal(t, 2, ToPixels(767))
+	assert.Equal(t, -1, ToPixels(-1), "floors negative positions")
+}
+
+func TestMulDiv(t *testing.T) {
+	assert.Equal(t, 12, MulDiv(10, 6, 5))
+	assert.Equal(t, -1, MulDiv(-7, 1, 4), "truncates toward zero")
+
+	// a*b overflows int32 but the result fits
+	big := math.MaxInt32 / 2
+	assert.Equal(t, big, MulDiv(big, 1000, 1000))
+
+	assert.Equal(t, 80, MulPct(100, 80))
+	assert.Equal(t, -33, MulPct(-67, 50))
+	assert.Equal(t, 384, Mul(256, 384), "1.0 * 1.5 = 1.5")
+}
@@ -0,0 +1,72 @@
+package fixedpoint
+
+// Rate is a simulation rate in frames per second. Every frame still runs
+// SubstepsPerFrame substeps, so speeds and accelerations in IU depend on it.
+type Rate int
+
+// Sim is the simulation rate the package-level conversions use. Set it once
+// at startup with SetSimulationRate, before any config is converted.
+var Sim Rate = FramesPerSecond
+
+// SetSimulationRate sets Sim to fps frames per second. Non-positive values
+// are ignored.
+func SetSimulationRate(fps int) {
+	if fps > 0 {
+		Sim = Rate(fps)
+	}
+}
+
+// SubstepsPerSecond returns the number of substeps simulated per second
+func (r Rate) SubstepsPerSecond() float64 {
+	return float64(r) * SubstepsPerFrame
+}
+
+// ToIUPerSubstep converts a speed in pixels/sec to IU/substep
+func (r Rate) ToIUPerSubstep(pixelsPerSec float64) int {
+	return RoundHalfUp(pixelsPerSec * Scale / r.SubstepsPerSecond())
+}
+
+// FromIUPerSubstep converts a speed in IU/substep back to pixels/sec
+func (r Rate) FromIUPerSubstep(iu int) float64 {
+	return float64(iu) * r.SubstepsPerSecond() / Scale
+}
+
+// ToIUAccelPerFrame converts an acceleration in pixels/sec² to the IU/substep
+// velocity change applied once per frame
+func (r Rate) ToIUAccelPerFrame(pixelsPerSecSq float64) int {
+	return RoundHalfUp(pixelsPerSecSq * Scale / (r.SubstepsPerSecond() * float64(r)))
+}
+
+// FromIUAccelPerFrame converts a per-frame velocity change back to pixels/sec²
+func (r Rate) FromIUAccelPerFrame(iu int) float64 {
+	return float64(iu) * r.SubstepsPerSecond() * float64(r) / Scale
+}
+
+// ToIUAccelPerSubstep converts an acceleration in pixels/sec² to the
+// IU/substep velocity change applied every substep
+func (r Rate) ToIUAccelPerSubstep(pixelsPerSecSq float64) int {
+	sps := r.SubstepsPerSecond()
+	return RoundHalfUp(pixelsPerSecSq * Scale / (sps * sps))
+}
+
+// FromIUAccelPerSubstep converts a per-substep velocity change back to
+// pixels/sec²
+func (r Rate) FromIUAccelPerSubstep(iu int) float64 {
+	sps := r.SubstepsPerSecond()
+	return float64(iu) * sps * sps / Scale
+}
+
+// SecondsToFrames converts a duration in seconds to frames
+func (r Rate) SecondsToFrames(seconds float64) int {
+	return RoundHalfUp(seconds * float64(r))
+}
+
+// FramesToSeconds converts a frame count back to seconds
+func (r Rate) FramesToSeconds(frames int) float64 {
+	return float64(frames) / float64(r)
+}
+
+// PerFrame converts a per-second amount to the amount per frame
+func (r Rate) PerFrame(perSecond float64) float64 {
+	return perSecond / float64(r)
+}
@@ -0,0 +1,32 @@
+package fixedpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRate_ScalesWithFramesPerSecond(t *testing.T) {
+	fast := Rate(120)
+
+	assert.Equal(t, Sim.ToIUPerSubstep(300)/2, fast.ToIUPerSubstep(300), "twice the substeps, half the distance each")
+	assert.Equal(t, 300.0, fast.FromIUPerSubstep(fast.ToIUPerSubstep(300)))
+	assert.Equal(t, 12, fast.SecondsToFrames(0.1))
+	assert.Equal(t, 0.25, fast.FramesToSeconds(30))
+	assert.Equal(t, 2.0, fast.PerFrame(240))
+
+	// Accelerations scale with the square of the rate
+	assert.Equal(t, 4*fast.FromIUAccelPerFrame(1), Rate(240).FromIUAccelPerFrame(1))
+	assert.Equal(t, 4*fast.FromIUAccelPerSubstep(1), Rate(240).FromIUAccelPerSubstep(1))
+}
+
+func TestSetSimulationRate(t *testing.T) {
+	t.Cleanup(func() { Sim = FramesPerSecond })
+
+	SetSimulationRate(30)
+	assert.Equal(t, Rate(30), Sim)
+	assert.Equal(t, 3, SecondsToFrames(0.1), "package conversions follow Sim")
+
+	SetSimulationRate(0)
+	assert.Equal(t, Rate(30), Sim, "non-positive rates are ignored")
+}
@@ -0,0 +1,179 @@
+package ecs
+
+import (
+	"slices"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// HazardConfig holds damage tile tuning.
+// Values are pre-converted to frames and IU (like PhysicsConfig).
+type HazardConfig struct {
+	Bounce         int // upward knockback off a hazard (IU/substep)
+	IframeFrames   int // player invincibility after a hazard hit
+	EnemyIframes   int // enemy invincibility between hazard hits
+	KnockbackForce int // knockback of elite explosions set off by hazard kills (IU/substep)
+	KnockbackUp    int
+	BurnFrames     int // how long lava keeps burning after contact
+	BurnTickFrames int // frames between burn damage ticks (0 = every frame)
+}
+
+// UpdateTileHazards damages entities touching damage tiles (spikes, lava;
+// call once per frame, after movement). The player is hurt through the
+// feet, enemies through the whole hitbox. Both are bounced up (harder off
+// surfaces with a knockback boost) and given iframes; burning surfaces also
+// set them on fire. Enemies killed this way drop loot like any other kill.
+func UpdateTileHazards(w *World, stage Stage, cfg HazardConfig) DamageResult {
+	result := DamageResult{}
+	killed := updateBurns(w, w.killBuf[:0], cfg)
+
+	if playerID := w.PlayerID; playerID != 0 && !w.IsInvincible(playerID) {
+		pos := w.Position[playerID]
+		hitbox := w.HitboxTrapezoid[playerID]
+		fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), w.Facing[playerID].Right, hitbox.MirrorWidth())
+		if damage := tileDamage(stage, fx, fy, fw, fh, false); damage > 0 {
+			health := w.Health[playerID]
+			health.Current -= damage
+			w.Health[playerID] = health
+			GrantIframes(w, playerID, cfg.IframeFrames)
+			hazard := tileHazard(stage, fx, fy, fw, fh, false)
+			if hazard.Burn > 0 {
+				Ignite(w, playerID, hazard.Burn, cfg.BurnFrames)
+			}
+
+			result.PlayerDamaged = true
+			result.PlayerKnockback.VY = -hazardBounce(cfg, hazard)
+			w.Feedback.Trigger(FeedbackPlayerHurt)
+			w.Events.Emit(Event{Type: EventPlayerDamaged, Amount: damage})
+		}
+	}
+
+	for _, id := range w.Query(TagEnemy) {
+		if w.IsInvincible(id) || w.AI[id].SpawnTimer > 0 || slices.Contains(killed, id) {
+			continue
+		}
+		pos := w.Position[id]
+		hb := w.Hitbox[id]
+		ex, ey := pos.PixelX()+hb.OffsetX, pos.PixelY()+hb.OffsetY
+		damage := tileDamage(stage, ex, ey, hb.Width, hb.Height, true)
+		if damage <= 0 {
+			continue
+		}
+		hazard := tileHazard(stage, ex, ey, hb.Width, hb.Height, true)
+		if hazard.Burn > 0 {
+			Ignite(w, id, hazard.Burn, cfg.BurnFrames)
+		}
+
+		health := w.Health[id]
+		damage = eliteDamage(w, id, damage)
+		health.Current -= damage
+		w.Events.Emit(Event{Type: EventEnemyHit, Entity: id, Amount: damage})
+		GrantIframes(w, id, cfg.EnemyIframes)
+		Knockback(w, id, 0, -hazardBounce(cfg, hazard), 0)
+		if health.Current <= 0 {
+			killed = append(killed, id)
+		} else {
+			w.Health[id] = health
+		}
+	}
+	killEnemies(w, killed, &result, cfg.KnockbackForce, cfg.KnockbackUp, cfg.IframeFrames)
+	w.killBuf = killed[:0]
+
+	if result.PlayerDamaged {
+		Knockback(w, w.PlayerID, result.PlayerKnockback.VX, result.PlayerKnockback.VY, 0)
+	}
+	return result
+}
+
+// tileDamage returns the highest tile damage under a pixel rect (0 = safe)
+// to an enemy or the player, skipping tiles that spare them
+func tileDamage(stage Stage, x, y, w, h int, enemy bool) int {
+	tileSize := stage.GetTileSize()
+	startTX := x / tileSize
+	endTX := (x + w - 1) / tileSize
+	startTY := y / tileSize
+	endTY := (y + h - 1) / tileSize
+
+	damage := 0
+	for ty := startTY; ty <= endTY; ty++ {
+		for tx := startTX; tx <= endTX; tx++ {
+			px, py := tx*tileSize, ty*tileSize
+			if stage.GetSurface(px, py).spares(enemy) {
+				continue
+			}
+			damage = max(damage, stage.GetTileDamage(px, py))
+		}
+	}
+	return damage
+}
+
+// tileHazard returns the strongest knockback boost and burn of the surfaces
+// under a pixel rect that don't spare an enemy (or the player)
+func tileHazard(stage Stage, x, y, w, h int, enemy bool) Surface {
+	tileSize := stage.GetTileSize()
+	var hazard Surface
+	for ty := y / tileSize; ty <= (y+h-1)/tileSize; ty++ {
+		for tx := x / tileSize; tx <= (x+w-1)/tileSize; tx++ {
+			s := stage.GetSurface(tx*tileSize, ty*tileSize)
+			if s.spares(enemy) {
+				continue
+			}
+			hazard.KnockbackPct = max(hazard.KnockbackPct, s.KnockbackPct)
+			hazard.Burn = max(hazard.Burn, s.Burn)
+		}
+	}
+	return hazard
+}
+
+// hazardBounce returns the upward knockback off a hazard surface
+func hazardBounce(cfg HazardConfig, hazard Surface) int {
+	if hazard.KnockbackPct > 0 {
+		return fixedpoint.MulPct(cfg.Bounce, hazard.KnockbackPct)
+	}
+	return cfg.Bounce
+}
+
+// updateBurns advances burn timers and deals burn damage on each tick.
+// Burns ignore iframes. Enemies burned to death are appended to killed,
+// which is returned.
+func updateBurns(w *World, killed []EntityID, cfg HazardConfig) []EntityID {
+	tick := max(1, cfg.BurnTickFrames)
+	for _, id := range w.Query(CompCrowdControl) {
+		cc := w.CrowdControl[id]
+		if !cc.IsBurning() {
+			continue
+		}
+		cc.BurnTimer--
+		damage := cc.BurnDamage
+		if !cc.IsBurning() {
+			cc.BurnDamage = 0
+		}
+		w.CrowdControl[id] = cc
+		if cc.BurnTimer%tick != 0 {
+			continue
+		}
+
+		health := w.Health[id]
+		if health.Current <= 0 {
+			continue
+		}
+		if id == w.PlayerID {
+			health.Current -= damage
+			w.Health[id] = health
+			w.Events.Emit(Event{Type: EventPlayerDamaged, Amount: damage})
+			continue
+		}
+		if _, ok := w.IsEnemy[id]; !ok {
+			continue
+		}
+		damage = eliteDamage(w, id, damage)
+		health.Current -= damage
+		w.Events.Emit(Event{Type: EventEnemyHit, Entity: id, Amount: damage})
+		if health.Current <= 0 {
+			killed = append(killed, id)
+		} else {
+			w.Health[id] = health
+		}
+	}
+	return killed
+}
@@ -0,0 +1,109 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hazardStage is a mockStage with damage tiles and surfaces
+type hazardStage struct {
+	*mockStage
+	damage  map[[2]int]int
+	surface map[[2]int]Surface
+}
+
+func newHazardStage() *hazardStage {
+	return &hazardStage{mockStage: newMockStage(40, 40, 16), damage: make(map[[2]int]int), surface: make(map[[2]int]Surface)}
+}
+
+func (s *hazardStage) GetTileDamage(px, py int) int {
+	return s.damage[[2]int{px / s.tileSize, py / s.tileSize}]
+}
+
+func (s *hazardStage) GetSurface(px, py int) Surface {
+	return s.surface[[2]int{px / s.tileSize, py / s.tileSize}]
+}
+
+var testHazard = HazardConfig{Bounce: 120, IframeFrames: 60, EnemyIframes: 30, KnockbackForce: 100, KnockbackUp: 50}
+
+func TestUpdateTileHazards_HurtsPlayerThroughFeet(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{6, 7}] = 25               // pixels 96..111, 112..127
+	w, player, enemy := newSolidTestWorld(96, 96) // feet at y 114..119
+	w.DestroyEntity(enemy)
+
+	result := UpdateTileHazards(w, stage, testHazard)
+
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 75, w.Health[player].Current)
+	assert.True(t, w.IsInvincible(player))
+	assert.Equal(t, -120, w.CrowdControl[player].KnockbackVelY)
+	assert.Equal(t, []Event{{Type: EventPlayerDamaged, Amount: 25}}, w.Events.Drain())
+
+	UpdateTileHazards(w, stage, testHazard)
+	assert.Equal(t, 75, w.Health[player].Current, "iframes")
+}
+
+func TestUpdateTileHazards_HurtsAndKillsEnemies(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{2, 2}] = 30
+	w := NewWorld()
+	tough := w.CreateEnemy(32, 32, EnemyConfig{MaxHealth: 100, HitboxWidth: 12, HitboxHeight: 12}, true)
+	weak := w.CreateEnemy(40, 36, EnemyConfig{MaxHealth: 20, HitboxWidth: 12, HitboxHeight: 12}, true)
+	safe := w.CreateEnemy(200, 200, EnemyConfig{MaxHealth: 20, HitboxWidth: 12, HitboxHeight: 12}, true)
+
+	UpdateTileHazards(w, stage, testHazard)
+
+	assert.Equal(t, 70, w.Health[tough].Current)
+	assert.True(t, w.IsInvincible(tough))
+	assert.Equal(t, -120, w.CrowdControl[tough].KnockbackVelY)
+	assert.NotContains(t, w.IsEnemy, weak)
+	assert.Equal(t, 20, w.Health[safe].Current)
+
+	var killed []EntityID
+	for _, e := range w.Events.Drain() {
+		if e.Type == EventEnemyKilled {
+			killed = append(killed, e.Entity)
+		}
+	}
+	require.Equal(t, []EntityID{weak}, killed)
+}
+
+func TestTileDamage_HighestUnderRect(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{1, 1}] = 10
+	stage.damage[[2]int{2, 1}] = 40
+
+	assert.Equal(t, 40, tileDamage(stage, 20, 20, 20, 4, false))
+	assert.Equal(t, 10, tileDamage(stage, 16, 16, 16, 16, false))
+	assert.Equal(t, 0, tileDamage(stage, 0, 0, 16, 16, false))
+}
+
+func TestTileDamage_SparedSide(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{1, 1}] = 10
+	stage.surface[[2]int{1, 1}] = Surface{SparesPlayer: true, Burn: 5}
+	stage.damage[[2]int{2, 1}] = 40
+	stage.surface[[2]int{2, 1}] = Surface{SparesEnemies: true}
+
+	assert.Equal(t, 40, tileDamage(stage, 20, 20, 20, 4, false), "the player only takes the enemy-safe tile")
+	assert.Equal(t, 10, tileDamage(stage, 20, 20, 20, 4, true), "enemies only take the trap")
+	assert.Equal(t, 0, tileHazard(stage, 20, 20, 20, 4, false).Burn)
+	assert.Equal(t, 5, tileHazard(stage, 20, 20, 20, 4, true).Burn)
+}
+
+func TestUpdateTileHazards_TrapSparesPlayer(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{2, 2}] = 30
+	stage.surface[[2]int{2, 2}] = Surface{SparesPlayer: true}
+	w := NewWorld()
+	w.CreatePlayer(32, 20, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(32, 32, EnemyConfig{MaxHealth: 100, HitboxWidth: 12, HitboxHeight: 12}, true)
+
+	result := UpdateTileHazards(w, stage, testHazard)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 70, w.Health[enemy].Current)
+}
@@ -0,0 +1,84 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSolidRect_UsesStageTileSize(t *testing.T) {
+	small := newMockStage(40, 40, 8)
+	small.setSolid(1, 1) // pixels 8..15
+	assert.True(t, isSolidRect(small, 8, 8, 8, 8))
+	assert.False(t, isSolidRect(small, 0, 0, 8, 8))
+
+	large := newMockStage(10, 10, 32)
+	large.setSolid(1, 1) // pixels 32..63
+	assert.True(t, isSolidRect(large, 60, 60, 2, 2))
+	assert.False(t, isSolidRect(large, 0, 0, 32, 32))
+}
+
+func TestHitboxTrapezoid_MirrorWidth(t *testing.T) {
+	assert.Equal(t, DefaultSpriteWidth, HitboxTrapezoid{}.MirrorWidth())
+
+	w := NewWorld()
+	body := Hitbox{OffsetX: 4, Width: 8, Height: 8}
+	id := w.CreatePlayer(100, 100, PlayerProfile{
+		Hitbox:    HitboxTrapezoid{Head: body, Body: body, Feet: body, SpriteWidth: 32},
+		MaxHealth: 100,
+	})
+	w.Facing[id] = Facing{Right: false}
+
+	x, y := HitboxCenter(w, id)
+	assert.Equal(t, 124, x, "body mirrors across the 32px sprite")
+	assert.Equal(t, 104, y)
+}
+
+func TestMoveEnemyKnockbackX_TallHitboxHitsWall(t *testing.T) {
+	stage := newMockStage(40, 40, 8)
+	stage.setSolid(4, 8) // pixels 32..39, y 64..71: below the old hardcoded hitbox
+
+	hb := Hitbox{Width: 8, Height: 40}
+	pos := Position{X: 16 * PositionScale, Y: 40 * PositionScale}
+	vel := Velocity{X: 30 * PositionScale}
+	moveEnemyKnockbackX(stage, hb, &pos, &vel, vel.X)
+
+	assert.Equal(t, 24, pos.PixelX(), "right edge stops against the wall")
+	assert.Equal(t, 0, vel.X)
+}
+
+func TestApplyEnemyGravity_WideHitboxFindsGround(t *testing.T) {
+	stage := newMockStage(40, 40, 8)
+	stage.setSolid(1, 10) // pixels 8..15, y 80..87: between the old sample points
+
+	w := NewWorld()
+	id := w.CreateEnemy(0, 64, EnemyConfig{MaxHealth: 10, HitboxWidth: 32, HitboxHeight: 16}, true)
+	mov := w.Movement[id]
+	mov.OnGround = true
+	w.Movement[id] = mov
+
+	ApplyEnemyGravity(w, stage, 5, 100)
+
+	assert.True(t, w.Movement[id].OnGround)
+	assert.Equal(t, 0, w.Velocity[id].Y)
+}
+
+func TestEnemyShoot_FiresFromHitboxCenter(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(400, 100, PlayerProfile{MaxHealth: 100})
+	hb := Hitbox{OffsetX: 4, OffsetY: 6, Width: 40, Height: 42}
+	pos := Position{X: 100 * PositionScale, Y: 100 * PositionScale}
+	ai := AI{AttackRange: 300}
+	facing := Facing{Right: true}
+
+	enemyShoot(w, newMockStage(640, 480, 16), 0, &pos, hb, &ai, &facing, 100, ProjectileConfig{MaxRange: 300}, FactionEnemy)
+	assert.Empty(t, w.IsProjectile, "spawned at the next sync point")
+	w.Flush()
+
+	require.Len(t, w.IsProjectile, 1)
+	for id := range w.IsProjectile {
+		assert.Equal(t, 124, w.Position[id].PixelX())
+		assert.Equal(t, 127, w.Position[id].PixelY())
+	}
+}
@@ -0,0 +1,214 @@
+package ecs
+
+import "slices"
+
+// BeamFrames is how long a hitscan beam stays visible
+const BeamFrames = 8
+
+// beamScale is the fixed-point scale of beamEntry's distance along a beam
+const beamScale = 1 << 12
+
+// Beam is an instant hitscan shot: it damages the first hostile target on
+// the line from its start to its end (every one with Piercing) when damage
+// is next resolved, then stays for BeamFrames so it can be drawn
+type Beam struct {
+	X0, Y0, X1, Y1 int // pixels, the end clipped at the first wall
+	Damage         int
+	Piercing       bool
+	Faction        Faction
+	Source         EntityID  // the shooter (0 = unknown)
+	Arrow          ArrowType // player arrow type (rendering)
+	Life           int       // frames left on screen
+	Resolved       bool      // its damage has been dealt
+}
+
+// FireBeam fires a hitscan beam from (x, y) in the direction (dirX, dirY),
+// both in pixels, out to cfg.MaxRange or the first solid tile (unless
+// projectiles pass through terrain)
+func FireBeam(w *World, stage Stage, source EntityID, x, y, dirX, dirY int, cfg ProjectileConfig, faction Faction) {
+	x1, y1, ok := beamEnd(w, stage, x, y, dirX, dirY, cfg.MaxRange)
+	if !ok {
+		return
+	}
+	w.Beams = append(w.Beams, Beam{
+		X0: x, Y0: y, X1: x1, Y1: y1,
+		Damage:   cfg.Damage,
+		Piercing: cfg.Piercing,
+		Faction:  faction,
+		Source:   source,
+		Arrow:    cfg.Arrow,
+		Life:     BeamFrames,
+	})
+}
+
+// beamEnd returns where a beam from (x, y) in the direction (dirX, dirY)
+// stops: after rangePx pixels or at the first solid tile (ok is false
+// without a direction)
+func beamEnd(w *World, stage Stage, x, y, dirX, dirY, rangePx int) (x1, y1 int, ok bool) {
+	length := isqrt(dirX*dirX + dirY*dirY)
+	if length == 0 {
+		return 0, 0, false
+	}
+	x1 = x + dirX*rangePx/length
+	y1 = y + dirY*rangePx/length
+	if w.Collision.Collides(LayerProjectile, LayerTerrain) {
+		if hit, ok := stage.Raycast(x, y, x1, y1); ok {
+			x1, y1 = hit.X, hit.Y
+		}
+	}
+	return x1, y1, true
+}
+
+// UpdateBeams ages the beams and drops the faded ones (once per frame)
+func UpdateBeams(w *World) {
+	for i := range w.Beams {
+		w.Beams[i].Life--
+	}
+	w.Beams = slices.DeleteFunc(w.Beams, func(b Beam) bool { return b.Life <= 0 })
+}
+
+// beamTarget is an entity a beam crosses, at distance t along it
+type beamTarget struct {
+	id EntityID
+	t  int
+}
+
+// resolveBeams deals the damage of the beams fired since the last call and
+// returns killed with the enemies they killed added
+func resolveBeams(w *World, killed []EntityID, result *DamageResult, knockbackForce, knockbackUp, iframeFrames int) []EntityID {
+	var targets []beamTarget
+	for i := range w.Beams {
+		beam := &w.Beams[i]
+		if beam.Resolved {
+			continue
+		}
+		beam.Resolved = true
+
+		targets = beamTargets(w, beam, killed, targets[:0])
+		if !beam.Piercing && len(targets) > 1 {
+			targets = targets[:1]
+		}
+		kbX, kbY := calcKnockbackFromVelocity(beam.X1-beam.X0, beam.Y1-beam.Y0, knockbackForce)
+		for _, target := range targets {
+			if target.id == w.PlayerID {
+				if !beamHitsPlayer(w, beam, result, knockbackForce, knockbackUp, iframeFrames) {
+					break // blocked
+				}
+				continue
+			}
+
+			health := w.Health[target.id]
+			damage := eliteDamage(w, target.id, beam.Damage)
+			health.Current -= damage
+			w.Events.Emit(Event{Type: EventEnemyHit, Entity: target.id, Amount: damage})
+			Knockback(w, target.id, kbX, kbY, 0)
+			Stun(w, target.id, 0)
+
+			if health.Current <= 0 {
+				w.Feedback.TriggerDir(FeedbackHeavyHit, kbX, kbY)
+				killed = append(killed, target.id)
+			} else {
+				w.Feedback.TriggerDir(FeedbackLightHit, kbX, kbY)
+				w.Health[target.id] = health
+			}
+		}
+	}
+	return killed
+}
+
+// beamTargets appends the hostile entities a beam crosses to buf, nearest
+// first (ties to the lower ID), skipping the already killed
+func beamTargets(w *World, beam *Beam, killed []EntityID, buf []beamTarget) []beamTarget {
+	if w.Collision.Collides(LayerProjectile, LayerEnemy) {
+		for _, id := range w.Query(TagEnemy) {
+			if w.IsInvincible(id) || !w.Factions.Hostile(beam.Faction, w.Faction[id]) || slices.Contains(killed, id) {
+				continue
+			}
+			pos, hb := w.Position[id], w.Hitbox[id]
+			rx, ry := pos.PixelX()+hb.OffsetX, pos.PixelY()+hb.OffsetY
+			if t, ok := beamEntry(beam, rx, ry, hb.Width, hb.Height); ok {
+				buf = append(buf, beamTarget{id: id, t: t})
+			}
+		}
+	}
+
+	playerID := w.PlayerID
+	if playerID != 0 && !w.IsInvincible(playerID) && w.Collision.Collides(LayerProjectile, LayerPlayer) &&
+		w.Factions.Hostile(beam.Faction, w.Faction[playerID]) {
+		pos, hb := w.Position[playerID], w.HitboxTrapezoid[playerID]
+		rx, ry, rw, rh := hb.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), w.Facing[playerID].Right, hb.MirrorWidth())
+		if t, ok := beamEntry(beam, rx, ry, rw, rh); ok {
+			buf = append(buf, beamTarget{id: playerID, t: t})
+		}
+	}
+
+	slices.SortFunc(buf, func(a, b beamTarget) int {
+		if a.t != b.t {
+			return a.t - b.t
+		}
+		return int(a.id) - int(b.id)
+	})
+	return buf
+}
+
+// beamHitsPlayer hurts the player with a beam, through the shield. It
+// returns false if the shield stopped the beam.
+func beamHitsPlayer(w *World, beam *Beam, result *DamageResult, knockbackForce, knockbackUp, iframeFrames int) bool {
+	playerID := w.PlayerID
+	player := w.PlayerData[playerID]
+	damage := beam.Damage
+	if blocksFrom(w, playerID, &player, beam.X0) {
+		if player.BlockFrames <= w.Block.ParryFrames {
+			damage = 0 // a parried beam has nothing to reflect
+		} else {
+			damage = w.Block.absorbHit(&player, damage)
+			w.PlayerData[playerID] = player
+		}
+		w.Feedback.Trigger(FeedbackBlock)
+		w.Events.Emit(Event{Type: EventAttackBlocked, Entity: beam.Source, Amount: beam.Damage - damage})
+		if damage <= 0 {
+			return false
+		}
+	}
+
+	health := w.Health[playerID]
+	health.Current -= damage
+	w.Health[playerID] = health
+	w.PlayerData[playerID] = player
+	GrantIframes(w, playerID, iframeFrames)
+
+	result.PlayerDamaged = true
+	w.Feedback.Trigger(FeedbackPlayerHurt)
+	w.Events.Emit(Event{Type: EventPlayerDamaged, Entity: beam.Source, Amount: damage})
+
+	dir := 1
+	if beam.X0 > w.Position[playerID].PixelX() {
+		dir = -1
+	}
+	result.PlayerKnockback.VX = dir * knockbackForce
+	result.PlayerKnockback.VY = -knockbackUp
+	return true
+}
+
+// beamEntry returns where a beam enters a rectangle (pixels), as a
+// distance along the beam from 0 to beamScale, by clipping the beam
+// against the rectangle's slabs
+func beamEntry(beam *Beam, rx, ry, rw, rh int) (int, bool) {
+	tMin, tMax := 0, beamScale
+	clip := func(p0, d, lo, size int) bool {
+		if d == 0 {
+			return p0 >= lo && p0 < lo+size
+		}
+		t0 := (lo - p0) * beamScale / d
+		t1 := (lo + size - p0) * beamScale / d
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		tMin, tMax = max(tMin, t0), min(tMax, t1)
+		return tMin <= tMax
+	}
+	if !clip(beam.X0, beam.X1-beam.X0, rx, rw) || !clip(beam.Y0, beam.Y1-beam.Y0, ry, rh) {
+		return 0, false
+	}
+	return tMin, true
+}
@@ -0,0 +1,122 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBeamEnemy = EnemyConfig{MaxHealth: 50, HitboxWidth: 16, HitboxHeight: 24, Flying: true}
+
+func TestFireBeam_HitsFirstEnemyOnly(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(640, 480, 16)
+	far := w.CreateEnemy(150, 100, testBeamEnemy, true)
+	near := w.CreateEnemy(100, 100, testBeamEnemy, true)
+
+	FireBeam(w, stage, 0, 50, 110, 1, 0, ProjectileConfig{Damage: 20, MaxRange: 300}, FactionPlayer)
+	require.Len(t, w.Beams, 1)
+	assert.Equal(t, 350, w.Beams[0].X1)
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 30, w.Health[near].Current)
+	assert.Equal(t, 50, w.Health[far].Current)
+	assert.Greater(t, w.CrowdControl[near].KnockbackVelX, 0, "pushed along the beam")
+
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 30, w.Health[near].Current, "a beam hits once")
+}
+
+func TestFireBeam_PiercingHitsEveryEnemy(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(640, 480, 16)
+	far := w.CreateEnemy(150, 100, testBeamEnemy, true)
+	near := w.CreateEnemy(100, 100, testBeamEnemy, true)
+	missed := w.CreateEnemy(100, 200, testBeamEnemy, true)
+
+	FireBeam(w, stage, 0, 50, 110, 1, 0, ProjectileConfig{Damage: 60, MaxRange: 300, Piercing: true}, FactionPlayer)
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, w.Exists(near))
+	assert.False(t, w.Exists(far))
+	assert.Equal(t, 50, w.Health[missed].Current)
+}
+
+func TestFireBeam_StopsAtWall(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(640, 480, 16)
+	stage.setSolid(7, 6)
+	behind := w.CreateEnemy(150, 100, testBeamEnemy, true)
+
+	FireBeam(w, stage, 0, 50, 105, 1, 0, ProjectileConfig{Damage: 20, MaxRange: 300}, FactionPlayer)
+	require.Len(t, w.Beams, 1)
+	assert.Equal(t, 7*16, w.Beams[0].X1)
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 50, w.Health[behind].Current)
+}
+
+func TestFireBeam_IgnoresFriendlies(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(640, 480, 16)
+	player := w.CreatePlayer(100, 96, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(150, 100, testBeamEnemy, true)
+
+	FireBeam(w, stage, player, 50, 110, 1, 0, ProjectileConfig{Damage: 20, MaxRange: 300}, FactionPlayer)
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 30, w.Health[enemy].Current, "the beam passes the player")
+}
+
+func TestUpdateBeams_FadeOut(t *testing.T) {
+	w := NewWorld()
+	FireBeam(w, newMockStage(640, 480, 16), 0, 50, 110, 1, 0, ProjectileConfig{MaxRange: 300}, FactionPlayer)
+
+	for range BeamFrames - 1 {
+		UpdateBeams(w)
+	}
+	require.Len(t, w.Beams, 1)
+	assert.Equal(t, 1, w.Beams[0].Life)
+	UpdateBeams(w)
+	assert.Empty(t, w.Beams)
+}
+
+func TestEnemyShoot_HitscanHurtsPlayer(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(640, 480, 16)
+	player := w.CreatePlayer(200, 96, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	cfg := testBeamEnemy
+	cfg.AttackRange = 300
+	cfg.Hitscan = true
+	enemy := w.CreateEnemy(100, 100, cfg, true)
+
+	pos, ai, facing := w.Position[enemy], w.AI[enemy], w.Facing[enemy]
+	enemyShoot(w, stage, enemy, &pos, w.Hitbox[enemy], &ai, &facing, 100, ProjectileConfig{Damage: 15, MaxRange: 300}, FactionEnemy)
+	w.Flush()
+	assert.Empty(t, w.IsProjectile, "no projectile for a beam")
+	require.Len(t, w.Beams, 1)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 85, w.Health[player].Current)
+	assert.Equal(t, 100, result.PlayerKnockback.VX, "pushed away from the shooter")
+	assert.True(t, w.IsInvincible(player))
+}
+
+func TestBeamEntry(t *testing.T) {
+	beam := &Beam{X0: 0, Y0: 0, X1: 100, Y1: 0}
+
+	tEnter, ok := beamEntry(beam, 50, -5, 10, 10)
+	assert.True(t, ok)
+	assert.Equal(t, beamScale/2, tEnter)
+
+	_, ok = beamEntry(beam, 50, 5, 10, 10)
+	assert.False(t, ok, "beside the beam")
+	_, ok = beamEntry(beam, 120, -5, 10, 10)
+	assert.False(t, ok, "past its end")
+	_, ok = beamEntry(beam, -20, -5, 10, 10)
+	assert.False(t, ok, "behind its start")
+}
@@ -0,0 +1,111 @@
+package ecs
+
+// homingActive reports whether a projectile is still steering toward targets
+func homingActive(proj *Projectile) bool {
+	return proj.Homing.Enabled() && proj.Age < proj.Homing.Lifetime
+}
+
+// steerHoming turns a projectile's velocity toward its target for one substep.
+// Each velocity component moves at most TurnRate toward the desired
+// direction, then the vector is rescaled so the projectile keeps its speed.
+func steerHoming(w *World, pos Position, vel *Velocity, proj *Projectile, faction Faction) {
+	px, py := pos.PixelX(), pos.PixelY()
+	if !w.Exists(proj.Target) {
+		proj.Target = acquireHomingTarget(w, px, py, proj, faction)
+		if proj.Target == 0 {
+			return
+		}
+	}
+
+	tx, ty := HitboxCenter(w, proj.Target)
+	dx, dy := tx-px, ty-py
+	dist := isqrt(dx*dx + dy*dy)
+	speed := isqrt(vel.X*vel.X + vel.Y*vel.Y)
+	if dist == 0 || speed == 0 {
+		return
+	}
+
+	turn := proj.Homing.TurnRate
+	vel.X += clampInt(divRound(dx*speed, dist)-vel.X, -turn, turn)
+	vel.Y += clampInt(divRound(dy*speed, dist)-vel.Y, -turn, turn)
+
+	if mag := isqrt(vel.X*vel.X + vel.Y*vel.Y); mag > 0 && mag != speed {
+		vel.X = divRound(vel.X*speed, mag)
+		vel.Y = divRound(vel.Y*speed, mag)
+	}
+}
+
+// acquireHomingTarget picks the closest target within range that the
+// shooter's faction is hostile to (the player or an enemy). Returns 0 when
+// nothing is in range.
+func acquireHomingTarget(w *World, px, py int, proj *Projectile, faction Faction) EntityID {
+	rangeSq := proj.Homing.Range * proj.Homing.Range
+
+	var best EntityID
+	bestSq := rangeSq + 1
+	consider := func(id EntityID) {
+		if !w.Factions.Hostile(faction, w.Faction[id]) {
+			return
+		}
+		tx, ty := HitboxCenter(w, id)
+		d2 := (tx-px)*(tx-px) + (ty-py)*(ty-py)
+		// Lower ID wins ties so map iteration order doesn't matter
+		if d2 < bestSq || (d2 == bestSq && id < best) {
+			best = id
+			bestSq = d2
+		}
+	}
+	if w.PlayerID != 0 && w.Exists(w.PlayerID) {
+		consider(w.PlayerID)
+	}
+	for _, id := range w.Query(TagEnemy) {
+		consider(id)
+	}
+	return best
+}
+
+// HitboxCenter returns the pixel center of an entity's body hitbox
+func HitboxCenter(w *World, id EntityID) (int, int) {
+	pos := w.Position[id]
+	px, py := pos.PixelX(), pos.PixelY()
+
+	if trap, ok := w.HitboxTrapezoid[id]; ok {
+		x, y, width, height := trap.Body.GetWorldRect(px, py, w.Facing[id].Right, trap.MirrorWidth())
+		return x + width/2, y + height/2
+	}
+	hb := w.Hitbox[id]
+	return px + hb.OffsetX + hb.Width/2, py + hb.OffsetY + hb.Height/2
+}
+
+// isqrt returns floor(sqrt(n)) for n >= 0 using integer Newton iteration
+func isqrt(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	x := n
+	y := (x + 1) / 2
+	for y < x {
+		x = y
+		y = (x + n/x) / 2
+	}
+	return x
+}
+
+// divRound divides a by b (b > 0), rounding half away from zero.
+// Truncating would shrink the velocity a little every substep.
+func divRound(a, b int) int {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
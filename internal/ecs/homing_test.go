@@ -0,0 +1,68 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsqrt(t *testing.T) {
+	assert.Equal(t, 0, isqrt(0))
+	assert.Equal(t, 1, isqrt(3))
+	assert.Equal(t, 12, isqrt(144))
+	assert.Equal(t, 12, isqrt(168))
+	assert.Equal(t, 0, isqrt(-4))
+}
+
+func TestUpdateProjectiles_HomingCurvesTowardEnemy(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	enemy := w.CreateEnemy(200, 160, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	homing := Homing{TurnRate: 4, Range: 200, Lifetime: 60}
+	proj := w.CreateProjectile(100, 100, 100, 0, ProjectileConfig{MaxRange: 300, Homing: homing}, FactionPlayer)
+
+	for i := 0; i < 10; i++ {
+		UpdateProjectiles(w, stage)
+	}
+
+	data := w.ProjectileData[proj]
+	vel := w.Velocity[proj]
+	assert.Equal(t, enemy, data.Target)
+	assert.Positive(t, vel.Y, "arrow should curve down toward the enemy")
+	assert.InDelta(t, 100, isqrt(vel.X*vel.X+vel.Y*vel.Y), 2, "speed is preserved")
+}
+
+func TestUpdateProjectiles_EnemyHomingSeeksPlayerInRange(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	body := Hitbox{Width: 12, Height: 12}
+	player := w.CreatePlayer(100, 40, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	homing := Homing{TurnRate: 4, Range: 50, Lifetime: 60}
+	far := w.CreateProjectile(300, 100, -100, 0, ProjectileConfig{MaxRange: 300, Homing: homing}, FactionEnemy)
+	near := w.CreateProjectile(130, 80, -100, 0, ProjectileConfig{MaxRange: 300, Homing: homing}, FactionEnemy)
+
+	UpdateProjectiles(w, stage)
+
+	assert.Equal(t, EntityID(0), w.ProjectileData[far].Target, "player is out of range")
+	assert.Equal(t, player, w.ProjectileData[near].Target)
+	assert.Negative(t, w.Velocity[near].Y, "arrow should curve up toward the player")
+}
+
+func TestHoming_StopsAfterLifetime(t *testing.T) {
+	w := NewWorld()
+	homing := Homing{TurnRate: 4, Range: 200, Lifetime: 2}
+	proj := w.CreateProjectile(100, 100, 100, 0, ProjectileConfig{GravityAccel: 5, MaxFallSpeed: 50, Homing: homing}, FactionPlayer)
+
+	ApplyProjectileGravity(w)
+	assert.Equal(t, 0, w.Velocity[proj].Y, "homing projectiles ignore gravity")
+
+	UpdateTimers(w)
+	UpdateTimers(w)
+	data := w.ProjectileData[proj]
+	require.Equal(t, 2, data.Age)
+	assert.False(t, homingActive(&data))
+
+	ApplyProjectileGravity(w)
+	assert.Equal(t, 5, w.Velocity[proj].Y, "gravity resumes once homing expires")
+}
@@ -0,0 +1,123 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InspectComponent is one component of an inspected entity
+type InspectComponent struct {
+	Name   string
+	Fields []InspectField // numbers and flags (none for tags)
+}
+
+// InspectField is a number or flag of a component. Path is the field
+// name, dotted for nested structs (e.g. "Surface.SpeedPct").
+type InspectField struct {
+	Path  string
+	Kind  reflect.Kind
+	Value float64 // bools are 0 or 1
+	Name  string  // enum name for fmt.Stringer types ("" = none)
+}
+
+// Inspect lists an entity's components and their numeric fields, in the
+// world's component order (for the debug inspector)
+func (w *World) Inspect(id EntityID) []InspectComponent {
+	var out []InspectComponent
+	key := reflect.ValueOf(id)
+	for _, c := range w.componentMaps() {
+		v := c.components.MapIndex(key)
+		if !v.IsValid() {
+			continue
+		}
+		comp := InspectComponent{Name: c.name}
+		inspectFields(v, "", &comp.Fields)
+		out = append(out, comp)
+	}
+	return out
+}
+
+// inspectFields appends the numeric and bool fields of a struct value
+func inspectFields(v reflect.Value, prefix string, out *[]InspectField) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		path := prefix + f.Name
+		switch fv.Kind() {
+		case reflect.Struct:
+			inspectFields(fv, path+".", out)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			*out = append(*out, InspectField{path, fv.Kind(), float64(fv.Int()), enumName(fv)})
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			*out = append(*out, InspectField{path, fv.Kind(), float64(fv.Uint()), enumName(fv)})
+		case reflect.Float32, reflect.Float64:
+			*out = append(*out, InspectField{path, fv.Kind(), fv.Float(), ""})
+		case reflect.Bool:
+			value := 0.0
+			if fv.Bool() {
+				value = 1
+			}
+			*out = append(*out, InspectField{path, fv.Kind(), value, ""})
+		}
+	}
+}
+
+// enumName returns the String() of enum-like integer fields
+func enumName(v reflect.Value) string {
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ""
+}
+
+// SetInspectField sets a numeric or bool field of an entity's component
+// (bools are true for non-zero values). It reports whether the field exists.
+func (w *World) SetInspectField(id EntityID, component, path string, value float64) bool {
+	for _, c := range w.componentMaps() {
+		if c.name != component {
+			continue
+		}
+		key := reflect.ValueOf(id)
+		v := c.components.MapIndex(key)
+		if !v.IsValid() {
+			return false
+		}
+		edited := reflect.New(v.Type()).Elem()
+		edited.Set(v)
+
+		f := edited
+		for _, name := range strings.Split(path, ".") {
+			if f.Kind() != reflect.Struct {
+				return false
+			}
+			sf, ok := f.Type().FieldByName(name)
+			if !ok || !sf.IsExported() {
+				return false
+			}
+			f = f.FieldByIndex(sf.Index)
+		}
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f.SetInt(int64(value))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f.SetUint(uint64(max(0, value)))
+		case reflect.Float32, reflect.Float64:
+			f.SetFloat(value)
+		case reflect.Bool:
+			f.SetBool(value != 0)
+		default:
+			return false
+		}
+		c.components.SetMapIndex(key, edited)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inspectField finds a field of an inspected component
+func inspectField(t *testing.T, comps []InspectComponent, component, path string) InspectField {
+	t.Helper()
+	for _, c := range comps {
+		if c.Name != component {
+			continue
+		}
+		for _, f := range c.Fields {
+			if f.Path == path {
+				return f
+			}
+		}
+	}
+	require.Failf(t, "field not found", "%s.%s", component, path)
+	return InspectField{}
+}
+
+func TestWorld_InspectListsComponentFields(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEnemy(32, 48, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	w.Movement[id] = Movement{OnGround: true, Surface: Surface{SpeedPct: 50}}
+
+	comps := w.Inspect(id)
+
+	assert.Equal(t, "Position", comps[0].Name, "world component order")
+	assert.Equal(t, float64(32*PositionScale), inspectField(t, comps, "Position", "X").Value)
+	assert.Equal(t, 1.0, inspectField(t, comps, "Movement", "OnGround").Value)
+	assert.Equal(t, reflect.Bool, inspectField(t, comps, "Movement", "OnGround").Kind)
+	assert.Equal(t, 50.0, inspectField(t, comps, "Movement", "Surface.SpeedPct").Value, "nested structs are flattened")
+	assert.Equal(t, w.AI[id].Type.String(), inspectField(t, comps, "AI", "Type").Name, "enums show their name")
+	assert.Contains(t, comps, InspectComponent{Name: "IsEnemy"})
+	assert.Empty(t, w.Inspect(999))
+}
+
+func TestWorld_SetInspectField(t *testing.T) {
+	w := NewWorld()
+	id := w.CreateEnemy(32, 48, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+
+	assert.True(t, w.SetInspectField(id, "Position", "Y", 100*PositionScale))
+	assert.True(t, w.SetInspectField(id, "Movement", "Surface.NoDash", 1))
+	assert.True(t, w.SetInspectField(id, "Health", "Current", 3))
+
+	assert.Equal(t, 100*PositionScale, w.Position[id].Y)
+	assert.True(t, w.Movement[id].Surface.NoDash)
+	assert.Equal(t, 3, w.Health[id].Current)
+
+	assert.False(t, w.SetInspectField(id, "Position", "Z", 1), "unknown field")
+	assert.False(t, w.SetInspectField(id, "Dash", "Timer", 1), "entity has no such component")
+}
@@ -0,0 +1,82 @@
+package ecs
+
+import "sort"
+
+// projRect is a projectile's world hitbox for the interception pass
+type projRect struct {
+	id         EntityID
+	x, y, w, h int
+}
+
+// interceptProjectiles destroys projectiles hit by intercepting arrows of a
+// faction hostile to them. The arrow is consumed as well and sparks are
+// spawned at the impact.
+//
+// Broadphase: the other projectiles are sorted by left edge once, so each
+// arrow only tests the ones whose X range can overlap its own (sweep and
+// prune).
+func interceptProjectiles(w *World) {
+	if !w.Collision.Collides(LayerProjectile, LayerProjectile) {
+		return
+	}
+	targets := w.interceptBuf[:0]
+	maxWidth := 0
+	for _, id := range w.Query(TagProjectile) {
+		proj := w.ProjectileData[id]
+		if proj.Intercepts || proj.Stuck {
+			continue
+		}
+		pos := w.Position[id]
+		hit := w.Hitbox[id]
+		targets = append(targets, projRect{
+			id: id,
+			x:  pos.PixelX() + hit.OffsetX,
+			y:  pos.PixelY() + hit.OffsetY,
+			w:  hit.Width,
+			h:  hit.Height,
+		})
+		maxWidth = max(maxWidth, hit.Width)
+	}
+	w.interceptBuf = targets[:0]
+	if len(targets) == 0 {
+		return
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].x != targets[j].x {
+			return targets[i].x < targets[j].x
+		}
+		return targets[i].id < targets[j].id
+	})
+
+	toDestroy := w.destroyBuf[:0]
+	for _, id := range w.Query(TagProjectile) {
+		proj := w.ProjectileData[id]
+		if !proj.Intercepts || proj.Stuck {
+			continue
+		}
+		pos := w.Position[id]
+		hit := w.Hitbox[id]
+		ax, ay := pos.PixelX()+hit.OffsetX, pos.PixelY()+hit.OffsetY
+
+		// First target whose right edge can reach the arrow's left edge
+		start := sort.Search(len(targets), func(i int) bool {
+			return targets[i].x+maxWidth > ax
+		})
+		for i := start; i < len(targets) && targets[i].x < ax+hit.Width; i++ {
+			t := targets[i]
+			if t.id == 0 || !w.Hostile(id, t.id) || !rectsOverlap(ax, ay, hit.Width, hit.Height, t.x, t.y, t.w, t.h) {
+				continue
+			}
+			targets[i].id = 0 // consumed
+
+			toDestroy = append(toDestroy, id, t.id)
+			SpawnSparks(w, (ax+t.x+(hit.Width+t.w)/2)/2, (ay+t.y+(hit.Height+t.h)/2)/2)
+			w.Events.Emit(Event{Type: EventProjectileIntercepted, Entity: id})
+			break
+		}
+	}
+	for _, id := range toDestroy {
+		w.DestroyEntity(id)
+	}
+	w.destroyBuf = toDestroy[:0]
+}
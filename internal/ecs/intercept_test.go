@@ -0,0 +1,54 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDamage_ArrowInterceptsEnemyProjectile(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	hitbox := ProjectileConfig{HitboxWidth: 12, HitboxHeight: 4}
+	enemyProj := w.CreateProjectile(100, 100, -90, 0, hitbox, FactionEnemy)
+	farProj := w.CreateProjectile(300, 100, -90, 0, hitbox, FactionEnemy)
+
+	intercepting := hitbox
+	intercepting.Intercepts = true
+	arrow := w.CreateProjectile(106, 101, 90, 0, intercepting, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, w.Exists(enemyProj))
+	assert.False(t, w.Exists(arrow), "intercepting arrow is consumed")
+	assert.True(t, w.Exists(farProj))
+	assert.Len(t, w.Particle, sparkCount)
+	assert.Equal(t, []Event{{Type: EventProjectileIntercepted, Entity: arrow}}, w.Events.Drain())
+}
+
+func TestUpdateDamage_NonInterceptingArrowPassesThrough(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	hitbox := ProjectileConfig{HitboxWidth: 12, HitboxHeight: 4}
+	enemyProj := w.CreateProjectile(100, 100, -90, 0, hitbox, FactionEnemy)
+	arrow := w.CreateProjectile(106, 101, 90, 0, hitbox, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.True(t, w.Exists(enemyProj))
+	assert.True(t, w.Exists(arrow))
+	assert.Empty(t, w.Particle)
+}
+
+func TestUpdateParticles_ExpireAndRecycle(t *testing.T) {
+	w := NewWorld()
+	SpawnSparks(w, 50, 50)
+
+	for i := 0; i < sparkLife; i++ {
+		UpdateParticles(w)
+		w.Flush()
+	}
+
+	assert.Empty(t, w.Particle)
+	assert.Len(t, w.particlePool, sparkCount)
+}
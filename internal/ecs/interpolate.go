@@ -0,0 +1,33 @@
+package ecs
+
+// interpolationSnap is the distance (IU, per axis) past which a frame's move
+// counts as a teleport (respawn, checkpoint) and is drawn without blending
+const interpolationSnap = 32 * PositionScale
+
+// StorePreviousPositions remembers every position before the frame moves
+// anything. Runs first in PhaseFrameStart.
+func StorePreviousPositions(w *World) {
+	clear(w.PreviousPosition)
+	for id, pos := range w.Position {
+		w.PreviousPosition[id] = PreviousPosition(pos)
+	}
+}
+
+// InterpolatedPosition returns the position of id drawn alpha (0-1) of the
+// way from its previous frame to the current one. Entities spawned this
+// frame and teleports use the current position.
+func InterpolatedPosition(w *World, id EntityID, alpha float64) Position {
+	pos := w.Position[id]
+	prev, ok := w.PreviousPosition[id]
+	if !ok || alpha >= 1 {
+		return pos
+	}
+	dx, dy := pos.X-prev.X, pos.Y-prev.Y
+	if max(dx, -dx) > interpolationSnap || max(dy, -dy) > interpolationSnap {
+		return pos
+	}
+	return Position{
+		X: prev.X + int(float64(dx)*alpha),
+		Y: prev.Y + int(float64(dy)*alpha),
+	}
+}
@@ -0,0 +1,26 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolatedPosition(t *testing.T) {
+	w := NewWorld()
+	id := w.CreatePlayer(10, 20, PlayerProfile{MaxHealth: 10})
+
+	StorePreviousPositions(w)
+	w.Position[id] = Position{X: w.Position[id].X + 4*PositionScale, Y: w.Position[id].Y}
+
+	half := InterpolatedPosition(w, id, 0.5)
+	assert.Equal(t, 12, half.PixelX(), "halfway through the move")
+	assert.Equal(t, 20, half.PixelY())
+	assert.Equal(t, w.Position[id], InterpolatedPosition(w, id, 1))
+
+	w.Position[id] = Position{X: 500 * PositionScale, Y: 20 * PositionScale}
+	assert.Equal(t, 500, InterpolatedPosition(w, id, 0.5).PixelX(), "teleports are not blended")
+
+	arrow := w.CreateProjectile(0, 0, 1, 0, ProjectileConfig{}, FactionPlayer)
+	assert.Equal(t, w.Position[arrow], InterpolatedPosition(w, arrow, 0.25), "spawned this frame")
+}
@@ -0,0 +1,43 @@
+package ecs
+
+import "fmt"
+
+// Invariant limits: far past any speed clamp or stage size, so values
+// beyond them mean an overflow or a runaway integration
+const (
+	maxSanePosition = 1 << 28 // IU
+	maxSaneVelocity = 1 << 20 // IU per substep
+)
+
+// CheckInvariants returns the first broken world invariant, or nil: the
+// player's body inside the stage (w x h pixels), health never above its
+// maximum and positions and velocities far from overflowing. Soak tests
+// check it every frame.
+func CheckInvariants(w *World, stageW, stageH int) error {
+	if pos, ok := w.Position[w.PlayerID]; ok {
+		hitbox := w.HitboxTrapezoid[w.PlayerID]
+		bx, by, bw, bh := hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), w.Facing[w.PlayerID].Right, hitbox.MirrorWidth())
+		if bx < 0 || by < 0 || bx+bw > stageW || by+bh > stageH {
+			return fmt.Errorf("player body (%d,%d %dx%d) left the %dx%d stage", bx, by, bw, bh, stageW, stageH)
+		}
+	}
+	for _, id := range w.Query(CompHealth) {
+		h := w.Health[id]
+		if h.Current > h.Max {
+			return fmt.Errorf("entity %d health %d above max %d", id, h.Current, h.Max)
+		}
+	}
+	for _, id := range w.Query(CompPosition) {
+		pos := w.Position[id]
+		if abs(pos.X) > maxSanePosition || abs(pos.Y) > maxSanePosition {
+			return fmt.Errorf("entity %d position (%d,%d) out of range", id, pos.X, pos.Y)
+		}
+	}
+	for _, id := range w.Query(CompVelocity) {
+		vel := w.Velocity[id]
+		if abs(vel.X) > maxSaneVelocity || abs(vel.Y) > maxSaneVelocity {
+			return fmt.Errorf("entity %d velocity (%d,%d) out of range", id, vel.X, vel.Y)
+		}
+	}
+	return nil
+}
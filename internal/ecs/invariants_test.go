@@ -0,0 +1,28 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{
+		MaxHealth: 10,
+		Hitbox:    HitboxTrapezoid{Body: Hitbox{OffsetX: 2, Width: 12, Height: 16}},
+	})
+	assert.NoError(t, CheckInvariants(w, 320, 240))
+
+	w.Position[player] = Position{X: 310 * PositionScale, Y: 100 * PositionScale}
+	assert.ErrorContains(t, CheckInvariants(w, 320, 240), "left the")
+	w.Position[player] = Position{X: 100 * PositionScale, Y: 100 * PositionScale}
+
+	w.Health[player] = Health{Current: 11, Max: 10}
+	assert.ErrorContains(t, CheckInvariants(w, 320, 240), "above max")
+	w.Health[player] = Health{Current: -5, Max: 10}
+	assert.NoError(t, CheckInvariants(w, 320, 240), "overkill is fine")
+
+	w.Velocity[player] = Velocity{X: maxSaneVelocity + 1}
+	assert.ErrorContains(t, CheckInvariants(w, 320, 240), "velocity")
+}
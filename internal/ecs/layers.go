@@ -0,0 +1,102 @@
+package ecs
+
+// Layer is the collision layer of an entity or of the stage
+type Layer int
+
+const (
+	LayerNone       Layer = iota // collides with nothing (NPCs, allies, particles)
+	LayerPlayer                  // the player
+	LayerEnemy                   // enemies
+	LayerProjectile              // every projectile, whoever fired it
+	LayerPickup                  // gold, health, ammo and treasure
+	LayerTerrain                 // the stage's solid tiles
+	LayerTrigger                 // stage trigger areas (exits, dialogue)
+	LayerCount
+)
+
+// layerNames maps layers to their config names
+var layerNames = [LayerCount]string{"", "player", "enemy", "projectile", "pickup", "terrain", "trigger"}
+
+// String returns the config name of the layer
+func (l Layer) String() string {
+	if l < 0 || l >= LayerCount {
+		return "unknown"
+	}
+	return layerNames[l]
+}
+
+// ParseLayer returns the layer for a config name
+func ParseLayer(name string) (Layer, bool) {
+	for l := LayerPlayer; l < LayerCount; l++ {
+		if layerNames[l] == name {
+			return l, true
+		}
+	}
+	return LayerNone, false
+}
+
+// CollisionMask says which pairs of layers interact. It is symmetric: Set
+// changes both directions of a pair.
+type CollisionMask [LayerCount][LayerCount]bool
+
+// DefaultCollisionMask returns the built-in mask: everything the game has
+// always checked. Player and enemy movement always stops at terrain; the
+// mask decides whether projectiles and pickups do.
+func DefaultCollisionMask() CollisionMask {
+	var m CollisionMask
+	m.Set(LayerPlayer, LayerEnemy, true)
+	m.Set(LayerPlayer, LayerProjectile, true)
+	m.Set(LayerPlayer, LayerPickup, true)
+	m.Set(LayerPlayer, LayerTerrain, true)
+	m.Set(LayerPlayer, LayerTrigger, true)
+	m.Set(LayerEnemy, LayerEnemy, true)
+	m.Set(LayerEnemy, LayerProjectile, true)
+	m.Set(LayerEnemy, LayerTerrain, true)
+	m.Set(LayerProjectile, LayerProjectile, true)
+	m.Set(LayerProjectile, LayerTerrain, true)
+	m.Set(LayerPickup, LayerTerrain, true)
+	return m
+}
+
+// Set turns the interaction of two layers on or off
+func (m *CollisionMask) Set(a, b Layer, on bool) {
+	if a <= LayerNone || a >= LayerCount || b <= LayerNone || b >= LayerCount {
+		return
+	}
+	m[a][b] = on
+	m[b][a] = on
+}
+
+// Collides reports whether two layers interact
+func (m *CollisionMask) Collides(a, b Layer) bool {
+	if a < 0 || a >= LayerCount || b < 0 || b >= LayerCount {
+		return false
+	}
+	return m[a][b]
+}
+
+// LayerOf returns the collision layer of an entity, from its tags
+func (w *World) LayerOf(id EntityID) Layer {
+	switch {
+	case has(w.IsPlayer, id):
+		return LayerPlayer
+	case has(w.IsEnemy, id):
+		return LayerEnemy
+	case has(w.IsProjectile, id):
+		return LayerProjectile
+	case has(w.IsPickup, id):
+		return LayerPickup
+	}
+	return LayerNone
+}
+
+// Collides reports whether two entities' layers interact
+func (w *World) Collides(a, b EntityID) bool {
+	return w.Collision.Collides(w.LayerOf(a), w.LayerOf(b))
+}
+
+// has reports whether a tag map contains id
+func has(tag map[EntityID]struct{}, id EntityID) bool {
+	_, ok := tag[id]
+	return ok
+}
@@ -0,0 +1,101 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultCollisionMask(t *testing.T) {
+	m := DefaultCollisionMask()
+
+	assert.True(t, m.Collides(LayerPlayer, LayerEnemy))
+	assert.True(t, m.Collides(LayerEnemy, LayerPlayer))
+	assert.True(t, m.Collides(LayerProjectile, LayerTerrain))
+	assert.True(t, m.Collides(LayerProjectile, LayerProjectile), "shots intercept each other")
+	assert.False(t, m.Collides(LayerEnemy, LayerPickup))
+	assert.False(t, m.Collides(LayerEnemy, LayerTrigger))
+	for l := LayerNone; l < LayerCount; l++ {
+		assert.False(t, m.Collides(LayerNone, l))
+	}
+	assert.False(t, m.Collides(LayerCount, LayerPlayer))
+}
+
+func TestCollisionMask_SetIsSymmetric(t *testing.T) {
+	m := DefaultCollisionMask()
+	m.Set(LayerTerrain, LayerProjectile, false)
+	m.Set(LayerEnemy, LayerPickup, true)
+	m.Set(LayerNone, LayerPlayer, true)
+
+	assert.False(t, m.Collides(LayerProjectile, LayerTerrain))
+	assert.True(t, m.Collides(LayerPickup, LayerEnemy))
+	assert.False(t, m.Collides(LayerPlayer, LayerNone))
+}
+
+func TestParseLayer(t *testing.T) {
+	for l := LayerPlayer; l < LayerCount; l++ {
+		got, ok := ParseLayer(l.String())
+		assert.True(t, ok)
+		assert.Equal(t, l, got)
+	}
+	_, ok := ParseLayer("")
+	assert.False(t, ok)
+	_, ok = ParseLayer("water")
+	assert.False(t, ok)
+}
+
+func TestLayerOf(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 10})
+	enemy := w.CreateEnemy(0, 0, EnemyConfig{MaxHealth: 10}, true)
+	proj := w.CreateProjectile(0, 0, 0, 0, ProjectileConfig{}, FactionPlayer)
+	gold := w.CreateGold(0, 0, 1, PickupConfig{})
+
+	assert.Equal(t, LayerPlayer, w.LayerOf(player))
+	assert.Equal(t, LayerEnemy, w.LayerOf(enemy))
+	assert.Equal(t, LayerProjectile, w.LayerOf(proj))
+	assert.Equal(t, LayerPickup, w.LayerOf(gold))
+	assert.Equal(t, LayerNone, w.LayerOf(w.NewEntity()))
+	assert.True(t, w.Collides(proj, enemy))
+	assert.False(t, w.Collides(enemy, gold))
+}
+
+func TestUpdateProjectiles_PassThroughTerrainWhenMasked(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(7, 6)
+	fire := func(w *World) EntityID {
+		return w.CreateProjectile(100, 100, 1000, 0, ProjectileConfig{MaxRange: 300}, FactionPlayer)
+	}
+
+	w := NewWorld()
+	stuck := fire(w)
+	for range 10 {
+		UpdateProjectiles(w, stage)
+	}
+	assert.True(t, w.ProjectileData[stuck].Stuck)
+
+	w = NewWorld()
+	w.Collision.Set(LayerProjectile, LayerTerrain, false)
+	through := fire(w)
+	for range 10 {
+		UpdateProjectiles(w, stage)
+	}
+	assert.False(t, w.ProjectileData[through].Stuck)
+	assert.Greater(t, w.Position[through].PixelX(), 8*16)
+}
+
+func TestUpdateDamage_MaskedPairsDontHit(t *testing.T) {
+	w := NewWorld()
+	w.Collision.Set(LayerProjectile, LayerEnemy, false)
+	w.Collision.Set(LayerPlayer, LayerEnemy, false)
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100, Hitbox: testPlayerHitbox})
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 16, HitboxHeight: 24}, true)
+	w.CreateProjectile(104, 110, 100, 0, ProjectileConfig{Damage: 5, HitboxWidth: 8, HitboxHeight: 4}, FactionPlayer)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 100, w.Health[player].Current)
+	assert.Equal(t, 10, w.Health[enemy].Current)
+	assert.Len(t, w.IsProjectile, 1)
+}
@@ -0,0 +1,164 @@
+package ecs
+
+import (
+	"math/rand"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// PickupKind is what a dropped pickup gives the player
+type PickupKind int
+
+const (
+	PickupGold     PickupKind = iota // Amount: gold
+	PickupHealth                     // Amount: health restored
+	PickupAmmo                       // Amount: homing arrows
+	PickupTreasure                   // rare item worth bonus points
+	PickupNone                       // loot entry that drops nothing
+)
+
+// String returns the config name of the pickup kind
+func (k PickupKind) String() string {
+	switch k {
+	case PickupGold:
+		return "gold"
+	case PickupHealth:
+		return "health"
+	case PickupAmmo:
+		return "ammo"
+	case PickupTreasure:
+		return "treasure"
+	case PickupNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePickupKind returns the pickup kind for a config name
+func ParsePickupKind(name string) (PickupKind, bool) {
+	for k := PickupGold; k <= PickupNone; k++ {
+		if k.String() == name {
+			return k, true
+		}
+	}
+	return PickupNone, false
+}
+
+// LootEntry is one weighted outcome of a loot roll
+type LootEntry struct {
+	Kind     PickupKind
+	Weight   int
+	Min, Max int // amount range (inclusive)
+}
+
+// LootTable is rolled when an enemy dies. Each roll picks one entry by
+// weight, so Rolls bounds the number of pickups dropped.
+type LootTable struct {
+	Rolls   int // 0 = one roll
+	Entries []LootEntry
+}
+
+// GoldLoot returns a table that always drops gold in [min, max]
+func GoldLoot(min, max int) LootTable {
+	if max <= 0 {
+		return LootTable{}
+	}
+	return LootTable{Entries: []LootEntry{{Kind: PickupGold, Weight: 1, Min: min, Max: max}}}
+}
+
+// Drop is a rolled pickup
+type Drop struct {
+	Kind   PickupKind
+	Amount int
+}
+
+// Roll draws the table's drops from rng, appending them to drops
+func (t LootTable) Roll(rng *rand.Rand, drops []Drop) []Drop {
+	total := 0
+	for _, e := range t.Entries {
+		total += max(0, e.Weight)
+	}
+	if total == 0 {
+		return drops
+	}
+
+	for i := 0; i < max(1, t.Rolls); i++ {
+		r := rng.Intn(total)
+		for _, e := range t.Entries {
+			if e.Weight <= 0 {
+				continue
+			}
+			if r >= e.Weight {
+				r -= e.Weight
+				continue
+			}
+			amount := e.Min
+			if e.Max > e.Min {
+				amount += rng.Intn(e.Max - e.Min + 1)
+			}
+			if e.Kind != PickupNone && amount > 0 {
+				drops = append(drops, Drop{Kind: e.Kind, Amount: amount})
+			}
+			break
+		}
+	}
+	return drops
+}
+
+// dropLoot rolls a killed enemy's loot table and spawns the pickups.
+// It returns the gold dropped.
+func dropLoot(w *World, id EntityID) int {
+	centerX, _ := HitboxCenter(w, id)
+	return dropTable(w, centerX, w.Position[id].PixelY(), w.AI[id].Loot)
+}
+
+// dropTable rolls a loot table and spawns the pickups around (x, y), in
+// pixels. It returns the gold dropped.
+func dropTable(w *World, x, y int, table LootTable) int {
+	drops := table.Roll(w.Rand, w.dropBuf[:0])
+
+	gold := 0
+	for i, d := range drops {
+		if d.Kind == PickupGold {
+			gold += d.Amount
+		}
+		// Fan multiple pickups out so they don't stack
+		w.CreatePickup(x+(i%3-1)*6, y, d.Kind, d.Amount, dropPickupConfig)
+	}
+	w.dropBuf = drops[:0]
+	return gold
+}
+
+// dropPickupConfig is the physics of pickups dropped by enemies
+var dropPickupConfig = PickupConfig{
+	Gravity:       fixedpoint.ToIUAccelPerFrame(400), // 400 pixels/sec² → IU velocity change per frame
+	BouncePercent: 50,                                // 50% velocity retained on bounce
+	CollectDelay:  18,                                // 0.3 seconds
+	HitboxWidth:   8,
+	HitboxHeight:  8,
+	CollectRadius: 16,
+	MagnetRadius:  40,
+	MagnetSpeed:   fixedpoint.ToIUPerSubstep(180), // 180 pixels/sec
+}
+
+// collectPickup applies a pickup to the player and emits its event
+func collectPickup(w *World, playerID EntityID, player *Player, id EntityID, pickup Pickup) {
+	switch pickup.Kind {
+	case PickupGold:
+		player.Gold += pickup.Amount
+		restoreEnergy(player, pickup.Amount*w.Energy.PerGold)
+		w.Events.Emit(Event{Type: EventGoldCollected, Entity: id, Amount: pickup.Amount})
+	case PickupHealth:
+		health := w.Health[playerID]
+		healed := max(0, min(pickup.Amount, health.Max-health.Current))
+		health.Current += healed
+		w.Health[playerID] = health
+		w.Events.Emit(Event{Type: EventHealthCollected, Entity: id, Amount: healed})
+	case PickupAmmo:
+		player.Ammo += pickup.Amount
+		w.Events.Emit(Event{Type: EventAmmoCollected, Entity: id, Amount: pickup.Amount})
+	case PickupTreasure:
+		w.Events.Emit(Event{Type: EventTreasureCollected, Entity: id, Amount: pickup.Amount})
+	}
+}
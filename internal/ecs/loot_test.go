@@ -0,0 +1,133 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLootTable_RollFollowsWeights(t *testing.T) {
+	table := LootTable{Entries: []LootEntry{
+		{Kind: PickupGold, Weight: 3, Min: 1, Max: 3},
+		{Kind: PickupNone, Weight: 1},
+		{Kind: PickupTreasure, Weight: 0, Min: 1, Max: 1},
+	}}
+	rng := rand.New(rand.NewSource(7))
+
+	counts := map[PickupKind]int{}
+	for i := 0; i < 4000; i++ {
+		for _, d := range table.Roll(rng, nil) {
+			counts[d.Kind]++
+			assert.True(t, d.Amount >= 1 && d.Amount <= 3)
+		}
+	}
+	assert.InDelta(t, 3000, counts[PickupGold], 150)
+	assert.Zero(t, counts[PickupTreasure], "zero weight never drops")
+	assert.Len(t, counts, 1, "none drops nothing")
+}
+
+func TestLootTable_RollIsDeterministic(t *testing.T) {
+	table := LootTable{Rolls: 3, Entries: []LootEntry{
+		{Kind: PickupGold, Weight: 5, Min: 1, Max: 9},
+		{Kind: PickupAmmo, Weight: 2, Min: 2, Max: 3},
+	}}
+	a := table.Roll(rand.New(rand.NewSource(42)), nil)
+	b := table.Roll(rand.New(rand.NewSource(42)), nil)
+
+	assert.Len(t, a, 3)
+	assert.Equal(t, a, b)
+}
+
+func TestGoldLoot(t *testing.T) {
+	drops := GoldLoot(4, 4).Roll(rand.New(rand.NewSource(1)), nil)
+	assert.Equal(t, []Drop{{Kind: PickupGold, Amount: 4}}, drops)
+	assert.Empty(t, GoldLoot(0, 0).Entries)
+}
+
+func TestParsePickupKind(t *testing.T) {
+	kind, ok := ParsePickupKind("ammo")
+	assert.True(t, ok)
+	assert.Equal(t, PickupAmmo, kind)
+
+	_, ok = ParsePickupKind("sword")
+	assert.False(t, ok)
+}
+
+func TestUpdateDamage_KillDropsRolledLoot(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    5,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+		Loot:         LootTable{Rolls: 2, Entries: []LootEntry{{Kind: PickupHealth, Weight: 1, Min: 20, Max: 20}}},
+	}, true)
+	w.CreateProjectile(100, 100, 50, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	require.Len(t, w.IsPickup, 2)
+	for id := range w.IsPickup {
+		assert.Equal(t, PickupHealth, w.PickupData[id].Kind)
+		assert.Equal(t, 20, w.PickupData[id].Amount)
+	}
+}
+
+func TestUpdateDamage_TwoKillingArrowsKillOnce(t *testing.T) {
+	w := NewWorld()
+	w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth:    10,
+		HitboxWidth:  12,
+		HitboxHeight: 12,
+		Loot:         LootTable{Rolls: 1, Entries: []LootEntry{{Kind: PickupHealth, Weight: 1, Min: 20, Max: 20}}},
+	}, true)
+	arrow := ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}
+	w.CreateProjectile(100, 100, 50, 0, arrow, FactionPlayer)
+	w.CreateProjectile(101, 100, 50, 0, arrow, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	kills := 0
+	for _, ev := range w.Events.Drain() {
+		if ev.Type == EventEnemyKilled {
+			kills++
+		}
+	}
+	assert.Equal(t, 1, kills)
+	assert.Len(t, w.IsPickup, 1, "loot drops once")
+}
+
+func TestCollectPickups_AppliesPickupKinds(t *testing.T) {
+	w := NewWorld()
+	body := Hitbox{Width: 16, Height: 16}
+	player := w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Body: body}, MaxHealth: 100})
+	health := w.Health[player]
+	health.Current = 90
+	w.Health[player] = health
+
+	cfg := PickupConfig{CollectRadius: 16, HitboxWidth: 8, HitboxHeight: 8}
+	w.CreatePickup(104, 104, PickupHealth, 25, cfg)
+	w.CreatePickup(104, 104, PickupAmmo, 3, cfg)
+	w.CreatePickup(104, 104, PickupTreasure, 1, cfg)
+	w.CreateGold(104, 104, 7, cfg)
+
+	CollectPickups(w)
+
+	assert.Equal(t, 100, w.Health[player].Current, "healing caps at max health")
+	assert.Equal(t, 3, w.PlayerData[player].Ammo)
+	assert.Equal(t, 7, w.PlayerData[player].Gold)
+	assert.Empty(t, w.IsPickup)
+	assert.ElementsMatch(t, []EventType{EventHealthCollected, EventAmmoCollected, EventTreasureCollected, EventGoldCollected},
+		eventTypes(w.Events.Drain()))
+}
+
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, ev := range events {
+		types[i] = ev.Type
+	}
+	return types
+}
@@ -0,0 +1,140 @@
+package ecs
+
+// NPCBehavior is how a friendly NPC moves when the player isn't near
+type NPCBehavior int
+
+const (
+	NPCStand  NPCBehavior = iota // stays at its spawn point
+	NPCPatrol                    // walks back and forth around its spawn point
+)
+
+// String returns the config name of the behavior
+func (b NPCBehavior) String() string {
+	switch b {
+	case NPCStand:
+		return "stand"
+	case NPCPatrol:
+		return "patrol"
+	default:
+		return "unknown"
+	}
+}
+
+// NPCConfig holds configuration for creating a friendly NPC
+// All velocity values are in IU/substep (pre-converted)
+type NPCConfig struct {
+	Behavior       NPCBehavior
+	PatrolDistance int // pixels each side of the spawn point
+	Speed          int // IU/substep
+	PauseFrames    int // idle time at each patrol turn
+	HitboxWidth    int // pixels
+	HitboxHeight   int // pixels
+	InteractRange  int // pixels between player and NPC centers
+}
+
+// CreateNPC creates a friendly NPC entity.
+// NPCs have no Health and are not enemies, so the damage system, enemy
+// contact and homing never target them.
+// x, y: pixel coordinates
+func (w *World) CreateNPC(x, y int, cfg NPCConfig, facingRight bool) EntityID {
+	id := w.NewEntity()
+
+	w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
+	w.Velocity[id] = Velocity{}
+	w.Hitbox[id] = Hitbox{Width: cfg.HitboxWidth, Height: cfg.HitboxHeight}
+	w.Facing[id] = Facing{Right: facingRight}
+	dir := -1
+	if facingRight {
+		dir = 1
+	}
+	w.NPC[id] = NPC{
+		Behavior:       cfg.Behavior,
+		HomeX:          x,
+		PatrolDistance: cfg.PatrolDistance,
+		PatrolDir:      dir,
+		Speed:          cfg.Speed,
+		PauseFrames:    cfg.PauseFrames,
+		InteractRange:  cfg.InteractRange,
+	}
+	w.IsNPC[id] = struct{}{}
+
+	return id
+}
+
+// npcCenter returns the pixel center of an NPC's hitbox
+func npcCenter(w *World, id EntityID) (int, int) {
+	pos := w.Position[id]
+	hb := w.Hitbox[id]
+	return pos.PixelX() + hb.Width/2, pos.PixelY() + hb.Height/2
+}
+
+// NearbyNPC returns the closest NPC whose InteractRange reaches the
+// player's center, or 0 if none can be talked to.
+func NearbyNPC(w *World) EntityID {
+	if w.PlayerID == 0 || !w.Exists(w.PlayerID) {
+		return 0
+	}
+	px, py := HitboxCenter(w, w.PlayerID)
+
+	var best EntityID
+	bestSq := 0
+	for _, id := range w.Query(TagNPC) {
+		nx, ny := npcCenter(w, id)
+		d2 := (px-nx)*(px-nx) + (py-ny)*(py-ny)
+		r := w.NPC[id].InteractRange
+		if d2 > r*r {
+			continue
+		}
+		if best == 0 || d2 < bestSq {
+			best = id
+			bestSq = d2
+		}
+	}
+	return best
+}
+
+// UpdateNPCs moves NPCs for one substep. NPCs in talking range stop and
+// face the player; patrolling NPCs otherwise walk between their patrol
+// bounds, pausing at each turn and turning early at walls and ledges.
+func UpdateNPCs(w *World, stage Stage) {
+	nearby := NearbyNPC(w)
+	for _, id := range w.Query(TagNPC) {
+		npc := w.NPC[id]
+		facing := w.Facing[id]
+
+		if id == nearby {
+			px, _ := HitboxCenter(w, w.PlayerID)
+			nx, _ := npcCenter(w, id)
+			facing.Right = px > nx
+			w.Facing[id] = facing
+			continue
+		}
+		if npc.Behavior != NPCPatrol || npc.PauseTimer > 0 || npc.Speed <= 0 {
+			continue
+		}
+
+		pos := w.Position[id]
+		hb := w.Hitbox[id]
+		next := pos
+		next.X += npc.PatrolDir * npc.Speed
+
+		frontX := next.PixelX()
+		if npc.PatrolDir > 0 {
+			frontX += hb.Width - 1
+		}
+		blocked := stage.IsSolidAt(frontX, next.PixelY()+hb.Height/2) ||
+			!stage.IsSolidAt(frontX, next.PixelY()+hb.Height)
+		outOfBounds := (npc.PatrolDir > 0 && next.PixelX() > npc.HomeX+npc.PatrolDistance) ||
+			(npc.PatrolDir < 0 && next.PixelX() < npc.HomeX-npc.PatrolDistance)
+
+		if blocked || outOfBounds {
+			npc.PatrolDir = -npc.PatrolDir
+			npc.PauseTimer = npc.PauseFrames
+		} else {
+			w.Position[id] = next
+		}
+		facing.Right = npc.PatrolDir > 0
+		w.Facing[id] = facing
+		w.NPC[id] = npc
+	}
+}
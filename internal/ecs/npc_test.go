@@ -0,0 +1,80 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newNPCWorld returns a world with ground at y=160 and the player far away
+func newNPCWorld() (*World, *mockStage) {
+	stage := newMockStage(40, 20, 16)
+	for x := 0; x < 40; x++ {
+		stage.setSolid(x, 10)
+	}
+	w := NewWorld()
+	body := Hitbox{Width: 12, Height: 16}
+	w.CreatePlayer(500, 144, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	return w, stage
+}
+
+var patrolNPC = NPCConfig{Behavior: NPCPatrol, PatrolDistance: 8, Speed: 64, PauseFrames: 3, HitboxWidth: 12, HitboxHeight: 16, InteractRange: 24}
+
+func TestUpdateNPCs_PatrolTurnsAndPausesAtBounds(t *testing.T) {
+	w, stage := newNPCWorld()
+	npc := w.CreateNPC(100, 144, patrolNPC, true)
+
+	for i := 0; i < 40; i++ {
+		UpdateNPCs(w, stage)
+	}
+
+	assert.LessOrEqual(t, w.Position[npc].PixelX(), 108)
+	assert.Equal(t, -1, w.NPC[npc].PatrolDir)
+	assert.False(t, w.Facing[npc].Right)
+	assert.Equal(t, 3, w.NPC[npc].PauseTimer)
+
+	x := w.Position[npc].X
+	UpdateNPCs(w, stage)
+	assert.Equal(t, x, w.Position[npc].X, "paused at the turn")
+
+	for i := 0; i < 3; i++ {
+		UpdateTimers(w)
+	}
+	UpdateNPCs(w, stage)
+	assert.Less(t, w.Position[npc].X, x, "walks back after the pause")
+}
+
+func TestUpdateNPCs_StopsAndFacesNearbyPlayer(t *testing.T) {
+	w, stage := newNPCWorld()
+	npc := w.CreateNPC(100, 144, patrolNPC, false)
+	standing := w.CreateNPC(300, 144, NPCConfig{HitboxWidth: 12, HitboxHeight: 16, InteractRange: 24}, false)
+	pos := w.Position[w.PlayerID]
+	pos.X = 115 * PositionScale
+	w.Position[w.PlayerID] = pos
+
+	assert.Equal(t, npc, NearbyNPC(w))
+
+	x := w.Position[npc].X
+	UpdateNPCs(w, stage)
+	assert.Equal(t, x, w.Position[npc].X)
+	assert.True(t, w.Facing[npc].Right, "turned toward the player")
+
+	UpdateNPCs(w, stage)
+	assert.Equal(t, 300, w.Position[standing].PixelX(), "standing NPCs don't move")
+}
+
+func TestNPC_IgnoredByDamage(t *testing.T) {
+	w, _ := newNPCWorld()
+	npc := w.CreateNPC(100, 144, NPCConfig{HitboxWidth: 12, HitboxHeight: 16}, false)
+	w.CreateProjectile(104, 150, 0, 0, ProjectileConfig{Damage: 10, HitboxWidth: 4, HitboxHeight: 4}, FactionPlayer)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.True(t, w.Exists(npc))
+	assert.Len(t, w.IsProjectile, 1, "arrows pass through NPCs")
+	assert.Empty(t, w.Events.Drain())
+
+	w.DestroyEntity(npc)
+	assert.Empty(t, w.IsNPC)
+	assert.Empty(t, w.NPC)
+}
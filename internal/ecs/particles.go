@@ -0,0 +1,71 @@
+package ecs
+
+import (
+	"image/color"
+	"math"
+)
+
+// Particle is a short-lived visual effect (no collision).
+// Particles are advanced once per frame by UpdateParticles.
+type Particle struct {
+	VX, VY  int // IU per frame
+	Gravity int // IU per frame²
+	Life    int // remaining frames
+	MaxLife int
+	Color   color.RGBA
+	Streak  bool // drawn as a line along its velocity (rain)
+}
+
+// Alpha returns the particle's fade factor (1.0 at spawn → 0.0 at death)
+func (p *Particle) Alpha() float64 {
+	if p.MaxLife <= 0 {
+		return 0
+	}
+	return float64(p.Life) / float64(p.MaxLife)
+}
+
+// Spark burst tuning
+const (
+	sparkCount   = 6
+	sparkSpeed   = 2 * PositionScale // IU per frame (2 pixels/frame)
+	sparkGravity = PositionScale / 8 // IU per frame²
+	sparkLife    = 12                // frames
+)
+
+// SparkColor is the color of projectile interception sparks
+var SparkColor = color.RGBA{255, 230, 120, 255}
+
+// SpawnSparks emits a radial burst of spark particles at pixel (x, y)
+func SpawnSparks(w *World, x, y int) {
+	for i := 0; i < sparkCount; i++ {
+		angle := 2 * math.Pi * float64(i) / sparkCount
+		id := w.newPooledEntity(&w.particlePool)
+		w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
+		w.Particle[id] = Particle{
+			VX:      int(math.Cos(angle) * sparkSpeed),
+			VY:      int(math.Sin(angle) * sparkSpeed),
+			Gravity: sparkGravity,
+			Life:    sparkLife,
+			MaxLife: sparkLife,
+			Color:   SparkColor,
+		}
+	}
+}
+
+// UpdateParticles moves particles and removes expired ones (call once per frame)
+func UpdateParticles(w *World) {
+	for _, id := range w.Query(CompParticle) {
+		p := w.Particle[id]
+		p.Life--
+		if p.Life <= 0 {
+			w.Defer(Destroy(id))
+			continue
+		}
+		pos := w.Position[id]
+		pos.X += p.VX
+		pos.Y += p.VY
+		p.VY += p.Gravity
+		w.Position[id] = pos
+		w.Particle[id] = p
+	}
+}
@@ -0,0 +1,128 @@
+package ecs
+
+// PatrolMode is what a patrol does after its last waypoint
+type PatrolMode int
+
+const (
+	PatrolLoop     PatrolMode = iota // back to the first waypoint
+	PatrolPingPong                   // walk the route back in reverse
+	PatrolOnce                       // stop at the last waypoint
+)
+
+// patrolModeNames maps patrol modes to their config names
+var patrolModeNames = map[PatrolMode]string{
+	PatrolLoop:     "loop",
+	PatrolPingPong: "pingPong",
+	PatrolOnce:     "once",
+}
+
+// String returns the config name of the mode
+func (m PatrolMode) String() string {
+	return patrolModeNames[m]
+}
+
+// ParsePatrolMode returns the patrol mode for a config name
+func ParsePatrolMode(name string) (PatrolMode, bool) {
+	for m, n := range patrolModeNames {
+		if n == name {
+			return m, true
+		}
+	}
+	return PatrolLoop, false
+}
+
+// Waypoint is a patrol route point (enemy position, pixels)
+type Waypoint struct {
+	X, Y int
+}
+
+// PatrolRoute is a designer-placed patrol path. An empty route patrols
+// PatrolDistance either side of the spawn point instead.
+type PatrolRoute struct {
+	Points []Waypoint
+	Mode   PatrolMode
+}
+
+const (
+	// waypointReach is how close (pixels) an enemy must get to a waypoint
+	waypointReach = 2
+	// waypointJumpReach is how close (pixels, horizontally) a walker gets
+	// to a higher waypoint before jumping up to it
+	waypointJumpReach = 16
+	// waypointStepHeight is how far (pixels) above a walker a waypoint must
+	// be before it jumps; lower ones are reached by walking
+	waypointStepHeight = 8
+)
+
+// followRoute walks (or flies) toward the current waypoint, jumping up to
+// higher ones and over walls, and moves on to the next on arrival
+func followRoute(stage Stage, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement) {
+	if !ai.Flying {
+		moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
+	}
+	if ai.RouteDone {
+		return
+	}
+
+	target := ai.Route.Points[ai.Waypoint]
+	dx := target.X - pos.PixelX()
+	dy := target.Y - pos.PixelY()
+	arrived := abs(dy) <= waypointReach
+	if !ai.Flying {
+		arrived = mov.OnGround && abs(dy) <= waypointStepHeight
+	}
+	if abs(dx) <= waypointReach && arrived {
+		nextWaypoint(ai)
+		return
+	}
+
+	startX := pos.X
+	if abs(dx) > waypointReach {
+		ai.PatrolDir = sign(dx)
+		facing.Right = dx > 0
+		moveEnemyX(stage, hb, pos, vel, ai, facing, mov, sign(dx)*min(ai.MoveSpeed, abs(dx)*PositionScale))
+		// moveEnemyX turns around at walls; the route decides the direction
+		ai.PatrolDir = sign(dx)
+		facing.Right = dx > 0
+	}
+
+	if ai.Flying {
+		if abs(dy) > waypointReach {
+			moveEnemyY(stage, hb, pos, vel, mov, sign(dy)*min(ai.MoveSpeed, abs(dy)*PositionScale))
+		}
+		return
+	}
+
+	// Jump up to a higher waypoint, or over a wall in the way
+	blocked := abs(dx) > waypointReach && pos.X == startX
+	if mov.OnGround && ai.JumpForce > 0 && (blocked || dy < -waypointStepHeight && abs(dx) <= waypointJumpReach) {
+		vel.Y = -ai.JumpForce
+		mov.OnGround = false
+	}
+}
+
+// nextWaypoint advances the route according to its mode
+func nextWaypoint(ai *AI) {
+	last := len(ai.Route.Points) - 1
+	switch ai.Route.Mode {
+	case PatrolLoop:
+		ai.Waypoint++
+		if ai.Waypoint > last {
+			ai.Waypoint = 0
+		}
+	case PatrolPingPong:
+		if ai.RouteDir == 0 {
+			ai.RouteDir = 1
+		}
+		if ai.Waypoint+ai.RouteDir < 0 || ai.Waypoint+ai.RouteDir > last {
+			ai.RouteDir = -ai.RouteDir
+		}
+		ai.Waypoint = max(0, min(last, ai.Waypoint+ai.RouteDir))
+	case PatrolOnce:
+		if ai.Waypoint == last {
+			ai.RouteDone = true
+		} else {
+			ai.Waypoint++
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextWaypoint_Modes(t *testing.T) {
+	tests := []struct {
+		mode PatrolMode
+		want []int
+	}{
+		{PatrolLoop, []int{1, 2, 0, 1}},
+		{PatrolPingPong, []int{1, 2, 1, 0, 1}},
+		{PatrolOnce, []int{1, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			ai := AI{Route: PatrolRoute{Points: make([]Waypoint, 3), Mode: tt.mode}}
+			var got []int
+			for range tt.want {
+				nextWaypoint(&ai)
+				got = append(got, ai.Waypoint)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.mode == PatrolOnce, ai.RouteDone)
+		})
+	}
+}
+
+func TestParsePatrolMode(t *testing.T) {
+	for _, mode := range []PatrolMode{PatrolLoop, PatrolPingPong, PatrolOnce} {
+		parsed, ok := ParsePatrolMode(mode.String())
+		assert.True(t, ok)
+		assert.Equal(t, mode, parsed)
+	}
+	_, ok := ParsePatrolMode("zigzag")
+	assert.False(t, ok)
+}
+
+func TestPatrolRoute_FlyerVisitsWaypoints(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{
+		MaxHealth: 10, AIType: AIPatrol, MoveSpeed: 100, Flying: true, HitboxWidth: 16, HitboxHeight: 16,
+		Route: PatrolRoute{Points: []Waypoint{{140, 100}, {140, 60}}, Mode: PatrolOnce},
+	}, false)
+
+	for range 60 {
+		stepFrame(w, stage)
+	}
+
+	assert.True(t, w.AI[enemy].RouteDone)
+	assert.InDelta(t, 140, w.Position[enemy].PixelX(), waypointReach)
+	assert.InDelta(t, 60, w.Position[enemy].PixelY(), waypointReach)
+}
+
+func TestPatrolRoute_WalkerJumpsUpStep(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	for tx := range 40 {
+		stage.setSolid(tx, 10) // floor at y=160
+	}
+	for tx := 8; tx <= 10; tx++ {
+		stage.setSolid(tx, 9) // step up to y=144, x 128..175
+	}
+	enemy := w.CreateEnemy(32, 144, EnemyConfig{
+		MaxHealth: 10, AIType: AIPatrol, MoveSpeed: 40, JumpForce: 120, HitboxWidth: 16, HitboxHeight: 16,
+		Route: PatrolRoute{Points: []Waypoint{{144, 128}}, Mode: PatrolOnce},
+	}, true)
+
+	for range 300 {
+		ApplyEnemyGravity(w, stage, 5, 100)
+		stepFrame(w, stage)
+	}
+
+	assert.True(t, w.AI[enemy].RouteDone)
+	assert.Equal(t, 128, w.Position[enemy].PixelY(), "standing on the step")
+}
+
+func TestUpdatePatrolAI_TurnsAtLedge(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	for tx := 2; tx <= 5; tx++ {
+		stage.setSolid(tx, 10) // platform x 32..95, top y=160
+	}
+	hb := Hitbox{Width: 16, Height: 16}
+
+	for _, turn := range []bool{true, false} {
+		pos := Position{X: 80 * PositionScale, Y: 144 * PositionScale} // right edge at the platform's end
+		ai := AI{MoveSpeed: 64, PatrolDir: 1, PatrolStartX: 40, PatrolDistance: 100, TurnAtLedges: turn}
+		facing := Facing{Right: true}
+		mov := Movement{OnGround: true}
+		vel := Velocity{}
+
+		updatePatrolAI(stage, hb, &pos, &vel, &ai, &facing, &mov)
+
+		if turn {
+			assert.Equal(t, -1, ai.PatrolDir)
+			assert.False(t, facing.Right)
+			assert.Less(t, pos.X, 80*PositionScale)
+		} else {
+			assert.Equal(t, 1, ai.PatrolDir, "walks off without the flag")
+			assert.Greater(t, pos.X, 80*PositionScale)
+		}
+	}
+}
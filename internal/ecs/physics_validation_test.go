@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 )
 
 // mockStage implements Stage interface for testing
@@ -26,61 +28,26 @@ func (s *mockStage) setSolid(tileX, tileY int) {
 }
 
 func (s *mockStage) IsSolidAt(px, py int) bool {
-	tx := px / s.tileSize
-	ty := py / s.tileSize
+	tx := floorDiv(px, s.tileSize)
+	ty := floorDiv(py, s.tileSize)
 	return s.solidTiles[[2]int{tx, ty}]
 }
 
-func (s *mockStage) GetTileType(px, py int) int   { return TileEmpty }
-func (s *mockStage) GetTileDamage(px, py int) int { return 0 }
-func (s *mockStage) GetWidth() int                { return s.width }
-func (s *mockStage) GetHeight() int               { return s.height }
-func (s *mockStage) GetTileSize() int             { return s.tileSize }
-func (s *mockStage) GetSpawnX() int               { return 0 }
-func (s *mockStage) GetSpawnY() int               { return 0 }
-
-// =============================================================================
-// Conversion Function Tests
-// =============================================================================
-
-func TestToIUPerSubstep(t *testing.T) {
-	tests := []struct {
-		name         string
-		pixelsPerSec float64
-		expectedIU   int
-	}{
-		{"60 pixels/sec", 60, 25},    // 60 * 256 / 600 = 25.6 ≈ 25
-		{"120 pixels/sec", 120, 51},  // 120 * 256 / 600 = 51.2 ≈ 51
-		{"300 pixels/sec", 300, 128}, // 300 * 256 / 600 = 128
-		{"600 pixels/sec", 600, 256}, // 600 * 256 / 600 = 256 (1 pixel/substep)
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := ToIUPerSubstep(tt.pixelsPerSec)
-			assert.Equal(t, tt.expectedIU, result)
-		})
-	}
+func (s *mockStage) SetTileFallen(tx, ty int, fallen bool) {
+	s.solidTiles[[2]int{tx, ty}] = !fallen
 }
 
-func TestToIUAccelPerFrame(t *testing.T) {
-	tests := []struct {
-		name           string
-		pixelsPerSecSq float64
-		expectedIU     int
-	}{
-		{"800 pixels/sec² (gravity)", 800, 5},   // 800 * 256 / 36000 = 5.68 ≈ 5
-		{"2000 pixels/sec² (accel)", 2000, 14},  // 2000 * 256 / 36000 = 14.2 ≈ 14
-		{"400 pixels/sec² (gold)", 400, 2},      // 400 * 256 / 36000 = 2.84 ≈ 2
-		{"3600 pixels/sec²", 3600, 25},          // 3600 * 256 / 36000 = 25.6 ≈ 25
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := ToIUAccelPerFrame(tt.pixelsPerSecSq)
-			assert.Equal(t, tt.expectedIU, result)
-		})
-	}
+func (s *mockStage) GetTileType(px, py int) int    { return TileEmpty }
+func (s *mockStage) GetTileDamage(px, py int) int  { return 0 }
+func (s *mockStage) GetSurface(px, py int) Surface { return Surface{} }
+func (s *mockStage) GetWidth() int                 { return s.width }
+func (s *mockStage) GetHeight() int                { return s.height }
+func (s *mockStage) GetTileSize() int              { return s.tileSize }
+func (s *mockStage) GetSpawnX() int                { return 0 }
+func (s *mockStage) GetSpawnY() int                { return 0 }
+
+func (s *mockStage) Raycast(x0, y0, x1, y1 int) (RayHit, bool) {
+	return RaycastTiles(s, x0, y0, x1, y1)
 }
 
 // =============================================================================
@@ -104,7 +71,7 @@ func TestPlayerMovement_OneSecond(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(500, 500, hitbox, 100)
+	world.CreatePlayer(500, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Set player on ground to avoid gravity affecting horizontal movement
 	mov := world.Movement[world.PlayerID]
@@ -112,13 +79,13 @@ func TestPlayerMovement_OneSecond(t *testing.T) {
 	world.Movement[world.PlayerID] = mov
 
 	cfg := PhysicsConfig{
-		MaxSpeed:        ToIUPerSubstep(targetSpeedPixels),
-		Acceleration:    ToIUAccelPerFrame(10000), // Very high for instant accel
-		Deceleration:    ToIUAccelPerFrame(10000),
+		MaxSpeed:        fixedpoint.ToIUPerSubstep(targetSpeedPixels),
+		Acceleration:    fixedpoint.ToIUAccelPerFrame(10000), // Very high for instant accel
+		Deceleration:    fixedpoint.ToIUAccelPerFrame(10000),
 		AirControlPct:   100,
 		TurnaroundPct:   100,
-		Gravity:         ToIUAccelPerFrame(800),
-		MaxFallSpeed:    ToIUPerSubstep(400),
+		Gravity:         fixedpoint.ToIUAccelPerFrame(800),
+		MaxFallSpeed:    fixedpoint.ToIUPerSubstep(400),
 	}
 
 	startPos := world.Position[world.PlayerID]
@@ -172,12 +139,12 @@ func TestPlayerGravity_OneSecond(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(500, 100, hitbox, 100)
+	world.CreatePlayer(500, 100, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	cfg := PhysicsConfig{
-		Gravity:           ToIUAccelPerFrame(gravityPixelsSec),
-		MaxFallSpeed:      ToIUPerSubstep(10000), // Very high to not clamp
-		MaxSpeed:          ToIUPerSubstep(120),
+		Gravity:           fixedpoint.ToIUAccelPerFrame(gravityPixelsSec),
+		MaxFallSpeed:      fixedpoint.ToIUPerSubstep(10000), // Very high to not clamp
+		MaxSpeed:          fixedpoint.ToIUPerSubstep(120),
 		FallMultiplierPct: 100, // Normal fall
 		ApexModEnabled:    false,
 	}
@@ -229,7 +196,7 @@ func TestEnemyMovement_OneSecond(t *testing.T) {
 	startX := 500
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
-		MoveSpeed:     ToIUPerSubstep(moveSpeedPixels),
+		MoveSpeed:     fixedpoint.ToIUPerSubstep(moveSpeedPixels),
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 4,
 		HitboxWidth:   12,
@@ -257,7 +224,7 @@ func TestEnemyMovement_OneSecond(t *testing.T) {
 
 	// Simulate 1 second
 	for frame := 0; frame < framesPerSecond; frame++ {
-		ApplyEnemyGravity(world, stage, ToIUAccelPerFrame(800), ToIUPerSubstep(400))
+		ApplyEnemyGravity(world, stage, fixedpoint.ToIUAccelPerFrame(800), fixedpoint.ToIUPerSubstep(400))
 
 		for sub := 0; sub < subStepsPerFrame; sub++ {
 			UpdateEnemyAI(world, stage, arrowCfg, PhysicsConfig{})
@@ -308,8 +275,8 @@ func TestEnemyGravity_OneSecond(t *testing.T) {
 	mov.OnGround = false
 	world.Movement[enemyID] = mov
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
-	maxFall := ToIUPerSubstep(10000) // Very high
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
+	maxFall := fixedpoint.ToIUPerSubstep(10000) // Very high
 
 	arrowCfg := ProjectileConfig{}
 
@@ -357,7 +324,7 @@ func TestProjectileMovement_OneSecond(t *testing.T) {
 	// Create a horizontal projectile (no gravity for this test)
 	projCfg := ProjectileConfig{
 		GravityAccel:  0, // No gravity
-		MaxFallSpeed:  ToIUPerSubstep(300),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(300),
 		MaxRange:      10000,
 		Damage:        10,
 		HitboxOffsetX: 0,
@@ -367,9 +334,9 @@ func TestProjectileMovement_OneSecond(t *testing.T) {
 		StuckDuration: 300,
 	}
 
-	vx := ToIUPerSubstep(speedPixels)
+	vx := fixedpoint.ToIUPerSubstep(speedPixels)
 	vy := 0
-	projID := world.CreateProjectile(100, 500, vx, vy, projCfg, true)
+	projID := world.CreateProjectile(100, 500, vx, vy, projCfg, FactionPlayer)
 
 	startPos := world.Position[projID]
 	startPixelX := startPos.PixelX()
@@ -411,8 +378,8 @@ func TestProjectileGravity_OneSecond(t *testing.T) {
 	world := NewWorld()
 
 	projCfg := ProjectileConfig{
-		GravityAccel:  ToIUAccelPerFrame(gravityPixelsSec),
-		MaxFallSpeed:  ToIUPerSubstep(10000), // Very high
+		GravityAccel:  fixedpoint.ToIUAccelPerFrame(gravityPixelsSec),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(10000), // Very high
 		MaxRange:      10000,
 		Damage:        10,
 		HitboxOffsetX: 0,
@@ -423,9 +390,9 @@ func TestProjectileGravity_OneSecond(t *testing.T) {
 	}
 
 	// Horizontal shot, gravity will pull down
-	vx := ToIUPerSubstep(100)
+	vx := fixedpoint.ToIUPerSubstep(100)
 	vy := 0
-	projID := world.CreateProjectile(100, 100, vx, vy, projCfg, true)
+	projID := world.CreateProjectile(100, 100, vx, vy, projCfg, FactionPlayer)
 
 	startPos := world.Position[projID]
 	startPixelY := startPos.PixelY()
@@ -444,7 +411,7 @@ func TestProjectileGravity_OneSecond(t *testing.T) {
 	distanceFallen := endPixelY - startPixelY
 
 	// Note: Due to integer truncation in ToIUAccelPerFrame
-	actualGravity := float64(ToIUAccelPerFrame(gravityPixelsSec)) * 36000.0 / float64(PositionScale)
+	actualGravity := float64(fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)) * 36000.0 / float64(PositionScale)
 	expectedDistance := int(0.5 * actualGravity * 1.0 * 1.0)
 
 	t.Logf("Projectile fell %d pixels in 1 second (expected ~%d with actual gravity %.1f pixels/sec²)",
@@ -471,8 +438,8 @@ func TestGoldGravity_OneSecond(t *testing.T) {
 	stage := newMockStage(100, 1000, 16)
 	world := NewWorld()
 
-	goldCfg := GoldConfig{
-		Gravity:       ToIUAccelPerFrame(gravityPixelsSec),
+	goldCfg := PickupConfig{
+		Gravity:       fixedpoint.ToIUAccelPerFrame(gravityPixelsSec),
 		BouncePercent: 0, // No bounce
 		CollectDelay:  0,
 		HitboxWidth:   8,
@@ -490,10 +457,10 @@ func TestGoldGravity_OneSecond(t *testing.T) {
 
 	// Simulate 1 second
 	for frame := 0; frame < framesPerSecond; frame++ {
-		ApplyGoldGravity(world)
+		ApplyPickupGravity(world)
 
 		for sub := 0; sub < subStepsPerFrame; sub++ {
-			UpdateGoldPhysics(world, stage)
+			UpdatePickupPhysics(world, stage)
 		}
 	}
 
@@ -501,10 +468,10 @@ func TestGoldGravity_OneSecond(t *testing.T) {
 	endPixelY := endPos.PixelY()
 	distanceFallen := endPixelY - startPixelY
 
-	// Note: Due to integer truncation in ToIUAccelPerFrame:
-	// ToIUAccelPerFrame(400) = 2 IU/frame (actual: 281 pixels/sec²)
-	// Expected: 0.5 * 281 * 1² = 140 pixels
-	actualGravity := float64(ToIUAccelPerFrame(gravityPixelsSec)) * 36000.0 / float64(PositionScale)
+	// Note: Due to integer rounding in ToIUAccelPerFrame:
+	// fixedpoint.ToIUAccelPerFrame(400) = 3 IU/frame (actual: 422 pixels/sec²)
+	// Expected: 0.5 * 422 * 1² = 211 pixels
+	actualGravity := float64(fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)) * 36000.0 / float64(PositionScale)
 	expectedDistance := int(0.5 * actualGravity * 1.0 * 1.0)
 
 	t.Logf("Gold fell %d pixels in 1 second (expected ~%d with actual gravity %.1f pixels/sec²)",
@@ -537,12 +504,12 @@ func TestFinalVelocity_AfterOneSecondGravity(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(500, 100, hitbox, 100)
+	world.CreatePlayer(500, 100, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
-	gravityIU := ToIUAccelPerFrame(gravityPixelsSec)
+	gravityIU := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
 	cfg := PhysicsConfig{
 		Gravity:           gravityIU,
-		MaxFallSpeed:      ToIUPerSubstep(10000),
+		MaxFallSpeed:      fixedpoint.ToIUPerSubstep(10000),
 		FallMultiplierPct: 100,
 		ApexModEnabled:    false,
 	}
@@ -582,7 +549,7 @@ func TestPrintPhysicsValues(t *testing.T) {
 	t.Log("=== Velocity Conversions (ToIUPerSubstep) ===")
 	velocities := []float64{60, 120, 280, 300, 400, 600}
 	for _, v := range velocities {
-		iu := ToIUPerSubstep(v)
+		iu := fixedpoint.ToIUPerSubstep(v)
 		// Back-calculate: what's the actual pixels/sec?
 		// IU/substep * 600 / 256 = pixels/sec
 		actual := float64(iu) * 600.0 / float64(PositionScale)
@@ -593,7 +560,7 @@ func TestPrintPhysicsValues(t *testing.T) {
 	t.Log("=== Acceleration Conversions (ToIUAccelPerFrame) ===")
 	accels := []float64{400, 800, 2000, 2500}
 	for _, a := range accels {
-		iu := ToIUAccelPerFrame(a)
+		iu := fixedpoint.ToIUAccelPerFrame(a)
 		// Back-calculate: IU/frame * 36000 / 256 = pixels/sec²
 		actual := float64(iu) * 36000.0 / float64(PositionScale)
 		t.Logf("  %.0f pixels/sec² → %d IU/frame (actual: %.1f pixels/sec²)", a, iu, actual)
@@ -620,10 +587,10 @@ func TestGoldGravity_Debug(t *testing.T) {
 	stage := newMockStage(100, 1000, 16)
 	world := NewWorld()
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
 	t.Logf("Gravity: %d IU/frame (from %.0f pixels/sec²)", gravity, gravityPixelsSec)
 
-	goldCfg := GoldConfig{
+	goldCfg := PickupConfig{
 		Gravity:       gravity,
 		BouncePercent: 0,
 		CollectDelay:  0,
@@ -642,12 +609,12 @@ func TestGoldGravity_Debug(t *testing.T) {
 		velBefore := world.Velocity[goldID]
 		posBefore := world.Position[goldID]
 
-		ApplyGoldGravity(world)
+		ApplyPickupGravity(world)
 
 		velAfterGravity := world.Velocity[goldID]
 
 		for sub := 0; sub < subStepsPerFrame; sub++ {
-			UpdateGoldPhysics(world, stage)
+			UpdatePickupPhysics(world, stage)
 		}
 
 		posAfter := world.Position[goldID]
@@ -675,12 +642,12 @@ func TestProjectileGravity_Debug(t *testing.T) {
 	stage := newMockStage(1000, 1000, 16)
 	world := NewWorld()
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
 	t.Logf("Gravity: %d IU/frame (from %.0f pixels/sec²)", gravity, gravityPixelsSec)
 
 	projCfg := ProjectileConfig{
 		GravityAccel:  gravity,
-		MaxFallSpeed:  ToIUPerSubstep(10000),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(10000),
 		MaxRange:      10000,
 		Damage:        10,
 		HitboxOffsetX: 0,
@@ -690,7 +657,7 @@ func TestProjectileGravity_Debug(t *testing.T) {
 		StuckDuration: 300,
 	}
 
-	projID := world.CreateProjectile(100, 100, 0, 0, projCfg, true)
+	projID := world.CreateProjectile(100, 100, 0, 0, projCfg, FactionPlayer)
 
 	startPos := world.Position[projID]
 	t.Logf("Start position: %d IU (%d pixels)", startPos.Y, startPos.PixelY())
@@ -753,12 +720,12 @@ func TestEnemySpawnInAir_ShouldFall(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Create enemy in mid-air
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
-		MoveSpeed:     ToIUPerSubstep(60),
+		MoveSpeed:     fixedpoint.ToIUPerSubstep(60),
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 4,
 		HitboxWidth:   12,
@@ -776,8 +743,8 @@ func TestEnemySpawnInAir_ShouldFall(t *testing.T) {
 	assert.False(t, mov.OnGround, "Enemy should start with OnGround=false")
 	assert.Equal(t, 0, vel.Y, "Enemy should start with zero Y velocity")
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
-	maxFall := ToIUPerSubstep(400)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
+	maxFall := fixedpoint.ToIUPerSubstep(400)
 	arrowCfg := ProjectileConfig{}
 
 	startPos := world.Position[enemyID]
@@ -835,7 +802,7 @@ func TestEnemySpawnOnGround_ThenWalkOffEdge(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Spawn enemy on the platform (tile 31 = pixel 496, y=9*16=144)
 	// Enemy hitbox: OffsetY=4, Height=20, so feet end at y+4+20=y+24
@@ -845,7 +812,7 @@ func TestEnemySpawnOnGround_ThenWalkOffEdge(t *testing.T) {
 
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
-		MoveSpeed:     ToIUPerSubstep(60),
+		MoveSpeed:     fixedpoint.ToIUPerSubstep(60),
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 4,
 		HitboxWidth:   12,
@@ -862,8 +829,8 @@ func TestEnemySpawnOnGround_ThenWalkOffEdge(t *testing.T) {
 	ai.PatrolStartX = enemyX + 50
 	world.AI[enemyID] = ai
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
-	maxFall := ToIUPerSubstep(400)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
+	maxFall := fixedpoint.ToIUPerSubstep(400)
 	arrowCfg := ProjectileConfig{}
 
 	t.Logf("=== Enemy Walk Off Edge Test ===")
@@ -922,12 +889,12 @@ func TestEnemyStartsWithOnGroundTrue(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Create enemy in mid-air
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
-		MoveSpeed:     ToIUPerSubstep(60),
+		MoveSpeed:     fixedpoint.ToIUPerSubstep(60),
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 4,
 		HitboxWidth:   12,
@@ -942,8 +909,8 @@ func TestEnemyStartsWithOnGroundTrue(t *testing.T) {
 	mov.OnGround = true // This might be what's happening in real game
 	world.Movement[enemyID] = mov
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
-	maxFall := ToIUPerSubstep(400)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
+	maxFall := fixedpoint.ToIUPerSubstep(400)
 	arrowCfg := ProjectileConfig{}
 
 	startPos := world.Position[enemyID]
@@ -1005,7 +972,7 @@ func TestEnemyGroundCheckMismatch(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Enemy hitbox: OffsetX=2, OffsetY=4, Width=12, Height=20
 	// Feet end at: y + 4 + 20 = y + 24
@@ -1040,7 +1007,7 @@ func TestEnemyGroundCheckMismatch(t *testing.T) {
 	for _, tc := range testPositions {
 		t.Run(tc.name, func(t *testing.T) {
 			world := NewWorld()
-			world.CreatePlayer(100, 500, hitbox, 100)
+			world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 			enemyID := world.CreateEnemy(tc.x, tc.y, enemyCfg, true)
 
 			// Force OnGround = true to test if ApplyEnemyGravity corrects it
@@ -1101,12 +1068,12 @@ func TestEnemySpawnInAir_Debug(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Create enemy in mid-air
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
-		MoveSpeed:     ToIUPerSubstep(60),
+		MoveSpeed:     fixedpoint.ToIUPerSubstep(60),
 		HitboxOffsetX: 2,
 		HitboxOffsetY: 4,
 		HitboxWidth:   12,
@@ -1116,8 +1083,8 @@ func TestEnemySpawnInAir_Debug(t *testing.T) {
 	}
 	enemyID := world.CreateEnemy(500, 100, enemyCfg, true)
 
-	gravity := ToIUAccelPerFrame(gravityPixelsSec)
-	maxFall := ToIUPerSubstep(400)
+	gravity := fixedpoint.ToIUAccelPerFrame(gravityPixelsSec)
+	maxFall := fixedpoint.ToIUPerSubstep(400)
 	arrowCfg := ProjectileConfig{}
 
 	t.Logf("=== Enemy Spawn In Air Debug ===")
@@ -1170,11 +1137,11 @@ func TestSingleFrameMovement(t *testing.T) {
 			Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 			Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 		}
-		world.CreatePlayer(500, 500, hitbox, 100)
+		world.CreatePlayer(500, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 		// Set velocity directly to max speed
 		vel := world.Velocity[world.PlayerID]
-		vel.X = ToIUPerSubstep(maxSpeedPixels)
+		vel.X = fixedpoint.ToIUPerSubstep(maxSpeedPixels)
 		world.Velocity[world.PlayerID] = vel
 
 		mov := world.Movement[world.PlayerID]
@@ -1182,9 +1149,9 @@ func TestSingleFrameMovement(t *testing.T) {
 		world.Movement[world.PlayerID] = mov
 
 		cfg := PhysicsConfig{
-			MaxSpeed:     ToIUPerSubstep(maxSpeedPixels),
-			MaxFallSpeed: ToIUPerSubstep(400),
-			Gravity:      ToIUAccelPerFrame(800),
+			MaxSpeed:     fixedpoint.ToIUPerSubstep(maxSpeedPixels),
+			MaxFallSpeed: fixedpoint.ToIUPerSubstep(400),
+			Gravity:      fixedpoint.ToIUAccelPerFrame(800),
 		}
 
 		startPos := world.Position[world.PlayerID]
@@ -1215,14 +1182,14 @@ func TestSingleFrameMovement(t *testing.T) {
 
 		projCfg := ProjectileConfig{
 			GravityAccel:  0,
-			MaxFallSpeed:  ToIUPerSubstep(300),
+			MaxFallSpeed:  fixedpoint.ToIUPerSubstep(300),
 			MaxRange:      10000,
 			Damage:        10,
 			StuckDuration: 300,
 		}
 
-		vx := ToIUPerSubstep(speedPixels)
-		projID := world.CreateProjectile(100, 500, vx, 0, projCfg, true)
+		vx := fixedpoint.ToIUPerSubstep(speedPixels)
+		projID := world.CreateProjectile(100, 500, vx, 0, projCfg, FactionPlayer)
 
 		startPos := world.Position[projID]
 
@@ -1264,7 +1231,7 @@ func TestEnemyKnockback_XMovement(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Create enemy
 	enemyCfg := EnemyConfig{
@@ -1282,24 +1249,19 @@ func TestEnemyKnockback_XMovement(t *testing.T) {
 	startPos := world.Position[enemyID]
 	startPixelX := startPos.PixelX()
 
-	// Simulate knockback: set velocity, HitTimer, and initial knockback values
+	// Simulate knockback: set velocity and knockback timer
 	vel := world.Velocity[enemyID]
 	vel.X = knockbackForce // Push right
 	world.Velocity[enemyID] = vel
 
-	ai := world.AI[enemyID]
-	ai.HitTimer = 12    // Stun frames
-	ai.HitTimerMax = 12 // Initial value for decay calculation
-	ai.KnockbackVelX = knockbackForce
-	ai.KnockbackVelY = 0
-	world.AI[enemyID] = ai
+	Knockback(world, enemyID, knockbackForce, 0, 12)
 
 	cfg := PhysicsConfig{}
 	arrowCfg := ProjectileConfig{}
 
 	t.Logf("=== Enemy Knockback X Movement Test ===")
-	t.Logf("Start: pos=(%d, %d), VelX=%d, HitTimer=%d",
-		startPixelX, startPos.PixelY(), knockbackForce, ai.HitTimer)
+	t.Logf("Start: pos=(%d, %d), VelX=%d, KnockbackTimer=%d",
+		startPixelX, startPos.PixelY(), knockbackForce, world.CrowdControl[enemyID].KnockbackTimer)
 
 	// Simulate 10 frames
 	for frame := 0; frame < 10; frame++ {
@@ -1315,13 +1277,13 @@ func TestEnemyKnockback_XMovement(t *testing.T) {
 
 		posAfter := world.Position[enemyID]
 		velAfter := world.Velocity[enemyID]
-		aiAfter := world.AI[enemyID]
+		ccAfter := world.CrowdControl[enemyID]
 
 		movedX := posAfter.X - posBefore.X
 		movedPixels := float64(movedX) / float64(PositionScale)
 
-		t.Logf("Frame %d: VelX=%d→%d, Moved=%.2f px, HitTimer=%d",
-			frame, velBefore.X, velAfter.X, movedPixels, aiAfter.HitTimer)
+		t.Logf("Frame %d: VelX=%d→%d, Moved=%.2f px, KnockbackTimer=%d",
+			frame, velBefore.X, velAfter.X, movedPixels, ccAfter.KnockbackTimer)
 	}
 
 	endPos := world.Position[enemyID]
@@ -1335,7 +1297,7 @@ func TestEnemyKnockback_XMovement(t *testing.T) {
 		"Enemy should move at least 10 pixels from knockback, moved %d", totalMoved)
 }
 
-// TestEnemyKnockback_ProportionalDeceleration verifies velocity decreases proportionally to HitTimer
+// TestEnemyKnockback_ProportionalDeceleration verifies velocity decreases proportionally to the knockback timer
 func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 	stage := newMockStage(100, 100, 16)
 	world := NewWorld()
@@ -1345,7 +1307,7 @@ func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	enemyCfg := EnemyConfig{
 		MaxHealth:     100,
@@ -1361,18 +1323,13 @@ func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 
 	// Set initial knockback velocity
 	initialVelX := 100
-	hitTimerMax := 10
+	knockbackFrames := 10
 
 	vel := world.Velocity[enemyID]
 	vel.X = initialVelX
 	world.Velocity[enemyID] = vel
 
-	ai := world.AI[enemyID]
-	ai.HitTimer = hitTimerMax
-	ai.HitTimerMax = hitTimerMax
-	ai.KnockbackVelX = initialVelX
-	ai.KnockbackVelY = 0
-	world.AI[enemyID] = ai
+	Knockback(world, enemyID, initialVelX, 0, knockbackFrames)
 
 	cfg := PhysicsConfig{}
 	arrowCfg := ProjectileConfig{}
@@ -1380,7 +1337,7 @@ func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 	velocities := []int{}
 
 	// Record velocity each frame
-	for frame := 0; frame < hitTimerMax+1; frame++ {
+	for frame := 0; frame < knockbackFrames+1; frame++ {
 		vel := world.Velocity[enemyID]
 		velocities = append(velocities, vel.X)
 
@@ -1392,11 +1349,11 @@ func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 		}
 	}
 
-	t.Logf("Velocities over %d frames: %v", hitTimerMax+1, velocities)
+	t.Logf("Velocities over %d frames: %v", knockbackFrames+1, velocities)
 
 	// Verify proportional deceleration: vel = initialVel * remainingTimer / maxTimer
-	// After UpdateTimers in frame 0: HitTimer becomes 9, so vel = 100 * 9/10 = 90
-	// After UpdateTimers in frame 1: HitTimer becomes 8, so vel = 100 * 8/10 = 80
+	// After UpdateTimers in frame 0: KnockbackTimer becomes 9, so vel = 100 * 9/10 = 90
+	// After UpdateTimers in frame 1: KnockbackTimer becomes 8, so vel = 100 * 8/10 = 80
 	// ...
 	expectedVelocities := []int{100, 90, 80, 70, 60, 50, 40, 30, 20, 10, 0}
 	for i, expected := range expectedVelocities {
@@ -1408,7 +1365,7 @@ func TestEnemyKnockback_ProportionalDeceleration(t *testing.T) {
 
 	// Final velocity should be 0
 	finalVel := world.Velocity[enemyID]
-	assert.Equal(t, 0, finalVel.X, "Velocity should reach 0 when HitTimer reaches 0")
+	assert.Equal(t, 0, finalVel.X, "Velocity should reach 0 when KnockbackTimer reaches 0")
 }
 
 // TestEnemyKnockback_StopsAtWall verifies knockback stops when hitting wall
@@ -1424,7 +1381,7 @@ func TestEnemyKnockback_StopsAtWall(t *testing.T) {
 		Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
 		Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
 	}
-	world.CreatePlayer(100, 500, hitbox, 100)
+	world.CreatePlayer(100, 500, PlayerProfile{Hitbox: hitbox, MaxHealth: 100})
 
 	// Place enemy close to wall (will be pushed into it)
 	enemyCfg := EnemyConfig{
@@ -1444,12 +1401,7 @@ func TestEnemyKnockback_StopsAtWall(t *testing.T) {
 	vel.X = 200
 	world.Velocity[enemyID] = vel
 
-	ai := world.AI[enemyID]
-	ai.HitTimer = 20
-	ai.HitTimerMax = 20
-	ai.KnockbackVelX = 200
-	ai.KnockbackVelY = 0
-	world.AI[enemyID] = ai
+	Knockback(world, enemyID, 200, 0, 20)
 
 	cfg := PhysicsConfig{}
 	arrowCfg := ProjectileConfig{}
@@ -1471,3 +1423,11 @@ func TestEnemyKnockback_StopsAtWall(t *testing.T) {
 	assert.Less(t, endPos.PixelX(), 528-12, // Wall at 528, enemy width ~12
 		"Enemy should stop before wall")
 }
+
+func TestIsSolidRect_AboveTheStage(t *testing.T) {
+	stage := newMockStage(160, 80, 16)
+	stage.setSolid(2, -1) // the boundary above column 2
+
+	assert.True(t, isSolidRect(stage, 32, -3, 12, 12), "pixels above the stage are in row -1, not row 0")
+	assert.False(t, isSolidRect(stage, 32, 0, 12, 12))
+}
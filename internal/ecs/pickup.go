@@ -0,0 +1,68 @@
+package ecs
+
+// MagnetConfig holds the player's pickup magnet tuning.
+// Values are pre-converted to IU (like PhysicsConfig).
+type MagnetConfig struct {
+	Radius []int // pixels per upgrade tier (empty = pickups use their own radius)
+	Accel  int   // IU/substep gained per frame (0 = full speed at once)
+}
+
+// radiusAt returns the magnet radius of an upgrade tier, clamped to the last tier
+func (cfg MagnetConfig) radiusAt(level int) int {
+	if len(cfg.Radius) == 0 {
+		return 0
+	}
+	return cfg.Radius[max(0, min(level, len(cfg.Radius)-1))]
+}
+
+// UpdatePickupMagnet steers collectible pickups toward the player
+// (call once per frame, like gravity). A pickup is attracted once it is
+// within its own MagnetRadius or the player's magnet radius, whichever is
+// larger; it then accelerates by MagnetConfig.Accel up to its MagnetSpeed,
+// ignoring gravity and walls, until collected.
+func UpdatePickupMagnet(w *World) {
+	playerID := w.PlayerID
+	if playerID == 0 || !w.Exists(playerID) {
+		return
+	}
+	px, py := HitboxCenter(w, playerID)
+	playerRadius := w.Magnet.radiusAt(w.PlayerData[playerID].MagnetLevel)
+
+	for _, id := range w.Query(TagPickup) {
+		pickup := w.PickupData[id]
+		radius := max(pickup.MagnetRadius, playerRadius)
+		if radius <= 0 || pickup.MagnetSpeed <= 0 || pickup.CollectDelay > 0 {
+			continue
+		}
+
+		pos := w.Position[id]
+		dx := px - (pos.PixelX() + pickup.HitboxWidth/2)
+		dy := py - (pos.PixelY() + pickup.HitboxHeight/2)
+		distSq := dx*dx + dy*dy
+		if !pickup.Attracted && distSq > radius*radius {
+			continue
+		}
+
+		if !pickup.Attracted {
+			pickup.Attracted = true
+			pickup.Grounded = false
+			w.PickupData[id] = pickup
+		}
+
+		dist := isqrt(distSq)
+		if dist == 0 {
+			continue
+		}
+		desiredX := divRound(dx*pickup.MagnetSpeed, dist)
+		desiredY := divRound(dy*pickup.MagnetSpeed, dist)
+
+		vel := w.Velocity[id]
+		if accel := w.Magnet.Accel; accel > 0 {
+			vel.X += clampInt(desiredX-vel.X, -accel, accel)
+			vel.Y += clampInt(desiredY-vel.Y, -accel, accel)
+		} else {
+			vel = Velocity{X: desiredX, Y: desiredY}
+		}
+		w.Velocity[id] = vel
+	}
+}
@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMagnetWorld() *World {
+	w := NewWorld()
+	body := Hitbox{Width: 16, Height: 16}
+	w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	return w
+}
+
+var magnetCfg = PickupConfig{HitboxWidth: 8, HitboxHeight: 8, CollectRadius: 8, MagnetRadius: 40, MagnetSpeed: 50}
+
+func TestUpdatePickupMagnet_PullsPickupInRange(t *testing.T) {
+	w := newMagnetWorld()
+	id := w.PlacePickup(134, 104, PickupHealth, 25, magnetCfg) // 30px right of the player center
+
+	UpdatePickupMagnet(w)
+
+	assert.True(t, w.PickupData[id].Attracted)
+	assert.Equal(t, Velocity{X: -50, Y: 0}, w.Velocity[id])
+
+	// Flies straight in, ignoring gravity, and is collected
+	for i := 0; i < 20 && w.Exists(id); i++ {
+		UpdatePickupMagnet(w)
+		ApplyPickupGravity(w)
+		for s := 0; s < 10; s++ {
+			UpdatePickupPhysics(w, newMockStage(40, 40, 16))
+		}
+		CollectPickups(w)
+	}
+	assert.False(t, w.Exists(id))
+}
+
+func TestUpdatePickupMagnet_IgnoresDistantAndDelayedPickups(t *testing.T) {
+	w := newMagnetWorld()
+	far := w.PlacePickup(200, 104, PickupAmmo, 5, magnetCfg)
+	delayedCfg := magnetCfg
+	delayedCfg.CollectDelay = 10
+	delayed := w.PlacePickup(120, 104, PickupGold, 5, delayedCfg)
+	noMagnetCfg := magnetCfg
+	noMagnetCfg.MagnetRadius = 0
+	plain := w.PlacePickup(120, 104, PickupGold, 5, noMagnetCfg)
+
+	UpdatePickupMagnet(w)
+
+	assert.False(t, w.PickupData[far].Attracted)
+	assert.False(t, w.PickupData[delayed].Attracted, "not before it can be collected")
+	assert.False(t, w.PickupData[plain].Attracted)
+	assert.Equal(t, Velocity{}, w.Velocity[far], "placed pickups start at rest")
+}
+
+func TestUpdatePickupMagnet_AcceleratesToMagnetSpeed(t *testing.T) {
+	w := newMagnetWorld()
+	w.Magnet = MagnetConfig{Accel: 20}
+	grounded := magnetCfg
+	id := w.PlacePickup(134, 104, PickupGold, 5, grounded)
+	pickup := w.PickupData[id]
+	pickup.Grounded = true
+	w.PickupData[id] = pickup
+
+	UpdatePickupMagnet(w)
+	assert.False(t, w.PickupData[id].Grounded, "lifted off the ground")
+	assert.Equal(t, Velocity{X: -20, Y: 0}, w.Velocity[id])
+
+	UpdatePickupMagnet(w)
+	UpdatePickupMagnet(w)
+	assert.Equal(t, Velocity{X: -50, Y: 0}, w.Velocity[id], "capped at MagnetSpeed")
+}
+
+func TestUpdatePickupMagnet_PlayerRadiusByTier(t *testing.T) {
+	w := newMagnetWorld()
+	w.Magnet = MagnetConfig{Radius: []int{20, 80}}
+	cfg := magnetCfg
+	cfg.MagnetRadius = 0
+	id := w.PlacePickup(164, 104, PickupGold, 5, cfg) // 60px away
+
+	UpdatePickupMagnet(w)
+	assert.False(t, w.PickupData[id].Attracted, "out of tier 0 range")
+
+	player := w.PlayerData[w.PlayerID]
+	player.MagnetLevel = 5 // clamps to the last tier
+	w.PlayerData[w.PlayerID] = player
+
+	UpdatePickupMagnet(w)
+	assert.True(t, w.PickupData[id].Attracted)
+}
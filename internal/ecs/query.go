@@ -0,0 +1,149 @@
+package ecs
+
+import "slices"
+
+// Component selects one of the World's component or tag maps in a Query
+type Component int
+
+const (
+	CompPosition Component = iota
+	CompPreviousPosition
+	CompVelocity
+	CompMovement
+	CompHealth
+	CompHitbox
+	CompHitboxTrapezoid
+	CompFacing
+	CompAI
+	CompDash
+	CompCrouch
+	CompProjectile
+	CompPickup
+	CompPlayer
+	CompElite
+	CompParticle
+	CompCrowdControl
+	CompNPC
+	CompFaction
+	CompAlly
+
+	TagPlayer
+	TagEnemy
+	TagProjectile
+	TagPickup
+	TagNPC
+)
+
+// componentSet is the part of a component map a query needs
+type componentSet interface {
+	size() int
+	has(id EntityID) bool
+	appendIDs(dst []EntityID) []EntityID
+}
+
+// mapSet adapts a component map of any type to componentSet
+type mapSet[T any] map[EntityID]T
+
+func (m mapSet[T]) size() int {
+	return len(m)
+}
+
+func (m mapSet[T]) has(id EntityID) bool {
+	_, ok := m[id]
+	return ok
+}
+
+func (m mapSet[T]) appendIDs(dst []EntityID) []EntityID {
+	for id := range m {
+		dst = append(dst, id)
+	}
+	return dst
+}
+
+// set returns the map a Component selects
+func (w *World) set(c Component) componentSet {
+	switch c {
+	case CompPosition:
+		return mapSet[Position](w.Position)
+	case CompPreviousPosition:
+		return mapSet[PreviousPosition](w.PreviousPosition)
+	case CompVelocity:
+		return mapSet[Velocity](w.Velocity)
+	case CompMovement:
+		return mapSet[Movement](w.Movement)
+	case CompHealth:
+		return mapSet[Health](w.Health)
+	case CompHitbox:
+		return mapSet[Hitbox](w.Hitbox)
+	case CompHitboxTrapezoid:
+		return mapSet[HitboxTrapezoid](w.HitboxTrapezoid)
+	case CompFacing:
+		return mapSet[Facing](w.Facing)
+	case CompAI:
+		return mapSet[AI](w.AI)
+	case CompDash:
+		return mapSet[Dash](w.Dash)
+	case CompCrouch:
+		return mapSet[Crouch](w.Crouch)
+	case CompProjectile:
+		return mapSet[Projectile](w.ProjectileData)
+	case CompPickup:
+		return mapSet[Pickup](w.PickupData)
+	case CompPlayer:
+		return mapSet[Player](w.PlayerData)
+	case CompElite:
+		return mapSet[Elite](w.Elite)
+	case CompParticle:
+		return mapSet[Particle](w.Particle)
+	case CompCrowdControl:
+		return mapSet[CrowdControl](w.CrowdControl)
+	case CompNPC:
+		return mapSet[NPC](w.NPC)
+	case CompFaction:
+		return mapSet[Faction](w.Faction)
+	case CompAlly:
+		return mapSet[Ally](w.Ally)
+	case TagPlayer:
+		return mapSet[struct{}](w.IsPlayer)
+	case TagEnemy:
+		return mapSet[struct{}](w.IsEnemy)
+	case TagProjectile:
+		return mapSet[struct{}](w.IsProjectile)
+	case TagPickup:
+		return mapSet[struct{}](w.IsPickup)
+	case TagNPC:
+		return mapSet[struct{}](w.IsNPC)
+	}
+	panic("ecs: unknown component in query")
+}
+
+// Query returns the entities that have every given component, sorted by
+// ID. Go randomizes map iteration order, so systems iterate a query
+// instead of ranging over a component map: the same world always runs
+// the same way, which replays and lockstep netplay depend on.
+//
+// The result is a snapshot. An entity destroyed while iterating it is
+// still in the slice; systems that destroy others mid-loop check before
+// using an entity.
+func (w *World) Query(components ...Component) []EntityID {
+	if len(components) == 0 {
+		return nil
+	}
+	sets := make([]componentSet, len(components))
+	for i, c := range components {
+		sets[i] = w.set(c)
+	}
+	// Scan the smallest map, check the others
+	smallest := slices.MinFunc(sets, func(a, b componentSet) int { return a.size() - b.size() })
+	ids := smallest.appendIDs(make([]EntityID, 0, smallest.size()))
+	ids = slices.DeleteFunc(ids, func(id EntityID) bool {
+		for _, s := range sets {
+			if !s.has(id) {
+				return true
+			}
+		}
+		return false
+	})
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,62 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_SortedByID(t *testing.T) {
+	w := NewWorld()
+	var want []EntityID
+	for range 50 {
+		id := w.NewEntity()
+		w.IsEnemy[id] = struct{}{}
+		want = append(want, id)
+	}
+
+	// Map iteration order varies between runs; the query's never does
+	for range 10 {
+		assert.Equal(t, want, w.Query(TagEnemy))
+	}
+}
+
+func TestQuery_Intersection(t *testing.T) {
+	w := NewWorld()
+	a, b, c := w.NewEntity(), w.NewEntity(), w.NewEntity()
+	for _, id := range []EntityID{a, b, c} {
+		w.Position[id] = Position{}
+	}
+	w.Velocity[a] = Velocity{}
+	w.Velocity[c] = Velocity{}
+	w.IsEnemy[c] = struct{}{}
+
+	assert.Equal(t, []EntityID{a, b, c}, w.Query(CompPosition))
+	assert.Equal(t, []EntityID{a, c}, w.Query(CompPosition, CompVelocity))
+	assert.Equal(t, []EntityID{c}, w.Query(CompVelocity, TagEnemy, CompPosition))
+	assert.Empty(t, w.Query(TagEnemy, TagPlayer))
+}
+
+func TestQuery_Snapshot(t *testing.T) {
+	w := NewWorld()
+	a, b := w.NewEntity(), w.NewEntity()
+	w.IsPickup[a] = struct{}{}
+	w.IsPickup[b] = struct{}{}
+
+	ids := w.Query(TagPickup)
+	w.DestroyEntity(a)
+
+	assert.Equal(t, []EntityID{a, b}, ids, "destroying an entity doesn't change a query already made")
+	assert.Equal(t, []EntityID{b}, w.Query(TagPickup))
+}
+
+func TestQuery_Empty(t *testing.T) {
+	w := NewWorld()
+	assert.Empty(t, w.Query(TagProjectile))
+	assert.Nil(t, w.Query())
+}
+
+func TestQuery_UnknownComponentPanics(t *testing.T) {
+	w := NewWorld()
+	assert.Panics(t, func() { w.Query(Component(-1)) })
+}
@@ -0,0 +1,63 @@
+package ecs
+
+import "math/rand"
+
+// RandSource is a seeded math/rand source that counts its draws, so a
+// snapshot can put it back to an earlier point in its sequence. It yields
+// the same numbers as rand.NewSource with the same seed.
+type RandSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+// NewRandSource returns a source seeded with seed
+func NewRandSource(seed int64) *RandSource {
+	return &RandSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// Seed restarts the sequence from seed
+func (s *RandSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed, s.draws = seed, 0
+}
+
+// Int63 returns the next value as a non-negative int64
+func (s *RandSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+// Uint64 returns the next value as a uint64
+func (s *RandSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+// randState is the position of a RandSource in its sequence
+type randState struct {
+	seed  int64
+	draws uint64
+}
+
+func (s *RandSource) state() randState {
+	return randState{seed: s.seed, draws: s.draws}
+}
+
+// restore puts the source back to st by reseeding and skipping ahead
+func (s *RandSource) restore(st randState) {
+	if st.seed != s.seed || st.draws < s.draws {
+		s.Seed(st.seed)
+	}
+	for s.draws < st.draws {
+		s.Int63()
+	}
+}
+
+// SeedRand gives the world a new RNG seeded with seed and returns it, so
+// the scene can share it. Snapshots restore its position.
+func (w *World) SeedRand(seed int64) *rand.Rand {
+	w.randSource = NewRandSource(seed)
+	w.Rand = rand.New(w.randSource)
+	return w.Rand
+}
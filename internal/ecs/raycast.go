@@ -0,0 +1,84 @@
+package ecs
+
+// RayHit is the first solid tile on a ray
+type RayHit struct {
+	X, Y         int // pixel where the ray enters the tile
+	TileX, TileY int
+}
+
+// RaycastTiles walks the tiles a segment crosses from (x0, y0) to (x1, y1)
+// (pixels) in order and returns the first solid one (ok is false if there
+// is none). A ray starting in a solid tile hits it at the start. Stages
+// implement Raycast with it.
+func RaycastTiles(stage Stage, x0, y0, x1, y1 int) (hit RayHit, ok bool) {
+	x, y, ok := castRay(stage, x0, y0, x1, y1, 1)
+	if !ok {
+		return RayHit{}, false
+	}
+	size := stage.GetTileSize()
+	return RayHit{X: x, Y: y, TileX: floorDiv(x, size), TileY: floorDiv(y, size)}, true
+}
+
+// castRay is the integer grid DDA behind RaycastTiles and projectile
+// sweeps, in units of 1/scale pixel: it visits only the tiles the segment
+// crosses, one solid check each, and returns the point where it enters the
+// first solid one. Coordinates left of or above the stage floor into the
+// tiles outside it.
+func castRay(stage Stage, x0, y0, x1, y1, scale int) (x, y int, ok bool) {
+	if stage.IsSolidAt(floorDiv(x0, scale), floorDiv(y0, scale)) {
+		return x0, y0, true
+	}
+
+	size := stage.GetTileSize() * scale
+	dx, dy := x1-x0, y1-y0
+	adx, ady := abs(dx), abs(dy)
+	tx, ty := floorDiv(x0, size), floorDiv(y0, size)
+	nextX, nextY := tileBoundary(tx, dx, size), tileBoundary(ty, dy, size)
+
+	for {
+		// The segment reaches the next boundary on an axis at t = n/a; a
+		// boundary past its end (n > a) isn't crossed
+		nX, nY := abs(nextX-x0), abs(nextY-y0)
+		crossX := dx != 0 && nX <= adx
+		crossY := dy != 0 && nY <= ady
+		if crossX && crossY {
+			// The nearer one, both through a corner
+			tX, tY := nX*ady, nY*adx
+			crossX, crossY = tX <= tY, tY <= tX
+		}
+		if !crossX && !crossY {
+			return 0, 0, false
+		}
+
+		// Where the segment crosses, rounded back toward the start so the
+		// other axis stays in its tile
+		x, y = nextX, nextY
+		if !crossY {
+			y = y0 + dy*nX/adx
+		}
+		if !crossX {
+			x = x0 + dx*nY/ady
+		}
+		if crossX {
+			tx += sign(dx)
+			nextX = tileBoundary(tx, dx, size)
+		}
+		if crossY {
+			ty += sign(dy)
+			nextY = tileBoundary(ty, dy, size)
+		}
+
+		if stage.IsSolidAt(floorDiv(x, scale), floorDiv(y, scale)) {
+			return x, y, true
+		}
+	}
+}
+
+// tileBoundary returns the first unit past tile t in the direction of d
+// (size units per tile)
+func tileBoundary(t, d, size int) int {
+	if d < 0 {
+		return t*size - 1
+	}
+	return (t + 1) * size
+}
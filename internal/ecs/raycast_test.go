@@ -0,0 +1,111 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaycast_FirstSolidTile(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(8, 2)
+	stage.setSolid(5, 2)
+
+	hit, ok := stage.Raycast(10, 40, 200, 40)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 80, Y: 40, TileX: 5, TileY: 2}, hit)
+
+	hit, ok = stage.Raycast(200, 40, 10, 40)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 143, Y: 40, TileX: 8, TileY: 2}, hit, "enters from the right edge")
+}
+
+func TestRaycast_Diagonal(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(3, 3)
+
+	hit, ok := stage.Raycast(8, 8, 100, 100)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 48, Y: 48, TileX: 3, TileY: 3}, hit, "through the corner")
+
+	hit, ok = stage.Raycast(8, 20, 100, 112)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 48, Y: 60, TileX: 3, TileY: 3}, hit, "through the left edge")
+
+	hit, ok = stage.Raycast(100, 10, 40, 70)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 62, Y: 48, TileX: 3, TileY: 3}, hit, "through the top edge")
+}
+
+func TestRaycast_Misses(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(10, 2)
+
+	_, ok := stage.Raycast(10, 40, 159, 40)
+	assert.False(t, ok, "ends just short of the wall")
+	_, ok = stage.Raycast(10, 40, 10, 40)
+	assert.False(t, ok)
+	_, ok = stage.Raycast(10, 40, 300, 10)
+	assert.False(t, ok)
+}
+
+func TestRaycast_StartsInsideWall(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(2, 2)
+
+	hit, ok := stage.Raycast(40, 40, 200, 40)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: 40, Y: 40, TileX: 2, TileY: 2}, hit)
+}
+
+// No tile is skipped: a dense sampling of the segment meets no solid tile
+// before the hit
+func TestRaycast_NegativeOrigin(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(-1, 0) // the boundary left of row 0
+
+	hit, ok := stage.Raycast(-5, 8, 40, 8)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: -5, Y: 8, TileX: -1, TileY: 0}, hit, "starts outside the stage")
+
+	hit, ok = stage.Raycast(20, 8, -40, 8)
+	require.True(t, ok)
+	assert.Equal(t, RayHit{X: -1, Y: 8, TileX: -1, TileY: 0}, hit, "enters the boundary")
+
+	// Half a pixel left of the stage is already outside it
+	_, _, ok = castRay(stage, -50, 800, 2000, 800, 100)
+	assert.True(t, ok)
+}
+
+func TestRaycast_NoTileSkipped(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	stage := newMockStage(40, 40, 16)
+	for range 120 {
+		stage.setSolid(rng.Intn(40), rng.Intn(40))
+	}
+
+	for range 1000 {
+		x0, y0 := rng.Intn(640), rng.Intn(640)
+		x1, y1 := rng.Intn(640), rng.Intn(640)
+		hit, ok := stage.Raycast(x0, y0, x1, y1)
+		if ok {
+			assert.True(t, stage.IsSolidAt(hit.X, hit.Y))
+		}
+
+		n := 4 * max(abs(x1-x0), abs(y1-y0))
+		for i := 0; i <= n && n > 0; i++ {
+			x, y := x0+(x1-x0)*i/n, y0+(y1-y0)*i/n
+			if !stage.IsSolidAt(x, y) {
+				continue
+			}
+			// Sampling rounds differently at tile corners: allow a pixel
+			require.True(t, ok, "(%d,%d)->(%d,%d) misses (%d,%d)", x0, y0, x1, y1, x, y)
+			dist := max(abs(x-x0), abs(y-y0))
+			hitDist := max(abs(hit.X-x0), abs(hit.Y-y0))
+			assert.LessOrEqual(t, hitDist, dist+1, "(%d,%d)->(%d,%d) passes (%d,%d)", x0, y0, x1, y1, x, y)
+			break
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ecs
+
+// RespawnRule is when a destroyed stage-authored entity comes back
+type RespawnRule int
+
+const (
+	RespawnNever     RespawnRule = iota // gone for the rest of the run
+	RespawnTimed                        // back after its delay
+	RespawnOffscreen                    // back after its delay, once its spawn point is out of view
+)
+
+// respawnNames maps respawn rules to their config names
+var respawnNames = map[RespawnRule]string{
+	RespawnNever:     "never",
+	RespawnTimed:     "timed",
+	RespawnOffscreen: "offscreen",
+}
+
+// String returns the config name of the rule
+func (r RespawnRule) String() string {
+	return respawnNames[r]
+}
+
+// ParseRespawnRule returns the respawn rule for a config name
+func ParseRespawnRule(name string) (RespawnRule, bool) {
+	for r, n := range respawnNames {
+		if n == name {
+			return r, true
+		}
+	}
+	return RespawnNever, false
+}
+
+// Respawn tracks a stage-authored enemy or pickup so it can repopulate
+// after being destroyed. Spawn is the scene's index of the stage definition.
+type Respawn struct {
+	Spawn  int
+	Pickup bool // Spawn indexes the stage pickups instead of the enemies
+	Rule   RespawnRule
+	Frames int // delay after destruction
+	X, Y   int // spawn point (pixels)
+
+	Entity EntityID // live entity (0 = destroyed, waiting to respawn)
+	Timer  int      // frames left of the delay
+}
+
+// TrackRespawn starts tracking a stage-authored entity.
+// Entities that never respawn aren't tracked.
+func (w *World) TrackRespawn(id EntityID, r Respawn) {
+	if id == 0 || r.Rule == RespawnNever {
+		return
+	}
+	r.Entity = id
+	w.Respawns = append(w.Respawns, r)
+}
+
+// UpdateRespawns notices destroyed tracked entities and counts down their
+// delay (call once per frame). It returns the indexes into w.Respawns that
+// are ready, in stage order; the caller spawns them and hands the new
+// entities back with Respawned. The view rect (pixels) is the visible
+// screen for off-screen respawns.
+func UpdateRespawns(w *World, viewX, viewY, viewW, viewH int) []int {
+	var ready []int
+	for i := range w.Respawns {
+		r := &w.Respawns[i]
+		if r.Entity != 0 {
+			if w.Exists(r.Entity) {
+				continue
+			}
+			r.Entity = 0
+			r.Timer = r.Frames
+		}
+
+		if r.Timer > 0 {
+			r.Timer--
+		}
+		if r.Timer > 0 {
+			continue
+		}
+		if r.Rule == RespawnOffscreen && pointInRect(r.X, r.Y, viewX, viewY, viewW, viewH) {
+			continue
+		}
+		ready = append(ready, i)
+	}
+	return ready
+}
+
+// Respawned attaches the entity spawned for a ready respawn.
+// A failed spawn (id 0) is retried next frame.
+func (w *World) Respawned(i int, id EntityID) {
+	w.Respawns[i].Entity = id
+}
+
+// pointInRect reports whether (x, y) lies inside a pixel rect
+func pointInRect(x, y, rx, ry, rw, rh int) bool {
+	return x >= rx && x < rx+rw && y >= ry && y < ry+rh
+}
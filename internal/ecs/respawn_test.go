@@ -0,0 +1,55 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRespawns_Timed(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10}, true)
+	w.TrackRespawn(enemy, Respawn{Spawn: 2, Rule: RespawnTimed, Frames: 3, X: 100, Y: 100})
+
+	assert.Empty(t, UpdateRespawns(w, 0, 0, 320, 240), "still alive")
+
+	w.DestroyEntity(enemy)
+	assert.Empty(t, UpdateRespawns(w, 0, 0, 320, 240))
+	assert.Empty(t, UpdateRespawns(w, 0, 0, 320, 240))
+	ready := UpdateRespawns(w, 0, 0, 320, 240)
+	assert.Equal(t, []int{0}, ready, "on screen doesn't matter")
+	assert.Equal(t, 2, w.Respawns[0].Spawn)
+
+	again := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10}, true)
+	w.Respawned(0, again)
+	assert.Empty(t, UpdateRespawns(w, 0, 0, 320, 240))
+}
+
+func TestUpdateRespawns_OffscreenWaitsForView(t *testing.T) {
+	w := NewWorld()
+	pickup := w.PlacePickup(100, 100, PickupHealth, 10, PickupConfig{})
+	w.TrackRespawn(pickup, Respawn{Pickup: true, Rule: RespawnOffscreen, X: 100, Y: 100})
+	w.DestroyEntity(pickup)
+
+	assert.Empty(t, UpdateRespawns(w, 0, 0, 320, 240), "spawn point in view")
+	assert.Equal(t, []int{0}, UpdateRespawns(w, 200, 0, 320, 240))
+}
+
+func TestTrackRespawn_NeverIsNotTracked(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10}, true)
+	w.TrackRespawn(enemy, Respawn{Rule: RespawnNever})
+	w.TrackRespawn(0, Respawn{Rule: RespawnTimed})
+
+	assert.Empty(t, w.Respawns)
+}
+
+func TestParseRespawnRule(t *testing.T) {
+	for _, rule := range []RespawnRule{RespawnNever, RespawnTimed, RespawnOffscreen} {
+		parsed, ok := ParseRespawnRule(rule.String())
+		assert.True(t, ok)
+		assert.Equal(t, rule, parsed)
+	}
+	_, ok := ParseRespawnRule("sometimes")
+	assert.False(t, ok)
+}
@@ -0,0 +1,106 @@
+package ecs
+
+// Phase is the point in a frame at which a system runs
+type Phase int
+
+const (
+	PhaseFrameStart Phase = iota // once per frame first: timers, input
+	PhasePreStep                 // once per frame before the substeps: gravity, steering
+	PhaseSubstep                 // every substep: movement and collision
+	PhaseResolve                 // once per frame after the substeps: pickups, damage
+	PhaseFrameEnd                // once per frame last: feedback, particles, spawning
+)
+
+// String returns the phase name
+func (p Phase) String() string {
+	switch p {
+	case PhaseFrameStart:
+		return "FrameStart"
+	case PhasePreStep:
+		return "PreStep"
+	case PhaseSubstep:
+		return "Substep"
+	case PhaseResolve:
+		return "Resolve"
+	case PhaseFrameEnd:
+		return "FrameEnd"
+	default:
+		return "Unknown"
+	}
+}
+
+// SystemFunc is a system registered with the scheduler
+type SystemFunc func(w *World)
+
+// scheduledSystem is a registered system and where it runs
+type scheduledSystem struct {
+	fn       SystemFunc
+	phase    Phase
+	priority int
+}
+
+// Scheduler runs registered systems by phase. Within a phase, lower
+// priorities run first and equal priorities run in registration order.
+type Scheduler struct {
+	systems []scheduledSystem // sorted by phase, then priority, then registration
+}
+
+// Add registers a system
+func (s *Scheduler) Add(fn SystemFunc, phase Phase, priority int) {
+	// Insert after every system that runs no later, keeping registration order
+	i := len(s.systems)
+	for i > 0 {
+		prev := s.systems[i-1]
+		if prev.phase < phase || (prev.phase == phase && prev.priority <= priority) {
+			break
+		}
+		i--
+	}
+	s.systems = append(s.systems, scheduledSystem{})
+	copy(s.systems[i+1:], s.systems[i:])
+	s.systems[i] = scheduledSystem{fn: fn, phase: phase, priority: priority}
+}
+
+// Len returns the number of registered systems
+func (s *Scheduler) Len() int {
+	return len(s.systems)
+}
+
+// Run runs the systems of one phase, then flushes the commands they
+// deferred: the end of a phase is a sync point
+func (s *Scheduler) Run(w *World, phase Phase) {
+	for _, sys := range s.systems {
+		if sys.phase == phase {
+			sys.fn(w)
+		}
+	}
+	w.Flush()
+}
+
+// RunFrame runs one frame: the once-per-frame phases around subSteps runs
+// of PhaseSubstep (SubstepsPerFrame at normal speed, fewer for slow motion)
+func (s *Scheduler) RunFrame(w *World, subSteps int) {
+	s.Run(w, PhaseFrameStart)
+	s.Run(w, PhasePreStep)
+	for i := 0; i < subSteps; i++ {
+		s.Run(w, PhaseSubstep)
+	}
+	s.Run(w, PhaseResolve)
+	s.Run(w, PhaseFrameEnd)
+}
+
+// AddSystem registers a system in a phase after the systems already there
+func (w *World) AddSystem(fn SystemFunc, phase Phase) {
+	w.Systems.Add(fn, phase, 0)
+}
+
+// AddSystemPriority registers a system in a phase with a priority.
+// Built-in systems use priority 0: negative runs before them, positive after.
+func (w *World) AddSystemPriority(fn SystemFunc, phase Phase, priority int) {
+	w.Systems.Add(fn, phase, priority)
+}
+
+// RunFrame runs the world's registered systems for one frame
+func (w *World) RunFrame(subSteps int) {
+	w.Systems.RunFrame(w, subSteps)
+}
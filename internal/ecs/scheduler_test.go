@@ -0,0 +1,52 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Order(t *testing.T) {
+	w := NewWorld()
+	var order []string
+	add := func(name string, phase Phase, priority int) {
+		w.AddSystemPriority(func(*World) { order = append(order, name) }, phase, priority)
+	}
+
+	add("end", PhaseFrameEnd, 0)
+	add("resolve", PhaseResolve, 0)
+	add("start-a", PhaseFrameStart, 0)
+	add("start-b", PhaseFrameStart, 0)
+	add("start-late", PhaseFrameStart, 5)
+	add("start-early", PhaseFrameStart, -1)
+	add("pre", PhasePreStep, 0)
+	add("step", PhaseSubstep, 0)
+	assert.Equal(t, 8, w.Systems.Len())
+
+	w.RunFrame(2)
+	assert.Equal(t, []string{
+		"start-early", "start-a", "start-b", "start-late",
+		"pre", "step", "step", "resolve", "end",
+	}, order)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	w := NewWorld()
+	calls := 0
+	w.AddSystem(func(got *World) {
+		assert.Same(t, w, got)
+		calls++
+	}, PhaseSubstep)
+
+	w.Systems.Run(w, PhaseResolve)
+	assert.Equal(t, 0, calls, "other phases don't run the system")
+	w.RunFrame(SubstepsPerFrame)
+	assert.Equal(t, SubstepsPerFrame, calls)
+}
+
+func TestPhase_String(t *testing.T) {
+	assert.Equal(t, "FrameStart", PhaseFrameStart.String())
+	assert.Equal(t, "Substep", PhaseSubstep.String())
+	assert.Equal(t, "FrameEnd", PhaseFrameEnd.String())
+	assert.Equal(t, "Unknown", Phase(99).String())
+}
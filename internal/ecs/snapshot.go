@@ -0,0 +1,139 @@
+package ecs
+
+import (
+	"maps"
+	"slices"
+)
+
+// Snapshot is a copy of a world's state at one frame (see Rewind).
+// It leaves out the registered systems. The loot RNG is kept as its
+// position in the sequence, so frames replayed after a restore roll the
+// same loot (unless Rand was replaced without SeedRand).
+type Snapshot struct {
+	world World
+	rand  randState
+}
+
+// Snapshot copies the world's entities, components and resources
+func (w *World) Snapshot() *Snapshot {
+	s := &Snapshot{world: w.clone()}
+	if w.randSource != nil {
+		s.rand = w.randSource.state()
+	}
+	return s
+}
+
+// Restore puts the world back to a snapshot. The snapshot stays valid, so
+// the same frame can be restored again. The live RNG is rewound in place,
+// so a scene sharing it sees the restored sequence too.
+func (w *World) Restore(s *Snapshot) {
+	systems, rng, source := w.Systems, w.Rand, w.randSource
+	*w = s.world.clone()
+	w.Systems, w.Rand, w.randSource = systems, rng, source
+	if source != nil && source == s.world.randSource {
+		source.restore(s.rand)
+	}
+}
+
+// clone deep-copies the world state that systems mutate. Scratch buffers
+// are left empty; config values (routes, feedback profiles) are shared.
+func (w *World) clone() World {
+	c := *w
+	c.generations = slices.Clone(w.generations)
+	c.projectilePool = slices.Clone(w.projectilePool)
+	c.pickupPool = slices.Clone(w.pickupPool)
+	c.particlePool = slices.Clone(w.particlePool)
+	c.destroyBuf, c.killBuf, c.interceptBuf, c.dropBuf = nil, nil, nil, nil
+	c.commands = slices.Clone(w.commands)
+
+	c.Position = maps.Clone(w.Position)
+	c.PreviousPosition = maps.Clone(w.PreviousPosition)
+	c.Velocity = maps.Clone(w.Velocity)
+	c.Movement = maps.Clone(w.Movement)
+	c.Health = maps.Clone(w.Health)
+	c.Hitbox = maps.Clone(w.Hitbox)
+	c.HitboxTrapezoid = maps.Clone(w.HitboxTrapezoid)
+	c.Facing = maps.Clone(w.Facing)
+	c.AI = maps.Clone(w.AI)
+	c.Dash = make(map[EntityID]Dash, len(w.Dash))
+	for id, d := range w.Dash {
+		d.Hits = slices.Clone(d.Hits)
+		c.Dash[id] = d
+	}
+	c.Crouch = maps.Clone(w.Crouch)
+	c.ProjectileData = maps.Clone(w.ProjectileData)
+	c.PickupData = maps.Clone(w.PickupData)
+	c.PlayerData = maps.Clone(w.PlayerData)
+	c.Elite = maps.Clone(w.Elite)
+	c.Particle = maps.Clone(w.Particle)
+	c.CrowdControl = maps.Clone(w.CrowdControl)
+	c.NPC = maps.Clone(w.NPC)
+	c.Faction = maps.Clone(w.Faction)
+	c.Ally = maps.Clone(w.Ally)
+	c.IsPlayer = maps.Clone(w.IsPlayer)
+	c.IsEnemy = maps.Clone(w.IsEnemy)
+	c.IsProjectile = maps.Clone(w.IsProjectile)
+	c.IsPickup = maps.Clone(w.IsPickup)
+	c.IsNPC = maps.Clone(w.IsNPC)
+
+	feedback := *w.Feedback
+	feedback.shakes = slices.Clone(w.Feedback.shakes)
+	c.Feedback = &feedback
+	c.Events = &Events{queue: slices.Clone(w.Events.queue)}
+	timeScale := *w.TimeScale
+	timeScale.requests = maps.Clone(w.TimeScale.requests)
+	c.TimeScale = &timeScale
+	c.Crumbles = maps.Clone(w.Crumbles)
+	c.ContactCooldowns = maps.Clone(w.ContactCooldowns)
+	c.Respawns = slices.Clone(w.Respawns)
+	c.Beams = slices.Clone(w.Beams)
+	return c
+}
+
+// Rewind keeps the last frames of a world as snapshots in a ring buffer
+type Rewind struct {
+	snapshots []*Snapshot
+	next      int // index of the slot the next snapshot writes to
+	count     int // number of snapshots stored
+}
+
+// NewRewind creates a buffer of the last capacity frames
+func NewRewind(capacity int) *Rewind {
+	return &Rewind{snapshots: make([]*Snapshot, capacity)}
+}
+
+// Record stores a snapshot of the world, replacing the oldest when full
+func (r *Rewind) Record(w *World) {
+	r.snapshots[r.next] = w.Snapshot()
+	r.next = (r.next + 1) % len(r.snapshots)
+	if r.count < len(r.snapshots) {
+		r.count++
+	}
+}
+
+// Len returns the number of stored frames
+func (r *Rewind) Len() int {
+	return r.count
+}
+
+// At returns the snapshot from back frames ago (0 = the latest recorded)
+func (r *Rewind) At(back int) *Snapshot {
+	if back < 0 || back >= r.count {
+		return nil
+	}
+	return r.snapshots[(r.next-1-back+len(r.snapshots))%len(r.snapshots)]
+}
+
+// Truncate drops the newest back frames, so recording resumes from the
+// frame that was rewound to
+func (r *Rewind) Truncate(back int) {
+	back = max(0, min(back, r.count))
+	r.next = (r.next - back + len(r.snapshots)) % len(r.snapshots)
+	r.count -= back
+}
+
+// Clear drops all snapshots (e.g. on restart)
+func (r *Rewind) Clear() {
+	r.next, r.count = 0, 0
+	clear(r.snapshots)
+}
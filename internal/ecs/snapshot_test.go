@@ -0,0 +1,98 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorld_SnapshotRestore(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	w.Dash[player] = Dash{Hits: []EntityID{7}}
+	snap := w.Snapshot()
+
+	w.Position[player] = Position{X: 5, Y: 5}
+	dash := w.Dash[player]
+	dash.Hits[0] = 9
+	w.Dash[player] = dash
+	enemy := w.CreateEnemy(32, 32, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	w.Crumbles[TileCoord{1, 2}] = Crumble{}
+	w.Events.Emit(Event{Type: EventEnemyKilled})
+
+	w.Restore(snap)
+
+	assert.Equal(t, 100*PositionScale, w.Position[player].X)
+	assert.Equal(t, []EntityID{7}, w.Dash[player].Hits, "slices in components are copied")
+	assert.False(t, w.Exists(enemy))
+	assert.Empty(t, w.Crumbles)
+	assert.Empty(t, w.Events.Drain())
+
+	// The snapshot survives restoring and mutating again
+	w.Position[player] = Position{}
+	w.Restore(snap)
+	assert.Equal(t, 100*PositionScale, w.Position[player].X)
+}
+
+func TestWorld_RestoreKeepsSystems(t *testing.T) {
+	w := NewWorld()
+	snap := w.Snapshot()
+	w.Systems.Add(func(*World) {}, PhaseFrameStart, 0)
+
+	w.Restore(snap)
+
+	assert.Equal(t, 1, w.Systems.Len())
+}
+
+func TestWorld_RestoreRewindsRand(t *testing.T) {
+	w := NewWorld()
+	rng := w.SeedRand(42)
+	rng.Intn(100)
+	snap := w.Snapshot()
+	want := []int{rng.Intn(100), rng.Intn(100), int(rng.Uint64() % 100)}
+
+	w.Restore(snap)
+	assert.Same(t, rng, w.Rand, "a shared RNG stays shared")
+	assert.Equal(t, want, []int{rng.Intn(100), rng.Intn(100), int(rng.Uint64() % 100)})
+
+	// Restoring an earlier frame reseeds and skips ahead
+	w.Restore(snap)
+	rng.Intn(100)
+	w.Restore(snap)
+	assert.Equal(t, want[0], rng.Intn(100))
+}
+
+func TestRandSource_MatchesMathRand(t *testing.T) {
+	a, b := rand.New(NewRandSource(7)), rand.New(rand.NewSource(7))
+	for range 10 {
+		assert.Equal(t, b.Int63(), a.Int63())
+	}
+}
+
+func TestRewind_RingBuffer(t *testing.T) {
+	w := NewWorld()
+	player := w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	r := NewRewind(3)
+
+	for x := range 5 {
+		w.Position[player] = Position{X: x}
+		r.Record(w)
+	}
+	require.Equal(t, 3, r.Len())
+	assert.Nil(t, r.At(3))
+
+	w.Restore(r.At(2))
+	assert.Equal(t, 2, w.Position[player].X, "oldest kept frame")
+	w.Restore(r.At(0))
+	assert.Equal(t, 4, w.Position[player].X, "latest frame")
+
+	r.Truncate(1)
+	assert.Equal(t, 2, r.Len())
+	w.Restore(r.At(0))
+	assert.Equal(t, 3, w.Position[player].X, "recording resumes after the rewound frame")
+
+	r.Clear()
+	assert.Equal(t, 0, r.Len())
+}
@@ -0,0 +1,68 @@
+package ecs
+
+// solidLandDepth is how far (pixels) the player's feet may sink into a solid
+// enemy's top and still land on it instead of being pushed aside
+const solidLandDepth = 4
+
+// ResolveSolidEnemies keeps the player out of solid enemies (call once per
+// substep, after movement). Feet at or slightly inside an enemy's top stand
+// on it; any other overlap pushes the player out to the side, stopping at
+// walls.
+func ResolveSolidEnemies(w *World, stage Stage) {
+	id := w.PlayerID
+	if id == 0 {
+		return
+	}
+
+	var solid []EntityID
+	for _, enemyID := range w.Query(TagEnemy) {
+		if ai := w.AI[enemyID]; ai.Solid && ai.SpawnTimer <= 0 {
+			solid = append(solid, enemyID)
+		}
+	}
+	if len(solid) == 0 {
+		return
+	}
+
+	pos := w.Position[id]
+	vel := w.Velocity[id]
+	mov := w.Movement[id]
+	hitbox := w.HitboxTrapezoid[id]
+	facing := w.Facing[id]
+	mirror := hitbox.MirrorWidth()
+
+	for _, enemyID := range solid {
+		enemyPos, hb := w.Position[enemyID], w.Hitbox[enemyID]
+		ex, ey := enemyPos.PixelX()+hb.OffsetX, enemyPos.PixelY()+hb.OffsetY
+
+		// Land on top
+		fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, mirror)
+		depth := fy + fh - ey
+		if vel.Y >= 0 && depth >= 0 && depth <= solidLandDepth && fx < ex+hb.Width && fx+fw > ex {
+			landed := pos
+			landed.Y = (pos.PixelY() - depth) * PositionScale
+			hx, hy, hw, hh := hitbox.Head.GetWorldRect(landed.PixelX(), landed.PixelY(), facing.Right, mirror)
+			if !isSolidRect(stage, hx, hy, hw, hh) {
+				pos = landed
+				vel.Y = 0
+				mov.OnGround = true
+				continue
+			}
+		}
+
+		// Push out to the side the body's center is on
+		bx, by, bw, bh := hitbox.Body.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, mirror)
+		if !rectsOverlap(bx, by, bw, bh, ex, ey, hb.Width, hb.Height) {
+			continue
+		}
+		push := ex - (bx + bw)
+		if 2*bx+bw > 2*ex+hb.Width {
+			push = ex + hb.Width - bx
+		}
+		movePlayerX(stage, &pos, &vel, &mov, hitbox, facing.Right, push*PositionScale)
+	}
+
+	w.Position[id] = pos
+	w.Velocity[id] = vel
+	w.Movement[id] = mov
+}
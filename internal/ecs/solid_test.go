@@ -0,0 +1,104 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSolidTestWorld(playerX, playerY int) (*World, EntityID, EntityID) {
+	w := NewWorld()
+	player := w.CreatePlayer(playerX, playerY, PlayerProfile{
+		Hitbox: HitboxTrapezoid{
+			Head: Hitbox{OffsetX: 4, OffsetY: 0, Width: 8, Height: 6},
+			Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12},
+			Feet: Hitbox{OffsetX: 0, OffsetY: 18, Width: 16, Height: 6},
+		},
+		MaxHealth: 100,
+	})
+	w.Facing[player] = Facing{Right: true}
+	enemy := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 100, ContactDamage: 10, HitboxWidth: 32, HitboxHeight: 32, Solid: true}, true)
+	return w, player, enemy
+}
+
+func TestResolveSolidEnemies_PlayerLandsOnTop(t *testing.T) {
+	w, player, _ := newSolidTestWorld(104, 78) // feet 2px into the enemy's top
+	w.Velocity[player] = Velocity{Y: 50}
+
+	ResolveSolidEnemies(w, newMockStage(40, 40, 16))
+
+	assert.Equal(t, 76, w.Position[player].PixelY())
+	assert.Equal(t, 0, w.Velocity[player].Y)
+	assert.True(t, w.Movement[player].OnGround)
+}
+
+func TestResolveSolidEnemies_PushesPlayerAside(t *testing.T) {
+	w, player, _ := newSolidTestWorld(92, 100) // body 6px into the enemy's left side
+
+	ResolveSolidEnemies(w, newMockStage(40, 40, 16))
+
+	assert.Equal(t, 86, w.Position[player].PixelX())
+	assert.False(t, w.Movement[player].OnGround)
+}
+
+func TestResolveSolidEnemies_IgnoresRegularEnemies(t *testing.T) {
+	w, player, enemy := newSolidTestWorld(92, 100)
+	ai := w.AI[enemy]
+	ai.Solid = false
+	w.AI[enemy] = ai
+
+	ResolveSolidEnemies(w, newMockStage(40, 40, 16))
+
+	assert.Equal(t, 92, w.Position[player].PixelX())
+}
+
+func TestUpdateDamage_TouchingSolidEnemyHurts(t *testing.T) {
+	w, player, _ := newSolidTestWorld(86, 100) // body right edge against the enemy
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 90, w.Health[player].Current)
+}
+
+func TestResolveEnemyCollisions_SeparatesCrowd(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(100, 100, 16)
+	var enemies []EntityID
+	for range 5 {
+		enemies = append(enemies, w.CreateEnemy(200, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true))
+	}
+
+	ResolveEnemyCollisions(w, stage)
+
+	for i, e1 := range enemies {
+		for _, e2 := range enemies[i+1:] {
+			x1, x2 := w.Position[e1].PixelX(), w.Position[e2].PixelX()
+			assert.False(t, rectsOverlap(x1, 100, 12, 20, x2, 100, 12, 20), "enemies %d and %d still overlap", e1, e2)
+		}
+	}
+}
+
+func TestResolveEnemyCollisions_SplitsByMass(t *testing.T) {
+	w := NewWorld()
+	light := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true)
+	heavy := w.CreateEnemy(108, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20, Mass: 300}, true)
+
+	ResolveEnemyCollisions(w, newMockStage(40, 40, 16))
+
+	assert.Equal(t, 97, w.Position[light].PixelX(), "light enemy takes 3 of the 4px")
+	assert.Equal(t, 109, w.Position[heavy].PixelX())
+}
+
+func TestResolveEnemyCollisions_WallPushesOtherEnemy(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(5, 6) // pixels 80..95
+	stage.setSolid(5, 7)
+	pinned := w.CreateEnemy(96, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true)
+	other := w.CreateEnemy(100, 100, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 20}, true)
+
+	ResolveEnemyCollisions(w, stage)
+
+	assert.Equal(t, 96, w.Position[pinned].PixelX())
+	assert.Equal(t, 108, w.Position[other].PixelX())
+}
@@ -0,0 +1,154 @@
+package ecs
+
+import "slices"
+
+// StompKind is how an enemy reacts to the player landing on its head
+type StompKind int
+
+const (
+	StompNone      StompKind = iota // ordinary contact damage
+	StompStompable                  // the player bounces off and damages it
+	StompSpiky                      // hurts the player and launches them back up
+)
+
+// stompNames maps stomp kinds to their config names
+var stompNames = map[StompKind]string{
+	StompStompable: "stompable",
+	StompSpiky:     "spiky",
+}
+
+// String returns the config name of the kind ("" for StompNone)
+func (k StompKind) String() string {
+	return stompNames[k]
+}
+
+// ParseStompKind returns the stomp kind for a config name
+func ParseStompKind(name string) (StompKind, bool) {
+	for k, n := range stompNames {
+		if n == name {
+			return k, true
+		}
+	}
+	return StompNone, false
+}
+
+// StompConfig holds stomp tuning.
+// Values are pre-converted to frames and IU (like PhysicsConfig).
+type StompConfig struct {
+	Damage     int // dealt to a stomped enemy
+	Bounce     int // upward launch off an enemy's head (IU/substep)
+	JumpFrames int // after a stomp, a jump press within this window jumps again
+}
+
+// stompMinDepth is how far (pixels) the player's feet may be inside an
+// enemy's top and still count as landing on its head. Fast falls allow
+// up to a frame of fall distance.
+const stompMinDepth = 4
+
+// stompDepth returns how far the player's feet are inside the enemy's head,
+// or -1 if the player isn't coming down onto it
+func stompDepth(w *World, playerID, enemyID EntityID) int {
+	vel := w.Velocity[playerID]
+	if vel.Y < 0 {
+		return -1
+	}
+	pos := w.Position[playerID]
+	hitbox := w.HitboxTrapezoid[playerID]
+	fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), w.Facing[playerID].Right, hitbox.MirrorWidth())
+
+	enemyPos := w.Position[enemyID]
+	hb := w.Hitbox[enemyID]
+	ex, ey := enemyPos.PixelX()+hb.OffsetX, enemyPos.PixelY()+hb.OffsetY
+	if fx >= ex+hb.Width || fx+fw <= ex {
+		return -1
+	}
+
+	depth := fy + fh - ey
+	maxDepth := max(stompMinDepth, vel.Y*SubstepsPerFrame/PositionScale+1)
+	if depth < 0 || depth > maxDepth || depth >= hb.Height {
+		return -1
+	}
+	return depth
+}
+
+// stompEnemies resolves the player landing on enemy heads. The feet are
+// pushed out onto the head and the player is launched up: stompable enemies
+// take damage and refresh the player's jump and dash, spiky ones hurt the
+// player. Killed enemies are appended to killed, which is returned.
+func stompEnemies(w *World, killed []EntityID, result *DamageResult, iframeFrames int) []EntityID {
+	playerID := w.PlayerID
+	if playerID == 0 || !w.Collision.Collides(LayerPlayer, LayerEnemy) {
+		return killed
+	}
+
+	// Lowest ID wins when landing on several heads at once
+	var enemyID EntityID
+	depth := -1
+	for _, id := range w.Query(TagEnemy) {
+		ai := w.AI[id]
+		if ai.Stomp == StompNone || ai.SpawnTimer > 0 || slices.Contains(killed, id) {
+			continue
+		}
+		if d := stompDepth(w, playerID, id); d >= 0 && (enemyID == 0 || id < enemyID) {
+			enemyID, depth = id, d
+		}
+	}
+	if enemyID == 0 {
+		return killed
+	}
+	ai := w.AI[enemyID]
+	spiky := ai.Stomp == StompSpiky
+	if spiky && w.IsInvincible(playerID) {
+		return killed
+	}
+
+	// Out onto the head and back up
+	pos := w.Position[playerID]
+	pos.Y = (pos.PixelY() - depth) * PositionScale
+	w.Position[playerID] = pos
+	mov := w.Movement[playerID]
+	mov.OnGround = false
+	w.Movement[playerID] = mov
+
+	if spiky {
+		health := w.Health[playerID]
+		health.Current -= ai.ContactDamage
+		w.Health[playerID] = health
+		GrantIframes(w, playerID, iframeFrames)
+		Stun(w, playerID, 0)
+
+		result.PlayerDamaged = true
+		result.PlayerKnockback.VX = 0
+		result.PlayerKnockback.VY = -w.Stomp.Bounce
+		w.Feedback.Trigger(FeedbackPlayerHurt)
+		w.Events.Emit(Event{Type: EventPlayerDamaged, Entity: enemyID, Amount: ai.ContactDamage})
+		return killed
+	}
+
+	vel := w.Velocity[playerID]
+	vel.Y = -w.Stomp.Bounce
+	w.Velocity[playerID] = vel
+	player := w.PlayerData[playerID]
+	player.CoyoteTimer = w.Stomp.JumpFrames
+	w.PlayerData[playerID] = player
+	dash := w.Dash[playerID]
+	dash.CanDash = true
+	w.Dash[playerID] = dash
+
+	if w.IsInvincible(enemyID) {
+		return killed
+	}
+	health := w.Health[enemyID]
+	damage := eliteDamage(w, enemyID, w.Stomp.Damage)
+	health.Current -= damage
+	w.Events.Emit(Event{Type: EventStomp, Entity: enemyID, Amount: damage})
+	Stun(w, enemyID, 0)
+	if health.Current <= 0 {
+		w.Feedback.TriggerDir(FeedbackHeavyHit, 0, 1)
+		killed = append(killed, enemyID)
+	} else {
+		w.Feedback.TriggerDir(FeedbackLightHit, 0, 1)
+		w.Health[enemyID] = health
+	}
+	return killed
+}
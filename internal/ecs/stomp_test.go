@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStompTestWorld(kind StompKind, enemyHealth int) (*World, EntityID, EntityID) {
+	w, player, enemy := newSolidTestWorld(104, 78) // feet 2px into the enemy's top
+	ai := w.AI[enemy]
+	ai.Solid = false
+	ai.Stomp = kind
+	w.AI[enemy] = ai
+	w.Health[enemy] = Health{Current: enemyHealth, Max: enemyHealth}
+	w.Velocity[player] = Velocity{Y: 300}
+	w.Stomp = StompConfig{Damage: 25, Bounce: 200, JumpFrames: 6}
+	return w, player, enemy
+}
+
+func TestStomp_BouncesAndDamagesEnemy(t *testing.T) {
+	w, player, enemy := newStompTestWorld(StompStompable, 100)
+	dash := w.Dash[player]
+	dash.CanDash = false
+	w.Dash[player] = dash
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 100, w.Health[player].Current)
+	assert.Equal(t, 75, w.Health[enemy].Current)
+	assert.Equal(t, 76, w.Position[player].PixelY(), "feet pushed out onto the head")
+	assert.Equal(t, -200, w.Velocity[player].Y)
+	assert.Equal(t, 6, w.PlayerData[player].CoyoteTimer, "jump refreshed")
+	assert.True(t, w.Dash[player].CanDash, "dash refreshed")
+	assert.True(t, w.CrowdControl[enemy].IsStunned())
+}
+
+func TestStomp_KillsEnemy(t *testing.T) {
+	w, _, enemy := newStompTestWorld(StompStompable, 20)
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.NotContains(t, w.IsEnemy, enemy)
+}
+
+func TestStomp_SpikyHurtsPlayer(t *testing.T) {
+	w, player, enemy := newStompTestWorld(StompSpiky, 100)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.True(t, result.PlayerDamaged)
+	assert.Equal(t, 90, w.Health[player].Current)
+	assert.Equal(t, 100, w.Health[enemy].Current)
+	assert.Equal(t, 0, result.PlayerKnockback.VX)
+	assert.Equal(t, -200, result.PlayerKnockback.VY, "launched back up")
+	assert.True(t, w.IsInvincible(player))
+}
+
+func TestStomp_IgnoresRisingAndSideHits(t *testing.T) {
+	w, player, enemy := newStompTestWorld(StompStompable, 100)
+	w.Velocity[player] = Velocity{Y: -100}
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 100, w.Health[enemy].Current, "jumping up into it")
+
+	w, player, enemy = newStompTestWorld(StompStompable, 100)
+	w.Position[player] = Position{X: 88 * PositionScale, Y: 100 * PositionScale}
+	UpdateDamage(w, 100, 50, 60)
+	assert.Equal(t, 100, w.Health[enemy].Current, "falling past its side")
+	assert.Equal(t, 90, w.Health[player].Current, "side contact still hurts")
+}
+
+func TestStomp_RegularEnemyHurtsPlayer(t *testing.T) {
+	w, player, enemy := newStompTestWorld(StompNone, 100)
+	w.Position[player] = Position{X: 104 * PositionScale, Y: 90 * PositionScale} // body inside the enemy
+
+	UpdateDamage(w, 100, 50, 60)
+
+	assert.Equal(t, 100, w.Health[enemy].Current)
+	assert.Equal(t, 90, w.Health[player].Current)
+}
+
+func TestParseStompKind(t *testing.T) {
+	for _, kind := range []StompKind{StompStompable, StompSpiky} {
+		parsed, ok := ParseStompKind(kind.String())
+		assert.True(t, ok)
+		assert.Equal(t, kind, parsed)
+	}
+	_, ok := ParseStompKind("bouncy")
+	assert.False(t, ok)
+}
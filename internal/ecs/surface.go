@@ -0,0 +1,39 @@
+package ecs
+
+// Surface holds the movement and hazard properties of a tile.
+// The zero value is ordinary ground.
+type Surface struct {
+	FrictionPct  int  // deceleration in percent of normal (0 = normal; ice is low)
+	SpeedPct     int  // max speed in percent of normal (0 = normal)
+	NoDash       bool // dashing is disabled (sticky)
+	KnockbackPct int  // hazard bounce in percent of HazardConfig.Bounce (0 = normal)
+	Burn         int  // damage per burn tick after touching it (lava)
+
+	// Damage tiles that only hurt one side (traps the player can lure
+	// enemies into, or ones enemies walk over safely)
+	SparesPlayer  bool
+	SparesEnemies bool
+
+	// Crumbling platforms shake for CrumbleFrames after being stood on,
+	// then fall and respawn after RespawnFrames (0 = never)
+	CrumbleFrames int
+	RespawnFrames int
+}
+
+// spares reports whether the surface's damage leaves the player (or
+// enemies) unharmed
+func (s Surface) spares(enemy bool) bool {
+	if enemy {
+		return s.SparesEnemies
+	}
+	return s.SparesPlayer
+}
+
+// groundSurface returns the surface under the middle of a pixel rect's
+// bottom edge, or ordinary ground when not standing
+func groundSurface(stage Stage, onGround bool, x, y, w, h int) Surface {
+	if !onGround {
+		return Surface{}
+	}
+	return stage.GetSurface(x+w/2, y+h)
+}
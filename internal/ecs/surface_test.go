@@ -0,0 +1,109 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSurfaceTestPlayer(surface Surface) (*World, EntityID) {
+	w := NewWorld()
+	id := w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	w.Facing[id] = Facing{Right: true}
+	dash := w.Dash[id]
+	dash.CanDash = true
+	w.Dash[id] = dash
+	w.Movement[id] = Movement{OnGround: true, Surface: surface}
+	return w, id
+}
+
+func TestUpdatePlayerInput_IceDeceleratesSlowly(t *testing.T) {
+	cfg := PhysicsConfig{MaxSpeed: 100, Deceleration: 20}
+
+	w, id := newSurfaceTestPlayer(Surface{})
+	w.Velocity[id] = Velocity{X: 100}
+	UpdatePlayerInput(w, InputState{}, cfg)
+	assert.Equal(t, 80, w.Velocity[id].X)
+
+	w, id = newSurfaceTestPlayer(Surface{FrictionPct: 10})
+	w.Velocity[id] = Velocity{X: 100}
+	UpdatePlayerInput(w, InputState{}, cfg)
+	assert.Equal(t, 98, w.Velocity[id].X)
+}
+
+func TestUpdatePlayerInput_StickySlowsAndBlocksDash(t *testing.T) {
+	cfg := PhysicsConfig{MaxSpeed: 100, Acceleration: 1000, DashFrames: 10, DashSpeed: 300}
+	w, id := newSurfaceTestPlayer(Surface{SpeedPct: 50, NoDash: true})
+
+	UpdatePlayerInput(w, InputState{Right: true, Dash: true}, cfg)
+
+	assert.Equal(t, 50, w.Velocity[id].X)
+	assert.False(t, w.Dash[id].Active)
+	assert.True(t, w.Dash[id].CanDash)
+}
+
+func TestUpdatePlayerPhysics_RecordsGroundSurface(t *testing.T) {
+	stage := newHazardStage()
+	stage.setSolid(6, 8) // pixels 96..111, y 128..143
+	stage.surface[[2]int{6, 8}] = Surface{FrictionPct: 10}
+	w, player, enemy := newSolidTestWorld(96, 104) // feet bottom at y 128
+	w.DestroyEntity(enemy)
+	w.Velocity[player] = Velocity{Y: PositionScale}
+	cfg := PhysicsConfig{MaxFallSpeed: 1000}
+
+	UpdatePlayerPhysics(w, stage, cfg)
+	assert.True(t, w.Movement[player].OnGround)
+	assert.Equal(t, Surface{FrictionPct: 10}, w.Movement[player].Surface)
+
+	w.Position[player] = Position{X: 96 * PositionScale, Y: 40 * PositionScale}
+	w.Velocity[player] = Velocity{Y: PositionScale}
+	UpdatePlayerPhysics(w, stage, cfg)
+	assert.Equal(t, Surface{}, w.Movement[player].Surface, "no surface in the air")
+}
+
+func TestApplyEnemyGravity_RecordsGroundSurface(t *testing.T) {
+	stage := newHazardStage()
+	stage.setSolid(2, 6) // pixels 32..47, y 96..111
+	stage.surface[[2]int{2, 6}] = Surface{SpeedPct: 50, NoDash: true}
+	w := NewWorld()
+	id := w.CreateEnemy(32, 80, EnemyConfig{MaxHealth: 10, HitboxWidth: 16, HitboxHeight: 16}, true)
+	w.Movement[id] = Movement{OnGround: true}
+
+	ApplyEnemyGravity(w, stage, 5, 100)
+
+	assert.Equal(t, 50, w.Movement[id].Surface.SpeedPct)
+}
+
+func TestUpdateTileHazards_LavaBurnsAndLaunches(t *testing.T) {
+	stage := newHazardStage()
+	stage.damage[[2]int{6, 7}] = 40 // pixels 96..111, 112..127
+	stage.surface[[2]int{6, 7}] = Surface{KnockbackPct: 200, Burn: 5}
+	w, player, enemy := newSolidTestWorld(96, 96) // feet at y 114..119
+	w.DestroyEntity(enemy)
+	cfg := testHazard
+	cfg.BurnFrames, cfg.BurnTickFrames = 4, 2
+
+	UpdateTileHazards(w, stage, cfg)
+	assert.Equal(t, 60, w.Health[player].Current)
+	assert.Equal(t, -240, w.CrowdControl[player].KnockbackVelY)
+	assert.True(t, w.CrowdControl[player].IsBurning())
+
+	// Moved off the lava: burn ticks every 2 frames through iframes
+	w.Position[player] = Position{X: 96 * PositionScale, Y: 40 * PositionScale}
+	for range 5 {
+		UpdateTileHazards(w, stage, cfg)
+	}
+	assert.Equal(t, 50, w.Health[player].Current)
+	assert.False(t, w.CrowdControl[player].IsBurning())
+}
+
+func TestUpdateTileHazards_BurnKillsEnemy(t *testing.T) {
+	w := NewWorld()
+	enemy := w.CreateEnemy(32, 32, EnemyConfig{MaxHealth: 5, HitboxWidth: 12, HitboxHeight: 12}, true)
+	Ignite(w, enemy, 5, 1)
+
+	UpdateTileHazards(w, newHazardStage(), testHazard)
+
+	assert.NotContains(t, w.IsEnemy, enemy)
+	assert.Contains(t, w.Events.Drain(), Event{Type: EventEnemyKilled, Entity: enemy})
+}
@@ -0,0 +1,158 @@
+package ecs
+
+// Flock configures swarm AI steering. Weights are in percent of the
+// enemy's MoveSpeed.
+type Flock struct {
+	Radius     int // pixels: flockmates this close steer each other
+	Separation int // away from flockmates closer than half the radius
+	Alignment  int // toward the flockmates' average velocity
+	Cohesion   int // toward the flockmates' center
+	Seek       int // toward the player while aware of them
+}
+
+// swarmSteerDiv is the fraction (1/n) of the gap to the desired velocity
+// a swarm member closes each frame
+const swarmSteerDiv = 4
+
+// swarmCell is a spatial hash cell key (flocks of different radii bucket
+// separately)
+type swarmCell struct{ x, y, size int }
+
+// UpdateSwarm steers swarm enemies (call once per frame, before the
+// substeps). Each member blends separation, alignment and cohesion with
+// nearby flockmates, found through a spatial hash with cells of the flock
+// radius, and seeks the player once it spots them or is alerted.
+func UpdateSwarm(w *World) {
+	var members []EntityID
+	for _, id := range w.Query(TagEnemy) {
+		if w.AI[id].Type == AISwarm {
+			members = append(members, id)
+		}
+	}
+	if len(members) == 0 {
+		return
+	}
+
+	// Bucket members by cell so each only checks its 3x3 neighbourhood
+	cells := make(map[swarmCell][]EntityID)
+	for _, id := range members {
+		cell := swarmCellOf(w, id)
+		cells[cell] = append(cells[cell], id)
+	}
+
+	var playerX, playerY int
+	if w.PlayerID != 0 {
+		playerX, playerY = HitboxCenter(w, w.PlayerID)
+	}
+
+	// Steer from a snapshot of velocities so update order doesn't matter
+	desired := make([]Velocity, len(members))
+	for i, id := range members {
+		desired[i] = swarmDesired(w, id, cells, playerX, playerY)
+	}
+
+	for i, id := range members {
+		ai := w.AI[id]
+		cc := w.CrowdControl[id]
+		if cc.IsStunned() || cc.InKnockback() || ai.SpawnTimer > 0 || cc.IsRooted() {
+			continue
+		}
+		vel := w.Velocity[id]
+		vel.X += (desired[i].X - vel.X) / swarmSteerDiv
+		vel.Y += (desired[i].Y - vel.Y) / swarmSteerDiv
+		w.Velocity[id] = vel
+	}
+}
+
+// swarmCellOf returns the spatial hash cell of a swarm member
+func swarmCellOf(w *World, id EntityID) swarmCell {
+	size := max(1, w.AI[id].Flock.Radius)
+	x, y := HitboxCenter(w, id)
+	return swarmCell{floorDiv(x, size), floorDiv(y, size), size}
+}
+
+// swarmDesired returns the velocity (IU/substep) a swarm member steers toward
+func swarmDesired(w *World, id EntityID, cells map[swarmCell][]EntityID, playerX, playerY int) Velocity {
+	ai := w.AI[id]
+	flock := ai.Flock
+	speed := ai.MoveSpeed
+	x, y := HitboxCenter(w, id)
+	vel := w.Velocity[id]
+
+	var sepX, sepY, aliX, aliY, cohX, cohY, count int
+	radiusSq := flock.Radius * flock.Radius
+	cell := swarmCellOf(w, id)
+	for cy := cell.y - 1; cy <= cell.y+1; cy++ {
+		for cx := cell.x - 1; cx <= cell.x+1; cx++ {
+			for _, other := range cells[swarmCell{cx, cy, cell.size}] {
+				if other == id || w.AI[other].Flock != flock {
+					continue
+				}
+				ox, oy := HitboxCenter(w, other)
+				dx, dy := x-ox, y-oy
+				d2 := dx*dx + dy*dy
+				if d2 > radiusSq {
+					continue
+				}
+				if 4*d2 < radiusSq {
+					sepX += dx
+					sepY += dy
+				}
+				otherVel := w.Velocity[other]
+				aliX += otherVel.X
+				aliY += otherVel.Y
+				cohX += ox
+				cohY += oy
+				count++
+			}
+		}
+	}
+
+	var steerX, steerY int
+	add := func(dx, dy, weight int) {
+		dx, dy = scaleVector(dx, dy, speed)
+		steerX += dx * weight / 100
+		steerY += dy * weight / 100
+	}
+	if count > 0 {
+		add(sepX, sepY, flock.Separation)
+		add(aliX/count-vel.X, aliY/count-vel.Y, flock.Alignment)
+		add(cohX/count-x, cohY/count-y, flock.Cohesion)
+	}
+	if w.PlayerID != 0 && (ai.Alerted() || abs(playerX-x)+abs(playerY-y) <= ai.DetectRange) {
+		add(playerX-x, playerY-y, flock.Seek)
+	}
+
+	// Never faster than the member's own speed
+	if mag := isqrt(steerX*steerX + steerY*steerY); mag > speed {
+		steerX, steerY = scaleVector(steerX, steerY, speed)
+	}
+	return Velocity{X: steerX, Y: steerY}
+}
+
+// scaleVector returns (x, y) rescaled to the given length ((0, 0) stays)
+func scaleVector(x, y, length int) (int, int) {
+	mag := isqrt(x*x + y*y)
+	if mag == 0 {
+		return 0, 0
+	}
+	return divRound(x*length, mag), divRound(y*length, mag)
+}
+
+// floorDiv divides rounding toward negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// updateSwarmAI moves a swarm member along the velocity UpdateSwarm chose
+func updateSwarmAI(stage Stage, hb Hitbox, pos *Position, vel *Velocity, facing *Facing, mov *Movement) {
+	moveEnemyKnockbackX(stage, hb, pos, vel, vel.X)
+	moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
+	if vel.X != 0 {
+		facing.Right = vel.X > 0
+	}
+}
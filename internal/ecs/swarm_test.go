@@ -0,0 +1,84 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFlock = Flock{Radius: 48, Separation: 150, Alignment: 50, Cohesion: 50, Seek: 100}
+
+func createSwarmling(w *World, x, y int) EntityID {
+	return w.CreateEnemy(x, y, EnemyConfig{
+		MaxHealth:    5,
+		AIType:       AISwarm,
+		DetectRange:  400,
+		MoveSpeed:    40,
+		Flying:       true,
+		HitboxWidth:  8,
+		HitboxHeight: 8,
+		Flock:        testFlock,
+	}, true)
+}
+
+// stepSwarm runs one frame of swarm steering and movement
+func stepSwarm(w *World, stage Stage) {
+	UpdateSwarm(w)
+	for range SubstepsPerFrame {
+		UpdateEnemyAI(w, stage, ProjectileConfig{}, PhysicsConfig{})
+	}
+}
+
+func TestUpdateSwarm_ConvergesOnPlayer(t *testing.T) {
+	w := newAimWorld(400, 200)
+	stage := newMockStage(100, 100, 16)
+	var swarm []EntityID
+	for i := range 5 {
+		swarm = append(swarm, createSwarmling(w, 100+i*10, 100+i*6))
+	}
+
+	for range 300 {
+		stepSwarm(w, stage)
+	}
+
+	px, py := HitboxCenter(w, w.PlayerID)
+	for _, id := range swarm {
+		x, y := HitboxCenter(w, id)
+		assert.Less(t, abs(px-x)+abs(py-y), 60, "swarmling %d reached the player", id)
+	}
+}
+
+func TestUpdateSwarm_SeparatesCrowdedMembers(t *testing.T) {
+	w := NewWorld() // no player: flocking only
+	stage := newMockStage(100, 100, 16)
+	a := createSwarmling(w, 200, 200)
+	b := createSwarmling(w, 204, 200)
+
+	for range 10 {
+		stepSwarm(w, stage)
+	}
+
+	ax, _ := HitboxCenter(w, a)
+	bx, _ := HitboxCenter(w, b)
+	assert.Greater(t, bx-ax, 4)
+}
+
+func TestUpdateSwarm_IgnoresOtherFlocks(t *testing.T) {
+	w := NewWorld()
+	createSwarmling(w, 200, 200)
+	other := createSwarmling(w, 204, 200)
+	ai := w.AI[other]
+	ai.Flock.Radius = 64
+	w.AI[other] = ai
+
+	UpdateSwarm(w)
+
+	assert.Equal(t, Velocity{}, w.Velocity[other])
+}
+
+func TestFloorDiv(t *testing.T) {
+	assert.Equal(t, 1, floorDiv(7, 4))
+	assert.Equal(t, -2, floorDiv(-7, 4))
+	assert.Equal(t, -1, floorDiv(-4, 4))
+	assert.Equal(t, 0, floorDiv(0, 4))
+}
@@ -0,0 +1,21 @@
+package ecs
+
+// sweptSpeed is the speed (IU/substep, on either axis) from which
+// projectiles are swept through the tile grid instead of stepped one IU at
+// a time: a pixel per substep, well above an arrow's
+const sweptSpeed = PositionScale
+
+// sweepProjectile moves a projectile by its velocity in one pass over the
+// tiles its path crosses and sticks it at the exact point it enters the
+// first solid one. The cost is one check per tile crossed, not per IU
+// moved, and no tile on the path is skipped.
+func sweepProjectile(stage Stage, pos *Position, vel *Velocity, proj *Projectile) {
+	x, y, hit := castRay(stage, pos.X, pos.Y, pos.X+vel.X, pos.Y+vel.Y, PositionScale)
+	if !hit {
+		pos.X += vel.X
+		pos.Y += vel.Y
+		return
+	}
+	pos.X, pos.Y = x, y
+	stickProjectile(vel, proj)
+}
@@ -0,0 +1,82 @@
+package ecs
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweepProjectile_StopsAtThinWall(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(10, 6)
+	pos := Position{X: 100 * PositionScale, Y: 100 * PositionScale}
+	vel := Velocity{X: 60 * PositionScale, Y: 2 * PositionScale}
+	var proj Projectile
+
+	sweepProjectile(stage, &pos, &vel, &proj)
+
+	assert.True(t, proj.Stuck)
+	assert.Equal(t, 160, pos.PixelX(), "at the wall's near edge")
+	assert.Equal(t, 102, pos.PixelY())
+	assert.Equal(t, Velocity{}, vel)
+}
+
+func TestSweepProjectile_StuckInsideWall(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(6, 6)
+	pos := Position{X: 100 * PositionScale, Y: 100 * PositionScale}
+	vel := Velocity{X: -10 * PositionScale}
+	var proj Projectile
+
+	sweepProjectile(stage, &pos, &vel, &proj)
+
+	assert.True(t, proj.Stuck)
+	assert.Equal(t, 100, pos.PixelX())
+}
+
+// The sweep ends in the same tile as stepping one IU at a time
+func TestSweepProjectile_MatchesStepping(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	stage := newMockStage(40, 40, 16)
+	for range 150 {
+		stage.setSolid(1+rng.Intn(38), 1+rng.Intn(38))
+	}
+
+	for range 2000 {
+		start := Position{X: (200 + rng.Intn(240)) * PositionScale, Y: (200 + rng.Intn(240)) * PositionScale}
+		if stage.IsSolidAt(start.PixelX(), start.PixelY()) {
+			continue
+		}
+		v := Velocity{X: rng.Intn(16*PositionScale) - 8*PositionScale, Y: rng.Intn(16*PositionScale) - 8*PositionScale}
+
+		sPos, sVel, sProj := start, v, Projectile{}
+		stepProjectile(stage, &sPos, &sVel, &sProj)
+		wPos, wVel, wProj := start, v, Projectile{}
+		sweepProjectile(stage, &wPos, &wVel, &wProj)
+
+		if !assert.Equal(t, sProj.Stuck, wProj.Stuck, "from %v by %v", start, v) {
+			continue
+		}
+		if sProj.Stuck {
+			assert.Equal(t, sPos.PixelX()/16, wPos.PixelX()/16, "from %v by %v", start, v)
+			assert.Equal(t, sPos.PixelY()/16, wPos.PixelY()/16, "from %v by %v", start, v)
+		} else {
+			assert.Equal(t, sPos, wPos)
+		}
+	}
+}
+
+func TestMoveProjectile_FastShotsAreSwept(t *testing.T) {
+	w := NewWorld()
+	stage := newMockStage(40, 40, 16)
+	stage.setSolid(20, 6)
+	proj := w.CreateProjectile(100, 100, 40*PositionScale, 0, ProjectileConfig{MaxRange: 600}, FactionPlayer)
+
+	for range 8 {
+		UpdateProjectiles(w, stage)
+	}
+
+	assert.True(t, w.ProjectileData[proj].Stuck)
+	assert.Equal(t, 320, w.Position[proj].PixelX())
+}
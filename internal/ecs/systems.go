@@ -2,6 +2,9 @@ package ecs
 
 import (
 	"math"
+	"slices"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
 )
 
 // Stage interface for collision detection
@@ -9,42 +12,31 @@ type Stage interface {
 	IsSolidAt(px, py int) bool
 	GetTileType(px, py int) int
 	GetTileDamage(px, py int) int
+	GetSurface(px, py int) Surface
+	SetTileFallen(tx, ty int, fallen bool) // crumbling tiles: fallen tiles aren't solid
 	GetWidth() int
 	GetHeight() int
 	GetTileSize() int
 	GetSpawnX() int
 	GetSpawnY() int
+	Raycast(x0, y0, x1, y1 int) (RayHit, bool) // first solid tile on a segment (see RaycastTiles)
 }
 
 const (
-	TileEmpty = 0
-	TileWall  = 1
-	TileSpike = 2
+	TileEmpty  = 0
+	TileWall   = 1
+	TileSpike  = 2
+	TileLava   = 3
+	TileIce    = 4
+	TileSticky = 5
 )
 
-// ToIUPerSubstep converts pixels/sec to IU/substep.
-// Formula: pixels_per_sec * PositionScale / 60 / 10
-// = pixels_per_sec * 256 / 600
-func ToIUPerSubstep(pixelsPerSec float64) int {
-	return int(pixelsPerSec * float64(PositionScale) / 600.0)
-}
-
-// ToIUAccelPerFrame converts pixels/sec² to IU velocity change per frame.
-// Acceleration: velocity changes by (accel / 60) pixels/sec per frame.
-// Convert to IU/substep: * 256 / 600
-// Combined: pixels_per_sec_sq * 256 / 36000
-func ToIUAccelPerFrame(pixelsPerSecSq float64) int {
-	return int(pixelsPerSecSq * float64(PositionScale) / 36000.0)
-}
-
-// PctToInt converts a 0.0-1.0+ float to 0-100+ percentage int.
-func PctToInt(f float64) int {
-	return int(f * 100)
-}
+// SubstepsPerFrame is how many physics substeps run per frame at normal speed
+const SubstepsPerFrame = fixedpoint.SubstepsPerFrame
 
 // PhysicsConfig holds physics configuration.
 // All velocity/acceleration values are in IU (internal units) per substep.
-// Conversion: pixels_per_sec * PositionScale / 600
+// Conversion: see package fixedpoint
 type PhysicsConfig struct {
 	// Physics (IU per substep)
 	Gravity      int // IU/substep²
@@ -62,6 +54,7 @@ type PhysicsConfig struct {
 	VarJumpPct        int // 0-100 (percentage of jump force when released early)
 	CoyoteFrames      int
 	JumpBufferFrames  int
+	InputGraceFrames  int // added to both windows to compensate for input lag (set per frame)
 	ApexModEnabled    bool
 	ApexThreshold     int // IU/substep (velocity threshold for apex modifier)
 	ApexGravityPct    int // 0-100 (percentage of gravity at apex)
@@ -72,31 +65,36 @@ type PhysicsConfig struct {
 	DashFrames         int
 	DashCooldownFrames int
 	DashIframes        int
+	DashDirections     DashDirections // allowed dash directions
+	DashToAim          bool           // dash toward the aim when no direction is held
+
+	// Crouch and slide
+	CrouchSpeedPct int // 0-100 (percentage of max speed while crouched)
+	SlideMinSpeed  int // IU/substep needed to slide when crouching
+	SlideFrames    int
+	SlideDecel     int // IU/substep per frame
 
 	// Collision
 	CornerCorrectionMargin  int
 	CornerCorrectionEnabled bool
-
-	// Knockback
-	KnockbackDecay int // IU/frame linear deceleration during stun
 }
 
 // UpdateTimers decrements all frame-based timers
 func UpdateTimers(w *World) {
 	// Player timers
-	for id := range w.IsPlayer {
+	for _, id := range w.Query(TagPlayer) {
 		player := w.PlayerData[id]
 		if player.CoyoteTimer > 0 {
 			player.CoyoteTimer--
 		}
 		if player.JumpBufferTimer > 0 {
 			player.JumpBufferTimer--
+			if player.JumpBufferTimer == 0 {
+				w.Events.Emit(Event{Type: EventJumpMissed})
+			}
 		}
-		if player.IframeTimer > 0 {
-			player.IframeTimer--
-		}
-		if player.StunTimer > 0 {
-			player.StunTimer--
+		if player.SummonCooldown > 0 {
+			player.SummonCooldown--
 		}
 		w.PlayerData[id] = player
 
@@ -120,54 +118,56 @@ func UpdateTimers(w *World) {
 		}
 	}
 
-	// Enemy AI timers and knockback deceleration
-	for id := range w.IsEnemy {
+	// Stun, knockback deceleration and i-frames
+	UpdateCrowdControl(w)
+
+	// Enemy AI timers
+	for _, id := range w.Query(TagEnemy) {
 		ai := w.AI[id]
-		if ai.HitTimer > 0 {
-			ai.HitTimer--
-
-			// Calculate velocity proportional to remaining HitTimer
-			// This ensures velocity reaches 0 exactly when HitTimer reaches 0
-			vel := w.Velocity[id]
-			if ai.HitTimerMax > 0 {
-				// vel = initialVel * (remainingTimer / maxTimer)
-				vel.X = ai.KnockbackVelX * ai.HitTimer / ai.HitTimerMax
-				vel.Y = ai.KnockbackVelY * ai.HitTimer / ai.HitTimerMax
-			} else {
-				vel.X = 0
-				vel.Y = 0
-			}
-			w.Velocity[id] = vel
-		}
 		if ai.AttackTimer > 0 {
 			ai.AttackTimer--
 		}
+		if ai.SpawnTimer > 0 {
+			ai.SpawnTimer--
+		}
+		if ai.WindupTimer > 0 {
+			ai.WindupTimer--
+		}
 		w.AI[id] = ai
 	}
+	updateEliteRegen(w)
+
+	// NPC patrol pauses
+	for _, id := range w.Query(TagNPC) {
+		npc := w.NPC[id]
+		if npc.PauseTimer > 0 {
+			npc.PauseTimer--
+			w.NPC[id] = npc
+		}
+	}
 
 	// Projectile stuck timers
-	toDestroy := make([]EntityID, 0)
-	for id := range w.IsProjectile {
+	for _, id := range w.Query(TagProjectile) {
 		proj := w.ProjectileData[id]
 		if proj.Stuck {
 			proj.StuckTimer++
 			if proj.StuckTimer >= proj.StuckDuration {
-				toDestroy = append(toDestroy, id)
+				w.Defer(Destroy(id))
 				continue
 			}
 			w.ProjectileData[id] = proj
+		} else if proj.Homing.Enabled() {
+			proj.Age++
+			w.ProjectileData[id] = proj
 		}
 	}
-	for _, id := range toDestroy {
-		w.DestroyEntity(id)
-	}
 
-	// Gold collect delay
-	for id := range w.IsGold {
-		gold := w.GoldData[id]
+	// Pickup collect delay
+	for _, id := range w.Query(TagPickup) {
+		gold := w.PickupData[id]
 		if gold.CollectDelay > 0 {
 			gold.CollectDelay--
-			w.GoldData[id] = gold
+			w.PickupData[id] = gold
 		}
 	}
 }
@@ -178,6 +178,7 @@ type InputState struct {
 	JumpPressed           bool
 	JumpReleased          bool
 	Dash                  bool
+	AimX, AimY            int // aim vector from the player (pixels, 0 = none)
 }
 
 // UpdatePlayerInput processes player input
@@ -193,25 +194,11 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 	mov := w.Movement[id]
 	vel := w.Velocity[id]
 	facing := w.Facing[id]
+	cc := w.CrowdControl[id]
+	crouch := w.Crouch[id]
 
-	// Skip if stunned (linear deceleration toward zero)
-	if player.IsStunned() {
-		decay := cfg.KnockbackDecay
-		if decay == 0 {
-			decay = 10 // default fallback
-		}
-		if vel.X > 0 {
-			vel.X -= decay
-			if vel.X < 0 {
-				vel.X = 0
-			}
-		} else if vel.X < 0 {
-			vel.X += decay
-			if vel.X > 0 {
-				vel.X = 0
-			}
-		}
-		w.Velocity[id] = vel
+	// Skip if stunned (knockback deceleration is applied in UpdateCrowdControl)
+	if cc.IsStunned() {
 		return
 	}
 
@@ -222,12 +209,18 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 
 	// Coyote time
 	if mov.OnGround {
-		player.CoyoteTimer = cfg.CoyoteFrames
+		player.CoyoteTimer = cfg.CoyoteFrames + cfg.InputGraceFrames
 	}
 
 	// Movement - MaxSpeed is already in IU/substep
 	targetVX := 0
 	maxSpeed := cfg.MaxSpeed
+	if mov.Surface.SpeedPct > 0 {
+		maxSpeed = fixedpoint.MulPct(maxSpeed, mov.Surface.SpeedPct)
+	}
+	if crouch.Crouching {
+		maxSpeed = fixedpoint.MulPct(maxSpeed, cfg.CrouchSpeedPct)
+	}
 
 	if input.Left {
 		targetVX = -maxSpeed
@@ -238,17 +231,27 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 		facing.Right = true
 	}
 
+	// Holding the shield or being rooted plants the player (turning is still allowed)
+	if player.Blocking || cc.IsRooted() {
+		targetVX = 0
+	}
+
 	// Air control (percentage)
 	if !mov.OnGround {
-		targetVX = targetVX * cfg.AirControlPct / 100
+		targetVX = fixedpoint.MulPct(targetVX, cfg.AirControlPct)
 	}
 
 	// Acceleration/Deceleration
-	if targetVX != 0 {
+	switch {
+	case cc.InKnockback():
+		// Knockback owns the horizontal velocity until it decays
+	case crouch.SlideTimer > 0:
+		// So does a slide (UpdatePlayerCrouch)
+	case targetVX != 0:
 		accel := cfg.Acceleration
 		// Turnaround boost (percentage)
 		if (vel.X > 0 && targetVX < 0) || (vel.X < 0 && targetVX > 0) {
-			accel = accel * cfg.TurnaroundPct / 100
+			accel = fixedpoint.MulPct(accel, cfg.TurnaroundPct)
 		}
 		// Approach target
 		if vel.X < targetVX {
@@ -262,9 +265,15 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 				vel.X = targetVX
 			}
 		}
-	} else {
-		// Deceleration
+	default:
+		// Deceleration (slippery surfaces and rain decelerate slower)
 		decel := cfg.Deceleration
+		if mov.Surface.FrictionPct > 0 {
+			decel = max(1, fixedpoint.MulPct(decel, mov.Surface.FrictionPct))
+		}
+		if w.Weather.Kind == WeatherRain && w.Weather.FrictionPct > 0 {
+			decel = max(1, fixedpoint.MulPct(decel, w.Weather.FrictionPct))
+		}
 		if vel.X > 0 {
 			vel.X -= decel
 			if vel.X < 0 {
@@ -280,13 +289,21 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 
 	// Jump buffer
 	if input.JumpPressed {
-		player.JumpBufferTimer = cfg.JumpBufferFrames
+		player.JumpBufferTimer = cfg.JumpBufferFrames + cfg.InputGraceFrames
 	}
 
 	// Jump - JumpForce is in IU/substep, negate for upward
-	canJump := mov.OnGround || player.CoyoteTimer > 0
+	canJump := (mov.OnGround || player.CoyoteTimer > 0) && !crouch.Crouching
 	wantsJump := player.JumpBufferTimer > 0
 	if canJump && wantsJump {
+		kind := JumpBuffered
+		if input.JumpPressed && mov.OnGround {
+			kind = JumpGround
+		} else if input.JumpPressed {
+			kind = JumpCoyote
+		}
+		w.Events.Emit(Event{Type: EventJump, Amount: int(kind)})
+
 		vel.Y = -cfg.JumpForce
 		mov.OnGround = false
 		player.CoyoteTimer = 0
@@ -295,23 +312,28 @@ func UpdatePlayerInput(w *World, input InputState, cfg PhysicsConfig) {
 
 	// Variable jump height (percentage)
 	if input.JumpReleased && vel.Y < 0 {
-		vel.Y = vel.Y * cfg.VarJumpPct / 100
+		vel.Y = fixedpoint.MulPct(vel.Y, cfg.VarJumpPct)
 	}
 
 	// Dash
-	if input.Dash && dash.CanDash && dash.Cooldown <= 0 {
+	if input.Dash && dash.CanDash && dash.Cooldown <= 0 && !mov.Surface.NoDash && w.Energy.spend(&player, w.Energy.DashCost) {
 		dash.Active = true
 		dash.Timer = cfg.DashFrames
 		dash.Cooldown = cfg.DashCooldownFrames
 		dash.CanDash = false
-		player.IframeTimer = cfg.DashIframes
+		dash.Hits = dash.Hits[:0]
+		GrantIframes(w, id, cfg.DashIframes)
 
-		dir := 1
-		if !facing.Right {
-			dir = -1
+		dx, dy := dashDirection(input, cfg, facing.Right, mov.OnGround)
+		vel.X, vel.Y = dashVelocity(dx, dy, cfg.DashSpeed)
+		if dx != 0 {
+			facing.Right = dx > 0
 		}
-		vel.X = dir * cfg.DashSpeed
-		vel.Y = 0
+		if dy < 0 {
+			mov.OnGround = false
+		}
+
+		w.Feedback.Trigger(FeedbackDash)
 	}
 
 	w.PlayerData[id] = player
@@ -337,18 +359,18 @@ func ApplyPlayerGravity(w *World, cfg PhysicsConfig) {
 		return
 	}
 
-	gravity := cfg.Gravity
+	gravity := mov.gravity(cfg.Gravity)
 
 	// Apex modifier (percentage)
 	if cfg.ApexModEnabled {
 		if abs(vel.Y) < cfg.ApexThreshold {
-			gravity = gravity * cfg.ApexGravityPct / 100
+			gravity = fixedpoint.MulPct(gravity, cfg.ApexGravityPct)
 		}
 	}
 
 	// Fall multiplier (percentage, 100 = normal)
 	if vel.Y > 0 {
-		gravity = gravity * cfg.FallMultiplierPct / 100
+		gravity = fixedpoint.MulPct(gravity, cfg.FallMultiplierPct)
 	}
 
 	vel.Y += gravity
@@ -411,6 +433,13 @@ func UpdatePlayerPhysics(w *World, stage Stage, cfg PhysicsConfig) {
 		resolvePlayerOverlap(w, id, stage, &pos, &vel, &mov, hitbox, facing.Right)
 	}
 
+	// Remember what the player stands on for the next frame's input
+	fx, fy, fw, fh := hitbox.Feet.GetWorldRect(pos.PixelX(), pos.PixelY(), facing.Right, hitbox.MirrorWidth())
+	mov.Surface = groundSurface(stage, mov.OnGround, fx, fy, fw, fh)
+	if mov.OnGround {
+		w.touchGround(stage, fx, fy+fh, fw)
+	}
+
 	// Update facing based on velocity
 	if vel.X > 0 {
 		facing.Right = true
@@ -472,7 +501,7 @@ func checkPlayerCollisionX(stage Stage, pos Position, hitbox HitboxTrapezoid, fa
 	pixelX := (pos.X + dx) / PositionScale
 	pixelY := pos.Y / PositionScale
 	hb := hitbox.Body
-	x, y, w, h := hb.GetWorldRect(pixelX, pixelY, facingRight, 16)
+	x, y, w, h := hb.GetWorldRect(pixelX, pixelY, facingRight, hitbox.MirrorWidth())
 	return isSolidRect(stage, x, y, w, h)
 }
 
@@ -486,7 +515,7 @@ func checkPlayerCollisionY(stage Stage, pos Position, hitbox HitboxTrapezoid, fa
 	} else {
 		hb = hitbox.Head
 	}
-	x, y, w, h := hb.GetWorldRect(pixelX, pixelY, facingRight, 16)
+	x, y, w, h := hb.GetWorldRect(pixelX, pixelY, facingRight, hitbox.MirrorWidth())
 	return isSolidRect(stage, x, y, w, h)
 }
 
@@ -498,7 +527,7 @@ func tryCornerCorrection(stage Stage, pos *Position, hitbox HitboxTrapezoid, fac
 	// Try nudging left
 	for i := PositionScale; i <= marginScaled; i += PositionScale {
 		testPixelX := (pos.X - i) / PositionScale
-		x, y, w, h := hb.GetWorldRect(testPixelX, pixelY, facingRight, 16)
+		x, y, w, h := hb.GetWorldRect(testPixelX, pixelY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, x, y, w, h) {
 			pos.X -= i
 			return
@@ -508,7 +537,7 @@ func tryCornerCorrection(stage Stage, pos *Position, hitbox HitboxTrapezoid, fac
 	// Try nudging right
 	for i := PositionScale; i <= marginScaled; i += PositionScale {
 		testPixelX := (pos.X + i) / PositionScale
-		x, y, w, h := hb.GetWorldRect(testPixelX, pixelY, facingRight, 16)
+		x, y, w, h := hb.GetWorldRect(testPixelX, pixelY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, x, y, w, h) {
 			pos.X += i
 			return
@@ -521,7 +550,7 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 	pixelX := pos.X / PositionScale
 	pixelY := pos.Y / PositionScale
 	hb := hitbox.Body
-	x, y, ww, h := hb.GetWorldRect(pixelX, pixelY, facingRight, 16)
+	x, y, ww, h := hb.GetWorldRect(pixelX, pixelY, facingRight, hitbox.MirrorWidth())
 
 	if !isSolidRect(stage, x, y, ww, h) {
 		return
@@ -537,7 +566,7 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 	for i := step; i <= maxPushOut; i += step {
 		// Left
 		testPX := (pos.X - i) / PositionScale
-		tx, ty, tw, th := hb.GetWorldRect(testPX, pixelY, facingRight, 16)
+		tx, ty, tw, th := hb.GetWorldRect(testPX, pixelY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, tx, ty, tw, th) {
 			options = append(options, pushOption{-i, 0, i})
 			break
@@ -546,7 +575,7 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 	for i := step; i <= maxPushOut; i += step {
 		// Right
 		testPX := (pos.X + i) / PositionScale
-		tx, ty, tw, th := hb.GetWorldRect(testPX, pixelY, facingRight, 16)
+		tx, ty, tw, th := hb.GetWorldRect(testPX, pixelY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, tx, ty, tw, th) {
 			options = append(options, pushOption{i, 0, i})
 			break
@@ -555,7 +584,7 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 	for i := step; i <= maxPushOut; i += step {
 		// Up
 		testPY := (pos.Y - i) / PositionScale
-		tx, ty, tw, th := hb.GetWorldRect(pixelX, testPY, facingRight, 16)
+		tx, ty, tw, th := hb.GetWorldRect(pixelX, testPY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, tx, ty, tw, th) {
 			options = append(options, pushOption{0, -i, i})
 			break
@@ -564,7 +593,7 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 	for i := step; i <= maxPushOut; i += step {
 		// Down
 		testPY := (pos.Y + i) / PositionScale
-		tx, ty, tw, th := hb.GetWorldRect(pixelX, testPY, facingRight, 16)
+		tx, ty, tw, th := hb.GetWorldRect(pixelX, testPY, facingRight, hitbox.MirrorWidth())
 		if !isSolidRect(stage, tx, ty, tw, th) {
 			options = append(options, pushOption{0, i, i})
 			break
@@ -602,11 +631,11 @@ func resolvePlayerOverlap(w *World, id EntityID, stage Stage, pos *Position, vel
 }
 
 func isSolidRect(stage Stage, x, y, w, h int) bool {
-	tileSize := 16 // TODO: get from stage
-	startTX := x / tileSize
-	endTX := (x + w - 1) / tileSize
-	startTY := y / tileSize
-	endTY := (y + h - 1) / tileSize
+	tileSize := stage.GetTileSize()
+	startTX := floorDiv(x, tileSize)
+	endTX := floorDiv(x+w-1, tileSize)
+	startTY := floorDiv(y, tileSize)
+	endTY := floorDiv(y+h-1, tileSize)
 
 	for ty := startTY; ty <= endTY; ty++ {
 		for tx := startTX; tx <= endTX; tx++ {
@@ -618,30 +647,40 @@ func isSolidRect(stage Stage, x, y, w, h int) bool {
 	return false
 }
 
+// unawareDistance is the player distance seen by enemies that aren't
+// hostile to the player: beyond every detect and attack range
+const unawareDistance = 1 << 30
+
 // UpdateEnemyAI updates enemy AI behavior for one substep
 // Gravity is applied separately via ApplyEnemyGravity (once per frame)
 func UpdateEnemyAI(w *World, stage Stage, arrowCfg ProjectileConfig, cfg PhysicsConfig) {
 	playerPos := w.GetPlayerPosition()
 	playerPX, playerPY := playerPos.PixelX(), playerPos.PixelY()
 
-	for id := range w.IsEnemy {
+	for _, id := range w.Query(TagEnemy) {
 		pos := w.Position[id]
 		vel := w.Velocity[id]
 		ai := w.AI[id]
 		facing := w.Facing[id]
 		mov := w.Movement[id]
+		cc := w.CrowdControl[id]
+		hb := w.Hitbox[id]
 
-		// If hit stunned, apply knockback movement (no AI control)
-		// Note: deceleration is applied in UpdateTimers (once per frame)
-		if ai.HitTimer > 0 {
+		// If hit stunned or spawning in, apply knockback movement (no AI control).
+		// Hits interrupt a telegraphed attack.
+		// Note: deceleration is applied in UpdateCrowdControl (once per frame)
+		if cc.IsStunned() || cc.InKnockback() || ai.SpawnTimer > 0 {
 			// Apply knockback movement (both X and Y)
-			moveEnemyKnockbackX(stage, &pos, &vel, vel.X)
+			moveEnemyKnockbackX(stage, hb, &pos, &vel, vel.X)
 			if !ai.Flying {
-				moveEnemyY(stage, &pos, &vel, &mov, vel.Y)
+				moveEnemyY(stage, hb, &pos, &vel, &mov, vel.Y)
 			}
+			ai.Telegraph = TelegraphNone
+			ai.Charging = false
 			w.Position[id] = pos
 			w.Velocity[id] = vel
 			w.Movement[id] = mov
+			w.AI[id] = ai
 			continue
 		}
 
@@ -650,17 +689,39 @@ func UpdateEnemyAI(w *World, stage Stage, arrowCfg ProjectileConfig, cfg Physics
 		dy := playerPY - py
 		// Approximate distance using taxicab metric for int
 		dist := abs(dx) + abs(dy)
+		if !w.Factions.Hostile(w.Faction[id], FactionPlayer) {
+			dist = unawareDistance // friends and wildlife leave the player alone
+		}
+
+		// Rooted enemies keep attacking but can't move; sticky ground slows them
+		moveSpeed, jumpForce, chargeSpeed := ai.MoveSpeed, ai.JumpForce, ai.ChargeSpeed
+		if cc.IsRooted() {
+			ai.MoveSpeed, ai.JumpForce, ai.ChargeSpeed = 0, 0, 0
+		} else if mov.Surface.SpeedPct > 0 {
+			ai.MoveSpeed = fixedpoint.MulPct(ai.MoveSpeed, mov.Surface.SpeedPct)
+			ai.ChargeSpeed = fixedpoint.MulPct(ai.ChargeSpeed, mov.Surface.SpeedPct)
+		}
 
 		switch ai.Type {
 		case AIPatrol:
-			updatePatrolAI(stage, &pos, &vel, &ai, &facing, &mov)
+			if ai.Alerted() {
+				updateChaseAI(stage, hb, &pos, &vel, &ai, &facing, &mov, dx, dy, dist)
+			} else {
+				ai.Charging = false
+				updatePatrolAI(stage, hb, &pos, &vel, &ai, &facing, &mov)
+			}
 		case AIAggressive:
-			updateAggressiveAI(w, stage, &pos, &vel, &ai, &facing, &mov, dx, dy, dist, arrowCfg)
+			updateAggressiveAI(w, stage, id, hb, &pos, &vel, &ai, &facing, &mov, dx, dy, dist, arrowCfg, w.Faction[id])
 		case AIRanged:
-			updateRangedAI(w, stage, &pos, &vel, &ai, &facing, &mov, dx, dist, arrowCfg)
+			updateRangedAI(w, stage, id, hb, &pos, &vel, &ai, &facing, &mov, dx, dist, arrowCfg, w.Faction[id])
 		case AIChase:
-			updateChaseAI(stage, &pos, &vel, &ai, &facing, &mov, dx, dy, dist)
+			updateChaseAI(stage, hb, &pos, &vel, &ai, &facing, &mov, dx, dy, dist)
+		case AISwarm:
+			updateSwarmAI(stage, hb, &pos, &vel, &facing, &mov)
+		case AICharger:
+			updateChargerAI(w, stage, id, hb, &pos, &vel, &ai, &facing, &mov, dx, dy, dist)
 		}
+		ai.MoveSpeed, ai.JumpForce, ai.ChargeSpeed = moveSpeed, jumpForce, chargeSpeed
 
 		w.Position[id] = pos
 		w.Velocity[id] = vel
@@ -670,10 +731,21 @@ func UpdateEnemyAI(w *World, stage Stage, arrowCfg ProjectileConfig, cfg Physics
 	}
 }
 
-func updatePatrolAI(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement) {
+func updatePatrolAI(stage Stage, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement) {
+	if len(ai.Route.Points) > 0 {
+		followRoute(stage, hb, pos, vel, ai, facing, mov)
+		return
+	}
+
+	// Turn around instead of walking off a ledge
+	if ai.TurnAtLedges && !ai.Flying && mov.OnGround && atLedge(stage, hb, pos, ai.PatrolDir) {
+		ai.PatrolDir = -ai.PatrolDir
+		facing.Right = ai.PatrolDir > 0
+	}
+
 	// Move using AI's MoveSpeed (already in IU/substep)
 	moveX := ai.PatrolDir * ai.MoveSpeed
-	moveEnemyX(stage, pos, vel, ai, facing, mov, moveX)
+	moveEnemyX(stage, hb, pos, vel, ai, facing, mov, moveX)
 
 	// Turn at patrol bounds
 	px := pos.PixelX()
@@ -687,22 +759,22 @@ func updatePatrolAI(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *F
 
 	// Apply Y movement from velocity (gravity is applied separately per frame)
 	if !ai.Flying {
-		moveEnemyY(stage, pos, vel, mov, vel.Y)
+		moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
 	}
 }
 
-func updateAggressiveAI(w *World, stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dy, dist int, arrowCfg ProjectileConfig) {
+func updateAggressiveAI(w *World, stage Stage, id EntityID, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dy, dist int, arrowCfg ProjectileConfig, faction Faction) {
 	// Apply Y movement from velocity (gravity is applied separately per frame)
-	moveEnemyY(stage, pos, vel, mov, vel.Y)
+	moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
 
 	// Face player
 	facing.Right = dx > 0
 
 	// Charge toward player using MoveSpeed (IU/substep)
 	if dx > 0 {
-		moveEnemyX(stage, pos, vel, ai, facing, mov, ai.MoveSpeed)
+		moveEnemyX(stage, hb, pos, vel, ai, facing, mov, ai.MoveSpeed)
 	} else if dx < 0 {
-		moveEnemyX(stage, pos, vel, ai, facing, mov, -ai.MoveSpeed)
+		moveEnemyX(stage, hb, pos, vel, ai, facing, mov, -ai.MoveSpeed)
 	}
 
 	// Jump if player above
@@ -713,54 +785,61 @@ func updateAggressiveAI(w *World, stage Stage, pos *Position, vel *Velocity, ai
 	}
 
 	// Shoot
-	if dist < ai.AttackRange && ai.AttackTimer <= 0 {
-		spawnEnemyArrow(w, pos, facing.Right, arrowCfg)
-		ai.AttackTimer = 90 // 1.5 seconds at 60fps
-	}
+	enemyShoot(w, stage, id, pos, hb, ai, facing, dist, arrowCfg, faction)
 }
 
-func updateRangedAI(w *World, stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dist int, arrowCfg ProjectileConfig) {
+func updateRangedAI(w *World, stage Stage, id EntityID, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dist int, arrowCfg ProjectileConfig, faction Faction) {
 	facing.Right = dx > 0
 
 	// Apply Y movement from velocity (gravity is applied separately per frame)
 	if !ai.Flying {
-		moveEnemyY(stage, pos, vel, mov, vel.Y)
+		moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
 	}
 
-	if dist < ai.AttackRange && ai.AttackTimer <= 0 {
-		spawnEnemyArrow(w, pos, facing.Right, arrowCfg)
-		ai.AttackTimer = 90
-	}
+	enemyShoot(w, stage, id, pos, hb, ai, facing, dist, arrowCfg, faction)
 }
 
-func updateChaseAI(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dy, dist int) {
+func updateChaseAI(stage Stage, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, dx, dy, dist int) {
 	// Apply Y movement from velocity (gravity is applied separately per frame)
 	if !ai.Flying {
-		moveEnemyY(stage, pos, vel, mov, vel.Y)
+		moveEnemyY(stage, hb, pos, vel, mov, vel.Y)
 	}
 
-	if dist > ai.DetectRange {
+	if dist > ai.DetectRange && !ai.Alerted() {
+		ai.Charging = false
+		if ai.Telegraph == TelegraphCharge {
+			ai.Telegraph = TelegraphNone
+		}
 		return
 	}
 
+	// Wind up before the first rush after spotting the player
+	if !ai.Charging {
+		facing.Right = dx > 0
+		if !windUp(ai, TelegraphCharge) {
+			return
+		}
+		ai.Charging = true
+	}
+
 	if dx > 0 {
-		moveEnemyX(stage, pos, vel, ai, facing, mov, ai.MoveSpeed)
+		moveEnemyX(stage, hb, pos, vel, ai, facing, mov, ai.MoveSpeed)
 		facing.Right = true
 	} else if dx < 0 {
-		moveEnemyX(stage, pos, vel, ai, facing, mov, -ai.MoveSpeed)
+		moveEnemyX(stage, hb, pos, vel, ai, facing, mov, -ai.MoveSpeed)
 		facing.Right = false
 	}
 
 	if ai.Flying {
 		if dy > 0 {
-			moveEnemyY(stage, pos, vel, mov, ai.MoveSpeed)
+			moveEnemyY(stage, hb, pos, vel, mov, ai.MoveSpeed)
 		} else if dy < 0 {
-			moveEnemyY(stage, pos, vel, mov, -ai.MoveSpeed)
+			moveEnemyY(stage, hb, pos, vel, mov, -ai.MoveSpeed)
 		}
 	}
 }
 
-func moveEnemyX(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, moveX int) {
+func moveEnemyX(stage Stage, hb Hitbox, pos *Position, vel *Velocity, ai *AI, facing *Facing, mov *Movement, moveX int) {
 	if moveX == 0 {
 		return
 	}
@@ -768,25 +847,18 @@ func moveEnemyX(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facin
 	step := sign(moveX)
 	steps := abs(moveX)
 
-	hitbox := Hitbox{OffsetX: 2, OffsetY: 4, Width: 12, Height: 20} // Default enemy hitbox
-
 	// moveX is in IU, step 1 IU at a time
 	for i := 0; i < steps; i++ {
 		// Check collision at next pixel boundary
 		nextPixelX := (pos.X + step) / PositionScale
 		if nextPixelX != pos.PixelX() {
-			// About to cross pixel boundary, check collision
-			x := nextPixelX + hitbox.OffsetX
-			y := pos.PixelY() + hitbox.OffsetY
-			w := hitbox.Width
-			h := hitbox.Height
-
-			checkX := x
+			// About to cross pixel boundary, check the leading edge
+			checkX := nextPixelX + hb.OffsetX
 			if step > 0 {
-				checkX = x + w - 1
+				checkX += hb.Width - 1
 			}
 
-			if stage.IsSolidAt(checkX, y) || stage.IsSolidAt(checkX, y+h-1) || stage.IsSolidAt(checkX, y+h/2) {
+			if isSolidRect(stage, checkX, pos.PixelY()+hb.OffsetY, 1, hb.Height) {
 				ai.PatrolDir *= -1
 				facing.Right = ai.PatrolDir > 0
 				return
@@ -796,8 +868,19 @@ func moveEnemyX(stage Stage, pos *Position, vel *Velocity, ai *AI, facing *Facin
 	}
 }
 
+// atLedge returns true if there is no ground within a tile below the pixel
+// column just ahead of the hitbox in direction dir
+func atLedge(stage Stage, hb Hitbox, pos *Position, dir int) bool {
+	frontX := pos.PixelX() + hb.OffsetX - 1
+	if dir > 0 {
+		frontX += hb.Width + 1
+	}
+	feetY := pos.PixelY() + hb.OffsetY + hb.Height
+	return !isSolidRect(stage, frontX, feetY, 1, stage.GetTileSize())
+}
+
 // moveEnemyKnockbackX moves enemy horizontally during knockback (no AI logic)
-func moveEnemyKnockbackX(stage Stage, pos *Position, vel *Velocity, moveX int) {
+func moveEnemyKnockbackX(stage Stage, hb Hitbox, pos *Position, vel *Velocity, moveX int) {
 	if moveX == 0 {
 		return
 	}
@@ -805,22 +888,15 @@ func moveEnemyKnockbackX(stage Stage, pos *Position, vel *Velocity, moveX int) {
 	step := sign(moveX)
 	steps := abs(moveX)
 
-	hitbox := Hitbox{OffsetX: 2, OffsetY: 4, Width: 12, Height: 20}
-
 	for i := 0; i < steps; i++ {
 		nextPixelX := (pos.X + step) / PositionScale
 		if nextPixelX != pos.PixelX() {
-			x := nextPixelX + hitbox.OffsetX
-			y := pos.PixelY() + hitbox.OffsetY
-			w := hitbox.Width
-			h := hitbox.Height
-
-			checkX := x
+			checkX := nextPixelX + hb.OffsetX
 			if step > 0 {
-				checkX = x + w - 1
+				checkX += hb.Width - 1
 			}
 
-			if stage.IsSolidAt(checkX, y) || stage.IsSolidAt(checkX, y+h-1) || stage.IsSolidAt(checkX, y+h/2) {
+			if isSolidRect(stage, checkX, pos.PixelY()+hb.OffsetY, 1, hb.Height) {
 				vel.X = 0
 				return
 			}
@@ -829,7 +905,7 @@ func moveEnemyKnockbackX(stage Stage, pos *Position, vel *Velocity, moveX int) {
 	}
 }
 
-func moveEnemyY(stage Stage, pos *Position, vel *Velocity, mov *Movement, moveY int) {
+func moveEnemyY(stage Stage, hb Hitbox, pos *Position, vel *Velocity, mov *Movement, moveY int) {
 	if moveY == 0 {
 		return
 	}
@@ -837,25 +913,18 @@ func moveEnemyY(stage Stage, pos *Position, vel *Velocity, mov *Movement, moveY
 	step := sign(moveY)
 	steps := abs(moveY)
 
-	hitbox := Hitbox{OffsetX: 2, OffsetY: 4, Width: 12, Height: 20}
-
 	// moveY is in IU, step 1 IU at a time
 	for i := 0; i < steps; i++ {
 		// Check collision at next pixel boundary
 		nextPixelY := (pos.Y + step) / PositionScale
 		if nextPixelY != pos.PixelY() {
-			// About to cross pixel boundary, check collision
-			x := pos.PixelX() + hitbox.OffsetX
-			y := nextPixelY + hitbox.OffsetY
-			w := hitbox.Width
-			h := hitbox.Height
-
-			checkY := y
+			// About to cross pixel boundary, check the leading edge
+			checkY := nextPixelY + hb.OffsetY
 			if step > 0 {
-				checkY = y + h - 1
+				checkY += hb.Height - 1
 			}
 
-			if stage.IsSolidAt(x, checkY) || stage.IsSolidAt(x+w-1, checkY) || stage.IsSolidAt(x+w/2, checkY) {
+			if isSolidRect(stage, pos.PixelX()+hb.OffsetX, checkY, hb.Width, 1) {
 				if step > 0 {
 					mov.OnGround = true
 				}
@@ -872,7 +941,7 @@ func moveEnemyY(stage Stage, pos *Position, vel *Velocity, mov *Movement, moveY
 // gravity: IU velocity change per frame
 // maxFall: max fall speed in IU/substep
 func ApplyEnemyGravity(w *World, stage Stage, gravity, maxFall int) {
-	for id := range w.IsEnemy {
+	for _, id := range w.Query(TagEnemy) {
 		ai := w.AI[id]
 		if ai.Flying {
 			continue
@@ -880,27 +949,28 @@ func ApplyEnemyGravity(w *World, stage Stage, gravity, maxFall int) {
 
 		mov := w.Movement[id]
 		vel := w.Velocity[id]
+		pos := w.Position[id]
+		hitbox := w.Hitbox[id]
 
 		// If on ground, verify ground still exists below
 		if mov.OnGround && vel.Y >= 0 {
-			pos := w.Position[id]
-			hitbox := w.Hitbox[id]
 			// Check 1 pixel below feet
 			checkY := pos.PixelY() + hitbox.OffsetY + hitbox.Height
-			groundExists := stage.IsSolidAt(pos.PixelX()+hitbox.OffsetX, checkY) ||
-				stage.IsSolidAt(pos.PixelX()+hitbox.OffsetX+hitbox.Width-1, checkY) ||
-				stage.IsSolidAt(pos.PixelX()+hitbox.OffsetX+hitbox.Width/2, checkY)
-			if !groundExists {
+			if !isSolidRect(stage, pos.PixelX()+hitbox.OffsetX, checkY, hitbox.Width, 1) {
 				mov.OnGround = false
-				w.Movement[id] = mov
 			}
 		}
+		mov.Surface = groundSurface(stage, mov.OnGround, pos.PixelX()+hitbox.OffsetX, pos.PixelY()+hitbox.OffsetY, hitbox.Width, hitbox.Height)
+		if mov.OnGround {
+			w.touchGround(stage, pos.PixelX()+hitbox.OffsetX, pos.PixelY()+hitbox.OffsetY+hitbox.Height, hitbox.Width)
+		}
+		w.Movement[id] = mov
 
 		if mov.OnGround {
 			continue
 		}
 
-		vel.Y += gravity
+		vel.Y += mov.gravity(gravity)
 		if vel.Y > maxFall {
 			vel.Y = maxFall
 		}
@@ -910,26 +980,32 @@ func ApplyEnemyGravity(w *World, stage Stage, gravity, maxFall int) {
 
 // ApplyProjectileGravity applies gravity to all projectiles (call once per frame)
 func ApplyProjectileGravity(w *World) {
-	for id := range w.IsProjectile {
+	for _, id := range w.Query(TagProjectile) {
 		proj := w.ProjectileData[id]
-		if proj.Stuck {
+		if proj.Stuck || homingActive(&proj) {
 			continue
 		}
 
 		vel := w.Velocity[id]
-		vel.Y += proj.GravityAccel
-		if vel.Y > proj.MaxFallSpeed {
-			vel.Y = proj.MaxFallSpeed
-		}
+		applyProjectileGravity(&vel, &proj)
 		w.Velocity[id] = vel
 	}
 }
 
-// ApplyGoldGravity applies gravity to all gold pickups (call once per frame)
-func ApplyGoldGravity(w *World) {
-	for id := range w.IsGold {
-		gold := w.GoldData[id]
-		if gold.Grounded {
+// applyProjectileGravity accelerates one projectile for a frame, capped at
+// its max fall speed
+func applyProjectileGravity(vel *Velocity, proj *Projectile) {
+	vel.Y += proj.GravityAccel
+	if vel.Y > proj.MaxFallSpeed {
+		vel.Y = proj.MaxFallSpeed
+	}
+}
+
+// ApplyPickupGravity applies gravity to all pickups (call once per frame)
+func ApplyPickupGravity(w *World) {
+	for _, id := range w.Query(TagPickup) {
+		gold := w.PickupData[id]
+		if gold.Grounded || gold.Attracted {
 			continue
 		}
 
@@ -939,28 +1015,26 @@ func ApplyGoldGravity(w *World) {
 	}
 }
 
-func spawnEnemyArrow(w *World, pos *Position, facingRight bool, cfg ProjectileConfig) {
-	px := pos.PixelX() + 8
-	py := pos.PixelY() + 8
+func spawnEnemyArrow(w *World, stage Stage, shooter EntityID, pos *Position, hb Hitbox, ai *AI, facingRight bool, cfg ProjectileConfig, faction Faction) {
+	// Fired from the center of the body
+	px := pos.PixelX() + hb.OffsetX + hb.Width/2
+	py := pos.PixelY() + hb.OffsetY + hb.Height/2
 
-	dir := 1
-	if !facingRight {
-		dir = -1
+	vx, vy := aimShot(w, px, py, ai, facingRight, cfg)
+	if cfg.Hitscan || ai.Hitscan {
+		cfg.Piercing = cfg.Piercing || ai.Piercing
+		FireBeam(w, stage, shooter, px, py, vx, vy, cfg, faction)
+		return
 	}
 
-	// Simple horizontal arrow: 220 pixels/sec ≈ 94 IU/substep
-	vx := dir * 94
-	vy := 0
-
-	w.CreateProjectile(px, py, vx, vy, cfg, false)
+	cfg.Homing = ai.Homing
+	w.Defer(Spawn(func(w *World) { w.CreateProjectile(px, py, vx, vy, cfg, faction) }))
 }
 
 // UpdateProjectiles updates all projectile physics and movement for one substep
 // Gravity is applied separately via ApplyProjectileGravity (once per frame)
 func UpdateProjectiles(w *World, stage Stage) {
-	toDestroy := make([]EntityID, 0)
-
-	for id := range w.IsProjectile {
+	for _, id := range w.Query(TagProjectile) {
 		pos := w.Position[id]
 		vel := w.Velocity[id]
 		proj := w.ProjectileData[id]
@@ -969,62 +1043,22 @@ func UpdateProjectiles(w *World, stage Stage) {
 			continue
 		}
 
-		// Movement is velocity (IU/substep)
-		dx := vel.X
-		dy := vel.Y
-
-		// Substep movement for collision detection
-		totalSteps := abs(dx)
-		if abs(dy) > totalSteps {
-			totalSteps = abs(dy)
+		if homingActive(&proj) {
+			steerHoming(w, pos, &vel, &proj, w.Faction[id])
 		}
-		if totalSteps == 0 {
-			w.Position[id] = pos
-			w.Velocity[id] = vel
-			continue
+		if proj.Returning {
+			steerBoomerang(w, pos, &vel, &proj)
 		}
 
-		// Integer-based diagonal stepping
-		stepX := dx / totalSteps
-		stepY := dy / totalSteps
-		remX := dx % totalSteps
-		remY := dy % totalSteps
-		accumX, accumY := 0, 0
-
-		for i := 0; i < totalSteps; i++ {
-			moveX := stepX
-			moveY := stepY
+		moveProjectile(w, stage, &pos, &vel, &proj)
 
-			// Distribute remainder evenly
-			accumX += abs(remX)
-			if accumX >= totalSteps {
-				accumX -= totalSteps
-				moveX += sign(remX)
-			}
-			accumY += abs(remY)
-			if accumY >= totalSteps {
-				accumY -= totalSteps
-				moveY += sign(remY)
+		// Check max range (pixels); boomerangs turn back there instead
+		if proj.Boomerang {
+			if updateBoomerang(w, id, pos, &proj) {
+				continue // caught
 			}
-
-			pos.X += moveX
-			pos.Y += moveY
-
-			px, py := pos.PixelX(), pos.PixelY()
-			if stage.IsSolidAt(px, py) {
-				proj.StuckRotation = math.Atan2(float64(vel.Y), float64(vel.X))
-				proj.Stuck = true
-				proj.StuckTimer = 0
-				vel.X = 0
-				vel.Y = 0
-				break
-			}
-		}
-
-		// Check max range (pixels)
-		traveled := abs(pos.PixelX() - proj.StartX)
-		if traveled > proj.MaxRange {
-			toDestroy = append(toDestroy, id)
+		} else if projectileOutOfRange(pos, &proj) {
+			w.Defer(Destroy(id))
 			continue
 		}
 
@@ -1032,35 +1066,130 @@ func UpdateProjectiles(w *World, stage Stage) {
 		w.Velocity[id] = vel
 		w.ProjectileData[id] = proj
 	}
+}
 
-	for _, id := range toDestroy {
-		w.DestroyEntity(id)
+// moveProjectile moves a projectile by its velocity for one substep and
+// sticks it into the first solid tile it enters (unless projectiles pass
+// through terrain). Bouncing projectiles rebound instead. Fast projectiles
+// are swept tile by tile, the rest stepped one IU at a time.
+func moveProjectile(w *World, stage Stage, pos *Position, vel *Velocity, proj *Projectile) {
+	if !w.Collision.Collides(LayerProjectile, LayerTerrain) {
+		pos.X += vel.X
+		pos.Y += vel.Y
+		return
+	}
+	if proj.BouncePercent > 0 {
+		bounceProjectile(stage, pos, vel, proj)
+		return
+	}
+	if max(abs(vel.X), abs(vel.Y)) >= sweptSpeed {
+		sweepProjectile(stage, pos, vel, proj)
+		return
+	}
+	stepProjectile(stage, pos, vel, proj)
+}
+
+// stepProjectile moves a projectile one IU at a time
+func stepProjectile(stage Stage, pos *Position, vel *Velocity, proj *Projectile) {
+	// Movement is velocity (IU/substep)
+	dx := vel.X
+	dy := vel.Y
+
+	// Substep movement for collision detection
+	totalSteps := abs(dx)
+	if abs(dy) > totalSteps {
+		totalSteps = abs(dy)
+	}
+	if totalSteps == 0 {
+		return
+	}
+
+	// Integer-based diagonal stepping
+	stepX := dx / totalSteps
+	stepY := dy / totalSteps
+	remX := dx % totalSteps
+	remY := dy % totalSteps
+	accumX, accumY := 0, 0
+
+	for i := 0; i < totalSteps; i++ {
+		moveX := stepX
+		moveY := stepY
+
+		// Distribute remainder evenly
+		accumX += abs(remX)
+		if accumX >= totalSteps {
+			accumX -= totalSteps
+			moveX += sign(remX)
+		}
+		accumY += abs(remY)
+		if accumY >= totalSteps {
+			accumY -= totalSteps
+			moveY += sign(remY)
+		}
+
+		pos.X += moveX
+		pos.Y += moveY
+
+		px, py := pos.PixelX(), pos.PixelY()
+		if stage.IsSolidAt(px, py) {
+			stickProjectile(vel, proj)
+			return
+		}
 	}
 }
 
-// UpdateGoldPhysics updates gold pickup physics for one substep
-// Gravity is applied separately via ApplyGoldGravity (once per frame)
-func UpdateGoldPhysics(w *World, stage Stage) {
-	for id := range w.IsGold {
+// stickProjectile stops a projectile in the wall it hit
+func stickProjectile(vel *Velocity, proj *Projectile) {
+	proj.StuckRotation = math.Atan2(float64(vel.Y), float64(vel.X))
+	proj.Stuck = true
+	proj.StuckTimer = 0
+	vel.X = 0
+	vel.Y = 0
+}
+
+// projectileOutOfRange reports whether a projectile has flown past its max
+// range (horizontal pixels from where it was fired). Bombs last until they
+// explode.
+func projectileOutOfRange(pos Position, proj *Projectile) bool {
+	return !proj.IsBomb() && abs(pos.PixelX()-proj.StartX) > proj.MaxRange
+}
+
+// UpdatePickupPhysics updates pickup physics for one substep
+// Gravity is applied separately via ApplyPickupGravity (once per frame)
+func UpdatePickupPhysics(w *World, stage Stage) {
+	for _, id := range w.Query(TagPickup) {
 		pos := w.Position[id]
 		vel := w.Velocity[id]
-		gold := w.GoldData[id]
+		gold := w.PickupData[id]
 
+		if gold.Attracted || !w.Collision.Collides(LayerPickup, LayerTerrain) {
+			// Magnet pull passes through walls
+			pos.X += vel.X
+			pos.Y += vel.Y
+			w.Position[id] = pos
+			continue
+		}
 		if gold.Grounded {
 			continue
 		}
 
+		// Pickups without a configured hitbox still collide as a point
+		hbW, hbH := max(1, gold.HitboxWidth), max(1, gold.HitboxHeight)
+
 		// Move X (vel.X is in IU, step 1 IU at a time)
 		dx := vel.X
 		for i := 0; i < abs(dx); i++ {
 			step := sign(dx)
 			nextPixelX := (pos.X + step) / PositionScale
 			if nextPixelX != pos.PixelX() {
-				// About to cross pixel boundary, check collision
-				if stage.IsSolidAt(nextPixelX, pos.PixelY()) ||
-					stage.IsSolidAt(nextPixelX, pos.PixelY()+gold.HitboxHeight-1) {
+				// About to cross pixel boundary, check the leading edge
+				edgeX := nextPixelX
+				if step > 0 {
+					edgeX += hbW - 1
+				}
+				if isSolidRect(stage, edgeX, pos.PixelY(), 1, hbH) {
 					// Bounce: reverse and decay (percentage)
-					vel.X = -vel.X * gold.BouncePercent / 100
+					vel.X = fixedpoint.MulPct(-vel.X, gold.BouncePercent)
 					break
 				}
 			}
@@ -1073,15 +1202,19 @@ func UpdateGoldPhysics(w *World, stage Stage) {
 			step := sign(dy)
 			nextPixelY := (pos.Y + step) / PositionScale
 			if nextPixelY != pos.PixelY() {
-				// About to cross pixel boundary, check collision
-				if stage.IsSolidAt(pos.PixelX(), nextPixelY+gold.HitboxHeight-1) ||
-					stage.IsSolidAt(pos.PixelX()+gold.HitboxWidth-1, nextPixelY+gold.HitboxHeight-1) {
+				// About to cross pixel boundary, check the leading edge
+				// (the top when rising, so pickups stop at ceilings too)
+				edgeY := nextPixelY
+				if step > 0 {
+					edgeY += hbH - 1
+				}
+				if isSolidRect(stage, pos.PixelX(), edgeY, hbW, 1) {
 					if step > 0 {
 						gold.Grounded = true
 						vel.Y = 0
 						vel.X = 0
 					} else {
-						vel.Y = -vel.Y * gold.BouncePercent / 100
+						vel.Y = fixedpoint.MulPct(-vel.Y, gold.BouncePercent)
 					}
 					break
 				}
@@ -1091,15 +1224,15 @@ func UpdateGoldPhysics(w *World, stage Stage) {
 
 		w.Position[id] = pos
 		w.Velocity[id] = vel
-		w.GoldData[id] = gold
+		w.PickupData[id] = gold
 	}
 }
 
-// CollectGold checks for gold collection by player
+// CollectPickups checks for pickup collection by player
 // Uses squared distance comparison for integer math
-func CollectGold(w *World) {
+func CollectPickups(w *World) {
 	playerID := w.PlayerID
-	if playerID == 0 {
+	if playerID == 0 || !w.Collision.Collides(LayerPlayer, LayerPickup) {
 		return
 	}
 
@@ -1110,10 +1243,10 @@ func CollectGold(w *World) {
 	px := playerPos.PixelX() + playerHitbox.Body.OffsetX + playerHitbox.Body.Width/2
 	py := playerPos.PixelY() + playerHitbox.Body.OffsetY + playerHitbox.Body.Height/2
 
-	toDestroy := make([]EntityID, 0)
+	toDestroy := w.destroyBuf[:0]
 
-	for id := range w.IsGold {
-		gold := w.GoldData[id]
+	for _, id := range w.Query(TagPickup) {
+		gold := w.PickupData[id]
 		if gold.CollectDelay > 0 {
 			continue
 		}
@@ -1128,7 +1261,7 @@ func CollectGold(w *World) {
 		distSq := dx*dx + dy*dy
 		radiusSq := gold.CollectRadius * gold.CollectRadius
 		if distSq < radiusSq {
-			playerData.Gold += gold.Amount
+			collectPickup(w, playerID, &playerData, id, gold)
 			toDestroy = append(toDestroy, id)
 		}
 	}
@@ -1138,12 +1271,12 @@ func CollectGold(w *World) {
 	for _, id := range toDestroy {
 		w.DestroyEntity(id)
 	}
+	w.destroyBuf = toDestroy[:0]
 }
 
-// DamageResult holds information about damage events
+// DamageResult holds information about damage events.
+// Hitstop and screen shake are triggered through w.Feedback.
 type DamageResult struct {
-	HitstopFrames   int
-	ScreenShake     float64 // Rendering only
 	PlayerDamaged   bool
 	PlayerKnockback struct {
 		VX, VY int // IU/substep
@@ -1155,13 +1288,18 @@ type DamageResult struct {
 func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) DamageResult {
 	result := DamageResult{}
 
-	// Player projectiles vs enemies
-	enemiesToDestroy := make([]EntityID, 0)
-	projToDestroy := make([]EntityID, 0)
+	// Player projectiles vs enemy projectiles
+	interceptProjectiles(w)
+
+	// Projectiles vs the enemies their faction is hostile to
+	enemiesToDestroy := w.killBuf[:0]
+	projToDestroy := w.destroyBuf[:0]
+	enemies := w.Query(TagEnemy)
+	projHitsEnemies := w.Collision.Collides(LayerProjectile, LayerEnemy)
 
-	for projID := range w.IsProjectile {
+	for _, projID := range w.Query(TagProjectile) {
 		proj := w.ProjectileData[projID]
-		if !proj.IsPlayerOwned || proj.Stuck {
+		if proj.Stuck || proj.IsBomb() || !projHitsEnemies {
 			continue
 		}
 
@@ -1169,7 +1307,11 @@ func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) D
 		projHit := w.Hitbox[projID]
 		projPX, projPY := projPos.PixelX(), projPos.PixelY()
 
-		for enemyID := range w.IsEnemy {
+		for _, enemyID := range enemies {
+			if w.IsInvincible(enemyID) || !w.Hostile(projID, enemyID) || (proj.Boomerang && !w.contactReady(projID, enemyID)) ||
+				slices.Contains(enemiesToDestroy, enemyID) {
+				continue
+			}
 			enemyPos := w.Position[enemyID]
 			enemyHit := w.Hitbox[enemyID]
 			enemyPX, enemyPY := enemyPos.PixelX(), enemyPos.PixelY()
@@ -1179,81 +1321,74 @@ func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) D
 				enemyPX+enemyHit.OffsetX, enemyPY+enemyHit.OffsetY, enemyHit.Width, enemyHit.Height,
 			) {
 				health := w.Health[enemyID]
-				ai := w.AI[enemyID]
-				health.Current -= proj.Damage
+				damage := eliteDamage(w, enemyID, proj.Damage)
+				health.Current -= damage
+				w.Events.Emit(Event{Type: EventEnemyHit, Entity: enemyID, Amount: damage})
 
 				// Calculate knockback based on projectile velocity direction
 				projVel := w.Velocity[projID]
 				kbVelX, kbVelY := calcKnockbackFromVelocity(projVel.X, projVel.Y, knockbackForce)
 
-				// Set hit stun and store initial knockback values
-				hitFrames := 12
-				ai.HitTimer = hitFrames
-				ai.HitTimerMax = hitFrames
-				ai.KnockbackVelX = kbVelX
-				ai.KnockbackVelY = kbVelY
-
-				// Apply initial knockback velocity
-				vel := w.Velocity[enemyID]
-				vel.X = kbVelX
-				vel.Y = kbVelY
-				w.Velocity[enemyID] = vel
-
-				result.HitstopFrames = 3
-				result.ScreenShake = 4.0
+				// Hit stun and decaying knockback
+				Knockback(w, enemyID, kbVelX, kbVelY, 0)
+				Stun(w, enemyID, 0)
 
 				if health.Current <= 0 {
+					w.Feedback.TriggerDir(FeedbackHeavyHit, kbVelX, kbVelY)
 					enemiesToDestroy = append(enemiesToDestroy, enemyID)
 				} else {
+					w.Feedback.TriggerDir(FeedbackLightHit, kbVelX, kbVelY)
 					w.Health[enemyID] = health
-					w.AI[enemyID] = ai
 				}
 
-				projToDestroy = append(projToDestroy, projID)
+				switch {
+				case proj.Boomerang:
+					boomerangHit(w, projID, enemyID) // flies on
+				case proj.StickInEnemies && health.Current > 0:
+					attachProjectile(w, projID, enemyID, &proj)
+				default:
+					projToDestroy = append(projToDestroy, projID)
+				}
 				break
 			}
 		}
 	}
 
-	// Spawn gold for killed enemies
-	for _, id := range enemiesToDestroy {
-		pos := w.Position[id]
-		ai := w.AI[id]
-		amount := ai.GoldDropMin
-		if ai.GoldDropMax > ai.GoldDropMin {
-			amount += (ai.GoldDropMax - ai.GoldDropMin) / 2 // simple average
-		}
-		w.CreateGold(pos.PixelX()+8, pos.PixelY(), amount, GoldConfig{
-			Gravity:       ToIUAccelPerFrame(400), // 400 pixels/sec² → IU velocity change per frame
-			BouncePercent: 50,                     // 50% velocity retained on bounce
-			CollectDelay:  18,                     // 0.3 seconds
-			HitboxWidth:   8,
-			HitboxHeight:  8,
-			CollectRadius: 16,
-		})
-		w.DestroyEntity(id)
-	}
+	// Hitscan beams vs the first (or every) target on their line
+	enemiesToDestroy = resolveBeams(w, enemiesToDestroy, &result, knockbackForce, knockbackUp, iframeFrames)
+
+	// Arrows stuck in enemies
+	enemiesToDestroy = bleedEnemies(w, enemiesToDestroy)
+
+	// Dash through enemies
+	enemiesToDestroy = dashAttack(w, enemiesToDestroy)
+
+	// Land on enemy heads
+	enemiesToDestroy = stompEnemies(w, enemiesToDestroy, &result, iframeFrames)
+
+	killEnemies(w, enemiesToDestroy, &result, knockbackForce, knockbackUp, iframeFrames)
 
 	for _, id := range projToDestroy {
 		w.DestroyEntity(id)
 	}
+	w.killBuf = enemiesToDestroy[:0]
+	w.destroyBuf = projToDestroy[:0]
 
-	// Enemy projectiles vs player
+	// Hostile projectiles vs player
 	playerID := w.PlayerID
 	if playerID != 0 {
 		playerData := w.PlayerData[playerID]
-		dash := w.Dash[playerID]
 
-		if !playerData.IsInvincible(dash.Active) {
+		if !w.IsInvincible(playerID) && w.Collision.Collides(LayerPlayer, LayerProjectile) {
 			playerPos := w.Position[playerID]
 			playerHitbox := w.HitboxTrapezoid[playerID]
 			playerFacing := w.Facing[playerID]
 			playerPX, playerPY := playerPos.PixelX(), playerPos.PixelY()
-			px, py, pw, ph := playerHitbox.Body.GetWorldRect(playerPX, playerPY, playerFacing.Right, 16)
+			px, py, pw, ph := playerHitbox.Body.GetWorldRect(playerPX, playerPY, playerFacing.Right, playerHitbox.MirrorWidth())
 
-			for projID := range w.IsProjectile {
+			for _, projID := range w.Query(TagProjectile) {
 				proj := w.ProjectileData[projID]
-				if proj.IsPlayerOwned || proj.Stuck {
+				if proj.Stuck || proj.IsBomb() || !w.Hostile(projID, playerID) {
 					continue
 				}
 
@@ -1265,14 +1400,31 @@ func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) D
 					projPX+projHit.OffsetX, projPY+projHit.OffsetY, projHit.Width, projHit.Height,
 					px, py, pw, ph,
 				) {
+					damage := proj.Damage
+					if blocksFrom(w, playerID, &playerData, projPX+projHit.OffsetX+projHit.Width/2) {
+						if playerData.BlockFrames <= w.Block.ParryFrames {
+							parryProjectile(w, projID)
+							continue
+						}
+						damage = w.Block.absorbHit(&playerData, damage)
+						w.PlayerData[playerID] = playerData
+						w.Feedback.Trigger(FeedbackBlock)
+						w.Events.Emit(Event{Type: EventAttackBlocked, Entity: projID, Amount: proj.Damage - damage})
+						if damage <= 0 {
+							w.Defer(Destroy(projID))
+							break
+						}
+					}
+
 					health := w.Health[playerID]
-					health.Current -= proj.Damage
-					playerData.IframeTimer = iframeFrames
+					health.Current -= damage
 					w.Health[playerID] = health
 					w.PlayerData[playerID] = playerData
+					GrantIframes(w, playerID, iframeFrames)
 
 					result.PlayerDamaged = true
-					result.ScreenShake = 6.0
+					w.Feedback.Trigger(FeedbackPlayerHurt)
+					w.Events.Emit(Event{Type: EventPlayerDamaged, Entity: projID, Amount: damage})
 
 					// Knockback (values already in IU/substep)
 					dir := 1
@@ -1282,45 +1434,68 @@ func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) D
 					result.PlayerKnockback.VX = dir * knockbackForce
 					result.PlayerKnockback.VY = -knockbackUp
 
-					w.DestroyEntity(projID)
+					w.Defer(Destroy(projID))
 					break
 				}
 			}
 		}
 
 		// Enemy contact vs player
-		if !playerData.IsInvincible(dash.Active) {
+		if !w.IsInvincible(playerID) && w.Collision.Collides(LayerPlayer, LayerEnemy) {
 			playerPos := w.Position[playerID]
 			playerHitbox := w.HitboxTrapezoid[playerID]
 			playerFacing := w.Facing[playerID]
 			playerPX, playerPY := playerPos.PixelX(), playerPos.PixelY()
-			px, py, pw, ph := playerHitbox.Body.GetWorldRect(playerPX, playerPY, playerFacing.Right, 16)
+			px, py, pw, ph := playerHitbox.Body.GetWorldRect(playerPX, playerPY, playerFacing.Right, playerHitbox.MirrorWidth())
 
-			for enemyID := range w.IsEnemy {
+			for _, enemyID := range w.Query(TagEnemy) {
 				enemyPos := w.Position[enemyID]
 				enemyHit := w.Hitbox[enemyID]
 				ai := w.AI[enemyID]
+				if ai.SpawnTimer > 0 || !w.Hostile(enemyID, playerID) || !w.contactReady(enemyID, playerID) {
+					continue // harmless while spawning in, after its last touch or to its friends
+				}
 				enemyPX, enemyPY := enemyPos.PixelX(), enemyPos.PixelY()
+				ex, ey, ew, eh := enemyPX+enemyHit.OffsetX, enemyPY+enemyHit.OffsetY, enemyHit.Width, enemyHit.Height
+				if ai.Solid {
+					// The player is kept out of solid enemies, so touching counts
+					ex, ey, ew, eh = ex-1, ey-1, ew+2, eh+2
+				}
+
+				if rectsOverlap(ex, ey, ew, eh, px, py, pw, ph) {
+					dir := 1
+					if enemyPX > playerPX {
+						dir = -1
+					}
+
+					w.contactConnected(enemyID, playerID, dir)
+
+					damage := ai.ContactDamage
+					if blocksFrom(w, playerID, &playerData, enemyPX+enemyHit.OffsetX+enemyHit.Width/2) {
+						damage = w.Block.absorbHit(&playerData, damage)
+						w.PlayerData[playerID] = playerData
+						GrantIframes(w, playerID, iframeFrames)
+						w.Feedback.Trigger(FeedbackBlock)
+						w.Events.Emit(Event{Type: EventAttackBlocked, Entity: enemyID, Amount: ai.ContactDamage - damage})
+						if damage <= 0 {
+							// Shield pushes the player back without stun
+							Knockback(w, playerID, dir*knockbackForce/2, w.Velocity[playerID].Y, 0)
+							break
+						}
+					}
 
-				if rectsOverlap(
-					enemyPX+enemyHit.OffsetX, enemyPY+enemyHit.OffsetY, enemyHit.Width, enemyHit.Height,
-					px, py, pw, ph,
-				) {
 					health := w.Health[playerID]
-					health.Current -= ai.ContactDamage
-					playerData.IframeTimer = iframeFrames
-					playerData.StunTimer = 12 // stun frames
+					health.Current -= damage
 					w.Health[playerID] = health
 					w.PlayerData[playerID] = playerData
+					GrantIframes(w, playerID, iframeFrames)
+					Stun(w, playerID, 0)
 
 					result.PlayerDamaged = true
-					result.ScreenShake = 6.0
+					w.Feedback.Trigger(FeedbackPlayerHurt)
+					w.Events.Emit(Event{Type: EventPlayerDamaged, Entity: enemyID, Amount: damage})
 
 					// Knockback
-					dir := 1
-					if enemyPX > playerPX {
-						dir = -1
-					}
 					result.PlayerKnockback.VX = dir * knockbackForce
 					result.PlayerKnockback.VY = -knockbackUp
 					break
@@ -1330,57 +1505,106 @@ func UpdateDamage(w *World, knockbackForce, knockbackUp int, iframeFrames int) D
 
 		// Apply knockback
 		if result.PlayerDamaged {
-			vel := w.Velocity[playerID]
-			vel.X = result.PlayerKnockback.VX
-			vel.Y = result.PlayerKnockback.VY
-			w.Velocity[playerID] = vel
+			Knockback(w, playerID, result.PlayerKnockback.VX, result.PlayerKnockback.VY, 0)
 		}
 	}
 
 	return result
 }
 
-// ResolveEnemyCollisions pushes overlapping enemies apart
-func ResolveEnemyCollisions(w *World) {
-	enemies := make([]EntityID, 0, len(w.IsEnemy))
-	for id := range w.IsEnemy {
-		enemies = append(enemies, id)
+// killEnemies drops loot for and destroys killed enemies (sorted so the RNG
+// draws replay identically), setting off elite explosions
+func killEnemies(w *World, killed []EntityID, result *DamageResult, knockbackForce, knockbackUp, iframeFrames int) {
+	slices.Sort(killed)
+	for _, id := range killed {
+		gold := dropLoot(w, id)
+		w.Events.Emit(Event{Type: EventEnemyKilled, Entity: id, Amount: gold})
+		explodeElite(w, id, result, knockbackForce, knockbackUp, iframeFrames)
+		w.DestroyEntity(id)
 	}
+}
 
-	for i := 0; i < len(enemies); i++ {
-		e1 := enemies[i]
-		pos1 := w.Position[e1]
-		hit1 := w.Hitbox[e1]
-		px1, py1 := pos1.PixelX(), pos1.PixelY()
-		x1, y1, w1, h1 := px1+hit1.OffsetX, py1+hit1.OffsetY, hit1.Width, hit1.Height
-
-		for j := i + 1; j < len(enemies); j++ {
-			e2 := enemies[j]
-			pos2 := w.Position[e2]
-			hit2 := w.Hitbox[e2]
-			px2, py2 := pos2.PixelX(), pos2.PixelY()
-			x2, y2, w2, h2 := px2+hit2.OffsetX, py2+hit2.OffsetY, hit2.Width, hit2.Height
-
-			if !rectsOverlap(x1, y1, w1, h1, x2, y2, w2, h2) {
-				continue
-			}
+// maxSeparationPasses caps how often ResolveEnemyCollisions sweeps a crowd
+// that can't be pulled apart (e.g. wedged between walls)
+const maxSeparationPasses = 16
 
-			cx1 := float64(x1) + float64(w1)/2
-			cx2 := float64(x2) + float64(w2)/2
+// ResolveEnemyCollisions pushes overlapping enemies apart, repeating until
+// no overlaps remain. Each pair is separated by its full overlap, split by
+// mass so heavy enemies barely move; when a wall blocks one side the other
+// takes the rest of the push.
+func ResolveEnemyCollisions(w *World, stage Stage) {
+	if !w.Collision.Collides(LayerEnemy, LayerEnemy) {
+		return
+	}
+	enemies := w.Query(TagEnemy)
 
-			pushAmount := 2 * PositionScale
-			if cx1 < cx2 {
-				pos1.X -= pushAmount
-				pos2.X += pushAmount
-			} else {
-				pos1.X += pushAmount
-				pos2.X -= pushAmount
+	for range maxSeparationPasses {
+		moved := false
+		for i, e1 := range enemies {
+			for _, e2 := range enemies[i+1:] {
+				if separateEnemies(w, stage, e1, e2) {
+					moved = true
+				}
 			}
+		}
+		if !moved {
+			return
+		}
+	}
+}
 
-			w.Position[e1] = pos1
-			w.Position[e2] = pos2
+// separateEnemies pushes two overlapping enemies apart, horizontally unless
+// both fly and overlap less vertically. Returns true if either moved.
+func separateEnemies(w *World, stage Stage, e1, e2 EntityID) bool {
+	pos1, pos2 := w.Position[e1], w.Position[e2]
+	hb1, hb2 := w.Hitbox[e1], w.Hitbox[e2]
+	x1, y1 := pos1.PixelX()+hb1.OffsetX, pos1.PixelY()+hb1.OffsetY
+	x2, y2 := pos2.PixelX()+hb2.OffsetX, pos2.PixelY()+hb2.OffsetY
+	if !rectsOverlap(x1, y1, hb1.Width, hb1.Height, x2, y2, hb2.Width, hb2.Height) {
+		return false
+	}
+
+	// e1 moves in dir (away from e2's center; left/up on a tie)
+	overlap := min(x1+hb1.Width, x2+hb2.Width) - max(x1, x2)
+	dir := -1
+	if 2*x1+hb1.Width > 2*x2+hb2.Width {
+		dir = 1
+	}
+	overlapY := min(y1+hb1.Height, y2+hb2.Height) - max(y1, y2)
+	vertical := w.AI[e1].Flying && w.AI[e2].Flying && overlapY < overlap
+	if vertical {
+		overlap, dir = overlapY, -1
+		if 2*y1+hb1.Height > 2*y2+hb2.Height {
+			dir = 1
 		}
 	}
+
+	// Each side moves by the other's share of the combined mass
+	m1, m2 := w.Movement[e1].mass(), w.Movement[e2].mass()
+	total := overlap * PositionScale
+	moved1 := pushEnemy(stage, hb1, &pos1, dir*fixedpoint.MulDiv(total, m2, m1+m2), vertical)
+	moved2 := pushEnemy(stage, hb2, &pos2, -dir*(total-abs(moved1)), vertical)
+	if rest := total - abs(moved1) - abs(moved2); rest > 0 {
+		moved1 += pushEnemy(stage, hb1, &pos1, dir*rest, vertical)
+	}
+
+	w.Position[e1] = pos1
+	w.Position[e2] = pos2
+	return moved1 != 0 || moved2 != 0
+}
+
+// pushEnemy moves an enemy by up to d IU along one axis, stopping at walls.
+// Returns the distance moved.
+func pushEnemy(stage Stage, hb Hitbox, pos *Position, d int, vertical bool) int {
+	var vel Velocity
+	start := *pos
+	if vertical {
+		var mov Movement
+		moveEnemyY(stage, hb, pos, &vel, &mov, d)
+		return pos.Y - start.Y
+	}
+	moveEnemyKnockbackX(stage, hb, pos, &vel, d)
+	return pos.X - start.X
 }
 
 // Helper functions
@@ -0,0 +1,53 @@
+package ecs
+
+// Telegraph is the attack an enemy is winding up
+type Telegraph int
+
+const (
+	TelegraphNone   Telegraph = iota
+	TelegraphShot             // about to fire along its facing
+	TelegraphCharge           // about to rush the player
+)
+
+// SpawnIn starts an enemy's spawn-in: for its SpawnFrames it flashes, is
+// invulnerable and harmless, and its AI doesn't run.
+func SpawnIn(w *World, id EntityID) {
+	ai := w.AI[id]
+	if ai.SpawnFrames <= 0 {
+		return
+	}
+	ai.SpawnTimer = ai.SpawnFrames
+	w.AI[id] = ai
+	GrantIframes(w, id, ai.SpawnFrames)
+}
+
+// windUp telegraphs an attack of the given kind before it happens.
+// It reports true once the wind-up is over (at once without WindupFrames)
+// and the attack should happen now.
+func windUp(ai *AI, kind Telegraph) bool {
+	if ai.WindupFrames <= 0 {
+		return true
+	}
+	if ai.Telegraph != kind {
+		ai.Telegraph = kind
+		ai.WindupTimer = ai.WindupFrames
+		return false
+	}
+	if ai.WindupTimer > 0 {
+		return false
+	}
+	ai.Telegraph = TelegraphNone
+	return true
+}
+
+// enemyShoot fires at the player when in range and off cooldown, after the
+// shot telegraph. A started wind-up always ends in a shot.
+func enemyShoot(w *World, stage Stage, shooter EntityID, pos *Position, hb Hitbox, ai *AI, facing *Facing, dist int, arrowCfg ProjectileConfig, faction Faction) {
+	if ai.Telegraph != TelegraphShot && (dist >= ai.AttackRange || ai.AttackTimer > 0) {
+		return
+	}
+	if windUp(ai, TelegraphShot) {
+		spawnEnemyArrow(w, stage, shooter, pos, hb, ai, facing.Right, arrowCfg, faction)
+		ai.AttackTimer = ai.AttackCooldown
+	}
+}
@@ -0,0 +1,110 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTelegraphWorld(ai AIType, windup int) (*World, *mockStage, EntityID) {
+	w := NewWorld()
+	w.CreatePlayer(100, 100, PlayerProfile{MaxHealth: 100})
+	enemy := w.CreateEnemy(150, 100, EnemyConfig{
+		MaxHealth:    10,
+		AIType:       ai,
+		AttackRange:  200,
+		DetectRange:  200,
+		MoveSpeed:    10,
+		Flying:       true,
+		SpawnFrames:  20,
+		WindupFrames: windup,
+	}, false)
+	return w, newMockStage(100, 100, 16), enemy
+}
+
+// stepFrame runs one frame of timers and enemy AI
+func stepFrame(w *World, stage Stage) {
+	UpdateTimers(w)
+	w.Flush()
+	for sub := 0; sub < 10; sub++ {
+		UpdateEnemyAI(w, stage, ProjectileConfig{}, PhysicsConfig{})
+		w.Flush()
+	}
+}
+
+func TestEnemyShot_TelegraphedBeforeFiring(t *testing.T) {
+	w, stage, enemy := newTelegraphWorld(AIRanged, 5)
+
+	stepFrame(w, stage)
+	assert.Equal(t, TelegraphShot, w.AI[enemy].Telegraph)
+	assert.Empty(t, w.IsProjectile, "winding up")
+
+	for i := 0; i < 5; i++ {
+		stepFrame(w, stage)
+	}
+	assert.Len(t, w.IsProjectile, 1)
+	assert.Equal(t, TelegraphNone, w.AI[enemy].Telegraph)
+}
+
+func TestEnemyShot_NoWindupFiresAtOnce(t *testing.T) {
+	w, stage, _ := newTelegraphWorld(AIRanged, 0)
+
+	stepFrame(w, stage)
+
+	assert.Len(t, w.IsProjectile, 1)
+}
+
+func TestEnemyShot_HitInterruptsWindup(t *testing.T) {
+	w, stage, enemy := newTelegraphWorld(AIRanged, 5)
+	stepFrame(w, stage)
+
+	Stun(w, enemy, 2)
+	stepFrame(w, stage)
+
+	assert.Equal(t, TelegraphNone, w.AI[enemy].Telegraph)
+	assert.Empty(t, w.IsProjectile)
+}
+
+func TestChaseAI_WindsUpBeforeRushing(t *testing.T) {
+	w, stage, enemy := newTelegraphWorld(AIChase, 3)
+	startX := w.Position[enemy].X
+
+	stepFrame(w, stage)
+	assert.Equal(t, TelegraphCharge, w.AI[enemy].Telegraph)
+	assert.Equal(t, startX, w.Position[enemy].X)
+
+	for i := 0; i < 4; i++ {
+		stepFrame(w, stage)
+	}
+	assert.True(t, w.AI[enemy].Charging)
+	assert.Less(t, w.Position[enemy].X, startX, "rushes toward the player")
+}
+
+func TestSpawnIn_InvulnerableAndInactive(t *testing.T) {
+	w, stage, enemy := newTelegraphWorld(AIRanged, 0)
+	SpawnIn(w, enemy)
+
+	stepFrame(w, stage)
+	assert.Empty(t, w.IsProjectile, "no AI while spawning in")
+	assert.True(t, w.IsInvincible(enemy))
+
+	for i := 0; i < 20; i++ {
+		stepFrame(w, stage)
+	}
+	assert.Equal(t, 0, w.AI[enemy].SpawnTimer)
+	assert.False(t, w.IsInvincible(enemy))
+	assert.Len(t, w.IsProjectile, 1)
+}
+
+func TestUpdateDamage_SpawningEnemyIsHarmless(t *testing.T) {
+	w := NewWorld()
+	body := Hitbox{Width: 12, Height: 20}
+	player := w.CreatePlayer(100, 100, PlayerProfile{Hitbox: HitboxTrapezoid{Head: body, Body: body, Feet: body}, MaxHealth: 100})
+	enemy := w.CreateEnemy(104, 104, EnemyConfig{MaxHealth: 10, ContactDamage: 10, HitboxWidth: 12, HitboxHeight: 12, SpawnFrames: 30}, true)
+	SpawnIn(w, enemy)
+
+	result := UpdateDamage(w, 100, 50, 60)
+
+	assert.False(t, result.PlayerDamaged)
+	assert.Equal(t, 100, w.Health[player].Current)
+}
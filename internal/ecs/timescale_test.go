@@ -0,0 +1,74 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeScale_SlowestRequestWins(t *testing.T) {
+	ts := NewTimeScale()
+	assert.Equal(t, SubstepsPerFrame, ts.Tick(), "normal speed without requests")
+
+	ts.Hold(TimeScaleArrowSelect, 10)
+	ts.SlowFor(TimeScaleDashHit, 2, 50)
+	assert.Equal(t, 10, ts.Target())
+
+	ts.Release(TimeScaleArrowSelect)
+	assert.Equal(t, 5, ts.Tick())
+	assert.Equal(t, 5, ts.Tick())
+	assert.Equal(t, SubstepsPerFrame, ts.Tick(), "timed request expired")
+}
+
+func TestTimeScale_AtLeastOneSubstep(t *testing.T) {
+	ts := NewTimeScale()
+	ts.Hold(TimeScaleArrowSelect, 0)
+	assert.Equal(t, 1, ts.Tick())
+	assert.Equal(t, 1, ts.Tick(), "held requests do not expire")
+}
+
+func TestTimeScale_LongerSlowForReplaces(t *testing.T) {
+	ts := NewTimeScale()
+	ts.SlowFor(TimeScaleDashHit, 3, 50)
+	ts.SlowFor(TimeScaleDashHit, 1, 20) // shorter: ignored
+	assert.Equal(t, 50, ts.Target())
+
+	ts.SlowFor(TimeScaleDashHit, 5, 30)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, 3, ts.Tick())
+	}
+	assert.Equal(t, 100, ts.Target())
+}
+
+func TestTimeScale_Ramps(t *testing.T) {
+	ts := NewTimeScale()
+	ts.RampInPct = 30
+	ts.RampOutPct = 20
+
+	ts.Hold(TimeScaleBulletTime, 10)
+	var steps []int
+	for range 4 {
+		steps = append(steps, ts.Tick())
+	}
+	assert.Equal(t, []int{7, 4, 1, 1}, steps, "slows down 30 points per frame")
+	assert.Equal(t, 10, ts.Pct())
+
+	ts.Release(TimeScaleBulletTime)
+	steps = steps[:0]
+	for range 5 {
+		steps = append(steps, ts.Tick())
+	}
+	assert.Equal(t, []int{3, 5, 7, 9, 10}, steps, "speeds back up 20 points per frame")
+}
+
+func TestTimeScale_HitstopTakesLongest(t *testing.T) {
+	ts := NewTimeScale()
+	ts.Hitstop(3)
+	ts.Hitstop(1) // shorter: ignored
+
+	assert.Equal(t, 3, ts.HitstopFrames())
+	for range 3 {
+		assert.True(t, ts.TickHitstop())
+	}
+	assert.False(t, ts.TickHitstop())
+}
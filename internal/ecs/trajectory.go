@@ -0,0 +1,78 @@
+package ecs
+
+// PathPoint is a sampled projectile position (pixels)
+type PathPoint struct {
+	X, Y int
+}
+
+// PredictProjectilePath simulates a player-owned projectile fired from
+// (startX, startY) pixels with velocity (vx, vy) IU/substep, using the same
+// integer stepping, per-frame gravity and homing as the real systems. It
+// returns the start point and the position after each substep, ending where
+// the projectile sticks into a wall, leaves its range (a boomerang turns
+// back), explodes or after maxSteps substeps. The world is only read (homing targets).
+func PredictProjectilePath(w *World, stage Stage, startX, startY, vx, vy int, cfg ProjectileConfig, maxSteps int) []PathPoint {
+	pos := Position{X: startX * PositionScale, Y: startY * PositionScale}
+	vel := Velocity{X: vx, Y: vy}
+	proj := Projectile{
+		StartX:       startX,
+		StartY:       startY,
+		GravityAccel: cfg.GravityAccel,
+		MaxFallSpeed: cfg.MaxFallSpeed,
+		MaxRange:     cfg.MaxRange,
+		Homing:       cfg.Homing,
+
+		BouncePercent: cfg.BouncePercent,
+		Fuse:          cfg.FuseFrames,
+		Boomerang:     cfg.Boomerang,
+	}
+
+	path := []PathPoint{{startX, startY}}
+	for step := 0; step < maxSteps; step++ {
+		// Once per frame: UpdateTimers ages homing, then gravity applies
+		if step%SubstepsPerFrame == 0 {
+			if proj.Homing.Enabled() {
+				proj.Age++
+			}
+			if !homingActive(&proj) {
+				applyProjectileGravity(&vel, &proj)
+			}
+		}
+
+		if homingActive(&proj) {
+			steerHoming(w, pos, &vel, &proj, FactionPlayer)
+		}
+		moveProjectile(w, stage, &pos, &vel, &proj)
+		if projectileOutOfRange(pos, &proj) || (proj.Boomerang && boomerangAtRange(pos, &proj)) {
+			break
+		}
+		path = append(path, PathPoint{pos.PixelX(), pos.PixelY()})
+		if proj.Stuck {
+			break
+		}
+
+		// After the substeps UpdateBombs burns the fuse
+		if proj.IsBomb() && (step+1)%SubstepsPerFrame == 0 {
+			if proj.Fuse--; proj.Fuse == 0 {
+				break
+			}
+		}
+	}
+	return path
+}
+
+// PredictBeamPath returns the line a hitscan beam fired from (x, y) pixels
+// in the direction (dirX, dirY) would cover, a point per pixel, ending at
+// its range or the first solid tile
+func PredictBeamPath(w *World, stage Stage, x, y, dirX, dirY int, cfg ProjectileConfig) []PathPoint {
+	x1, y1, ok := beamEnd(w, stage, x, y, dirX, dirY, cfg.MaxRange)
+	if !ok {
+		return []PathPoint{{x, y}}
+	}
+	steps := max(abs(x1-x), abs(y1-y), 1)
+	path := make([]PathPoint, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		path = append(path, PathPoint{x + (x1-x)*i/steps, y + (y1-y)*i/steps})
+	}
+	return path
+}
@@ -0,0 +1,82 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// simulateProjectile runs a real projectile through the frame systems and
+// records its pixel position after every substep
+func simulateProjectile(w *World, stage Stage, id EntityID, frames int) []PathPoint {
+	pos := w.Position[id]
+	path := []PathPoint{{pos.PixelX(), pos.PixelY()}}
+	for f := 0; f < frames; f++ {
+		UpdateTimers(w)
+		ApplyProjectileGravity(w)
+		for i := 0; i < SubstepsPerFrame; i++ {
+			if w.ProjectileData[id].Stuck {
+				return path
+			}
+			UpdateProjectiles(w, stage)
+			if !w.Exists(id) {
+				return path
+			}
+			pos := w.Position[id]
+			path = append(path, PathPoint{pos.PixelX(), pos.PixelY()})
+		}
+	}
+	return path
+}
+
+func TestPredictProjectilePath_MatchesSimulation(t *testing.T) {
+	stage := newMockStage(40, 20, 16)
+	for x := 0; x < 40; x++ {
+		stage.setSolid(x, 10)
+	}
+	cfg := ProjectileConfig{
+		GravityAccel:  fixedpoint.ToIUAccelPerFrame(500),
+		MaxFallSpeed:  fixedpoint.ToIUPerSubstep(400),
+		MaxRange:      400,
+		StuckDuration: 300,
+	}
+
+	w := NewWorld()
+	predicted := PredictProjectilePath(w, stage, 40, 100, 120, -60, cfg, 2000)
+	id := w.CreateProjectile(40, 100, 120, -60, cfg, FactionPlayer)
+	actual := simulateProjectile(w, stage, id, 200)
+
+	require.True(t, w.ProjectileData[id].Stuck, "arrow lands in the floor")
+	assert.Equal(t, actual, predicted)
+	assert.GreaterOrEqual(t, predicted[len(predicted)-1].Y, 160)
+}
+
+func TestPredictProjectilePath_StopsAtRangeAndMaxSteps(t *testing.T) {
+	stage := newMockStage(100, 20, 16)
+	cfg := ProjectileConfig{MaxRange: 50}
+	w := NewWorld()
+
+	path := PredictProjectilePath(w, stage, 10, 50, 256, 0, cfg, 1000)
+	last := path[len(path)-1]
+	assert.Equal(t, PathPoint{60, 50}, last, "1 px/substep flat shot ends at max range")
+
+	path = PredictProjectilePath(w, stage, 10, 50, 256, 0, cfg, 5)
+	assert.Len(t, path, 6, "start point plus one point per substep")
+}
+
+func TestPredictProjectilePath_Homing(t *testing.T) {
+	stage := newMockStage(40, 40, 16)
+	cfg := ProjectileConfig{MaxRange: 300, Homing: Homing{TurnRate: 4, Range: 200, Lifetime: 60}}
+
+	w := NewWorld()
+	w.CreateEnemy(200, 160, EnemyConfig{MaxHealth: 10, HitboxWidth: 12, HitboxHeight: 12}, true)
+	predicted := PredictProjectilePath(w, stage, 100, 100, 100, 0, cfg, 100)
+	id := w.CreateProjectile(100, 100, 100, 0, cfg, FactionPlayer)
+	actual := simulateProjectile(w, stage, id, 10)
+
+	assert.Equal(t, actual, predicted)
+	assert.Greater(t, predicted[len(predicted)-1].Y, 100, "path curves toward the enemy")
+}
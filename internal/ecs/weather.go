@@ -0,0 +1,98 @@
+package ecs
+
+import "image/color"
+
+// WeatherKind is a stage's weather
+type WeatherKind int
+
+const (
+	WeatherClear WeatherKind = iota
+	WeatherRain              // falling streaks; wet ground is slippery
+	WeatherSnow              // slowly drifting flakes
+	WeatherFog               // a screen gradient; enemies see less far
+)
+
+// weatherNames maps weather kinds to their config names
+var weatherNames = map[WeatherKind]string{
+	WeatherClear: "clear",
+	WeatherRain:  "rain",
+	WeatherSnow:  "snow",
+	WeatherFog:   "fog",
+}
+
+// String returns the config name of the weather
+func (k WeatherKind) String() string {
+	return weatherNames[k]
+}
+
+// ParseWeatherKind returns the weather kind for a config name
+func ParseWeatherKind(name string) (WeatherKind, bool) {
+	for k, n := range weatherNames {
+		if n == name {
+			return k, true
+		}
+	}
+	return WeatherClear, false
+}
+
+// Weather is a stage's weather (zero = clear skies)
+type Weather struct {
+	Kind        WeatherKind
+	Density     int // rain or snow particles spawned per frame
+	Wind        int // horizontal drift (IU per frame)
+	FrictionPct int // player deceleration in percent while it rains (0 = normal)
+
+	seed uint32 // particle placement RNG (kept apart from the loot RNG)
+}
+
+// Weather particle tuning
+const (
+	rainSpeed     = 6 * PositionScale // IU per frame
+	rainLife      = 24                // frames
+	snowSpeed     = PositionScale / 2
+	snowSway      = PositionScale / 4 // random drift either way (IU per frame)
+	snowLife      = 90
+	weatherMargin = 32 // pixels around the view that are filled as well
+)
+
+// Weather particle colors
+var (
+	RainColor = color.RGBA{150, 170, 220, 180}
+	SnowColor = color.RGBA{240, 240, 255, 220}
+)
+
+// rand returns a pseudo-random number in [0, n)
+func (wt *Weather) rand(n int) int {
+	wt.seed = wt.seed*1664525 + 1013904223
+	return int(wt.seed>>8) % n
+}
+
+// SpawnWeather emits rain or snow particles over the view rect (pixels,
+// call once per frame). Particles live in world space, so they scroll with
+// the camera; spawning across the whole view keeps it filled as it moves.
+func SpawnWeather(w *World, viewX, viewY, viewW, viewH int) {
+	weather := &w.Weather
+	if weather.Kind != WeatherRain && weather.Kind != WeatherSnow {
+		return
+	}
+
+	spanW := viewW + 2*weatherMargin
+	spanH := viewH + weatherMargin
+	for range weather.Density {
+		x := viewX - weatherMargin + weather.rand(spanW)
+		y := viewY - weatherMargin + weather.rand(spanH)
+		p := Particle{VX: weather.Wind, VY: rainSpeed, Life: rainLife, MaxLife: rainLife, Color: RainColor, Streak: true}
+		if weather.Kind == WeatherSnow {
+			p = Particle{
+				VX:      weather.Wind + weather.rand(2*snowSway+1) - snowSway,
+				VY:      snowSpeed,
+				Life:    snowLife,
+				MaxLife: snowLife,
+				Color:   SnowColor,
+			}
+		}
+		id := w.newPooledEntity(&w.particlePool)
+		w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
+		w.Particle[id] = p
+	}
+}
@@ -0,0 +1,59 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpawnWeather_FillsView(t *testing.T) {
+	w := NewWorld()
+	w.Weather = Weather{Kind: WeatherRain, Density: 20, Wind: 64}
+
+	SpawnWeather(w, 500, 300, 320, 240)
+
+	assert.Len(t, w.Particle, 20)
+	for id, p := range w.Particle {
+		pos := w.Position[id]
+		assert.True(t, p.Streak)
+		assert.Equal(t, 64, p.VX)
+		assert.GreaterOrEqual(t, pos.PixelX(), 500-weatherMargin)
+		assert.Less(t, pos.PixelX(), 500+320+weatherMargin)
+		assert.GreaterOrEqual(t, pos.PixelY(), 300-weatherMargin)
+		assert.Less(t, pos.PixelY(), 300+240)
+	}
+}
+
+func TestSpawnWeather_SnowDrifts(t *testing.T) {
+	w := NewWorld()
+	w.Weather = Weather{Kind: WeatherSnow, Density: 10}
+
+	SpawnWeather(w, 0, 0, 320, 240)
+
+	assert.Len(t, w.Particle, 10)
+	for _, p := range w.Particle {
+		assert.False(t, p.Streak)
+		assert.Equal(t, snowSpeed, p.VY)
+		assert.LessOrEqual(t, max(p.VX, -p.VX), snowSway)
+	}
+}
+
+func TestSpawnWeather_ClearAndFogSpawnNothing(t *testing.T) {
+	w := NewWorld()
+	SpawnWeather(w, 0, 0, 320, 240)
+	w.Weather = Weather{Kind: WeatherFog, Density: 10}
+	SpawnWeather(w, 0, 0, 320, 240)
+
+	assert.Empty(t, w.Particle)
+}
+
+func TestUpdatePlayerInput_RainIsSlippery(t *testing.T) {
+	cfg := PhysicsConfig{MaxSpeed: 100, Deceleration: 20}
+	w, id := newSurfaceTestPlayer(Surface{})
+	w.Weather = Weather{Kind: WeatherRain, FrictionPct: 50}
+	w.Velocity[id] = Velocity{X: 100}
+
+	UpdatePlayerInput(w, InputState{}, cfg)
+
+	assert.Equal(t, 90, w.Velocity[id].X)
+}
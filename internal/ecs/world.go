@@ -1,69 +1,209 @@
 package ecs
 
-// EntityID is a unique identifier for an entity (never recycled)
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/younwookim/mg/internal/ecs/fixedpoint"
+)
+
+// EntityID is a unique identifier for an entity.
+// The low 32 bits are the slot index and the high 32 bits the slot's
+// generation. Projectile and gold slots are pooled: a destroyed slot is
+// reused with the next generation, so an ID is never handed out twice and
+// stale IDs are rejected.
 type EntityID uint64
 
+// newEntityID combines a slot index and generation
+func newEntityID(index, generation uint32) EntityID {
+	return EntityID(generation)<<32 | EntityID(index)
+}
+
+// Index returns the slot index of the ID
+func (id EntityID) Index() uint32 {
+	return uint32(id)
+}
+
+// Generation returns how many times the slot was reused before this ID
+func (id EntityID) Generation() uint32 {
+	return uint32(id >> 32)
+}
+
 // World holds all component maps and the next entity ID
 type World struct {
-	nextID EntityID
+	nextID EntityID // next fresh slot index
+
+	// Slot generations (indexed by EntityID.Index) and pooled free slots
+	generations    []uint32
+	projectilePool []uint32
+	pickupPool     []uint32
+	particlePool   []uint32
+
+	// Scratch buffers reused by systems across frames
+	destroyBuf   []EntityID
+	killBuf      []EntityID
+	interceptBuf []projRect
+	dropBuf      []Drop
+
+	// Destroys and spawns deferred until the next sync point (see Defer)
+	commands []Command
 
 	// Components
-	Position        map[EntityID]Position
-	Velocity        map[EntityID]Velocity
-	Movement        map[EntityID]Movement
-	Health          map[EntityID]Health
-	Hitbox          map[EntityID]Hitbox
-	HitboxTrapezoid map[EntityID]HitboxTrapezoid
-	Facing          map[EntityID]Facing
-	AI              map[EntityID]AI
-	Dash            map[EntityID]Dash
-	ProjectileData  map[EntityID]Projectile
-	GoldData        map[EntityID]Gold
-	PlayerData      map[EntityID]Player
+	Position         map[EntityID]Position
+	PreviousPosition map[EntityID]PreviousPosition
+	Velocity         map[EntityID]Velocity
+	Movement         map[EntityID]Movement
+	Health           map[EntityID]Health
+	Hitbox           map[EntityID]Hitbox
+	HitboxTrapezoid  map[EntityID]HitboxTrapezoid
+	Facing           map[EntityID]Facing
+	AI               map[EntityID]AI
+	Dash             map[EntityID]Dash
+	Crouch           map[EntityID]Crouch
+	ProjectileData   map[EntityID]Projectile
+	PickupData       map[EntityID]Pickup
+	PlayerData       map[EntityID]Player
+	Elite            map[EntityID]Elite
+	Particle         map[EntityID]Particle
+	CrowdControl     map[EntityID]CrowdControl
+	NPC              map[EntityID]NPC
+	Faction          map[EntityID]Faction
+	Ally             map[EntityID]Ally
 
 	// Tags
 	IsPlayer     map[EntityID]struct{}
 	IsEnemy      map[EntityID]struct{}
 	IsProjectile map[EntityID]struct{}
-	IsGold       map[EntityID]struct{}
+	IsPickup     map[EntityID]struct{}
+	IsNPC        map[EntityID]struct{}
 
 	// Singleton references
 	PlayerID EntityID
+
+	// Resources
+	Feedback   *Feedback
+	Events     *Events
+	TimeScale  *TimeScale
+	Block      BlockConfig      // player shield tuning (zero = blocking disabled)
+	DashAttack DashAttackConfig // dash damage tuning (no tiers = dash deals no damage)
+	CC         CCConfig         // knockback curves and stun durations
+	Magnet     MagnetConfig     // pickup attraction by upgrade tier
+	Stomp      StompConfig      // bouncing off enemy heads
+	Energy     EnergyConfig     // energy meter costs and regen (no pool = everything free)
+	Aggro      AggroConfig      // alert sharing between enemies
+	Contact    ContactConfig    // touch damage cooldown and separation
+	Factions   FactionRelations // who can hurt whom
+	Collision  CollisionMask    // which layers interact
+	Summon     SummonConfig     // the player's summonable turret (zero lifetime = none)
+	Boss       BossFight        // scripted boss fight state (see BossFight)
+	Rand       *rand.Rand       // deterministic RNG for loot (the scene shares its seeded RNG)
+	randSource *RandSource      // Rand's source when set by SeedRand (nil = not restorable)
+
+	// Crumbling tiles that have been stood on (shaking or fallen)
+	Crumbles map[TileCoord]Crumble
+
+	// Frames until an attacker's touch can hurt its target again
+	ContactCooldowns map[ContactPair]int
+
+	// Stage-authored entities that come back after being destroyed
+	Respawns []Respawn
+
+	// Hitscan beams fired recently (see FireBeam)
+	Beams []Beam
+
+	// Stage weather (zero = clear)
+	Weather Weather
+
+	// Systems run by RunFrame (the scene registers the built-ins)
+	Systems Scheduler
 }
 
 // NewWorld creates a new empty world
 func NewWorld() *World {
-	return &World{
-		nextID:          1, // 0 is "nil"
-		Position:        make(map[EntityID]Position),
-		Velocity:        make(map[EntityID]Velocity),
-		Movement:        make(map[EntityID]Movement),
-		Health:          make(map[EntityID]Health),
-		Hitbox:          make(map[EntityID]Hitbox),
-		HitboxTrapezoid: make(map[EntityID]HitboxTrapezoid),
-		Facing:          make(map[EntityID]Facing),
-		AI:              make(map[EntityID]AI),
-		Dash:            make(map[EntityID]Dash),
-		ProjectileData:  make(map[EntityID]Projectile),
-		GoldData:        make(map[EntityID]Gold),
-		PlayerData:      make(map[EntityID]Player),
-		IsPlayer:        make(map[EntityID]struct{}),
-		IsEnemy:         make(map[EntityID]struct{}),
-		IsProjectile:    make(map[EntityID]struct{}),
-		IsGold:          make(map[EntityID]struct{}),
+	w := &World{
+		nextID:           1, // 0 is "nil"
+		generations:      []uint32{0},
+		Position:         make(map[EntityID]Position),
+		PreviousPosition: make(map[EntityID]PreviousPosition),
+		Velocity:         make(map[EntityID]Velocity),
+		Movement:         make(map[EntityID]Movement),
+		Health:           make(map[EntityID]Health),
+		Hitbox:           make(map[EntityID]Hitbox),
+		HitboxTrapezoid:  make(map[EntityID]HitboxTrapezoid),
+		Facing:           make(map[EntityID]Facing),
+		AI:               make(map[EntityID]AI),
+		Dash:             make(map[EntityID]Dash),
+		Crouch:           make(map[EntityID]Crouch),
+		ProjectileData:   make(map[EntityID]Projectile),
+		PickupData:       make(map[EntityID]Pickup),
+		PlayerData:       make(map[EntityID]Player),
+		Elite:            make(map[EntityID]Elite),
+		Particle:         make(map[EntityID]Particle),
+		CrowdControl:     make(map[EntityID]CrowdControl),
+		NPC:              make(map[EntityID]NPC),
+		Faction:          make(map[EntityID]Faction),
+		Ally:             make(map[EntityID]Ally),
+		Crumbles:         make(map[TileCoord]Crumble),
+		ContactCooldowns: make(map[ContactPair]int),
+		IsPlayer:         make(map[EntityID]struct{}),
+		IsEnemy:          make(map[EntityID]struct{}),
+		IsProjectile:     make(map[EntityID]struct{}),
+		IsPickup:         make(map[EntityID]struct{}),
+		IsNPC:            make(map[EntityID]struct{}),
+		Feedback:         NewFeedback(),
+		Events:           &Events{},
+		TimeScale:        NewTimeScale(),
+		CC:               DefaultCCConfig(),
+		Factions:         DefaultFactionRelations(),
+		Collision:        DefaultCollisionMask(),
 	}
+	w.SeedRand(1)
+	return w
 }
 
-// NewEntity returns a new unique entity ID
+// NewEntity returns a new unique entity ID in a fresh slot
 func (w *World) NewEntity() EntityID {
 	id := w.nextID
 	w.nextID++
+	w.generations = append(w.generations, 0)
 	return id
 }
 
-// DestroyEntity removes all components for an entity
+// newPooledEntity reuses a free slot from pool, or allocates a fresh one
+func (w *World) newPooledEntity(pool *[]uint32) EntityID {
+	n := len(*pool)
+	if n == 0 {
+		return w.NewEntity()
+	}
+	index := (*pool)[n-1]
+	*pool = (*pool)[:n-1]
+	return newEntityID(index, w.generations[index])
+}
+
+// isCurrent reports whether id refers to its slot's current generation
+func (w *World) isCurrent(id EntityID) bool {
+	index := id.Index()
+	return int(index) < len(w.generations) && w.generations[index] == id.Generation()
+}
+
+// PoolSizes returns the number of free projectile and pickup slots
+func (w *World) PoolSizes() (projectiles, pickups int) {
+	return len(w.projectilePool), len(w.pickupPool)
+}
+
+// DestroyEntity removes all components for an entity.
+// Stale IDs (already destroyed) are ignored. Projectile, pickup and particle
+// slots are returned to their pool with a new generation.
 func (w *World) DestroyEntity(id EntityID) {
+	if !w.isCurrent(id) {
+		return
+	}
+	_, isProjectile := w.IsProjectile[id]
+	_, isPickup := w.IsPickup[id]
+	_, isParticle := w.Particle[id]
+
 	delete(w.Position, id)
+	delete(w.PreviousPosition, id)
 	delete(w.Velocity, id)
 	delete(w.Movement, id)
 	delete(w.Health, id)
@@ -72,36 +212,89 @@ func (w *World) DestroyEntity(id EntityID) {
 	delete(w.Facing, id)
 	delete(w.AI, id)
 	delete(w.Dash, id)
+	delete(w.Crouch, id)
 	delete(w.ProjectileData, id)
-	delete(w.GoldData, id)
+	delete(w.PickupData, id)
 	delete(w.PlayerData, id)
+	delete(w.Elite, id)
+	delete(w.Particle, id)
+	delete(w.CrowdControl, id)
+	delete(w.NPC, id)
+	delete(w.Faction, id)
+	delete(w.Ally, id)
 	delete(w.IsPlayer, id)
 	delete(w.IsEnemy, id)
 	delete(w.IsProjectile, id)
-	delete(w.IsGold, id)
+	delete(w.IsPickup, id)
+	delete(w.IsNPC, id)
+
+	index := id.Index()
+	w.generations[index]++
+	switch {
+	case isProjectile:
+		w.projectilePool = append(w.projectilePool, index)
+	case isPickup:
+		w.pickupPool = append(w.pickupPool, index)
+	case isParticle:
+		w.particlePool = append(w.particlePool, index)
+	}
 }
 
-// Exists checks if an entity has Position component
+// Exists checks if an entity is current and has a Position component
 func (w *World) Exists(id EntityID) bool {
+	if !w.isCurrent(id) {
+		return false
+	}
 	_, ok := w.Position[id]
 	return ok
 }
 
-// CreatePlayer creates a player entity
-func (w *World) CreatePlayer(pixelX, pixelY int, hitbox HitboxTrapezoid, maxHealth int) EntityID {
+// PlayerProfile holds the character-specific data for creating the player
+type PlayerProfile struct {
+	Hitbox       HitboxTrapezoid
+	Crouch       HitboxTrapezoid // hitbox while crouched (zero = can't crouch)
+	MaxHealth    int
+	Arrows       []ArrowType // equipped arrows, one per select slot (nil = DefaultArrows)
+	Ammo         int         // homing arrows at the start of a run
+	DashLevel    int         // starting dash attack tier
+	MagnetLevel  int         // starting magnet tier
+	Summon       bool        // starts with the summonable turret unlocked
+	Mass         int         // knockback resistance in percent (0 = 100)
+	GravityScale int         // percent of the player gravity (0 = 100)
+}
+
+// DefaultArrows is the loadout of a profile without Arrows
+var DefaultArrows = []ArrowType{ArrowGray, ArrowRed, ArrowBlue, ArrowPurple}
+
+// CreatePlayer creates a player entity from a character profile
+func (w *World) CreatePlayer(pixelX, pixelY int, profile PlayerProfile) EntityID {
 	id := w.NewEntity()
 
+	arrows := profile.Arrows
+	if len(arrows) == 0 {
+		arrows = DefaultArrows
+	}
+	equipped := slices.Clone(arrows)
+
 	w.Position[id] = Position{X: pixelX * PositionScale, Y: pixelY * PositionScale}
 	w.Velocity[id] = Velocity{}
-	w.Movement[id] = Movement{}
-	w.Health[id] = Health{Current: maxHealth, Max: maxHealth}
-	w.HitboxTrapezoid[id] = hitbox
+	w.Movement[id] = Movement{GravityScale: profile.GravityScale, Mass: profile.Mass}
+	w.Health[id] = Health{Current: profile.MaxHealth, Max: profile.MaxHealth}
+	w.HitboxTrapezoid[id] = profile.Hitbox
 	w.Facing[id] = Facing{Right: true}
-	w.Dash[id] = Dash{CanDash: true}
+	w.Dash[id] = Dash{CanDash: true, Level: profile.DashLevel}
+	if profile.Crouch.Body.Height > 0 {
+		w.Crouch[id] = Crouch{Stand: profile.Hitbox, Low: profile.Crouch}
+	}
+	w.CrowdControl[id] = CrowdControl{}
 	w.PlayerData[id] = Player{
-		EquippedArrows: [4]ArrowType{ArrowGray, ArrowRed, ArrowBlue, ArrowPurple},
-		CurrentArrow:   ArrowGray,
+		EquippedArrows: equipped,
+		CurrentArrow:   equipped[0],
+		Ammo:           profile.Ammo,
+		MagnetLevel:    profile.MagnetLevel,
+		SummonUnlocked: profile.Summon,
 	}
+	w.Faction[id] = FactionPlayer
 	w.IsPlayer[id] = struct{}{}
 
 	w.PlayerID = id
@@ -111,52 +304,137 @@ func (w *World) CreatePlayer(pixelX, pixelY int, hitbox HitboxTrapezoid, maxHeal
 // EnemyConfig holds configuration for creating an enemy
 // Physics values are in IU/substep (pre-converted)
 type EnemyConfig struct {
-	MaxHealth     int
-	ContactDamage int
-	MoveSpeed     int // IU/substep
-	HitboxOffsetX int
-	HitboxOffsetY int
-	HitboxWidth   int
-	HitboxHeight  int
-	AIType        AIType
-	DetectRange   int // pixels
-	PatrolDist    int // pixels
-	AttackRange   int // pixels
-	JumpForce     int // IU/substep
-	Flying        bool
-	GoldDropMin   int
-	GoldDropMax   int
+	MaxHealth      int
+	ContactDamage  int
+	MoveSpeed      int // IU/substep
+	HitboxOffsetX  int
+	HitboxOffsetY  int
+	HitboxWidth    int
+	HitboxHeight   int
+	AIType         AIType
+	DetectRange    int // pixels
+	PatrolDist     int // pixels
+	AttackRange    int // pixels
+	AttackCooldown int // frames between shots (0 = DefaultAttackCooldown)
+	JumpForce      int // IU/substep
+	Flying         bool
+	Loot           LootTable
+	Elite          Elite       // Modifier EliteNone = regular enemy
+	Homing         Homing      // homing for fired projectiles
+	Hitscan        bool        // fires instant beams instead of projectiles
+	Piercing       bool        // its beams hit every target on their line
+	SpawnFrames    int         // spawn-in duration (applied by SpawnIn)
+	WindupFrames   int         // attack telegraph (0 = attack instantly)
+	Mass           int         // knockback resistance in percent (0 = from hitbox size)
+	GravityScale   int         // percent of the enemy gravity (0 = 100)
+	Solid          bool        // the player stands on it and is pushed out of it
+	Stomp          StompKind   // reaction to the player landing on its head
+	Group          string      // stage-defined alert group ("" = alert neighbours within AggroConfig.Radius)
+	Route          PatrolRoute // stage-defined patrol waypoints (empty = PatrolDist around the spawn)
+	TurnAtLedges   bool        // ground patrollers turn around at platform edges
+	Aim            AimMode     // how shots are aimed at the player
+	ArcShots       bool        // aimed shots lob on the high trajectory
+	Flock          Flock       // swarm AI steering
+	Faction        Faction     // side it fights on (FactionNone = FactionEnemy)
+
+	ChargeSpeed      int // IU/substep dash speed of charger AI (0 = MoveSpeed)
+	ChargeStunFrames int // charger AI self-stun after dashing into a wall (0 = the stun profile's)
+
+	KnockbackResistPct int  // share of knockback shrugged off (100 = never pushed)
+	SuperArmor         bool // hits never push or stun it
+	ArmoredCharge      bool // super-armor while winding up and rushing
+}
+
+// defaultAttackCooldownSeconds is the enemy shot cooldown when none is configured
+const defaultAttackCooldownSeconds = 1.5
+
+// DefaultAttackCooldown returns the enemy shot cooldown in frames when none
+// is configured
+func DefaultAttackCooldown() int {
+	return fixedpoint.SecondsToFrames(defaultAttackCooldownSeconds)
+}
+
+// massTileArea is the hitbox area (one 16px tile) of a normal-weight enemy
+const massTileArea = 16 * 16
+
+// enemyMass returns the knockback resistance of an enemy that has no mass
+// configured: 100% up to one tile, growing with the hitbox area above that
+func enemyMass(hb Hitbox) int {
+	return max(100, hb.Width*hb.Height*100/massTileArea)
 }
 
 // CreateEnemy creates an enemy entity
 func (w *World) CreateEnemy(pixelX, pixelY int, cfg EnemyConfig, facingRight bool) EntityID {
 	id := w.NewEntity()
 
+	attackCooldown := cfg.AttackCooldown
+	if attackCooldown <= 0 {
+		attackCooldown = DefaultAttackCooldown()
+	}
+	chargeSpeed := cfg.ChargeSpeed
+	if chargeSpeed <= 0 {
+		chargeSpeed = cfg.MoveSpeed
+	}
+
 	w.Position[id] = Position{X: pixelX * PositionScale, Y: pixelY * PositionScale}
 	w.Velocity[id] = Velocity{}
-	w.Movement[id] = Movement{}
 	w.Health[id] = Health{Current: cfg.MaxHealth, Max: cfg.MaxHealth}
-	w.Hitbox[id] = Hitbox{
+	hitbox := Hitbox{
 		OffsetX: cfg.HitboxOffsetX,
 		OffsetY: cfg.HitboxOffsetY,
 		Width:   cfg.HitboxWidth,
 		Height:  cfg.HitboxHeight,
 	}
+	w.Hitbox[id] = hitbox
+	mass := cfg.Mass
+	if mass <= 0 {
+		mass = enemyMass(hitbox)
+	}
+	w.Movement[id] = Movement{GravityScale: cfg.GravityScale, Mass: mass}
 	w.Facing[id] = Facing{Right: facingRight}
 	w.AI[id] = AI{
 		Type:           cfg.AIType,
 		DetectRange:    cfg.DetectRange,
 		AttackRange:    cfg.AttackRange,
+		AttackCooldown: attackCooldown,
 		PatrolDistance: cfg.PatrolDist,
 		JumpForce:      cfg.JumpForce,
 		MoveSpeed:      cfg.MoveSpeed,
 		ContactDamage:  cfg.ContactDamage,
 		Flying:         cfg.Flying,
+		Homing:         cfg.Homing,
+		Hitscan:        cfg.Hitscan,
+		Piercing:       cfg.Piercing,
+		SpawnFrames:    cfg.SpawnFrames,
+		WindupFrames:   cfg.WindupFrames,
+		Solid:          cfg.Solid,
+		Stomp:          cfg.Stomp,
+		Group:          cfg.Group,
+		Route:          cfg.Route,
+		TurnAtLedges:   cfg.TurnAtLedges,
+		Aim:            cfg.Aim,
+		ArcShots:       cfg.ArcShots,
+		Flock:          cfg.Flock,
 		PatrolStartX:   pixelX,
 		PatrolDir:      -1,
-		GoldDropMin:    cfg.GoldDropMin,
-		GoldDropMax:    cfg.GoldDropMax,
+		Loot:           cfg.Loot,
+
+		KnockbackResistPct: cfg.KnockbackResistPct,
+		SuperArmor:         cfg.SuperArmor,
+		ArmoredCharge:      cfg.ArmoredCharge,
+
+		ChargeSpeed:      chargeSpeed,
+		ChargeStunFrames: cfg.ChargeStunFrames,
+	}
+	w.CrowdControl[id] = CrowdControl{}
+	if cfg.Elite.Modifier != EliteNone {
+		w.Elite[id] = cfg.Elite
 	}
+	faction := cfg.Faction
+	if faction == FactionNone {
+		faction = FactionEnemy
+	}
+	w.Faction[id] = faction
 	w.IsEnemy[id] = struct{}{}
 
 	return id
@@ -165,6 +443,7 @@ func (w *World) CreateEnemy(pixelX, pixelY int, cfg EnemyConfig, facingRight boo
 // ProjectileConfig holds configuration for creating a projectile
 // All velocity values are in IU/substep (pre-converted)
 type ProjectileConfig struct {
+	Speed         int // IU/substep launch speed of enemy shots (0 = DefaultArrowSpeed)
 	GravityAccel  int // IU/substep²
 	MaxFallSpeed  int // IU/substep
 	MaxRange      int // pixels
@@ -174,13 +453,26 @@ type ProjectileConfig struct {
 	HitboxWidth   int
 	HitboxHeight  int
 	StuckDuration int // frames
+	Homing        Homing
+	Intercepts    bool      // destroys hostile projectiles on overlap
+	Hitscan       bool      // fired as an instant beam (see FireBeam)
+	Piercing      bool      // a beam hits every target on its line, not just the first
+	BouncePercent int       // 0-100 velocity kept bouncing off tiles (0 = sticks in them)
+	FuseFrames    int       // frames until it explodes (0 = no fuse, see UpdateBombs)
+	Explosion     Explosion // blast when the fuse runs out
+	Boomerang     bool      // flies back to the player from its max range (see updateBoomerang)
+	Ammo          bool      // the shot spent ammo (a caught boomerang gives it back)
+
+	Arrow          ArrowType // player arrow type (rendering)
+	StickInEnemies bool      // sticks into enemies it hits instead of breaking
+	Bleed          Bleed     // damage over time while stuck in an enemy
 }
 
-// CreateProjectile creates a projectile entity
+// CreateProjectile creates a projectile entity fired by faction
 // x, y: pixel coordinates
 // vx, vy: IU/substep velocity
-func (w *World) CreateProjectile(x, y int, vx, vy int, cfg ProjectileConfig, isPlayer bool) EntityID {
-	id := w.NewEntity()
+func (w *World) CreateProjectile(x, y int, vx, vy int, cfg ProjectileConfig, faction Faction) EntityID {
+	id := w.newPooledEntity(&w.projectilePool)
 
 	w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
 	w.Velocity[id] = Velocity{X: vx, Y: vy}
@@ -192,33 +484,55 @@ func (w *World) CreateProjectile(x, y int, vx, vy int, cfg ProjectileConfig, isP
 	}
 	w.ProjectileData[id] = Projectile{
 		StartX:        x,
+		StartY:        y,
 		GravityAccel:  cfg.GravityAccel,
 		MaxFallSpeed:  cfg.MaxFallSpeed,
 		MaxRange:      cfg.MaxRange,
 		Damage:        cfg.Damage,
-		IsPlayerOwned: isPlayer,
 		StuckDuration: cfg.StuckDuration,
+		Homing:        cfg.Homing,
+		Intercepts:    cfg.Intercepts,
+		BouncePercent: cfg.BouncePercent,
+		Fuse:          cfg.FuseFrames,
+		Explosion:     cfg.Explosion,
+		Boomerang:     cfg.Boomerang,
+		Speed:         isqrt(vx*vx + vy*vy),
+		Ammo:          cfg.Ammo,
+
+		Arrow:          cfg.Arrow,
+		StickInEnemies: cfg.StickInEnemies,
+		Bleed:          cfg.Bleed,
 	}
+	w.Faction[id] = faction
 	w.IsProjectile[id] = struct{}{}
 
 	return id
 }
 
-// GoldConfig holds configuration for creating gold
+// PickupConfig holds configuration for creating pickups
 // All velocity values are in IU/substep (pre-converted)
-type GoldConfig struct {
+type PickupConfig struct {
 	Gravity       int // IU/substep²
 	BouncePercent int // 0-100 (percentage of velocity retained on bounce)
 	CollectDelay  int // frames
 	HitboxWidth   int // pixels
 	HitboxHeight  int // pixels
 	CollectRadius int // pixels
+	MagnetRadius  int // pixels (0 = no magnet)
+	MagnetSpeed   int // IU/substep
 }
 
 // CreateGold creates a gold pickup entity
 // x, y: pixel coordinates
-func (w *World) CreateGold(x, y int, amount int, cfg GoldConfig) EntityID {
-	id := w.NewEntity()
+func (w *World) CreateGold(x, y int, amount int, cfg PickupConfig) EntityID {
+	return w.CreatePickup(x, y, PickupGold, amount, cfg)
+}
+
+// CreatePickup creates a dropped pickup entity of any kind.
+// It pops up with a small spread; see PlacePickup for placed pickups.
+// x, y: pixel coordinates
+func (w *World) CreatePickup(x, y int, kind PickupKind, amount int, cfg PickupConfig) EntityID {
+	id := w.newPooledEntity(&w.pickupPool)
 
 	w.Position[id] = Position{X: x * PositionScale, Y: y * PositionScale}
 	// Random spread velocity (IU/substep)
@@ -226,7 +540,8 @@ func (w *World) CreateGold(x, y int, amount int, cfg GoldConfig) EntityID {
 	spreadVX := ((amount % 10) - 5) * 9 // -45 to +45 IU/substep
 	popVelocity := -43                  // -100 pixels/sec ≈ -43 IU/substep
 	w.Velocity[id] = Velocity{X: spreadVX, Y: popVelocity}
-	w.GoldData[id] = Gold{
+	w.PickupData[id] = Pickup{
+		Kind:          kind,
 		Amount:        amount,
 		Grounded:      false,
 		CollectDelay:  cfg.CollectDelay,
@@ -235,9 +550,19 @@ func (w *World) CreateGold(x, y int, amount int, cfg GoldConfig) EntityID {
 		CollectRadius: cfg.CollectRadius,
 		HitboxWidth:   cfg.HitboxWidth,
 		HitboxHeight:  cfg.HitboxHeight,
+		MagnetRadius:  cfg.MagnetRadius,
+		MagnetSpeed:   cfg.MagnetSpeed,
 	}
-	w.IsGold[id] = struct{}{}
+	w.IsPickup[id] = struct{}{}
+
+	return id
+}
 
+// PlacePickup creates a pickup at rest, for pickups placed in the stage
+// x, y: pixel coordinates
+func (w *World) PlacePickup(x, y int, kind PickupKind, amount int, cfg PickupConfig) EntityID {
+	id := w.CreatePickup(x, y, kind, amount, cfg)
+	w.Velocity[id] = Velocity{}
 	return id
 }
 
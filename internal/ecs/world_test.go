@@ -43,6 +43,59 @@ func TestEntityIDNeverRecycled(t *testing.T) {
 	assert.Equal(t, EntityID(2), id2)
 }
 
+func TestProjectilePoolReusesSlotWithNewGeneration(t *testing.T) {
+	w := NewWorld()
+	cfg := ProjectileConfig{HitboxWidth: 4, HitboxHeight: 4}
+
+	id1 := w.CreateProjectile(10, 20, 5, 0, cfg, FactionPlayer)
+	w.DestroyEntity(id1)
+
+	projectiles, gold := w.PoolSizes()
+	assert.Equal(t, 1, projectiles)
+	assert.Equal(t, 0, gold)
+
+	id2 := w.CreateProjectile(30, 40, -5, 0, cfg, FactionEnemy)
+	assert.Equal(t, id1.Index(), id2.Index(), "Slot should be recycled")
+	assert.Equal(t, id1.Generation()+1, id2.Generation())
+	assert.NotEqual(t, id1, id2)
+
+	assert.True(t, w.Exists(id2))
+	assert.False(t, w.Exists(id1), "Stale ID should be rejected")
+
+	projectiles, _ = w.PoolSizes()
+	assert.Equal(t, 0, projectiles)
+}
+
+func TestDestroyStaleIDIsNoop(t *testing.T) {
+	w := NewWorld()
+
+	id1 := w.CreateGold(0, 0, 5, PickupConfig{HitboxWidth: 8, HitboxHeight: 8})
+	w.DestroyEntity(id1)
+	w.DestroyEntity(id1)
+
+	_, gold := w.PoolSizes()
+	assert.Equal(t, 1, gold, "Double destroy should not pool the slot twice")
+
+	id2 := w.CreateGold(0, 0, 5, PickupConfig{HitboxWidth: 8, HitboxHeight: 8})
+	w.DestroyEntity(id1)
+	assert.True(t, w.Exists(id2), "Stale destroy should not remove the new occupant")
+	_, hasGold := w.PickupData[id2]
+	assert.True(t, hasGold)
+}
+
+func TestPoolsAreSeparatedByKind(t *testing.T) {
+	w := NewWorld()
+
+	proj := w.CreateProjectile(0, 0, 0, 0, ProjectileConfig{}, FactionPlayer)
+	w.DestroyEntity(proj)
+
+	gold := w.CreateGold(0, 0, 1, PickupConfig{})
+	assert.NotEqual(t, proj.Index(), gold.Index(), "Gold should not take a projectile slot")
+
+	plain := w.NewEntity()
+	assert.NotEqual(t, proj.Index(), plain.Index(), "Plain entities should never be recycled")
+}
+
 func TestDestroyEntity(t *testing.T) {
 	w := NewWorld()
 	id := w.NewEntity()
@@ -69,6 +122,33 @@ func TestDestroyEntity(t *testing.T) {
 	assert.False(t, isEnemy)
 }
 
+func TestCreatePlayerProfile(t *testing.T) {
+	w := NewWorld()
+	id := w.CreatePlayer(10, 20, PlayerProfile{
+		Hitbox:      HitboxTrapezoid{Body: Hitbox{OffsetX: 2, OffsetY: 6, Width: 12, Height: 12}},
+		MaxHealth:   140,
+		Arrows:      []ArrowType{ArrowRed, ArrowBlue},
+		Ammo:        5,
+		DashLevel:   1,
+		MagnetLevel: 2,
+	})
+
+	assert.Equal(t, id, w.PlayerID)
+	assert.Equal(t, Health{Current: 140, Max: 140}, w.Health[id])
+	assert.Equal(t, 12, w.HitboxTrapezoid[id].Body.Width)
+	assert.Equal(t, 1, w.Dash[id].Level)
+
+	player := w.PlayerData[id]
+	assert.Equal(t, []ArrowType{ArrowRed, ArrowBlue}, player.EquippedArrows)
+	assert.Equal(t, ArrowRed, player.CurrentArrow)
+	assert.Equal(t, 5, player.Ammo)
+	assert.Equal(t, 2, player.MagnetLevel)
+
+	w = NewWorld()
+	id = w.CreatePlayer(0, 0, PlayerProfile{MaxHealth: 100})
+	assert.Equal(t, DefaultArrows, w.PlayerData[id].EquippedArrows)
+}
+
 func TestExists(t *testing.T) {
 	w := NewWorld()
 	id := w.NewEntity()
@@ -0,0 +1,18 @@
+package config
+
+// AchievementsConfig is the root config for achievements.json
+type AchievementsConfig struct {
+	Achievements []AchievementConfig `json:"achievements"`
+}
+
+// AchievementConfig defines one achievement.
+// Kind selects the condition: "kills" and "gold" are lifetime totals,
+// "stageNoDamage" is clearing a stage without taking damage and
+// "bossTime" is killing a boss within Target seconds.
+type AchievementConfig struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Kind        string `json:"kind"`
+	Target      int    `json:"target"`
+}
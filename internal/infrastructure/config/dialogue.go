@@ -0,0 +1,30 @@
+package config
+
+// DialoguesConfig is the root config for dialogues.json
+type DialoguesConfig struct {
+	TypewriterSpeed float64                   `json:"typewriterSpeed"` // characters per second
+	Portraits       map[string]PortraitConfig `json:"portraits"`
+	Dialogues       map[string]DialogueConfig `json:"dialogues"`
+}
+
+// PortraitConfig is a speaker portrait (placeholder color until sprites exist)
+type PortraitConfig struct {
+	Color string `json:"color"`
+}
+
+// DialogueConfig is one conversation
+type DialogueConfig struct {
+	Pages   []DialoguePageConfig   `json:"pages"`
+	Choices []DialogueChoiceConfig `json:"choices"`
+}
+
+type DialoguePageConfig struct {
+	Speaker  string `json:"speaker"`
+	Portrait string `json:"portrait"`
+	Text     string `json:"text"`
+}
+
+type DialogueChoiceConfig struct {
+	Text string `json:"text"`
+	Next string `json:"next"`
+}
@@ -0,0 +1,30 @@
+package config
+
+// DifficultyConfig is the root config for difficulty.json
+type DifficultyConfig struct {
+	Default  string                       `json:"default"`
+	Profiles map[string]DifficultyProfile `json:"profiles"`
+}
+
+// DifficultyProfile holds multipliers applied when building enemy and
+// player tuning (1.0 = unchanged, 0 or missing = 1.0).
+// SpawnRate scales how often enemies spawn (2.0 = twice as often).
+type DifficultyProfile struct {
+	EnemyHealth    float64 `json:"enemyHealth"`
+	ContactDamage  float64 `json:"contactDamage"`
+	AttackCooldown float64 `json:"attackCooldown"`
+	SpawnRate      float64 `json:"spawnRate"`
+	PlayerIframes  float64 `json:"playerIframes"`
+}
+
+// Profile returns the named profile, falling back to the default profile.
+// A nil config or unknown default yields a neutral profile.
+func (c *DifficultyConfig) Profile(name string) DifficultyProfile {
+	if c == nil {
+		return DifficultyProfile{}
+	}
+	if p, ok := c.Profiles[name]; ok {
+		return p
+	}
+	return c.Profiles[c.Default]
+}
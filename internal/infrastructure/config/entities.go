@@ -2,19 +2,46 @@ package config
 
 // EntitiesConfig is the root config for entities.json
 type EntitiesConfig struct {
-	Player      PlayerConfig               `json:"player"`
+	Characters  []PlayerConfig              `json:"characters"` // selectable player characters (first = default)
 	Projectiles map[string]ProjectileConfig `json:"projectiles"`
 	Enemies     map[string]EnemyConfig      `json:"enemies"`
 	Pickups     map[string]PickupConfig     `json:"pickups"`
 	Effects     map[string]EffectConfig     `json:"effects"`
+	Elites      map[string]EliteConfig      `json:"elites"`
 }
 
+// Character returns the character with the given ID, falling back to the
+// first character (zero config if none are defined).
+func (c *EntitiesConfig) Character(id string) PlayerConfig {
+	for _, ch := range c.Characters {
+		if ch.ID == id {
+			return ch
+		}
+	}
+	if len(c.Characters) == 0 {
+		return PlayerConfig{}
+	}
+	return c.Characters[0]
+}
+
+// PlayerConfig is a selectable player character
 type PlayerConfig struct {
-	ID      string       `json:"id"`
-	Sprite  SpriteConfig `json:"sprite"`
-	Hitbox  HitboxConfig `json:"hitbox"`
-	Hurtbox Rect         `json:"hurtbox"`
-	Stats   PlayerStats  `json:"stats"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"` // i18n key of the display name
+	Sprite    SpriteConfig    `json:"sprite"`
+	Hitbox    HitboxConfig    `json:"hitbox"`
+	Hurtbox   Rect            `json:"hurtbox"`
+	Stats     PlayerStats     `json:"stats"`
+	Abilities AbilitiesConfig `json:"abilities"`
+}
+
+// AbilitiesConfig is what a character starts a run with
+type AbilitiesConfig struct {
+	Arrows      []string `json:"arrows,omitempty"` // arrow select slots: gray, red, blue, purple (empty = all four)
+	Block       bool     `json:"block"`            // can raise the shield
+	DashLevel   int      `json:"dashLevel,omitempty"`
+	MagnetLevel int      `json:"magnetLevel,omitempty"`
+	Summon      bool     `json:"summon,omitempty"` // starts with the summonable turret
 }
 
 type SpriteConfig struct {
@@ -34,6 +61,8 @@ type HitboxConfig struct {
 	Head Rect `json:"head"`
 	Body Rect `json:"body"`
 	Feet Rect `json:"feet"`
+
+	Crouch *HitboxConfig `json:"crouch,omitempty"` // while crouched (nil = can't crouch)
 }
 
 type Rect struct {
@@ -44,8 +73,13 @@ type Rect struct {
 }
 
 type PlayerStats struct {
-	MaxHealth    int `json:"maxHealth"`
-	AttackDamage int `json:"attackDamage"`
+	MaxHealth    int     `json:"maxHealth"`
+	AttackDamage int     `json:"attackDamage"`
+	StartingAmmo int     `json:"startingAmmo"`           // homing arrows at the start of a run
+	MoveSpeed    float64 `json:"moveSpeed,omitempty"`    // movement max speed multiplier (0 = 1)
+	JumpForce    float64 `json:"jumpForce,omitempty"`    // jump force multiplier (0 = 1)
+	Mass         float64 `json:"mass,omitempty"`         // knockback resistance (0 = 1)
+	GravityScale float64 `json:"gravityScale,omitempty"` // gravity multiplier (0 = 1)
 }
 
 type ProjectileConfig struct {
@@ -57,22 +91,49 @@ type ProjectileConfig struct {
 }
 
 type ProjectilePhysicsConfig struct {
-	Speed            float64 `json:"speed"`
-	LaunchAngleDeg   float64 `json:"launchAngleDeg"`
-	GravityAccel     float64 `json:"gravityAccel"`
-	MaxFallSpeed     float64 `json:"maxFallSpeed"`
-	MaxRange         float64 `json:"maxRange"`
-	RotateToVelocity bool    `json:"rotateToVelocity"`
-	Piercing         bool    `json:"piercing"`
+	Speed            float64       `json:"speed"`
+	LaunchAngleDeg   float64       `json:"launchAngleDeg"`
+	GravityAccel     float64       `json:"gravityAccel"`
+	MaxFallSpeed     float64       `json:"maxFallSpeed"`
+	MaxRange         float64       `json:"maxRange"`
+	RotateToVelocity bool          `json:"rotateToVelocity"`
+	Piercing         bool          `json:"piercing"`
+	Homing           *HomingConfig `json:"homing,omitempty"`
+	Intercepts       bool          `json:"intercepts,omitempty"` // player arrow destroys enemy projectiles
+	Hitscan          bool          `json:"hitscan,omitempty"`    // fired as an instant beam; piercing beams hit every target on the line
+	Cooldown         float64       `json:"cooldown,omitempty"`   // seconds between enemy shots unless the enemy sets attackCooldown
+	Bounce           float64       `json:"bounce,omitempty"`     // share of speed kept bouncing off tiles, 0-1 (0 = sticks in them)
+	Fuse             float64       `json:"fuse,omitempty"`       // seconds until it explodes (a bomb; passes through targets)
+	Explosion        *BlastConfig  `json:"explosion,omitempty"`  // blast when the fuse runs out, dealing the projectile's damage at the center
+	Boomerang        bool          `json:"boomerang,omitempty"`  // flies back to the player from maxRange (in any direction) and is caught
+	Ammo             bool          `json:"ammo,omitempty"`       // each shot spends one ammo (a caught boomerang gives it back)
+}
+
+// BlastConfig is the explosion of a bomb
+type BlastConfig struct {
+	Radius    float64 `json:"radius"`    // pixels
+	Falloff   float64 `json:"falloff"`   // 0-1, share of damage and knockback lost at the edge
+	Knockback float64 `json:"knockback"` // radial push at the center (pixels/sec)
+}
+
+// HomingConfig makes a projectile curve toward the nearest target
+type HomingConfig struct {
+	TurnRate     float64 `json:"turnRate"`     // steering acceleration (pixels/sec²)
+	AcquireRange float64 `json:"acquireRange"` // pixels
+	Lifetime     float64 `json:"lifetime"`     // seconds of homing before flying straight
 }
 
 type EnemyConfig struct {
-	ID      string           `json:"id"`
-	Sprite  SpriteConfig     `json:"sprite"`
+	ID      string            `json:"id"`
+	Sprite  SpriteConfig      `json:"sprite"`
 	Hitbox  EnemyHitboxConfig `json:"hitbox"`
-	Hurtbox Rect             `json:"hurtbox"`
-	Stats   EnemyStats       `json:"stats"`
-	AI      AIConfig         `json:"ai"`
+	Hurtbox Rect              `json:"hurtbox"`
+	Stats   EnemyStats        `json:"stats"`
+	AI      AIConfig          `json:"ai"`
+	Loot    LootTableConfig   `json:"loot"`
+	Solid   bool              `json:"solid,omitempty"` // the player stands on it and is pushed out of it
+	Stomp   string            `json:"stomp,omitempty"`   // stompable or spiky ("" = ordinary contact)
+	Faction string            `json:"faction,omitempty"` // enemy, neutral or hostile ("" = enemy)
 }
 
 type EnemyHitboxConfig struct {
@@ -80,15 +141,29 @@ type EnemyHitboxConfig struct {
 }
 
 type EnemyStats struct {
-	MaxHealth     int      `json:"maxHealth"`
-	ContactDamage int      `json:"contactDamage"`
-	MoveSpeed     float64  `json:"moveSpeed,omitempty"`
-	GoldDrop      GoldDrop `json:"goldDrop"`
+	MaxHealth     int     `json:"maxHealth"`
+	ContactDamage int     `json:"contactDamage"`
+	MoveSpeed     float64 `json:"moveSpeed,omitempty"`
+	Mass          float64 `json:"mass,omitempty"`         // knockback resistance (1 = normal, 0 = from hitbox size)
+	GravityScale  float64 `json:"gravityScale,omitempty"` // gravity multiplier, e.g. 0.5 for floaty enemies (0 = 1)
+
+	KnockbackResist float64 `json:"knockbackResist,omitempty"` // share of knockback ignored (1 = never pushed)
+	SuperArmor      bool    `json:"superArmor,omitempty"`      // hits deal damage but never push or stun
+}
+
+// LootTableConfig configures what an enemy drops on death.
+// Each roll picks one entry by weight.
+type LootTableConfig struct {
+	Rolls   int               `json:"rolls,omitempty"` // 0 = one roll
+	Entries []LootEntryConfig `json:"entries"`
 }
 
-type GoldDrop struct {
-	Min int `json:"min"`
-	Max int `json:"max"`
+// LootEntryConfig is one weighted loot outcome
+type LootEntryConfig struct {
+	Pickup string `json:"pickup"` // gold, health, ammo, treasure or none
+	Weight int    `json:"weight"`
+	Min    int    `json:"min"` // amount range (inclusive)
+	Max    int    `json:"max"`
 }
 
 type AIConfig struct {
@@ -101,15 +176,36 @@ type AIConfig struct {
 	Projectile     string  `json:"projectile,omitempty"`
 	ChaseSpeed     float64 `json:"chaseSpeed,omitempty"`
 	Flying         bool    `json:"flying,omitempty"`
-	JumpForce      float64 `json:"jumpForce,omitempty"` // For aggressive AI
+	JumpForce      float64 `json:"jumpForce,omitempty"`      // For aggressive AI
+	SpawnDuration  float64 `json:"spawnDuration,omitempty"`  // seconds spawning in when created by a spawner
+	WindupDuration float64 `json:"windupDuration,omitempty"` // seconds telegraphing shots and charges
+	TurnAtLedges   bool    `json:"turnAtLedges,omitempty"`   // patrollers turn around instead of walking off platforms
+	Aim            string  `json:"aim,omitempty"`            // "straight" (default), "direct" or "lead"
+	Arc            bool    `json:"arc,omitempty"`            // aimed shots lob instead of flying flat
+	ArmoredCharge  bool    `json:"armoredCharge,omitempty"`  // super-armor while winding up and charging
+	ChargeSpeed    float64 `json:"chargeSpeed,omitempty"`    // charger AI dash speed in pixels/sec (0 = moveSpeed)
+	ChargeStun     float64 `json:"chargeStun,omitempty"`     // seconds a charger is stunned after dashing into a wall
+
+	Flock *FlockConfig `json:"flock,omitempty"` // steering for swarm AI
+}
+
+// FlockConfig tunes swarm AI. Weights are fractions of the enemy's move
+// speed (1.0 = full speed).
+type FlockConfig struct {
+	Radius     float64 `json:"radius"`     // pixels: flockmates this close steer each other
+	Separation float64 `json:"separation"` // away from crowding flockmates
+	Alignment  float64 `json:"alignment"`  // toward the flock's heading
+	Cohesion   float64 `json:"cohesion"`   // toward the flock's center
+	Seek       float64 `json:"seek"`       // toward the player once aware of them
 }
 
 type PickupConfig struct {
-	ID         string             `json:"id"`
-	Sprite     SpriteConfig       `json:"sprite"`
-	Hitbox     Rect               `json:"hitbox"`
+	ID         string              `json:"id"`
+	Sprite     SpriteConfig        `json:"sprite"`
+	Hitbox     Rect                `json:"hitbox"`
 	Physics    PickupPhysicsConfig `json:"physics,omitempty"`
-	HealAmount int                `json:"healAmount,omitempty"`
+	HealAmount int                 `json:"healAmount,omitempty"`
+	AmmoAmount int                 `json:"ammoAmount,omitempty"` // homing arrows given
 }
 
 type PickupPhysicsConfig struct {
@@ -117,6 +213,8 @@ type PickupPhysicsConfig struct {
 	BounceDecay   float64 `json:"bounceDecay"`
 	CollectDelay  float64 `json:"collectDelay"`
 	CollectRadius float64 `json:"collectRadius"`
+	MagnetRadius  float64 `json:"magnetRadius,omitempty"` // pixels; pickups closer than this fly to the player
+	MagnetSpeed   float64 `json:"magnetSpeed,omitempty"`  // pixels/sec
 }
 
 type EffectConfig struct {
@@ -124,3 +222,18 @@ type EffectConfig struct {
 	Sprite   SpriteConfig `json:"sprite"`
 	Duration float64      `json:"duration"`
 }
+
+// EliteConfig is an elite modifier ("fast", "armored", "explosive",
+// "regenerating") that can be attached to any enemy type.
+// Multipliers of 0 leave the stat unchanged.
+type EliteConfig struct {
+	Tint           string  `json:"tint"`
+	Health         float64 `json:"health,omitempty"`
+	MoveSpeed      float64 `json:"moveSpeed,omitempty"`
+	AttackCooldown float64 `json:"attackCooldown,omitempty"`
+	GoldMultiplier float64 `json:"goldMultiplier,omitempty"`
+	ArmorPct       int     `json:"armorPct,omitempty"`
+	RegenPerSecond float64 `json:"regenPerSecond,omitempty"`
+	ExplodeRadius  int     `json:"explodeRadius,omitempty"`
+	ExplodeDamage  int     `json:"explodeDamage,omitempty"`
+}
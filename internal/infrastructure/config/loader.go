@@ -9,14 +9,24 @@ import (
 
 // GameConfig holds all loaded configurations
 type GameConfig struct {
-	Physics  *PhysicsConfig
-	Entities *EntitiesConfig
+	Physics      *PhysicsConfig
+	Entities     *EntitiesConfig
+	Dialogues    *DialoguesConfig
+	Achievements *AchievementsConfig
+	Difficulty   *DifficultyConfig
+	UI           *UIConfig
 }
 
-// Loader loads game configuration from JSON files using fs.FS interface
+// Loader loads game configuration from JSON files using fs.FS interface.
+// Each file can be layered with override files (see SetOverrides) and
+// programmatic overrides (see AddOverride), deep-merged in that order.
 type Loader struct {
 	fsys     fs.FS
 	basePath string
+
+	stageID    string     // overrides/stages/<stageID>.json ("" = none)
+	difficulty string     // overrides/difficulty/<difficulty>.json ("" = none)
+	overrides  []Override // applied last, in order
 }
 
 // NewLoader creates a new config loader from filesystem path
@@ -43,7 +53,7 @@ func (l *Loader) LoadPhysics() (*PhysicsConfig, error) {
 	}
 
 	var cfg PhysicsConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := l.decode(data, "physics", &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse physics.json: %w", err)
 	}
 
@@ -58,13 +68,89 @@ func (l *Loader) LoadEntities() (*EntitiesConfig, error) {
 	}
 
 	var cfg EntitiesConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := l.decode(data, "entities", &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse entities.json: %w", err)
 	}
 
 	return &cfg, nil
 }
 
+// LoadDialogues loads dialogues.json
+func (l *Loader) LoadDialogues() (*DialoguesConfig, error) {
+	data, err := fs.ReadFile(l.fsys, "dialogues.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dialogues.json: %w", err)
+	}
+
+	var cfg DialoguesConfig
+	if err := l.decode(data, "dialogues", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse dialogues.json: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// LoadAchievements loads achievements.json
+func (l *Loader) LoadAchievements() (*AchievementsConfig, error) {
+	data, err := fs.ReadFile(l.fsys, "achievements.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read achievements.json: %w", err)
+	}
+
+	var cfg AchievementsConfig
+	if err := l.decode(data, "achievements", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse achievements.json: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// LoadDifficulty loads difficulty.json
+func (l *Loader) LoadDifficulty() (*DifficultyConfig, error) {
+	data, err := fs.ReadFile(l.fsys, "difficulty.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read difficulty.json: %w", err)
+	}
+
+	var cfg DifficultyConfig
+	if err := l.decode(data, "difficulty", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse difficulty.json: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// LoadUI loads ui.json
+func (l *Loader) LoadUI() (*UIConfig, error) {
+	data, err := fs.ReadFile(l.fsys, "ui.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ui.json: %w", err)
+	}
+
+	var cfg UIConfig
+	if err := l.decode(data, "ui", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse ui.json: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// LoadTower loads tower.json, the endless tower mode (optional: the mode
+// is off without it)
+func (l *Loader) LoadTower() (*TowerConfig, error) {
+	data, err := fs.ReadFile(l.fsys, "tower.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tower.json: %w", err)
+	}
+
+	var cfg TowerConfig
+	if err := l.decode(data, "tower", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse tower.json: %w", err)
+	}
+
+	return &cfg, nil
+}
+
 // LoadStage loads a stage JSON file
 func (l *Loader) LoadStage(name string) (*StageConfig, error) {
 	path := "stages/" + name + ".json"
@@ -74,14 +160,31 @@ func (l *Loader) LoadStage(name string) (*StageConfig, error) {
 	}
 
 	var cfg StageConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := l.decode(data, "stage", &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse stage %s: %w", name, err)
 	}
 
 	return &cfg, nil
 }
 
-// LoadAll loads all base configurations (physics, entities)
+// LoadLocale loads a UI string table (locales/<lang>.json)
+func (l *Loader) LoadLocale(lang string) (map[string]string, error) {
+	path := "locales/" + lang + ".json"
+	data, err := fs.ReadFile(l.fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locale %s: %w", lang, err)
+	}
+
+	var table map[string]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse locale %s: %w", lang, err)
+	}
+
+	return table, nil
+}
+
+// LoadAll loads all base configurations (physics, entities, dialogues, achievements, difficulty)
+// with the loader's overrides applied
 func (l *Loader) LoadAll() (*GameConfig, error) {
 	physics, err := l.LoadPhysics()
 	if err != nil {
@@ -93,8 +196,32 @@ func (l *Loader) LoadAll() (*GameConfig, error) {
 		return nil, err
 	}
 
+	dialogues, err := l.LoadDialogues()
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := l.LoadAchievements()
+	if err != nil {
+		return nil, err
+	}
+
+	difficulty, err := l.LoadDifficulty()
+	if err != nil {
+		return nil, err
+	}
+
+	ui, err := l.LoadUI()
+	if err != nil {
+		return nil, err
+	}
+
 	return &GameConfig{
-		Physics:  physics,
-		Entities: entities,
+		Physics:      physics,
+		Entities:     entities,
+		Dialogues:    dialogues,
+		Achievements: achievements,
+		Difficulty:   difficulty,
+		UI:           ui,
 	}, nil
 }
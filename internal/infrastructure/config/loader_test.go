@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,18 @@ func TestLoader_LoadPhysics(t *testing.T) {
 	assert.Equal(t, 800.0, cfg.Physics.Gravity)
 	assert.Equal(t, 0.1, cfg.Jump.CoyoteTime)
 	assert.True(t, cfg.Feedback.Hitstop.Enabled)
+
+	lightHit, ok := cfg.Feedback.Profiles["lightHit"]
+	require.True(t, ok)
+	assert.Equal(t, 3, lightHit.Hitstop)
+	assert.Equal(t, "max", lightHit.Stacking)
+
+	assert.Equal(t, 100.0, cfg.Combat.Block.MaxStamina)
+	assert.Equal(t, 0.15, cfg.Combat.Block.ParryWindow)
+	assert.Equal(t, []int{15, 25, 40}, cfg.Dash.Attack.Damage)
+	assert.Equal(t, "linear", cfg.Combat.CrowdControl.Enemy.KnockbackCurve)
+	assert.True(t, cfg.Combat.CrowdControl.Enemy.DecayVertical)
+	assert.Equal(t, []float64{48, 72, 104}, cfg.Magnet.Radius)
 }
 
 func TestLoader_LoadEntities(t *testing.T) {
@@ -27,19 +40,57 @@ func TestLoader_LoadEntities(t *testing.T) {
 	cfg, err := loader.LoadEntities()
 	require.NoError(t, err)
 
-	assert.Equal(t, "player", cfg.Player.ID)
-	assert.Equal(t, 100, cfg.Player.Stats.MaxHealth)
-	assert.Equal(t, 8, cfg.Player.Hitbox.Head.Width)
-	assert.Equal(t, 16, cfg.Player.Hitbox.Feet.Width)
+	player := cfg.Character("")
+	assert.Equal(t, "adventurer", player.ID)
+	assert.Equal(t, 100, player.Stats.MaxHealth)
+	assert.Equal(t, 8, player.Hitbox.Head.Width)
+	assert.Equal(t, 16, player.Hitbox.Feet.Width)
+	assert.True(t, player.Abilities.Block)
+
+	archer := cfg.Character("archer")
+	assert.Equal(t, "archer", archer.ID)
+	assert.Equal(t, 80, archer.Stats.MaxHealth)
+	assert.False(t, archer.Abilities.Block)
+
+	swordsman := cfg.Character("swordsman")
+	assert.Equal(t, []string{"gray", "red"}, swordsman.Abilities.Arrows)
+	assert.Equal(t, 1, swordsman.Abilities.DashLevel)
+	assert.Equal(t, "adventurer", cfg.Character("unknown").ID)
 
 	arrow, ok := cfg.Projectiles["playerArrow"]
 	require.True(t, ok)
 	assert.Equal(t, 20.0, arrow.Physics.LaunchAngleDeg)
 	assert.Equal(t, 500.0, arrow.Physics.GravityAccel)
+	assert.Nil(t, arrow.Physics.Homing)
+	assert.True(t, arrow.Physics.Intercepts)
+
+	homing, ok := cfg.Projectiles["homingArrow"]
+	require.True(t, ok)
+	require.NotNil(t, homing.Physics.Homing)
+	assert.Equal(t, 140.0, homing.Physics.Homing.AcquireRange)
+
+	bomb, ok := cfg.Projectiles["bomb"]
+	require.True(t, ok)
+	assert.Equal(t, 0.5, bomb.Physics.Bounce)
+	require.NotNil(t, bomb.Physics.Explosion)
+	assert.Equal(t, 40.0, bomb.Physics.Explosion.Radius)
 
 	slime, ok := cfg.Enemies["slime"]
 	require.True(t, ok)
 	assert.Equal(t, "patrol", slime.AI.Type)
+
+	berserker, ok := cfg.Enemies["berserker"]
+	require.True(t, ok)
+	assert.Equal(t, 0.75, berserker.AI.SpawnDuration)
+	assert.Equal(t, 0.4, berserker.AI.WindupDuration)
+	assert.Equal(t, 2, berserker.Loot.Rolls)
+	assert.Equal(t, LootEntryConfig{Pickup: "gold", Weight: 75, Min: 15, Max: 30}, berserker.Loot.Entries[0])
+	assert.Equal(t, 10, player.Stats.StartingAmmo)
+
+	ammo, ok := cfg.Pickups["ammo"]
+	require.True(t, ok)
+	assert.Equal(t, 5, ammo.AmmoAmount)
+	assert.Equal(t, 32.0, ammo.Physics.MagnetRadius)
 }
 
 func TestLoader_LoadStage(t *testing.T) {
@@ -60,6 +111,11 @@ func TestLoader_LoadStage(t *testing.T) {
 	require.True(t, ok)
 	assert.True(t, wall.Solid)
 	assert.Equal(t, "wall", wall.Type)
+
+	require.Len(t, cfg.NPCs, 2)
+	assert.Equal(t, "patrol", cfg.NPCs[0].Behavior)
+	assert.Equal(t, "shopkeeper", cfg.NPCs[1].Role)
+	assert.Equal(t, ShopItemConfig{Item: "health", Price: 30, Amount: 25}, cfg.NPCs[1].Shop[0])
 }
 
 func TestLoader_LoadAll(t *testing.T) {
@@ -70,4 +126,120 @@ func TestLoader_LoadAll(t *testing.T) {
 
 	assert.NotNil(t, cfg.Physics)
 	assert.NotNil(t, cfg.Entities)
+	assert.NotNil(t, cfg.Dialogues)
+	assert.NotNil(t, cfg.Achievements)
+	assert.NotNil(t, cfg.Difficulty)
+	assert.NotNil(t, cfg.UI)
+}
+
+func TestLoader_LoadDifficulty(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	cfg, err := loader.LoadDifficulty()
+	require.NoError(t, err)
+
+	for _, name := range []string{"easy", "normal", "hard"} {
+		assert.Contains(t, cfg.Profiles, name)
+	}
+	assert.Contains(t, cfg.Profiles, cfg.Default)
+	assert.Equal(t, cfg.Profiles["hard"], cfg.Profile("hard"))
+	assert.Equal(t, cfg.Profiles[cfg.Default], cfg.Profile("unknown"), "unknown names use the default")
+
+	var nilCfg *DifficultyConfig
+	assert.Equal(t, DifficultyProfile{}, nilCfg.Profile("hard"))
+}
+
+func TestLoader_LoadUI(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	cfg, err := loader.LoadUI()
+	require.NoError(t, err)
+
+	assert.Contains(t, cfg.Themes, cfg.Theme)
+	assert.Equal(t, "bottomLeft", cfg.HUD.Health.Anchor)
+	assert.Positive(t, cfg.HUD.Health.Width)
+	assert.Positive(t, cfg.HUD.Minimap.Width)
+}
+
+func TestLoader_LoadTower(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	cfg, err := loader.LoadTower()
+	require.NoError(t, err)
+
+	assert.Positive(t, cfg.Size.TileSize)
+	assert.NotEmpty(t, cfg.Enemies.Types)
+	assert.Contains(t, cfg.TileMapping, "#")
+	assert.Contains(t, cfg.TileMapping, "S")
+	assert.Greater(t, cfg.Modifiers.LowGravity, 0.0)
+}
+
+func TestLoader_LoadAchievements(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	cfg, err := loader.LoadAchievements()
+	require.NoError(t, err)
+
+	ids := map[string]bool{}
+	for _, a := range cfg.Achievements {
+		assert.False(t, ids[a.ID], "duplicate id %s", a.ID)
+		ids[a.ID] = true
+		assert.Contains(t, []string{"kills", "gold", "stageNoDamage", "bossTime"}, a.Kind, a.ID)
+	}
+	assert.True(t, ids["slayer"])
+}
+
+func TestLoader_LoadDialogues(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	cfg, err := loader.LoadDialogues()
+	require.NoError(t, err)
+
+	intro, ok := cfg.Dialogues["tutorial_intro"]
+	require.True(t, ok)
+	assert.NotEmpty(t, intro.Pages)
+
+	// Every choice and stage reference points at an existing dialogue
+	for id, d := range cfg.Dialogues {
+		for _, c := range d.Choices {
+			if c.Next != "" {
+				assert.Contains(t, cfg.Dialogues, c.Next, "%s choice %q", id, c.Text)
+			}
+		}
+		for _, page := range d.Pages {
+			if page.Portrait != "" {
+				assert.Contains(t, cfg.Portraits, page.Portrait, id)
+			}
+		}
+	}
+
+	stage, err := loader.LoadStage("demo")
+	require.NoError(t, err)
+	for _, npc := range stage.NPCs {
+		assert.Contains(t, cfg.Dialogues, npc.Dialogue, npc.Name)
+	}
+}
+
+func TestLoader_LoadLocale(t *testing.T) {
+	loader := NewLoader("../../../cmd/game/configs")
+
+	en, err := loader.LoadLocale("en")
+	require.NoError(t, err)
+	assert.Equal(t, "GAME OVER", en["gameover.title"])
+
+	// Translations cover every English key except the language names,
+	// which are written in their own script and only live in en.json
+	for _, lang := range []string{"ko", "ja"} {
+		table, err := loader.LoadLocale(lang)
+		require.NoError(t, err, lang)
+		for key := range en {
+			if strings.HasPrefix(key, "language.") {
+				continue
+			}
+			assert.Contains(t, table, key, "%s missing %s", lang, key)
+		}
+	}
+
+	_, err = loader.LoadLocale("xx")
+	assert.Error(t, err)
 }
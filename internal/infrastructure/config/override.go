@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Override is a partial config layered over the base files. Keys are the
+// config being patched ("physics", "entities", "dialogues", "achievements",
+// "difficulty", "ui", "tower", "stage") and values are JSON-shaped patches for
+// that file:
+//
+//	{"physics": {"physics": {"gravity": 600}}, "stage": {"spawner": {"eliteChance": 0.5}}}
+//
+// Objects are merged key by key; any other value (numbers, strings, arrays)
+// replaces the base value.
+type Override map[string]any
+
+// SetOverrides selects the stage and difficulty override files applied on
+// top of the base files: overrides/stages/<stageID>.json, then
+// overrides/difficulty/<difficulty>.json. Missing files are skipped and
+// empty names apply none.
+func (l *Loader) SetOverrides(stageID, difficulty string) {
+	l.stageID = stageID
+	l.difficulty = difficulty
+}
+
+// AddOverride adds a programmatic override applied after the override
+// files (e.g. tests tweaking one value without a config file)
+func (l *Loader) AddOverride(o Override) {
+	l.overrides = append(l.overrides, normalizeOverride(o))
+}
+
+// normalizeOverride round-trips an override through JSON so nested Go maps
+// and structs become the map[string]any objects mergeJSON works on
+func normalizeOverride(o Override) Override {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return o
+	}
+	var n Override
+	if err := json.Unmarshal(data, &n); err != nil {
+		return o
+	}
+	return n
+}
+
+// layers returns the overrides to apply, in order
+func (l *Loader) layers() ([]Override, error) {
+	var layers []Override
+	for _, path := range []string{
+		overridePath("stages", l.stageID),
+		overridePath("difficulty", l.difficulty),
+	} {
+		if path == "" {
+			continue
+		}
+		o, err := l.loadOverride(path)
+		if err != nil {
+			return nil, err
+		}
+		if o != nil {
+			layers = append(layers, o)
+		}
+	}
+	return append(layers, l.overrides...), nil
+}
+
+// overridePath returns the override file for a stage or difficulty name
+// ("" = none)
+func overridePath(dir, name string) string {
+	if name == "" {
+		return ""
+	}
+	return "overrides/" + dir + "/" + name + ".json"
+}
+
+// loadOverride reads an override file (missing = nil)
+func (l *Loader) loadOverride(path string) (Override, error) {
+	data, err := fs.ReadFile(l.fsys, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read override %s: %w", path, err)
+	}
+
+	var o Override
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse override %s: %w", path, err)
+	}
+	return o, nil
+}
+
+// decode unmarshals a config file into v with the overrides for key merged in
+func (l *Loader) decode(data []byte, key string, v any) error {
+	layers, err := l.layers()
+	if err != nil {
+		return err
+	}
+
+	var patches []map[string]any
+	for _, o := range layers {
+		patch, ok := o[key].(map[string]any)
+		if !ok {
+			if o[key] != nil {
+				return fmt.Errorf("override for %s must be an object", key)
+			}
+			continue
+		}
+		patches = append(patches, patch)
+	}
+	if len(patches) == 0 {
+		return json.Unmarshal(data, v)
+	}
+
+	var merged map[string]any
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return err
+	}
+	for _, patch := range patches {
+		mergeJSON(merged, patch)
+	}
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// mergeJSON deep-merges src into dst: nested objects are merged, anything
+// else replaces the value in dst
+func mergeJSON(dst, src map[string]any) {
+	for k, v := range src {
+		srcObj, srcIsObj := v.(map[string]any)
+		dstObj, dstIsObj := dst[k].(map[string]any)
+		if srcIsObj && dstIsObj {
+			mergeJSON(dstObj, srcObj)
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOverrideFS returns a minimal config directory with stage and
+// difficulty override files
+func newOverrideFS() fstest.MapFS {
+	return fstest.MapFS{
+		"physics.json": {Data: []byte(`{"physics": {"gravity": 800, "maxFallSpeed": 400}, "jump": {"force": 300}}`)},
+		"stages/cave.json": {Data: []byte(`{"id": "cave", "enemies": [{"type": "slime", "x": 10, "y": 20}],
+			"spawner": {"eliteChance": 0.1, "eliteWeights": {"armored": 1}}}`)},
+		"overrides/stages/cave.json": {Data: []byte(`{"physics": {"physics": {"gravity": 500}}}`)},
+		"overrides/difficulty/hard.json": {Data: []byte(`{"physics": {"physics": {"maxFallSpeed": 450}},
+			"stage": {"enemies": [{"type": "archer", "x": 1, "y": 2}], "spawner": {"eliteChance": 0.5}}}`)},
+	}
+}
+
+func TestLoader_Overrides(t *testing.T) {
+	loader := NewFSLoader(newOverrideFS(), "configs")
+
+	base, err := loader.LoadPhysics()
+	require.NoError(t, err)
+	assert.Equal(t, 800.0, base.Physics.Gravity, "no overrides selected")
+
+	loader.SetOverrides("cave", "hard")
+	physics, err := loader.LoadPhysics()
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, physics.Physics.Gravity, "stage override")
+	assert.Equal(t, 450.0, physics.Physics.MaxFallSpeed, "difficulty override")
+	assert.Equal(t, 300.0, physics.Jump.Force, "untouched values are kept")
+
+	stage, err := loader.LoadStage("cave")
+	require.NoError(t, err)
+	assert.Equal(t, []EnemySpawnConfig{{Type: "archer", X: 1, Y: 2}}, stage.Enemies, "arrays are replaced")
+	assert.Equal(t, 0.5, stage.Spawner.EliteChance)
+	assert.Equal(t, map[string]int{"armored": 1}, stage.Spawner.EliteWeights, "objects are merged")
+}
+
+func TestLoader_MissingOverridesSkipped(t *testing.T) {
+	loader := NewFSLoader(newOverrideFS(), "configs")
+	loader.SetOverrides("forest", "easy")
+
+	physics, err := loader.LoadPhysics()
+	require.NoError(t, err)
+	assert.Equal(t, 800.0, physics.Physics.Gravity)
+}
+
+func TestLoader_AddOverride(t *testing.T) {
+	loader := NewFSLoader(newOverrideFS(), "configs")
+	loader.SetOverrides("cave", "")
+	loader.AddOverride(Override{"physics": map[string]map[string]float64{"physics": {"gravity": 100}}})
+
+	physics, err := loader.LoadPhysics()
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, physics.Physics.Gravity, "programmatic overrides apply last")
+	assert.Equal(t, 400.0, physics.Physics.MaxFallSpeed)
+}
+
+func TestLoader_InvalidOverride(t *testing.T) {
+	fsys := newOverrideFS()
+	fsys["overrides/difficulty/hard.json"] = &fstest.MapFile{Data: []byte(`{"physics": 5}`)}
+	loader := NewFSLoader(fsys, "configs")
+	loader.SetOverrides("", "hard")
+
+	_, err := loader.LoadPhysics()
+	assert.Error(t, err)
+
+	fsys["overrides/difficulty/hard.json"] = &fstest.MapFile{Data: []byte(`{`)}
+	_, err = loader.LoadPhysics()
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ type StageConfig struct {
 	ID          string                   `json:"id"`
 	Name        string                   `json:"name"`
 	Size        StageSizeConfig          `json:"size"`
+	ParTime     int                      `json:"parTime"` // seconds for full time score (0 = untimed)
 	Tileset     string                   `json:"tileset"`
 	Background  BackgroundConfig         `json:"background"`
 	Connections ConnectionsConfig        `json:"connections"`
@@ -12,9 +13,18 @@ type StageConfig struct {
 	Layers      LayersConfig             `json:"layers"`
 	TileMapping map[string]TileMappingConfig `json:"tileMapping"`
 	Enemies     []EnemySpawnConfig       `json:"enemies"`
+	Spawner     SpawnerConfig            `json:"spawner"`
 	Pickups     []PickupSpawnConfig      `json:"pickups"`
 	Triggers    []TriggerConfig          `json:"triggers"`
+	NPCs        []NPCSpawnConfig         `json:"npcs"`
 	Decorations []DecorationConfig       `json:"decorations"`
+
+	// Lighting darkens the stage down to an ambient level (nil = fully lit)
+	Lighting *StageLightingConfig `json:"lighting,omitempty"`
+	Weather  *WeatherConfig       `json:"weather,omitempty"` // nil = clear
+
+	// Next is the stage that clearing this one unlocks ("" = none)
+	Next string `json:"next,omitempty"`
 }
 
 type StageSizeConfig struct {
@@ -43,6 +53,12 @@ type PositionConfig struct {
 
 type LayersConfig struct {
 	Collision []string `json:"collision"`
+
+	// Foreground rows use the same tile mapping but are drawn over the
+	// entities and never collide (grass overhangs, pillars). ForegroundFade
+	// is their opacity while the player is behind one (0 = stay opaque).
+	Foreground     []string `json:"foreground,omitempty"`
+	ForegroundFade float64  `json:"foregroundFade,omitempty"`
 }
 
 type TileMappingConfig struct {
@@ -50,6 +66,24 @@ type TileMappingConfig struct {
 	Solid     bool   `json:"solid"`
 	Damage    int    `json:"damage,omitempty"`
 	TileIndex int    `json:"tileIndex"`
+
+	// Surface properties (0 = normal). Friction and Speed scale deceleration
+	// and max speed of anything standing on the tile, Knockback scales the
+	// hazard bounce and Burn is damage per burn tick after touching it.
+	Friction  float64 `json:"friction,omitempty"`
+	Speed     float64 `json:"speed,omitempty"`
+	NoDash    bool    `json:"noDash,omitempty"`
+	Knockback float64 `json:"knockback,omitempty"`
+	Burn      int     `json:"burn,omitempty"`
+
+	// Affects is who the tile's damage hurts: "player", "enemies" or ""
+	// (both)
+	Affects string `json:"affects,omitempty"`
+
+	// Crumbling platforms: seconds a tile shakes after being stood on before
+	// it falls (0 = doesn't crumble), and seconds until it respawns (0 = never)
+	CrumbleDelay float64 `json:"crumbleDelay,omitempty"`
+	Respawn      float64 `json:"respawn,omitempty"`
 }
 
 type EnemySpawnConfig struct {
@@ -57,12 +91,35 @@ type EnemySpawnConfig struct {
 	X           int    `json:"x"`
 	Y           int    `json:"y"`
 	FacingRight bool   `json:"facingRight"`
+	Modifier    string `json:"modifier,omitempty"` // elite modifier name (empty = regular)
+	Group       string `json:"group,omitempty"`    // alert group (empty = alert by proximity)
+
+	// Patrol route for patrol AI (empty = patrol around the spawn point).
+	// PatrolMode is "loop" (default), "pingPong" or "once".
+	Waypoints  []PositionConfig `json:"waypoints,omitempty"`
+	PatrolMode string           `json:"patrolMode,omitempty"`
+
+	// Respawn is "never" (default), "timed" or "offscreen"; see RespawnDelay
+	Respawn      string  `json:"respawn,omitempty"`
+	RespawnDelay float64 `json:"respawnDelay,omitempty"` // seconds after being killed
+}
+
+// SpawnerConfig controls the periodic enemy spawner.
+// Each spawn becomes an elite with EliteChance (0-1), picking the modifier
+// by EliteWeights.
+type SpawnerConfig struct {
+	EliteChance  float64        `json:"eliteChance"`
+	EliteWeights map[string]int `json:"eliteWeights"`
 }
 
 type PickupSpawnConfig struct {
 	Type string `json:"type"`
 	X    int    `json:"x"`
 	Y    int    `json:"y"`
+
+	// Respawn is "never" (default), "timed" or "offscreen"; see RespawnDelay
+	Respawn      string  `json:"respawn,omitempty"`
+	RespawnDelay float64 `json:"respawnDelay,omitempty"` // seconds after being collected
 }
 
 type TriggerConfig struct {
@@ -70,6 +127,43 @@ type TriggerConfig struct {
 	Rect       RectConfig `json:"rect"`
 	Target     string     `json:"target"`
 	SpawnPoint string     `json:"spawnPoint"`
+	Once       bool       `json:"once"`
+
+	// Arena scripts the fight of a "boss" trigger
+	Arena *ArenaConfig `json:"arena,omitempty"`
+}
+
+// ArenaConfig is a boss fight started by walking into a "boss" trigger:
+// the door tiles in Doors close, the music switches, a banner introduces
+// the boss and it spawns. Beating it opens the doors and drops the Reward
+// loot at Chest. Each arena is fought once per run.
+type ArenaConfig struct {
+	Boss   EnemySpawnConfig `json:"boss"`
+	Title  string           `json:"title"`  // banner text (i18n key, else shown as is)
+	Music  string           `json:"music"`  // track played during the fight ("" = keep the current one)
+	Doors  RectConfig       `json:"doors"`  // pixels; door tiles inside close during the fight
+	Chest  PositionConfig   `json:"chest"`  // where the reward drops
+	Reward LootTableConfig  `json:"reward"` // rolled when the boss is beaten
+}
+
+// NPCSpawnConfig places a non-hostile character the player can talk to.
+// Shopkeepers open their shop on interaction; other roles start Dialogue.
+type NPCSpawnConfig struct {
+	Name           string           `json:"name"`
+	X              int              `json:"x"`
+	Y              int              `json:"y"`
+	Dialogue       string           `json:"dialogue"`
+	Role           string           `json:"role,omitempty"`           // "talker" (default), "shopkeeper" or "questGiver"
+	Behavior       string           `json:"behavior,omitempty"`       // "stand" (default) or "patrol"
+	PatrolDistance int              `json:"patrolDistance,omitempty"` // pixels each side of the spawn point
+	Shop           []ShopItemConfig `json:"shop,omitempty"`
+}
+
+// ShopItemConfig is an item a shopkeeper sells for gold
+type ShopItemConfig struct {
+	Item   string `json:"item"` // "health", "ammo", "magnet", "dash", "energy" or "turret"
+	Price  int    `json:"price"`
+	Amount int    `json:"amount,omitempty"` // health restored or arrows given (upgrades add one tier)
 }
 
 type RectConfig struct {
@@ -79,6 +173,23 @@ type RectConfig struct {
 	H int `json:"h"`
 }
 
+// StageLightingConfig configures a stage's ambient darkness and torches
+type StageLightingConfig struct {
+	Ambient float64          `json:"ambient"` // 0.0 (pitch black) - 1.0 (fully lit)
+	Color   string           `json:"color"`   // ambient tint "#rrggbb" ("" = white)
+	Torches []PositionConfig `json:"torches"` // torch positions (pixels)
+}
+
+// WeatherConfig configures a stage's weather overlay and its gameplay effects
+type WeatherConfig struct {
+	Type       string  `json:"type"`                 // rain, snow or fog
+	Density    int     `json:"density"`              // rain or snow particles per frame
+	Wind       float64 `json:"wind,omitempty"`       // horizontal drift (pixels/sec)
+	Friction   float64 `json:"friction,omitempty"`   // rain: player deceleration multiplier (0 = 1)
+	Visibility float64 `json:"visibility,omitempty"` // fog: enemy detect range multiplier (0 = 1)
+	Color      string  `json:"color,omitempty"`      // fog color "#rrggbb" ("" = gray)
+}
+
 type DecorationConfig struct {
 	Sprite    string `json:"sprite"`
 	X         int    `json:"x"`
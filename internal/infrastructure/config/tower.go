@@ -0,0 +1,41 @@
+package config
+
+// TowerConfig is the root config for tower.json: how the endless tower's
+// floors are generated and how they get harder
+type TowerConfig struct {
+	Size        StageSizeConfig              `json:"size"`
+	Background  BackgroundConfig             `json:"background"`
+	Platforms   int                          `json:"platforms"` // platforms per floor
+	Enemies     TowerEnemiesConfig           `json:"enemies"`
+	Spikes      TowerSpikesConfig            `json:"spikes"`
+	Growth      float64                      `json:"growth"` // enemy health, damage and spawn rate added per floor (0.1 = +10%)
+	Modifiers   TowerModifiersConfig         `json:"modifiers"`
+	TileMapping map[string]TileMappingConfig `json:"tileMapping"` // "#" wall, "S" spikes, "." empty
+}
+
+// TowerEnemiesConfig is how many enemies a floor has: Base on the first,
+// PerFloor more on each one after, up to Max
+type TowerEnemiesConfig struct {
+	Types    []string `json:"types"` // picked at random for each enemy
+	Base     int      `json:"base"`
+	PerFloor int      `json:"perFloor"`
+	Max      int      `json:"max"`
+}
+
+// TowerSpikesConfig is how many spike strips a floor has: none before
+// FromFloor, then PerFloor more on each floor, up to Max
+type TowerSpikesConfig struct {
+	FromFloor int `json:"fromFloor"`
+	PerFloor  int `json:"perFloor"`
+	Max       int `json:"max"`
+}
+
+// TowerModifiersConfig is how floor modifiers (low gravity, double enemies,
+// no dash) are rolled: from FromFloor on, each has Chance to apply, up to
+// Max per floor
+type TowerModifiersConfig struct {
+	FromFloor  int     `json:"fromFloor"`
+	Chance     float64 `json:"chance"`
+	Max        int     `json:"max"`
+	LowGravity float64 `json:"lowGravity"` // gravity multiplier of a low gravity floor
+}
@@ -2,16 +2,54 @@ package config
 
 // PhysicsConfig is the root config for physics.json
 type PhysicsConfig struct {
-	Display     DisplayConfig     `json:"display"`
-	Physics     PhysicsSettings   `json:"physics"`
-	Movement    MovementConfig    `json:"movement"`
-	Jump        JumpConfig        `json:"jump"`
-	Dash        DashConfig        `json:"dash"`
-	Collision   CollisionConfig   `json:"collision"`
-	Combat      CombatConfig      `json:"combat"`
-	Feedback    FeedbackConfig    `json:"feedback"`
-	ArrowSelect        ArrowSelectConfig        `json:"arrowSelect"`
-	Projectile         ProjectileBehaviorConfig `json:"projectile"`
+	Display     DisplayConfig            `json:"display"`
+	Physics     PhysicsSettings          `json:"physics"`
+	Movement    MovementConfig           `json:"movement"`
+	Jump        JumpConfig               `json:"jump"`
+	Dash        DashConfig               `json:"dash"`
+	Crouch      CrouchConfig             `json:"crouch"`
+	Collision   CollisionConfig          `json:"collision"`
+	Combat      CombatConfig             `json:"combat"`
+	Feedback    FeedbackConfig           `json:"feedback"`
+	ArrowSelect ArrowSelectConfig        `json:"arrowSelect"`
+	TimeScale   TimeScaleConfig          `json:"timeScale"`
+	Energy      EnergyConfig             `json:"energy"`
+	Projectile  ProjectileBehaviorConfig `json:"projectile"`
+	Magnet      MagnetConfig             `json:"magnet"`
+	Lighting    LightingConfig           `json:"lighting"`
+	Camera      CameraConfig             `json:"camera"`
+}
+
+// CameraConfig configures how the camera follows the player
+type CameraConfig struct {
+	Peek CameraPeekConfig `json:"peek"`
+}
+
+// CameraPeekConfig configures looking up or down by holding Up/Down while
+// standing still
+type CameraPeekConfig struct {
+	Distance float64 `json:"distance"` // pixels the camera shifts (0 = no peeking)
+	Delay    float64 `json:"delay"`    // seconds of holding before the camera moves
+	Speed    float64 `json:"speed"`    // pixels/sec the camera shifts and returns (0 = instant)
+}
+
+// LightingConfig configures the point lights of dark stages
+type LightingConfig struct {
+	Player     LightConfig `json:"player"`
+	Projectile LightConfig `json:"projectile"`
+	Torch      LightConfig `json:"torch"`
+}
+
+// LightConfig configures a point light
+type LightConfig struct {
+	Radius int    `json:"radius"` // pixels (0 = no light)
+	Color  string `json:"color"`  // "#rrggbb" ("" = white)
+}
+
+// MagnetConfig configures how pickups are pulled toward the player
+type MagnetConfig struct {
+	Radius       []float64 `json:"radius"`       // pixels per upgrade tier
+	Acceleration float64   `json:"acceleration"` // pixels/sec² (0 = full speed at once)
 }
 
 // ArrowSelectConfig configures the arrow selection UI
@@ -19,6 +57,27 @@ type ArrowSelectConfig struct {
 	Radius      int `json:"radius"`      // Icon distance from center (pixels)
 	MinDistance int `json:"minDistance"` // Minimum distance for selection (pixels)
 	MaxFrame    int `json:"maxFrame"`    // Animation duration (frames)
+
+	SlowMotion    float64 `json:"slowMotion"`    // 0.0-1.0 simulation speed while open (0 = 0.1)
+	StickDeadzone float64 `json:"stickDeadzone"` // 0.0-1.0 right stick deflection needed to highlight a slot
+}
+
+// EnergyConfig configures the player's energy meter, spent by dashing,
+// raising the shield and firing charged arrows
+type EnergyConfig struct {
+	Max            []float64          `json:"max"`            // energy points per upgrade tier (empty = no meter)
+	RegenPerSecond float64            `json:"regenPerSecond"` // energy recovered per second
+	RegenDelay     float64            `json:"regenDelay"`     // seconds after spending before regen
+	PerGold        float64            `json:"perGold"`        // energy restored per gold collected
+	DashCost       float64            `json:"dashCost"`
+	BlockCost      float64            `json:"blockCost"` // to raise the shield
+	ShotCost       map[string]float64 `json:"shotCost"`  // per arrow fired by arrow name (gray, red, blue, purple)
+}
+
+// TimeScaleConfig configures how slow motion eases in and out
+type TimeScaleConfig struct {
+	RampIn  float64 `json:"rampIn"`  // seconds to slow from normal speed to a stop (0 = instant)
+	RampOut float64 `json:"rampOut"` // seconds to speed back up from a stop (0 = instant)
 }
 
 type DisplayConfig struct {
@@ -48,6 +107,7 @@ type JumpConfig struct {
 	VariableJumpMultiplier float64           `json:"variableJumpMultiplier"`
 	CoyoteTime             float64           `json:"coyoteTime"`
 	JumpBuffer             float64           `json:"jumpBuffer"`
+	LagCompensation        float64           `json:"lagCompensation"` // max seconds added to coyote time and jump buffer below the target TPS (0 = off)
 	ApexModifier           ApexModifierConfig `json:"apexModifier"`
 	FallMultiplier         float64           `json:"fallMultiplier"`
 }
@@ -60,15 +120,39 @@ type ApexModifierConfig struct {
 }
 
 type DashConfig struct {
-	Speed           float64 `json:"speed"`
-	Duration        float64 `json:"duration"`
-	Cooldown        float64 `json:"cooldown"`
-	IframesDuration float64 `json:"iframesDuration"`
+	Speed           float64          `json:"speed"`
+	Duration        float64          `json:"duration"`
+	Cooldown        float64          `json:"cooldown"`
+	IframesDuration float64          `json:"iframesDuration"`
+	Directions      string           `json:"directions"` // "horizontal" (default), "four" or "eight"
+	Aim             bool             `json:"aim"`        // dash toward the aim when no direction is held
+	Attack          DashAttackConfig `json:"attack"`
+}
+
+// DashAttackConfig configures damage dealt to enemies the dash passes through
+type DashAttackConfig struct {
+	Damage        []int   `json:"damage"`        // per upgrade tier (empty = no dash damage)
+	Knockback     float64 `json:"knockback"`     // pixels/sec
+	Slowdown      float64 `json:"slowdown"`      // seconds of slow motion after a hit
+	SlowdownScale float64 `json:"slowdownScale"` // 0.0-1.0 simulation speed while slowed
+}
+
+// CrouchConfig configures crouching (hold down) and sliding
+type CrouchConfig struct {
+	Speed         float64 `json:"speed"`         // 0.0-1.0 of max speed while crouched
+	SlideMinSpeed float64 `json:"slideMinSpeed"` // pixels/sec needed to slide when crouching
+	SlideDuration float64 `json:"slideDuration"` // seconds (0 = no slide)
+	SlideFriction float64 `json:"slideFriction"` // pixels/sec² of slowdown while sliding
 }
 
 type CollisionConfig struct {
 	CornerCorrection MarginConfig `json:"cornerCorrection"`
 	LedgeAssist      MarginConfig `json:"ledgeAssist"`
+
+	// Layers lists the collision layers each layer interacts with, e.g.
+	// {"projectile": ["player", "enemy"]} for arrows that fly through
+	// walls. Layers not listed keep the built-in pairs.
+	Layers map[string][]string `json:"layers,omitempty"`
 }
 
 type MarginConfig struct {
@@ -79,18 +163,127 @@ type MarginConfig struct {
 type CombatConfig struct {
 	Iframes   float64        `json:"iframes"`
 	Knockback KnockbackConfig `json:"knockback"`
+	Block     BlockConfig     `json:"block"`
+	Stomp     StompConfig     `json:"stomp"`
+	Aggro     AggroConfig     `json:"aggro"`
+	Hazard    HazardConfig    `json:"hazard"`
+	Contact   ContactConfig   `json:"contact"`
+	Summon    SummonConfig    `json:"summon"`
+
+	// Factions lists the factions each faction can hurt, e.g.
+	// {"enemy": ["player"]}. Factions not listed keep the built-in relations.
+	Factions map[string][]string `json:"factions,omitempty"`
+
+	// CrowdControl tunes knockback decay and stun for the player and enemies
+	CrowdControl CrowdControlConfig `json:"crowdControl"`
+}
+
+// StompConfig configures bouncing off the heads of stompable enemies
+type StompConfig struct {
+	Damage     int     `json:"damage"`     // dealt to the stomped enemy
+	Bounce     float64 `json:"bounce"`     // upward launch speed (pixels/sec)
+	JumpWindow float64 `json:"jumpWindow"` // seconds after a stomp in which a jump press jumps again
+}
+
+// ContactConfig configures touch damage from enemies
+type ContactConfig struct {
+	Cooldown   float64 `json:"cooldown"`   // seconds before the same enemy's touch can hurt again (0 = i-frames only)
+	Separation float64 `json:"separation"` // speed (pixels/sec) the enemy is pushed back after its touch connects
+}
+
+// SummonConfig configures the turret the player can summon once unlocked
+// (by the character's abilities or a shop). Its shots are enemyArrows with
+// their own damage.
+type SummonConfig struct {
+	Duration float64 `json:"duration"` // seconds a turret stays (0 = summoning disabled)
+	Cooldown float64 `json:"cooldown"` // seconds from a summon until the next
+	Range    int     `json:"range"`    // pixels to the farthest enemy it shoots at
+	FireRate float64 `json:"fireRate"` // seconds between shots
+	Damage   int     `json:"damage"`   // per shot
+	Aim      string  `json:"aim"`      // straight, direct or lead (default direct)
+	Width    int     `json:"width"`    // pixels
+	Height   int     `json:"height"`   // pixels
+}
+
+// HazardConfig configures damage tiles (spikes, lava)
+type HazardConfig struct {
+	Bounce       float64 `json:"bounce"`       // upward knockback off a hazard (pixels/sec)
+	EnemyIframes float64 `json:"enemyIframes"` // seconds an enemy is immune between hazard hits
+	BurnDuration float64 `json:"burnDuration"` // seconds a burning surface keeps burning after contact
+	BurnInterval float64 `json:"burnInterval"` // seconds between burn damage ticks
+}
+
+// AggroConfig configures how enemies share an alert when one spots the player
+type AggroConfig struct {
+	Radius   int     `json:"radius"`   // pixels: ungrouped enemies this close are alerted (0 = no sharing)
+	Delay    float64 `json:"delay"`    // seconds before an alerted enemy reacts
+	Duration float64 `json:"duration"` // seconds an alert lasts after losing sight of the player
+}
+
+// BlockConfig configures the hold-to-block shield and parry
+type BlockConfig struct {
+	MaxStamina      float64 `json:"maxStamina"`      // stamina points (0 = blocking disabled)
+	DrainPerSecond  float64 `json:"drainPerSecond"`  // stamina spent per second while blocking
+	HitCost         float64 `json:"hitCost"`         // stamina spent per blocked hit
+	RegenPerSecond  float64 `json:"regenPerSecond"`  // stamina recovered per second
+	RegenDelay      float64 `json:"regenDelay"`      // seconds after lowering the shield before regen
+	DamageReduction float64 `json:"damageReduction"` // 0.0-1.0, frontal damage prevented
+	ParryWindow     float64 `json:"parryWindow"`     // seconds after raising the shield that deflect arrows
 }
 
 type KnockbackConfig struct {
-	Force        float64 `json:"force"`
-	UpForce      float64 `json:"upForce"`
-	StunDuration float64 `json:"stunDuration"`
+	Force   float64 `json:"force"`
+	UpForce float64 `json:"upForce"`
+}
+
+// CrowdControlConfig configures hit reactions per side
+type CrowdControlConfig struct {
+	Player CCProfileConfig `json:"player"`
+	Enemy  CCProfileConfig `json:"enemy"`
+}
+
+// CCProfileConfig configures knockback decay and stun for one side
+type CCProfileConfig struct {
+	KnockbackCurve    string  `json:"knockbackCurve"`    // linear, quadratic or constant
+	KnockbackDuration float64 `json:"knockbackDuration"` // seconds until knockback velocity reaches zero
+	StunDuration      float64 `json:"stunDuration"`      // seconds without control after a hit
+	DecayVertical     bool    `json:"decayVertical"`     // vertical knockback follows the curve too
 }
 
 type FeedbackConfig struct {
 	Hitstop       HitstopConfig       `json:"hitstop"`
 	ScreenShake   ScreenShakeConfig   `json:"screenShake"`
 	SquashStretch SquashStretchConfig `json:"squashStretch"`
+	Rumble        RumbleToggleConfig  `json:"rumble"`
+
+	// Profiles maps feedback events (lightHit, heavyHit, playerHurt, bossSlam)
+	// to their hitstop and shake settings. Missing events use built-in defaults.
+	Profiles map[string]FeedbackProfileConfig `json:"profiles"`
+}
+
+// FeedbackProfileConfig configures hitstop and screen shake for one event
+type FeedbackProfileConfig struct {
+	Hitstop     int     `json:"hitstop"`               // frames
+	Shake       float64 `json:"shake"`                 // pixels
+	Duration    float64 `json:"duration"`              // seconds
+	Falloff     string  `json:"falloff"`               // "linear", "quadratic", "exponential"
+	Decay       float64 `json:"decay,omitempty"`       // per-frame multiplier (exponential only)
+	Directional bool    `json:"directional,omitempty"` // shake along the hit direction
+	Stacking    string  `json:"stacking"`              // "replace", "max", "add"
+
+	Rumble *RumbleConfig `json:"rumble,omitempty"` // gamepad vibration, nil = none
+}
+
+// RumbleToggleConfig globally enables gamepad vibration
+type RumbleToggleConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RumbleConfig configures a gamepad vibration effect
+type RumbleConfig struct {
+	Strong   float64 `json:"strong"`   // 0-1, low-frequency motor
+	Weak     float64 `json:"weak"`     // 0-1, high-frequency motor
+	Duration float64 `json:"duration"` // seconds
 }
 
 type HitstopConfig struct {
@@ -123,4 +316,26 @@ type ProjectileBehaviorConfig struct {
 	// 1.0 = full influence (player velocity is fully added to arrow)
 	// 0.5 = partial influence (50% of player velocity is added)
 	VelocityInfluence float64 `json:"velocityInfluence"`
+
+	// AimAssist is how close (pixels) the predicted path must pass to an
+	// enemy for the aim to snap to it when enabled in settings (0 = never)
+	AimAssist float64 `json:"aimAssist"`
+
+	// StickInEnemies makes player arrows stick into the enemies they hit
+	// (and follow them) instead of breaking
+	StickInEnemies bool `json:"stickInEnemies"`
+
+	// Bleed is the damage over time a stuck arrow deals, by arrow name
+	Bleed map[string]BleedConfig `json:"bleed,omitempty"`
+
+	// Fires maps arrow names to the projectile they shoot instead of
+	// playerArrow (e.g. a bomb or a hitscan beam)
+	Fires map[string]string `json:"fires,omitempty"`
+}
+
+// BleedConfig is damage over time dealt by an arrow stuck in an enemy
+type BleedConfig struct {
+	Damage   int     `json:"damage"`   // per tick
+	Interval float64 `json:"interval"` // seconds between ticks
+	Duration float64 `json:"duration"` // seconds of bleeding (0 = while stuck)
 }
@@ -0,0 +1,47 @@
+package config
+
+// UIConfig is the root config for ui.json: where the HUD elements sit and
+// the themes (skins) they are drawn with
+type UIConfig struct {
+	HUD    HUDConfig              `json:"hud"`
+	Theme  string                 `json:"theme"` // theme used (must be in Themes)
+	Themes map[string]ThemeConfig `json:"themes"`
+}
+
+// HUDConfig lays out the HUD. The HUD is drawn at Scale (0 = 1) times its
+// pixel size, and each element is placed against an anchor of the screen
+// so the layout follows the resolution.
+type HUDConfig struct {
+	Scale     float64          `json:"scale"`
+	Health    HUDElementConfig `json:"health"`    // health bar, with the stamina and energy bars under it
+	Gold      HUDElementConfig `json:"gold"`      // gold counter
+	Arrows    HUDElementConfig `json:"arrows"`    // equipped arrows and ability widgets
+	Minimap   HUDElementConfig `json:"minimap"`   // stage overview
+	Controls  HUDElementConfig `json:"controls"`  // key hints
+	CombatLog HUDElementConfig `json:"combatLog"` // kill feed, when turned on in the options
+}
+
+// HUDElementConfig places one HUD element. Anchor is the point of the
+// screen the element's same point sits on: topLeft, top, topRight, left,
+// center, right, bottomLeft, bottom or bottomRight. X and Y move it from
+// there (pixels, before scaling; positive = right/down).
+type HUDElementConfig struct {
+	Anchor string `json:"anchor"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// ThemeConfig holds the HUD colors ("#rrggbb"; "" = the built-in color)
+type ThemeConfig struct {
+	HealthFG      string `json:"healthFG"`
+	BarBG         string `json:"barBG"` // behind the bars and ability widgets
+	Text          string `json:"text"`
+	Gold          string `json:"gold"`
+	MinimapBG     string `json:"minimapBG"`
+	MinimapWall   string `json:"minimapWall"`
+	MinimapPlayer string `json:"minimapPlayer"`
+	MinimapEnemy  string `json:"minimapEnemy"`
+}
@@ -0,0 +1,174 @@
+// Package logging is the game's leveled logger.
+//
+// Messages carry a level and the module they come from. They are written
+// to the output (stderr by default, optionally tee'd to a file) and kept
+// in a short history for the in-game log panel and crash reports.
+//
+// Log through a module tag, e.g. logging.Scene.Infof("Recording saved: %s", name).
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Level is a message's severity
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelNames maps levels to their flag names
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// String returns the flag name of the level
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// MarshalText writes the level by name (JSON crash reports)
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// ParseLevel returns the level for a flag name
+func ParseLevel(name string) (Level, bool) {
+	for l, n := range levelNames {
+		if n == name {
+			return l, true
+		}
+	}
+	return LevelInfo, false
+}
+
+// Tag is the module a message comes from
+type Tag string
+
+const (
+	ECS    Tag = "ecs"
+	Scene  Tag = "scene"
+	Config Tag = "config"
+	Replay Tag = "replay"
+	Game   Tag = "game"
+)
+
+// Entry is one logged message
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Tag     Tag
+	Message string
+}
+
+// String formats the entry as a log line
+func (e Entry) String() string {
+	return fmt.Sprintf("%s %-5s [%s] %s", e.Time.Format("2006/01/02 15:04:05"), e.Level, e.Tag, e.Message)
+}
+
+// DefaultHistory is how many entries are kept for the log panel
+const DefaultHistory = 200
+
+// Logger writes messages at or above its level and keeps recent entries
+type Logger struct {
+	mu      sync.Mutex
+	level   Level
+	out     io.Writer
+	history []Entry
+	next    int // index of the slot the next entry writes to
+	count   int // number of entries stored
+}
+
+// New creates a logger writing to out that keeps the last history entries
+func New(out io.Writer, level Level, history int) *Logger {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	return &Logger{
+		level:   level,
+		out:     out,
+		history: make([]Entry, history),
+	}
+}
+
+// SetLevel changes the minimum level that is logged
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// SetOutput changes where messages are written
+func (l *Logger) SetOutput(out io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out = out
+}
+
+// Logf logs a formatted message
+func (l *Logger) Logf(level Level, tag Tag, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level {
+		return
+	}
+	e := Entry{Time: time.Now(), Level: level, Tag: tag, Message: fmt.Sprintf(format, args...)}
+	_, _ = fmt.Fprintln(l.out, e)
+
+	l.history[l.next] = e
+	l.next = (l.next + 1) % len(l.history)
+	if l.count < len(l.history) {
+		l.count++
+	}
+}
+
+// Entries returns the kept entries, oldest first
+func (l *Logger) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]Entry, 0, l.count)
+	start := (l.next - l.count + len(l.history)) % len(l.history)
+	for i := 0; i < l.count; i++ {
+		out = append(out, l.history[(start+i)%len(l.history)])
+	}
+	return out
+}
+
+// std is the game-wide logger the tags write to
+var std = New(os.Stderr, LevelInfo, DefaultHistory)
+
+// Default returns the game-wide logger
+func Default() *Logger {
+	return std
+}
+
+// Debugf logs a debug message from the module
+func (t Tag) Debugf(format string, args ...any) {
+	std.Logf(LevelDebug, t, format, args...)
+}
+
+// Infof logs an info message from the module
+func (t Tag) Infof(format string, args ...any) {
+	std.Logf(LevelInfo, t, format, args...)
+}
+
+// Warnf logs a warning from the module
+func (t Tag) Warnf(format string, args ...any) {
+	std.Logf(LevelWarn, t, format, args...)
+}
+
+// Errorf logs an error from the module
+func (t Tag) Errorf(format string, args ...any) {
+	std.Logf(LevelError, t, format, args...)
+}
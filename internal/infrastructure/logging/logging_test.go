@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_FiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelWarn, 10)
+
+	l.Logf(LevelInfo, Scene, "loaded %s", "demo")
+	l.Logf(LevelError, Config, "bad value %d", 3)
+
+	assert.NotContains(t, buf.String(), "loaded")
+	assert.Contains(t, buf.String(), "error [config] bad value 3")
+	require.Len(t, l.Entries(), 1)
+
+	l.SetLevel(LevelDebug)
+	l.Logf(LevelDebug, ECS, "tick")
+	assert.Contains(t, buf.String(), "debug [ecs] tick")
+}
+
+func TestLogger_HistoryWraps(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelDebug, 3)
+	for i := range 5 {
+		l.Logf(LevelInfo, Replay, "frame %d", i)
+	}
+
+	entries := l.Entries()
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		assert.Equal(t, Replay, e.Tag)
+		assert.Equal(t, []string{"frame 2", "frame 3", "frame 4"}[i], e.Message, "oldest first")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for l := LevelDebug; l <= LevelError; l++ {
+		parsed, ok := ParseLevel(l.String())
+		assert.True(t, ok)
+		assert.Equal(t, l, parsed)
+	}
+	_, ok := ParseLevel("verbose")
+	assert.False(t, ok)
+
+	data, err := json.Marshal(Entry{Level: LevelWarn, Tag: Config})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Level":"warn","Tag":"config"`)
+}
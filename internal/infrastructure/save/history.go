@@ -0,0 +1,84 @@
+package save
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// HistoryEntry is one cleared stage in the profile history
+type HistoryEntry struct {
+	Time        int64   `json:"time"` // unix seconds
+	Stage       string  `json:"stage"`
+	Seconds     float64 `json:"seconds"`
+	DamageTaken int     `json:"damageTaken"`
+	ArrowsFired int     `json:"arrowsFired"`
+	ArrowsHit   int     `json:"arrowsHit"`
+	Kills       int     `json:"kills"`
+	Gold        int     `json:"gold"`
+	Score       int     `json:"score"`
+	Rank        string  `json:"rank"`
+}
+
+// HistoryPath returns the history file stored next to the save file
+func HistoryPath(savePath string) string {
+	return filepath.Join(filepath.Dir(savePath), "history.jsonl")
+}
+
+// AppendHistory appends an entry as one JSON line
+func AppendHistory(path string, e HistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history dir: %w", err)
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
+
+// LoadHistory reads all entries, oldest first. A missing file is empty.
+func LoadHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return entries, fmt.Errorf("failed to parse history line %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,30 @@
+package save
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("cfg", "mg", "history.jsonl"), HistoryPath(filepath.Join("cfg", "mg", "save.json")))
+}
+
+func TestAppendAndLoadHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mg", "history.jsonl")
+
+	entries, err := LoadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "missing file is empty")
+
+	first := HistoryEntry{Time: 1, Stage: "demo", Seconds: 42.5, Kills: 3, Rank: "B"}
+	second := HistoryEntry{Time: 2, Stage: "demo", Seconds: 30, Kills: 7, Rank: "A"}
+	require.NoError(t, AppendHistory(path, first))
+	require.NoError(t, AppendHistory(path, second))
+
+	entries, err = LoadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []HistoryEntry{first, second}, entries)
+}
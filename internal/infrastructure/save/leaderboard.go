@@ -0,0 +1,148 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LeaderboardSize is the number of entries kept per stage
+const LeaderboardSize = 10
+
+// InitialsLength is the number of letters in a leaderboard name
+const InitialsLength = 3
+
+// ScoreEntry is one leaderboard row
+type ScoreEntry struct {
+	Initials string  `json:"initials"`
+	Score    int     `json:"score"`
+	Seconds  float64 `json:"seconds"`
+	Cleared  bool    `json:"cleared"`
+	Time     int64   `json:"time"` // unix seconds
+}
+
+// Leaderboard holds the local top scores per stage ID
+type Leaderboard struct {
+	Version int                     `json:"version"`
+	Stages  map[string][]ScoreEntry `json:"stages"`
+}
+
+// NewLeaderboard returns an empty leaderboard
+func NewLeaderboard() *Leaderboard {
+	return &Leaderboard{
+		Version: Version,
+		Stages:  map[string][]ScoreEntry{},
+	}
+}
+
+// LeaderboardPath returns the leaderboard file stored next to the save file
+func LeaderboardPath(savePath string) string {
+	return filepath.Join(filepath.Dir(savePath), "leaderboard.json")
+}
+
+// LoadLeaderboard reads a leaderboard file. A missing file is empty.
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewLeaderboard(), nil
+	}
+	if err != nil {
+		return NewLeaderboard(), fmt.Errorf("failed to read leaderboard: %w", err)
+	}
+
+	lb := NewLeaderboard()
+	if err := json.Unmarshal(data, lb); err != nil {
+		return NewLeaderboard(), fmt.Errorf("failed to parse leaderboard: %w", err)
+	}
+	if lb.Stages == nil {
+		lb.Stages = map[string][]ScoreEntry{}
+	}
+	for stage, entries := range lb.Stages {
+		sortEntries(entries)
+		if len(entries) > LeaderboardSize {
+			lb.Stages[stage] = entries[:LeaderboardSize]
+		}
+	}
+
+	return lb, nil
+}
+
+// Save writes the leaderboard file, creating the directory if needed
+func (lb *Leaderboard) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create leaderboard dir: %w", err)
+	}
+
+	lb.Version = Version
+	data, err := json.MarshalIndent(lb, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode leaderboard: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write leaderboard: %w", err)
+	}
+
+	return nil
+}
+
+// Top returns the ranked entries of a stage (best first)
+func (lb *Leaderboard) Top(stage string) []ScoreEntry {
+	return lb.Stages[stage]
+}
+
+// Qualifies reports whether a run would enter the stage's top list
+func (lb *Leaderboard) Qualifies(stage string, score int, seconds float64) bool {
+	entries := lb.Stages[stage]
+	if len(entries) < LeaderboardSize {
+		return true
+	}
+	return better(ScoreEntry{Score: score, Seconds: seconds}, entries[len(entries)-1])
+}
+
+// Insert adds an entry and returns its 0-based rank,
+// or -1 if it did not make the list.
+// Initials are upper-cased and cut to InitialsLength.
+func (lb *Leaderboard) Insert(stage string, e ScoreEntry) int {
+	e.Initials = normalizeInitials(e.Initials)
+	if !lb.Qualifies(stage, e.Score, e.Seconds) {
+		return -1
+	}
+
+	entries := lb.Stages[stage]
+	rank := sort.Search(len(entries), func(i int) bool { return better(e, entries[i]) })
+	entries = append(entries, ScoreEntry{})
+	copy(entries[rank+1:], entries[rank:])
+	entries[rank] = e
+	if len(entries) > LeaderboardSize {
+		entries = entries[:LeaderboardSize]
+	}
+	lb.Stages[stage] = entries
+
+	return rank
+}
+
+// better orders entries by score (high first), then time (fast first).
+// Ties keep the older entry ahead.
+func better(a, b ScoreEntry) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Seconds < b.Seconds
+}
+
+func sortEntries(entries []ScoreEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return better(entries[i], entries[j]) })
+}
+
+func normalizeInitials(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if r := []rune(s); len(r) > InitialsLength {
+		s = string(r[:InitialsLength])
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package save
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboard_InsertOrdersByScoreThenTime(t *testing.T) {
+	lb := NewLeaderboard()
+
+	assert.Equal(t, 0, lb.Insert("demo", ScoreEntry{Initials: "aaa", Score: 100, Seconds: 50}))
+	assert.Equal(t, 0, lb.Insert("demo", ScoreEntry{Initials: "BBB", Score: 200, Seconds: 80}))
+	assert.Equal(t, 1, lb.Insert("demo", ScoreEntry{Initials: "CCC", Score: 100, Seconds: 40}), "faster time wins a tie")
+	assert.Equal(t, 3, lb.Insert("demo", ScoreEntry{Initials: "DDD", Score: 100, Seconds: 50}), "older entry keeps an exact tie")
+
+	top := lb.Top("demo")
+	require.Len(t, top, 4)
+	assert.Equal(t, []string{"BBB", "CCC", "AAA", "DDD"}, []string{top[0].Initials, top[1].Initials, top[2].Initials, top[3].Initials})
+	assert.Empty(t, lb.Top("other"), "stages are separate")
+}
+
+func TestLeaderboard_KeepsTopTen(t *testing.T) {
+	lb := NewLeaderboard()
+	for i := 1; i <= LeaderboardSize; i++ {
+		lb.Insert("demo", ScoreEntry{Initials: "AAA", Score: i * 10})
+	}
+
+	assert.False(t, lb.Qualifies("demo", 5, 0))
+	assert.Equal(t, -1, lb.Insert("demo", ScoreEntry{Initials: "LOW", Score: 5}))
+	assert.True(t, lb.Qualifies("demo", 15, 0))
+	assert.Equal(t, 9, lb.Insert("demo", ScoreEntry{Initials: "MID", Score: 15}))
+
+	top := lb.Top("demo")
+	require.Len(t, top, LeaderboardSize)
+	assert.Equal(t, 100, top[0].Score)
+	assert.Equal(t, "MID", top[LeaderboardSize-1].Initials, "lowest entry was dropped")
+}
+
+func TestLeaderboard_InitialsNormalized(t *testing.T) {
+	lb := NewLeaderboard()
+	lb.Insert("demo", ScoreEntry{Initials: " abcd ", Score: 1})
+	assert.Equal(t, "ABC", lb.Top("demo")[0].Initials)
+}
+
+func TestLeaderboard_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mg", "leaderboard.json")
+
+	lb, err := LoadLeaderboard(path)
+	require.NoError(t, err)
+	assert.Empty(t, lb.Stages, "missing file is empty")
+
+	lb.Insert("demo", ScoreEntry{Initials: "ABC", Score: 42, Seconds: 12.5, Cleared: true, Time: 7})
+	require.NoError(t, lb.Save(path))
+
+	loaded, err := LoadLeaderboard(path)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Stages, loaded.Stages)
+	assert.Equal(t, filepath.Join("x", "leaderboard.json"), LeaderboardPath(filepath.Join("x", "save.json")))
+}
@@ -0,0 +1,139 @@
+// Package save persists player progress (the profile save file).
+//
+// The save lives next to the settings file in the user config directory.
+// Like settings, a missing file yields an empty profile so a first run
+// needs no special casing.
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Version is the current save format version
+const Version = 1
+
+// Data is the persisted profile
+type Data struct {
+	Version      int              `json:"version"`
+	ContentHash  string           `json:"contentHash,omitempty"` // game content the save was last written with
+	Achievements AchievementsData `json:"achievements"`
+	Stages       StagesData       `json:"stages"`
+}
+
+// AchievementsData holds lifetime counters and unlock times
+type AchievementsData struct {
+	Kills    int              `json:"kills"`
+	Gold     int              `json:"gold"`
+	Unlocked map[string]int64 `json:"unlocked"` // achievement ID → unix time
+}
+
+// StagesData holds the stages the player has cleared
+type StagesData struct {
+	Cleared map[string]int64 `json:"cleared"` // stage ID → unix time of the first clear
+}
+
+// New returns an empty profile
+func New() *Data {
+	return &Data{
+		Version: Version,
+		Achievements: AchievementsData{
+			Unlocked: map[string]int64{},
+		},
+		Stages: StagesData{
+			Cleared: map[string]int64{},
+		},
+	}
+}
+
+// ClearStage records that a stage was cleared at unix time now and reports
+// whether it was the first clear
+func (d *Data) ClearStage(id string, now int64) (first bool) {
+	if d.StageCleared(id) {
+		return false
+	}
+	d.Stages.Cleared[id] = now
+	return true
+}
+
+// StageCleared reports whether a stage has ever been cleared
+func (d *Data) StageCleared(id string) bool {
+	_, ok := d.Stages.Cleared[id]
+	return ok
+}
+
+// CheckContent records the current game content hash (config values,
+// stage layouts, build) and reports whether the save was last written with
+// different content. The progress still loads; the caller warns.
+func (d *Data) CheckContent(hash string) (changed bool) {
+	changed = d.ContentHash != "" && d.ContentHash != hash
+	d.ContentHash = hash
+	return changed
+}
+
+// DefaultPath returns the save file path in the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config dir: %w", err)
+	}
+	return filepath.Join(dir, "mg", "save.json"), nil
+}
+
+// Load reads a save file. A missing file returns an empty profile.
+func Load(path string) (*Data, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return New(), fmt.Errorf("failed to read save: %w", err)
+	}
+
+	d := New()
+	if err := json.Unmarshal(data, d); err != nil {
+		return New(), fmt.Errorf("failed to parse save: %w", err)
+	}
+	if d.Achievements.Unlocked == nil {
+		d.Achievements.Unlocked = map[string]int64{}
+	}
+	if d.Stages.Cleared == nil {
+		d.Stages.Cleared = map[string]int64{}
+	}
+
+	return d, nil
+}
+
+// Save writes the save file, creating the directory if needed.
+// The file is written to a temp file first so a crash never leaves a
+// truncated save behind.
+func (d *Data) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create save dir: %w", err)
+	}
+
+	d.Version = Version
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save: %w", err)
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write save: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
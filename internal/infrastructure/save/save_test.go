@@ -0,0 +1,73 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFileReturnsEmptyProfile(t *testing.T) {
+	d, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, New(), d)
+}
+
+func TestSaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "save.json")
+
+	d := New()
+	d.Achievements.Kills = 42
+	d.Achievements.Unlocked["first_blood"] = 1700000000
+	d.Stages.Cleared["demo"] = 1700000100
+	require.NoError(t, d.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, d, loaded)
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "temp file is renamed away")
+}
+
+func TestLoad_NullUnlockedMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "achievements": {"kills": 3, "unlocked": null}}`), 0o644))
+
+	d, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, d.Achievements.Kills)
+	assert.NotNil(t, d.Achievements.Unlocked)
+	assert.NotNil(t, d.Stages.Cleared, "saves from before stage progress")
+}
+
+func TestClearStage(t *testing.T) {
+	d := New()
+	assert.False(t, d.StageCleared("demo"))
+
+	assert.True(t, d.ClearStage("demo", 100))
+	assert.True(t, d.StageCleared("demo"))
+	assert.False(t, d.ClearStage("demo", 200), "cleared again")
+	assert.Equal(t, int64(100), d.Stages.Cleared["demo"], "keeps the first clear")
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0o644))
+
+	d, err := Load(path)
+	assert.Error(t, err)
+	assert.Equal(t, New(), d)
+}
+
+func TestCheckContent(t *testing.T) {
+	d := New()
+	assert.False(t, d.CheckContent("aaa"), "a new save has nothing to compare")
+	assert.Equal(t, "aaa", d.ContentHash)
+	assert.False(t, d.CheckContent("aaa"))
+	assert.True(t, d.CheckContent("bbb"), "written with other content")
+	assert.Equal(t, "bbb", d.ContentHash)
+}
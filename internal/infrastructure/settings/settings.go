@@ -0,0 +1,171 @@
+// Package settings loads and saves user options (video, audio, gameplay, input).
+//
+// Settings are stored as JSON in the user config directory, separate from the
+// embedded game configs. Zero values mean "use the game config default", so a
+// missing or partial settings file never overrides tuned values by accident.
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Input actions that can be rebound
+const (
+	ActionLeft  = "left"
+	ActionRight = "right"
+	ActionUp    = "up"
+	ActionDown  = "down"
+	ActionJump  = "jump"
+	ActionDash  = "dash"
+
+	ActionInteract = "interact"
+	ActionBlock    = "block"
+	ActionSlow     = "slow" // toggles the reduced game speed
+	ActionSummon   = "summon"
+)
+
+// Actions lists the rebindable actions in menu order
+var Actions = []string{ActionLeft, ActionRight, ActionUp, ActionDown, ActionJump, ActionDash, ActionInteract, ActionBlock, ActionSlow, ActionSummon}
+
+// Settings holds user-adjustable options
+type Settings struct {
+	// Video
+	WindowScale int  `json:"windowScale"` // 0 = config display.scale
+	Fullscreen  bool `json:"fullscreen"`
+	VSync       bool `json:"vsync"`
+	TPS         int  `json:"tps"` // 0 = config display.framerate
+
+	// Audio (0-100)
+	MasterVolume int `json:"masterVolume"`
+	SFXVolume    int `json:"sfxVolume"`
+	MusicVolume  int `json:"musicVolume"`
+
+	// Gameplay
+	ScreenShakePct int    `json:"screenShakePct"` // 0-100
+	Rumble         bool   `json:"rumble"`
+	Language       string `json:"language"`   // "en", "ko", "ja"
+	Difficulty     string `json:"difficulty"` // difficulty profile name ("" = config default)
+	Character      string `json:"character"`  // character ID ("" = first configured character)
+	AimAssist      bool   `json:"aimAssist"`  // snap arrows to enemies the aim nearly passes through
+	CombatLog      bool   `json:"combatLog"`  // kill feed: the last hits, kills and pickups on screen
+
+	// Accessibility
+	Palette      string `json:"palette"`      // arrow and enemy state colors ("" = default, "redGreen", "blueYellow")
+	ArrowMarkers bool   `json:"arrowMarkers"` // shape markers that tell arrow types apart without color
+	HighContrast bool   `json:"highContrast"` // outline hazards and hitboxes
+	ReduceShake  bool   `json:"reduceShake"`  // cap screen shake well below the Screen Shake setting
+
+	// Accessibility: input (applied where keys become input, not in systems)
+	DashToggle        bool `json:"dashToggle"`        // the dash key toggles running: dash whenever ready while moving
+	JumpRepeatGuardMs int  `json:"jumpRepeatGuardMs"` // ignore jump presses this soon after the last one (0 = off)
+	HoldToleranceMs   int  `json:"holdToleranceMs"`   // directions and block stay held through releases this short (0 = off)
+	GameSpeedPct      int  `json:"gameSpeedPct"`      // simulation speed, toggled by the slow key (0 = 100)
+
+	// Input: action → key name (ebiten key names, e.g. "A", "Space")
+	KeyBindings map[string]string `json:"keyBindings"`
+}
+
+// Default returns the default settings
+func Default() *Settings {
+	return &Settings{
+		VSync:          true,
+		MasterVolume:   100,
+		SFXVolume:      80,
+		MusicVolume:    70,
+		ScreenShakePct: 100,
+		Rumble:         true,
+		Language:       "en",
+		KeyBindings:    DefaultKeyBindings(),
+	}
+}
+
+// DefaultKeyBindings returns the default action → key mapping
+func DefaultKeyBindings() map[string]string {
+	return map[string]string{
+		ActionLeft:  "A",
+		ActionRight: "D",
+		ActionUp:    "W",
+		ActionDown:  "S",
+		ActionJump:  "W",
+		ActionDash:  "Space",
+
+		ActionInteract: "E",
+		ActionBlock:    "F",
+		ActionSlow:     "G",
+		ActionSummon:   "Q",
+	}
+}
+
+// DefaultPath returns the settings file path in the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config dir: %w", err)
+	}
+	return filepath.Join(dir, "mg", "settings.json"), nil
+}
+
+// Load reads settings from a file. A missing file returns defaults.
+// Fields absent from the file keep their default values.
+func Load(path string) (*Settings, error) {
+	s := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return Default(), fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	// Fill bindings added after the file was written (or all of them when
+	// the file has "keyBindings": null)
+	if s.KeyBindings == nil {
+		s.KeyBindings = map[string]string{}
+	}
+	for action, key := range DefaultKeyBindings() {
+		if _, ok := s.KeyBindings[action]; !ok {
+			s.KeyBindings[action] = key
+		}
+	}
+
+	return s, nil
+}
+
+// Save writes settings to a file, creating the directory if needed
+func (s *Settings) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create settings dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	return nil
+}
+
+// ClampVolume keeps a volume in the 0-100 range
+func ClampVolume(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
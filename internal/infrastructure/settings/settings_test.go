@@ -0,0 +1,72 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFileReturnsDefaults(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Default(), s)
+}
+
+func TestSaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "settings.json")
+
+	s := Default()
+	s.WindowScale = 3
+	s.Fullscreen = true
+	s.MusicVolume = 20
+	s.KeyBindings[ActionDash] = "ShiftLeft"
+	require.NoError(t, s.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+}
+
+func TestLoad_PartialFileKeepsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tps": 120, "keyBindings": {"jump": "Space"}}`), 0o644))
+
+	s, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 120, s.TPS)
+	assert.Equal(t, 100, s.ScreenShakePct, "absent fields keep defaults")
+	assert.Equal(t, "Space", s.KeyBindings[ActionJump])
+	assert.Equal(t, "A", s.KeyBindings[ActionLeft], "absent bindings are filled in")
+	assert.Equal(t, "G", s.KeyBindings[ActionSlow])
+	assert.Equal(t, "Q", s.KeyBindings[ActionSummon])
+}
+
+func TestLoad_NullKeyBindingsGetDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keyBindings": null}`), 0o644))
+
+	s, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultKeyBindings(), s.KeyBindings)
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{not json`), 0o644))
+
+	s, err := Load(path)
+	assert.Error(t, err)
+	assert.Equal(t, Default(), s, "defaults are still usable on error")
+}
+
+func TestClampVolume(t *testing.T) {
+	assert.Equal(t, 0, ClampVolume(-10))
+	assert.Equal(t, 55, ClampVolume(55))
+	assert.Equal(t, 100, ClampVolume(130))
+}